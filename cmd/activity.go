@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"sort"
@@ -261,6 +262,8 @@ func parseTimeFlag(value string, now time.Time) (time.Time, error) {
 
 // runActivity implements `zh activity`.
 func runActivity(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+
 	cfg, err := requireWorkspace()
 	if err != nil {
 		return err
@@ -282,7 +285,7 @@ func runActivity(cmd *cobra.Command, args []string) error {
 	// Resolve repo filter
 	var repoFilter string
 	if activityRepo != "" {
-		repo, err := resolve.LookupRepoWithRefresh(client, cfg.Workspace, activityRepo)
+		repo, err := resolve.LookupRepoWithRefresh(ctx, client, cfg.Workspace, activityRepo)
 		if err != nil {
 			return err
 		}
@@ -293,13 +296,13 @@ func runActivity(cmd *cobra.Command, args []string) error {
 	var pipelineIDs []struct{ ID, Name string }
 
 	if activityPipeline != "" {
-		resolved, err := resolve.Pipeline(client, cfg.Workspace, activityPipeline, cfg.Aliases.Pipelines)
+		resolved, err := resolve.Pipeline(ctx, client, cfg.Workspace, activityPipeline, cfg.Aliases.Pipelines)
 		if err != nil {
 			return err
 		}
 		pipelineIDs = []struct{ ID, Name string }{{resolved.ID, resolved.Name}}
 	} else {
-		pipelines, err := fetchPipelineIDsForList(client, cfg.Workspace)
+		pipelines, err := fetchPipelineIDsForList(ctx, client, cfg.Workspace)
 		if err != nil {
 			return err
 		}
@@ -308,6 +311,32 @@ func runActivity(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	w := cmd.OutOrStdout()
+
+	// With --output=ndjson (and no --detail, which needs every issue before
+	// fetching timelines), issues are written as each page is scanned
+	// instead of being sorted by update time first.
+	stream := output.IsNDJSON(outputFormat) && !activityDetail
+
+	issueMap := make(map[string]*activityIssue) // dedup by ID
+	collect := func(found []activityIssue) error {
+		var fresh []activityIssue
+		for i := range found {
+			issue := &found[i]
+			if _, exists := issueMap[issue.ID]; exists {
+				continue
+			}
+			issueMap[issue.ID] = issue
+			if stream && (repoFilter == "" || strings.EqualFold(issue.RepoName, repoFilter)) {
+				fresh = append(fresh, *issue)
+			}
+		}
+		if len(fresh) == 0 {
+			return nil
+		}
+		return output.NDJSON(w, fresh)
+	}
+
 	// Scan each pipeline in parallel
 	type pipelineResult struct {
 		issues []activityIssue
@@ -315,41 +344,44 @@ func runActivity(cmd *cobra.Command, args []string) error {
 	}
 	results := make([]pipelineResult, len(pipelineIDs))
 	var wg sync.WaitGroup
+	var mu sync.Mutex
 
 	for i, p := range pipelineIDs {
 		wg.Add(1)
 		go func(idx int, pipelineID, pipelineName string) {
 			defer wg.Done()
-			issues, err := scanPipelineActivity(client, cfg.Workspace, pipelineID, pipelineName, fromTime, toTime)
+			var issues []activityIssue
+			err := forEachPipelineActivityPage(ctx, client, cfg.Workspace, pipelineID, pipelineName, fromTime, toTime, func(page []activityIssue) error {
+				if stream {
+					mu.Lock()
+					defer mu.Unlock()
+					return collect(page)
+				}
+				issues = append(issues, page...)
+				return nil
+			})
 			results[idx] = pipelineResult{issues: issues, err: err}
 		}(i, p.ID, p.Name)
 	}
 	wg.Wait()
 
-	// Collect results
-	issueMap := make(map[string]*activityIssue) // dedup by ID
+	// Collect results in pipeline order
 	for _, r := range results {
 		if r.err != nil {
 			return r.err
 		}
-		for i := range r.issues {
-			issue := &r.issues[i]
-			if _, exists := issueMap[issue.ID]; !exists {
-				issueMap[issue.ID] = issue
-			}
+		if err := collect(r.issues); err != nil {
+			return err
 		}
 	}
 
 	// Scan closed issues
-	closedIssues, err := scanClosedActivity(client, cfg.Workspace, fromTime, toTime)
+	closedIssues, err := scanClosedActivity(ctx, client, cfg.Workspace, fromTime, toTime)
 	if err != nil {
 		return err
 	}
-	for i := range closedIssues {
-		issue := &closedIssues[i]
-		if _, exists := issueMap[issue.ID]; !exists {
-			issueMap[issue.ID] = issue
-		}
+	if err := collect(closedIssues); err != nil {
+		return err
 	}
 
 	// Step 2: GitHub search (optional)
@@ -357,7 +389,7 @@ func runActivity(cmd *cobra.Command, args []string) error {
 		if ghClient == nil {
 			fmt.Fprintln(cmd.ErrOrStderr(), output.Yellow("Warning: --github flag ignored — GitHub access not configured"))
 		} else {
-			ghIssues, repos, err := searchGitHubActivity(client, ghClient, cfg.Workspace, fromTime)
+			ghIssues, repos, err := searchGitHubActivity(ctx, client, ghClient, cfg.Workspace, fromTime)
 			if err != nil {
 				fmt.Fprintf(cmd.ErrOrStderr(), "%s\n", output.Yellow("Warning: GitHub search failed: "+err.Error()))
 			} else {
@@ -379,12 +411,28 @@ func runActivity(cmd *cobra.Command, args []string) error {
 
 				// Resolve pipelines for GitHub-sourced items
 				if len(ghOnly) > 0 {
-					resolveGitHubIssuePipelines(client, cfg.Workspace, ghOnly, repos, issueMap)
+					resolveGitHubIssuePipelines(ctx, client, cfg.Workspace, ghOnly, repos, issueMap)
+				}
+
+				if stream {
+					var fresh []activityIssue
+					for _, issue := range ghOnly {
+						if repoFilter == "" || strings.EqualFold(issue.RepoName, repoFilter) {
+							fresh = append(fresh, *issue)
+						}
+					}
+					if err := output.NDJSON(w, fresh); err != nil {
+						return err
+					}
 				}
 			}
 		}
 	}
 
+	if stream {
+		return nil
+	}
+
 	// Collect and filter
 	var issues []activityIssue
 	for _, issue := range issueMap {
@@ -401,14 +449,21 @@ func runActivity(cmd *cobra.Command, args []string) error {
 
 	// Step 3: Detail mode — fetch per-issue timelines
 	if activityDetail && len(issues) > 0 {
-		fetchActivityTimelines(client, ghClient, activityGitHub, &issues, fromTime, toTime)
-		fetchMissingParentIssues(client, ghClient, activityGitHub, &issues, fromTime, toTime)
+		fetchActivityTimelines(ctx, client, ghClient, activityGitHub, &issues, fromTime, toTime)
+		fetchMissingParentIssues(ctx, client, ghClient, activityGitHub, &issues, fromTime, toTime)
 		nestConnectedPRs(&issues)
-	}
 
-	w := cmd.OutOrStdout()
+		// Timeline fetch errors are tolerated per issue, so check for
+		// cancellation explicitly rather than render partial results.
+		if err := commandContext(cmd).Err(); err != nil {
+			return err
+		}
+	}
 
 	// JSON output
+	if output.IsNDJSON(outputFormat) {
+		return output.NDJSON(w, issues)
+	}
 	if output.IsJSON(outputFormat) {
 		pipelineCount := countPipelines(issues)
 		return output.JSON(w, map[string]any{
@@ -443,10 +498,12 @@ func runActivity(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-// scanPipelineActivity scans a single pipeline for issues updated within the time range.
-// Uses early termination: stops paginating when updatedAt falls before fromTime.
-func scanPipelineActivity(client *api.Client, workspaceID, pipelineID, pipelineName string, fromTime, toTime time.Time) ([]activityIssue, error) {
-	var issues []activityIssue
+// forEachPipelineActivityPage scans a single pipeline for issues updated
+// within the time range, calling fn with the in-range issues from each page
+// as it arrives. Uses early termination: stops paginating when updatedAt
+// falls before fromTime, or as soon as fn returns an error, which is passed
+// through.
+func forEachPipelineActivityPage(ctx context.Context, client *api.Client, workspaceID, pipelineID, pipelineName string, fromTime, toTime time.Time, fn func(issues []activityIssue) error) error {
 	var cursor *string
 	pageSize := 100
 
@@ -460,9 +517,9 @@ func scanPipelineActivity(client *api.Client, workspaceID, pipelineID, pipelineN
 			vars["after"] = *cursor
 		}
 
-		data, err := client.Execute(activitySearchQuery, vars)
+		data, err := client.Execute(ctx, activitySearchQuery, vars)
 		if err != nil {
-			return nil, exitcode.General("scanning pipeline activity", err)
+			return exitcode.General("scanning pipeline activity", err)
 		}
 
 		var resp struct {
@@ -498,9 +555,10 @@ func scanPipelineActivity(client *api.Client, workspaceID, pipelineID, pipelineN
 			} `json:"searchIssuesByPipeline"`
 		}
 		if err := json.Unmarshal(data, &resp); err != nil {
-			return nil, exitcode.General("parsing pipeline activity", err)
+			return exitcode.General("parsing pipeline activity", err)
 		}
 
+		var issues []activityIssue
 		pastCutoff := false
 		for _, node := range resp.SearchIssuesByPipeline.Nodes {
 			updatedAt, _ := time.Parse(time.RFC3339, node.UpdatedAt)
@@ -559,6 +617,10 @@ func scanPipelineActivity(client *api.Client, workspaceID, pipelineID, pipelineN
 			})
 		}
 
+		if err := fn(issues); err != nil {
+			return err
+		}
+
 		// Stop if we've gone past the cutoff or no more pages
 		if pastCutoff || !resp.SearchIssuesByPipeline.PageInfo.HasNextPage {
 			break
@@ -566,17 +628,17 @@ func scanPipelineActivity(client *api.Client, workspaceID, pipelineID, pipelineN
 		cursor = &resp.SearchIssuesByPipeline.PageInfo.EndCursor
 	}
 
-	return issues, nil
+	return nil
 }
 
 // scanClosedActivity fetches recently closed issues and filters by time range.
-func scanClosedActivity(client *api.Client, workspaceID string, fromTime, toTime time.Time) ([]activityIssue, error) {
+func scanClosedActivity(ctx context.Context, client *api.Client, workspaceID string, fromTime, toTime time.Time) ([]activityIssue, error) {
 	vars := map[string]any{
 		"workspaceId": workspaceID,
 		"first":       100,
 	}
 
-	data, err := client.Execute(activityClosedQuery, vars)
+	data, err := client.Execute(ctx, activityClosedQuery, vars)
 	if err != nil {
 		return nil, exitcode.General("scanning closed issues", err)
 	}
@@ -653,9 +715,9 @@ func scanClosedActivity(client *api.Client, workspaceID string, fromTime, toTime
 
 // searchGitHubActivity searches GitHub for recently updated issues/PRs
 // across all workspace repos. Returns the discovered issues and the repo list.
-func searchGitHubActivity(client *api.Client, ghClient *gh.Client, workspaceID string, fromTime time.Time) ([]activityIssue, []resolve.CachedRepo, error) {
+func searchGitHubActivity(ctx context.Context, client *api.Client, ghClient *gh.Client, workspaceID string, fromTime time.Time) ([]activityIssue, []resolve.CachedRepo, error) {
 	// Get workspace repos
-	repos, err := fetchWorkspaceReposForActivity(client, workspaceID)
+	repos, err := fetchWorkspaceReposForActivity(ctx, client, workspaceID)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -679,7 +741,7 @@ func searchGitHubActivity(client *api.Client, ghClient *gh.Client, workspaceID s
 	batchLen := 0
 	for _, part := range repoParts {
 		if batchLen+len(part)+1 > 200 && len(batch) > 0 {
-			issues, err := runGitHubSearchBatch(ghClient, batch, dateStr)
+			issues, err := runGitHubSearchBatch(ctx, ghClient, batch, dateStr)
 			if err != nil {
 				return nil, repos, err
 			}
@@ -691,7 +753,7 @@ func searchGitHubActivity(client *api.Client, ghClient *gh.Client, workspaceID s
 		batchLen += len(part) + 1
 	}
 	if len(batch) > 0 {
-		issues, err := runGitHubSearchBatch(ghClient, batch, dateStr)
+		issues, err := runGitHubSearchBatch(ctx, ghClient, batch, dateStr)
 		if err != nil {
 			return nil, repos, err
 		}
@@ -701,7 +763,7 @@ func searchGitHubActivity(client *api.Client, ghClient *gh.Client, workspaceID s
 	return allIssues, repos, nil
 }
 
-func runGitHubSearchBatch(ghClient *gh.Client, repoParts []string, dateStr string) ([]activityIssue, error) {
+func runGitHubSearchBatch(ctx context.Context, ghClient *gh.Client, repoParts []string, dateStr string) ([]activityIssue, error) {
 	repoClause := strings.Join(repoParts, " ")
 
 	// GitHub's search API requires an explicit is:issue or is:pr qualifier
@@ -721,7 +783,7 @@ func runGitHubSearchBatch(ghClient *gh.Client, repoParts []string, dateStr strin
 				vars["after"] = *cursor
 			}
 
-			data, err := ghClient.Execute(activityGitHubSearchQuery, vars)
+			data, err := ghClient.Execute(ctx, activityGitHubSearchQuery, vars)
 			if err != nil {
 				return nil, err
 			}
@@ -779,12 +841,12 @@ func runGitHubSearchBatch(ghClient *gh.Client, repoParts []string, dateStr strin
 
 // fetchWorkspaceReposForActivity gets workspace repos from cache,
 // falling back to the API if the cache is empty.
-func fetchWorkspaceReposForActivity(client *api.Client, workspaceID string) ([]resolve.CachedRepo, error) {
+func fetchWorkspaceReposForActivity(ctx context.Context, client *api.Client, workspaceID string) ([]resolve.CachedRepo, error) {
 	key := resolve.RepoCacheKey(workspaceID)
 	repos, ok := cache.Get[[]resolve.CachedRepo](key)
 	if !ok {
 		var err error
-		repos, err = resolve.FetchRepos(client, workspaceID)
+		repos, err = resolve.FetchRepos(ctx, client, workspaceID)
 		if err != nil {
 			return nil, err
 		}
@@ -818,7 +880,7 @@ const activityDefaultPRPipelineQuery = `query ActivityDefaultPRPipeline($workspa
 // For each item it queries ZenHub's issueByInfo to get the real node ID and pipeline.
 // Items where pipelineIssue is null (e.g. PRs never moved) get the workspace's default
 // PR pipeline name. The issueMap is updated so the old synthetic ID is replaced.
-func resolveGitHubIssuePipelines(client *api.Client, workspaceID string, items []*activityIssue, repos []resolve.CachedRepo, issueMap map[string]*activityIssue) {
+func resolveGitHubIssuePipelines(ctx context.Context, client *api.Client, workspaceID string, items []*activityIssue, repos []resolve.CachedRepo, issueMap map[string]*activityIssue) {
 	// Build repo GhID lookup by owner/name
 	repoGhIDs := make(map[string]int) // "owner/name" -> GhID
 	for _, r := range repos {
@@ -832,7 +894,7 @@ func resolveGitHubIssuePipelines(client *api.Client, workspaceID string, items [
 
 	fetchDefaultPRPipeline := func() string {
 		defaultPROnce.Do(func() {
-			data, err := client.Execute(activityDefaultPRPipelineQuery, map[string]any{
+			data, err := client.Execute(ctx, activityDefaultPRPipelineQuery, map[string]any{
 				"workspaceId": workspaceID,
 			})
 			if err != nil {
@@ -878,7 +940,7 @@ func resolveGitHubIssuePipelines(client *api.Client, workspaceID string, items [
 			sem <- struct{}{}
 			defer func() { <-sem }()
 
-			data, err := client.Execute(activityIssueByInfoQuery, map[string]any{
+			data, err := client.Execute(ctx, activityIssueByInfoQuery, map[string]any{
 				"repositoryGhId": repoGhID,
 				"issueNumber":    issue.Number,
 				"workspaceId":    workspaceID,
@@ -924,7 +986,7 @@ func resolveGitHubIssuePipelines(client *api.Client, workspaceID string, items [
 }
 
 // fetchActivityTimelines fetches per-issue event timelines with bounded concurrency.
-func fetchActivityTimelines(client *api.Client, ghClient *gh.Client, includeGitHub bool, issues *[]activityIssue, fromTime, toTime time.Time) {
+func fetchActivityTimelines(ctx context.Context, client *api.Client, ghClient *gh.Client, includeGitHub bool, issues *[]activityIssue, fromTime, toTime time.Time) {
 	const concurrency = 5
 	sem := make(chan struct{}, concurrency)
 	var mu sync.Mutex
@@ -943,7 +1005,7 @@ func fetchActivityTimelines(client *api.Client, ghClient *gh.Client, includeGitH
 
 			// Fetch ZenHub timeline (skip for GitHub-sourced items with synthetic IDs)
 			if !ghSourced {
-				_, zhEvents, err := fetchZenHubTimelineByNode(client, issue.ID)
+				_, zhEvents, err := fetchZenHubTimelineByNode(ctx, client, issue.ID)
 				if err == nil {
 					for _, ev := range zhEvents {
 						if !ev.Time.Before(fromTime) && !ev.Time.After(toTime) {
@@ -959,7 +1021,7 @@ func fetchActivityTimelines(client *api.Client, ghClient *gh.Client, includeGitH
 			var createdAt time.Time
 			var createdBy string
 			if (includeGitHub || ghSourced) && ghClient != nil && issue.RepoOwner != "" {
-				ghResult, err := fetchGitHubTimeline(ghClient, issue.RepoOwner, issue.RepoName, issue.Number)
+				ghResult, err := fetchGitHubTimeline(ctx, ghClient, issue.RepoOwner, issue.RepoName, issue.Number)
 				if err == nil {
 					isPR = ghResult.IsPR
 					headBranch = ghResult.HeadBranch
@@ -976,13 +1038,13 @@ func fetchActivityTimelines(client *api.Client, ghClient *gh.Client, includeGitH
 			// For PRs, fetch connected issues via ZenHub connections field
 			var connInfo connectedIssueInfo
 			if (isPR || issue.IsPR) && !ghSourced {
-				connInfo = fetchPRConnection(client, issue.ID)
+				connInfo = fetchPRConnection(ctx, client, issue.ID)
 			}
 
 			// For non-PR issues, fetch connected PRs
 			var prRefs []connectedPRRef
 			if !isPR && !issue.IsPR && !ghSourced {
-				prRefs = fetchIssueConnectedPRs(client, issue.ID)
+				prRefs = fetchIssueConnectedPRs(ctx, client, issue.ID)
 			}
 
 			// Synthesize "created" event if creation is within the time range
@@ -1023,7 +1085,7 @@ func fetchActivityTimelines(client *api.Client, ghClient *gh.Client, includeGitH
 // by PRs in the activity results but aren't themselves in the results (their updatedAt
 // wasn't recent enough to appear in the pipeline scan). This resolves duplicate
 // placeholder parents, missing timelines, and missing sibling PRs.
-func fetchMissingParentIssues(client *api.Client, ghClient *gh.Client, includeGitHub bool, issues *[]activityIssue, fromTime, toTime time.Time) {
+func fetchMissingParentIssues(ctx context.Context, client *api.Client, ghClient *gh.Client, includeGitHub bool, issues *[]activityIssue, fromTime, toTime time.Time) {
 	// Build ref set of current issues
 	refSet := make(map[string]bool, len(*issues))
 	for _, item := range *issues {
@@ -1086,7 +1148,7 @@ func fetchMissingParentIssues(client *api.Client, ghClient *gh.Client, includeGi
 			var events []activityEvent
 
 			// Fetch ZenHub timeline
-			_, zhEvents, err := fetchZenHubTimelineByNode(client, pi.info.ID)
+			_, zhEvents, err := fetchZenHubTimelineByNode(ctx, client, pi.info.ID)
 			if err == nil {
 				for _, ev := range zhEvents {
 					if !ev.Time.Before(fromTime) && !ev.Time.After(toTime) {
@@ -1097,7 +1159,7 @@ func fetchMissingParentIssues(client *api.Client, ghClient *gh.Client, includeGi
 
 			// Fetch GitHub timeline if requested
 			if includeGitHub && ghClient != nil && pi.info.RepoOwner != "" {
-				ghResult, err := fetchGitHubTimeline(ghClient, pi.info.RepoOwner, pi.info.RepoName, pi.info.Number)
+				ghResult, err := fetchGitHubTimeline(ctx, ghClient, pi.info.RepoOwner, pi.info.RepoName, pi.info.Number)
 				if err == nil {
 					for _, ev := range ghResult.Events {
 						if !ev.Time.Before(fromTime) && !ev.Time.After(toTime) {
@@ -1114,7 +1176,7 @@ func fetchMissingParentIssues(client *api.Client, ghClient *gh.Client, includeGi
 			parent.Events = events
 
 			// Fetch connected PRs
-			parent.ConnectedPRRefs = fetchIssueConnectedPRs(client, pi.info.ID)
+			parent.ConnectedPRRefs = fetchIssueConnectedPRs(ctx, client, pi.info.ID)
 
 			mu.Lock()
 			fetched = append(fetched, parent)
@@ -1279,8 +1341,8 @@ type connectedIssueInfo struct {
 }
 
 // fetchPRConnection fetches the connected issue for a PR via ZenHub's connections field.
-func fetchPRConnection(client *api.Client, nodeID string) connectedIssueInfo {
-	data, err := client.Execute(prConnectionQuery, map[string]any{"id": nodeID})
+func fetchPRConnection(ctx context.Context, client *api.Client, nodeID string) connectedIssueInfo {
+	data, err := client.Execute(ctx, prConnectionQuery, map[string]any{"id": nodeID})
 	if err != nil {
 		return connectedIssueInfo{}
 	}
@@ -1334,8 +1396,8 @@ const issueConnectedPRsQuery = `query IssueConnectedPRs($id: ID!) {
 }`
 
 // fetchIssueConnectedPRs fetches PR references connected to a non-PR issue.
-func fetchIssueConnectedPRs(client *api.Client, nodeID string) []connectedPRRef {
-	data, err := client.Execute(issueConnectedPRsQuery, map[string]any{"id": nodeID})
+func fetchIssueConnectedPRs(ctx context.Context, client *api.Client, nodeID string) []connectedPRRef {
+	data, err := client.Execute(ctx, issueConnectedPRsQuery, map[string]any{"id": nodeID})
 	if err != nil {
 		return nil
 	}
@@ -1434,7 +1496,7 @@ func renderActivitySummary(w interface{ Write([]byte) (int, error) }, issues []a
 			if len(issue.Assignees) > 0 {
 				assignee = "@" + strings.Join(issue.Assignees, ", @")
 			}
-			ago := formatTimeAgo(issue.UpdatedAt)
+			ago := output.FormatTimeAgo(issue.UpdatedAt)
 			prefix := formatActivityPrefix(issue)
 			line := fmt.Sprintf("  %s%s  %s", prefix, output.Cyan(fmt.Sprintf("%-24s", issue.Ref)), title)
 			if assignee != "" {
@@ -1526,33 +1588,6 @@ func renderActivityDetail(w interface{ Write([]byte) (int, error) }, issues []ac
 	fmt.Fprintln(w, summary)
 }
 
-// formatTimeAgo formats a time as a human-readable relative duration.
-func formatTimeAgo(t time.Time) string {
-	d := time.Since(t)
-	switch {
-	case d < time.Minute:
-		return "just now"
-	case d < time.Hour:
-		m := int(d.Minutes())
-		if m == 1 {
-			return "1m ago"
-		}
-		return fmt.Sprintf("%dm ago", m)
-	case d < 24*time.Hour:
-		h := int(d.Hours())
-		if h == 1 {
-			return "1h ago"
-		}
-		return fmt.Sprintf("%dh ago", h)
-	default:
-		days := int(d.Hours() / 24)
-		if days == 1 {
-			return "1d ago"
-		}
-		return fmt.Sprintf("%dd ago", days)
-	}
-}
-
 func countPipelines(issues []activityIssue) int {
 	seen := make(map[string]bool)
 	for _, issue := range issues {
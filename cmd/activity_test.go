@@ -354,6 +354,42 @@ func TestActivityJSON(t *testing.T) {
 	}
 }
 
+func TestActivityNDJSON(t *testing.T) {
+	resetActivityFlags()
+
+	ms := setupActivityServer(t)
+	setupActivityTestEnv(t, ms)
+	defer resetOutputFlags()
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"activity", "--output=ndjson"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("activity --output=ndjson returned error: %v", err)
+	}
+
+	// Both pipelines return the same issues; duplicates are written once.
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 issue lines, got %d:\n%s", len(lines), buf.String())
+	}
+	refs := map[string]bool{}
+	for _, line := range lines {
+		var issue map[string]any
+		if err := json.Unmarshal([]byte(line), &issue); err != nil {
+			t.Fatalf("line is not a JSON object: %v\nLine: %s", err, line)
+		}
+		ref, _ := issue["ref"].(string)
+		refs[ref] = true
+	}
+	for _, want := range []string{"task-tracker#42", "task-tracker#38", "task-tracker#40"} {
+		if !refs[want] {
+			t.Errorf("expected %s in output, got:\n%s", want, buf.String())
+		}
+	}
+}
+
 func TestActivityEarlyTermination(t *testing.T) {
 	resetActivityFlags()
 
@@ -814,32 +850,6 @@ func TestActivityClosedIssues(t *testing.T) {
 	}
 }
 
-// --- formatTimeAgo ---
-
-func TestFormatTimeAgo(t *testing.T) {
-	now := time.Now()
-
-	tests := []struct {
-		t        time.Time
-		contains string
-	}{
-		{now.Add(-30 * time.Second), "just now"},
-		{now.Add(-5 * time.Minute), "5m ago"},
-		{now.Add(-1 * time.Minute), "1m ago"},
-		{now.Add(-3 * time.Hour), "3h ago"},
-		{now.Add(-1 * time.Hour), "1h ago"},
-		{now.Add(-2 * 24 * time.Hour), "2d ago"},
-		{now.Add(-1 * 24 * time.Hour), "1d ago"},
-	}
-
-	for _, tt := range tests {
-		result := formatTimeAgo(tt.t)
-		if result != tt.contains {
-			t.Errorf("formatTimeAgo(%v) = %q, want %q", time.Since(tt.t), result, tt.contains)
-		}
-	}
-}
-
 func TestActivityDetailNestedPR(t *testing.T) {
 	resetActivityFlags()
 
@@ -0,0 +1,352 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/dslh/zh/internal/api"
+	"github.com/dslh/zh/internal/config"
+	"github.com/dslh/zh/internal/exitcode"
+	"github.com/dslh/zh/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// Commands
+
+var apiCmd = &cobra.Command{
+	Use:   "api",
+	Short: "Make authenticated ZenHub API requests",
+	Long: `Make raw requests to the ZenHub GraphQL or REST v1 API using the configured
+credentials, endpoint, retry policy and --verbose logging.
+
+Use this for fields that zh doesn't expose yet.`,
+}
+
+var apiGraphqlCmd = &cobra.Command{
+	Use:   "graphql",
+	Short: "Send a GraphQL query or mutation",
+	Long: `Send a GraphQL document to the ZenHub API and print the response data as JSON.
+
+The document is passed as the "query" field; every other field becomes a
+variable:
+
+  -f key=value   add a string variable
+  -F key=value   add a typed variable: true, false, null, numbers and JSON
+                 objects/arrays are decoded; anything else is a string
+
+For both flags, a value of @file reads the value from a file, @- reads it
+from stdin, and @current is replaced with the current workspace ID.
+
+With --paginate, the query must accept an $endCursor: String variable and
+select pageInfo { hasNextPage endCursor } on the connection being paged.
+Each page is printed as a separate JSON document.
+
+Examples:
+  zh api graphql -f query='{ viewer { id } }'
+  zh api graphql -f query=@board.graphql -F workspaceId=@current
+  zh api graphql -f query=@issues.graphql -F workspaceId=@current --paginate`,
+	Args: cobra.NoArgs,
+	RunE: runAPIGraphql,
+}
+
+var apiRestCmd = &cobra.Command{
+	Use:   "rest <path>",
+	Short: "Send a request to the ZenHub REST v1 API",
+	Long: `Send a request to the ZenHub REST v1 API and print the response body.
+
+Requires a REST API token (rest_api_key in config.yml or ZH_REST_API_KEY).
+The method defaults to GET, or POST when fields are given. For GET requests
+fields are sent as query parameters; otherwise they form a JSON body.
+Fields use the same -f/-F syntax as 'zh api graphql'.
+
+Examples:
+  zh api rest /p1/repositories/123456/epics
+  zh api rest /p1/repositories/123456/epics/42/update_issues -F add_issues='[{"repo_id":123456,"issue_number":7}]'`,
+	Args: cobra.ExactArgs(1),
+	RunE: runAPIRest,
+}
+
+var (
+	apiRawFields []string
+	apiFields    []string
+	apiPaginate  bool
+	apiMethod    string
+)
+
+func init() {
+	for _, c := range []*cobra.Command{apiGraphqlCmd, apiRestCmd} {
+		c.Flags().StringArrayVarP(&apiRawFields, "raw-field", "f", nil, "Add a string field in key=value format")
+		c.Flags().StringArrayVarP(&apiFields, "field", "F", nil, "Add a typed field in key=value format")
+	}
+	apiGraphqlCmd.Flags().BoolVar(&apiPaginate, "paginate", false, "Fetch all pages by following pageInfo.endCursor")
+	apiRestCmd.Flags().StringVarP(&apiMethod, "method", "X", "", "HTTP method (default GET, or POST when fields are given)")
+
+	apiCmd.AddCommand(apiGraphqlCmd)
+	apiCmd.AddCommand(apiRestCmd)
+	rootCmd.AddCommand(apiCmd)
+}
+
+func resetAPIFlags() {
+	apiRawFields = nil
+	apiFields = nil
+	apiPaginate = false
+	apiMethod = ""
+}
+
+func runAPIGraphql(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+
+	cfg, err := requireConfig()
+	if err != nil {
+		return err
+	}
+
+	fields, err := parseAPIFields(cfg, cmd.InOrStdin())
+	if err != nil {
+		return err
+	}
+
+	query, ok := fields["query"].(string)
+	if !ok || strings.TrimSpace(query) == "" {
+		return exitcode.Usage("a query is required — pass it with -f query=... or -f query=@file.graphql")
+	}
+	delete(fields, "query")
+
+	if apiPaginate && !strings.Contains(query, "$endCursor") {
+		return exitcode.Usage("--paginate requires the query to accept an $endCursor: String variable")
+	}
+
+	client := newClient(cfg, cmd)
+	w := cmd.OutOrStdout()
+
+	for {
+		data, err := client.Execute(ctx, query, fields)
+		if err != nil {
+			// Print partial data alongside GraphQL errors, as the API returned it.
+			if len(data) > 0 && string(data) != "null" {
+				_ = output.JSON(w, data)
+			}
+			if api.IsGraphQLNotFound(err) {
+				return exitcode.NotFoundError(err.Error())
+			}
+			return err
+		}
+
+		if err := output.JSON(w, data); err != nil {
+			return err
+		}
+
+		if !apiPaginate {
+			return nil
+		}
+		hasNext, cursor := findPageInfo(data)
+		if !hasNext || cursor == "" {
+			return nil
+		}
+		fields["endCursor"] = cursor
+	}
+}
+
+func runAPIRest(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+
+	cfg, err := requireConfig()
+	if err != nil {
+		return err
+	}
+
+	path := args[0]
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+
+	fields, err := parseAPIFields(cfg, cmd.InOrStdin())
+	if err != nil {
+		return err
+	}
+
+	method := strings.ToUpper(apiMethod)
+	if method == "" {
+		method = http.MethodGet
+		if len(fields) > 0 {
+			method = http.MethodPost
+		}
+	}
+
+	var body []byte
+	if len(fields) > 0 {
+		if method == http.MethodGet || method == http.MethodHead {
+			path = appendQueryParams(path, fields)
+		} else {
+			body, err = json.Marshal(fields)
+			if err != nil {
+				return exitcode.General("marshaling request body", err)
+			}
+		}
+	}
+
+	client := newClient(cfg, cmd)
+	respBody, err := client.REST(ctx, method, path, body)
+	if err != nil {
+		return err
+	}
+
+	w := cmd.OutOrStdout()
+	if len(bytes.TrimSpace(respBody)) == 0 {
+		return nil
+	}
+	if json.Valid(respBody) {
+		return output.JSON(w, json.RawMessage(respBody))
+	}
+	_, err = w.Write(respBody)
+	return err
+}
+
+// parseAPIFields builds the field map from the -f and -F flags.
+func parseAPIFields(cfg *config.Config, stdin io.Reader) (map[string]any, error) {
+	fields := map[string]any{}
+
+	// Stdin can only be read once, so every @- field gets the same contents
+	var stdinData *string
+	readStdin := func() (string, error) {
+		if stdinData == nil {
+			data, err := io.ReadAll(stdin)
+			if err != nil {
+				return "", exitcode.General("reading stdin", err)
+			}
+			s := string(data)
+			stdinData = &s
+		}
+		return *stdinData, nil
+	}
+
+	for _, f := range apiRawFields {
+		key, value, err := splitAPIField(f, "-f")
+		if err != nil {
+			return nil, err
+		}
+		resolved, err := resolveAPIFieldValue(value, cfg, readStdin)
+		if err != nil {
+			return nil, err
+		}
+		fields[key] = resolved
+	}
+
+	for _, f := range apiFields {
+		key, value, err := splitAPIField(f, "-F")
+		if err != nil {
+			return nil, err
+		}
+		resolved, err := resolveAPIFieldValue(value, cfg, readStdin)
+		if err != nil {
+			return nil, err
+		}
+		fields[key] = typedAPIFieldValue(resolved)
+	}
+
+	return fields, nil
+}
+
+func splitAPIField(field, flag string) (string, string, error) {
+	key, value, ok := strings.Cut(field, "=")
+	if !ok || key == "" {
+		return "", "", exitcode.Usage(fmt.Sprintf("invalid %s value %q — expected key=value", flag, field))
+	}
+	return key, value, nil
+}
+
+// resolveAPIFieldValue expands @current, @- and @file values.
+func resolveAPIFieldValue(value string, cfg *config.Config, readStdin func() (string, error)) (string, error) {
+	switch {
+	case value == "@current":
+		if cfg.Workspace == "" {
+			return "", exitcode.Usage("no workspace configured for @current — use 'zh workspace switch' to set one")
+		}
+		return cfg.Workspace, nil
+	case value == "@-":
+		return readStdin()
+	case strings.HasPrefix(value, "@"):
+		data, err := os.ReadFile(value[1:])
+		if err != nil {
+			return "", exitcode.General(fmt.Sprintf("reading %s", value[1:]), err)
+		}
+		return string(data), nil
+	}
+	return value, nil
+}
+
+// typedAPIFieldValue decodes JSON literals (booleans, null, numbers, objects
+// and arrays), falling back to the string itself.
+func typedAPIFieldValue(value string) any {
+	trimmed := strings.TrimSpace(value)
+	if trimmed == "" || strings.HasPrefix(trimmed, `"`) {
+		return value
+	}
+	var decoded any
+	dec := json.NewDecoder(strings.NewReader(trimmed))
+	dec.UseNumber()
+	if err := dec.Decode(&decoded); err != nil || dec.More() {
+		return value
+	}
+	return decoded
+}
+
+// findPageInfo searches a GraphQL response for a pageInfo object with a next
+// page and returns its hasNextPage and endCursor values.
+func findPageInfo(data json.RawMessage) (bool, string) {
+	var decoded any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return false, ""
+	}
+	return findPageInfoValue(decoded)
+}
+
+func findPageInfoValue(v any) (bool, string) {
+	switch node := v.(type) {
+	case map[string]any:
+		if pi, ok := node["pageInfo"].(map[string]any); ok {
+			hasNext, _ := pi["hasNextPage"].(bool)
+			cursor, _ := pi["endCursor"].(string)
+			return hasNext, cursor
+		}
+		// Visit keys in a stable order so the same connection is always
+		// chosen for a given query.
+		keys := make([]string, 0, len(node))
+		for k := range node {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if hasNext, cursor := findPageInfoValue(node[k]); hasNext {
+				return hasNext, cursor
+			}
+		}
+	case []any:
+		for _, item := range node {
+			if hasNext, cursor := findPageInfoValue(item); hasNext {
+				return hasNext, cursor
+			}
+		}
+	}
+	return false, ""
+}
+
+// appendQueryParams encodes fields as URL query parameters.
+func appendQueryParams(path string, fields map[string]any) string {
+	params := url.Values{}
+	for k, v := range fields {
+		params.Set(k, fmt.Sprint(v))
+	}
+	sep := "?"
+	if strings.Contains(path, "?") {
+		sep = "&"
+	}
+	return path + sep + params.Encode()
+}
@@ -0,0 +1,274 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dslh/zh/internal/config"
+	"github.com/dslh/zh/internal/exitcode"
+	"github.com/dslh/zh/internal/testutil"
+)
+
+// --- api graphql ---
+
+func TestAPIGraphql(t *testing.T) {
+	resetAPIFlags()
+
+	ms := testutil.NewMockServer(t)
+	var vars map[string]any
+	ms.Handle(
+		func(req testutil.GraphQLRequest) bool { return strings.Contains(req.Query, "GetWorkspace") },
+		func(w http.ResponseWriter, req testutil.GraphQLRequest) {
+			_ = json.Unmarshal(req.Variables, &vars)
+			w.Write([]byte(`{"data":{"workspace":{"id":"ws-123","name":"Dev"}}}`))
+		},
+	)
+	setupIssueTestEnv(t, ms)
+
+	queryFile := filepath.Join(t.TempDir(), "ws.graphql")
+	os.WriteFile(queryFile, []byte("query GetWorkspace($id: ID!, $first: Int) { workspace(id: $id) { id name } }"), 0o600)
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"api", "graphql", "-f", "query=@" + queryFile, "-F", "id=@current", "-F", "first=5"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("api graphql returned error: %v", err)
+	}
+
+	if vars["id"] != "ws-123" {
+		t.Errorf("id = %v, want current workspace ws-123", vars["id"])
+	}
+	if vars["first"] != float64(5) {
+		t.Errorf("first = %v (%T), want number 5", vars["first"], vars["first"])
+	}
+	if _, ok := vars["query"]; ok {
+		t.Error("query should not be sent as a variable")
+	}
+
+	var result map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+		t.Fatalf("invalid JSON: %v\nOutput: %s", err, buf.String())
+	}
+	ws, _ := result["workspace"].(map[string]any)
+	if ws["name"] != "Dev" {
+		t.Errorf("output should contain response data, got: %s", buf.String())
+	}
+}
+
+func TestAPIGraphqlPaginate(t *testing.T) {
+	resetAPIFlags()
+
+	ms := testutil.NewMockServer(t)
+	var cursors []any
+	ms.Handle(
+		func(req testutil.GraphQLRequest) bool { return strings.Contains(req.Query, "ListIssues") },
+		func(w http.ResponseWriter, req testutil.GraphQLRequest) {
+			var vars map[string]any
+			_ = json.Unmarshal(req.Variables, &vars)
+			cursors = append(cursors, vars["endCursor"])
+			if vars["endCursor"] == nil {
+				w.Write([]byte(`{"data":{"issues":{"nodes":[{"number":1}],"pageInfo":{"hasNextPage":true,"endCursor":"c1"}}}}`))
+				return
+			}
+			w.Write([]byte(`{"data":{"issues":{"nodes":[{"number":2}],"pageInfo":{"hasNextPage":false,"endCursor":"c2"}}}}`))
+		},
+	)
+	setupIssueTestEnv(t, ms)
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"api", "graphql", "--paginate",
+		"-f", "query=query ListIssues($endCursor: String) { issues(after: $endCursor) { nodes { number } pageInfo { hasNextPage endCursor } } }"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("api graphql --paginate returned error: %v", err)
+	}
+
+	if len(cursors) != 2 || cursors[0] != nil || cursors[1] != "c1" {
+		t.Errorf("cursors = %v, want [<nil> c1]", cursors)
+	}
+	out := buf.String()
+	if !strings.Contains(out, `"number": 1`) || !strings.Contains(out, `"number": 2`) {
+		t.Errorf("output should contain both pages, got: %s", out)
+	}
+}
+
+func TestAPIGraphqlPaginateRequiresCursorVariable(t *testing.T) {
+	resetAPIFlags()
+
+	ms := testutil.NewMockServer(t)
+	setupIssueTestEnv(t, ms)
+
+	rootCmd.SetOut(new(bytes.Buffer))
+	rootCmd.SetErr(new(bytes.Buffer))
+	rootCmd.SetArgs([]string{"api", "graphql", "--paginate", "-f", "query={ viewer { id } }"})
+
+	err := rootCmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "$endCursor") {
+		t.Errorf("expected $endCursor usage error, got: %v", err)
+	}
+}
+
+func TestAPIGraphqlErrors(t *testing.T) {
+	resetAPIFlags()
+
+	ms := testutil.NewMockServer(t)
+	ms.HandleQuery("GetIssue", map[string]any{
+		"data":   map[string]any{"issue": nil},
+		"errors": []any{map[string]any{"message": "Issue not found", "extensions": map[string]any{"code": "NOT_FOUND"}}},
+	})
+	setupIssueTestEnv(t, ms)
+
+	rootCmd.SetOut(new(bytes.Buffer))
+	rootCmd.SetErr(new(bytes.Buffer))
+	rootCmd.SetArgs([]string{"api", "graphql", "-f", "query=query GetIssue { issue(id: \"x\") { id } }"})
+
+	err := rootCmd.Execute()
+	if err == nil {
+		t.Fatal("expected error for GraphQL errors")
+	}
+	if code := exitcode.ExitCode(err); code != exitcode.NotFound {
+		t.Errorf("exit code = %d, want %d (NotFound)", code, exitcode.NotFound)
+	}
+}
+
+func TestAPIGraphqlMissingQuery(t *testing.T) {
+	resetAPIFlags()
+
+	ms := testutil.NewMockServer(t)
+	setupIssueTestEnv(t, ms)
+
+	rootCmd.SetOut(new(bytes.Buffer))
+	rootCmd.SetErr(new(bytes.Buffer))
+	rootCmd.SetArgs([]string{"api", "graphql", "-F", "id=1"})
+
+	err := rootCmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "query is required") {
+		t.Errorf("expected missing query error, got: %v", err)
+	}
+}
+
+// --- api rest ---
+
+func TestAPIRestGet(t *testing.T) {
+	resetAPIFlags()
+
+	ms := testutil.NewMockServer(t)
+	setupIssueTestEnv(t, ms)
+	t.Setenv("ZH_REST_API_KEY", "rest-key")
+
+	var gotMethod, gotQuery, gotToken string
+	ms.Server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMethod = r.Method
+		gotQuery = r.URL.RawQuery
+		gotToken = r.Header.Get("X-Authentication-Token")
+		w.Write([]byte(`{"epic_issues":[{"issue_number":42}]}`))
+	})
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"api", "rest", "/p1/repositories/123/epics", "-X", "GET", "-f", "page=2"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("api rest returned error: %v", err)
+	}
+
+	if gotMethod != http.MethodGet || gotQuery != "page=2" {
+		t.Errorf("request = %s ?%s, want GET ?page=2", gotMethod, gotQuery)
+	}
+	if gotToken != "rest-key" {
+		t.Errorf("X-Authentication-Token = %q, want rest-key", gotToken)
+	}
+	if !strings.Contains(buf.String(), `"issue_number": 42`) {
+		t.Errorf("output should contain pretty-printed response, got: %s", buf.String())
+	}
+}
+
+func TestAPIRestPostFields(t *testing.T) {
+	resetAPIFlags()
+
+	ms := testutil.NewMockServer(t)
+	setupIssueTestEnv(t, ms)
+	t.Setenv("ZH_REST_API_KEY", "rest-key")
+
+	var gotMethod string
+	var gotBody map[string]any
+	ms.Server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMethod = r.Method
+		_ = json.NewDecoder(r.Body).Decode(&gotBody)
+		w.WriteHeader(200)
+	})
+
+	rootCmd.SetOut(new(bytes.Buffer))
+	rootCmd.SetArgs([]string{"api", "rest", "p1/repositories/123/epics/1/update_issues",
+		"-F", `add_issues=[{"repo_id":123,"issue_number":7}]`})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("api rest returned error: %v", err)
+	}
+
+	if gotMethod != http.MethodPost {
+		t.Errorf("method = %s, want POST when fields are given", gotMethod)
+	}
+	add, _ := gotBody["add_issues"].([]any)
+	if len(add) != 1 {
+		t.Errorf("add_issues = %v, want decoded JSON array", gotBody["add_issues"])
+	}
+}
+
+func TestAPIRestRequiresRESTKey(t *testing.T) {
+	resetAPIFlags()
+
+	ms := testutil.NewMockServer(t)
+	setupIssueTestEnv(t, ms)
+	t.Setenv("ZH_REST_API_KEY", "")
+
+	rootCmd.SetOut(new(bytes.Buffer))
+	rootCmd.SetErr(new(bytes.Buffer))
+	rootCmd.SetArgs([]string{"api", "rest", "/p1/repositories/123/epics"})
+
+	err := rootCmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "REST API token") {
+		t.Errorf("expected REST token error, got: %v", err)
+	}
+}
+
+func TestParseAPIFieldsStdinReused(t *testing.T) {
+	resetAPIFlags()
+	defer resetAPIFlags()
+	apiRawFields = []string{"body=@-", "note=@-"}
+	apiFields = []string{"count=@-"}
+
+	fields, err := parseAPIFields(&config.Config{}, strings.NewReader("42"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fields["body"] != "42" || fields["note"] != "42" || fields["count"] != json.Number("42") {
+		t.Errorf("every @- field should get the stdin contents, got %v", fields)
+	}
+}
+
+func TestTypedAPIFieldValue(t *testing.T) {
+	tests := []struct {
+		in   string
+		want any
+	}{
+		{"true", true},
+		{"null", nil},
+		{"42", json.Number("42")},
+		{"hello", "hello"},
+		{"12 monkeys", "12 monkeys"},
+		{`"quoted"`, `"quoted"`},
+	}
+	for _, tt := range tests {
+		if got := typedAPIFieldValue(tt.in); got != tt.want {
+			t.Errorf("typedAPIFieldValue(%q) = %v (%T), want %v", tt.in, got, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,503 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/dslh/zh/internal/api"
+	"github.com/dslh/zh/internal/exitcode"
+	"github.com/dslh/zh/internal/history"
+	"github.com/dslh/zh/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// Commands
+
+var applyCmd = &cobra.Command{
+	Use:   "apply <plan.json>",
+	Short: "Apply a plan written by --plan-out",
+	Long: `Apply a plan written by the --plan-out flag of a batch mutation command.
+
+The plan records the resolved pipeline, sprint, epic, priority or labels
+along with each issue's state when the plan was made. Before applying it,
+zh checks each issue's current state. If any issue has moved to another
+pipeline, been re-estimated, or been opened or closed since, the plan is
+not applied. Use --force to apply it anyway.
+
+Plans can be written by issue move, issue close, issue reopen, issue
+priority, issue label add/remove, sprint add/remove and epic add/remove.
+Applied plans are recorded in zh history, except for issue close and
+issue reopen, which can't be undone.
+
+Examples:
+  zh issue move task-tracker#1 task-tracker#2 Done --plan-out=plan.json
+  zh apply plan.json
+  zh apply plan.json --dry-run
+  zh apply plan.json --force`,
+	Args: cobra.ExactArgs(1),
+	RunE: runApply,
+}
+
+var (
+	applyDryRun bool
+	applyForce  bool
+)
+
+func init() {
+	applyCmd.Flags().BoolVar(&applyDryRun, "dry-run", false, "Check the plan against the current state without applying it")
+	applyCmd.Flags().BoolVar(&applyForce, "force", false, "Apply the plan even if issues have changed since it was made")
+
+	rootCmd.AddCommand(applyCmd)
+}
+
+func resetApplyFlags() {
+	applyDryRun = false
+	applyForce = false
+}
+
+// planDrift is a difference between an issue's state when a plan was made
+// and its current state.
+type planDrift struct {
+	Ref   string `json:"ref"`
+	Field string `json:"field"`
+	From  any    `json:"from"`
+	To    any    `json:"to"`
+}
+
+// planExecutor applies a plan to the given issues, which hold their current
+// state. Per-issue failures are returned in failed; err is for failures that
+// stop the whole plan.
+type planExecutor func(ctx context.Context, client *api.Client, workspaceID string, plan *mutationPlan, issues []planIssue) (failed []output.FailedItem, err error)
+
+// planExecutors maps a plan's command to the function that applies it.
+var planExecutors = map[string]planExecutor{
+	"issue move":         applyIssueMovePlan,
+	"issue close":        applyIssueClosePlan,
+	"issue reopen":       applyIssueReopenPlan,
+	"issue priority":     applyIssuePriorityPlan,
+	"issue label add":    applyIssueLabelPlan,
+	"issue label remove": applyIssueLabelPlan,
+	"sprint add":         applySprintPlan,
+	"sprint remove":      applySprintPlan,
+	"epic add":           applyEpicPlan,
+	"epic remove":        applyEpicPlan,
+}
+
+func runApply(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+
+	cfg, err := requireWorkspace()
+	if err != nil {
+		return err
+	}
+
+	plan, err := loadPlan(args[0])
+	if err != nil {
+		return err
+	}
+	if plan.Workspace != cfg.Workspace {
+		return exitcode.Usage(fmt.Sprintf("plan was made for workspace %s, but the current workspace is %s", plan.Workspace, cfg.Workspace))
+	}
+
+	client := newClient(cfg, cmd)
+	w := cmd.OutOrStdout()
+
+	// Fetch each issue's current state and compare it with the plan
+	current := make([]planIssue, len(plan.Issues))
+	var drift []planDrift
+	for i, planned := range plan.Issues {
+		issue, err := fetchPlanIssueState(ctx, client, cfg.Workspace, planned.ID)
+		if err != nil {
+			return err
+		}
+		current[i] = *issue
+		drift = append(drift, comparePlanIssue(planned, *issue)...)
+	}
+
+	if len(drift) > 0 && !output.IsJSON(outputFormat) {
+		renderPlanDrift(w, plan, drift)
+		fmt.Fprintln(w)
+	}
+	if len(drift) > 0 && !applyForce {
+		if output.IsJSON(outputFormat) {
+			if err := output.JSON(w, map[string]any{
+				"command": plan.Command,
+				"summary": plan.Summary,
+				"applied": false,
+				"drift":   drift,
+			}); err != nil {
+				return err
+			}
+		}
+		return exitcode.Generalf("plan is out of date — re-run the command to make a new plan, or use --force to apply it anyway")
+	}
+
+	items := make([]output.MutationItem, len(current))
+	for i, issue := range current {
+		items[i] = output.MutationItem{
+			Ref:   issue.Ref(),
+			Title: truncateTitle(issue.Title),
+		}
+	}
+
+	if applyDryRun {
+		if output.IsJSON(outputFormat) {
+			return output.JSON(w, map[string]any{
+				"dryRun":  true,
+				"command": plan.Command,
+				"summary": plan.Summary,
+				"issues":  items,
+				"drift":   drift,
+			})
+		}
+		output.MutationDryRun(w, fmt.Sprintf("Would %s", plan.Summary), items)
+		return nil
+	}
+
+	failed, err := planExecutors[plan.Command](ctx, client, cfg.Workspace, plan, current)
+	if err != nil {
+		return err
+	}
+
+	failedRefs := make(map[string]bool, len(failed))
+	for _, f := range failed {
+		failedRefs[f.Ref] = true
+	}
+	var succeeded []output.MutationItem
+	var applied []planIssue
+	for i, item := range items {
+		if !failedRefs[item.Ref] {
+			succeeded = append(succeeded, item)
+			applied = append(applied, current[i])
+		}
+	}
+
+	recordHistory(cmd.ErrOrStderr(), cfg.Workspace, "apply", "Applied plan: "+plan.Summary, planHistoryChanges(plan, applied))
+
+	if output.IsJSON(outputFormat) {
+		if err := output.JSON(w, map[string]any{
+			"command": plan.Command,
+			"summary": plan.Summary,
+			"applied": true,
+			"issues":  succeeded,
+			"failed":  failed,
+			"drift":   drift,
+		}); err != nil {
+			return err
+		}
+	} else {
+		header := output.Green(fmt.Sprintf("Applied plan: %s.", plan.Summary))
+		if len(failed) > 0 {
+			output.MutationPartialFailure(w, header, succeeded, failed)
+		} else {
+			output.MutationBatch(w, header, succeeded)
+		}
+	}
+
+	if len(failed) > 0 {
+		return exitcode.Generalf("some issues failed")
+	}
+	return nil
+}
+
+// planHistoryChanges returns the history changes made by applying plan to
+// issues, which hold their state from before it was applied. Closing and
+// reopening issues can't be undone, so those plans aren't recorded.
+func planHistoryChanges(plan *mutationPlan, issues []planIssue) []history.Change {
+	var changes []history.Change
+	for _, issue := range issues {
+		change := history.Change{
+			Issue: history.Issue{
+				ID:        issue.ID,
+				Number:    issue.Number,
+				RepoName:  issue.RepoName,
+				RepoOwner: issue.RepoOwner,
+				RepoGhID:  issue.RepoGhID,
+			},
+		}
+
+		switch plan.Command {
+		case "issue move":
+			change.Kind = history.KindMove
+			if issue.Pipeline != nil {
+				change.Before = &history.Value{ID: issue.Pipeline.ID, Name: issue.Pipeline.Name, Position: issue.Position}
+			}
+			change.After = &history.Value{ID: plan.Pipeline.ID, Name: plan.Pipeline.Name}
+			changes = append(changes, change)
+
+		case "issue priority":
+			change.Kind = history.KindPriority
+			if issue.Priority != nil {
+				change.Before = &history.Value{ID: issue.Priority.ID, Name: issue.Priority.Name}
+			}
+			if plan.Priority != nil {
+				change.After = &history.Value{ID: plan.Priority.ID, Name: plan.Priority.Name}
+			}
+			if change.Before != nil || change.After != nil {
+				changes = append(changes, change)
+			}
+
+		case "issue label add", "issue label remove":
+			// As with issue label, only labels that actually changed are
+			// recorded.
+			add := plan.Command == "issue label add"
+			for _, l := range plan.Labels {
+				if slices.Contains(issue.LabelIDs, l.ID) == add {
+					continue
+				}
+				c := change
+				c.Kind = history.KindLabel
+				if add {
+					c.After = &history.Value{ID: l.ID, Name: l.Name}
+				} else {
+					c.Before = &history.Value{ID: l.ID, Name: l.Name}
+				}
+				changes = append(changes, c)
+			}
+
+		case "sprint add", "sprint remove":
+			change.Kind = history.KindSprint
+			value := &history.Value{ID: plan.Sprint.ID, Name: plan.Sprint.Name}
+			if plan.Command == "sprint add" {
+				change.After = value
+			} else {
+				change.Before = value
+			}
+			changes = append(changes, change)
+
+		case "epic add", "epic remove":
+			change.Kind = history.KindEpic
+			value := epicHistoryValue(plan.Epic.result())
+			if plan.Command == "epic add" {
+				change.After = value
+			} else {
+				change.Before = value
+			}
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
+
+// comparePlanIssue reports how an issue's pipeline, estimate and state have
+// changed since the plan was made.
+func comparePlanIssue(planned, current planIssue) []planDrift {
+	var drift []planDrift
+	ref := planned.Ref()
+
+	pipelineName := func(p *planEntity) any {
+		if p == nil {
+			return nil
+		}
+		return p.Name
+	}
+	pipelineID := func(p *planEntity) string {
+		if p == nil {
+			return ""
+		}
+		return p.ID
+	}
+	if pipelineID(planned.Pipeline) != pipelineID(current.Pipeline) {
+		drift = append(drift, planDrift{Ref: ref, Field: "pipeline", From: pipelineName(planned.Pipeline), To: pipelineName(current.Pipeline)})
+	}
+
+	estimate := func(v *float64) any {
+		if v == nil {
+			return nil
+		}
+		return *v
+	}
+	if (planned.Estimate == nil) != (current.Estimate == nil) ||
+		(planned.Estimate != nil && *planned.Estimate != *current.Estimate) {
+		drift = append(drift, planDrift{Ref: ref, Field: "estimate", From: estimate(planned.Estimate), To: estimate(current.Estimate)})
+	}
+
+	if !strings.EqualFold(planned.State, current.State) {
+		drift = append(drift, planDrift{Ref: ref, Field: "state", From: strings.ToLower(planned.State), To: strings.ToLower(current.State)})
+	}
+
+	return drift
+}
+
+func renderPlanDrift(w writerFlusher, plan *mutationPlan, drift []planDrift) {
+	fmt.Fprintln(w, output.Yellow(fmt.Sprintf("Issues have changed since the plan was made (%s):", output.FormatTimeAgo(plan.CreatedAt))))
+	fmt.Fprintln(w)
+	for _, d := range drift {
+		fmt.Fprintf(w, "  %s  %s: %s → %s\n", d.Ref, d.Field, formatDriftValue(d.From), formatDriftValue(d.To))
+	}
+}
+
+func formatDriftValue(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return output.Dim("none")
+	case float64:
+		return formatEstimate(val)
+	case string:
+		return val
+	}
+	return fmt.Sprint(v)
+}
+
+// Executors
+
+func applyIssueMovePlan(ctx context.Context, client *api.Client, workspaceID string, plan *mutationPlan, issues []planIssue) ([]output.FailedItem, error) {
+	posType, posNum, err := parsePosition(plan.Position)
+	if err != nil {
+		return nil, err
+	}
+
+	var failed []output.FailedItem
+	for _, issue := range issues {
+		err := executeMoveIssue(ctx, client, resolvedMoveIssue{
+			IssueID:         issue.ID,
+			PipelineIssueID: issue.PipelineIssueID,
+			Number:          issue.Number,
+			Title:           issue.Title,
+			RepoName:        issue.RepoName,
+			RepoOwner:       issue.RepoOwner,
+		}, plan.Pipeline.ID, posType, posNum)
+		if err != nil {
+			failed = append(failed, output.FailedItem{Ref: issue.Ref(), Reason: err.Error()})
+		}
+	}
+	return failed, nil
+}
+
+func applyIssueClosePlan(ctx context.Context, client *api.Client, workspaceID string, plan *mutationPlan, issues []planIssue) ([]output.FailedItem, error) {
+	data, err := client.Execute(ctx, closeIssuesMutation, map[string]any{
+		"input": map[string]any{
+			"issueIds": planIssueIDs(issues),
+		},
+	})
+	if err != nil {
+		return nil, exitcode.General("closing issues", err)
+	}
+	return planBatchFailures(data, "closeIssues", issues, "failed to close")
+}
+
+func applyIssueReopenPlan(ctx context.Context, client *api.Client, workspaceID string, plan *mutationPlan, issues []planIssue) ([]output.FailedItem, error) {
+	position := "END"
+	if plan.Position == "top" {
+		position = "START"
+	}
+	data, err := client.Execute(ctx, reopenIssuesMutation, map[string]any{
+		"input": map[string]any{
+			"issueIds":   planIssueIDs(issues),
+			"pipelineId": plan.Pipeline.ID,
+			"position":   position,
+		},
+	})
+	if err != nil {
+		return nil, exitcode.General("reopening issues", err)
+	}
+	return planBatchFailures(data, "reopenIssues", issues, "failed to reopen")
+}
+
+func applyIssuePriorityPlan(ctx context.Context, client *api.Client, workspaceID string, plan *mutationPlan, issues []planIssue) ([]output.FailedItem, error) {
+	resolved := make([]resolvedPriorityIssue, len(issues))
+	for i, issue := range issues {
+		resolved[i] = resolvedPriorityIssue{
+			IssueID:  issue.ID,
+			Number:   issue.Number,
+			RepoName: issue.RepoName,
+			RepoGhID: issue.RepoGhID,
+		}
+	}
+	if plan.Priority != nil {
+		return nil, executeSetPriority(ctx, client, resolved, plan.Priority.ID)
+	}
+	return nil, executeClearPriority(ctx, client, workspaceID, resolved)
+}
+
+func applyIssueLabelPlan(ctx context.Context, client *api.Client, workspaceID string, plan *mutationPlan, issues []planIssue) ([]output.FailedItem, error) {
+	mutation, key, op, action := addLabelsToIssuesMutation, "addLabelsToIssues", "add", "adding labels"
+	if plan.Command == "issue label remove" {
+		mutation, key, op, action = removeLabelsFromIssuesMutation, "removeLabelsFromIssues", "remove", "removing labels"
+	}
+
+	labelIDs := make([]string, len(plan.Labels))
+	for i, l := range plan.Labels {
+		labelIDs[i] = l.ID
+	}
+
+	data, err := client.Execute(ctx, mutation, map[string]any{
+		"input": map[string]any{
+			"issueIds": planIssueIDs(issues),
+			"labelIds": labelIDs,
+		},
+	})
+	if err != nil {
+		return nil, exitcode.General(action, err)
+	}
+	return planBatchFailures(data, key, issues, fmt.Sprintf("failed to %s labels", op))
+}
+
+func applySprintPlan(ctx context.Context, client *api.Client, workspaceID string, plan *mutationPlan, issues []planIssue) ([]output.FailedItem, error) {
+	mutation, action := addIssuesToSprintsMutation, "adding issues to sprint"
+	if plan.Command == "sprint remove" {
+		mutation, action = removeIssuesFromSprintsMutation, "removing issues from sprint"
+	}
+
+	_, err := client.Execute(ctx, mutation, map[string]any{
+		"input": map[string]any{
+			"issueIds":  planIssueIDs(issues),
+			"sprintIds": []string{plan.Sprint.ID},
+		},
+	})
+	if err != nil {
+		return nil, exitcode.General(action, err)
+	}
+	return nil, nil
+}
+
+func applyEpicPlan(ctx context.Context, client *api.Client, workspaceID string, plan *mutationPlan, issues []planIssue) ([]output.FailedItem, error) {
+	resolved := make([]resolvedEpicIssue, len(issues))
+	for i, issue := range issues {
+		resolved[i] = resolvedEpicIssue{
+			ID:        issue.ID,
+			Number:    issue.Number,
+			RepoGhID:  issue.RepoGhID,
+			RepoName:  issue.RepoName,
+			RepoOwner: issue.RepoOwner,
+			Title:     issue.Title,
+		}
+	}
+	return nil, executeEpicIssuesChange(ctx, client, workspaceID, plan.Epic.result(), resolved, plan.Command == "epic add")
+}
+
+func planIssueIDs(issues []planIssue) []string {
+	ids := make([]string, len(issues))
+	for i, issue := range issues {
+		ids[i] = issue.ID
+	}
+	return ids
+}
+
+// planBatchFailures reads the failedIssues list from a batch mutation
+// response nested under key.
+func planBatchFailures(data json.RawMessage, key string, issues []planIssue, reason string) ([]output.FailedItem, error) {
+	var resp map[string]struct {
+		FailedIssues []struct {
+			ID string `json:"id"`
+		} `json:"failedIssues"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, exitcode.General("parsing mutation response", err)
+	}
+
+	failedIDs := make(map[string]bool)
+	for _, f := range resp[key].FailedIssues {
+		failedIDs[f.ID] = true
+	}
+	var failed []output.FailedItem
+	for _, issue := range issues {
+		if failedIDs[issue.ID] {
+			failed = append(failed, output.FailedItem{Ref: issue.Ref(), Reason: reason})
+		}
+	}
+	return failed, nil
+}
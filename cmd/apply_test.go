@@ -0,0 +1,472 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dslh/zh/internal/cache"
+	"github.com/dslh/zh/internal/exitcode"
+	"github.com/dslh/zh/internal/history"
+	"github.com/dslh/zh/internal/resolve"
+	"github.com/dslh/zh/internal/testutil"
+)
+
+// planIssueStateResponse builds a GetIssueStateForPlan response for
+// task-tracker#<number>.
+func planIssueStateResponse(id string, number int, pipelineID, pipelineName string, estimate any, state string) map[string]any {
+	var est any
+	if estimate != nil {
+		est = map[string]any{"value": estimate}
+	}
+	return map[string]any{
+		"data": map[string]any{
+			"node": map[string]any{
+				"id":       id,
+				"number":   number,
+				"title":    "Fix login button alignment",
+				"state":    state,
+				"estimate": est,
+				"repository": map[string]any{
+					"ghId":      12345,
+					"name":      "task-tracker",
+					"ownerName": "dlakehammond",
+				},
+				"pipelineIssue": map[string]any{
+					"id":       "pi-" + id,
+					"pipeline": map[string]any{"id": pipelineID, "name": pipelineName},
+				},
+			},
+		},
+	}
+}
+
+// recordMutations records the variables of every mutation sent to ms and
+// answers with a generic success response.
+func recordMutations(ms *testutil.MockServer) *[]testutil.GraphQLRequest {
+	var recorded []testutil.GraphQLRequest
+	ms.Handle(
+		func(req testutil.GraphQLRequest) bool {
+			return strings.HasPrefix(strings.TrimSpace(req.Query), "mutation")
+		},
+		func(w http.ResponseWriter, req testutil.GraphQLRequest) {
+			recorded = append(recorded, req)
+			_, _ = w.Write([]byte(`{"data":{}}`))
+		},
+	)
+	return &recorded
+}
+
+// writeTestPlan writes a plan for task-tracker#1, made while it was in
+// In Development with an estimate of 3.
+func writeTestPlan(t *testing.T, plan mutationPlan) string {
+	t.Helper()
+	estimate := 3.0
+	plan.Version = planVersion
+	plan.Workspace = "ws-123"
+	plan.CreatedAt = time.Now().Add(-time.Hour)
+	plan.Issues = []planIssue{{
+		ID:        "i1",
+		Number:    1,
+		Title:     "Fix login button alignment",
+		RepoName:  "task-tracker",
+		RepoOwner: "dlakehammond",
+		RepoGhID:  12345,
+		State:     "OPEN",
+		Pipeline:  &planEntity{ID: "p2", Name: "In Development"},
+		Estimate:  &estimate,
+	}}
+	if plan.Summary == "" {
+		plan.Summary = "test plan"
+	}
+
+	data, err := json.Marshal(plan)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "plan.json")
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func setupApplyServer(t *testing.T, state map[string]any) (*testutil.MockServer, *[]testutil.GraphQLRequest) {
+	t.Helper()
+	ms := testutil.NewMockServer(t)
+	ms.HandleQuery("GetIssueStateForPlan", state)
+	mutations := recordMutations(ms)
+	setupIssueTestEnv(t, ms)
+	return ms, mutations
+}
+
+func TestIssueMovePlanOut(t *testing.T) {
+	resetIssueFlags()
+	resetIssueMoveFlags()
+
+	ms := testutil.NewMockServer(t)
+	ms.HandleQuery("ListPipelines", pipelineResolutionResponse())
+	ms.HandleQuery("IssueByInfo", issueByInfoResolutionResponse())
+	ms.HandleQuery("GetPipelineIssueId", pipelineIssueIDResponse("i1", 1, "Fix login button alignment", "pi1", "p2", "In Development"))
+	ms.HandleQuery("GetIssueStateForPlan", planIssueStateResponse("i1", 1, "p2", "In Development", 3, "OPEN"))
+	mutations := recordMutations(ms)
+	setupIssueTestEnv(t, ms)
+	_ = cache.Set(resolve.RepoCacheKey("ws-123"), []resolve.CachedRepo{
+		{ID: "r1", GhID: 12345, Name: "task-tracker", OwnerName: "dlakehammond"},
+	})
+
+	path := filepath.Join(t.TempDir(), "plan.json")
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"issue", "move", "task-tracker#1", "Done", "--position=top", "--plan-out=" + path})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("issue move --plan-out returned error: %v", err)
+	}
+
+	if len(*mutations) != 0 {
+		t.Errorf("--plan-out should not execute mutations, got %d", len(*mutations))
+	}
+	out := buf.String()
+	if !strings.Contains(out, `Would move 1 issue(s) to "Done" at top`) {
+		t.Errorf("output should show the dry run, got: %s", out)
+	}
+	if !strings.Contains(out, "zh apply "+path) {
+		t.Errorf("output should say how to apply the plan, got: %s", out)
+	}
+
+	plan, err := loadPlan(path)
+	if err != nil {
+		t.Fatalf("loading written plan: %v", err)
+	}
+	if plan.Command != "issue move" || plan.Pipeline == nil || plan.Pipeline.ID != "p3" || plan.Position != "top" {
+		t.Errorf("unexpected plan target: %+v", plan)
+	}
+	if plan.Summary != `move 1 issue(s) to "Done" at top` {
+		t.Errorf("summary = %q", plan.Summary)
+	}
+	if len(plan.Issues) != 1 {
+		t.Fatalf("expected 1 issue in plan, got %d", len(plan.Issues))
+	}
+	issue := plan.Issues[0]
+	if issue.ID != "i1" || issue.Pipeline == nil || issue.Pipeline.ID != "p2" || issue.Estimate == nil || *issue.Estimate != 3 {
+		t.Errorf("plan should record the issue's current state, got %+v", issue)
+	}
+}
+
+func TestApplyPlan(t *testing.T) {
+	resetApplyFlags()
+	setupHistoryDataDir(t)
+
+	_, mutations := setupApplyServer(t, planIssueStateResponse("i1", 1, "p2", "In Development", 3, "OPEN"))
+	path := writeTestPlan(t, mutationPlan{
+		Command:  "issue move",
+		Summary:  `move 1 issue(s) to "Done" at top`,
+		Pipeline: &planEntity{ID: "p3", Name: "Done"},
+		Position: "top",
+	})
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"apply", path})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("apply returned error: %v", err)
+	}
+
+	if len(*mutations) != 1 {
+		t.Fatalf("expected 1 mutation, got %d", len(*mutations))
+	}
+	vars := string((*mutations)[0].Variables)
+	if !strings.Contains(vars, `"pipelineId":"p3"`) || !strings.Contains(vars, `"pi-i1"`) || !strings.Contains(vars, `"START"`) {
+		t.Errorf("unexpected move variables: %s", vars)
+	}
+	out := buf.String()
+	if !strings.Contains(out, `Applied plan: move 1 issue(s) to "Done" at top.`) {
+		t.Errorf("output should confirm the plan was applied, got: %s", out)
+	}
+	if !strings.Contains(out, "task-tracker#1") {
+		t.Errorf("output should list the issue, got: %s", out)
+	}
+
+	entries, _ := history.Load("ws-123")
+	if len(entries) != 1 || entries[0].Command != "apply" || len(entries[0].Changes) != 1 {
+		t.Fatalf("expected one recorded apply entry, got %+v", entries)
+	}
+	c := entries[0].Changes[0]
+	if c.Kind != history.KindMove || c.Issue.ID != "i1" || c.Before.ID != "p2" || c.After.ID != "p3" {
+		t.Errorf("unexpected change: %+v", c)
+	}
+}
+
+func TestPlanHistoryChanges(t *testing.T) {
+	issue := planIssue{
+		ID:       "i1",
+		Number:   1,
+		RepoName: "task-tracker",
+		RepoGhID: 12345,
+		Priority: &planEntity{ID: "pr1", Name: "High priority"},
+		LabelIDs: []string{"l1"},
+	}
+
+	changes := planHistoryChanges(&mutationPlan{Command: "issue close"}, []planIssue{issue})
+	if len(changes) != 0 {
+		t.Errorf("closing issues should not be recorded, got %+v", changes)
+	}
+
+	changes = planHistoryChanges(&mutationPlan{Command: "issue priority"}, []planIssue{issue})
+	if len(changes) != 1 || changes[0].Before.ID != "pr1" || changes[0].After != nil || changes[0].Issue.RepoGhID != 12345 {
+		t.Errorf("clearing priority should record the previous priority, got %+v", changes)
+	}
+
+	changes = planHistoryChanges(&mutationPlan{
+		Command: "issue label add",
+		Labels:  []planEntity{{ID: "l1", Name: "bug"}, {ID: "l2", Name: "enhancement"}},
+	}, []planIssue{issue})
+	if len(changes) != 1 || changes[0].After.ID != "l2" {
+		t.Errorf("only labels the issue didn't have should be recorded, got %+v", changes)
+	}
+
+	changes = planHistoryChanges(&mutationPlan{Command: "sprint add", Sprint: &planEntity{ID: "s1", Name: "Sprint 47"}}, []planIssue{issue})
+	if len(changes) != 1 || changes[0].Kind != history.KindSprint || changes[0].After.ID != "s1" {
+		t.Errorf("unexpected sprint change: %+v", changes)
+	}
+}
+
+func TestApplyPlanDrift(t *testing.T) {
+	resetApplyFlags()
+
+	_, mutations := setupApplyServer(t, planIssueStateResponse("i1", 1, "p3", "Done", 5, "OPEN"))
+	path := writeTestPlan(t, mutationPlan{
+		Command: "sprint add",
+		Sprint:  &planEntity{ID: "s1", Name: "Sprint 47"},
+	})
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"apply", path})
+
+	err := rootCmd.Execute()
+	if err == nil {
+		t.Fatal("expected apply to refuse a drifted plan")
+	}
+	if !strings.Contains(err.Error(), "--force") {
+		t.Errorf("error should mention --force, got: %v", err)
+	}
+	if len(*mutations) != 0 {
+		t.Errorf("drifted plan should not be applied, got %d mutation(s)", len(*mutations))
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "pipeline: In Development → Done") {
+		t.Errorf("output should show pipeline drift, got: %s", out)
+	}
+	if !strings.Contains(out, "estimate: 3 → 5") {
+		t.Errorf("output should show estimate drift, got: %s", out)
+	}
+}
+
+func TestApplyPlanDriftForce(t *testing.T) {
+	resetApplyFlags()
+
+	_, mutations := setupApplyServer(t, planIssueStateResponse("i1", 1, "p3", "Done", 3, "OPEN"))
+	path := writeTestPlan(t, mutationPlan{
+		Command: "sprint add",
+		Summary: "add 1 issue(s) to Sprint 47",
+		Sprint:  &planEntity{ID: "s1", Name: "Sprint 47"},
+	})
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"apply", path, "--force"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("apply --force returned error: %v", err)
+	}
+
+	if len(*mutations) != 1 || !strings.Contains((*mutations)[0].Query, "AddIssuesToSprints") {
+		t.Fatalf("expected an AddIssuesToSprints mutation, got %v", *mutations)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "pipeline: In Development → Done") {
+		t.Errorf("output should still warn about drift, got: %s", out)
+	}
+	if !strings.Contains(out, "Applied plan: add 1 issue(s) to Sprint 47.") {
+		t.Errorf("output should confirm the plan was applied, got: %s", out)
+	}
+}
+
+func TestApplyPlanDryRun(t *testing.T) {
+	resetApplyFlags()
+
+	_, mutations := setupApplyServer(t, planIssueStateResponse("i1", 1, "p2", "In Development", 3, "OPEN"))
+	path := writeTestPlan(t, mutationPlan{
+		Command: "issue close",
+		Summary: "close 1 issue(s)",
+	})
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"apply", path, "--dry-run"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("apply --dry-run returned error: %v", err)
+	}
+	if len(*mutations) != 0 {
+		t.Errorf("dry run should not execute mutations, got %d", len(*mutations))
+	}
+	if !strings.Contains(buf.String(), "Would close 1 issue(s)") {
+		t.Errorf("unexpected output: %s", buf.String())
+	}
+}
+
+func TestApplyPlanExecutors(t *testing.T) {
+	tests := []struct {
+		name     string
+		plan     mutationPlan
+		mutation string
+		vars     []string
+	}{
+		{
+			name:     "issue close",
+			plan:     mutationPlan{Command: "issue close"},
+			mutation: "CloseIssues",
+			vars:     []string{`"issueIds":["i1"]`},
+		},
+		{
+			name:     "issue reopen",
+			plan:     mutationPlan{Command: "issue reopen", Pipeline: &planEntity{ID: "p1", Name: "New Issues"}, Position: "top"},
+			mutation: "ReopenIssues",
+			vars:     []string{`"pipelineId":"p1"`, `"position":"START"`},
+		},
+		{
+			name:     "issue priority set",
+			plan:     mutationPlan{Command: "issue priority", Priority: &planEntity{ID: "pr1", Name: "High priority"}},
+			mutation: "SetIssuePriority",
+			vars:     []string{`"priorityId":"pr1"`, `"repositoryGhId":12345`},
+		},
+		{
+			name:     "issue priority clear",
+			plan:     mutationPlan{Command: "issue priority"},
+			mutation: "RemoveIssuePriority",
+			vars:     []string{`"issueNumber":1`},
+		},
+		{
+			name:     "issue label remove",
+			plan:     mutationPlan{Command: "issue label remove", Labels: []planEntity{{ID: "l1", Name: "bug"}}},
+			mutation: "RemoveLabelsFromIssues",
+			vars:     []string{`"labelIds":["l1"]`},
+		},
+		{
+			name:     "sprint remove",
+			plan:     mutationPlan{Command: "sprint remove", Sprint: &planEntity{ID: "s1", Name: "Sprint 47"}},
+			mutation: "RemoveIssuesFromSprints",
+			vars:     []string{`"sprintIds":["s1"]`},
+		},
+		{
+			name:     "epic add",
+			plan:     mutationPlan{Command: "epic add", Epic: &planEpic{ID: "e1", Title: "Q1", Type: "zenhub"}},
+			mutation: "AddIssuesToZenhubEpics",
+			vars:     []string{`"zenhubEpicIds":["e1"]`, `"issueIds":["i1"]`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetApplyFlags()
+
+			_, mutations := setupApplyServer(t, planIssueStateResponse("i1", 1, "p2", "In Development", 3, "OPEN"))
+			path := writeTestPlan(t, tt.plan)
+
+			rootCmd.SetOut(new(bytes.Buffer))
+			rootCmd.SetArgs([]string{"apply", path})
+
+			if err := rootCmd.Execute(); err != nil {
+				t.Fatalf("apply returned error: %v", err)
+			}
+			if len(*mutations) != 1 || !strings.Contains((*mutations)[0].Query, tt.mutation) {
+				t.Fatalf("expected a %s mutation, got %v", tt.mutation, *mutations)
+			}
+			vars := string((*mutations)[0].Variables)
+			for _, want := range tt.vars {
+				if !strings.Contains(vars, want) {
+					t.Errorf("variables should contain %s, got: %s", want, vars)
+				}
+			}
+		})
+	}
+}
+
+func TestApplyPlanInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		edit func(*mutationPlan)
+		want string
+	}{
+		{"wrong workspace", func(p *mutationPlan) { p.Workspace = "ws-other" }, "made for workspace ws-other"},
+		{"unknown command", func(p *mutationPlan) { p.Command = "issue explode" }, `unsupported command "issue explode"`},
+		{"future version", func(p *mutationPlan) { p.Version = 99 }, "plan version 99"},
+		{"move without pipeline", func(p *mutationPlan) { p.Command = "issue move" }, "is missing pipeline for issue move"},
+		{"sprint add without sprint", func(p *mutationPlan) { p.Command = "sprint add" }, "is missing sprint for sprint add"},
+		{"epic remove without epic", func(p *mutationPlan) { p.Command = "epic remove" }, "is missing epic for epic remove"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetApplyFlags()
+			setupApplyServer(t, planIssueStateResponse("i1", 1, "p2", "In Development", 3, "OPEN"))
+
+			path := writeTestPlan(t, mutationPlan{Command: "issue close"})
+			plan, err := loadPlan(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tt.edit(plan)
+			data, _ := json.Marshal(plan)
+			_ = os.WriteFile(path, data, 0o644)
+
+			rootCmd.SetOut(new(bytes.Buffer))
+			rootCmd.SetArgs([]string{"apply", path})
+
+			err = rootCmd.Execute()
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if exitcode.ExitCode(err) != exitcode.UsageError {
+				t.Errorf("exit code = %d, want usage error", exitcode.ExitCode(err))
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %q, want it to contain %q", err.Error(), tt.want)
+			}
+		})
+	}
+}
+
+func TestComparePlanIssue(t *testing.T) {
+	three, five := 3.0, 5.0
+	planned := planIssue{Number: 1, RepoName: "api", State: "OPEN", Pipeline: &planEntity{ID: "p1", Name: "Todo"}, Estimate: &three}
+
+	if drift := comparePlanIssue(planned, planned); len(drift) != 0 {
+		t.Errorf("identical state should not drift, got %+v", drift)
+	}
+
+	current := planned
+	current.State = "CLOSED"
+	current.Pipeline = nil
+	current.Estimate = &five
+	drift := comparePlanIssue(planned, current)
+	if len(drift) != 3 {
+		t.Fatalf("expected pipeline, estimate and state drift, got %+v", drift)
+	}
+	if drift[0].Field != "pipeline" || drift[0].To != nil {
+		t.Errorf("unexpected pipeline drift: %+v", drift[0])
+	}
+	if drift[2].Field != "state" || drift[2].From != "open" || drift[2].To != "closed" {
+		t.Errorf("unexpected state drift: %+v", drift[2])
+	}
+}
@@ -14,6 +14,13 @@ var allCommands = [][]string{
 	{"version"},
 	{"cache"},
 	{"cache", "clear"},
+	{"cache", "status"},
+	{"cache", "refresh"},
+	{"outbox"},
+	{"outbox", "clear"},
+	{"api"},
+	{"api", "graphql"},
+	{"api", "rest"},
 
 	// Workspace
 	{"workspace"},
@@ -22,6 +29,10 @@ var allCommands = [][]string{
 	{"workspace", "switch"},
 	{"workspace", "repos"},
 	{"workspace", "stats"},
+	{"workspace", "export"},
+	{"workspace", "apply"},
+	{"workspace", "backup"},
+	{"workspace", "restore"},
 
 	// Pipeline
 	{"pipeline"},
@@ -35,11 +46,23 @@ var allCommands = [][]string{
 
 	// Board
 	{"board"},
+	{"board", "edit"},
+	{"triage"},
+	{"apply"},
+	{"history"},
+	{"undo"},
+	{"sync"},
+	{"search"},
+	{"outbox"},
+	{"outbox", "push"},
+	{"outbox", "clear"},
 
 	// Issue
 	{"issue"},
 	{"issue", "list"},
 	{"issue", "show"},
+	{"issue", "create"},
+	{"issue", "edit"},
 	{"issue", "move"},
 	{"issue", "estimate"},
 	{"issue", "close"},
@@ -47,12 +70,16 @@ var allCommands = [][]string{
 	{"issue", "connect"},
 	{"issue", "disconnect"},
 	{"issue", "block"},
+	{"issue", "unblock"},
 	{"issue", "blockers"},
 	{"issue", "blocking"},
 	{"issue", "priority"},
 	{"issue", "label"},
 	{"issue", "label", "add"},
 	{"issue", "label", "remove"},
+	{"issue", "assignee"},
+	{"issue", "assignee", "add"},
+	{"issue", "assignee", "remove"},
 	{"issue", "activity"},
 
 	// Epic
@@ -68,6 +95,7 @@ var allCommands = [][]string{
 	{"epic", "remove"},
 	{"epic", "alias"},
 	{"epic", "progress"},
+	{"epic", "forecast"},
 	{"epic", "estimate"},
 	{"epic", "assignee"},
 	{"epic", "assignee", "add"},
@@ -89,6 +117,12 @@ var allCommands = [][]string{
 	{"sprint", "velocity"},
 	{"sprint", "scope"},
 	{"sprint", "review"},
+	{"sprint", "burndown"},
+	{"sprint", "plan"},
+	{"sprint", "rollover"},
+	{"sprint", "config"},
+	{"sprint", "config", "show"},
+	{"sprint", "config", "set"},
 
 	// Utility
 	{"label"},
@@ -144,6 +178,8 @@ var dryRunCommands = [][]string{
 	{"pipeline", "delete"},
 
 	// Issue mutations
+	{"issue", "create"},
+	{"issue", "edit"},
 	{"issue", "move"},
 	{"issue", "estimate"},
 	{"issue", "close"},
@@ -151,9 +187,12 @@ var dryRunCommands = [][]string{
 	{"issue", "connect"},
 	{"issue", "disconnect"},
 	{"issue", "block"},
+	{"issue", "unblock"},
 	{"issue", "priority"},
 	{"issue", "label", "add"},
 	{"issue", "label", "remove"},
+	{"issue", "assignee", "add"},
+	{"issue", "assignee", "remove"},
 
 	// Epic mutations
 	{"epic", "create"},
@@ -174,6 +213,21 @@ var dryRunCommands = [][]string{
 	// Sprint mutations
 	{"sprint", "add"},
 	{"sprint", "remove"},
+	{"sprint", "plan"},
+	{"sprint", "rollover"},
+	{"sprint", "config", "set"},
+
+	// Board mutations
+	{"board", "edit"},
+
+	// Plans and history
+	{"apply"},
+	{"undo"},
+	{"outbox", "push"},
+
+	// Workspace configuration
+	{"workspace", "apply"},
+	{"workspace", "restore"},
 }
 
 func TestDryRunFlagRegistered(t *testing.T) {
@@ -203,6 +257,8 @@ var commandsThatShouldNotHaveDryRun = [][]string{
 	{"workspace", "show"},
 	{"workspace", "repos"},
 	{"workspace", "stats"},
+	{"workspace", "export"},
+	{"workspace", "backup"},
 	{"pipeline", "list"},
 	{"pipeline", "show"},
 	{"pipeline", "automations"},
@@ -214,15 +270,26 @@ var commandsThatShouldNotHaveDryRun = [][]string{
 	{"epic", "list"},
 	{"epic", "show"},
 	{"epic", "progress"},
+	{"epic", "forecast"},
 	{"sprint", "list"},
 	{"sprint", "show"},
 	{"sprint", "velocity"},
 	{"sprint", "scope"},
 	{"sprint", "review"},
+	{"sprint", "burndown"},
+	{"sprint", "config", "show"},
 	{"label", "list"},
 	{"priority", "list"},
 	{"board"},
+	{"triage"},
+	{"history"},
+	{"sync"},
+	{"search"},
 	{"cache", "clear"},
+	{"cache", "status"},
+	{"cache", "refresh"},
+	{"api", "graphql"},
+	{"api", "rest"},
 }
 
 func TestNoDryRunOnReadOnlyCommands(t *testing.T) {
@@ -1,11 +1,14 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"strings"
 
 	"github.com/dslh/zh/internal/api"
+	"github.com/dslh/zh/internal/cache"
 	"github.com/dslh/zh/internal/config"
 	"github.com/dslh/zh/internal/exitcode"
 	"github.com/dslh/zh/internal/output"
@@ -53,7 +56,8 @@ type boardIssueNode struct {
 		Nodes []connectedPrNode `json:"nodes"`
 	} `json:"connectedPrs"`
 	PipelineIssue *struct {
-		Priority *struct {
+		RelativePosition *int `json:"relativePosition,omitempty"`
+		Priority         *struct {
 			Name string `json:"name"`
 		} `json:"priority"`
 	} `json:"pipelineIssue"`
@@ -95,6 +99,7 @@ const boardQuery = `query GetBoard($workspaceId: ID!) {
               }
             }
             pipelineIssue(workspaceId: $workspaceId) {
+              relativePosition
               priority {
                 name
               }
@@ -188,26 +193,48 @@ var boardCmd = &cobra.Command{
 	Short: "Display all pipelines with their issues",
 	Long: `Display the workspace board showing all pipelines and their issues.
 
-Use --pipeline to filter to a single pipeline.`,
-	RunE: runBoard,
+Use --pipeline to filter to a single pipeline.
+
+With --offline, the board as it was last fetched is shown.
+
+With --output=csv, tsv or markdown, the board is written as a table with
+one row per issue and its pipeline in the first column.
+
+Use --tui to open a full-screen kanban board with pipelines as columns.
+Issues can be moved between pipelines (H/L) and reordered (J/K), opened
+in a detail pane (enter), and have their estimate (e), priority (p) and
+labels (t) edited in place. Press / to filter and q to quit.
+
+Examples:
+  zh board
+  zh board --pipeline="In Development"
+  zh board --tui
+  zh board --tui --pipeline=Review`,
+	RunE:        runBoard,
+	Annotations: map[string]string{tabularAnnotation: "true"},
 }
 
 var (
 	boardPipelineFilter string
+	boardTUI            bool
 )
 
 func init() {
 	boardCmd.Flags().StringVar(&boardPipelineFilter, "pipeline", "", "Show only the specified pipeline")
+	boardCmd.Flags().BoolVar(&boardTUI, "tui", false, "Open an interactive full-screen board")
 
 	rootCmd.AddCommand(boardCmd)
 }
 
 func resetBoardFlags() {
 	boardPipelineFilter = ""
+	boardTUI = false
 }
 
 // runBoard implements `zh board`.
 func runBoard(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+
 	cfg, err := requireWorkspace()
 	if err != nil {
 		return err
@@ -216,50 +243,32 @@ func runBoard(cmd *cobra.Command, args []string) error {
 	client := newClient(cfg, cmd)
 	w := cmd.OutOrStdout()
 
+	if boardTUI {
+		return runBoardTUI(cmd, cfg, client)
+	}
+
 	// If --pipeline is specified, use the single pipeline path
 	if boardPipelineFilter != "" {
 		return runBoardSinglePipeline(cmd, cfg, client)
 	}
 
-	// Fetch full board
-	data, err := client.Execute(boardQuery, map[string]any{
-		"workspaceId": cfg.Workspace,
-	})
-	if err != nil {
-		return exitcode.General("fetching board", err)
-	}
-
-	var resp struct {
-		Workspace struct {
-			ID                  string `json:"id"`
-			DisplayName         string `json:"displayName"`
-			PipelinesConnection struct {
-				Nodes []boardPipeline `json:"nodes"`
-			} `json:"pipelinesConnection"`
-		} `json:"workspace"`
-		SearchClosedIssues boardIssueConn `json:"searchClosedIssues"`
-	}
-	if err := json.Unmarshal(data, &resp); err != nil {
-		return exitcode.General("parsing board response", err)
+	var pipelines []boardPipeline
+	if offline {
+		pipelines, err = readOffline[[]boardPipeline](cmd, boardSnapshotKey(cfg.Workspace), "board")
+	} else {
+		pipelines, err = fetchBoard(ctx, client, cfg.Workspace)
 	}
-
-	pipelines := resp.Workspace.PipelinesConnection.Nodes
-
-	// Cache pipeline list for resolution (before adding synthetic Closed pipeline)
-	cachePipelinesFromBoard(pipelines, cfg.Workspace)
-
-	// Append synthetic "Closed" pipeline if there are closed issues
-	if resp.SearchClosedIssues.TotalCount > 0 {
-		pipelines = append(pipelines, boardPipeline{
-			ID:     "closed",
-			Name:   "Closed",
-			Issues: resp.SearchClosedIssues,
-		})
+	if err != nil {
+		return err
 	}
 
 	if output.IsJSON(outputFormat) {
 		return output.JSON(w, pipelines)
 	}
+	if output.IsTabular(outputFormat) {
+		renderBoardTable(w, pipelines)
+		return nil
+	}
 
 	if len(pipelines) == 0 {
 		fmt.Fprintln(w, "No pipelines found.")
@@ -301,8 +310,53 @@ func runBoard(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// fetchBoard fetches every pipeline with its issues, followed by a synthetic
+// "Closed" pipeline if there are closed issues. The pipelines are cached for
+// resolution, and the whole board is saved as a snapshot for --offline.
+func fetchBoard(ctx context.Context, client *api.Client, workspaceID string) ([]boardPipeline, error) {
+	data, err := client.Execute(ctx, boardQuery, map[string]any{
+		"workspaceId": workspaceID,
+	})
+	if err != nil {
+		return nil, exitcode.General("fetching board", err)
+	}
+
+	var resp struct {
+		Workspace struct {
+			ID                  string `json:"id"`
+			DisplayName         string `json:"displayName"`
+			PipelinesConnection struct {
+				Nodes []boardPipeline `json:"nodes"`
+			} `json:"pipelinesConnection"`
+		} `json:"workspace"`
+		SearchClosedIssues boardIssueConn `json:"searchClosedIssues"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, exitcode.General("parsing board response", err)
+	}
+
+	pipelines := resp.Workspace.PipelinesConnection.Nodes
+
+	// Cache pipeline list for resolution (before adding synthetic Closed pipeline)
+	cachePipelinesFromBoard(pipelines, workspaceID)
+
+	// Append synthetic "Closed" pipeline if there are closed issues
+	if resp.SearchClosedIssues.TotalCount > 0 {
+		pipelines = append(pipelines, boardPipeline{
+			ID:     "closed",
+			Name:   "Closed",
+			Issues: resp.SearchClosedIssues,
+		})
+	}
+
+	_ = cache.Set(boardSnapshotKey(workspaceID), pipelines)
+	return pipelines, nil
+}
+
 // runBoardSinglePipeline fetches and displays a single pipeline when --pipeline is used.
 func runBoardSinglePipeline(cmd *cobra.Command, cfg *config.Config, client *api.Client) error {
+	ctx := commandContext(cmd)
+
 	w := cmd.OutOrStdout()
 
 	// Handle virtual "Closed" pipeline
@@ -311,13 +365,13 @@ func runBoardSinglePipeline(cmd *cobra.Command, cfg *config.Config, client *api.
 	}
 
 	// Resolve the pipeline
-	resolved, err := resolve.Pipeline(client, cfg.Workspace, boardPipelineFilter, cfg.Aliases.Pipelines)
+	resolved, err := resolve.Pipeline(ctx, client, cfg.Workspace, boardPipelineFilter, cfg.Aliases.Pipelines)
 	if err != nil {
 		return err
 	}
 
 	// Fetch issues using the existing pipeline issues query
-	issues, totalCount, err := fetchPipelineIssues(client, resolved.ID, cfg.Workspace, 100)
+	issues, totalCount, err := fetchPipelineIssues(ctx, client, resolved.ID, cfg.Workspace, 100)
 	if err != nil {
 		return err
 	}
@@ -350,6 +404,18 @@ func runBoardSinglePipeline(cmd *cobra.Command, cfg *config.Config, client *api.
 		}
 		return output.JSON(w, jsonOut)
 	}
+	if output.IsTabular(outputFormat) {
+		nodes := make([]boardIssueNode, len(issues))
+		for i, issue := range issues {
+			nodes[i] = boardIssueFromPipelineNode(issue)
+		}
+		renderBoardTable(w, []boardPipeline{{
+			ID:     resolved.ID,
+			Name:   resolved.Name,
+			Issues: boardIssueConn{TotalCount: totalCount, Nodes: nodes},
+		}})
+		return nil
+	}
 
 	needLongRef := repoNamesAmbiguous(issues)
 
@@ -382,9 +448,11 @@ func runBoardSinglePipeline(cmd *cobra.Command, cfg *config.Config, client *api.
 
 // runBoardClosedPipeline fetches and displays the virtual "Closed" pipeline.
 func runBoardClosedPipeline(cmd *cobra.Command, cfg *config.Config, client *api.Client) error {
+	ctx := commandContext(cmd)
+
 	w := cmd.OutOrStdout()
 
-	data, err := client.Execute(closedIssuesQuery, map[string]any{
+	data, err := client.Execute(ctx, closedIssuesQuery, map[string]any{
 		"workspaceId": cfg.Workspace,
 	})
 	if err != nil {
@@ -418,6 +486,10 @@ func runBoardClosedPipeline(cmd *cobra.Command, cfg *config.Config, client *api.
 		}
 		return output.JSON(w, jsonOut)
 	}
+	if output.IsTabular(outputFormat) {
+		renderBoardTable(w, []boardPipeline{{ID: "closed", Name: "Closed", Issues: resp.SearchClosedIssues}})
+		return nil
+	}
 
 	needLongRef := boardIssueRepoNamesAmbiguous(issues)
 
@@ -562,6 +634,71 @@ func renderBoardIssueFromPipelineNode(w interface{ Write([]byte) (int, error) },
 	fmt.Fprintf(w, "  %s  %s%s%s\n", output.Cyan(ref), title, output.Dim(est), output.Dim(assignee))
 }
 
+// renderBoardTable writes the board as a table with one row per issue, for
+// --output=csv, tsv or markdown.
+func renderBoardTable(w io.Writer, pipelines []boardPipeline) {
+	needLongRef := boardRepoNamesAmbiguous(pipelines)
+
+	lw := output.NewListWriter(w, "PIPELINE", "ISSUE", "TITLE", "EST", "ASSIGNEE", "LABELS", "PRIORITY")
+	totalIssues := 0
+	for _, p := range pipelines {
+		for _, issue := range p.Issues.Nodes {
+			est := output.TableMissing
+			if issue.Estimate != nil {
+				est = formatEstimate(issue.Estimate.Value)
+			}
+
+			assignee := output.TableMissing
+			if len(issue.Assignees.Nodes) > 0 {
+				logins := make([]string, len(issue.Assignees.Nodes))
+				for i, a := range issue.Assignees.Nodes {
+					logins[i] = a.Login
+				}
+				assignee = strings.Join(logins, ", ")
+			}
+
+			labels := output.TableMissing
+			if len(issue.Labels.Nodes) > 0 {
+				names := make([]string, len(issue.Labels.Nodes))
+				for i, l := range issue.Labels.Nodes {
+					names[i] = l.Name
+				}
+				labels = strings.Join(names, ", ")
+			}
+
+			priority := output.TableMissing
+			if issue.PipelineIssue != nil && issue.PipelineIssue.Priority != nil {
+				priority = issue.PipelineIssue.Priority.Name
+			}
+
+			lw.Row(p.Name, boardFormatIssueRef(issue, needLongRef), issue.Title, est, assignee, labels, priority)
+		}
+		totalIssues += p.Issues.TotalCount
+	}
+	lw.FlushWithFooter(fmt.Sprintf("%d pipeline(s), %d issue(s)", len(pipelines), totalIssues))
+}
+
+// boardIssueFromPipelineNode converts an issue from the pipeline issues
+// query into a board issue.
+func boardIssueFromPipelineNode(n pipelineIssueNode) boardIssueNode {
+	issue := boardIssueNode{
+		ID:           n.ID,
+		Number:       n.Number,
+		Title:        n.Title,
+		State:        n.State,
+		PullRequest:  n.PullRequest,
+		Estimate:     n.Estimate,
+		Repository:   n.Repository,
+		Assignees:    n.Assignees,
+		Labels:       n.Labels,
+		ConnectedPrs: n.ConnectedPrs,
+	}
+	if n.PipelineIssue != nil && n.PipelineIssue.Priority != nil {
+		setBoardIssuePriority(&issue, n.PipelineIssue.Priority.Name)
+	}
+	return issue
+}
+
 // boardFormatIssueRef formats an issue reference for board display.
 func boardFormatIssueRef(issue boardIssueNode, longForm bool) string {
 	if longForm {
@@ -597,3 +734,9 @@ func cachePipelinesFromBoard(pipelines []boardPipeline, workspaceID string) {
 	}
 	_ = resolve.FetchPipelinesIntoCache(entries, workspaceID)
 }
+
+// boardSnapshotKey is the cache key of the last board fetched by `zh board`,
+// which is shown when offline.
+func boardSnapshotKey(workspaceID string) cache.Key {
+	return cache.NewScopedKey("board", workspaceID)
+}
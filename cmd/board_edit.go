@@ -0,0 +1,496 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/dslh/zh/internal/api"
+	"github.com/dslh/zh/internal/exitcode"
+	"github.com/dslh/zh/internal/history"
+	"github.com/dslh/zh/internal/output"
+	"github.com/dslh/zh/internal/resolve"
+	"github.com/spf13/cobra"
+)
+
+// Flag variables for board edit
+var (
+	boardEditPipelines []string
+	boardEditDryRun    bool
+)
+
+var boardEditCmd = &cobra.Command{
+	Use:   "edit",
+	Short: "Rearrange the board in your editor",
+	Long: `Open the board in $EDITOR as a plain text file and apply the changes.
+
+Each pipeline is a "## Pipeline Name" heading followed by one line per
+issue, in board order. Reorder lines to reprioritize issues, or move a line
+under another heading to move the issue to that pipeline. A heading can be
+added for any pipeline in the workspace; issues listed under a pipeline
+that was not in the file are moved to the top of it.
+
+Every issue must stay in the file — to leave an issue where it is, leave
+its line alone. Text after the issue reference is ignored, as are other
+lines starting with '#'. Saving an empty file aborts without making changes.
+
+Every open issue in the included pipelines is listed, including those
+beyond the first 100 that zh board shows.
+
+Examples:
+  zh board edit
+  zh board edit --pipeline="New Issues" --pipeline=Backlog
+  zh board edit --dry-run`,
+	Args: cobra.NoArgs,
+	RunE: runBoardEdit,
+}
+
+func init() {
+	boardEditCmd.Flags().StringSliceVar(&boardEditPipelines, "pipeline", nil, "Only include these pipelines (repeatable)")
+	boardEditCmd.Flags().BoolVar(&boardEditDryRun, "dry-run", false, "Show the moves that would be made without executing them")
+	boardCmd.AddCommand(boardEditCmd)
+}
+
+func resetBoardEditFlags() {
+	boardEditPipelines = nil
+	boardEditDryRun = false
+}
+
+// boardEditMove is a single MoveIssue call needed to turn the board into the
+// edited layout.
+type boardEditMove struct {
+	issue        boardIssueNode
+	ref          string
+	fromPipeline boardTUIColumn
+	toPipeline   boardTUIColumn
+	position     int    // 0-based position in the target pipeline when applied
+	after        string // ref of the issue it follows, or "" for the top
+}
+
+func (m boardEditMove) context() string {
+	where := "at top"
+	if m.after != "" {
+		where = "after " + m.after
+	}
+	if m.fromPipeline.id == m.toPipeline.id {
+		return fmt.Sprintf("(reordered in %q, %s)", m.toPipeline.name, where)
+	}
+	return fmt.Sprintf("(%q → %q, %s)", m.fromPipeline.name, m.toPipeline.name, where)
+}
+
+const boardEditPipelineIssuesQuery = `query BoardEditPipelineIssues($pipelineId: ID!, $workspaceId: ID!, $after: String) {
+  searchIssuesByPipeline(pipelineId: $pipelineId, filters: {}, first: 100, after: $after) {
+    pageInfo {
+      hasNextPage
+      endCursor
+    }
+    nodes {
+      id
+      number
+      title
+      state
+      pullRequest
+      repository {
+        name
+        ownerName
+      }
+      pipelineIssue(workspaceId: $workspaceId) {
+        relativePosition
+      }
+    }
+  }
+}`
+
+func runBoardEdit(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+
+	cfg, err := requireWorkspace()
+	if err != nil {
+		return err
+	}
+
+	client := newClient(cfg, cmd)
+	w := cmd.OutOrStdout()
+
+	all, err := fetchBoardTUIColumns(ctx, client, cfg.Workspace)
+	if err != nil {
+		return err
+	}
+
+	columns := all
+	if len(boardEditPipelines) > 0 {
+		columns = nil
+		for _, name := range boardEditPipelines {
+			resolved, err := resolve.Pipeline(ctx, client, cfg.Workspace, name, cfg.Aliases.Pipelines)
+			if err != nil {
+				return err
+			}
+			for _, c := range all {
+				if c.id == resolved.ID && !slices.ContainsFunc(columns, func(e boardTUIColumn) bool { return e.id == c.id }) {
+					columns = append(columns, c)
+				}
+			}
+		}
+	}
+
+	if err := fetchBoardEditRemainder(ctx, client, cfg.Workspace, columns); err != nil {
+		return err
+	}
+
+	longRef := boardEditNeedsLongRef(columns)
+	original := formatBoardEditBuffer(columns, longRef)
+
+	edited, err := editFunc(original, "zh-board-*.md")
+	if err != nil {
+		return exitcode.General("editing board", err)
+	}
+	if strings.TrimSpace(stripBoardEditComments(edited)) == "" {
+		fmt.Fprintln(w, "Aborted: the board file is empty. No changes made.")
+		return nil
+	}
+
+	layout, err := parseBoardEditBuffer(edited, columns, longRef, func(name string) (boardTUIColumn, error) {
+		resolved, err := resolve.Pipeline(ctx, client, cfg.Workspace, name, cfg.Aliases.Pipelines)
+		if err != nil {
+			return boardTUIColumn{}, err
+		}
+		return boardTUIColumn{id: resolved.ID, name: resolved.Name}, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	moves := planBoardEditMoves(columns, layout, longRef)
+	if len(moves) == 0 {
+		fmt.Fprintln(w, "No changes to the board.")
+		return nil
+	}
+
+	if boardEditDryRun {
+		items := make([]output.MutationItem, len(moves))
+		for i, m := range moves {
+			items[i] = output.MutationItem{
+				Ref:     m.ref,
+				Title:   truncateTitle(m.issue.Title),
+				Context: m.context(),
+			}
+		}
+		output.MutationDryRun(w, fmt.Sprintf("Would move %d issue(s):", len(moves)), items)
+		return nil
+	}
+
+	var succeeded []output.MutationItem
+	var failed []output.FailedItem
+	var changes []history.Change
+	for i, m := range moves {
+		err := executeMoveIssue(ctx, client, resolvedMoveIssue{
+			IssueID:   m.issue.ID,
+			Number:    m.issue.Number,
+			Title:     m.issue.Title,
+			RepoName:  m.issue.Repository.Name,
+			RepoOwner: m.issue.Repository.OwnerName,
+		}, m.toPipeline.id, posNumeric, m.position)
+		if err != nil {
+			// Later positions assume earlier moves happened, so stop here
+			failed = append(failed, output.FailedItem{Ref: m.ref, Reason: err.Error()})
+			for _, rest := range moves[i+1:] {
+				failed = append(failed, output.FailedItem{Ref: rest.ref, Reason: "skipped after earlier failure"})
+			}
+			break
+		}
+		succeeded = append(succeeded, output.MutationItem{
+			Ref:     m.ref,
+			Title:   truncateTitle(m.issue.Title),
+			Context: m.context(),
+		})
+		changes = append(changes, boardMoveChange(m.issue, m.fromPipeline, m.toPipeline))
+	}
+
+	recordHistory(cmd.ErrOrStderr(), cfg.Workspace, "board edit", fmt.Sprintf("Moved %d issue(s)", len(changes)), changes)
+
+	if output.IsJSON(outputFormat) {
+		if err := output.JSON(w, map[string]any{
+			"moved":  succeeded,
+			"failed": failed,
+		}); err != nil {
+			return err
+		}
+	} else {
+		header := output.Green(fmt.Sprintf("Moved %d issue(s).", len(succeeded)))
+		if len(failed) > 0 {
+			output.MutationPartialFailure(w, header, succeeded, failed)
+		} else {
+			output.MutationBatch(w, header, succeeded)
+		}
+	}
+
+	if len(failed) > 0 {
+		return exitcode.Generalf("%d of %d move(s) failed", len(failed), len(moves))
+	}
+	return nil
+}
+
+// boardEditNeedsLongRef reports whether issue refs need the owner to be
+// unambiguous.
+func boardEditNeedsLongRef(columns []boardTUIColumn) bool {
+	pipelines := make([]boardPipeline, len(columns))
+	for i, c := range columns {
+		pipelines[i].Issues.Nodes = c.issues
+	}
+	return boardRepoNamesAmbiguous(pipelines)
+}
+
+func boardEditRef(issue boardIssueNode, longRef bool) string {
+	if longRef {
+		return fmt.Sprintf("%s/%s#%d", issue.Repository.OwnerName, issue.Repository.Name, issue.Number)
+	}
+	return fmt.Sprintf("%s#%d", issue.Repository.Name, issue.Number)
+}
+
+// formatBoardEditBuffer renders the pipelines as the text file opened in the
+// editor.
+func formatBoardEditBuffer(columns []boardTUIColumn, longRef bool) string {
+	var b strings.Builder
+	b.WriteString("# Reorder lines to reprioritize issues, or move them under another\n")
+	b.WriteString("# pipeline heading to move them. Every issue must stay in the file.\n")
+	b.WriteString("# Other lines starting with '#' are ignored. Save an empty file to abort.\n")
+	for _, c := range columns {
+		fmt.Fprintf(&b, "\n## %s\n", c.name)
+		for _, issue := range c.issues {
+			fmt.Fprintf(&b, "%s %s\n", boardEditRef(issue, longRef), issue.Title)
+		}
+	}
+	return b.String()
+}
+
+// stripBoardEditComments removes comment lines, leaving headings and issues.
+func stripBoardEditComments(content string) string {
+	var kept []string
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "#") && !strings.HasPrefix(trimmed, "## ") {
+			continue
+		}
+		kept = append(kept, line)
+	}
+	return strings.Join(kept, "\n")
+}
+
+// boardEditLayout is the edited board: pipelines in file order, each with
+// the issues listed under it.
+type boardEditLayout []struct {
+	pipeline boardTUIColumn
+	issues   []boardIssueNode
+}
+
+// parseBoardEditBuffer parses an edited board file. Headings that don't name
+// one of the original pipelines are looked up with resolvePipeline.
+func parseBoardEditBuffer(content string, columns []boardTUIColumn, longRef bool, resolvePipeline func(string) (boardTUIColumn, error)) (boardEditLayout, error) {
+	issues := map[string]boardIssueNode{}
+	for _, c := range columns {
+		for _, issue := range c.issues {
+			issues[strings.ToLower(boardEditRef(issue, longRef))] = issue
+		}
+	}
+
+	var layout boardEditLayout
+	seenPipelines := map[string]bool{}
+	seenIssues := map[string]int{}
+
+	for i, line := range strings.Split(content, "\n") {
+		lineNum := i + 1
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continue
+		}
+
+		if name, ok := strings.CutPrefix(trimmed, "## "); ok {
+			name = strings.TrimSpace(name)
+			pipeline, err := boardEditPipeline(name, columns, resolvePipeline)
+			if err != nil {
+				return nil, err
+			}
+			if seenPipelines[pipeline.id] {
+				return nil, exitcode.Usage(fmt.Sprintf("line %d: pipeline %q appears more than once", lineNum, pipeline.name))
+			}
+			seenPipelines[pipeline.id] = true
+			layout = append(layout, struct {
+				pipeline boardTUIColumn
+				issues   []boardIssueNode
+			}{pipeline: pipeline})
+			continue
+		}
+		if strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		ref, _, _ := strings.Cut(trimmed, " ")
+		issue, ok := issues[strings.ToLower(ref)]
+		if !ok {
+			return nil, exitcode.Usage(fmt.Sprintf("line %d: %q is not one of the issues in the file", lineNum, ref))
+		}
+		if prev, dup := seenIssues[issue.ID]; dup {
+			return nil, exitcode.Usage(fmt.Sprintf("line %d: %s is already listed on line %d", lineNum, ref, prev))
+		}
+		if len(layout) == 0 {
+			return nil, exitcode.Usage(fmt.Sprintf("line %d: %s is not under a pipeline heading", lineNum, ref))
+		}
+		seenIssues[issue.ID] = lineNum
+		layout[len(layout)-1].issues = append(layout[len(layout)-1].issues, issue)
+	}
+
+	var missing []string
+	for _, c := range columns {
+		for _, issue := range c.issues {
+			if _, ok := seenIssues[issue.ID]; !ok {
+				missing = append(missing, boardEditRef(issue, longRef))
+			}
+		}
+	}
+	if len(missing) > 0 {
+		return nil, exitcode.Usage(fmt.Sprintf("issue(s) missing from the edited file: %s — issues can be moved but not removed", strings.Join(missing, ", ")))
+	}
+
+	return layout, nil
+}
+
+func boardEditPipeline(name string, columns []boardTUIColumn, resolvePipeline func(string) (boardTUIColumn, error)) (boardTUIColumn, error) {
+	for _, c := range columns {
+		if strings.EqualFold(c.name, name) {
+			return boardTUIColumn{id: c.id, name: c.name}, nil
+		}
+	}
+	return resolvePipeline(name)
+}
+
+// planBoardEditMoves works out which issues need to move, and to which
+// position, to turn the original columns into the edited layout.
+//
+// Within each pipeline, the longest run of issues that stay in the same
+// relative order is left alone; every other issue is moved to sit directly
+// after its predecessor in the edited order. Moves are simulated against a
+// copy of the board so that each position accounts for the moves before it.
+func planBoardEditMoves(columns []boardTUIColumn, layout boardEditLayout, longRef bool) []boardEditMove {
+	// Current contents of each pipeline, updated as moves are planned
+	board := map[string][]string{}
+	from := map[string]boardTUIColumn{}
+	for _, c := range columns {
+		for _, issue := range c.issues {
+			board[c.id] = append(board[c.id], issue.ID)
+			from[issue.ID] = c
+		}
+	}
+
+	var moves []boardEditMove
+	for _, entry := range layout {
+		target := entry.pipeline
+
+		// Original indexes of the issues that were already in this pipeline
+		var staying []int
+		var stayingIDs []string
+		for _, issue := range entry.issues {
+			if from[issue.ID].id == target.id {
+				staying = append(staying, slices.Index(board[target.id], issue.ID))
+				stayingIDs = append(stayingIDs, issue.ID)
+			}
+		}
+		anchored := map[string]bool{}
+		for _, idx := range longestIncreasingSubsequence(staying) {
+			anchored[stayingIDs[idx]] = true
+		}
+
+		for i, issue := range entry.issues {
+			if anchored[issue.ID] {
+				continue
+			}
+
+			source := from[issue.ID]
+			board[source.id] = slices.DeleteFunc(board[source.id], func(id string) bool { return id == issue.ID })
+
+			position, after := 0, ""
+			if i > 0 {
+				prev := entry.issues[i-1]
+				position = slices.Index(board[target.id], prev.ID) + 1
+				after = boardEditRef(prev, longRef)
+			}
+			board[target.id] = slices.Insert(board[target.id], position, issue.ID)
+
+			moves = append(moves, boardEditMove{
+				issue:        issue,
+				ref:          boardEditRef(issue, longRef),
+				fromPipeline: source,
+				toPipeline:   target,
+				position:     position,
+				after:        after,
+			})
+		}
+	}
+	return moves
+}
+
+// longestIncreasingSubsequence returns the indexes into values of one of its
+// longest strictly increasing subsequences.
+func longestIncreasingSubsequence(values []int) []int {
+	if len(values) == 0 {
+		return nil
+	}
+	// tails[k] is the index of the smallest tail of an increasing
+	// subsequence of length k+1; prev links each index to its predecessor.
+	var tails []int
+	prev := make([]int, len(values))
+	for i, v := range values {
+		k, _ := slices.BinarySearchFunc(tails, v, func(idx, target int) int {
+			return values[idx] - target
+		})
+		if k > 0 {
+			prev[i] = tails[k-1]
+		} else {
+			prev[i] = -1
+		}
+		if k == len(tails) {
+			tails = append(tails, i)
+		} else {
+			tails[k] = i
+		}
+	}
+
+	result := make([]int, len(tails))
+	for i, k := len(tails)-1, tails[len(tails)-1]; i >= 0; i, k = i-1, prev[k] {
+		result[i] = k
+	}
+	return result
+}
+
+// fetchBoardEditRemainder refetches, page by page, every pipeline with more
+// open issues than the board query returned. Moves are planned by position,
+// so the file must list each pipeline in full.
+func fetchBoardEditRemainder(ctx context.Context, client *api.Client, workspaceID string, columns []boardTUIColumn) error {
+	for i, c := range columns {
+		if c.total <= len(c.issues) {
+			continue
+		}
+
+		var issues []boardIssueNode
+		vars := map[string]any{"pipelineId": c.id, "workspaceId": workspaceID}
+		err := forEachQueryPage(ctx, client, boardEditPipelineIssuesQuery, vars, "issues in "+c.name, func(data json.RawMessage) (pageInfoNode, error) {
+			var resp struct {
+				SearchIssuesByPipeline struct {
+					PageInfo pageInfoNode     `json:"pageInfo"`
+					Nodes    []boardIssueNode `json:"nodes"`
+				} `json:"searchIssuesByPipeline"`
+			}
+			if err := json.Unmarshal(data, &resp); err != nil {
+				return pageInfoNode{}, err
+			}
+			issues = append(issues, resp.SearchIssuesByPipeline.Nodes...)
+			return resp.SearchIssuesByPipeline.PageInfo, nil
+		})
+		if err != nil {
+			return err
+		}
+		columns[i].issues = issues
+	}
+	return nil
+}
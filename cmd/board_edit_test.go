@@ -0,0 +1,518 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/dslh/zh/internal/exitcode"
+	"github.com/dslh/zh/internal/history"
+	"github.com/dslh/zh/internal/testutil"
+)
+
+// boardEditTestColumns builds columns of bare issues from repo#number refs.
+func boardEditTestColumns() []boardTUIColumn {
+	issue := func(id string, number int, repo string) boardIssueNode {
+		var n boardIssueNode
+		n.ID = id
+		n.Number = number
+		n.Title = "Issue " + id
+		n.Repository.Name = repo
+		n.Repository.OwnerName = "dlakehammond"
+		return n
+	}
+	return []boardTUIColumn{
+		{id: "p1", name: "New Issues", issues: []boardIssueNode{
+			issue("a", 1, "api"), issue("b", 2, "api"), issue("c", 3, "api"),
+		}},
+		{id: "p2", name: "In Development", issues: []boardIssueNode{
+			issue("d", 4, "api"), issue("e", 5, "api"),
+		}},
+	}
+}
+
+func noPipelineLookup(name string) (boardTUIColumn, error) {
+	return boardTUIColumn{}, exitcode.NotFoundError(fmt.Sprintf("pipeline %q not found", name))
+}
+
+func planFromBuffer(t *testing.T, content string) []boardEditMove {
+	t.Helper()
+	columns := boardEditTestColumns()
+	layout, err := parseBoardEditBuffer(content, columns, false, noPipelineLookup)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	return planBoardEditMoves(columns, layout, false)
+}
+
+// applyBoardEditMoves replays moves against the columns the way ZenHub would
+// and returns the resulting issue IDs per pipeline.
+func applyBoardEditMoves(columns []boardTUIColumn, moves []boardEditMove) map[string][]string {
+	board := map[string][]string{}
+	for _, c := range columns {
+		for _, issue := range c.issues {
+			board[c.id] = append(board[c.id], issue.ID)
+		}
+	}
+	for _, m := range moves {
+		for id, issues := range board {
+			board[id] = slices.DeleteFunc(issues, func(i string) bool { return i == m.issue.ID })
+		}
+		board[m.toPipeline.id] = slices.Insert(board[m.toPipeline.id], m.position, m.issue.ID)
+	}
+	return board
+}
+
+func TestFormatBoardEditBuffer(t *testing.T) {
+	got := formatBoardEditBuffer(boardEditTestColumns(), false)
+
+	for _, want := range []string{
+		"\n## New Issues\napi#1 Issue a\napi#2 Issue b\napi#3 Issue c\n",
+		"\n## In Development\napi#4 Issue d\napi#5 Issue e\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("buffer should contain %q, got:\n%s", want, got)
+		}
+	}
+
+	long := formatBoardEditBuffer(boardEditTestColumns(), true)
+	if !strings.Contains(long, "dlakehammond/api#1 Issue a") {
+		t.Errorf("long refs should include the owner, got:\n%s", long)
+	}
+}
+
+func TestPlanBoardEditUnchanged(t *testing.T) {
+	moves := planFromBuffer(t, formatBoardEditBuffer(boardEditTestColumns(), false))
+	if len(moves) != 0 {
+		t.Errorf("unchanged buffer should need no moves, got %+v", moves)
+	}
+}
+
+func TestPlanBoardEditMoves(t *testing.T) {
+	tests := []struct {
+		name      string
+		buffer    string
+		wantMoves int
+		want      map[string][]string
+	}{
+		{
+			name:      "move to top",
+			buffer:    "## New Issues\napi#3\napi#1\napi#2\n## In Development\napi#4\napi#5\n",
+			wantMoves: 1,
+			want:      map[string][]string{"p1": {"c", "a", "b"}, "p2": {"d", "e"}},
+		},
+		{
+			name:      "reverse",
+			buffer:    "## New Issues\napi#3\napi#2\napi#1\n## In Development\napi#4\napi#5\n",
+			wantMoves: 2,
+			want:      map[string][]string{"p1": {"c", "b", "a"}, "p2": {"d", "e"}},
+		},
+		{
+			name:      "across pipelines both ways",
+			buffer:    "## New Issues\napi#1\napi#5\napi#3\n## In Development\napi#2\napi#4\n",
+			wantMoves: 2,
+			want:      map[string][]string{"p1": {"a", "e", "c"}, "p2": {"b", "d"}},
+		},
+		{
+			name:      "headings reordered",
+			buffer:    "## In Development\napi#1\napi#4\napi#5\n## New Issues\napi#2\napi#3\n",
+			wantMoves: 1,
+			want:      map[string][]string{"p1": {"b", "c"}, "p2": {"a", "d", "e"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			moves := planFromBuffer(t, tt.buffer)
+			if len(moves) != tt.wantMoves {
+				t.Errorf("got %d moves, want %d: %+v", len(moves), tt.wantMoves, moves)
+			}
+			got := applyBoardEditMoves(boardEditTestColumns(), moves)
+			for id, want := range tt.want {
+				if !slices.Equal(got[id], want) {
+					t.Errorf("pipeline %s = %v, want %v", id, got[id], want)
+				}
+			}
+		})
+	}
+}
+
+func TestPlanBoardEditNewPipeline(t *testing.T) {
+	columns := boardEditTestColumns()
+	lookup := func(name string) (boardTUIColumn, error) {
+		return boardTUIColumn{id: "p3", name: "Done"}, nil
+	}
+	layout, err := parseBoardEditBuffer("## New Issues\napi#2\n## In Development\napi#4\napi#5\n## done\napi#3\napi#1\n", columns, false, lookup)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	moves := planBoardEditMoves(columns, layout, false)
+
+	got := applyBoardEditMoves(columns, moves)
+	if !slices.Equal(got["p3"], []string{"c", "a"}) {
+		t.Errorf("Done = %v, want [c a]", got["p3"])
+	}
+	if moves[0].context() != `("New Issues" → "Done", at top)` {
+		t.Errorf("unexpected context: %s", moves[0].context())
+	}
+	if moves[1].context() != `("New Issues" → "Done", after api#3)` {
+		t.Errorf("unexpected context: %s", moves[1].context())
+	}
+}
+
+func TestParseBoardEditBufferErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		buffer string
+		want   string
+	}{
+		{"unknown issue", "## New Issues\napi#1\napi#2\napi#3\napi#9\n## In Development\napi#4\napi#5\n", `"api#9" is not one of the issues`},
+		{"duplicate issue", "## New Issues\napi#1\napi#2\napi#3\napi#1\n## In Development\napi#4\napi#5\n", "api#1 is already listed on line 2"},
+		{"missing issue", "## New Issues\napi#1\napi#2\n## In Development\napi#4\napi#5\n", "missing from the edited file: api#3"},
+		{"no heading", "api#1\n## New Issues\napi#2\napi#3\n## In Development\napi#4\napi#5\n", "not under a pipeline heading"},
+		{"duplicate heading", "## New Issues\napi#1\napi#2\napi#3\n## new issues\n## In Development\napi#4\napi#5\n", `"New Issues" appears more than once`},
+		{"unknown pipeline", "## Nowhere\napi#1\n", `pipeline "Nowhere" not found`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseBoardEditBuffer(tt.buffer, boardEditTestColumns(), false, noPipelineLookup)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %q, want it to contain %q", err.Error(), tt.want)
+			}
+		})
+	}
+}
+
+func TestLongestIncreasingSubsequence(t *testing.T) {
+	tests := []struct {
+		values []int
+		want   int
+	}{
+		{nil, 0},
+		{[]int{0, 1, 2}, 3},
+		{[]int{2, 1, 0}, 1},
+		{[]int{2, 0, 1, 3}, 3},
+		{[]int{20, 30, 10, 40}, 3},
+		{[]int{5, 1, 6, 2, 3}, 3},
+	}
+	for _, tt := range tests {
+		got := longestIncreasingSubsequence(tt.values)
+		if len(got) != tt.want {
+			t.Errorf("LIS(%v) = %v, want length %d", tt.values, got, tt.want)
+		}
+		for i := 1; i < len(got); i++ {
+			if got[i] <= got[i-1] || tt.values[got[i]] <= tt.values[got[i-1]] {
+				t.Errorf("LIS(%v) = %v is not increasing", tt.values, got)
+			}
+		}
+	}
+}
+
+// setupBoardEditServer serves the board and records MoveIssue variables.
+func setupBoardEditServer(t *testing.T) (*testutil.MockServer, *[]map[string]any) {
+	t.Helper()
+	ms := testutil.NewMockServer(t)
+	ms.HandleQuery("GetBoard", boardResponse())
+	ms.HandleQuery("ListPipelines", pipelineResolutionResponse())
+
+	var inputs []map[string]any
+	ms.Handle(
+		func(req testutil.GraphQLRequest) bool {
+			return strings.Contains(req.Query, "MoveIssue")
+		},
+		func(w http.ResponseWriter, req testutil.GraphQLRequest) {
+			inputs = append(inputs, mutationInputVar(req))
+			_, _ = w.Write([]byte(`{"data":{"moveIssue":{"issue":{"id":"i"},"pipeline":{"id":"p","name":"P"}}}}`))
+		},
+	)
+	setupBoardTUITestEnv(t, ms)
+	return ms, &inputs
+}
+
+func stubBoardEditor(t *testing.T, edit func(string) string) *string {
+	t.Helper()
+	var seen string
+	origEdit := editFunc
+	editFunc = func(content, pattern string) (string, error) {
+		seen = content
+		return edit(content), nil
+	}
+	t.Cleanup(func() { editFunc = origEdit })
+	return &seen
+}
+
+// boardEditSwap moves recipe-book#2 to the top of In Development, with
+// task-tracker#1 from New Issues under it.
+func boardEditSwap(content string) string {
+	content = strings.Replace(content, "task-tracker#1 Fix login button\n", "", 1)
+	content = strings.Replace(content, "recipe-book#2 Fix recipe validation\n", "", 1)
+	return strings.Replace(content, "## In Development\n", "## In Development\nrecipe-book#2 Fix recipe validation\ntask-tracker#1 Fix login button\n", 1)
+}
+
+func TestBoardEdit(t *testing.T) {
+	resetBoardFlags()
+	resetBoardEditFlags()
+	setupHistoryDataDir(t)
+
+	_, inputs := setupBoardEditServer(t)
+	seen := stubBoardEditor(t, boardEditSwap)
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"board", "edit"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("board edit returned error: %v", err)
+	}
+
+	if !strings.Contains(*seen, "## New Issues\ntask-tracker#1 Fix login button\n") {
+		t.Errorf("editor buffer should list the board, got:\n%s", *seen)
+	}
+
+	if len(*inputs) != 2 {
+		t.Fatalf("expected 2 MoveIssue calls, got %d: %v", len(*inputs), *inputs)
+	}
+	first, second := (*inputs)[0], (*inputs)[1]
+	if first["issueId"] != "i3" || first["pipelineId"] != "p2" || first["position"] != float64(0) {
+		t.Errorf("first move = %v, want i3 to p2 at 0", first)
+	}
+	if second["issueId"] != "i1" || second["pipelineId"] != "p2" || second["position"] != float64(1) {
+		t.Errorf("second move = %v, want i1 to p2 at 1", second)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "Moved 2 issue(s).") {
+		t.Errorf("output should confirm the moves, got: %s", out)
+	}
+
+	entries, _ := history.Load("ws-123")
+	if len(entries) != 1 || entries[0].Command != "board edit" || len(entries[0].Changes) != 2 {
+		t.Fatalf("expected one recorded board edit with 2 changes, got %+v", entries)
+	}
+	if c := entries[0].Changes[1]; c.Kind != history.KindMove || c.Issue.ID != "i1" || c.Before.ID != "p1" || c.After.ID != "p2" {
+		t.Errorf("unexpected change: %+v", c)
+	}
+}
+
+func TestBoardEditDryRun(t *testing.T) {
+	resetBoardFlags()
+	resetBoardEditFlags()
+
+	_, inputs := setupBoardEditServer(t)
+	stubBoardEditor(t, func(content string) string {
+		return strings.Replace(content, "task-tracker#1 Fix login button\n", "", 1) + "task-tracker#1\n"
+	})
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"board", "edit", "--dry-run"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("board edit --dry-run returned error: %v", err)
+	}
+
+	if len(*inputs) != 0 {
+		t.Errorf("dry run should not move issues, got %v", *inputs)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "Would move 1 issue(s):") {
+		t.Errorf("output should contain dry-run header, got: %s", out)
+	}
+	if !strings.Contains(out, `task-tracker#1 Fix login button ("New Issues" → "Done", at top)`) {
+		t.Errorf("output should describe the move, got: %s", out)
+	}
+}
+
+func TestBoardEditNoChanges(t *testing.T) {
+	resetBoardFlags()
+	resetBoardEditFlags()
+
+	_, inputs := setupBoardEditServer(t)
+	stubBoardEditor(t, func(content string) string { return content })
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"board", "edit"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("board edit returned error: %v", err)
+	}
+	if len(*inputs) != 0 {
+		t.Errorf("unchanged board should not move issues, got %v", *inputs)
+	}
+	if !strings.Contains(buf.String(), "No changes to the board.") {
+		t.Errorf("unexpected output: %s", buf.String())
+	}
+}
+
+func TestBoardEditEmptyFileAborts(t *testing.T) {
+	resetBoardFlags()
+	resetBoardEditFlags()
+
+	_, inputs := setupBoardEditServer(t)
+	stubBoardEditor(t, func(content string) string { return "# nothing here\n" })
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"board", "edit"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("board edit returned error: %v", err)
+	}
+	if len(*inputs) != 0 {
+		t.Errorf("aborted edit should not move issues, got %v", *inputs)
+	}
+	if !strings.Contains(buf.String(), "Aborted") {
+		t.Errorf("unexpected output: %s", buf.String())
+	}
+}
+
+func TestBoardEditPipelineFilter(t *testing.T) {
+	resetBoardFlags()
+	resetBoardEditFlags()
+
+	setupBoardEditServer(t)
+	seen := stubBoardEditor(t, func(content string) string { return content })
+
+	rootCmd.SetOut(new(bytes.Buffer))
+	rootCmd.SetArgs([]string{"board", "edit", "--pipeline=In Development"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("board edit returned error: %v", err)
+	}
+	if strings.Contains(*seen, "New Issues") || !strings.Contains(*seen, "## In Development\n") {
+		t.Errorf("buffer should only hold In Development, got:\n%s", *seen)
+	}
+}
+
+func TestBoardEditMissingIssue(t *testing.T) {
+	resetBoardFlags()
+	resetBoardEditFlags()
+
+	_, inputs := setupBoardEditServer(t)
+	stubBoardEditor(t, func(content string) string {
+		return strings.Replace(content, "task-tracker#2 Add search feature\n", "", 1)
+	})
+
+	rootCmd.SetOut(new(bytes.Buffer))
+	rootCmd.SetArgs([]string{"board", "edit"})
+
+	err := rootCmd.Execute()
+	if err == nil {
+		t.Fatal("expected an error for a removed issue")
+	}
+	if exitcode.ExitCode(err) != exitcode.UsageError {
+		t.Errorf("exit code = %d, want usage error", exitcode.ExitCode(err))
+	}
+	if len(*inputs) != 0 {
+		t.Errorf("no moves should be made, got %v", *inputs)
+	}
+}
+
+func TestBoardEditJSON(t *testing.T) {
+	resetBoardFlags()
+	resetBoardEditFlags()
+	defer resetOutputFlags()
+
+	setupBoardEditServer(t)
+	stubBoardEditor(t, boardEditSwap)
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"board", "edit", "--output=json"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("board edit returned error: %v", err)
+	}
+
+	var result struct {
+		Moved []struct {
+			Ref     string `json:"ref"`
+			Context string `json:"context"`
+		} `json:"moved"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+		t.Fatalf("output is not valid JSON: %v\nOutput: %s", err, buf.String())
+	}
+	if len(result.Moved) != 2 || result.Moved[1].Ref != "task-tracker#1" {
+		t.Errorf("unexpected moved list: %+v", result.Moved)
+	}
+}
+
+func TestBoardEditFetchesFullPipelines(t *testing.T) {
+	resetBoardFlags()
+	resetBoardEditFlags()
+	defer resetBoardEditFlags()
+
+	// The board query only returned the first of New Issues' three issues
+	board := boardResponse()
+	pipelines := board["data"].(map[string]any)["workspace"].(map[string]any)["pipelinesConnection"].(map[string]any)["nodes"].([]any)
+	pipelines[0].(map[string]any)["issues"].(map[string]any)["totalCount"] = 3
+
+	ms := testutil.NewMockServer(t)
+	ms.HandleQuery("GetBoard", board)
+	ms.HandleQuery("ListPipelines", pipelineResolutionResponse())
+	var fetched []string
+	ms.Handle(
+		func(req testutil.GraphQLRequest) bool {
+			return strings.Contains(req.Query, "BoardEditPipelineIssues")
+		},
+		func(w http.ResponseWriter, req testutil.GraphQLRequest) {
+			var vars struct {
+				PipelineID string `json:"pipelineId"`
+				After      string `json:"after"`
+			}
+			_ = json.Unmarshal(req.Variables, &vars)
+			fetched = append(fetched, vars.PipelineID)
+
+			page := map[string]any{
+				"pageInfo": map[string]any{"hasNextPage": true, "endCursor": "c1"},
+				"nodes": []any{
+					boardIssueData("i1", 1, "Fix login button", "OPEN", false, 3, "task-tracker", "dlakehammond", "alice"),
+					boardIssueData("i5", 5, "Update docs", "OPEN", false, 0, "task-tracker", "dlakehammond", ""),
+				},
+			}
+			if vars.After == "c1" {
+				page = map[string]any{
+					"pageInfo": map[string]any{"hasNextPage": false, "endCursor": "c2"},
+					"nodes": []any{
+						boardIssueData("i6", 6, "Old bug", "OPEN", false, 0, "task-tracker", "dlakehammond", ""),
+					},
+				}
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"searchIssuesByPipeline": page}})
+		},
+	)
+	setupBoardTUITestEnv(t, ms)
+
+	seen := stubBoardEditor(t, func(content string) string {
+		content = strings.Replace(content, "task-tracker#6 Old bug\n", "", 1)
+		return strings.Replace(content, "## New Issues\n", "## New Issues\ntask-tracker#6 Old bug\n", 1)
+	})
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"board", "edit", "--dry-run"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("board edit returned error: %v", err)
+	}
+
+	if !strings.Contains(*seen, "## New Issues\ntask-tracker#1 Fix login button\ntask-tracker#5 Update docs\ntask-tracker#6 Old bug\n") {
+		t.Errorf("editor buffer should list the whole pipeline, got:\n%s", *seen)
+	}
+	if len(fetched) != 2 || fetched[0] != "p1" || fetched[1] != "p1" {
+		t.Errorf("only New Issues should be paged, fetched %v", fetched)
+	}
+	if out := buf.String(); !strings.Contains(out, "Would move 1 issue(s):") || !strings.Contains(out, "task-tracker#6") {
+		t.Errorf("expected a single move of task-tracker#6, got: %s", out)
+	}
+}
@@ -209,6 +209,52 @@ func TestBoardJSON(t *testing.T) {
 	}
 }
 
+func TestBoardCSV(t *testing.T) {
+	resetBoardFlags()
+	resetPipelineFlags()
+
+	ms := testutil.NewMockServer(t)
+	ms.HandleQuery("GetBoard", boardResponse())
+
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("ZH_API_KEY", "test-key")
+	t.Setenv("ZH_WORKSPACE", "ws-123")
+	t.Setenv("ZH_GITHUB_TOKEN", "")
+
+	origNew := apiNewFunc
+	apiNewFunc = func(apiKey string, opts ...api.Option) *api.Client {
+		return api.New(apiKey, append(opts, api.WithEndpoint(ms.URL()))...)
+	}
+	defer func() { apiNewFunc = origNew }()
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"board", "--output=csv"})
+	defer resetOutputFlags()
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("board --output=csv returned error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	want := []string{
+		"PIPELINE,ISSUE,TITLE,EST,ASSIGNEE,LABELS,PRIORITY",
+		"New Issues,task-tracker#1,Fix login button,3,alice,,",
+		"In Development,task-tracker#2,Add search feature,5,bob,,",
+		"In Development,recipe-book#2,Fix recipe validation,,,,",
+		"Closed,task-tracker#1,Initial setup,1,alice,,",
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("expected %d lines, got:\n%s", len(want), buf.String())
+	}
+	for i := range want {
+		if lines[i] != want[i] {
+			t.Errorf("line %d = %q, want %q", i, lines[i], want[i])
+		}
+	}
+}
+
 func TestBoardFilteredPipeline(t *testing.T) {
 	resetBoardFlags()
 	resetPipelineFlags()
@@ -0,0 +1,930 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/dslh/zh/internal/api"
+	"github.com/dslh/zh/internal/config"
+	"github.com/dslh/zh/internal/exitcode"
+	"github.com/dslh/zh/internal/gh"
+	"github.com/dslh/zh/internal/history"
+	"github.com/dslh/zh/internal/output"
+	"github.com/dslh/zh/internal/resolve"
+	"github.com/spf13/cobra"
+)
+
+// Board TUI types
+
+// boardTUIColumn is a pipeline column in the board TUI. Issues are held in
+// board order so that list indexes double as MoveIssue positions.
+type boardTUIColumn struct {
+	id     string
+	name   string
+	issues []boardIssueNode
+	total  int // open issues in the pipeline, which can exceed len(issues)
+}
+
+type boardTUIMode int
+
+const (
+	boardModeNormal boardTUIMode = iota
+	boardModeFilter
+	boardModePrompt
+	boardModeDetail
+)
+
+type boardTUIPrompt int
+
+const (
+	boardPromptEstimate boardTUIPrompt = iota
+	boardPromptPriority
+	boardPromptLabel
+)
+
+// boardMutationMsg reports the outcome of an edit started from the TUI.
+// Edits are applied to the issue only once they succeed.
+type boardMutationMsg struct {
+	status  string
+	err     error
+	issueID string
+	apply   func(*boardIssueNode)
+	change  history.Change
+}
+
+// boardMove is a move applied optimistically to the board and waiting to be
+// sent. Positions are worked out from the board as it will be once the moves
+// before it have run, so moves are sent one at a time, in order.
+type boardMove struct {
+	issue    boardIssueNode
+	ref      string
+	from     boardTUIColumn
+	pipeline boardTUIColumn
+	position int
+}
+
+// boardMoveMsg reports the outcome of the move at the head of the queue.
+type boardMoveMsg struct {
+	status string
+	err    error
+}
+
+// boardDetailMsg carries the rendered detail view for an issue.
+type boardDetailMsg struct {
+	content string
+	err     error
+}
+
+// boardLoadedMsg carries a freshly fetched board, and the status to show
+// once it has loaded.
+type boardLoadedMsg struct {
+	columns []boardTUIColumn
+	status  string
+	err     error
+}
+
+// boardTUIModel is the Bubble Tea model for `zh board --tui`.
+type boardTUIModel struct {
+	ctx         context.Context
+	client      *api.Client
+	ghClient    *gh.Client
+	workspaceID string
+
+	columns []boardTUIColumn
+	col     int
+	rows    []int // cursor per column, as an index into the visible issues
+	longRef bool
+
+	mode   boardTUIMode
+	prompt boardTUIPrompt
+	input  textinput.Model
+	filter string
+
+	detail       string
+	detailScroll int
+
+	// moves are the moves not yet confirmed by the server. The first one is
+	// in flight.
+	moves []boardMove
+
+	// changes are the confirmed changes, recorded in zh history on exit.
+	changes []history.Change
+
+	status string
+	width  int
+	height int
+}
+
+const boardTUIHelp = "h/l column  j/k issue  H/L move  J/K reorder  enter detail  e estimate  p priority  t label  / filter  r refresh  q quit"
+
+func newBoardTUIModel(ctx context.Context, client *api.Client, ghClient *gh.Client, workspaceID string, columns []boardTUIColumn) boardTUIModel {
+	input := textinput.New()
+	input.CharLimit = 100
+
+	m := boardTUIModel{
+		ctx:         ctx,
+		client:      client,
+		ghClient:    ghClient,
+		workspaceID: workspaceID,
+		input:       input,
+		width:       120,
+		height:      30,
+	}
+	m.setColumns(columns)
+	return m
+}
+
+// setColumns replaces the board contents, keeping the cursor in range.
+func (m *boardTUIModel) setColumns(columns []boardTUIColumn) {
+	m.columns = columns
+	for len(m.rows) < len(columns) {
+		m.rows = append(m.rows, 0)
+	}
+	m.rows = m.rows[:len(columns)]
+
+	pipelines := make([]boardPipeline, len(columns))
+	for i, c := range columns {
+		pipelines[i].Issues.Nodes = c.issues
+	}
+	m.longRef = boardRepoNamesAmbiguous(pipelines)
+
+	m.clampCursor()
+}
+
+func (m *boardTUIModel) clampCursor() {
+	m.col = max(0, min(m.col, len(m.columns)-1))
+	for i := range m.rows {
+		m.rows[i] = max(0, min(m.rows[i], len(m.visibleIssues(i))-1))
+	}
+}
+
+// visibleIssues returns the indexes of the issues in a column that match the
+// current filter.
+func (m boardTUIModel) visibleIssues(col int) []int {
+	var idx []int
+	for i, issue := range m.columns[col].issues {
+		if boardIssueMatches(issue, m.filter, m.longRef) {
+			idx = append(idx, i)
+		}
+	}
+	return idx
+}
+
+// boardIssueMatches reports whether an issue matches a / filter. The filter is
+// matched case-insensitively against the reference, title, assignees and labels.
+func boardIssueMatches(issue boardIssueNode, filter string, longRef bool) bool {
+	if filter == "" {
+		return true
+	}
+	fields := []string{boardFormatIssueRef(issue, longRef), issue.Title}
+	for _, a := range issue.Assignees.Nodes {
+		fields = append(fields, "@"+a.Login)
+	}
+	for _, l := range issue.Labels.Nodes {
+		fields = append(fields, l.Name)
+	}
+	needle := strings.ToLower(filter)
+	for _, f := range fields {
+		if strings.Contains(strings.ToLower(f), needle) {
+			return true
+		}
+	}
+	return false
+}
+
+// selected returns the issue under the cursor and its index in the column.
+func (m boardTUIModel) selected() (boardIssueNode, int, bool) {
+	if len(m.columns) == 0 {
+		return boardIssueNode{}, 0, false
+	}
+	visible := m.visibleIssues(m.col)
+	if len(visible) == 0 {
+		return boardIssueNode{}, 0, false
+	}
+	idx := visible[m.rows[m.col]]
+	return m.columns[m.col].issues[idx], idx, true
+}
+
+func (m boardTUIModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m boardTUIModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case boardMoveMsg:
+		if msg.err != nil {
+			// The board no longer matches the server, and the queued moves
+			// were positioned against it, so drop them and refetch
+			m.moves = nil
+			status := output.Red(msg.err.Error()) + output.Dim(" Reloading the board...")
+			m.status = status
+			return m, m.loadCmd(status)
+		}
+		move := m.moves[0]
+		m.changes = append(m.changes, boardMoveChange(move.issue, move.from, move.pipeline))
+		m.moves = m.moves[1:]
+		m.status = output.Green(msg.status)
+		if len(m.moves) > 0 {
+			return m, m.moveCmd(m.moves[0])
+		}
+		return m, nil
+
+	case boardMutationMsg:
+		if msg.err != nil {
+			m.status = output.Red(msg.err.Error())
+			return m, nil
+		}
+		if msg.apply != nil {
+			for c := range m.columns {
+				for i := range m.columns[c].issues {
+					if m.columns[c].issues[i].ID == msg.issueID {
+						msg.apply(&m.columns[c].issues[i])
+					}
+				}
+			}
+			m.clampCursor()
+		}
+		m.changes = append(m.changes, msg.change)
+		m.status = output.Green(msg.status)
+		return m, nil
+
+	case boardDetailMsg:
+		if m.mode != boardModeDetail {
+			return m, nil
+		}
+		if msg.err != nil {
+			m.detail = output.Red(msg.err.Error())
+		} else {
+			m.detail = msg.content
+		}
+		return m, nil
+
+	case boardLoadedMsg:
+		if msg.err != nil {
+			m.status = output.Red(msg.err.Error())
+			return m, nil
+		}
+		m.setColumns(msg.columns)
+		m.status = msg.status
+		return m, nil
+
+	case tea.KeyMsg:
+		if msg.Type == tea.KeyCtrlC {
+			return m, tea.Quit
+		}
+		switch m.mode {
+		case boardModeFilter:
+			return m.updateFilter(msg)
+		case boardModePrompt:
+			return m.updatePrompt(msg)
+		case boardModeDetail:
+			return m.updateDetail(msg)
+		}
+		return m.updateNormal(msg)
+	}
+
+	return m, nil
+}
+
+func (m boardTUIModel) updateNormal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if len(m.columns) == 0 {
+		if msg.String() == "q" || msg.String() == "esc" {
+			return m, tea.Quit
+		}
+		return m, nil
+	}
+
+	last := len(m.visibleIssues(m.col)) - 1
+	switch msg.String() {
+	case "q":
+		return m, tea.Quit
+	case "esc":
+		m.filter = ""
+		m.clampCursor()
+	case "left", "h":
+		m.col = max(0, m.col-1)
+	case "right", "l":
+		m.col = min(len(m.columns)-1, m.col+1)
+	case "up", "k":
+		m.rows[m.col] = max(0, m.rows[m.col]-1)
+	case "down", "j":
+		m.rows[m.col] = max(0, min(last, m.rows[m.col]+1))
+	case "home", "g":
+		m.rows[m.col] = 0
+	case "end", "G":
+		m.rows[m.col] = max(0, last)
+	case "shift+left", "H":
+		return m.moveToColumn(-1)
+	case "shift+right", "L":
+		return m.moveToColumn(1)
+	case "shift+up", "K":
+		return m.reorder(-1)
+	case "shift+down", "J":
+		return m.reorder(1)
+	case "enter":
+		issue, _, ok := m.selected()
+		if !ok {
+			return m, nil
+		}
+		m.mode = boardModeDetail
+		m.detail = output.Dim("Loading " + boardFormatIssueRef(issue, m.longRef) + "...")
+		m.detailScroll = 0
+		return m, m.detailCmd(issue)
+	case "e":
+		return m.startPrompt(boardPromptEstimate)
+	case "p":
+		return m.startPrompt(boardPromptPriority)
+	case "t":
+		return m.startPrompt(boardPromptLabel)
+	case "/":
+		m.mode = boardModeFilter
+		m.input.Prompt = "/"
+		m.input.Placeholder = ""
+		m.input.SetValue(m.filter)
+		m.input.CursorEnd()
+		return m, m.input.Focus()
+	case "r":
+		if len(m.moves) > 0 {
+			m.status = output.Dim("Waiting for moves to finish...")
+			return m, nil
+		}
+		m.status = output.Dim("Refreshing...")
+		return m, m.loadCmd(output.Green("Board refreshed."))
+	}
+	return m, nil
+}
+
+func (m boardTUIModel) updateFilter(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEnter:
+		m.mode = boardModeNormal
+		m.input.Blur()
+		return m, nil
+	case tea.KeyEsc:
+		m.mode = boardModeNormal
+		m.input.Blur()
+		m.filter = ""
+		m.clampCursor()
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	m.filter = m.input.Value()
+	m.clampCursor()
+	return m, cmd
+}
+
+func (m boardTUIModel) updatePrompt(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.mode = boardModeNormal
+		m.input.Blur()
+		return m, nil
+	case tea.KeyEnter:
+		m.mode = boardModeNormal
+		m.input.Blur()
+		issue, _, ok := m.selected()
+		if !ok {
+			return m, nil
+		}
+		value := strings.TrimSpace(m.input.Value())
+		ref := boardFormatIssueRef(issue, m.longRef)
+		switch m.prompt {
+		case boardPromptEstimate:
+			m.status = output.Dim("Setting estimate on " + ref + "...")
+			return m, m.estimateCmd(issue, ref, value)
+		case boardPromptPriority:
+			m.status = output.Dim("Setting priority on " + ref + "...")
+			return m, m.priorityCmd(issue, ref, value)
+		case boardPromptLabel:
+			if value == "" {
+				return m, nil
+			}
+			m.status = output.Dim("Updating labels on " + ref + "...")
+			return m, m.labelCmd(issue, ref, value)
+		}
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+func (m boardTUIModel) updateDetail(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q", "enter":
+		m.mode = boardModeNormal
+		m.detail = ""
+	case "down", "j":
+		m.detailScroll = min(m.detailScroll+1, max(0, strings.Count(m.detail, "\n")-1))
+	case "up", "k":
+		m.detailScroll = max(0, m.detailScroll-1)
+	}
+	return m, nil
+}
+
+// startPrompt opens the input line for a quick edit, pre-filled with the
+// issue's current value.
+func (m boardTUIModel) startPrompt(prompt boardTUIPrompt) (tea.Model, tea.Cmd) {
+	issue, _, ok := m.selected()
+	if !ok {
+		return m, nil
+	}
+
+	m.mode = boardModePrompt
+	m.prompt = prompt
+	m.input.SetValue("")
+	switch prompt {
+	case boardPromptEstimate:
+		m.input.Prompt = "Estimate: "
+		m.input.Placeholder = "blank to clear"
+		if issue.Estimate != nil {
+			m.input.SetValue(formatEstimate(issue.Estimate.Value))
+		}
+	case boardPromptPriority:
+		m.input.Prompt = "Priority: "
+		m.input.Placeholder = "blank to clear"
+		if issue.PipelineIssue != nil && issue.PipelineIssue.Priority != nil {
+			m.input.SetValue(issue.PipelineIssue.Priority.Name)
+		}
+	case boardPromptLabel:
+		m.input.Prompt = "Toggle label: "
+		m.input.Placeholder = "label name"
+	}
+	m.input.CursorEnd()
+	return m, m.input.Focus()
+}
+
+// moveToColumn moves the selected issue to the top of the neighbouring
+// pipeline. The board is updated straight away and the move is queued.
+func (m boardTUIModel) moveToColumn(delta int) (tea.Model, tea.Cmd) {
+	issue, idx, ok := m.selected()
+	target := m.col + delta
+	if !ok || target < 0 || target >= len(m.columns) {
+		return m, nil
+	}
+
+	src := &m.columns[m.col]
+	src.issues = slices.Delete(slices.Clone(src.issues), idx, idx+1)
+	dst := &m.columns[target]
+	dst.issues = slices.Insert(slices.Clone(dst.issues), 0, issue)
+
+	m.col = target
+	m.rows[target] = 0
+	m.clampCursor()
+
+	ref := boardFormatIssueRef(issue, m.longRef)
+	m.status = output.Dim(fmt.Sprintf("Moving %s to %s...", ref, dst.name))
+	return m.queueMove(boardMove{issue: issue, ref: ref, from: *src, pipeline: *dst, position: 0})
+}
+
+// reorder swaps the selected issue with its visible neighbour in the column.
+// Issues hidden by the filter keep their relative order.
+func (m boardTUIModel) reorder(delta int) (tea.Model, tea.Cmd) {
+	issue, from, ok := m.selected()
+	visible := m.visibleIssues(m.col)
+	row := m.rows[m.col] + delta
+	if !ok || row < 0 || row >= len(visible) {
+		return m, nil
+	}
+	to := visible[row]
+
+	c := &m.columns[m.col]
+	issues := slices.Delete(slices.Clone(c.issues), from, from+1)
+	c.issues = slices.Insert(issues, to, issue)
+	m.rows[m.col] = row
+
+	ref := boardFormatIssueRef(issue, m.longRef)
+	m.status = output.Dim(fmt.Sprintf("Moving %s to position %d...", ref, to+1))
+	return m.queueMove(boardMove{issue: issue, ref: ref, from: *c, pipeline: *c, position: to})
+}
+
+// queueMove adds a move to the queue, sending it straight away if no other
+// move is in flight.
+func (m boardTUIModel) queueMove(move boardMove) (tea.Model, tea.Cmd) {
+	m.moves = append(slices.Clip(m.moves), move)
+	if len(m.moves) > 1 {
+		return m, nil
+	}
+	return m, m.moveCmd(move)
+}
+
+// Commands
+
+func (m boardTUIModel) moveCmd(move boardMove) tea.Cmd {
+	client := m.client
+	return func() tea.Msg {
+		err := executeMoveIssue(m.ctx, client, resolvedMoveIssue{
+			IssueID:   move.issue.ID,
+			Number:    move.issue.Number,
+			Title:     move.issue.Title,
+			RepoName:  move.issue.Repository.Name,
+			RepoOwner: move.issue.Repository.OwnerName,
+		}, move.pipeline.id, posNumeric, move.position)
+		if err != nil {
+			return boardMoveMsg{err: err}
+		}
+		return boardMoveMsg{status: fmt.Sprintf("Moved %s to %s (position %d).", move.ref, move.pipeline.name, move.position+1)}
+	}
+}
+
+func (m boardTUIModel) estimateCmd(issue boardIssueNode, ref, value string) tea.Cmd {
+	client := m.client
+	return func() tea.Msg {
+		prev, newValue, err := setEstimateByNode(m.ctx, client, issue.ID, value)
+		if err != nil {
+			return boardMutationMsg{err: err}
+		}
+
+		status := fmt.Sprintf("Cleared estimate from %s.", ref)
+		if newValue != nil {
+			status = fmt.Sprintf("Set estimate on %s to %s.", ref, formatEstimate(*newValue))
+		}
+		return boardMutationMsg{
+			status:  status,
+			issueID: issue.ID,
+			apply:   func(n *boardIssueNode) { setBoardIssueEstimate(n, newValue) },
+			change: history.Change{
+				Kind:   history.KindEstimate,
+				Issue:  boardHistoryIssue(issue),
+				Before: estimateHistoryValue(prev.CurrentEstimate),
+				After:  estimateHistoryValue(newValue),
+			},
+		}
+	}
+}
+
+func (m boardTUIModel) priorityCmd(issue boardIssueNode, ref, value string) tea.Cmd {
+	client := m.client
+	workspaceID := m.workspaceID
+	return func() tea.Msg {
+		prev, priority, err := setPriorityByNode(m.ctx, client, workspaceID, issue.ID, value)
+		if err != nil {
+			return boardMutationMsg{err: err}
+		}
+
+		change := history.Change{Kind: history.KindPriority, Issue: boardHistoryIssue(issue)}
+		change.Issue.RepoGhID = prev.RepoGhID
+		if prev.CurrentPriorityID != "" {
+			change.Before = &history.Value{ID: prev.CurrentPriorityID, Name: prev.CurrentPriority}
+		}
+
+		name := ""
+		status := fmt.Sprintf("Cleared priority from %s.", ref)
+		if priority != nil {
+			name = priority.Name
+			status = fmt.Sprintf("Set priority on %s to %s.", ref, name)
+			change.After = &history.Value{ID: priority.ID, Name: priority.Name}
+		}
+		return boardMutationMsg{
+			status:  status,
+			issueID: issue.ID,
+			apply:   func(n *boardIssueNode) { setBoardIssuePriority(n, name) },
+			change:  change,
+		}
+	}
+}
+
+// labelCmd adds the named label to the issue, or removes it if the issue
+// already has it.
+func (m boardTUIModel) labelCmd(issue boardIssueNode, ref, value string) tea.Cmd {
+	client := m.client
+	workspaceID := m.workspaceID
+	return func() tea.Msg {
+		labels, err := resolve.Labels(m.ctx, client, workspaceID, []string{value})
+		if err != nil {
+			return boardMutationMsg{err: err}
+		}
+		label := labels[0]
+
+		add := !hasLabelNode(issue.Labels.Nodes, label.Name)
+		if err := executeIssueLabelChange(m.ctx, client, issue.ID, ref, label.ID, add); err != nil {
+			return boardMutationMsg{err: err}
+		}
+
+		change := history.Change{Kind: history.KindLabel, Issue: boardHistoryIssue(issue)}
+		value := &history.Value{ID: label.ID, Name: label.Name}
+		status := fmt.Sprintf("Removed label %s from %s.", label.Name, ref)
+		change.Before = value
+		if add {
+			status = fmt.Sprintf("Added label %s to %s.", label.Name, ref)
+			change.Before, change.After = nil, value
+		}
+		return boardMutationMsg{
+			status:  status,
+			issueID: issue.ID,
+			apply:   func(n *boardIssueNode) { n.Labels.Nodes = withLabelNode(n.Labels.Nodes, label.Name, add) },
+			change:  change,
+		}
+	}
+}
+
+func (m boardTUIModel) detailCmd(issue boardIssueNode) tea.Cmd {
+	client, ghClient, workspaceID := m.client, m.ghClient, m.workspaceID
+	return func() tea.Msg {
+		var buf bytes.Buffer
+		err := runIssueShowByNode(m.ctx, client, ghClient, workspaceID, issue.ID, &buf)
+		return boardDetailMsg{content: buf.String(), err: err}
+	}
+}
+
+// loadCmd refetches the board, showing status once it has loaded.
+func (m boardTUIModel) loadCmd(status string) tea.Cmd {
+	client, workspaceID := m.client, m.workspaceID
+	return func() tea.Msg {
+		columns, err := fetchBoardTUIColumns(m.ctx, client, workspaceID)
+		return boardLoadedMsg{columns: columns, status: status, err: err}
+	}
+}
+
+// boardHistoryIssue returns the history record of a board issue.
+func boardHistoryIssue(issue boardIssueNode) history.Issue {
+	return history.Issue{
+		ID:        issue.ID,
+		Number:    issue.Number,
+		RepoName:  issue.Repository.Name,
+		RepoOwner: issue.Repository.OwnerName,
+	}
+}
+
+// boardMoveChange returns the history change for moving issue between
+// pipelines, or reordering it when from and to are the same.
+func boardMoveChange(issue boardIssueNode, from, to boardTUIColumn) history.Change {
+	before := &history.Value{ID: from.id, Name: from.name}
+	if issue.PipelineIssue != nil {
+		before.Position = issue.PipelineIssue.RelativePosition
+	}
+	return history.Change{
+		Kind:   history.KindMove,
+		Issue:  boardHistoryIssue(issue),
+		Before: before,
+		After:  &history.Value{ID: to.id, Name: to.name},
+	}
+}
+
+// setBoardIssueEstimate updates the estimate shown on a board issue. A nil
+// value clears it.
+func setBoardIssueEstimate(n *boardIssueNode, value *float64) {
+	n.Estimate = nil
+	if value != nil {
+		n.Estimate = &struct {
+			Value float64 `json:"value"`
+		}{Value: *value}
+	}
+}
+
+// setBoardIssuePriority updates the priority shown on a board issue. An empty
+// name clears it.
+func setBoardIssuePriority(n *boardIssueNode, name string) {
+	if name == "" {
+		if n.PipelineIssue != nil {
+			n.PipelineIssue.Priority = nil
+		}
+		return
+	}
+	if n.PipelineIssue == nil {
+		n.PipelineIssue = &struct {
+			RelativePosition *int `json:"relativePosition,omitempty"`
+			Priority         *struct {
+				Name string `json:"name"`
+			} `json:"priority"`
+		}{}
+	}
+	n.PipelineIssue.Priority = &struct {
+		Name string `json:"name"`
+	}{Name: name}
+}
+
+// labelNodes is the shape of the labels on board and pipeline issue nodes.
+type labelNodes = []struct {
+	Name string `json:"name"`
+}
+
+// hasLabelNode reports whether a label is in nodes, ignoring case.
+func hasLabelNode(nodes labelNodes, name string) bool {
+	for _, l := range nodes {
+		if strings.EqualFold(l.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// withLabelNode returns nodes with a label added, or removed if add is false.
+func withLabelNode(nodes labelNodes, name string, add bool) labelNodes {
+	var out labelNodes
+	for _, l := range nodes {
+		if !strings.EqualFold(l.Name, name) {
+			out = append(out, l)
+		}
+	}
+	if add {
+		out = append(out, struct {
+			Name string `json:"name"`
+		}{Name: name})
+	}
+	return out
+}
+
+// View
+
+func (m boardTUIModel) View() string {
+	if m.mode == boardModeDetail {
+		return m.viewDetail()
+	}
+
+	var b strings.Builder
+	header := lipgloss.NewStyle().Bold(true).Render("Board")
+	if m.filter != "" && m.mode != boardModeFilter {
+		header += output.Dim("  filter: ") + m.filter
+	}
+	b.WriteString(header + "\n")
+
+	if len(m.columns) == 0 {
+		b.WriteString(output.Dim("No pipelines found.") + "\n")
+	} else {
+		b.WriteString(m.viewColumns() + "\n")
+	}
+
+	switch m.mode {
+	case boardModeFilter, boardModePrompt:
+		b.WriteString(m.input.View() + "\n")
+	default:
+		b.WriteString(m.status + "\n")
+	}
+	b.WriteString(lipgloss.NewStyle().Faint(true).Render(ansi.Truncate(boardTUIHelp, m.width, "…")))
+	return b.String()
+}
+
+// viewColumns renders as many pipeline columns as fit the terminal, scrolled
+// so that the focused column is always visible.
+func (m boardTUIModel) viewColumns() string {
+	colWidth := max(28, m.width/len(m.columns))
+	perPage := max(1, m.width/colWidth)
+	start := 0
+	if m.col >= perPage {
+		start = m.col - perPage + 1
+	}
+	end := min(len(m.columns), start+perPage)
+
+	// Leave room for the board header, the status line and the help line.
+	bodyHeight := max(4, m.height-3)
+
+	rendered := make([]string, 0, end-start)
+	for c := start; c < end; c++ {
+		rendered = append(rendered, m.viewColumn(c, colWidth, bodyHeight))
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, rendered...)
+}
+
+func (m boardTUIModel) viewColumn(c, width, height int) string {
+	inner := width - 2
+	col := m.columns[c]
+	visible := m.visibleIssues(c)
+	focused := c == m.col
+
+	title := fmt.Sprintf("%s (%d)", col.name, len(visible))
+	titleStyle := lipgloss.NewStyle().Bold(true)
+	if focused {
+		titleStyle = titleStyle.Foreground(lipgloss.Color("6"))
+	}
+
+	lines := []string{
+		titleStyle.Render(ansi.Truncate(title, inner, "…")),
+		output.Dim(strings.Repeat("─", inner)),
+	}
+
+	// Each issue takes two lines: reference and details, then title.
+	fit := max(1, (height-len(lines))/2)
+	offset := 0
+	if focused && m.rows[c] >= fit {
+		offset = m.rows[c] - fit + 1
+	}
+
+	if len(visible) == 0 {
+		lines = append(lines, output.Dim("No issues"))
+	}
+	for r := offset; r < len(visible) && r < offset+fit; r++ {
+		issue := col.issues[visible[r]]
+		ref := boardFormatIssueRef(issue, m.longRef)
+		meta := ""
+		if issue.Estimate != nil {
+			meta += " [" + formatEstimate(issue.Estimate.Value) + "]"
+		}
+		if issue.PipelineIssue != nil && issue.PipelineIssue.Priority != nil {
+			meta += " " + issue.PipelineIssue.Priority.Name
+		}
+		first := ansi.Truncate(ref+meta, inner, "…")
+		second := ansi.Truncate(issue.Title, inner, "…")
+
+		if focused && r == m.rows[c] {
+			style := lipgloss.NewStyle().Reverse(true).Width(inner)
+			lines = append(lines, style.Render(first), style.Render(second))
+			continue
+		}
+		lines = append(lines, ansi.Truncate(output.Cyan(ref)+output.Dim(meta), inner, "…"), second)
+	}
+
+	return lipgloss.NewStyle().Width(width).Height(height).PaddingRight(2).Render(strings.Join(lines, "\n"))
+}
+
+func (m boardTUIModel) viewDetail() string {
+	lines := strings.Split(strings.TrimRight(m.detail, "\n"), "\n")
+	height := max(1, m.height-1)
+	start := min(m.detailScroll, max(0, len(lines)-1))
+	end := min(len(lines), start+height)
+
+	var b strings.Builder
+	b.WriteString(strings.Join(lines[start:end], "\n"))
+	b.WriteString("\n")
+	b.WriteString(lipgloss.NewStyle().Faint(true).Render("j/k scroll  esc back"))
+	return b.String()
+}
+
+// fetchBoardTUIColumns fetches the open pipelines and their issues for the TUI.
+func fetchBoardTUIColumns(ctx context.Context, client *api.Client, workspaceID string) ([]boardTUIColumn, error) {
+	data, err := client.Execute(ctx, boardQuery, map[string]any{
+		"workspaceId": workspaceID,
+	})
+	if err != nil {
+		return nil, exitcode.General("fetching board", err)
+	}
+
+	var resp struct {
+		Workspace struct {
+			PipelinesConnection struct {
+				Nodes []boardPipeline `json:"nodes"`
+			} `json:"pipelinesConnection"`
+		} `json:"workspace"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, exitcode.General("parsing board response", err)
+	}
+
+	pipelines := resp.Workspace.PipelinesConnection.Nodes
+	cachePipelinesFromBoard(pipelines, workspaceID)
+
+	columns := make([]boardTUIColumn, len(pipelines))
+	for i, p := range pipelines {
+		columns[i] = boardTUIColumn{id: p.ID, name: p.Name, issues: p.Issues.Nodes, total: p.Issues.TotalCount}
+	}
+	return columns, nil
+}
+
+// runBoardTUI implements `zh board --tui`.
+func runBoardTUI(cmd *cobra.Command, cfg *config.Config, client *api.Client) error {
+	ctx := commandContext(cmd)
+
+	if output.IsJSON(outputFormat) || output.IsTabular(outputFormat) {
+		return exitcode.Usage(fmt.Sprintf("--tui cannot be combined with --output=%s", outputFormat))
+	}
+	if !isInteractive() {
+		return exitcode.Usage("--tui requires a terminal — cannot run in non-TTY environment")
+	}
+
+	columns, err := fetchBoardTUIColumns(ctx, client, cfg.Workspace)
+	if err != nil {
+		return err
+	}
+
+	m := newBoardTUIModel(commandContext(cmd), client, newGitHubClient(cfg, cmd), cfg.Workspace, columns)
+
+	// --pipeline focuses the board on that column
+	if boardPipelineFilter != "" {
+		resolved, err := resolve.Pipeline(ctx, client, cfg.Workspace, boardPipelineFilter, cfg.Aliases.Pipelines)
+		if err != nil {
+			return err
+		}
+		for i, c := range columns {
+			if c.id == resolved.ID {
+				m.col = i
+			}
+		}
+	}
+
+	p := tea.NewProgram(m, tea.WithOutput(cmd.ErrOrStderr()), tea.WithAltScreen())
+	final, err := p.Run()
+	if err != nil {
+		return exitcode.General("running board TUI", err)
+	}
+
+	changes := mergeHistoryChanges(final.(boardTUIModel).changes)
+	recordHistory(cmd.ErrOrStderr(), cfg.Workspace, "board",
+		fmt.Sprintf("Made %d change(s) from the board", len(changes)), changes)
+	return nil
+}
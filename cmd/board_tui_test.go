@@ -0,0 +1,521 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/dslh/zh/internal/api"
+	"github.com/dslh/zh/internal/exitcode"
+	"github.com/dslh/zh/internal/testutil"
+)
+
+// --- board --tui ---
+
+func TestBoardTUIRequiresTerminal(t *testing.T) {
+	resetBoardFlags()
+	defer resetBoardFlags()
+
+	ms := testutil.NewMockServer(t)
+	ms.HandleQuery("GetBoard", boardResponse())
+	setupBoardTUITestEnv(t, ms)
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"board", "--tui"})
+
+	err := rootCmd.Execute()
+	if err == nil {
+		t.Fatal("expected error in non-TTY environment")
+	}
+	if exitcode.ExitCode(err) != exitcode.UsageError {
+		t.Errorf("expected usage error, got: %v", err)
+	}
+	if !strings.Contains(err.Error(), "--tui requires a terminal") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestBoardTUIRejectsJSON(t *testing.T) {
+	resetBoardFlags()
+	defer resetBoardFlags()
+	defer resetOutputFlags()
+
+	ms := testutil.NewMockServer(t)
+	setupBoardTUITestEnv(t, ms)
+
+	rootCmd.SetOut(new(bytes.Buffer))
+	rootCmd.SetArgs([]string{"board", "--tui", "--output=json"})
+
+	err := rootCmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "--tui cannot be combined with --output=json") {
+		t.Errorf("expected --output conflict error, got: %v", err)
+	}
+}
+
+// --- boardTUIModel ---
+
+func TestBoardTUINavigation(t *testing.T) {
+	m := newBoardTUITestModel(t, nil)
+
+	m = boardTUIKeys(t, m, "l", "j")
+	issue, _, ok := m.selected()
+	if !ok || issue.ID != "i3" {
+		t.Fatalf("expected i3 selected, got %q", issue.ID)
+	}
+
+	// Moving past the end of a column stays on the last issue
+	m = boardTUIKeys(t, m, "j", "j")
+	if m.rows[1] != 1 {
+		t.Errorf("cursor should stop at the last issue, got row %d", m.rows[1])
+	}
+
+	// Empty columns can be focused, but have no selection
+	m = boardTUIKeys(t, m, "l")
+	if _, _, ok := m.selected(); ok {
+		t.Error("empty column should have no selection")
+	}
+
+	m = boardTUIKeys(t, m, "l", "l", "h", "h", "h", "h")
+	if m.col != 0 {
+		t.Errorf("cursor should stop at the first column, got %d", m.col)
+	}
+}
+
+func TestBoardTUIMoveToColumn(t *testing.T) {
+	ms := testutil.NewMockServer(t)
+	var variables string
+	ms.Handle(
+		func(req testutil.GraphQLRequest) bool {
+			return strings.Contains(req.Query, "MoveIssue")
+		},
+		func(w http.ResponseWriter, req testutil.GraphQLRequest) {
+			variables = string(req.Variables)
+			_, _ = w.Write([]byte(`{"data":{"moveIssue":{"issue":{"id":"i1"},"pipeline":{"id":"p2","name":"In Development"}}}}`))
+		},
+	)
+	m := newBoardTUITestModel(t, ms)
+
+	updated, cmd := m.Update(boardTUIKey("L"))
+	m = updated.(boardTUIModel)
+
+	if m.col != 1 {
+		t.Errorf("cursor should follow the issue, got column %d", m.col)
+	}
+	if len(m.columns[0].issues) != 0 || m.columns[1].issues[0].ID != "i1" {
+		t.Fatalf("issue should move to the top of In Development, got %+v", m.columns)
+	}
+	if cmd == nil {
+		t.Fatal("expected a move command")
+	}
+
+	updated, _ = m.Update(cmd())
+	m = updated.(boardTUIModel)
+
+	if !strings.Contains(variables, `"pipelineId":"p2"`) || !strings.Contains(variables, `"position":0`) {
+		t.Errorf("unexpected MoveIssue variables: %s", variables)
+	}
+	if !strings.Contains(m.status, "Moved task-tracker#1 to In Development") {
+		t.Errorf("unexpected status: %q", m.status)
+	}
+}
+
+func TestBoardTUIMoveFailureReloads(t *testing.T) {
+	ms := testutil.NewMockServer(t)
+	ms.HandleQuery("MoveIssue", map[string]any{
+		"errors": []any{map[string]any{"message": "Pipeline not found"}},
+	})
+	ms.HandleQuery("GetBoard", boardResponse())
+	m := newBoardTUITestModel(t, ms)
+
+	updated, cmd := m.Update(boardTUIKey("L"))
+	m = updated.(boardTUIModel)
+	// A second move waits behind the first
+	updated, queued := m.Update(boardTUIKey("L"))
+	m = updated.(boardTUIModel)
+	if queued != nil || len(m.moves) != 2 {
+		t.Fatalf("second move should be queued, got %d move(s)", len(m.moves))
+	}
+
+	updated, reload := m.Update(cmd())
+	m = updated.(boardTUIModel)
+	if len(m.moves) != 0 {
+		t.Errorf("queued moves should be dropped after a failure, got %d", len(m.moves))
+	}
+	if reload == nil {
+		t.Fatal("expected the board to be reloaded")
+	}
+	updated, _ = m.Update(reload())
+	m = updated.(boardTUIModel)
+
+	if len(m.columns[0].issues) != 1 || len(m.columns[1].issues) != 2 {
+		t.Errorf("board should match the server after reloading, got %+v", m.columns)
+	}
+	if !strings.Contains(m.status, "Pipeline not found") {
+		t.Errorf("status should show the error, got %q", m.status)
+	}
+}
+
+func TestBoardTUIMovesRunInOrder(t *testing.T) {
+	ms := testutil.NewMockServer(t)
+	var moves []string
+	ms.Handle(
+		func(req testutil.GraphQLRequest) bool {
+			return strings.Contains(req.Query, "MoveIssue")
+		},
+		func(w http.ResponseWriter, req testutil.GraphQLRequest) {
+			moves = append(moves, string(req.Variables))
+			_, _ = w.Write([]byte(`{"data":{"moveIssue":{"issue":{"id":"i1"},"pipeline":{"id":"p2","name":"In Development"}}}}`))
+		},
+	)
+	m := newBoardTUITestModel(t, ms)
+
+	updated, cmd := m.Update(boardTUIKey("L"))
+	m = updated.(boardTUIModel)
+	m = boardTUIKeys(t, m, "J")
+
+	// Each confirmed move sends the next one
+	for cmd != nil {
+		var updated tea.Model
+		updated, cmd = m.Update(cmd())
+		m = updated.(boardTUIModel)
+	}
+
+	if len(moves) != 2 {
+		t.Fatalf("expected 2 moves, got %v", moves)
+	}
+	if !strings.Contains(moves[0], `"position":0`) || !strings.Contains(moves[1], `"position":1`) {
+		t.Errorf("moves sent out of order: %v", moves)
+	}
+	if len(m.moves) != 0 {
+		t.Errorf("queue should be empty, got %d move(s)", len(m.moves))
+	}
+
+	// Both moves are recorded, and merge into a single move for history
+	merged := mergeHistoryChanges(m.changes)
+	if len(m.changes) != 2 || len(merged) != 1 || merged[0].Before.ID != "p1" || merged[0].After.ID != "p2" {
+		t.Errorf("unexpected changes: %+v", m.changes)
+	}
+}
+
+func TestBoardTUIReorder(t *testing.T) {
+	ms := testutil.NewMockServer(t)
+	var variables string
+	ms.Handle(
+		func(req testutil.GraphQLRequest) bool {
+			return strings.Contains(req.Query, "MoveIssue")
+		},
+		func(w http.ResponseWriter, req testutil.GraphQLRequest) {
+			variables = string(req.Variables)
+			_, _ = w.Write([]byte(`{"data":{"moveIssue":{"issue":{"id":"i2"},"pipeline":{"id":"p2","name":"In Development"}}}}`))
+		},
+	)
+	m := newBoardTUITestModel(t, ms)
+
+	m = boardTUIKeys(t, m, "l")
+	updated, cmd := m.Update(boardTUIKey("J"))
+	m = updated.(boardTUIModel)
+
+	if m.columns[1].issues[0].ID != "i3" || m.columns[1].issues[1].ID != "i2" {
+		t.Fatalf("issues should be swapped, got %+v", m.columns[1].issues)
+	}
+	if m.rows[1] != 1 {
+		t.Errorf("cursor should follow the issue, got row %d", m.rows[1])
+	}
+
+	cmd()
+	if !strings.Contains(variables, `"issueId":"i2"`) || !strings.Contains(variables, `"position":1`) {
+		t.Errorf("unexpected MoveIssue variables: %s", variables)
+	}
+
+	// Reordering past the top of the column does nothing
+	m = boardTUIKeys(t, m, "k")
+	if _, cmd := m.Update(boardTUIKey("K")); cmd != nil {
+		t.Error("expected no command when the issue is already at the top")
+	}
+}
+
+func TestBoardTUIFilter(t *testing.T) {
+	m := newBoardTUITestModel(t, nil)
+
+	m = boardTUIKeys(t, m, "/", "r", "e", "c", "i", "p", "e")
+	if m.mode != boardModeFilter {
+		t.Fatal("expected filter mode while typing")
+	}
+	m = boardTUIKeys(t, m, "enter")
+
+	if m.filter != "recipe" {
+		t.Errorf("filter = %q, want %q", m.filter, "recipe")
+	}
+	if len(m.visibleIssues(0)) != 0 {
+		t.Error("New Issues should have no matching issues")
+	}
+	visible := m.visibleIssues(1)
+	if len(visible) != 1 || m.columns[1].issues[visible[0]].ID != "i3" {
+		t.Errorf("expected only i3 to match, got %v", visible)
+	}
+
+	view := m.View()
+	if !strings.Contains(view, "filter: recipe") {
+		t.Errorf("view should show the active filter:\n%s", view)
+	}
+
+	// Assignees match with an @ prefix
+	if !boardIssueMatches(m.columns[1].issues[0], "@bob", false) {
+		t.Error("expected @bob to match the assignee")
+	}
+
+	m = boardTUIKeys(t, m, "esc")
+	if m.filter != "" || len(m.visibleIssues(1)) != 2 {
+		t.Error("esc should clear the filter")
+	}
+}
+
+func TestBoardTUIEstimate(t *testing.T) {
+	ms := testutil.NewMockServer(t)
+	ms.HandleQuery("GetIssueForEstimateByNode", map[string]any{
+		"data": map[string]any{
+			"node": map[string]any{
+				"id":       "i1",
+				"number":   1,
+				"title":    "Fix login button",
+				"estimate": map[string]any{"value": 3},
+				"repository": map[string]any{
+					"name":        "task-tracker",
+					"ownerName":   "dlakehammond",
+					"estimateSet": map[string]any{"values": []any{1, 2, 3, 5, 8}},
+				},
+			},
+		},
+	})
+	ms.HandleQuery("SetEstimate", setEstimateSuccessResponse())
+	m := newBoardTUITestModel(t, ms)
+
+	m = boardTUIKeys(t, m, "e")
+	if m.input.Value() != "3" {
+		t.Errorf("prompt should be pre-filled with the current estimate, got %q", m.input.Value())
+	}
+
+	m.input.SetValue("5")
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(boardTUIModel)
+	updated, _ = m.Update(cmd())
+	m = updated.(boardTUIModel)
+
+	issue := m.columns[0].issues[0]
+	if issue.Estimate == nil || issue.Estimate.Value != 5 {
+		t.Errorf("estimate should be updated to 5, got %+v", issue.Estimate)
+	}
+	if !strings.Contains(m.status, "Set estimate on task-tracker#1 to 5.") {
+		t.Errorf("unexpected status: %q", m.status)
+	}
+
+	// Values outside the repository's estimate set are rejected
+	m = boardTUIKeys(t, m, "e")
+	m.input.SetValue("4")
+	updated, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(boardTUIModel)
+	updated, _ = m.Update(cmd())
+	m = updated.(boardTUIModel)
+
+	if !strings.Contains(m.status, "valid values are: 1, 2, 3, 5, 8") {
+		t.Errorf("expected invalid estimate error, got %q", m.status)
+	}
+	if m.columns[0].issues[0].Estimate.Value != 5 {
+		t.Error("a rejected estimate should leave the issue unchanged")
+	}
+}
+
+func TestBoardTUIPriority(t *testing.T) {
+	ms := testutil.NewMockServer(t)
+	ms.HandleQuery("GetWorkspacePriorities", prioritiesResponse())
+	ms.HandleQuery("GetIssueForPriority", issuePriorityResolveResponse("i1", 1, "Fix login button", 12345, ""))
+	ms.HandleQuery("SetIssuePriority", setPriorityResponse())
+	m := newBoardTUITestModel(t, ms)
+
+	m = boardTUIKeys(t, m, "p")
+	m.input.SetValue("urgent")
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(boardTUIModel)
+	updated, _ = m.Update(cmd())
+	m = updated.(boardTUIModel)
+
+	issue := m.columns[0].issues[0]
+	if issue.PipelineIssue == nil || issue.PipelineIssue.Priority == nil || issue.PipelineIssue.Priority.Name != "Urgent" {
+		t.Errorf("priority should be set to Urgent, got %+v", issue.PipelineIssue)
+	}
+	if !strings.Contains(m.View(), "Urgent") {
+		t.Error("view should show the new priority")
+	}
+}
+
+func TestBoardTUILabelToggle(t *testing.T) {
+	ms := testutil.NewMockServer(t)
+	ms.HandleQuery("GetWorkspaceLabels", workspaceLabelsResponse())
+	ms.HandleQuery("AddLabelsToIssues", labelMutationResponse(1))
+	ms.HandleQuery("RemoveLabelsFromIssues", map[string]any{
+		"data": map[string]any{
+			"removeLabelsFromIssues": map[string]any{"successCount": 1, "failedIssues": []any{}},
+		},
+	})
+	m := newBoardTUITestModel(t, ms)
+
+	toggle := func(m boardTUIModel) boardTUIModel {
+		m = boardTUIKeys(t, m, "t")
+		m.input.SetValue("bug")
+		updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		m = updated.(boardTUIModel)
+		updated, _ = m.Update(cmd())
+		return updated.(boardTUIModel)
+	}
+
+	m = toggle(m)
+	if labels := m.columns[0].issues[0].Labels.Nodes; len(labels) != 1 || labels[0].Name != "bug" {
+		t.Errorf("expected bug label to be added, got %+v", labels)
+	}
+	if !strings.Contains(m.status, "Added label bug to task-tracker#1.") {
+		t.Errorf("unexpected status: %q", m.status)
+	}
+
+	m = toggle(m)
+	if labels := m.columns[0].issues[0].Labels.Nodes; len(labels) != 0 {
+		t.Errorf("expected bug label to be removed, got %+v", labels)
+	}
+	if !strings.Contains(m.status, "Removed label bug from task-tracker#1.") {
+		t.Errorf("unexpected status: %q", m.status)
+	}
+}
+
+func TestBoardTUIPromptEscCancels(t *testing.T) {
+	m := newBoardTUITestModel(t, nil)
+
+	m = boardTUIKeys(t, m, "t")
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(boardTUIModel)
+
+	if m.mode != boardModeNormal || cmd != nil {
+		t.Error("esc should close the prompt without running a mutation")
+	}
+}
+
+func TestBoardTUIDetail(t *testing.T) {
+	m := newBoardTUITestModel(t, nil)
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(boardTUIModel)
+	if m.mode != boardModeDetail || cmd == nil {
+		t.Fatal("enter should open the detail pane and fetch the issue")
+	}
+
+	updated, _ = m.Update(boardDetailMsg{content: "ISSUE: task-tracker#1: Fix login button\n\nline\n"})
+	m = updated.(boardTUIModel)
+	if !strings.Contains(m.View(), "ISSUE: task-tracker#1: Fix login button") {
+		t.Errorf("view should show the issue detail:\n%s", m.View())
+	}
+
+	m = boardTUIKeys(t, m, "esc")
+	if m.mode != boardModeNormal {
+		t.Error("esc should close the detail pane")
+	}
+
+	// A detail response arriving after the pane is closed is ignored
+	updated, _ = m.Update(boardDetailMsg{content: "late"})
+	if updated.(boardTUIModel).detail != "" {
+		t.Error("late detail response should be ignored")
+	}
+}
+
+func TestBoardTUIView(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	m := newBoardTUITestModel(t, nil)
+
+	view := m.View()
+	for _, want := range []string{"New Issues (1)", "In Development (2)", "Done (0)", "task-tracker#1 [3]", "Fix login button", "recipe-book#2", "No issues"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view should contain %q:\n%s", want, view)
+		}
+	}
+}
+
+func TestBoardTUIQuit(t *testing.T) {
+	m := newBoardTUITestModel(t, nil)
+
+	_, cmd := m.Update(boardTUIKey("q"))
+	if cmd == nil {
+		t.Fatal("q should quit")
+	}
+	if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Error("q should return tea.Quit")
+	}
+}
+
+// --- test helpers ---
+
+func setupBoardTUITestEnv(t *testing.T, ms *testutil.MockServer) {
+	t.Helper()
+
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("ZH_API_KEY", "test-key")
+	t.Setenv("ZH_WORKSPACE", "ws-123")
+	t.Setenv("ZH_GITHUB_TOKEN", "")
+
+	origNew := apiNewFunc
+	apiNewFunc = func(apiKey string, opts ...api.Option) *api.Client {
+		return api.New(apiKey, append(opts, api.WithEndpoint(ms.URL()))...)
+	}
+	t.Cleanup(func() { apiNewFunc = origNew })
+}
+
+// newBoardTUITestModel builds a board TUI model from boardResponse. If ms is
+// non-nil the model's client talks to it.
+func newBoardTUITestModel(t *testing.T, ms *testutil.MockServer) boardTUIModel {
+	t.Helper()
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	data, _ := json.Marshal(boardResponse()["data"])
+	var resp struct {
+		Workspace struct {
+			PipelinesConnection struct {
+				Nodes []boardPipeline `json:"nodes"`
+			} `json:"pipelinesConnection"`
+		} `json:"workspace"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		t.Fatalf("parsing board response: %v", err)
+	}
+
+	var columns []boardTUIColumn
+	for _, p := range resp.Workspace.PipelinesConnection.Nodes {
+		columns = append(columns, boardTUIColumn{id: p.ID, name: p.Name, issues: p.Issues.Nodes})
+	}
+
+	var client *api.Client
+	if ms != nil {
+		client = api.New("test-key", api.WithEndpoint(ms.URL()))
+	}
+	return newBoardTUIModel(context.Background(), client, nil, "ws-123", columns)
+}
+
+func boardTUIKey(key string) tea.KeyMsg {
+	switch key {
+	case "enter":
+		return tea.KeyMsg{Type: tea.KeyEnter}
+	case "esc":
+		return tea.KeyMsg{Type: tea.KeyEsc}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+}
+
+func boardTUIKeys(t *testing.T, m boardTUIModel, keys ...string) boardTUIModel {
+	t.Helper()
+	for _, k := range keys {
+		updated, _ := m.Update(boardTUIKey(k))
+		m = updated.(boardTUIModel)
+	}
+	return m
+}
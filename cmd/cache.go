@@ -1,17 +1,36 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/dslh/zh/internal/api"
 	"github.com/dslh/zh/internal/cache"
 	"github.com/dslh/zh/internal/config"
 	"github.com/dslh/zh/internal/exitcode"
+	"github.com/dslh/zh/internal/output"
+	"github.com/dslh/zh/internal/resolve"
 	"github.com/spf13/cobra"
 )
 
 var cacheCmd = &cobra.Command{
 	Use:   "cache",
 	Short: "Manage the local cache",
+	Long: `Manage the local cache of workspace resources.
+
+Each cached resource expires after a TTL, after which it is fetched again
+the next time it is needed. The TTLs can be changed in config.yml:
+
+  cache:
+    ttl:
+      sprints: 10m
+      pipelines: 48h
+
+A TTL of 0s keeps a resource until it is cleared or refreshed.`,
 }
 
 var cacheClearWorkspace bool
@@ -45,8 +64,270 @@ var cacheClearCmd = &cobra.Command{
 	},
 }
 
+var cacheStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the age and size of cached resources",
+	Long: `Show each cached resource for the current workspace, with when it was
+fetched, its TTL, its size on disk and whether it is fresh or stale.`,
+	Args:        cobra.NoArgs,
+	RunE:        runCacheStatus,
+	Annotations: map[string]string{tabularAnnotation: "true"},
+}
+
+var cacheRefreshCmd = &cobra.Command{
+	Use:   "refresh [resource...]",
+	Short: "Fetch cached resources ahead of time",
+	Long: `Fetch workspace resources from the API and write them to the cache, so
+that later commands don't have to. Resources are fetched in parallel.
+
+With no arguments, every resource is refreshed: pipelines, sprints, epics,
+repos, labels, users and priorities.
+
+Examples:
+  zh cache refresh
+  zh cache refresh sprints epics`,
+	ValidArgs: cacheRefreshResourceNames(),
+	RunE:      runCacheRefresh,
+}
+
 func init() {
 	cacheClearCmd.Flags().BoolVar(&cacheClearWorkspace, "workspace", false, "Clear only the current workspace's cache")
 	cacheCmd.AddCommand(cacheClearCmd)
+	cacheCmd.AddCommand(cacheStatusCmd)
+	cacheCmd.AddCommand(cacheRefreshCmd)
 	rootCmd.AddCommand(cacheCmd)
 }
+
+// cacheRefreshResource is a resource that zh cache refresh can fetch.
+type cacheRefreshResource struct {
+	name  string
+	fetch func(ctx context.Context, client *api.Client, workspaceID string) (int, error)
+}
+
+// cacheRefreshResources are the resources refreshed by zh cache refresh, in
+// the order they are reported.
+var cacheRefreshResources = []cacheRefreshResource{
+	{"pipelines", countFetched(resolve.FetchPipelines)},
+	{"sprints", countFetched(resolve.FetchSprints)},
+	{"epics", countFetched(resolve.FetchEpics)},
+	{"repos", countFetched(resolve.FetchRepos)},
+	{"labels", countFetched(resolve.FetchLabels)},
+	{"users", countFetched(resolve.FetchUsers)},
+	{"priorities", countFetched(resolve.FetchPriorities)},
+}
+
+// countFetched adapts a resolve fetcher to report how many entries it cached.
+func countFetched[T any](fetch func(context.Context, *api.Client, string) ([]T, error)) func(context.Context, *api.Client, string) (int, error) {
+	return func(ctx context.Context, client *api.Client, workspaceID string) (int, error) {
+		entries, err := fetch(ctx, client, workspaceID)
+		return len(entries), err
+	}
+}
+
+func cacheRefreshResourceNames() []string {
+	names := make([]string, len(cacheRefreshResources))
+	for i, r := range cacheRefreshResources {
+		names[i] = r.name
+	}
+	return names
+}
+
+// runCacheStatus implements `zh cache status`.
+func runCacheStatus(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return exitcode.General("loading config", err)
+	}
+	if cfg.Workspace == "" {
+		return exitcode.Usage("no workspace configured — use 'zh workspace switch' to set one")
+	}
+	cache.SetTTLs(cfg.Cache.TTL)
+
+	w := cmd.OutOrStdout()
+
+	infos, err := cache.List(cfg.Workspace)
+	if err != nil {
+		return exitcode.General("reading cache", err)
+	}
+
+	// Show the refreshable resources even when they aren't cached, then
+	// anything else that is, in name order.
+	byResource := make(map[string]cache.Info, len(infos))
+	for _, info := range infos {
+		byResource[info.Key.Resource] = info
+	}
+	resources := cacheRefreshResourceNames()
+	var extra []string
+	for resource := range byResource {
+		if !slices.Contains(resources, resource) {
+			extra = append(extra, resource)
+		}
+	}
+	slices.Sort(extra)
+	resources = append(resources, extra...)
+
+	if output.IsJSON(outputFormat) {
+		type jsonEntry struct {
+			Resource  string     `json:"resource"`
+			Cached    bool       `json:"cached"`
+			FetchedAt *time.Time `json:"fetchedAt,omitempty"`
+			TTL       string     `json:"ttl"`
+			Size      int64      `json:"size"`
+			Stale     bool       `json:"stale"`
+		}
+		out := make([]jsonEntry, 0, len(resources))
+		for _, resource := range resources {
+			info, ok := byResource[resource]
+			entry := jsonEntry{Resource: resource, Cached: ok, TTL: cache.TTL(resource).String()}
+			if ok {
+				entry.Size = info.Size
+				entry.Stale = info.Stale()
+				if !info.FetchedAt.IsZero() {
+					entry.FetchedAt = &info.FetchedAt
+				}
+			}
+			out = append(out, entry)
+		}
+		return output.JSON(w, out)
+	}
+
+	var total int64
+	lw := output.NewListWriter(w, "RESOURCE", "FETCHED", "TTL", "SIZE", "STATUS")
+	for _, resource := range resources {
+		ttl := formatCacheTTL(cache.TTL(resource))
+		info, ok := byResource[resource]
+		if !ok {
+			lw.Row(resource, output.TableMissing, ttl, output.TableMissing, output.Dim("not cached"))
+			continue
+		}
+		total += info.Size
+
+		fetched := output.TableMissing
+		if !info.FetchedAt.IsZero() {
+			fetched = output.FormatTimeAgo(info.FetchedAt)
+		}
+		status := output.Green("fresh")
+		if info.Stale() {
+			status = output.Yellow("stale")
+		}
+		lw.Row(resource, fetched, ttl, formatCacheSize(info.Size), status)
+	}
+	lw.FlushWithFooter(fmt.Sprintf("%d cached resource(s), %s · %s", len(infos), formatCacheSize(total), cache.Dir()))
+	return nil
+}
+
+// runCacheRefresh implements `zh cache refresh [resource...]`.
+func runCacheRefresh(cmd *cobra.Command, args []string) error {
+	resources := cacheRefreshResources
+	if len(args) > 0 {
+		resources = nil
+		for _, arg := range args {
+			i := slices.IndexFunc(cacheRefreshResources, func(r cacheRefreshResource) bool { return r.name == arg })
+			if i < 0 {
+				return exitcode.Usage(fmt.Sprintf("unknown resource %q — use one of: %s", arg, strings.Join(cacheRefreshResourceNames(), ", ")))
+			}
+			if !slices.ContainsFunc(resources, func(r cacheRefreshResource) bool { return r.name == arg }) {
+				resources = append(resources, cacheRefreshResources[i])
+			}
+		}
+	}
+
+	cfg, err := requireWorkspace()
+	if err != nil {
+		return err
+	}
+
+	client := newClient(cfg, cmd)
+	ctx := commandContext(cmd)
+	w := cmd.OutOrStdout()
+
+	counts := make([]int, len(resources))
+	errs := make([]error, len(resources))
+	var wg sync.WaitGroup
+	for i, r := range resources {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			counts[i], errs[i] = r.fetch(ctx, client, cfg.Workspace)
+		}()
+	}
+	wg.Wait()
+
+	if output.IsJSON(outputFormat) {
+		type jsonResult struct {
+			Resource string `json:"resource"`
+			Count    int    `json:"count"`
+			Error    string `json:"error,omitempty"`
+		}
+		out := make([]jsonResult, len(resources))
+		for i, r := range resources {
+			out[i] = jsonResult{Resource: r.name, Count: counts[i]}
+			if errs[i] != nil {
+				out[i].Error = errs[i].Error()
+			}
+		}
+		if err := output.JSON(w, out); err != nil {
+			return err
+		}
+		return cacheRefreshError(resources, errs)
+	}
+
+	for i, r := range resources {
+		if errs[i] != nil {
+			fmt.Fprintf(w, "  %s %s: %v\n", output.Red("✗"), r.name, errs[i])
+			continue
+		}
+		fmt.Fprintf(w, "  %s %s: %d cached\n", output.Green("✓"), r.name, counts[i])
+	}
+	if err := cacheRefreshError(resources, errs); err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "\nRefreshed %d resource(s).\n", len(resources))
+	return nil
+}
+
+// cacheRefreshError returns an error naming the resources that failed to
+// refresh, or nil if all of them succeeded.
+func cacheRefreshError(resources []cacheRefreshResource, errs []error) error {
+	var failed []string
+	var first error
+	for i, err := range errs {
+		if err != nil {
+			failed = append(failed, resources[i].name)
+			if first == nil {
+				first = err
+			}
+		}
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+	return exitcode.General("refreshing "+strings.Join(failed, ", "), first)
+}
+
+// formatCacheTTL formats a TTL for display, e.g. "24h" or "10m".
+func formatCacheTTL(d time.Duration) string {
+	if d == 0 {
+		return "never expires"
+	}
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
+
+// formatCacheSize formats a file size for display, e.g. "12.3 KB".
+func formatCacheSize(n int64) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(n)/(1<<10))
+	default:
+		return fmt.Sprintf("%d B", n)
+	}
+}
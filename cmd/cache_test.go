@@ -2,12 +2,16 @@ package cmd
 
 import (
 	"bytes"
+	"encoding/json"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/dslh/zh/internal/cache"
+	"github.com/dslh/zh/internal/exitcode"
+	"github.com/dslh/zh/internal/resolve"
+	"github.com/dslh/zh/internal/testutil"
 )
 
 func resetCacheFlags() {
@@ -181,3 +185,113 @@ func TestCacheClearPreservesNonJsonFiles(t *testing.T) {
 		t.Error("JSON cache file should be removed")
 	}
 }
+
+func TestCacheStatus(t *testing.T) {
+	ms := testutil.NewMockServer(t)
+	setupIssueTestEnv(t, ms)
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"cache", "status"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("cache status returned error: %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{"RESOURCE", "pipelines", "fresh", "24h", "not cached", "1 cached resource(s)"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestCacheStatusJSON(t *testing.T) {
+	ms := testutil.NewMockServer(t)
+	setupIssueTestEnv(t, ms)
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"cache", "status", "--output=json"})
+	defer func() { outputFormat = "" }()
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("cache status returned error: %v", err)
+	}
+
+	var entries []struct {
+		Resource string `json:"resource"`
+		Cached   bool   `json:"cached"`
+		TTL      string `json:"ttl"`
+		Stale    bool   `json:"stale"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &entries); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, buf.String())
+	}
+	if len(entries) != 7 || entries[0].Resource != "pipelines" || !entries[0].Cached || entries[0].Stale {
+		t.Errorf("unexpected entries: %+v", entries)
+	}
+	if entries[1].Resource != "sprints" || entries[1].Cached || entries[1].TTL != "1h0m0s" {
+		t.Errorf("sprints entry = %+v, want uncached with a 1h TTL", entries[1])
+	}
+}
+
+func TestCacheRefresh(t *testing.T) {
+	ms := testutil.NewMockServer(t)
+	ms.HandleQuery("GetWorkspaceLabels", labelListResponse())
+	ms.HandleQuery("GetWorkspacePriorities", priorityListResponse())
+	setupIssueTestEnv(t, ms)
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"cache", "refresh", "labels", "priorities"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("cache refresh returned error: %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{"labels: 3 cached", "priorities: 4 cached", "Refreshed 2 resource(s)."} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "pipelines") {
+		t.Errorf("only the named resources should be refreshed:\n%s", out)
+	}
+	if labels, ok := cache.Get[[]resolve.CachedLabel](resolve.LabelCacheKey("ws-123")); !ok || len(labels) != 3 {
+		t.Errorf("label cache = %v, %v; want 3 labels", labels, ok)
+	}
+}
+
+func TestCacheRefreshReportsFailures(t *testing.T) {
+	ms := testutil.NewMockServer(t)
+	ms.HandleQuery("GetWorkspaceLabels", labelListResponse())
+	ms.HandleQuery("GetWorkspacePriorities", map[string]any{"errors": []any{map[string]any{"message": "boom"}}})
+	setupIssueTestEnv(t, ms)
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"cache", "refresh", "labels", "priorities"})
+
+	err := rootCmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "refreshing priorities") {
+		t.Fatalf("cache refresh error = %v, want a priorities failure", err)
+	}
+	if !strings.Contains(buf.String(), "labels: 3 cached") {
+		t.Errorf("successful resources should still be reported:\n%s", buf.String())
+	}
+}
+
+func TestCacheRefreshUnknownResource(t *testing.T) {
+	ms := testutil.NewMockServer(t)
+	setupIssueTestEnv(t, ms)
+
+	rootCmd.SetOut(new(bytes.Buffer))
+	rootCmd.SetArgs([]string{"cache", "refresh", "milestones"})
+
+	err := rootCmd.Execute()
+	if exitcode.ExitCode(err) != exitcode.UsageError {
+		t.Fatalf("exit code = %d, want usage error (err: %v)", exitcode.ExitCode(err), err)
+	}
+}
@@ -1,8 +1,12 @@
 package cmd
 
 import (
+	"strings"
+
 	"github.com/dslh/zh/internal/cache"
 	"github.com/dslh/zh/internal/config"
+	"github.com/dslh/zh/internal/index"
+	"github.com/dslh/zh/internal/output"
 	"github.com/dslh/zh/internal/resolve"
 	"github.com/spf13/cobra"
 )
@@ -14,7 +18,7 @@ func completePipelineNames(cmd *cobra.Command, args []string, toComplete string)
 		return nil, cobra.ShellCompDirectiveNoFileComp
 	}
 
-	entries, ok := cache.Get[[]resolve.CachedPipeline](resolve.PipelineCacheKey(wsID))
+	entries, ok := cache.GetStale[[]resolve.CachedPipeline](resolve.PipelineCacheKey(wsID))
 	if !ok {
 		return nil, cobra.ShellCompDirectiveNoFileComp
 	}
@@ -41,7 +45,7 @@ func completeSprintNames(cmd *cobra.Command, args []string, toComplete string) (
 		return nil, cobra.ShellCompDirectiveNoFileComp
 	}
 
-	entries, ok := cache.Get[[]resolve.CachedSprint](resolve.SprintCacheKey(wsID))
+	entries, ok := cache.GetStale[[]resolve.CachedSprint](resolve.SprintCacheKey(wsID))
 	if !ok {
 		return nil, cobra.ShellCompDirectiveNoFileComp
 	}
@@ -61,7 +65,7 @@ func completeEpicNames(cmd *cobra.Command, args []string, toComplete string) ([]
 		return nil, cobra.ShellCompDirectiveNoFileComp
 	}
 
-	entries, ok := cache.Get[[]resolve.CachedEpic](resolve.EpicCacheKey(wsID))
+	entries, ok := cache.GetStale[[]resolve.CachedEpic](resolve.EpicCacheKey(wsID))
 	if !ok {
 		return nil, cobra.ShellCompDirectiveNoFileComp
 	}
@@ -90,7 +94,7 @@ func completeWorkspaceNames(cmd *cobra.Command, args []string, toComplete string
 		OrgName     string `json:"orgName"`
 	}
 
-	entries, ok := cache.Get[[]cachedWS](cache.NewKey("workspaces"))
+	entries, ok := cache.GetStale[[]cachedWS](cache.NewKey("workspaces"))
 	if !ok {
 		return nil, cobra.ShellCompDirectiveNoFileComp
 	}
@@ -114,7 +118,7 @@ func completeRepoNames(cmd *cobra.Command, args []string, toComplete string) ([]
 		return nil, cobra.ShellCompDirectiveNoFileComp
 	}
 
-	entries, ok := cache.Get[[]resolve.CachedRepo](resolve.RepoCacheKey(wsID))
+	entries, ok := cache.GetStale[[]resolve.CachedRepo](resolve.RepoCacheKey(wsID))
 	if !ok {
 		return nil, cobra.ShellCompDirectiveNoFileComp
 	}
@@ -134,7 +138,7 @@ func completeLabelNames(cmd *cobra.Command, args []string, toComplete string) ([
 		return nil, cobra.ShellCompDirectiveNoFileComp
 	}
 
-	entries, ok := cache.Get[[]resolve.CachedLabel](resolve.LabelCacheKey(wsID))
+	entries, ok := cache.GetStale[[]resolve.CachedLabel](resolve.LabelCacheKey(wsID))
 	if !ok {
 		return nil, cobra.ShellCompDirectiveNoFileComp
 	}
@@ -154,7 +158,7 @@ func completePriorityNames(cmd *cobra.Command, args []string, toComplete string)
 		return nil, cobra.ShellCompDirectiveNoFileComp
 	}
 
-	entries, ok := cache.Get[[]resolve.CachedPriority](resolve.PriorityCacheKey(wsID))
+	entries, ok := cache.GetStale[[]resolve.CachedPriority](resolve.PriorityCacheKey(wsID))
 	if !ok {
 		return nil, cobra.ShellCompDirectiveNoFileComp
 	}
@@ -167,6 +171,47 @@ func completePriorityNames(cmd *cobra.Command, args []string, toComplete string)
 	return names, cobra.ShellCompDirectiveNoFileComp
 }
 
+// maxIssueCompletions caps the issue references offered at once, so that
+// completing an empty word in a large workspace stays fast.
+const maxIssueCompletions = 200
+
+// completeIssueRefs returns open issue references from the local index,
+// newest first, with their titles as descriptions. Returns nothing until
+// zh sync has built the index.
+func completeIssueRefs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	_, wsID := completionConfig()
+	if wsID == "" {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	idx, ok := index.Load(wsID)
+	if !ok {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	prefix := strings.ToLower(toComplete)
+	var refs []string
+	for _, r := range index.Search(idx.Issues, "") {
+		issue := r.Issue
+		if issue.State == "CLOSED" {
+			continue
+		}
+		ref := issue.Ref()
+		if strings.Contains(prefix, "/") {
+			ref = issue.RepoOwner + "/" + ref
+		}
+		if !strings.HasPrefix(strings.ToLower(ref), prefix) {
+			continue
+		}
+		refs = append(refs, ref+"\t"+issue.Title)
+		if len(refs) == maxIssueCompletions {
+			break
+		}
+	}
+
+	return refs, cobra.ShellCompDirectiveNoFileComp
+}
+
 // completeEpicStates returns valid epic states for shell completion.
 func completeEpicStates(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 	return []string{"open", "todo", "in_progress", "closed"}, cobra.ShellCompDirectiveNoFileComp
@@ -177,9 +222,18 @@ func completePositionValues(cmd *cobra.Command, args []string, toComplete string
 	return []string{"top", "bottom"}, cobra.ShellCompDirectiveNoFileComp
 }
 
+// completeWeekdays returns the days of the week for shell completion.
+func completeWeekdays(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	days := make([]string, len(sprintConfigDays))
+	for i, d := range sprintConfigDays {
+		days[i] = strings.ToLower(d)
+	}
+	return days, cobra.ShellCompDirectiveNoFileComp
+}
+
 // completeOutputFormats returns valid output format values for shell completion.
 func completeOutputFormats(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-	return []string{"json"}, cobra.ShellCompDirectiveNoFileComp
+	return output.Formats, cobra.ShellCompDirectiveNoFileComp
 }
 
 // completionConfig loads config and returns the workspace ID for use in
@@ -21,6 +21,7 @@ func init() {
 	sprintShowCmd.ValidArgsFunction = completeSprintNames
 	sprintScopeCmd.ValidArgsFunction = completeSprintNames
 	sprintReviewCmd.ValidArgsFunction = completeSprintNames
+	sprintRolloverCmd.ValidArgsFunction = completeSprintNames
 
 	// Epic commands: first arg is an epic identifier
 	epicShowCmd.ValidArgsFunction = completeEpicNames
@@ -30,8 +31,6 @@ func init() {
 	epicProgressCmd.ValidArgsFunction = completeEpicNames
 	epicEstimateCmd.ValidArgsFunction = completeEpicNames
 	epicAliasCmd.ValidArgsFunction = completeEpicNames
-	epicAddCmd.ValidArgsFunction = completeEpicNames
-	epicRemoveCmd.ValidArgsFunction = completeEpicNames
 	epicAssigneeAddCmd.ValidArgsFunction = completeEpicNames
 	epicAssigneeRemoveCmd.ValidArgsFunction = completeEpicNames
 	epicLabelAddCmd.ValidArgsFunction = completeEpicNames
@@ -51,6 +50,43 @@ func init() {
 		return nil, cobra.ShellCompDirectiveNoFileComp
 	}
 
+	// Issue commands: args are issue references, from the local index
+	for _, c := range []*cobra.Command{
+		issueShowCmd, issueEditCmd, issueCloseCmd, issueReopenCmd, issueActivityCmd,
+		issueBlockCmd, issueUnblockCmd, issueBlockersCmd, issueBlockingCmd,
+		issueConnectCmd, issueDisconnectCmd, sprintAddCmd, sprintRemoveCmd,
+	} {
+		c.ValidArgsFunction = completeIssueRefs
+	}
+
+	// issue estimate: first arg is an issue
+	issueEstimateCmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return completeIssueRefs(cmd, args, toComplete)
+		}
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	// issue move: issues followed by a pipeline
+	issueMoveCmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		refs, _ := completeIssueRefs(cmd, args, toComplete)
+		if len(args) > 0 {
+			names, _ := completePipelineNames(cmd, args, toComplete)
+			refs = append(refs, names...)
+		}
+		return refs, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	// epic add/remove: an epic followed by issues
+	for _, c := range []*cobra.Command{epicAddCmd, epicRemoveCmd} {
+		c.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) == 0 {
+				return completeEpicNames(cmd, args, toComplete)
+			}
+			return completeIssueRefs(cmd, args, toComplete)
+		}
+	}
+
 	// Workspace commands: first arg is a workspace name
 	workspaceShowCmd.ValidArgsFunction = completeWorkspaceNames
 	workspaceSwitchCmd.ValidArgsFunction = completeWorkspaceNames
@@ -62,21 +98,32 @@ func init() {
 
 	// Pipeline flags
 	registerFlagCompletion(boardCmd, "pipeline", completePipelineNames)
+	registerFlagCompletion(boardEditCmd, "pipeline", completePipelineNames)
+	registerFlagCompletion(triageCmd, "pipeline", completePipelineNames)
 	registerFlagCompletion(issueListCmd, "pipeline", completePipelineNames)
 	registerFlagCompletion(issueReopenCmd, "pipeline", completePipelineNames)
+	registerFlagCompletion(issueCreateCmd, "pipeline", completePipelineNames)
 	registerFlagCompletion(pipelineDeleteCmd, "into", completePipelineNames)
+	registerFlagCompletion(workspaceApplyCmd, "into", completePipelineNames)
+	registerFlagCompletion(sprintPlanCmd, "from-pipeline", completePipelineNames)
 
 	// Sprint flags
 	registerFlagCompletion(issueListCmd, "sprint", completeSprintNames)
 	registerFlagCompletion(sprintAddCmd, "sprint", completeSprintNames)
 	registerFlagCompletion(sprintRemoveCmd, "sprint", completeSprintNames)
+	registerFlagCompletion(sprintPlanCmd, "sprint", completeSprintNames)
+	registerFlagCompletion(sprintRolloverCmd, "to", completeSprintNames)
+	registerFlagCompletion(issueCreateCmd, "sprint", completeSprintNames)
 
 	// Epic flags
 	registerFlagCompletion(issueListCmd, "epic", completeEpicNames)
+	registerFlagCompletion(issueCreateCmd, "epic", completeEpicNames)
 
 	// Repo flags
 	registerFlagCompletion(issueListCmd, "repo", completeRepoNames)
 	registerFlagCompletion(issueShowCmd, "repo", completeRepoNames)
+	registerFlagCompletion(issueCreateCmd, "repo", completeRepoNames)
+	registerFlagCompletion(issueEditCmd, "repo", completeRepoNames)
 	registerFlagCompletion(issueCloseCmd, "repo", completeRepoNames)
 	registerFlagCompletion(issueMoveCmd, "repo", completeRepoNames)
 	registerFlagCompletion(issueEstimateCmd, "repo", completeRepoNames)
@@ -86,7 +133,10 @@ func init() {
 	registerFlagCompletion(issuePriorityCmd, "repo", completeRepoNames)
 	registerFlagCompletion(issueLabelAddCmd, "repo", completeRepoNames)
 	registerFlagCompletion(issueLabelRemoveCmd, "repo", completeRepoNames)
+	registerFlagCompletion(issueAssigneeAddCmd, "repo", completeRepoNames)
+	registerFlagCompletion(issueAssigneeRemoveCmd, "repo", completeRepoNames)
 	registerFlagCompletion(issueBlockCmd, "repo", completeRepoNames)
+	registerFlagCompletion(issueUnblockCmd, "repo", completeRepoNames)
 	registerFlagCompletion(issueBlockersCmd, "repo", completeRepoNames)
 	registerFlagCompletion(issueBlockingCmd, "repo", completeRepoNames)
 	registerFlagCompletion(issueActivityCmd, "repo", completeRepoNames)
@@ -99,6 +149,10 @@ func init() {
 	// Position flags
 	registerFlagCompletion(issueMoveCmd, "position", completePositionValues)
 	registerFlagCompletion(issueReopenCmd, "position", completePositionValues)
+
+	// Sprint configuration flags
+	registerFlagCompletion(sprintConfigSetCmd, "start-day", completeWeekdays)
+	registerFlagCompletion(sprintConfigSetCmd, "end-day", completeWeekdays)
 }
 
 // registerFlagCompletion is a helper that registers a flag completion function,
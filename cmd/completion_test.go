@@ -4,8 +4,10 @@ import (
 	"bytes"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/dslh/zh/internal/cache"
+	"github.com/dslh/zh/internal/index"
 	"github.com/dslh/zh/internal/resolve"
 	"github.com/spf13/cobra"
 )
@@ -295,6 +297,38 @@ func TestCompletePriorityNames(t *testing.T) {
 	}
 }
 
+func TestCompleteIssueRefs(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("ZH_API_KEY", "test-key")
+	t.Setenv("ZH_WORKSPACE", "ws-123")
+	t.Setenv("ZH_GITHUB_TOKEN", "")
+
+	_ = index.Save(&index.Index{WorkspaceID: "ws-123", Issues: []index.Issue{
+		{ID: "i1", Number: 1, RepoName: "api", RepoOwner: "acme", Title: "Fix login", State: "OPEN", UpdatedAt: time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)},
+		{ID: "i2", Number: 2, RepoName: "api", RepoOwner: "acme", Title: "Old bug", State: "CLOSED"},
+		{ID: "i3", Number: 3, RepoName: "web", RepoOwner: "acme", Title: "Dark mode", State: "OPEN", UpdatedAt: time.Date(2026, 10, 2, 0, 0, 0, 0, time.UTC)},
+	}})
+
+	tests := []struct {
+		toComplete string
+		want       []string
+	}{
+		{"", []string{"web#3\tDark mode", "api#1\tFix login"}},
+		{"API", []string{"api#1\tFix login"}},
+		{"acme/web", []string{"acme/web#3\tDark mode"}},
+	}
+	for _, tt := range tests {
+		refs, directive := completeIssueRefs(nil, nil, tt.toComplete)
+		if directive != cobra.ShellCompDirectiveNoFileComp {
+			t.Errorf("directive = %v, want NoFileComp", directive)
+		}
+		if strings.Join(refs, ",") != strings.Join(tt.want, ",") {
+			t.Errorf("completeIssueRefs(%q) = %q, want %q", tt.toComplete, refs, tt.want)
+		}
+	}
+}
+
 func TestCompleteNoCacheReturnsEmpty(t *testing.T) {
 	t.Setenv("XDG_CACHE_HOME", t.TempDir())
 	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
@@ -310,6 +344,7 @@ func TestCompleteNoCacheReturnsEmpty(t *testing.T) {
 		"repo":     completeRepoNames,
 		"label":    completeLabelNames,
 		"priority": completePriorityNames,
+		"issue":    completeIssueRefs,
 	} {
 		names, directive := fn(nil, nil, "")
 		if directive != cobra.ShellCompDirectiveNoFileComp {
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/dslh/zh/internal/exitcode"
+)
+
+// editFunc opens content in the user's editor and returns the edited text.
+// It is a variable so tests can substitute a scripted editor.
+var editFunc = editInEditor
+
+// editorCommand returns the user's preferred editor, falling back to vi when
+// neither variable is set or both are blank.
+func editorCommand() string {
+	if e := strings.TrimSpace(os.Getenv("VISUAL")); e != "" {
+		return e
+	}
+	if e := strings.TrimSpace(os.Getenv("EDITOR")); e != "" {
+		return e
+	}
+	return "vi"
+}
+
+// editInEditor writes content to a temporary file, opens it in $VISUAL or
+// $EDITOR, and returns the saved contents once the editor exits.
+func editInEditor(content, pattern string) (string, error) {
+	f, err := os.CreateTemp("", pattern)
+	if err != nil {
+		return "", exitcode.General("creating temporary file", err)
+	}
+	path := f.Name()
+	defer os.Remove(path)
+
+	if _, err := f.WriteString(content); err != nil {
+		f.Close()
+		return "", exitcode.General("writing temporary file", err)
+	}
+	if err := f.Close(); err != nil {
+		return "", exitcode.General("writing temporary file", err)
+	}
+
+	// The editor setting may carry arguments, e.g. "code --wait"
+	parts := strings.Fields(editorCommand())
+	c := exec.Command(parts[0], append(parts[1:], path)...)
+	c.Stdin = os.Stdin
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+	if err := c.Run(); err != nil {
+		return "", exitcode.General(fmt.Sprintf("running editor %q", parts[0]), err)
+	}
+
+	edited, err := os.ReadFile(path)
+	if err != nil {
+		return "", exitcode.General("reading edited file", err)
+	}
+	return string(edited), nil
+}
@@ -0,0 +1,23 @@
+package cmd
+
+import "testing"
+
+func TestEditorCommand(t *testing.T) {
+	tests := []struct {
+		visual, editor string
+		want           string
+	}{
+		{"", "", "vi"},
+		{"code --wait", "nano", "code --wait"},
+		{"", "nano", "nano"},
+		{"  ", "nano", "nano"},
+		{" ", "\t", "vi"},
+	}
+	for _, tt := range tests {
+		t.Setenv("VISUAL", tt.visual)
+		t.Setenv("EDITOR", tt.editor)
+		if got := editorCommand(); got != tt.want {
+			t.Errorf("editorCommand() with VISUAL=%q EDITOR=%q = %q, want %q", tt.visual, tt.editor, got, tt.want)
+		}
+	}
+}
@@ -1,8 +1,10 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"strings"
 	"time"
 
@@ -384,7 +386,8 @@ var epicListCmd = &cobra.Command{
 	Short: "List epics in the workspace",
 	Long: `List all epics in the current workspace, including both ZenHub epics
 and legacy (issue-backed) epics.`,
-	RunE: runEpicList,
+	RunE:        runEpicList,
+	Annotations: map[string]string{tabularAnnotation: "true"},
 }
 
 var epicProgressCmd = &cobra.Command{
@@ -451,10 +454,15 @@ func resetEpicFlags() {
 
 // runEpicList implements `zh epic list`.
 func runEpicList(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+
 	cfg, err := requireWorkspace()
 	if err != nil {
 		return err
 	}
+	if offline {
+		return runEpicListOffline(cmd, cfg.Workspace)
+	}
 
 	client := newClient(cfg, cmd)
 	w := cmd.OutOrStdout()
@@ -464,7 +472,16 @@ func runEpicList(cmd *cobra.Command, args []string) error {
 		limit = 0
 	}
 
-	epics, totalCount, err := fetchEpicList(client, cfg.Workspace, limit)
+	if output.IsNDJSON(outputFormat) {
+		epics, err := streamEpicList(ctx, client, cfg.Workspace, limit, w)
+		if err != nil {
+			return err
+		}
+		cacheEpicsFromList(epics, cfg.Workspace)
+		return nil
+	}
+
+	epics, totalCount, err := fetchEpicList(ctx, client, cfg.Workspace, limit)
 	if err != nil {
 		return err
 	}
@@ -524,12 +541,12 @@ func runEpicList(cmd *cobra.Command, args []string) error {
 // fetchEpicList fetches epics by combining the zenhubEpics query (for all
 // standalone epics) with the roadmap query (for legacy epics). Results are
 // deduplicated by ID.
-func fetchEpicList(client *api.Client, workspaceID string, limit int) ([]epicListEntry, int, error) {
+func fetchEpicList(ctx context.Context, client *api.Client, workspaceID string, limit int) ([]epicListEntry, int, error) {
 	seen := make(map[string]bool)
 	var allEpics []epicListEntry
 
 	// 1. Fetch all ZenHub epics via the dedicated query.
-	zenhubEpics, zenhubTotal, err := fetchZenhubEpicList(client, workspaceID, limit)
+	zenhubEpics, zenhubTotal, err := fetchZenhubEpicList(ctx, client, workspaceID, limit)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -547,7 +564,7 @@ func fetchEpicList(client *api.Client, workspaceID string, limit int) ([]epicLis
 			legacyLimit = 1
 		}
 	}
-	legacyEpics, legacyTotal, err := fetchLegacyEpicList(client, workspaceID, legacyLimit)
+	legacyEpics, legacyTotal, err := fetchLegacyEpicList(ctx, client, workspaceID, legacyLimit)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -568,16 +585,69 @@ func fetchEpicList(client *api.Client, workspaceID string, limit int) ([]epicLis
 	return allEpics, totalCount, nil
 }
 
+// streamEpicList writes the epic list as NDJSON a page at a time, in the same
+// order and with the same deduplication as fetchEpicList. Returns the epics
+// written so they can be cached for resolution.
+func streamEpicList(ctx context.Context, client *api.Client, workspaceID string, limit int, w io.Writer) ([]epicListEntry, error) {
+	seen := make(map[string]bool)
+	var written []epicListEntry
+
+	emit := func(epics []epicListEntry, _ int) error {
+		var page []epicListEntry
+		for _, e := range epics {
+			if seen[e.ID] || (limit > 0 && len(written)+len(page) >= limit) {
+				continue
+			}
+			seen[e.ID] = true
+			page = append(page, e)
+		}
+		written = append(written, page...)
+		return output.NDJSON(w, page)
+	}
+
+	if err := forEachZenhubEpicPage(ctx, client, workspaceID, limit, emit); err != nil {
+		return nil, err
+	}
+	if limit > 0 && len(written) >= limit {
+		return written, nil
+	}
+
+	legacyLimit := 0
+	if limit > 0 {
+		legacyLimit = limit - len(written)
+	}
+	if err := forEachLegacyEpicPage(ctx, client, workspaceID, legacyLimit, emit); err != nil {
+		return nil, err
+	}
+	return written, nil
+}
+
 // fetchZenhubEpicList fetches ZenHub epics with full details for the list view.
-func fetchZenhubEpicList(client *api.Client, workspaceID string, limit int) ([]epicListEntry, int, error) {
+func fetchZenhubEpicList(ctx context.Context, client *api.Client, workspaceID string, limit int) ([]epicListEntry, int, error) {
 	var allEpics []epicListEntry
-	var cursor *string
 	totalCount := 0
+	err := forEachZenhubEpicPage(ctx, client, workspaceID, limit, func(epics []epicListEntry, total int) error {
+		allEpics = append(allEpics, epics...)
+		totalCount = total
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return allEpics, totalCount, nil
+}
+
+// forEachZenhubEpicPage pages through ZenHub epics, calling fn with each page
+// as it arrives. It stops after limit epics (0 for no limit), or as soon as
+// fn returns an error, which is passed through.
+func forEachZenhubEpicPage(ctx context.Context, client *api.Client, workspaceID string, limit int, fn func(epics []epicListEntry, totalCount int) error) error {
+	var cursor *string
+	fetched := 0
 	pageSize := 50
 
 	for {
 		if limit > 0 {
-			remaining := limit - len(allEpics)
+			remaining := limit - fetched
 			if remaining <= 0 {
 				break
 			}
@@ -594,9 +664,9 @@ func fetchZenhubEpicList(client *api.Client, workspaceID string, limit int) ([]e
 			vars["after"] = *cursor
 		}
 
-		data, err := client.Execute(listZenhubEpicsFullQuery, vars)
+		data, err := client.Execute(ctx, listZenhubEpicsFullQuery, vars)
 		if err != nil {
-			return nil, 0, exitcode.General("fetching zenhub epics", err)
+			return exitcode.General("fetching zenhub epics", err)
 		}
 
 		var resp struct {
@@ -612,40 +682,59 @@ func fetchZenhubEpicList(client *api.Client, workspaceID string, limit int) ([]e
 			} `json:"workspace"`
 		}
 		if err := json.Unmarshal(data, &resp); err != nil {
-			return nil, 0, exitcode.General("parsing zenhub epics response", err)
+			return exitcode.General("parsing zenhub epics response", err)
 		}
 
-		totalCount = resp.Workspace.ZenhubEpics.TotalCount
-
+		var page []epicListEntry
 		for _, raw := range resp.Workspace.ZenhubEpics.Nodes {
 			if entry, ok := parseZenhubEpicListItem(raw); ok {
-				allEpics = append(allEpics, entry)
+				page = append(page, entry)
 			}
 		}
+		fetched += len(page)
+		if err := fn(page, resp.Workspace.ZenhubEpics.TotalCount); err != nil {
+			return err
+		}
 
 		if !resp.Workspace.ZenhubEpics.PageInfo.HasNextPage {
 			break
 		}
-		if limit > 0 && len(allEpics) >= limit {
+		if limit > 0 && fetched >= limit {
 			break
 		}
 
 		cursor = &resp.Workspace.ZenhubEpics.PageInfo.EndCursor
 	}
 
-	return allEpics, totalCount, nil
+	return nil
 }
 
 // fetchLegacyEpicList fetches legacy (issue-backed) epics with full details.
-func fetchLegacyEpicList(client *api.Client, workspaceID string, limit int) ([]epicListEntry, int, error) {
+func fetchLegacyEpicList(ctx context.Context, client *api.Client, workspaceID string, limit int) ([]epicListEntry, int, error) {
 	var allEpics []epicListEntry
-	var cursor *string
 	totalCount := 0
+	err := forEachLegacyEpicPage(ctx, client, workspaceID, limit, func(epics []epicListEntry, total int) error {
+		allEpics = append(allEpics, epics...)
+		totalCount = total
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return allEpics, totalCount, nil
+}
+
+// forEachLegacyEpicPage pages through legacy epics, calling fn with each page
+// as it arrives. It stops after limit epics (0 for no limit), or as soon as
+// fn returns an error, which is passed through.
+func forEachLegacyEpicPage(ctx context.Context, client *api.Client, workspaceID string, limit int, fn func(epics []epicListEntry, totalCount int) error) error {
+	var cursor *string
+	fetched := 0
 	pageSize := 50
 
 	for {
 		if limit > 0 {
-			remaining := limit - len(allEpics)
+			remaining := limit - fetched
 			if remaining <= 0 {
 				break
 			}
@@ -662,9 +751,9 @@ func fetchLegacyEpicList(client *api.Client, workspaceID string, limit int) ([]e
 			vars["after"] = *cursor
 		}
 
-		data, err := client.Execute(listLegacyEpicsFullQuery, vars)
+		data, err := client.Execute(ctx, listLegacyEpicsFullQuery, vars)
 		if err != nil {
-			return nil, 0, exitcode.General("fetching legacy epics", err)
+			return exitcode.General("fetching legacy epics", err)
 		}
 
 		var resp struct {
@@ -709,11 +798,10 @@ func fetchLegacyEpicList(client *api.Client, workspaceID string, limit int) ([]e
 			} `json:"workspace"`
 		}
 		if err := json.Unmarshal(data, &resp); err != nil {
-			return nil, 0, exitcode.General("parsing legacy epics response", err)
+			return exitcode.General("parsing legacy epics response", err)
 		}
 
-		totalCount = resp.Workspace.Epics.TotalCount
-
+		var page []epicListEntry
 		for _, n := range resp.Workspace.Epics.Nodes {
 			entry := epicListEntry{
 				ID:          n.ID,
@@ -733,20 +821,24 @@ func fetchLegacyEpicList(client *api.Client, workspaceID string, limit int) ([]e
 			if n.IssueEstimateProgress != nil {
 				entry.IssueEstimateProgress = *n.IssueEstimateProgress
 			}
-			allEpics = append(allEpics, entry)
+			page = append(page, entry)
+		}
+		fetched += len(page)
+		if err := fn(page, resp.Workspace.Epics.TotalCount); err != nil {
+			return err
 		}
 
 		if !resp.Workspace.Epics.PageInfo.HasNextPage {
 			break
 		}
-		if limit > 0 && len(allEpics) >= limit {
+		if limit > 0 && fetched >= limit {
 			break
 		}
 
 		cursor = &resp.Workspace.Epics.PageInfo.EndCursor
 	}
 
-	return allEpics, totalCount, nil
+	return nil
 }
 
 // parseZenhubEpicListItem parses a ZenHub epic node from the zenhubEpics query.
@@ -808,6 +900,8 @@ func cacheEpicsFromList(epics []epicListEntry, workspaceID string) {
 
 // runEpicShow implements `zh epic show [epic]`.
 func runEpicShow(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+
 	cfg, err := requireWorkspace()
 	if err != nil {
 		return err
@@ -819,7 +913,7 @@ func runEpicShow(cmd *cobra.Command, args []string) error {
 	var identifier string
 	if epicShowInteractive {
 		identifier, err = interactiveOrArg(cmd, nil, true, func() ([]selectItem, error) {
-			return fetchEpicSelectItems(client, cfg.Workspace)
+			return fetchEpicSelectItems(ctx, client, cfg.Workspace)
 		}, "Select an epic")
 		if err != nil {
 			return err
@@ -832,7 +926,7 @@ func runEpicShow(cmd *cobra.Command, args []string) error {
 	}
 
 	// Resolve the epic
-	resolved, err := resolve.Epic(client, cfg.Workspace, identifier, cfg.Aliases.Epics)
+	resolved, err := resolve.Epic(ctx, client, cfg.Workspace, identifier, cfg.Aliases.Epics)
 	if err != nil {
 		return err
 	}
@@ -840,18 +934,18 @@ func runEpicShow(cmd *cobra.Command, args []string) error {
 	// Fetch full details based on type
 	switch resolved.Type {
 	case "zenhub":
-		return runEpicShowZenhub(client, cfg.Workspace, resolved.ID, w)
+		return runEpicShowZenhub(ctx, client, cfg.Workspace, resolved.ID, w)
 	case "legacy":
-		return runEpicShowLegacy(client, resolved.ID, w)
+		return runEpicShowLegacy(ctx, client, resolved.ID, w)
 	default:
 		// Unknown type — try zenhub first, fall back to legacy
-		return runEpicShowZenhub(client, cfg.Workspace, resolved.ID, w)
+		return runEpicShowZenhub(ctx, client, cfg.Workspace, resolved.ID, w)
 	}
 }
 
 // fetchEpicSelectItems fetches epics and converts them to selectItems for interactive mode.
-func fetchEpicSelectItems(client *api.Client, workspaceID string) ([]selectItem, error) {
-	epics, _, err := fetchEpicList(client, workspaceID, 0)
+func fetchEpicSelectItems(ctx context.Context, client *api.Client, workspaceID string) ([]selectItem, error) {
+	epics, _, err := fetchEpicList(ctx, client, workspaceID, 0)
 	if err != nil {
 		return nil, err
 	}
@@ -872,8 +966,8 @@ func fetchEpicSelectItems(client *api.Client, workspaceID string) ([]selectItem,
 }
 
 // runEpicShowZenhub renders a ZenHub epic detail view.
-func runEpicShowZenhub(client *api.Client, workspaceID, epicID string, w writerFlusher) error {
-	data, err := client.Execute(epicShowZenhubQuery, map[string]any{
+func runEpicShowZenhub(ctx context.Context, client *api.Client, workspaceID, epicID string, w writerFlusher) error {
+	data, err := client.Execute(ctx, epicShowZenhubQuery, map[string]any{
 		"id":          epicID,
 		"workspaceId": workspaceID,
 	})
@@ -1020,8 +1114,8 @@ func runEpicShowZenhub(client *api.Client, workspaceID, epicID string, w writerF
 }
 
 // runEpicShowLegacy renders a legacy epic detail view.
-func runEpicShowLegacy(client *api.Client, epicID string, w writerFlusher) error {
-	data, err := client.Execute(epicShowLegacyQuery, map[string]any{
+func runEpicShowLegacy(ctx context.Context, client *api.Client, epicID string, w writerFlusher) error {
+	data, err := client.Execute(ctx, epicShowLegacyQuery, map[string]any{
 		"id": epicID,
 	})
 	if err != nil {
@@ -1166,6 +1260,8 @@ const epicProgressLegacyQuery = `query GetLegacyEpicProgress($id: ID!) {
 
 // runEpicProgress implements `zh epic progress <epic>`.
 func runEpicProgress(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+
 	cfg, err := requireWorkspace()
 	if err != nil {
 		return err
@@ -1175,22 +1271,22 @@ func runEpicProgress(cmd *cobra.Command, args []string) error {
 	w := cmd.OutOrStdout()
 
 	// Resolve the epic
-	resolved, err := resolve.Epic(client, cfg.Workspace, args[0], cfg.Aliases.Epics)
+	resolved, err := resolve.Epic(ctx, client, cfg.Workspace, args[0], cfg.Aliases.Epics)
 	if err != nil {
 		return err
 	}
 
 	switch resolved.Type {
 	case "legacy":
-		return runEpicProgressLegacy(client, resolved.ID, w)
+		return runEpicProgressLegacy(ctx, client, resolved.ID, w)
 	default:
-		return runEpicProgressZenhub(client, cfg.Workspace, resolved.ID, w)
+		return runEpicProgressZenhub(ctx, client, cfg.Workspace, resolved.ID, w)
 	}
 }
 
 // runEpicProgressZenhub shows progress for a ZenHub epic.
-func runEpicProgressZenhub(client *api.Client, workspaceID, epicID string, w writerFlusher) error {
-	data, err := client.Execute(epicProgressZenhubQuery, map[string]any{
+func runEpicProgressZenhub(ctx context.Context, client *api.Client, workspaceID, epicID string, w writerFlusher) error {
+	data, err := client.Execute(ctx, epicProgressZenhubQuery, map[string]any{
 		"id": epicID,
 	})
 	if err != nil {
@@ -1265,8 +1361,8 @@ func runEpicProgressZenhub(client *api.Client, workspaceID, epicID string, w wri
 }
 
 // runEpicProgressLegacy shows progress for a legacy epic.
-func runEpicProgressLegacy(client *api.Client, epicID string, w writerFlusher) error {
-	data, err := client.Execute(epicProgressLegacyQuery, map[string]any{
+func runEpicProgressLegacy(ctx context.Context, client *api.Client, epicID string, w writerFlusher) error {
+	data, err := client.Execute(ctx, epicProgressLegacyQuery, map[string]any{
 		"id": epicID,
 	})
 	if err != nil {
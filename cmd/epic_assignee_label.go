@@ -206,6 +206,8 @@ func runEpicAssigneeRemove(cmd *cobra.Command, args []string) error {
 }
 
 func runEpicAssigneeOp(cmd *cobra.Command, args []string, op string, dryRun, continueOnError bool) error {
+	ctx := commandContext(cmd)
+
 	cfg, err := requireWorkspace()
 	if err != nil {
 		return err
@@ -215,7 +217,7 @@ func runEpicAssigneeOp(cmd *cobra.Command, args []string, op string, dryRun, con
 	w := cmd.OutOrStdout()
 
 	// Resolve the epic
-	resolved, err := resolve.Epic(client, cfg.Workspace, args[0], cfg.Aliases.Epics)
+	resolved, err := resolve.Epic(ctx, client, cfg.Workspace, args[0], cfg.Aliases.Epics)
 	if err != nil {
 		return err
 	}
@@ -231,7 +233,7 @@ func runEpicAssigneeOp(cmd *cobra.Command, args []string, op string, dryRun, con
 	var failed []output.FailedItem
 
 	for _, arg := range userArgs {
-		user, err := resolve.User(client, cfg.Workspace, arg)
+		user, err := resolve.User(ctx, client, cfg.Workspace, arg)
 		if err != nil {
 			if continueOnError {
 				failed = append(failed, output.FailedItem{
@@ -277,7 +279,7 @@ func runEpicAssigneeOp(cmd *cobra.Command, args []string, op string, dryRun, con
 		mutationKey = "removeAssigneesFromZenhubEpics"
 	}
 
-	data, err := client.Execute(mutation, map[string]any{
+	data, err := client.Execute(ctx, mutation, map[string]any{
 		"input": map[string]any{
 			"zenhubEpicIds": []string{resolved.ID},
 			"assigneeIds":   userIDs,
@@ -407,6 +409,8 @@ func runEpicLabelRemove(cmd *cobra.Command, args []string) error {
 }
 
 func runEpicLabelOp(cmd *cobra.Command, args []string, op string, dryRun, continueOnError bool) error {
+	ctx := commandContext(cmd)
+
 	cfg, err := requireWorkspace()
 	if err != nil {
 		return err
@@ -416,7 +420,7 @@ func runEpicLabelOp(cmd *cobra.Command, args []string, op string, dryRun, contin
 	w := cmd.OutOrStdout()
 
 	// Resolve the epic
-	resolved, err := resolve.Epic(client, cfg.Workspace, args[0], cfg.Aliases.Epics)
+	resolved, err := resolve.Epic(ctx, client, cfg.Workspace, args[0], cfg.Aliases.Epics)
 	if err != nil {
 		return err
 	}
@@ -434,7 +438,7 @@ func runEpicLabelOp(cmd *cobra.Command, args []string, op string, dryRun, contin
 	if continueOnError {
 		// Resolve one at a time for granular error reporting
 		for _, arg := range labelArgs {
-			label, err := resolve.ZenhubLabel(client, cfg.Workspace, arg)
+			label, err := resolve.ZenhubLabel(ctx, client, cfg.Workspace, arg)
 			if err != nil {
 				failed = append(failed, output.FailedItem{
 					Ref:    arg,
@@ -446,7 +450,7 @@ func runEpicLabelOp(cmd *cobra.Command, args []string, op string, dryRun, contin
 		}
 	} else {
 		// Resolve all at once (stops on first error)
-		labels, err = resolve.ZenhubLabels(client, cfg.Workspace, labelArgs)
+		labels, err = resolve.ZenhubLabels(ctx, client, cfg.Workspace, labelArgs)
 		if err != nil {
 			return err
 		}
@@ -484,7 +488,7 @@ func runEpicLabelOp(cmd *cobra.Command, args []string, op string, dryRun, contin
 		mutationKey = "removeZenhubLabelsFromZenhubEpics"
 	}
 
-	data, err := client.Execute(mutation, map[string]any{
+	data, err := client.Execute(ctx, mutation, map[string]any{
 		"input": map[string]any{
 			"zenhubEpicIds":  []string{resolved.ID},
 			"zenhubLabelIds": labelIDs,
@@ -544,6 +544,7 @@ func zenhubUsersResponse() map[string]any {
 							"id":   "user-1",
 							"name": "John Doe",
 							"githubUser": map[string]any{
+								"id":    "gh-user-1",
 								"login": "johndoe",
 							},
 						},
@@ -551,6 +552,7 @@ func zenhubUsersResponse() map[string]any {
 							"id":   "user-2",
 							"name": "Jane Doe",
 							"githubUser": map[string]any{
+								"id":    "gh-user-2",
 								"login": "janedoe",
 							},
 						},
@@ -0,0 +1,557 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dslh/zh/internal/api"
+	"github.com/dslh/zh/internal/exitcode"
+	"github.com/dslh/zh/internal/output"
+	"github.com/dslh/zh/internal/resolve"
+	"github.com/spf13/cobra"
+)
+
+// GraphQL queries for epic forecasts
+
+const epicForecastZenhubQuery = `query GetZenhubEpicForecast($id: ID!) {
+  node(id: $id) {
+    ... on ZenhubEpic {
+      id
+      title
+      state
+      endOn
+      zenhubIssueCountProgress { open closed total }
+      zenhubIssueEstimateProgress { open closed total }
+      keyDates(first: 50) {
+        nodes {
+          id
+          date
+          description
+          color
+        }
+      }
+    }
+  }
+}`
+
+const epicForecastLegacyQuery = `query GetLegacyEpicForecast($id: ID!) {
+  node(id: $id) {
+    ... on Epic {
+      id
+      endOn
+      issue {
+        title
+        number
+        state
+        repository { name ownerName }
+      }
+      issueCountProgress { open closed total }
+      issueEstimateProgress { open closed total }
+    }
+  }
+}`
+
+// forecastPercentiles are the completion percentiles reported by a forecast.
+var forecastPercentiles = []int{50, 85, 95}
+
+// maxForecastSprints bounds a single simulated trial.
+const maxForecastSprints = 1000
+
+// Command
+
+var epicForecastCmd = &cobra.Command{
+	Use:   "forecast <epic>",
+	Short: "Forecast when an epic will be done",
+	Long: `Forecast when an epic will be done by simulating its remaining estimate
+against the workspace's recent sprint velocity.
+
+Each trial draws a velocity at random from the last --sprints closed sprints
+for every sprint until the epic's open estimate is used up. The 50th, 85th
+and 95th percentile finish dates across all trials are reported. The active
+sprint counts for the share of it that is left. Use --capacity when only
+part of the team works on the epic.
+
+The forecast is compared with the epic's end date and upcoming key dates,
+showing the chance of finishing by each. A target is on track when the 85th
+percentile finish is in time, and at risk when only the 50th percentile is.
+The epic is flagged as at risk when any target isn't on track.
+
+Unestimated issues are not counted.
+
+The epic can be specified as:
+  - ZenHub ID
+  - exact title or unique title substring
+  - owner/repo#number (for legacy epics)
+  - an alias set with 'zh epic alias'
+
+Examples:
+  zh epic forecast "Q1 Platform"
+  zh epic forecast "Q1 Platform" --sprints=10 --capacity=50`,
+	Args: cobra.ExactArgs(1),
+	RunE: runEpicForecast,
+}
+
+var (
+	forecastSprints  int
+	forecastCapacity int
+	forecastTrials   int
+	forecastSeed     uint64
+)
+
+func init() {
+	epicForecastCmd.Flags().IntVar(&forecastSprints, "sprints", 6, "Number of recent closed sprints to sample velocity from")
+	epicForecastCmd.Flags().IntVar(&forecastCapacity, "capacity", 100, "Percentage of the team's velocity spent on the epic")
+	epicForecastCmd.Flags().IntVar(&forecastTrials, "trials", 10000, "Number of simulated trials")
+	epicForecastCmd.Flags().Uint64Var(&forecastSeed, "seed", 0, "Random seed, for repeatable forecasts (default random)")
+
+	epicCmd.AddCommand(epicForecastCmd)
+}
+
+func resetEpicForecastFlags() {
+	forecastSprints = 6
+	forecastCapacity = 100
+	forecastTrials = 10000
+	forecastSeed = 0
+}
+
+// epicForecastTarget is the part of an epic a forecast needs, for either
+// kind of epic.
+type epicForecastTarget struct {
+	ID         string
+	Title      string
+	State      string
+	EndOn      string
+	OpenIssues int
+	Remaining  float64
+	Total      float64
+	KeyDates   []keyDateNode
+}
+
+// forecastVelocity is the sprint history a forecast samples from.
+type forecastVelocity struct {
+	Sprints []velocitySprintEntry
+	// Length is the average length of the sampled sprints.
+	Length time.Duration
+	// Active is the sprint in progress, if any.
+	Active *velocitySprintEntry
+}
+
+// forecastOutcome is when one simulated trial finished.
+type forecastOutcome struct {
+	Sprints int
+	Finish  time.Time
+}
+
+// forecastTargetResult is the chance of finishing by an end date or key date.
+type forecastTargetResult struct {
+	Kind        string  `json:"kind"`
+	Description string  `json:"description"`
+	Date        string  `json:"date"`
+	Probability float64 `json:"probability"`
+	Status      string  `json:"status"`
+}
+
+// runEpicForecast implements `zh epic forecast <epic>`.
+func runEpicForecast(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+
+	cfg, err := requireWorkspace()
+	if err != nil {
+		return err
+	}
+
+	if forecastSprints < 1 {
+		return exitcode.Usage("--sprints must be at least 1")
+	}
+	if forecastCapacity < 1 || forecastCapacity > 100 {
+		return exitcode.Usage("--capacity must be a percentage between 1 and 100")
+	}
+	if forecastTrials < 1 {
+		return exitcode.Usage("--trials must be at least 1")
+	}
+
+	client := newClient(cfg, cmd)
+	w := cmd.OutOrStdout()
+
+	resolved, err := resolve.Epic(ctx, client, cfg.Workspace, args[0], cfg.Aliases.Epics)
+	if err != nil {
+		return err
+	}
+
+	epic, err := fetchEpicForecastTarget(ctx, client, resolved)
+	if err != nil {
+		return err
+	}
+
+	velocity, err := fetchForecastVelocity(ctx, client, cfg.Workspace, forecastSprints)
+	if err != nil {
+		return err
+	}
+	if len(velocity.Sprints) == 0 {
+		return exitcode.Generalf("no closed sprints to sample velocity from — forecasts need sprint history")
+	}
+
+	capacity := float64(forecastCapacity) / 100
+	samples := make([]float64, len(velocity.Sprints))
+	for i, s := range velocity.Sprints {
+		samples[i] = s.CompletedPoints * capacity
+	}
+	if slices.Max(samples) == 0 && epic.Remaining > 0 {
+		return exitcode.Generalf("no points were completed in the sampled sprints — there is no velocity to forecast with")
+	}
+
+	now := time.Now()
+	firstEnd, firstShare := now, 0.0
+	if a := velocity.Active; a != nil {
+		start, err1 := time.Parse(time.RFC3339, a.StartAt)
+		end, err2 := time.Parse(time.RFC3339, a.EndAt)
+		if err1 == nil && err2 == nil && end.After(now) && end.After(start) {
+			firstEnd = end
+			firstShare = min(1, end.Sub(now).Seconds()/end.Sub(start).Seconds())
+		}
+	}
+
+	seed := forecastSeed
+	if seed == 0 {
+		seed = uint64(now.UnixNano())
+	}
+	rng := rand.New(rand.NewPCG(seed, seed))
+	outcomes := simulateEpicCompletion(rng, epic.Remaining, samples, now, firstEnd, firstShare, velocity.Length, forecastTrials)
+
+	type percentileResult struct {
+		Percentile int    `json:"percentile"`
+		Date       string `json:"date"`
+		Sprints    int    `json:"sprints"`
+	}
+	var percentiles []percentileResult
+	if epic.Remaining > 0 {
+		for _, p := range forecastPercentiles {
+			o := forecastPercentile(outcomes, p)
+			percentiles = append(percentiles, percentileResult{Percentile: p, Date: output.FormatDateISO(o.Finish), Sprints: o.Sprints})
+		}
+	}
+
+	var targets []forecastTargetResult
+	if epic.EndOn != "" {
+		targets = append(targets, forecastTarget(outcomes, "endOn", "End date", epic.EndOn))
+	}
+	today := output.FormatDateISO(now)
+	for _, kd := range epic.KeyDates {
+		if kd.Date < today {
+			continue
+		}
+		targets = append(targets, forecastTarget(outcomes, "keyDate", kd.Description, kd.Date))
+	}
+	atRisk := slices.ContainsFunc(targets, func(t forecastTargetResult) bool { return t.Status != "on-track" })
+
+	if output.IsJSON(outputFormat) {
+		sampled := make([]map[string]any, len(velocity.Sprints))
+		for i, s := range velocity.Sprints {
+			sampled[i] = map[string]any{"id": s.ID, "name": s.DisplayName(), "completedPoints": s.CompletedPoints}
+		}
+		return output.JSON(w, map[string]any{
+			"epic": map[string]any{
+				"id":    epic.ID,
+				"title": epic.Title,
+				"state": epic.State,
+				"endOn": epic.EndOn,
+			},
+			"remainingPoints": epic.Remaining,
+			"totalPoints":     epic.Total,
+			"velocity": map[string]any{
+				"sprints":  sampled,
+				"capacity": capacity,
+			},
+			"trials":      forecastTrials,
+			"seed":        seed,
+			"percentiles": percentiles,
+			"targets":     targets,
+			"atRisk":      atRisk,
+		})
+	}
+
+	d := output.NewDetailWriter(w, "EPIC FORECAST", epic.Title)
+	remaining := fmt.Sprintf("%s of %s pts", formatEstimate(epic.Remaining), formatEstimate(epic.Total))
+	if epic.OpenIssues > 0 && epic.Remaining == 0 {
+		remaining += output.Yellow(fmt.Sprintf(" (%d open issue(s) have no estimate)", epic.OpenIssues))
+	}
+	fields := []output.KeyValue{
+		output.KV("State", formatEpicState(epic.State)),
+		output.KV("Remaining", remaining),
+		output.KV("Velocity", formatForecastVelocity(samples, forecastCapacity)),
+	}
+	if epic.EndOn != "" {
+		fields = append(fields, output.KV("End date", epic.EndOn+"  "+formatForecastStatus(targets[0].Status)))
+	}
+	if atRisk {
+		fields = append(fields, output.KV("Risk", output.Red("at risk — the forecast misses at least one target")))
+	}
+	d.Fields(fields)
+
+	d.Section("COMPLETION")
+	if epic.Remaining == 0 {
+		fmt.Fprintln(w, "No open estimate left — nothing to forecast.")
+	} else {
+		lw := output.NewListWriter(w, "CHANCE", "DONE BY", "SPRINTS")
+		for _, p := range percentiles {
+			finish, _ := time.Parse("2006-01-02", p.Date)
+			lw.Row(fmt.Sprintf("%d%%", p.Percentile), output.FormatDate(finish), strconv.Itoa(p.Sprints))
+		}
+		lw.FlushWithFooter(fmt.Sprintf("%d trial(s) sampling %d sprint(s)", forecastTrials, len(samples)))
+	}
+
+	if len(targets) > 0 {
+		d.Section("TARGETS")
+		lw := output.NewListWriter(w, "DATE", "TARGET", "CHANCE", "STATUS")
+		for _, t := range targets {
+			lw.Row(t.Date, t.Description, fmt.Sprintf("%.0f%%", t.Probability*100), formatForecastStatus(t.Status))
+		}
+		lw.Flush()
+	}
+	return nil
+}
+
+// fetchEpicForecastTarget fetches an epic's open estimate, end date and key
+// dates.
+func fetchEpicForecastTarget(ctx context.Context, client *api.Client, resolved *resolve.EpicResult) (*epicForecastTarget, error) {
+	type progress struct {
+		Open   float64 `json:"open"`
+		Closed float64 `json:"closed"`
+		Total  float64 `json:"total"`
+	}
+
+	if resolved.Type == "legacy" {
+		data, err := client.Execute(ctx, epicForecastLegacyQuery, map[string]any{"id": resolved.ID})
+		if err != nil {
+			return nil, exitcode.General("fetching epic", err)
+		}
+		var resp struct {
+			Node *struct {
+				ID    string  `json:"id"`
+				EndOn *string `json:"endOn"`
+				Issue struct {
+					Title string `json:"title"`
+					State string `json:"state"`
+				} `json:"issue"`
+				IssueCountProgress    progress `json:"issueCountProgress"`
+				IssueEstimateProgress progress `json:"issueEstimateProgress"`
+			} `json:"node"`
+		}
+		if err := json.Unmarshal(data, &resp); err != nil {
+			return nil, exitcode.General("parsing epic", err)
+		}
+		if resp.Node == nil {
+			return nil, exitcode.NotFoundError(fmt.Sprintf("epic %q not found", resolved.ID))
+		}
+		n := resp.Node
+		target := &epicForecastTarget{
+			ID:         n.ID,
+			Title:      n.Issue.Title,
+			State:      n.Issue.State,
+			OpenIssues: int(n.IssueCountProgress.Open),
+			Remaining:  n.IssueEstimateProgress.Open,
+			Total:      n.IssueEstimateProgress.Total,
+		}
+		if n.EndOn != nil {
+			target.EndOn = *n.EndOn
+		}
+		return target, nil
+	}
+
+	data, err := client.Execute(ctx, epicForecastZenhubQuery, map[string]any{"id": resolved.ID})
+	if err != nil {
+		return nil, exitcode.General("fetching epic", err)
+	}
+	var resp struct {
+		Node *struct {
+			ID                    string   `json:"id"`
+			Title                 string   `json:"title"`
+			State                 string   `json:"state"`
+			EndOn                 *string  `json:"endOn"`
+			IssueCountProgress    progress `json:"zenhubIssueCountProgress"`
+			IssueEstimateProgress progress `json:"zenhubIssueEstimateProgress"`
+			KeyDates              struct {
+				Nodes []keyDateNode `json:"nodes"`
+			} `json:"keyDates"`
+		} `json:"node"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, exitcode.General("parsing epic", err)
+	}
+	if resp.Node == nil {
+		return nil, exitcode.NotFoundError(fmt.Sprintf("epic %q not found", resolved.ID))
+	}
+	n := resp.Node
+	target := &epicForecastTarget{
+		ID:         n.ID,
+		Title:      n.Title,
+		State:      n.State,
+		OpenIssues: int(n.IssueCountProgress.Open),
+		Remaining:  n.IssueEstimateProgress.Open,
+		Total:      n.IssueEstimateProgress.Total,
+		KeyDates:   n.KeyDates.Nodes,
+	}
+	if n.EndOn != nil {
+		target.EndOn = *n.EndOn
+	}
+	slices.SortFunc(target.KeyDates, func(a, b keyDateNode) int { return strings.Compare(a.Date, b.Date) })
+	return target, nil
+}
+
+// fetchForecastVelocity fetches the most recent closed sprints and the active
+// sprint, using the same query as `zh sprint velocity`.
+func fetchForecastVelocity(ctx context.Context, client *api.Client, workspaceID string, count int) (*forecastVelocity, error) {
+	data, err := client.Execute(ctx, sprintVelocityQuery, map[string]any{
+		"workspaceId": workspaceID,
+		"sprintCount": count,
+	})
+	if err != nil {
+		return nil, exitcode.General("fetching sprint velocity", err)
+	}
+
+	var resp struct {
+		Workspace struct {
+			ActiveSprint *velocitySprintEntry `json:"activeSprint"`
+			Sprints      struct {
+				Nodes []velocitySprintEntry `json:"nodes"`
+			} `json:"sprints"`
+		} `json:"workspace"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, exitcode.General("parsing sprint velocity response", err)
+	}
+
+	v := &forecastVelocity{
+		Sprints: resp.Workspace.Sprints.Nodes,
+		Active:  resp.Workspace.ActiveSprint,
+		Length:  14 * 24 * time.Hour,
+	}
+	var total time.Duration
+	counted := 0
+	for _, s := range v.Sprints {
+		start, err1 := time.Parse(time.RFC3339, s.StartAt)
+		end, err2 := time.Parse(time.RFC3339, s.EndAt)
+		if err1 == nil && err2 == nil && end.After(start) {
+			total += end.Sub(start)
+			counted++
+		}
+	}
+	if counted > 0 {
+		v.Length = total / time.Duration(counted)
+	}
+	return v, nil
+}
+
+// simulateEpicCompletion runs Monte Carlo trials of the remaining points being
+// worked off at velocities drawn from samples, and returns when each trial
+// finished, earliest first. The current sprint ends at firstEnd and has
+// firstShare of a full sprint's capacity left; later sprints last length.
+// A trial finishes partway through a sprint in proportion to the points it
+// needed from that sprint.
+func simulateEpicCompletion(rng *rand.Rand, remaining float64, samples []float64, now, firstEnd time.Time, firstShare float64, length time.Duration, trials int) []forecastOutcome {
+	outcomes := make([]forecastOutcome, trials)
+	for t := range outcomes {
+		left := remaining
+		if left <= 0 {
+			outcomes[t] = forecastOutcome{Finish: now}
+			continue
+		}
+
+		for sprint := 0; ; sprint++ {
+			start, end, share := firstEnd.Add(time.Duration(sprint-1)*length), firstEnd.Add(time.Duration(sprint)*length), 1.0
+			if sprint == 0 {
+				start, share = now, firstShare
+			}
+			capacity := samples[rng.IntN(len(samples))] * share
+			if capacity >= left || sprint == maxForecastSprints {
+				fraction := 1.0
+				if capacity > 0 {
+					fraction = min(1, left/capacity)
+				}
+				outcomes[t] = forecastOutcome{
+					Sprints: sprint + 1,
+					Finish:  start.Add(time.Duration(fraction * float64(end.Sub(start)))),
+				}
+				break
+			}
+			left -= capacity
+		}
+		if firstShare == 0 {
+			// There is no sprint in progress, so the first sprint had no
+			// capacity and doesn't count.
+			outcomes[t].Sprints--
+		}
+	}
+
+	slices.SortFunc(outcomes, func(a, b forecastOutcome) int { return a.Finish.Compare(b.Finish) })
+	return outcomes
+}
+
+// forecastPercentile returns the outcome at the given percentile of sorted
+// outcomes.
+func forecastPercentile(outcomes []forecastOutcome, percentile int) forecastOutcome {
+	i := int(math.Ceil(float64(percentile)/100*float64(len(outcomes)))) - 1
+	return outcomes[max(0, min(i, len(outcomes)-1))]
+}
+
+// forecastTarget works out the chance of finishing by the end of a date
+// ("2026-03-31"). The status is on-track when the 85th percentile finishes
+// in time, at-risk when only the 50th does, and off-track otherwise.
+func forecastTarget(outcomes []forecastOutcome, kind, description, date string) forecastTargetResult {
+	result := forecastTargetResult{Kind: kind, Description: description, Date: date, Status: "off-track"}
+	day, err := time.ParseInLocation("2006-01-02", date, time.Local)
+	if err != nil {
+		return result
+	}
+	deadline := day.AddDate(0, 0, 1)
+
+	done := 0
+	for _, o := range outcomes {
+		if o.Finish.Before(deadline) {
+			done++
+		}
+	}
+	result.Probability = float64(done) / float64(len(outcomes))
+
+	switch {
+	case forecastPercentile(outcomes, 85).Finish.Before(deadline):
+		result.Status = "on-track"
+	case forecastPercentile(outcomes, 50).Finish.Before(deadline):
+		result.Status = "at-risk"
+	}
+	return result
+}
+
+// formatForecastVelocity summarizes the sampled velocities.
+func formatForecastVelocity(samples []float64, capacity int) string {
+	sum := 0.0
+	for _, v := range samples {
+		sum += v
+	}
+	s := fmt.Sprintf("%s–%s pts/sprint (avg %s) over %d sprint(s)",
+		formatEstimate(slices.Min(samples)), formatEstimate(slices.Max(samples)),
+		formatEstimate(math.Round(sum/float64(len(samples))*10)/10), len(samples))
+	if capacity < 100 {
+		s += fmt.Sprintf(" at %d%% capacity", capacity)
+	}
+	return s
+}
+
+// formatForecastStatus colors a forecast target status.
+func formatForecastStatus(status string) string {
+	switch status {
+	case "on-track":
+		return output.Green("on track")
+	case "at-risk":
+		return output.Yellow("at risk")
+	default:
+		return output.Red("off track")
+	}
+}
@@ -0,0 +1,192 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/rand/v2"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dslh/zh/internal/api"
+	"github.com/dslh/zh/internal/output"
+	"github.com/dslh/zh/internal/testutil"
+)
+
+func setupEpicForecastTest(t *testing.T) {
+	t.Helper()
+	resetEpicFlags()
+	resetEpicForecastFlags()
+
+	ms := testutil.NewMockServer(t)
+	handleEpicResolutionQueries(ms)
+	ms.HandleQuery("GetZenhubEpicForecast", epicForecastZenhubResponse())
+	ms.HandleQuery("SprintVelocity", sprintVelocityResponse())
+
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("ZH_API_KEY", "test-key")
+	t.Setenv("ZH_WORKSPACE", "ws-123")
+	t.Setenv("ZH_GITHUB_TOKEN", "")
+
+	origNew := apiNewFunc
+	apiNewFunc = func(apiKey string, opts ...api.Option) *api.Client {
+		return api.New(apiKey, append(opts, api.WithEndpoint(ms.URL()))...)
+	}
+	t.Cleanup(func() { apiNewFunc = origNew })
+}
+
+func TestEpicForecast(t *testing.T) {
+	setupEpicForecastTest(t)
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"epic", "forecast", "Q1 Platform", "--seed=1"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("epic forecast returned error: %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{
+		"EPIC FORECAST: Q1 Platform Improvements",
+		"100 of 155 pts",
+		"38–48 pts/sprint (avg 42) over 3 sprint(s)",
+		"2099-12-31  on track",
+		"at risk",
+		"COMPLETION",
+		"50%",
+		"95%",
+		"TARGETS",
+		"Beta launch",
+		"off track",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output should contain %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "Kickoff") {
+		t.Error("key dates that have passed should be skipped")
+	}
+}
+
+func TestEpicForecastJSON(t *testing.T) {
+	setupEpicForecastTest(t)
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"epic", "forecast", "Q1 Platform", "--capacity=50", "--seed=1", "--output=json"})
+	defer func() { outputFormat = "" }()
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("epic forecast returned error: %v", err)
+	}
+
+	var result struct {
+		RemainingPoints float64 `json:"remainingPoints"`
+		Velocity        struct {
+			Capacity float64 `json:"capacity"`
+		} `json:"velocity"`
+		Percentiles []struct {
+			Percentile int `json:"percentile"`
+			Sprints    int `json:"sprints"`
+		} `json:"percentiles"`
+		Targets []forecastTargetResult `json:"targets"`
+		AtRisk  bool                   `json:"atRisk"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+
+	if result.RemainingPoints != 100 || result.Velocity.Capacity != 0.5 {
+		t.Errorf("remainingPoints=%v capacity=%v, want 100 and 0.5", result.RemainingPoints, result.Velocity.Capacity)
+	}
+
+	// At half capacity the sprints deliver 19-24 points, so 100 points
+	// take five or six sprints.
+	if len(result.Percentiles) != 3 {
+		t.Fatalf("expected 3 percentiles, got %d", len(result.Percentiles))
+	}
+	for _, p := range result.Percentiles {
+		if p.Sprints < 5 || p.Sprints > 6 {
+			t.Errorf("p%d takes %d sprints, want 5 or 6", p.Percentile, p.Sprints)
+		}
+	}
+
+	if len(result.Targets) != 2 {
+		t.Fatalf("expected end date and one upcoming key date, got %+v", result.Targets)
+	}
+	if end := result.Targets[0]; end.Kind != "endOn" || end.Probability != 1 || end.Status != "on-track" {
+		t.Errorf("unexpected end date target: %+v", end)
+	}
+	if kd := result.Targets[1]; kd.Description != "Beta launch" || kd.Probability != 0 || kd.Status != "off-track" {
+		t.Errorf("unexpected key date target: %+v", kd)
+	}
+	if !result.AtRisk {
+		t.Error("epic should be at risk when a key date is off track")
+	}
+}
+
+func TestEpicForecastInvalidCapacity(t *testing.T) {
+	setupEpicForecastTest(t)
+
+	rootCmd.SetOut(new(bytes.Buffer))
+	rootCmd.SetArgs([]string{"epic", "forecast", "Q1 Platform", "--capacity=0"})
+
+	err := rootCmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "--capacity") {
+		t.Errorf("expected a --capacity error, got %v", err)
+	}
+}
+
+func TestSimulateEpicCompletion(t *testing.T) {
+	now := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	firstEnd := now.AddDate(0, 0, 7)
+	sprint := 14 * 24 * time.Hour
+
+	// Half of the current sprint is left, worth 5 points, so the remaining
+	// 10 points use all of the next sprint.
+	outcomes := simulateEpicCompletion(rand.New(rand.NewPCG(1, 1)), 15, []float64{10}, now, firstEnd, 0.5, sprint, 3)
+	for _, o := range outcomes {
+		if o.Sprints != 2 || !o.Finish.Equal(firstEnd.Add(sprint)) {
+			t.Errorf("outcome = %d sprints, %s; want 2 sprints, %s", o.Sprints, o.Finish, firstEnd.Add(sprint))
+		}
+	}
+
+	// Without a sprint in progress, 5 points at 10 per sprint finish halfway
+	// through the first sprint.
+	outcomes = simulateEpicCompletion(rand.New(rand.NewPCG(1, 1)), 5, []float64{10}, now, now, 0, sprint, 1)
+	if o := outcomes[0]; o.Sprints != 1 || !o.Finish.Equal(now.Add(sprint/2)) {
+		t.Errorf("outcome = %d sprints, %s; want 1 sprint, %s", o.Sprints, o.Finish, now.Add(sprint/2))
+	}
+}
+
+func epicForecastZenhubResponse() map[string]any {
+	tomorrow := output.FormatDateISO(time.Now().AddDate(0, 0, 1))
+	return map[string]any{
+		"data": map[string]any{
+			"node": map[string]any{
+				"id":    "epic-zen-1",
+				"title": "Q1 Platform Improvements",
+				"state": "IN_PROGRESS",
+				"endOn": "2099-12-31",
+				"zenhubIssueCountProgress": map[string]any{
+					"open":   10,
+					"closed": 12,
+					"total":  22,
+				},
+				"zenhubIssueEstimateProgress": map[string]any{
+					"open":   100,
+					"closed": 55,
+					"total":  155,
+				},
+				"keyDates": map[string]any{
+					"nodes": []any{
+						map[string]any{"id": "kd-2", "date": tomorrow, "description": "Beta launch", "color": nil},
+						map[string]any{"id": "kd-1", "date": "2020-01-06", "description": "Kickoff", "color": nil},
+					},
+				},
+			},
+		},
+	}
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -95,8 +96,9 @@ The epic can be specified as:
 
 Examples:
   zh epic key-date list "Q1 Roadmap"`,
-	Args: cobra.ExactArgs(1),
-	RunE: runEpicKeyDateList,
+	Args:        cobra.ExactArgs(1),
+	RunE:        runEpicKeyDateList,
+	Annotations: map[string]string{tabularAnnotation: "true"},
 }
 
 var epicKeyDateAddCmd = &cobra.Command{
@@ -152,6 +154,8 @@ func resetEpicKeyDateFlags() {
 // --- epic key-date list ---
 
 func runEpicKeyDateList(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+
 	cfg, err := requireWorkspace()
 	if err != nil {
 		return err
@@ -161,7 +165,7 @@ func runEpicKeyDateList(cmd *cobra.Command, args []string) error {
 	w := cmd.OutOrStdout()
 
 	// Resolve the epic
-	resolved, err := resolve.Epic(client, cfg.Workspace, args[0], cfg.Aliases.Epics)
+	resolved, err := resolve.Epic(ctx, client, cfg.Workspace, args[0], cfg.Aliases.Epics)
 	if err != nil {
 		return err
 	}
@@ -172,7 +176,7 @@ func runEpicKeyDateList(cmd *cobra.Command, args []string) error {
 	}
 
 	// Fetch key dates
-	keyDates, err := fetchEpicKeyDates(client, resolved.ID)
+	keyDates, err := fetchEpicKeyDates(ctx, client, resolved.ID)
 	if err != nil {
 		return err
 	}
@@ -199,8 +203,8 @@ func runEpicKeyDateList(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func fetchEpicKeyDates(client *api.Client, epicID string) ([]keyDateNode, error) {
-	data, err := client.Execute(epicKeyDatesQuery, map[string]any{
+func fetchEpicKeyDates(ctx context.Context, client *api.Client, epicID string) ([]keyDateNode, error) {
+	data, err := client.Execute(ctx, epicKeyDatesQuery, map[string]any{
 		"id": epicID,
 	})
 	if err != nil {
@@ -231,6 +235,8 @@ func fetchEpicKeyDates(client *api.Client, epicID string) ([]keyDateNode, error)
 // --- epic key-date add ---
 
 func runEpicKeyDateAdd(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+
 	cfg, err := requireWorkspace()
 	if err != nil {
 		return err
@@ -240,7 +246,7 @@ func runEpicKeyDateAdd(cmd *cobra.Command, args []string) error {
 	w := cmd.OutOrStdout()
 
 	// Resolve the epic
-	resolved, err := resolve.Epic(client, cfg.Workspace, args[0], cfg.Aliases.Epics)
+	resolved, err := resolve.Epic(ctx, client, cfg.Workspace, args[0], cfg.Aliases.Epics)
 	if err != nil {
 		return err
 	}
@@ -273,7 +279,7 @@ func runEpicKeyDateAdd(cmd *cobra.Command, args []string) error {
 	}
 
 	// Execute mutation
-	data, err := client.Execute(createZenhubEpicKeyDateMutation, map[string]any{
+	data, err := client.Execute(ctx, createZenhubEpicKeyDateMutation, map[string]any{
 		"input": map[string]any{
 			"zenhubEpicId": resolved.ID,
 			"date":         date,
@@ -321,6 +327,8 @@ func runEpicKeyDateAdd(cmd *cobra.Command, args []string) error {
 // --- epic key-date remove ---
 
 func runEpicKeyDateRemove(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+
 	cfg, err := requireWorkspace()
 	if err != nil {
 		return err
@@ -330,7 +338,7 @@ func runEpicKeyDateRemove(cmd *cobra.Command, args []string) error {
 	w := cmd.OutOrStdout()
 
 	// Resolve the epic
-	resolved, err := resolve.Epic(client, cfg.Workspace, args[0], cfg.Aliases.Epics)
+	resolved, err := resolve.Epic(ctx, client, cfg.Workspace, args[0], cfg.Aliases.Epics)
 	if err != nil {
 		return err
 	}
@@ -343,7 +351,7 @@ func runEpicKeyDateRemove(cmd *cobra.Command, args []string) error {
 	name := args[1]
 
 	// Fetch existing key dates to find the one matching by name
-	keyDates, err := fetchEpicKeyDates(client, resolved.ID)
+	keyDates, err := fetchEpicKeyDates(ctx, client, resolved.ID)
 	if err != nil {
 		return err
 	}
@@ -378,7 +386,7 @@ func runEpicKeyDateRemove(cmd *cobra.Command, args []string) error {
 	}
 
 	// Execute mutation
-	data, err := client.Execute(deleteZenhubEpicKeyDateMutation, map[string]any{
+	data, err := client.Execute(ctx, deleteZenhubEpicKeyDateMutation, map[string]any{
 		"input": map[string]any{
 			"keyDateId": match.ID,
 		},
@@ -1,8 +1,10 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"strconv"
 	"strings"
 	"time"
@@ -12,6 +14,7 @@ import (
 	"github.com/dslh/zh/internal/config"
 	"github.com/dslh/zh/internal/exitcode"
 	"github.com/dslh/zh/internal/gh"
+	"github.com/dslh/zh/internal/history"
 	"github.com/dslh/zh/internal/output"
 	"github.com/dslh/zh/internal/resolve"
 	"github.com/spf13/cobra"
@@ -218,14 +221,19 @@ const legacyEpicUpdateIssueMutation = `mutation UpdateIssue($input: UpdateIssueI
 
 // requireLegacyEpicGitHubID fetches the GitHub node ID for a legacy epic's
 // backing issue, which is needed for GitHub GraphQL mutations.
-func requireLegacyEpicGitHubID(ghClient *gh.Client, resolved *resolve.EpicResult) (string, error) {
-	data, err := ghClient.Execute(legacyEpicGitHubIssueQuery, map[string]any{
-		"owner":  resolved.RepoOwner,
-		"repo":   resolved.RepoName,
-		"number": resolved.IssueNumber,
+func requireLegacyEpicGitHubID(ctx context.Context, ghClient *gh.Client, resolved *resolve.EpicResult) (string, error) {
+	return requireGitHubIssueID(ctx, ghClient, resolved.RepoOwner, resolved.RepoName, resolved.IssueNumber)
+}
+
+// requireGitHubIssueID fetches the GitHub node ID for an issue.
+func requireGitHubIssueID(ctx context.Context, ghClient *gh.Client, owner, repo string, number int) (string, error) {
+	data, err := ghClient.Execute(ctx, legacyEpicGitHubIssueQuery, map[string]any{
+		"owner":  owner,
+		"repo":   repo,
+		"number": number,
 	})
 	if err != nil {
-		return "", exitcode.General("fetching GitHub issue for legacy epic", err)
+		return "", exitcode.General("fetching GitHub issue", err)
 	}
 
 	var resp struct {
@@ -240,8 +248,7 @@ func requireLegacyEpicGitHubID(ghClient *gh.Client, resolved *resolve.EpicResult
 	}
 
 	if resp.Repository.Issue == nil {
-		return "", exitcode.NotFoundError(fmt.Sprintf("GitHub issue %s/%s#%d not found",
-			resolved.RepoOwner, resolved.RepoName, resolved.IssueNumber))
+		return "", exitcode.NotFoundError(fmt.Sprintf("GitHub issue %s/%s#%d not found", owner, repo, number))
 	}
 
 	return resp.Repository.Issue.ID, nil
@@ -304,8 +311,9 @@ future commands. Aliases are stored in the config file.
 
 Use --delete to remove an existing alias. Use --list to show all
 epic aliases.`,
-	Args: cobra.RangeArgs(0, 2),
-	RunE: runEpicAlias,
+	Args:        cobra.RangeArgs(0, 2),
+	RunE:        runEpicAlias,
+	Annotations: map[string]string{tabularAnnotation: "true"},
 }
 
 var epicSetDatesCmd = &cobra.Command{
@@ -396,11 +404,13 @@ var (
 	epicAddDryRun          bool
 	epicAddRepo            string
 	epicAddContinueOnError bool
+	epicAddPlanOut         string
 
 	epicRemoveDryRun          bool
 	epicRemoveRepo            string
 	epicRemoveAll             bool
 	epicRemoveContinueOnError bool
+	epicRemovePlanOut         string
 
 	epicEstimateDryRun bool
 )
@@ -431,11 +441,13 @@ func init() {
 	epicAddCmd.Flags().BoolVar(&epicAddDryRun, "dry-run", false, "Show what would be added without executing")
 	epicAddCmd.Flags().StringVar(&epicAddRepo, "repo", "", "Repository context for bare issue numbers")
 	epicAddCmd.Flags().BoolVar(&epicAddContinueOnError, "continue-on-error", false, "Continue processing remaining issues after a resolution error")
+	epicAddCmd.Flags().StringVar(&epicAddPlanOut, "plan-out", "", "Write the resolved change to a plan file for zh apply instead of executing")
 
 	epicRemoveCmd.Flags().BoolVar(&epicRemoveDryRun, "dry-run", false, "Show what would be removed without executing")
 	epicRemoveCmd.Flags().StringVar(&epicRemoveRepo, "repo", "", "Repository context for bare issue numbers")
 	epicRemoveCmd.Flags().BoolVar(&epicRemoveAll, "all", false, "Remove all issues from the epic")
 	epicRemoveCmd.Flags().BoolVar(&epicRemoveContinueOnError, "continue-on-error", false, "Continue processing remaining issues after a resolution error")
+	epicRemoveCmd.Flags().StringVar(&epicRemovePlanOut, "plan-out", "", "Write the resolved change to a plan file for zh apply instead of executing")
 
 	epicEstimateCmd.Flags().BoolVar(&epicEstimateDryRun, "dry-run", false, "Show what would be changed without executing")
 
@@ -476,18 +488,20 @@ func resetEpicMutationFlags() {
 	epicAddDryRun = false
 	epicAddRepo = ""
 	epicAddContinueOnError = false
+	epicAddPlanOut = ""
 
 	epicRemoveDryRun = false
 	epicRemoveRepo = ""
 	epicRemoveAll = false
 	epicRemoveContinueOnError = false
+	epicRemovePlanOut = ""
 
 	epicEstimateDryRun = false
 }
 
 // fetchWorkspaceOrgID retrieves the ZenHub organization ID for a workspace.
-func fetchWorkspaceOrgID(client *api.Client, workspaceID string) (string, error) {
-	data, err := client.Execute(getWorkspaceOrgQuery, map[string]any{
+func fetchWorkspaceOrgID(ctx context.Context, client *api.Client, workspaceID string) (string, error) {
+	data, err := client.Execute(ctx, getWorkspaceOrgQuery, map[string]any{
 		"workspaceId": workspaceID,
 	})
 	if err != nil {
@@ -532,6 +546,8 @@ func runEpicCreate(cmd *cobra.Command, args []string) error {
 
 // runEpicCreateZenhub creates a standalone ZenHub epic.
 func runEpicCreateZenhub(client *api.Client, cfg *config.Config, cmd *cobra.Command, title string) error {
+	ctx := commandContext(cmd)
+
 	w := cmd.OutOrStdout()
 
 	if epicCreateDryRun {
@@ -551,7 +567,7 @@ func runEpicCreateZenhub(client *api.Client, cfg *config.Config, cmd *cobra.Comm
 	}
 
 	// Fetch org ID
-	orgID, err := fetchWorkspaceOrgID(client, cfg.Workspace)
+	orgID, err := fetchWorkspaceOrgID(ctx, client, cfg.Workspace)
 	if err != nil {
 		return err
 	}
@@ -568,7 +584,7 @@ func runEpicCreateZenhub(client *api.Client, cfg *config.Config, cmd *cobra.Comm
 		"zenhubEpic":           epicInput,
 	}
 
-	data, err := client.Execute(createZenhubEpicMutation, map[string]any{
+	data, err := client.Execute(ctx, createZenhubEpicMutation, map[string]any{
 		"input": input,
 	})
 	if err != nil {
@@ -610,10 +626,12 @@ func runEpicCreateZenhub(client *api.Client, cfg *config.Config, cmd *cobra.Comm
 
 // runEpicCreateLegacy creates a legacy epic backed by a GitHub issue.
 func runEpicCreateLegacy(client *api.Client, cfg *config.Config, cmd *cobra.Command, title string) error {
+	ctx := commandContext(cmd)
+
 	w := cmd.OutOrStdout()
 
 	// Resolve the repository
-	repo, err := resolve.LookupRepoWithRefresh(client, cfg.Workspace, epicCreateRepo)
+	repo, err := resolve.LookupRepoWithRefresh(ctx, client, cfg.Workspace, epicCreateRepo)
 	if err != nil {
 		return err
 	}
@@ -643,7 +661,7 @@ func runEpicCreateLegacy(client *api.Client, cfg *config.Config, cmd *cobra.Comm
 		issueInput["body"] = epicCreateBody
 	}
 
-	data, err := client.Execute(createLegacyEpicMutation, map[string]any{
+	data, err := client.Execute(ctx, createLegacyEpicMutation, map[string]any{
 		"input": map[string]any{
 			"issue": issueInput,
 		},
@@ -704,6 +722,8 @@ var validEpicStates = map[string]string{
 
 // runEpicEdit implements `zh epic edit <epic>`.
 func runEpicEdit(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+
 	if epicEditTitle == "" && epicEditBody == "" {
 		return exitcode.Usage("at least one of --title or --body must be provided")
 	}
@@ -717,7 +737,7 @@ func runEpicEdit(cmd *cobra.Command, args []string) error {
 	w := cmd.OutOrStdout()
 
 	// Resolve the epic
-	resolved, err := resolve.Epic(client, cfg.Workspace, args[0], cfg.Aliases.Epics)
+	resolved, err := resolve.Epic(ctx, client, cfg.Workspace, args[0], cfg.Aliases.Epics)
 	if err != nil {
 		return err
 	}
@@ -728,7 +748,7 @@ func runEpicEdit(cmd *cobra.Command, args []string) error {
 			return exitcode.Generalf("epic %q is a legacy epic (backed by GitHub issue %s/%s#%d) — GitHub access is required to edit it\n\nConfigure GitHub access with: zh",
 				resolved.Title, resolved.RepoOwner, resolved.RepoName, resolved.IssueNumber)
 		}
-		return runEpicEditLegacy(ghClient, w, resolved)
+		return runEpicEditLegacy(ctx, ghClient, w, resolved)
 	}
 
 	if epicEditDryRun {
@@ -758,7 +778,7 @@ func runEpicEdit(cmd *cobra.Command, args []string) error {
 		input["body"] = epicEditBody
 	}
 
-	data, err := client.Execute(updateZenhubEpicMutation, map[string]any{
+	data, err := client.Execute(ctx, updateZenhubEpicMutation, map[string]any{
 		"input": input,
 	})
 	if err != nil {
@@ -802,7 +822,7 @@ func runEpicEdit(cmd *cobra.Command, args []string) error {
 }
 
 // runEpicEditLegacy edits a legacy epic's title/body via the GitHub API.
-func runEpicEditLegacy(ghClient *gh.Client, w writerFlusher, resolved *resolve.EpicResult) error {
+func runEpicEditLegacy(ctx context.Context, ghClient *gh.Client, w writerFlusher, resolved *resolve.EpicResult) error {
 	ref := legacyEpicRef(resolved)
 
 	if epicEditDryRun {
@@ -823,7 +843,7 @@ func runEpicEditLegacy(ghClient *gh.Client, w writerFlusher, resolved *resolve.E
 	}
 
 	// Get the GitHub node ID
-	ghNodeID, err := requireLegacyEpicGitHubID(ghClient, resolved)
+	ghNodeID, err := requireLegacyEpicGitHubID(ctx, ghClient, resolved)
 	if err != nil {
 		return err
 	}
@@ -838,7 +858,7 @@ func runEpicEditLegacy(ghClient *gh.Client, w writerFlusher, resolved *resolve.E
 		input["body"] = epicEditBody
 	}
 
-	data, err := ghClient.Execute(legacyEpicUpdateIssueMutation, map[string]any{
+	data, err := ghClient.Execute(ctx, legacyEpicUpdateIssueMutation, map[string]any{
 		"input": input,
 	})
 	if err != nil {
@@ -885,6 +905,8 @@ func runEpicEditLegacy(ghClient *gh.Client, w writerFlusher, resolved *resolve.E
 
 // runEpicDelete implements `zh epic delete <epic>`.
 func runEpicDelete(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+
 	cfg, err := requireWorkspace()
 	if err != nil {
 		return err
@@ -894,7 +916,7 @@ func runEpicDelete(cmd *cobra.Command, args []string) error {
 	w := cmd.OutOrStdout()
 
 	// Resolve the epic
-	resolved, err := resolve.Epic(client, cfg.Workspace, args[0], cfg.Aliases.Epics)
+	resolved, err := resolve.Epic(ctx, client, cfg.Workspace, args[0], cfg.Aliases.Epics)
 	if err != nil {
 		return err
 	}
@@ -905,7 +927,7 @@ func runEpicDelete(cmd *cobra.Command, args []string) error {
 	}
 
 	// Fetch child issue count for informational output
-	detailData, err := client.Execute(epicChildIssueCountQuery, map[string]any{
+	detailData, err := client.Execute(ctx, epicChildIssueCountQuery, map[string]any{
 		"id":          resolved.ID,
 		"workspaceId": cfg.Workspace,
 	})
@@ -956,7 +978,7 @@ func runEpicDelete(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	data, err := client.Execute(deleteZenhubEpicMutation, map[string]any{
+	data, err := client.Execute(ctx, deleteZenhubEpicMutation, map[string]any{
 		"input": map[string]any{
 			"zenhubEpicId": resolved.ID,
 		},
@@ -995,6 +1017,8 @@ func runEpicDelete(cmd *cobra.Command, args []string) error {
 
 // runEpicSetState implements `zh epic set-state <epic> <state>`.
 func runEpicSetState(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+
 	cfg, err := requireWorkspace()
 	if err != nil {
 		return err
@@ -1011,7 +1035,7 @@ func runEpicSetState(cmd *cobra.Command, args []string) error {
 	}
 
 	// Resolve the epic
-	resolved, err := resolve.Epic(client, cfg.Workspace, args[0], cfg.Aliases.Epics)
+	resolved, err := resolve.Epic(ctx, client, cfg.Workspace, args[0], cfg.Aliases.Epics)
 	if err != nil {
 		return err
 	}
@@ -1022,7 +1046,7 @@ func runEpicSetState(cmd *cobra.Command, args []string) error {
 			return exitcode.Generalf("epic %q is a legacy epic (backed by GitHub issue %s/%s#%d) — GitHub access is required to change its state\n\nConfigure GitHub access with: zh",
 				resolved.Title, resolved.RepoOwner, resolved.RepoName, resolved.IssueNumber)
 		}
-		return runEpicSetStateLegacy(ghClient, w, resolved, graphqlState)
+		return runEpicSetStateLegacy(ctx, ghClient, w, resolved, graphqlState)
 	}
 
 	if epicSetStateDryRun {
@@ -1043,7 +1067,7 @@ func runEpicSetState(cmd *cobra.Command, args []string) error {
 		input["applyToIssues"] = true
 	}
 
-	data, err := client.Execute(updateZenhubEpicStateMutation, map[string]any{
+	data, err := client.Execute(ctx, updateZenhubEpicStateMutation, map[string]any{
 		"input": input,
 	})
 	if err != nil {
@@ -1083,7 +1107,7 @@ func runEpicSetState(cmd *cobra.Command, args []string) error {
 // runEpicSetStateLegacy changes the state of a legacy epic via the GitHub API.
 // Legacy epic state maps to GitHub issue state: CLOSED means closed, anything
 // else means open. The --apply-to-issues flag is not supported for legacy epics.
-func runEpicSetStateLegacy(ghClient *gh.Client, w writerFlusher, resolved *resolve.EpicResult, graphqlState string) error {
+func runEpicSetStateLegacy(ctx context.Context, ghClient *gh.Client, w writerFlusher, resolved *resolve.EpicResult, graphqlState string) error {
 	ref := legacyEpicRef(resolved)
 
 	// Map ZenHub epic states to GitHub issue states
@@ -1106,12 +1130,12 @@ func runEpicSetStateLegacy(ghClient *gh.Client, w writerFlusher, resolved *resol
 	}
 
 	// Get the GitHub node ID
-	ghNodeID, err := requireLegacyEpicGitHubID(ghClient, resolved)
+	ghNodeID, err := requireLegacyEpicGitHubID(ctx, ghClient, resolved)
 	if err != nil {
 		return err
 	}
 
-	data, err := ghClient.Execute(legacyEpicUpdateIssueMutation, map[string]any{
+	data, err := ghClient.Execute(ctx, legacyEpicUpdateIssueMutation, map[string]any{
 		"input": map[string]any{
 			"id":    ghNodeID,
 			"state": ghState,
@@ -1155,6 +1179,8 @@ func runEpicSetStateLegacy(ghClient *gh.Client, w writerFlusher, resolved *resol
 
 // runEpicAlias implements `zh epic alias <epic> <alias>`.
 func runEpicAlias(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+
 	cfg, err := requireWorkspace()
 	if err != nil {
 		return err
@@ -1181,6 +1207,11 @@ func runEpicAlias(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	// Setting and deleting aliases print a confirmation, not a table
+	if output.IsTabular(outputFormat) {
+		return tabularFormatError(cmd)
+	}
+
 	// --delete: remove an alias
 	if epicAliasDelete {
 		if len(args) != 1 {
@@ -1215,7 +1246,7 @@ func runEpicAlias(cmd *cobra.Command, args []string) error {
 
 	// Validate the epic exists
 	client := newClient(cfg, cmd)
-	resolved, err := resolve.Epic(client, cfg.Workspace, epicName, cfg.Aliases.Epics)
+	resolved, err := resolve.Epic(ctx, client, cfg.Workspace, epicName, cfg.Aliases.Epics)
 	if err != nil {
 		return err
 	}
@@ -1255,6 +1286,8 @@ func parseDate(s string) (string, error) {
 
 // runEpicSetDates implements `zh epic set-dates <epic>`.
 func runEpicSetDates(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+
 	if epicSetDatesStart == "" && epicSetDatesEnd == "" && !epicSetDatesClearStart && !epicSetDatesClearEnd {
 		return exitcode.Usage("at least one of --start, --end, --clear-start, or --clear-end must be provided")
 	}
@@ -1287,7 +1320,7 @@ func runEpicSetDates(cmd *cobra.Command, args []string) error {
 	w := cmd.OutOrStdout()
 
 	// Resolve the epic
-	resolved, err := resolve.Epic(client, cfg.Workspace, args[0], cfg.Aliases.Epics)
+	resolved, err := resolve.Epic(ctx, client, cfg.Workspace, args[0], cfg.Aliases.Epics)
 	if err != nil {
 		return err
 	}
@@ -1324,13 +1357,13 @@ func runEpicSetDates(cmd *cobra.Command, args []string) error {
 	}
 
 	if resolved.Type == "zenhub" {
-		return runEpicSetDatesZenhub(client, cfg, w, resolved, startOn, endOn)
+		return runEpicSetDatesZenhub(ctx, client, cfg, w, resolved, startOn, endOn)
 	}
-	return runEpicSetDatesLegacy(client, w, resolved, startOn, endOn)
+	return runEpicSetDatesLegacy(ctx, client, w, resolved, startOn, endOn)
 }
 
 // runEpicSetDatesZenhub sets dates on a ZenHub epic.
-func runEpicSetDatesZenhub(client *api.Client, cfg *config.Config, w writerFlusher, resolved *resolve.EpicResult, startOn, endOn any) error {
+func runEpicSetDatesZenhub(ctx context.Context, client *api.Client, cfg *config.Config, w writerFlusher, resolved *resolve.EpicResult, startOn, endOn any) error {
 	input := map[string]any{
 		"zenhubEpicId": resolved.ID,
 	}
@@ -1341,7 +1374,7 @@ func runEpicSetDatesZenhub(client *api.Client, cfg *config.Config, w writerFlush
 		input["endOn"] = endOn
 	}
 
-	data, err := client.Execute(updateZenhubEpicDatesMutation, map[string]any{
+	data, err := client.Execute(ctx, updateZenhubEpicDatesMutation, map[string]any{
 		"input": input,
 	})
 	if err != nil {
@@ -1377,7 +1410,7 @@ func runEpicSetDatesZenhub(client *api.Client, cfg *config.Config, w writerFlush
 }
 
 // runEpicSetDatesLegacy sets dates on a legacy epic.
-func runEpicSetDatesLegacy(client *api.Client, w writerFlusher, resolved *resolve.EpicResult, startOn, endOn any) error {
+func runEpicSetDatesLegacy(ctx context.Context, client *api.Client, w writerFlusher, resolved *resolve.EpicResult, startOn, endOn any) error {
 	input := map[string]any{
 		"epicId": resolved.ID,
 	}
@@ -1388,7 +1421,7 @@ func runEpicSetDatesLegacy(client *api.Client, w writerFlusher, resolved *resolv
 		input["endOn"] = endOn
 	}
 
-	data, err := client.Execute(updateLegacyEpicDatesMutation, map[string]any{
+	data, err := client.Execute(ctx, updateLegacyEpicDatesMutation, map[string]any{
 		"input": input,
 	})
 	if err != nil {
@@ -1464,8 +1497,8 @@ const issueResolveForEpicQuery = `query GetIssueForEpic($issueId: ID!) {
 }`
 
 // resolveIssueForEpic resolves an issue identifier and fetches its title.
-func resolveIssueForEpic(client *api.Client, workspaceID, identifier, repoFlag string, ghClient *gh.Client) (*resolvedEpicIssue, error) {
-	result, err := resolve.Issue(client, workspaceID, identifier, &resolve.IssueOptions{
+func resolveIssueForEpic(ctx context.Context, client *api.Client, workspaceID, identifier, repoFlag string, ghClient *gh.Client) (*resolvedEpicIssue, error) {
+	result, err := resolve.Issue(ctx, client, workspaceID, identifier, &resolve.IssueOptions{
 		RepoFlag:     repoFlag,
 		GitHubClient: ghClient,
 	})
@@ -1474,7 +1507,7 @@ func resolveIssueForEpic(client *api.Client, workspaceID, identifier, repoFlag s
 	}
 
 	// Fetch title
-	data, err := client.Execute(issueResolveForEpicQuery, map[string]any{
+	data, err := client.Execute(ctx, issueResolveForEpicQuery, map[string]any{
 		"issueId": result.ID,
 	})
 	if err != nil {
@@ -1510,8 +1543,97 @@ func resolveIssueForEpic(client *api.Client, workspaceID, identifier, repoFlag s
 	}, nil
 }
 
+// executeEpicIssueChange adds an issue to an epic, or removes it if add is
+// false. Legacy epics are updated through the REST API.
+func executeEpicIssueChange(ctx context.Context, client *api.Client, workspaceID string, epic *resolve.EpicResult, issue resolvedEpicIssue, add bool) error {
+	return executeEpicIssuesChange(ctx, client, workspaceID, epic, []resolvedEpicIssue{issue}, add)
+}
+
+// executeEpicIssuesChange adds issues to an epic, or removes them if add is
+// false, in a single request.
+func executeEpicIssuesChange(ctx context.Context, client *api.Client, workspaceID string, epic *resolve.EpicResult, issues []resolvedEpicIssue, add bool) error {
+	if epic.Type == "legacy" {
+		epicRepo, err := resolve.LookupRepoWithRefresh(ctx, client, workspaceID, epic.RepoOwner+"/"+epic.RepoName)
+		if err != nil {
+			return exitcode.General(fmt.Sprintf("resolving repository for legacy epic %s", legacyEpicRef(epic)), err)
+		}
+		refs := make([]api.RESTIssueRef, len(issues))
+		for i, iss := range issues {
+			refs[i] = api.RESTIssueRef{RepoID: iss.RepoGhID, IssueNumber: iss.Number}
+		}
+		if add {
+			err = client.UpdateEpicIssues(ctx, epicRepo.GhID, epic.IssueNumber, refs, nil)
+		} else {
+			err = client.UpdateEpicIssues(ctx, epicRepo.GhID, epic.IssueNumber, nil, refs)
+		}
+		if err != nil {
+			return exitcode.General(fmt.Sprintf("updating legacy epic %s", legacyEpicRef(epic)), err)
+		}
+		return nil
+	}
+
+	issueIDs := make([]string, len(issues))
+	for i, iss := range issues {
+		issueIDs[i] = iss.ID
+	}
+	mutation := addIssuesToZenhubEpicsMutation
+	if !add {
+		mutation = removeIssuesFromZenhubEpicsMutation
+	}
+	_, err := client.Execute(ctx, mutation, map[string]any{
+		"input": map[string]any{
+			"zenhubEpicIds": []string{epic.ID},
+			"issueIds":      issueIDs,
+		},
+	})
+	if err != nil {
+		return exitcode.General(fmt.Sprintf("updating epic %q", epic.Title), err)
+	}
+	return nil
+}
+
+// epicHistoryValue returns the history value for membership of an epic.
+func epicHistoryValue(epic *resolve.EpicResult) *history.Value {
+	return &history.Value{
+		ID:   epic.ID,
+		Name: epic.Title,
+		Epic: &history.Epic{
+			Type:        epic.Type,
+			IssueNumber: epic.IssueNumber,
+			RepoName:    epic.RepoName,
+			RepoOwner:   epic.RepoOwner,
+		},
+	}
+}
+
+// recordEpicHistory records issues added to an epic, or removed from it if
+// add is false.
+func recordEpicHistory(errW io.Writer, workspaceID string, epic *resolve.EpicResult, issues []resolvedEpicIssue, add bool, summary string) {
+	value := epicHistoryValue(epic)
+
+	command := "epic remove"
+	if add {
+		command = "epic add"
+	}
+	changes := make([]history.Change, len(issues))
+	for i, iss := range issues {
+		changes[i] = history.Change{
+			Kind:  history.KindEpic,
+			Issue: history.Issue{ID: iss.ID, Number: iss.Number, RepoName: iss.RepoName, RepoOwner: iss.RepoOwner, RepoGhID: iss.RepoGhID},
+		}
+		if add {
+			changes[i].After = value
+		} else {
+			changes[i].Before = value
+		}
+	}
+	recordHistory(errW, workspaceID, command, summary, changes)
+}
+
 // runEpicAdd implements `zh epic add <epic> <issue>...`.
 func runEpicAdd(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+
 	cfg, err := requireWorkspace()
 	if err != nil {
 		return err
@@ -1522,7 +1644,7 @@ func runEpicAdd(cmd *cobra.Command, args []string) error {
 	ghClient := newGitHubClient(cfg, cmd)
 
 	// Resolve the epic
-	resolved, err := resolve.Epic(client, cfg.Workspace, args[0], cfg.Aliases.Epics)
+	resolved, err := resolve.Epic(ctx, client, cfg.Workspace, args[0], cfg.Aliases.Epics)
 	if err != nil {
 		return err
 	}
@@ -1533,7 +1655,7 @@ func runEpicAdd(cmd *cobra.Command, args []string) error {
 	var failed []output.FailedItem
 
 	for _, arg := range issueArgs {
-		issue, err := resolveIssueForEpic(client, cfg.Workspace, arg, epicAddRepo, ghClient)
+		issue, err := resolveIssueForEpic(ctx, client, cfg.Workspace, arg, epicAddRepo, ghClient)
 		if err != nil {
 			if epicAddContinueOnError {
 				failed = append(failed, output.FailedItem{
@@ -1551,8 +1673,12 @@ func runEpicAdd(cmd *cobra.Command, args []string) error {
 		return exitcode.Generalf("all issues failed to resolve")
 	}
 
+	if epicAddPlanOut != "" {
+		return saveEpicIssuesPlan(ctx, client, cfg, w, epicAddPlanOut, resolved, issues, failed, true)
+	}
+
 	if resolved.Type == "legacy" {
-		return runEpicAddLegacy(client, cfg, w, resolved, issues, failed)
+		return runEpicAddLegacy(ctx, client, cfg, w, cmd.ErrOrStderr(), resolved, issues, failed)
 	}
 
 	// Dry run
@@ -1566,7 +1692,7 @@ func runEpicAdd(cmd *cobra.Command, args []string) error {
 		issueIDs[i] = iss.ID
 	}
 
-	data, err := client.Execute(addIssuesToZenhubEpicsMutation, map[string]any{
+	data, err := client.Execute(ctx, addIssuesToZenhubEpicsMutation, map[string]any{
 		"input": map[string]any{
 			"zenhubEpicIds": []string{resolved.ID},
 			"issueIds":      issueIDs,
@@ -1589,6 +1715,9 @@ func runEpicAdd(cmd *cobra.Command, args []string) error {
 		return exitcode.General("parsing add issues response", err)
 	}
 
+	recordEpicHistory(cmd.ErrOrStderr(), cfg.Workspace, resolved, issues, true,
+		fmt.Sprintf("Added %d issue(s) to epic %q", len(issues), resolved.Title))
+
 	// Build succeeded list
 	succeeded := make([]output.MutationItem, len(issues))
 	for i, iss := range issues {
@@ -1623,11 +1752,11 @@ func runEpicAdd(cmd *cobra.Command, args []string) error {
 }
 
 // runEpicAddLegacy adds issues to a legacy epic via the ZenHub REST API v1.
-func runEpicAddLegacy(client *api.Client, cfg *config.Config, w writerFlusher, resolved *resolve.EpicResult, issues []resolvedEpicIssue, failed []output.FailedItem) error {
+func runEpicAddLegacy(ctx context.Context, client *api.Client, cfg *config.Config, w writerFlusher, errW io.Writer, resolved *resolve.EpicResult, issues []resolvedEpicIssue, failed []output.FailedItem) error {
 	ref := legacyEpicRef(resolved)
 
 	// Look up the epic's repo GhID
-	epicRepo, err := resolve.LookupRepoWithRefresh(client, cfg.Workspace, resolved.RepoOwner+"/"+resolved.RepoName)
+	epicRepo, err := resolve.LookupRepoWithRefresh(ctx, client, cfg.Workspace, resolved.RepoOwner+"/"+resolved.RepoName)
 	if err != nil {
 		return exitcode.General(fmt.Sprintf("resolving repository for legacy epic %s", ref), err)
 	}
@@ -1646,10 +1775,13 @@ func runEpicAddLegacy(client *api.Client, cfg *config.Config, w writerFlusher, r
 		}
 	}
 
-	if err := client.UpdateEpicIssues(epicRepo.GhID, resolved.IssueNumber, addIssues, nil); err != nil {
+	if err := client.UpdateEpicIssues(ctx, epicRepo.GhID, resolved.IssueNumber, addIssues, nil); err != nil {
 		return exitcode.General("adding issues to legacy epic", err)
 	}
 
+	recordEpicHistory(errW, cfg.Workspace, resolved, issues, true,
+		fmt.Sprintf("Added %d issue(s) to legacy epic %q (%s)", len(issues), resolved.Title, ref))
+
 	// Build succeeded list
 	succeeded := make([]output.MutationItem, len(issues))
 	for i, iss := range issues {
@@ -1735,6 +1867,8 @@ func renderEpicAddDryRun(w writerFlusher, epic *resolve.EpicResult, issues []res
 
 // runEpicRemove implements `zh epic remove <epic> <issue>...`.
 func runEpicRemove(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+
 	cfg, err := requireWorkspace()
 	if err != nil {
 		return err
@@ -1745,17 +1879,24 @@ func runEpicRemove(cmd *cobra.Command, args []string) error {
 	ghClient := newGitHubClient(cfg, cmd)
 
 	// Resolve the epic
-	resolved, err := resolve.Epic(client, cfg.Workspace, args[0], cfg.Aliases.Epics)
+	resolved, err := resolve.Epic(ctx, client, cfg.Workspace, args[0], cfg.Aliases.Epics)
 	if err != nil {
 		return err
 	}
 
 	// Handle --all flag
 	if epicRemoveAll {
+		if epicRemovePlanOut != "" {
+			issues, err := fetchAllEpicChildIssues(ctx, client, cfg.Workspace, resolved.ID)
+			if err != nil {
+				return err
+			}
+			return saveEpicIssuesPlan(ctx, client, cfg, w, epicRemovePlanOut, resolved, issues, nil, false)
+		}
 		if resolved.Type == "legacy" {
-			return runEpicRemoveAllLegacy(client, cfg, w, resolved)
+			return runEpicRemoveAllLegacy(ctx, client, cfg, w, cmd.ErrOrStderr(), resolved)
 		}
-		return runEpicRemoveAll(client, cfg, w, resolved)
+		return runEpicRemoveAll(ctx, client, cfg, w, cmd.ErrOrStderr(), resolved)
 	}
 
 	if len(args) < 2 {
@@ -1768,7 +1909,7 @@ func runEpicRemove(cmd *cobra.Command, args []string) error {
 	var failed []output.FailedItem
 
 	for _, arg := range issueArgs {
-		issue, err := resolveIssueForEpic(client, cfg.Workspace, arg, epicRemoveRepo, ghClient)
+		issue, err := resolveIssueForEpic(ctx, client, cfg.Workspace, arg, epicRemoveRepo, ghClient)
 		if err != nil {
 			if epicRemoveContinueOnError {
 				failed = append(failed, output.FailedItem{
@@ -1786,8 +1927,12 @@ func runEpicRemove(cmd *cobra.Command, args []string) error {
 		return exitcode.Generalf("all issues failed to resolve")
 	}
 
+	if epicRemovePlanOut != "" {
+		return saveEpicIssuesPlan(ctx, client, cfg, w, epicRemovePlanOut, resolved, issues, failed, false)
+	}
+
 	if resolved.Type == "legacy" {
-		return runEpicRemoveLegacy(client, cfg, w, resolved, issues, failed)
+		return runEpicRemoveLegacy(ctx, client, cfg, w, cmd.ErrOrStderr(), resolved, issues, failed)
 	}
 
 	// Dry run
@@ -1801,7 +1946,7 @@ func runEpicRemove(cmd *cobra.Command, args []string) error {
 		issueIDs[i] = iss.ID
 	}
 
-	data, err := client.Execute(removeIssuesFromZenhubEpicsMutation, map[string]any{
+	data, err := client.Execute(ctx, removeIssuesFromZenhubEpicsMutation, map[string]any{
 		"input": map[string]any{
 			"zenhubEpicIds": []string{resolved.ID},
 			"issueIds":      issueIDs,
@@ -1823,6 +1968,9 @@ func runEpicRemove(cmd *cobra.Command, args []string) error {
 		return exitcode.General("parsing remove issues response", err)
 	}
 
+	recordEpicHistory(cmd.ErrOrStderr(), cfg.Workspace, resolved, issues, false,
+		fmt.Sprintf("Removed %d issue(s) from epic %q", len(issues), resolved.Title))
+
 	// Build succeeded list
 	succeeded := make([]output.MutationItem, len(issues))
 	for i, iss := range issues {
@@ -1857,11 +2005,11 @@ func runEpicRemove(cmd *cobra.Command, args []string) error {
 }
 
 // runEpicRemoveLegacy removes issues from a legacy epic via the ZenHub REST API v1.
-func runEpicRemoveLegacy(client *api.Client, cfg *config.Config, w writerFlusher, resolved *resolve.EpicResult, issues []resolvedEpicIssue, failed []output.FailedItem) error {
+func runEpicRemoveLegacy(ctx context.Context, client *api.Client, cfg *config.Config, w writerFlusher, errW io.Writer, resolved *resolve.EpicResult, issues []resolvedEpicIssue, failed []output.FailedItem) error {
 	ref := legacyEpicRef(resolved)
 
 	// Look up the epic's repo GhID
-	epicRepo, err := resolve.LookupRepoWithRefresh(client, cfg.Workspace, resolved.RepoOwner+"/"+resolved.RepoName)
+	epicRepo, err := resolve.LookupRepoWithRefresh(ctx, client, cfg.Workspace, resolved.RepoOwner+"/"+resolved.RepoName)
 	if err != nil {
 		return exitcode.General(fmt.Sprintf("resolving repository for legacy epic %s", ref), err)
 	}
@@ -1880,10 +2028,13 @@ func runEpicRemoveLegacy(client *api.Client, cfg *config.Config, w writerFlusher
 		}
 	}
 
-	if err := client.UpdateEpicIssues(epicRepo.GhID, resolved.IssueNumber, nil, removeIssues); err != nil {
+	if err := client.UpdateEpicIssues(ctx, epicRepo.GhID, resolved.IssueNumber, nil, removeIssues); err != nil {
 		return exitcode.General("removing issues from legacy epic", err)
 	}
 
+	recordEpicHistory(errW, cfg.Workspace, resolved, issues, false,
+		fmt.Sprintf("Removed %d issue(s) from legacy epic %q (%s)", len(issues), resolved.Title, ref))
+
 	// Build succeeded list
 	succeeded := make([]output.MutationItem, len(issues))
 	for i, iss := range issues {
@@ -1959,11 +2110,11 @@ func renderEpicRemoveLegacyDryRun(w writerFlusher, epic *resolve.EpicResult, ref
 }
 
 // runEpicRemoveAllLegacy removes all child issues from a legacy epic via the ZenHub REST API v1.
-func runEpicRemoveAllLegacy(client *api.Client, cfg *config.Config, w writerFlusher, resolved *resolve.EpicResult) error {
+func runEpicRemoveAllLegacy(ctx context.Context, client *api.Client, cfg *config.Config, w writerFlusher, errW io.Writer, resolved *resolve.EpicResult) error {
 	ref := legacyEpicRef(resolved)
 
 	// Fetch all child issues via GraphQL
-	issues, err := fetchAllEpicChildIssues(client, cfg.Workspace, resolved.ID)
+	issues, err := fetchAllEpicChildIssues(ctx, client, cfg.Workspace, resolved.ID)
 	if err != nil {
 		return err
 	}
@@ -2000,7 +2151,7 @@ func runEpicRemoveAllLegacy(client *api.Client, cfg *config.Config, w writerFlus
 	}
 
 	// Look up the epic's repo GhID
-	epicRepo, err := resolve.LookupRepoWithRefresh(client, cfg.Workspace, resolved.RepoOwner+"/"+resolved.RepoName)
+	epicRepo, err := resolve.LookupRepoWithRefresh(ctx, client, cfg.Workspace, resolved.RepoOwner+"/"+resolved.RepoName)
 	if err != nil {
 		return exitcode.General(fmt.Sprintf("resolving repository for legacy epic %s", ref), err)
 	}
@@ -2009,20 +2160,24 @@ func runEpicRemoveAllLegacy(client *api.Client, cfg *config.Config, w writerFlus
 	// returns RepoName/RepoOwner. Resolve GhIDs from the repo cache.
 	removeIssues := make([]api.RESTIssueRef, len(issues))
 	for i, iss := range issues {
-		repo, err := resolve.LookupRepoWithRefresh(client, cfg.Workspace, iss.RepoOwner+"/"+iss.RepoName)
+		repo, err := resolve.LookupRepoWithRefresh(ctx, client, cfg.Workspace, iss.RepoOwner+"/"+iss.RepoName)
 		if err != nil {
 			return exitcode.General(fmt.Sprintf("resolving repository for %s#%d", iss.RepoName, iss.Number), err)
 		}
+		issues[i].RepoGhID = repo.GhID
 		removeIssues[i] = api.RESTIssueRef{
 			RepoID:      repo.GhID,
 			IssueNumber: iss.Number,
 		}
 	}
 
-	if err := client.UpdateEpicIssues(epicRepo.GhID, resolved.IssueNumber, nil, removeIssues); err != nil {
+	if err := client.UpdateEpicIssues(ctx, epicRepo.GhID, resolved.IssueNumber, nil, removeIssues); err != nil {
 		return exitcode.General("removing issues from legacy epic", err)
 	}
 
+	recordEpicHistory(errW, cfg.Workspace, resolved, issues, false,
+		fmt.Sprintf("Removed all %d issue(s) from legacy epic %q (%s)", len(issues), resolved.Title, ref))
+
 	if output.IsJSON(outputFormat) {
 		return output.JSON(w, map[string]any{
 			"epic":    map[string]any{"id": resolved.ID, "title": resolved.Title, "issue": ref},
@@ -2036,9 +2191,9 @@ func runEpicRemoveAllLegacy(client *api.Client, cfg *config.Config, w writerFlus
 }
 
 // runEpicRemoveAll removes all child issues from a ZenHub epic.
-func runEpicRemoveAll(client *api.Client, cfg *config.Config, w writerFlusher, resolved *resolve.EpicResult) error {
+func runEpicRemoveAll(ctx context.Context, client *api.Client, cfg *config.Config, w writerFlusher, errW io.Writer, resolved *resolve.EpicResult) error {
 	// Fetch all child issues
-	issues, err := fetchAllEpicChildIssues(client, cfg.Workspace, resolved.ID)
+	issues, err := fetchAllEpicChildIssues(ctx, client, cfg.Workspace, resolved.ID)
 	if err != nil {
 		return err
 	}
@@ -2080,7 +2235,7 @@ func runEpicRemoveAll(client *api.Client, cfg *config.Config, w writerFlusher, r
 		issueIDs[i] = iss.ID
 	}
 
-	data, err := client.Execute(removeIssuesFromZenhubEpicsMutation, map[string]any{
+	data, err := client.Execute(ctx, removeIssuesFromZenhubEpicsMutation, map[string]any{
 		"input": map[string]any{
 			"zenhubEpicIds": []string{resolved.ID},
 			"issueIds":      issueIDs,
@@ -2102,6 +2257,9 @@ func runEpicRemoveAll(client *api.Client, cfg *config.Config, w writerFlusher, r
 		return exitcode.General("parsing remove issues response", err)
 	}
 
+	recordEpicHistory(errW, cfg.Workspace, resolved, issues, false,
+		fmt.Sprintf("Removed all %d issue(s) from epic %q", len(issues), resolved.Title))
+
 	if output.IsJSON(outputFormat) {
 		return output.JSON(w, map[string]any{
 			"epic":    map[string]any{"id": resolved.ID, "title": resolved.Title},
@@ -2115,7 +2273,7 @@ func runEpicRemoveAll(client *api.Client, cfg *config.Config, w writerFlusher, r
 }
 
 // fetchAllEpicChildIssues fetches all child issues of a ZenHub epic, paginating as needed.
-func fetchAllEpicChildIssues(client *api.Client, workspaceID, epicID string) ([]resolvedEpicIssue, error) {
+func fetchAllEpicChildIssues(ctx context.Context, client *api.Client, workspaceID, epicID string) ([]resolvedEpicIssue, error) {
 	var all []resolvedEpicIssue
 	var cursor *string
 	pageSize := 100
@@ -2130,7 +2288,7 @@ func fetchAllEpicChildIssues(client *api.Client, workspaceID, epicID string) ([]
 			vars["after"] = *cursor
 		}
 
-		data, err := client.Execute(epicChildIssueIDsQuery, vars)
+		data, err := client.Execute(ctx, epicChildIssueIDsQuery, vars)
 		if err != nil {
 			return nil, exitcode.General("fetching epic child issues", err)
 		}
@@ -2187,6 +2345,8 @@ func fetchAllEpicChildIssues(client *api.Client, workspaceID, epicID string) ([]
 
 // runEpicEstimate implements `zh epic estimate <epic> [value]`.
 func runEpicEstimate(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+
 	cfg, err := requireWorkspace()
 	if err != nil {
 		return err
@@ -2206,7 +2366,7 @@ func runEpicEstimate(cmd *cobra.Command, args []string) error {
 	}
 
 	// Resolve the epic
-	resolved, err := resolve.Epic(client, cfg.Workspace, args[0], cfg.Aliases.Epics)
+	resolved, err := resolve.Epic(ctx, client, cfg.Workspace, args[0], cfg.Aliases.Epics)
 	if err != nil {
 		return err
 	}
@@ -2218,7 +2378,7 @@ func runEpicEstimate(cmd *cobra.Command, args []string) error {
 
 	// Fetch current estimate for dry-run context
 	var currentEstimate *float64
-	data, err := client.Execute(epicEstimateQuery, map[string]any{
+	data, err := client.Execute(ctx, epicEstimateQuery, map[string]any{
 		"id": resolved.ID,
 	})
 	if err != nil {
@@ -2245,12 +2405,12 @@ func runEpicEstimate(cmd *cobra.Command, args []string) error {
 	// Dry run
 	if epicEstimateDryRun {
 		var header string
-		var ctx string
+		var current string
 
 		if currentEstimate != nil {
-			ctx = fmt.Sprintf("(currently: %s)", formatEstimate(*currentEstimate))
+			current = fmt.Sprintf("(currently: %s)", formatEstimate(*currentEstimate))
 		} else {
-			ctx = "(currently: none)"
+			current = "(currently: none)"
 		}
 
 		if newValue != nil {
@@ -2262,7 +2422,7 @@ func runEpicEstimate(cmd *cobra.Command, args []string) error {
 		items := []output.MutationItem{
 			{
 				Ref:   resolved.Title,
-				Title: ctx,
+				Title: current,
 			},
 		}
 
@@ -2280,7 +2440,7 @@ func runEpicEstimate(cmd *cobra.Command, args []string) error {
 		input["value"] = nil
 	}
 
-	data, err = client.Execute(setMultipleEstimatesOnZenhubEpicsMutation, map[string]any{
+	data, err = client.Execute(ctx, setMultipleEstimatesOnZenhubEpicsMutation, map[string]any{
 		"input": input,
 	})
 	if err != nil {
@@ -2375,6 +2535,40 @@ func renderEpicRemoveDryRun(w writerFlusher, epic *resolve.EpicResult, issues []
 	return nil
 }
 
+// saveEpicIssuesPlan shows the dry run for epic add or remove and writes it
+// to a plan file.
+func saveEpicIssuesPlan(ctx context.Context, client *api.Client, cfg *config.Config, w writerFlusher, path string, epic *resolve.EpicResult, issues []resolvedEpicIssue, failed []output.FailedItem, add bool) error {
+	var err error
+	switch {
+	case epic.Type == "legacy" && add:
+		err = renderEpicAddLegacyDryRun(w, epic, legacyEpicRef(epic), issues, failed)
+	case epic.Type == "legacy":
+		err = renderEpicRemoveLegacyDryRun(w, epic, legacyEpicRef(epic), issues, failed)
+	case add:
+		err = renderEpicAddDryRun(w, epic, issues, failed)
+	default:
+		err = renderEpicRemoveDryRun(w, epic, issues, failed)
+	}
+	if err != nil {
+		return err
+	}
+
+	plan := &mutationPlan{
+		Command: "epic add",
+		Summary: fmt.Sprintf("add %d issue(s) to epic %q", len(issues), epic.Title),
+		Epic:    planEpicFrom(epic),
+	}
+	if !add {
+		plan.Command = "epic remove"
+		plan.Summary = fmt.Sprintf("remove %d issue(s) from epic %q", len(issues), epic.Title)
+	}
+	issueIDs := make([]string, len(issues))
+	for i, iss := range issues {
+		issueIDs[i] = iss.ID
+	}
+	return savePlan(ctx, client, cfg.Workspace, w, path, plan, issueIDs)
+}
+
 func formatEpicIssueItemsJSON(issues []resolvedEpicIssue) []map[string]any {
 	result := make([]map[string]any, len(issues))
 	for i, iss := range issues {
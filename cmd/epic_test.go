@@ -127,6 +127,39 @@ func TestEpicListJSON(t *testing.T) {
 	}
 }
 
+func TestEpicListNDJSON(t *testing.T) {
+	resetEpicFlags()
+
+	ms := testutil.NewMockServer(t)
+	handleEpicListQueries(ms)
+	setupIssueTestEnv(t, ms)
+	defer resetOutputFlags()
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"epic", "list", "--output=ndjson"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("epic list --output=ndjson returned error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d:\n%s", len(lines), buf.String())
+	}
+	var first map[string]any
+	if err := json.Unmarshal([]byte(lines[0]), &first); err != nil {
+		t.Fatalf("line is not a JSON object: %v\nLine: %s", err, lines[0])
+	}
+	if first["type"] != "zenhub" {
+		t.Errorf("ZenHub epics should stream first, got: %s", lines[0])
+	}
+
+	if _, ok := cache.Get[[]resolve.CachedEpic](resolve.EpicCacheKey("ws-123")); !ok {
+		t.Error("streamed epics should be cached")
+	}
+}
+
 func TestEpicListEmpty(t *testing.T) {
 	resetEpicFlags()
 
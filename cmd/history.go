@@ -0,0 +1,253 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+
+	"github.com/dslh/zh/internal/exitcode"
+	"github.com/dslh/zh/internal/history"
+	"github.com/dslh/zh/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// Commands
+
+var historyCmd = &cobra.Command{
+	Use:   "history [id]",
+	Short: "List recent changes made with zh",
+	Long: `List the changes zh has made in the current workspace, newest first.
+Pass an ID to list each change the operation made.
+
+Issue moves, estimates, priorities, labels, epic issues and sprint issues
+are recorded along with their previous values, so that they can be
+reverted with zh undo. Changes made by zh apply, zh board edit, the board
+TUI, zh triage and workspace restores are recorded too. The journal is stored
+locally in $XDG_DATA_HOME/zh (default ~/.local/share/zh) and keeps the last
+100 operations per workspace.
+
+Examples:
+  zh history
+  zh history 12`,
+	Args:        cobra.MaximumNArgs(1),
+	RunE:        runHistory,
+	Annotations: map[string]string{tabularAnnotation: "true"},
+}
+
+var (
+	historyLimit int
+	historyAll   bool
+)
+
+func init() {
+	output.AddPaginationFlags(historyCmd, &historyLimit, &historyAll)
+
+	rootCmd.AddCommand(historyCmd)
+}
+
+func resetHistoryFlags() {
+	historyLimit = output.DefaultLimit
+	historyAll = false
+}
+
+// runHistory implements `zh history [id]`.
+func runHistory(cmd *cobra.Command, args []string) error {
+	cfg, err := requireWorkspace()
+	if err != nil {
+		return err
+	}
+
+	w := cmd.OutOrStdout()
+
+	entries, err := history.Load(cfg.Workspace)
+	if err != nil {
+		return exitcode.General("loading history", err)
+	}
+
+	if len(args) == 1 {
+		if output.IsTabular(outputFormat) {
+			return tabularFormatError(cmd)
+		}
+		entry, err := findHistoryEntry(entries, args[0])
+		if err != nil {
+			return err
+		}
+		return renderHistoryEntry(w, entry)
+	}
+
+	slices.Reverse(entries)
+	entries, truncated := output.Truncate(entries, output.EffectiveLimit(historyLimit, historyAll))
+
+	if output.IsJSON(outputFormat) {
+		return output.JSON(w, entries)
+	}
+
+	if len(entries) == 0 {
+		fmt.Fprintln(w, "No history for this workspace.")
+		return nil
+	}
+
+	lw := output.NewListWriter(w, "ID", "WHEN", "COMMAND", "SUMMARY")
+	for _, e := range entries {
+		summary := e.Summary
+		if e.UndoneAt != nil {
+			summary += output.Dim(" (undone)")
+		}
+		lw.Row(strconv.Itoa(e.ID), output.FormatTimeAgo(e.Time), e.Command, summary)
+	}
+
+	footer := fmt.Sprintf("Showing %d operation(s)", len(entries))
+	if truncated {
+		footer += " — use --all to see the rest"
+	}
+	lw.FlushWithFooter(footer)
+	return nil
+}
+
+// findHistoryEntry returns the entry with the given ID.
+func findHistoryEntry(entries []history.Entry, arg string) (*history.Entry, error) {
+	id, err := strconv.Atoi(arg)
+	if err != nil {
+		return nil, exitcode.Usage(fmt.Sprintf("invalid history ID %q — expected a number from zh history", arg))
+	}
+	for i := range entries {
+		if entries[i].ID == id {
+			return &entries[i], nil
+		}
+	}
+	return nil, exitcode.NotFoundError(fmt.Sprintf("history entry %d not found", id))
+}
+
+func renderHistoryEntry(w writerFlusher, entry *history.Entry) error {
+	if output.IsJSON(outputFormat) {
+		return output.JSON(w, entry)
+	}
+
+	fmt.Fprintf(w, "#%d %s — %s (%s)\n", entry.ID, entry.Command, entry.Summary, output.FormatTimeAgo(entry.Time))
+	if entry.UndoneAt != nil {
+		fmt.Fprintln(w, output.Dim(fmt.Sprintf("Undone %s.", output.FormatTimeAgo(*entry.UndoneAt))))
+	}
+	fmt.Fprintln(w)
+
+	if len(entry.Changes) == 0 {
+		fmt.Fprintln(w, "No changes recorded.")
+		return nil
+	}
+	for _, c := range entry.Changes {
+		fmt.Fprintf(w, "  %s  %s\n", c.Issue.Ref(), describeHistoryChange(c))
+	}
+	return nil
+}
+
+// describeHistoryChange describes a change, e.g. `pipeline: "Todo" → "Done"`.
+func describeHistoryChange(c history.Change) string {
+	switch c.Kind {
+	case history.KindMove:
+		if c.Before != nil && c.After != nil && c.Before.ID == c.After.ID {
+			return fmt.Sprintf("reordered in %q", c.After.Name)
+		}
+		return fmt.Sprintf("pipeline: %s → %s", historyValueName(c.Before), historyValueName(c.After))
+	case history.KindEstimate:
+		return fmt.Sprintf("estimate: %s → %s", historyEstimate(c.Before), historyEstimate(c.After))
+	case history.KindPriority:
+		return fmt.Sprintf("priority: %s → %s", historyValueName(c.Before), historyValueName(c.After))
+	case history.KindLabel:
+		if c.After != nil {
+			return fmt.Sprintf("label %s added", historyValueName(c.After))
+		}
+		return fmt.Sprintf("label %s removed", historyValueName(c.Before))
+	case history.KindEpic:
+		if c.After != nil {
+			return fmt.Sprintf("added to epic %s", historyValueName(c.After))
+		}
+		return fmt.Sprintf("removed from epic %s", historyValueName(c.Before))
+	case history.KindSprint:
+		if c.After != nil {
+			return fmt.Sprintf("added to sprint %s", historyValueName(c.After))
+		}
+		return fmt.Sprintf("removed from sprint %s", historyValueName(c.Before))
+	}
+	return c.Kind
+}
+
+func historyValueName(v *history.Value) string {
+	if v == nil {
+		return "none"
+	}
+	return strconv.Quote(v.Name)
+}
+
+func historyEstimate(v *history.Value) string {
+	if v == nil || v.Estimate == nil {
+		return "none"
+	}
+	return formatEstimate(*v.Estimate)
+}
+
+// estimateHistoryValue returns the history value for an estimate, or nil
+// for no estimate.
+func estimateHistoryValue(v *float64) *history.Value {
+	if v == nil {
+		return nil
+	}
+	return &history.Value{Estimate: v}
+}
+
+// mergeHistoryChanges combines repeated changes to the same field of an issue
+// into one, from the first change's Before to the last change's After, so
+// that undo can revert an interactive session in a single step. Changes that
+// end where they started are dropped, except for reorders within a pipeline.
+func mergeHistoryChanges(changes []history.Change) []history.Change {
+	key := func(c history.Change) string {
+		k := c.Kind + "/" + c.Issue.ID
+		if c.Kind == history.KindLabel || c.Kind == history.KindEpic || c.Kind == history.KindSprint {
+			v := c.After
+			if v == nil {
+				v = c.Before
+			}
+			k += "/" + v.ID
+		}
+		return k
+	}
+
+	var merged []history.Change
+	index := map[string]int{}
+	for _, c := range changes {
+		if i, ok := index[key(c)]; ok {
+			merged[i].After = c.After
+			continue
+		}
+		index[key(c)] = len(merged)
+		merged = append(merged, c)
+	}
+
+	return slices.DeleteFunc(merged, func(c history.Change) bool {
+		if c.Kind == history.KindMove {
+			return c.Before != nil && c.After != nil && c.Before.ID == c.After.ID && c.Before.Position == nil
+		}
+		if c.Kind == history.KindEstimate {
+			return historyEstimate(c.Before) == historyEstimate(c.After)
+		}
+		return (c.Before == nil) == (c.After == nil) && (c.Before == nil || c.Before.ID == c.After.ID)
+	})
+}
+
+// recordHistory appends an executed operation to the workspace's journal.
+// Operations without changes are not recorded. A failure to write the
+// journal is reported as a warning rather than failing a mutation that has
+// already been made.
+func recordHistory(errW io.Writer, workspaceID, command, summary string, changes []history.Change) {
+	if len(changes) == 0 {
+		return
+	}
+
+	_, err := history.Append(workspaceID, history.Entry{
+		Command: command,
+		Summary: summary,
+		Changes: changes,
+	})
+	if err != nil {
+		fmt.Fprintln(errW, output.Yellow("Warning: could not record history: "+err.Error()))
+	}
+}
@@ -0,0 +1,249 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dslh/zh/internal/cache"
+	"github.com/dslh/zh/internal/history"
+	"github.com/dslh/zh/internal/resolve"
+	"github.com/dslh/zh/internal/testutil"
+)
+
+// setupHistoryDataDir gives the test an empty mutation journal.
+func setupHistoryDataDir(t *testing.T) {
+	t.Helper()
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+}
+
+func seedHistory(t *testing.T, entries ...history.Entry) {
+	t.Helper()
+	for _, e := range entries {
+		if _, err := history.Append("ws-123", e); err != nil {
+			t.Fatalf("seeding history: %v", err)
+		}
+	}
+}
+
+func TestIssueMoveRecordsHistory(t *testing.T) {
+	resetIssueFlags()
+	resetIssueMoveFlags()
+	setupHistoryDataDir(t)
+
+	ms := setupIssueMoveServer(t)
+	setupIssueTestEnv(t, ms)
+
+	rootCmd.SetOut(new(bytes.Buffer))
+	rootCmd.SetArgs([]string{"issue", "move", "task-tracker#1", "Done"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("issue move returned error: %v", err)
+	}
+
+	entries, err := history.Load("ws-123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 history entry, got %d", len(entries))
+	}
+	e := entries[0]
+	if e.Command != "issue move" || e.Summary != `Moved 1 issue(s) to "Done"` {
+		t.Errorf("unexpected entry: %+v", e)
+	}
+	if len(e.Changes) != 1 {
+		t.Fatalf("expected 1 change, got %d", len(e.Changes))
+	}
+	c := e.Changes[0]
+	if c.Kind != history.KindMove || c.Issue.ID != "i1" || c.Before.ID != "p2" || c.After.ID != "p3" {
+		t.Errorf("unexpected change: %+v", c)
+	}
+}
+
+func TestIssueMoveDryRunRecordsNoHistory(t *testing.T) {
+	resetIssueFlags()
+	resetIssueMoveFlags()
+	setupHistoryDataDir(t)
+
+	ms := setupIssueMoveServer(t)
+	setupIssueTestEnv(t, ms)
+
+	rootCmd.SetOut(new(bytes.Buffer))
+	rootCmd.SetArgs([]string{"issue", "move", "task-tracker#1", "Done", "--dry-run"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("issue move returned error: %v", err)
+	}
+
+	entries, _ := history.Load("ws-123")
+	if len(entries) != 0 {
+		t.Errorf("dry run should not be recorded, got %d entries", len(entries))
+	}
+}
+
+func TestIssueLabelAddRecordsOnlyNewLabels(t *testing.T) {
+	resetIssueFlags()
+	resetIssueLabelFlags()
+	setupHistoryDataDir(t)
+
+	ms := testutil.NewMockServer(t)
+	ms.HandleQuery("GetIssueForLabel", map[string]any{
+		"data": map[string]any{
+			"node": map[string]any{
+				"id":         "i1",
+				"number":     1,
+				"title":      "Fix login button alignment",
+				"repository": map[string]any{"name": "task-tracker", "ownerName": "dlakehammond"},
+				"labels":     map[string]any{"nodes": []any{map[string]any{"id": "l1"}}},
+			},
+		},
+	})
+	ms.HandleQuery("ListRepos", repoResolutionResponse())
+	ms.HandleQuery("IssueByInfo", issueByInfoResolutionResponse())
+	ms.HandleQuery("GetWorkspaceLabels", workspaceLabelsResponse())
+	ms.HandleQuery("AddLabelsToIssues", labelMutationResponse(1))
+	setupIssueTestEnv(t, ms)
+	_ = cache.Set(resolve.RepoCacheKey("ws-123"), []resolve.CachedRepo{
+		{ID: "r1", GhID: 12345, Name: "task-tracker", OwnerName: "dlakehammond"},
+	})
+
+	rootCmd.SetOut(new(bytes.Buffer))
+	rootCmd.SetArgs([]string{"issue", "label", "add", "task-tracker#1", "--", "bug", "enhancement"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("issue label add returned error: %v", err)
+	}
+
+	entries, _ := history.Load("ws-123")
+	if len(entries) != 1 || len(entries[0].Changes) != 1 {
+		t.Fatalf("expected one recorded label change, got %+v", entries)
+	}
+	if c := entries[0].Changes[0]; c.After == nil || c.After.Name != "enhancement" || c.Before != nil {
+		t.Errorf("only the label the issue didn't have should be recorded, got %+v", c)
+	}
+}
+
+func TestHistoryList(t *testing.T) {
+	resetHistoryFlags()
+	setupHistoryDataDir(t)
+	setupIssueTestEnv(t, testutil.NewMockServer(t))
+
+	undone := time.Now()
+	seedHistory(t,
+		history.Entry{Command: "issue move", Summary: `Moved 2 issue(s) to "Done"`, UndoneAt: &undone},
+		history.Entry{Command: "issue estimate", Summary: "Set estimate on task-tracker#1 to 5"},
+	)
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"history"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("history returned error: %v", err)
+	}
+
+	out := buf.String()
+	move := strings.Index(out, `Moved 2 issue(s) to "Done" (undone)`)
+	estimate := strings.Index(out, "Set estimate on task-tracker#1 to 5")
+	if move < 0 || estimate < 0 {
+		t.Fatalf("output should list both operations, got: %s", out)
+	}
+	if estimate > move {
+		t.Errorf("newest operation should be listed first, got: %s", out)
+	}
+	if !strings.Contains(out, "Showing 2 operation(s)") {
+		t.Errorf("output should have a footer, got: %s", out)
+	}
+}
+
+func TestHistoryEmpty(t *testing.T) {
+	resetHistoryFlags()
+	setupHistoryDataDir(t)
+	setupIssueTestEnv(t, testutil.NewMockServer(t))
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"history"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("history returned error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "No history for this workspace.") {
+		t.Errorf("unexpected output: %s", buf.String())
+	}
+}
+
+func TestHistoryShow(t *testing.T) {
+	resetHistoryFlags()
+	setupHistoryDataDir(t)
+	setupIssueTestEnv(t, testutil.NewMockServer(t))
+
+	three := 3.0
+	issue := history.Issue{ID: "i1", Number: 1, RepoName: "task-tracker"}
+	seedHistory(t, history.Entry{
+		Command: "issue move",
+		Summary: `Moved 1 issue(s) to "Done"`,
+		Changes: []history.Change{
+			{Kind: history.KindMove, Issue: issue, Before: &history.Value{ID: "p2", Name: "In Development"}, After: &history.Value{ID: "p3", Name: "Done"}},
+			{Kind: history.KindEstimate, Issue: issue, Before: &history.Value{Estimate: &three}},
+			{Kind: history.KindLabel, Issue: issue, Before: &history.Value{ID: "l1", Name: "bug"}},
+			{Kind: history.KindEpic, Issue: issue, After: &history.Value{ID: "e1", Name: "Q1"}},
+		},
+	})
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"history", "1"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("history 1 returned error: %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{
+		`#1 issue move — Moved 1 issue(s) to "Done"`,
+		`task-tracker#1  pipeline: "In Development" → "Done"`,
+		"task-tracker#1  estimate: 3 → none",
+		`task-tracker#1  label "bug" removed`,
+		`task-tracker#1  added to epic "Q1"`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output should contain %q, got: %s", want, out)
+		}
+	}
+}
+
+func TestHistoryShowNotFound(t *testing.T) {
+	resetHistoryFlags()
+	setupHistoryDataDir(t)
+	setupIssueTestEnv(t, testutil.NewMockServer(t))
+
+	rootCmd.SetOut(new(bytes.Buffer))
+	rootCmd.SetArgs([]string{"history", "7"})
+	err := rootCmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "history entry 7 not found") {
+		t.Errorf("expected a not found error, got: %v", err)
+	}
+}
+
+func TestMergeHistoryChanges(t *testing.T) {
+	issue := history.Issue{ID: "i1", Number: 1, RepoName: "task-tracker"}
+	pipeline := func(id string) *history.Value { return &history.Value{ID: id, Name: id} }
+	label := &history.Value{ID: "l1", Name: "bug"}
+	est := func(v float64) *history.Value { return &history.Value{Estimate: &v} }
+
+	merged := mergeHistoryChanges([]history.Change{
+		{Kind: history.KindMove, Issue: issue, Before: pipeline("p1"), After: pipeline("p2")},
+		{Kind: history.KindEstimate, Issue: issue, Before: nil, After: est(3)},
+		{Kind: history.KindLabel, Issue: issue, After: label},
+		{Kind: history.KindMove, Issue: issue, Before: pipeline("p2"), After: pipeline("p3")},
+		{Kind: history.KindLabel, Issue: issue, Before: label},
+		{Kind: history.KindEstimate, Issue: issue, Before: est(3), After: est(5)},
+	})
+
+	if len(merged) != 2 {
+		t.Fatalf("expected 2 merged changes, got %+v", merged)
+	}
+	if m := merged[0]; m.Kind != history.KindMove || m.Before.ID != "p1" || m.After.ID != "p3" {
+		t.Errorf("moves should merge to p1 → p3, got %+v", m)
+	}
+	if m := merged[1]; m.Kind != history.KindEstimate || m.Before != nil || *m.After.Estimate != 5 {
+		t.Errorf("estimates should merge to none → 5, got %+v", m)
+	}
+}
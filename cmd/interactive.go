@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"strconv"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/list"
@@ -154,3 +155,152 @@ func interactiveOrArg(cmd *cobra.Command, args []string, interactive bool, fetch
 	}
 	return args[0], nil
 }
+
+// checklistItem is a toggleable entry in an interactive checklist.
+type checklistItem struct {
+	id          string
+	title       string
+	description string
+	checked     bool
+}
+
+// checklistVisibleItems caps how many items the checklist shows at once.
+const checklistVisibleItems = 15
+
+// checklistModel is the Bubble Tea model for toggling items on and off.
+// status, if set, renders a summary line from the current selection.
+type checklistModel struct {
+	title     string
+	items     []checklistItem
+	status    func([]checklistItem) string
+	cursor    int
+	offset    int
+	cancelled bool
+	quitting  bool
+}
+
+func newChecklistModel(title string, items []checklistItem, status func([]checklistItem) string) checklistModel {
+	return checklistModel{
+		title:  title,
+		items:  append([]checklistItem(nil), items...),
+		status: status,
+	}
+}
+
+func (m checklistModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m checklistModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch key.String() {
+	case "ctrl+c", "esc", "q":
+		m.cancelled = true
+		m.quitting = true
+		return m, tea.Quit
+	case "enter":
+		m.quitting = true
+		return m, tea.Quit
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "j":
+		if m.cursor < len(m.items)-1 {
+			m.cursor++
+		}
+	case " ", "x":
+		if len(m.items) > 0 {
+			m.items[m.cursor].checked = !m.items[m.cursor].checked
+		}
+	case "a":
+		all := true
+		for _, item := range m.items {
+			all = all && item.checked
+		}
+		for i := range m.items {
+			m.items[i].checked = !all
+		}
+	}
+
+	// Keep the cursor inside the visible window
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	} else if m.cursor >= m.offset+checklistVisibleItems {
+		m.offset = m.cursor - checklistVisibleItems + 1
+	}
+	return m, nil
+}
+
+func (m checklistModel) View() string {
+	if m.quitting {
+		return ""
+	}
+
+	faint := lipgloss.NewStyle().Faint(true)
+	var b strings.Builder
+	b.WriteString(lipgloss.NewStyle().Bold(true).Render(m.title))
+	b.WriteString("\n\n")
+
+	end := min(m.offset+checklistVisibleItems, len(m.items))
+	for i := m.offset; i < end; i++ {
+		item := m.items[i]
+		cursor, box := "  ", "[ ]"
+		if i == m.cursor {
+			cursor = "> "
+		}
+		if item.checked {
+			box = "[x]"
+		}
+		b.WriteString(cursor + box + " " + item.title)
+		if item.description != "" {
+			b.WriteString("  " + faint.Render(item.description))
+		}
+		b.WriteString("\n")
+	}
+	if len(m.items) > checklistVisibleItems {
+		b.WriteString(faint.Render(strings.Repeat(" ", 6) + "(" + strconv.Itoa(m.cursor+1) + "/" + strconv.Itoa(len(m.items)) + ")"))
+		b.WriteString("\n")
+	}
+
+	if m.status != nil {
+		b.WriteString("\n")
+		b.WriteString(m.status(m.items))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	b.WriteString(faint.Render("Press Space to toggle, a to toggle all, Enter to confirm, Esc to cancel"))
+	b.WriteString("\n")
+	return b.String()
+}
+
+// runInteractiveChecklist lets the user toggle items on and off and returns
+// the items with their final checked state. Returns an error if the user
+// cancels or the terminal is not interactive.
+func runInteractiveChecklist(cmd *cobra.Command, title string, items []checklistItem, status func([]checklistItem) string) ([]checklistItem, error) {
+	if !isInteractive() {
+		return nil, exitcode.Usage("--interactive requires a terminal — cannot run in non-TTY environment")
+	}
+
+	if len(items) == 0 {
+		return nil, exitcode.NotFoundError("no items to select from")
+	}
+
+	m := newChecklistModel(title, items, status)
+	p := tea.NewProgram(m, tea.WithOutput(cmd.ErrOrStderr()))
+	finalModel, err := p.Run()
+	if err != nil {
+		return nil, exitcode.General("interactive selection", err)
+	}
+
+	result := finalModel.(checklistModel)
+	if result.cancelled {
+		return nil, exitcode.General("selection cancelled", nil)
+	}
+
+	return result.items, nil
+}
@@ -170,6 +170,89 @@ func TestInteractiveOrArgNonTTY(t *testing.T) {
 	}
 }
 
+// --- checklistModel ---
+
+func TestChecklistModelToggle(t *testing.T) {
+	items := []checklistItem{
+		{id: "1", title: "Item 1", checked: true},
+		{id: "2", title: "Item 2"},
+	}
+	var m tea.Model = newChecklistModel("Test", items, nil)
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")})
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	result := m.(checklistModel)
+	if result.cancelled {
+		t.Error("Enter should not cancel")
+	}
+	if cmd == nil {
+		t.Error("Enter should return a quit command")
+	}
+	if result.items[0].checked || !result.items[1].checked {
+		t.Errorf("unexpected checked state: %+v", result.items)
+	}
+	if !items[0].checked {
+		t.Error("toggling should not modify the caller's items")
+	}
+}
+
+func TestChecklistModelToggleAll(t *testing.T) {
+	items := []checklistItem{{id: "1", checked: true}, {id: "2"}}
+	var m tea.Model = newChecklistModel("Test", items, nil)
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	for _, item := range m.(checklistModel).items {
+		if !item.checked {
+			t.Error("a should check every item when some are unchecked")
+		}
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	for _, item := range m.(checklistModel).items {
+		if item.checked {
+			t.Error("a should uncheck every item when all are checked")
+		}
+	}
+}
+
+func TestChecklistModelEsc(t *testing.T) {
+	m := newChecklistModel("Test", []checklistItem{{id: "1"}}, nil)
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if !updated.(checklistModel).cancelled {
+		t.Error("Esc should cancel")
+	}
+}
+
+func TestChecklistModelView(t *testing.T) {
+	items := []checklistItem{
+		{id: "1", title: "Item 1", description: "5 pts", checked: true},
+		{id: "2", title: "Item 2"},
+	}
+	status := func(items []checklistItem) string { return "selection summary" }
+	view := newChecklistModel("Plan Sprint 48", items, status).View()
+
+	for _, want := range []string{"Plan Sprint 48", "> [x] Item 1", "5 pts", "  [ ] Item 2", "selection summary"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view should contain %q:\n%s", want, view)
+		}
+	}
+}
+
+func TestRunInteractiveChecklistNonTTY(t *testing.T) {
+	origInteractive := isInteractive
+	isInteractive = func() bool { return false }
+	defer func() { isInteractive = origInteractive }()
+
+	_, err := runInteractiveChecklist(nil, "Test", []checklistItem{{id: "1"}}, nil)
+	if err == nil || !strings.Contains(err.Error(), "non-TTY") {
+		t.Errorf("expected a non-TTY error, got %v", err)
+	}
+}
+
 // --- --interactive flag audit ---
 
 // interactiveCommands lists every show command that should support --interactive.
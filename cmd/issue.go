@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strconv"
@@ -505,7 +506,8 @@ var issueListCmd = &cobra.Command{
 
 By default, lists open issues across all pipelines. Use filters to narrow results.
 Issues are fetched from each pipeline in parallel.`,
-	RunE: runIssueList,
+	RunE:        runIssueList,
+	Annotations: map[string]string{tabularAnnotation: "true"},
 }
 
 var issueShowCmd = &cobra.Command{
@@ -586,6 +588,8 @@ func resetIssueFlags() {
 
 // runIssueList implements `zh issue list`.
 func runIssueList(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+
 	cfg, err := requireWorkspace()
 	if err != nil {
 		return err
@@ -600,24 +604,24 @@ func runIssueList(cmd *cobra.Command, args []string) error {
 	}
 
 	// Build API filters
-	filters := buildIssueListFilters(client, cfg.Workspace)
+	filters := buildIssueListFilters(ctx, client, cfg.Workspace)
 
 	// Determine query strategy
 	if issueListState == "closed" {
-		return runIssueListClosed(client, cfg.Workspace, filters, limit, w)
+		return runIssueListClosed(ctx, client, cfg.Workspace, filters, limit, w)
 	}
 
 	// If --epic is set, use epic query strategy
 	if issueListEpic != "" {
-		return runIssueListByEpic(client, cfg, filters, limit, w)
+		return runIssueListByEpic(ctx, client, cfg, filters, limit, w)
 	}
 
 	// Default: fetch from pipelines
-	return runIssueListByPipelines(client, cfg, filters, limit, w)
+	return runIssueListByPipelines(ctx, client, cfg, filters, limit, w)
 }
 
 // buildIssueListFilters builds the IssueSearchFiltersInput from flags.
-func buildIssueListFilters(client *api.Client, workspaceID string) map[string]any {
+func buildIssueListFilters(ctx context.Context, client *api.Client, workspaceID string) map[string]any {
 	filters := map[string]any{}
 
 	if issueListAssignee != "" {
@@ -646,14 +650,14 @@ func buildIssueListFilters(client *api.Client, workspaceID string) map[string]an
 		filters["sprints"] = map[string]any{"specialFilters": "current_sprint"}
 	} else if issueListSprint != "" {
 		// Resolve sprint identifier
-		resolved, err := resolve.Sprint(client, workspaceID, issueListSprint)
+		resolved, err := resolve.Sprint(ctx, client, workspaceID, issueListSprint)
 		if err == nil {
 			filters["sprints"] = map[string]any{"in": []string{resolved.ID}}
 		}
 	}
 	if issueListRepo != "" {
 		// Resolve repo to get its ZenHub ID for filtering
-		repo, err := resolve.LookupRepoWithRefresh(client, workspaceID, issueListRepo)
+		repo, err := resolve.LookupRepoWithRefresh(ctx, client, workspaceID, issueListRepo)
 		if err == nil {
 			filters["repositoryIds"] = []string{repo.ID}
 		}
@@ -663,21 +667,21 @@ func buildIssueListFilters(client *api.Client, workspaceID string) map[string]an
 }
 
 // runIssueListByPipelines fetches issues across all (or a filtered) pipeline(s).
-func runIssueListByPipelines(client *api.Client, cfg *config.Config, filters map[string]any, limit int, w writerFlusher) error {
+func runIssueListByPipelines(ctx context.Context, client *api.Client, cfg *config.Config, filters map[string]any, limit int, w writerFlusher) error {
 	workspaceID := cfg.Workspace
 
 	// Resolve pipelines
 	var pipelineIDs []string
 
 	if issueListPipeline != "" {
-		resolved, err := resolve.Pipeline(client, workspaceID, issueListPipeline, cfg.Aliases.Pipelines)
+		resolved, err := resolve.Pipeline(ctx, client, workspaceID, issueListPipeline, cfg.Aliases.Pipelines)
 		if err != nil {
 			return err
 		}
 		pipelineIDs = []string{resolved.ID}
 	} else {
 		// Fetch all pipeline IDs
-		pipelines, err := fetchPipelineIDsForList(client, workspaceID)
+		pipelines, err := fetchPipelineIDsForList(ctx, client, workspaceID)
 		if err != nil {
 			return err
 		}
@@ -686,6 +690,10 @@ func runIssueListByPipelines(client *api.Client, cfg *config.Config, filters map
 		}
 	}
 
+	if output.IsNDJSON(outputFormat) {
+		return streamIssuesByPipelines(ctx, client, workspaceID, pipelineIDs, filters, limit, w)
+	}
+
 	// Fetch issues from each pipeline in parallel
 	type pipelineResult struct {
 		issues     []issueListNode
@@ -700,7 +708,7 @@ func runIssueListByPipelines(client *api.Client, cfg *config.Config, filters map
 		wg.Add(1)
 		go func(idx int, pipelineID string) {
 			defer wg.Done()
-			issues, total, err := fetchIssuesByPipeline(client, pipelineID, workspaceID, filters, limit)
+			issues, total, err := fetchIssuesByPipeline(ctx, client, pipelineID, workspaceID, filters, limit)
 			results[idx] = pipelineResult{issues: issues, totalCount: total, err: err}
 		}(i, pID)
 	}
@@ -735,9 +743,38 @@ func runIssueListByPipelines(client *api.Client, cfg *config.Config, filters map
 	return nil
 }
 
+// streamIssuesByPipelines writes issues as NDJSON a page at a time. Pipelines
+// are read one after another in board order, so the output matches the
+// --output=json array while the first lines appear after a single request.
+func streamIssuesByPipelines(ctx context.Context, client *api.Client, workspaceID string, pipelineIDs []string, filters map[string]any, limit int, w writerFlusher) error {
+	remaining := limit
+	for _, pipelineID := range pipelineIDs {
+		err := forEachIssuePageByPipeline(ctx, client, pipelineID, workspaceID, filters, remaining, func(issues []issueListNode, _ int) error {
+			if limit > 0 && len(issues) > remaining {
+				issues = issues[:remaining]
+			}
+			remaining -= len(issues)
+			return output.NDJSON(w, issues)
+		})
+		if err != nil {
+			return err
+		}
+		if limit > 0 && remaining <= 0 {
+			break
+		}
+	}
+	return nil
+}
+
 // runIssueListClosed fetches closed issues.
-func runIssueListClosed(client *api.Client, workspaceID string, filters map[string]any, limit int, w writerFlusher) error {
-	issues, totalCount, err := fetchClosedIssues(client, workspaceID, filters, limit)
+func runIssueListClosed(ctx context.Context, client *api.Client, workspaceID string, filters map[string]any, limit int, w writerFlusher) error {
+	if output.IsNDJSON(outputFormat) {
+		return forEachClosedIssuePage(ctx, client, workspaceID, filters, limit, func(issues []issueListNode, _ int) error {
+			return output.NDJSON(w, issues)
+		})
+	}
+
+	issues, totalCount, err := fetchClosedIssues(ctx, client, workspaceID, filters, limit)
 	if err != nil {
 		return err
 	}
@@ -756,22 +793,22 @@ func runIssueListClosed(client *api.Client, workspaceID string, filters map[stri
 }
 
 // runIssueListByEpic fetches issues belonging to an epic.
-func runIssueListByEpic(client *api.Client, cfg *config.Config, filters map[string]any, limit int, w writerFlusher) error {
+func runIssueListByEpic(ctx context.Context, client *api.Client, cfg *config.Config, filters map[string]any, limit int, w writerFlusher) error {
 	workspaceID := cfg.Workspace
 
-	resolved, err := resolve.Epic(client, workspaceID, issueListEpic, cfg.Aliases.Epics)
+	resolved, err := resolve.Epic(ctx, client, workspaceID, issueListEpic, cfg.Aliases.Epics)
 	if err != nil {
 		return err
 	}
 
-	issues, totalCount, err := fetchIssuesByEpic(client, workspaceID, resolved.ID, filters, limit)
+	issues, totalCount, err := fetchIssuesByEpic(ctx, client, workspaceID, resolved.ID, filters, limit)
 	if err != nil {
 		return err
 	}
 
 	// Client-side pipeline filter for epic queries
 	if issueListPipeline != "" {
-		pResolved, err := resolve.Pipeline(client, workspaceID, issueListPipeline, cfg.Aliases.Pipelines)
+		pResolved, err := resolve.Pipeline(ctx, client, workspaceID, issueListPipeline, cfg.Aliases.Pipelines)
 		if err != nil {
 			return err
 		}
@@ -802,7 +839,7 @@ type writerFlusher = interface {
 }
 
 // fetchPipelineIDsForList fetches all pipeline IDs for the workspace.
-func fetchPipelineIDsForList(client *api.Client, workspaceID string) ([]resolve.CachedPipeline, error) {
+func fetchPipelineIDsForList(ctx context.Context, client *api.Client, workspaceID string) ([]resolve.CachedPipeline, error) {
 	// Try cache first
 	key := resolve.PipelineCacheKey(workspaceID)
 	if entries, ok := resolve.GetCachedPipelines(key); ok {
@@ -810,19 +847,35 @@ func fetchPipelineIDsForList(client *api.Client, workspaceID string) ([]resolve.
 	}
 
 	// Fetch from API (reuses resolve package's fetch)
-	return resolve.FetchPipelines(client, workspaceID)
+	return resolve.FetchPipelines(ctx, client, workspaceID)
 }
 
 // fetchIssuesByPipeline fetches issues from a single pipeline with pagination.
-func fetchIssuesByPipeline(client *api.Client, pipelineID, workspaceID string, filters map[string]any, limit int) ([]issueListNode, int, error) {
+func fetchIssuesByPipeline(ctx context.Context, client *api.Client, pipelineID, workspaceID string, filters map[string]any, limit int) ([]issueListNode, int, error) {
 	var allIssues []issueListNode
-	var cursor *string
 	totalCount := 0
+	err := forEachIssuePageByPipeline(ctx, client, pipelineID, workspaceID, filters, limit, func(issues []issueListNode, total int) error {
+		allIssues = append(allIssues, issues...)
+		totalCount = total
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return allIssues, totalCount, nil
+}
+
+// forEachIssuePageByPipeline pages through a pipeline's issues, calling fn
+// with each page as it arrives. It stops after limit issues (0 for no limit),
+// or as soon as fn returns an error, which is passed through.
+func forEachIssuePageByPipeline(ctx context.Context, client *api.Client, pipelineID, workspaceID string, filters map[string]any, limit int, fn func(issues []issueListNode, totalCount int) error) error {
+	var cursor *string
+	fetched := 0
 	pageSize := 50
 
 	for {
 		if limit > 0 {
-			remaining := limit - len(allIssues)
+			remaining := limit - fetched
 			if remaining <= 0 {
 				break
 			}
@@ -841,9 +894,9 @@ func fetchIssuesByPipeline(client *api.Client, pipelineID, workspaceID string, f
 			vars["after"] = *cursor
 		}
 
-		data, err := client.Execute(issueListByPipelineQuery, vars)
+		data, err := client.Execute(ctx, issueListByPipelineQuery, vars)
 		if err != nil {
-			return nil, 0, exitcode.General("fetching issues", err)
+			return exitcode.General("fetching issues", err)
 		}
 
 		var resp struct {
@@ -857,35 +910,53 @@ func fetchIssuesByPipeline(client *api.Client, pipelineID, workspaceID string, f
 			} `json:"searchIssuesByPipeline"`
 		}
 		if err := json.Unmarshal(data, &resp); err != nil {
-			return nil, 0, exitcode.General("parsing issue list", err)
+			return exitcode.General("parsing issue list", err)
 		}
 
-		totalCount = resp.SearchIssuesByPipeline.TotalCount
-		allIssues = append(allIssues, resp.SearchIssuesByPipeline.Nodes...)
+		fetched += len(resp.SearchIssuesByPipeline.Nodes)
+		if err := fn(resp.SearchIssuesByPipeline.Nodes, resp.SearchIssuesByPipeline.TotalCount); err != nil {
+			return err
+		}
 
 		if !resp.SearchIssuesByPipeline.PageInfo.HasNextPage {
 			break
 		}
-		if limit > 0 && len(allIssues) >= limit {
+		if limit > 0 && fetched >= limit {
 			break
 		}
 
 		cursor = &resp.SearchIssuesByPipeline.PageInfo.EndCursor
 	}
 
-	return allIssues, totalCount, nil
+	return nil
 }
 
 // fetchClosedIssues fetches closed issues with pagination.
-func fetchClosedIssues(client *api.Client, workspaceID string, filters map[string]any, limit int) ([]issueListNode, int, error) {
+func fetchClosedIssues(ctx context.Context, client *api.Client, workspaceID string, filters map[string]any, limit int) ([]issueListNode, int, error) {
 	var allIssues []issueListNode
-	var cursor *string
 	totalCount := 0
+	err := forEachClosedIssuePage(ctx, client, workspaceID, filters, limit, func(issues []issueListNode, total int) error {
+		allIssues = append(allIssues, issues...)
+		totalCount = total
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return allIssues, totalCount, nil
+}
+
+// forEachClosedIssuePage pages through closed issues, calling fn with each
+// page as it arrives. It stops after limit issues (0 for no limit), or as
+// soon as fn returns an error, which is passed through.
+func forEachClosedIssuePage(ctx context.Context, client *api.Client, workspaceID string, filters map[string]any, limit int, fn func(issues []issueListNode, totalCount int) error) error {
+	var cursor *string
+	fetched := 0
 	pageSize := 50
 
 	for {
 		if limit > 0 {
-			remaining := limit - len(allIssues)
+			remaining := limit - fetched
 			if remaining <= 0 {
 				break
 			}
@@ -903,9 +974,9 @@ func fetchClosedIssues(client *api.Client, workspaceID string, filters map[strin
 			vars["after"] = *cursor
 		}
 
-		data, err := client.Execute(issueListClosedQuery, vars)
+		data, err := client.Execute(ctx, issueListClosedQuery, vars)
 		if err != nil {
-			return nil, 0, exitcode.General("fetching closed issues", err)
+			return exitcode.General("fetching closed issues", err)
 		}
 
 		var resp struct {
@@ -919,23 +990,25 @@ func fetchClosedIssues(client *api.Client, workspaceID string, filters map[strin
 			} `json:"searchClosedIssues"`
 		}
 		if err := json.Unmarshal(data, &resp); err != nil {
-			return nil, 0, exitcode.General("parsing closed issues", err)
+			return exitcode.General("parsing closed issues", err)
 		}
 
-		totalCount = resp.SearchClosedIssues.TotalCount
-		allIssues = append(allIssues, resp.SearchClosedIssues.Nodes...)
+		fetched += len(resp.SearchClosedIssues.Nodes)
+		if err := fn(resp.SearchClosedIssues.Nodes, resp.SearchClosedIssues.TotalCount); err != nil {
+			return err
+		}
 
 		if !resp.SearchClosedIssues.PageInfo.HasNextPage {
 			break
 		}
-		if limit > 0 && len(allIssues) >= limit {
+		if limit > 0 && fetched >= limit {
 			break
 		}
 
 		cursor = &resp.SearchClosedIssues.PageInfo.EndCursor
 	}
 
-	return allIssues, totalCount, nil
+	return nil
 }
 
 const issueListByEpicQuery = `query ListIssuesByEpic(
@@ -988,7 +1061,7 @@ const issueListByEpicQuery = `query ListIssuesByEpic(
 }`
 
 // fetchIssuesByEpic fetches issues belonging to an epic.
-func fetchIssuesByEpic(client *api.Client, workspaceID, epicID string, filters map[string]any, limit int) ([]issueListNode, int, error) {
+func fetchIssuesByEpic(ctx context.Context, client *api.Client, workspaceID, epicID string, filters map[string]any, limit int) ([]issueListNode, int, error) {
 	var allIssues []issueListNode
 	var cursor *string
 	totalCount := 0
@@ -1018,7 +1091,7 @@ func fetchIssuesByEpic(client *api.Client, workspaceID, epicID string, filters m
 			vars["after"] = *cursor
 		}
 
-		data, err := client.Execute(issueListByEpicQuery, vars)
+		data, err := client.Execute(ctx, issueListByEpicQuery, vars)
 		if err != nil {
 			return nil, 0, exitcode.General("fetching epic issues", err)
 		}
@@ -1128,6 +1201,8 @@ func issueListRepoNamesAmbiguous(issues []issueListNode) bool {
 
 // runIssueShow implements `zh issue show <issue>`.
 func runIssueShow(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+
 	cfg, err := requireWorkspace()
 	if err != nil {
 		return err
@@ -1140,12 +1215,12 @@ func runIssueShow(cmd *cobra.Command, args []string) error {
 	// Interactive mode: fetch issue list and let the user pick one
 	if issueShowInteractive {
 		identifier, err := interactiveOrArg(cmd, nil, true, func() ([]selectItem, error) {
-			return fetchIssueSelectItems(client, cfg)
+			return fetchIssueSelectItems(ctx, client, cfg)
 		}, "Select an issue")
 		if err != nil {
 			return err
 		}
-		return runIssueShowByNode(client, ghClient, cfg.Workspace, identifier, w)
+		return runIssueShowByNode(ctx, client, ghClient, cfg.Workspace, identifier, w)
 	}
 
 	if len(args) < 1 {
@@ -1157,11 +1232,11 @@ func runIssueShow(cmd *cobra.Command, args []string) error {
 
 	// If it's a ZenHub ID, use the node query directly
 	if parseErr == nil && parsed.ZenHubID != "" {
-		return runIssueShowByNode(client, ghClient, cfg.Workspace, parsed.ZenHubID, w)
+		return runIssueShowByNode(ctx, client, ghClient, cfg.Workspace, parsed.ZenHubID, w)
 	}
 
 	// Resolve to get repo GH ID and issue number
-	resolved, err := resolve.Issue(client, cfg.Workspace, args[0], &resolve.IssueOptions{
+	resolved, err := resolve.Issue(ctx, client, cfg.Workspace, args[0], &resolve.IssueOptions{
 		RepoFlag:     issueShowRepo,
 		GitHubClient: ghClient,
 	})
@@ -1169,13 +1244,13 @@ func runIssueShow(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	return runIssueShowByInfo(client, ghClient, cfg.Workspace, resolved.RepoGhID, resolved.Number, w)
+	return runIssueShowByInfo(ctx, client, ghClient, cfg.Workspace, resolved.RepoGhID, resolved.Number, w)
 }
 
 // fetchIssueSelectItems fetches issues and converts them to selectItems for interactive mode.
-func fetchIssueSelectItems(client *api.Client, cfg *config.Config) ([]selectItem, error) {
+func fetchIssueSelectItems(ctx context.Context, client *api.Client, cfg *config.Config) ([]selectItem, error) {
 	// Fetch all pipeline IDs
-	pipelines, err := fetchPipelineIDsForList(client, cfg.Workspace)
+	pipelines, err := fetchPipelineIDsForList(ctx, client, cfg.Workspace)
 	if err != nil {
 		return nil, err
 	}
@@ -1191,7 +1266,7 @@ func fetchIssueSelectItems(client *api.Client, cfg *config.Config) ([]selectItem
 		wg.Add(1)
 		go func(idx int, pipelineID string) {
 			defer wg.Done()
-			issues, _, err := fetchIssuesByPipeline(client, pipelineID, cfg.Workspace, nil, 100)
+			issues, _, err := fetchIssuesByPipeline(ctx, client, pipelineID, cfg.Workspace, nil, 100)
 			results[idx] = pipelineResult{issues: issues, err: err}
 		}(i, p.ID)
 	}
@@ -1229,8 +1304,8 @@ func fetchIssueSelectItems(client *api.Client, cfg *config.Config) ([]selectItem
 }
 
 // runIssueShowByInfo fetches issue details using repo GH ID and issue number.
-func runIssueShowByInfo(client *api.Client, ghClient *gh.Client, workspaceID string, repoGhID, issueNumber int, w writerFlusher) error {
-	data, err := client.Execute(issueShowQuery, map[string]any{
+func runIssueShowByInfo(ctx context.Context, client *api.Client, ghClient *gh.Client, workspaceID string, repoGhID, issueNumber int, w writerFlusher) error {
+	data, err := client.Execute(ctx, issueShowQuery, map[string]any{
 		"repositoryGhId": repoGhID,
 		"issueNumber":    issueNumber,
 		"workspaceId":    workspaceID,
@@ -1253,13 +1328,13 @@ func runIssueShowByInfo(client *api.Client, ghClient *gh.Client, workspaceID str
 		return exitcode.NotFoundError(fmt.Sprintf("issue #%d not found", issueNumber))
 	}
 
-	ghData := fetchGitHubIssueData(ghClient, resp.IssueByInfo)
+	ghData := fetchGitHubIssueData(ctx, ghClient, resp.IssueByInfo)
 	return renderIssueDetail(w, resp.IssueByInfo, ghData)
 }
 
 // runIssueShowByNode fetches issue details using ZenHub node ID.
-func runIssueShowByNode(client *api.Client, ghClient *gh.Client, workspaceID, nodeID string, w writerFlusher) error {
-	data, err := client.Execute(issueShowByNodeQuery, map[string]any{
+func runIssueShowByNode(ctx context.Context, client *api.Client, ghClient *gh.Client, workspaceID, nodeID string, w writerFlusher) error {
+	data, err := client.Execute(ctx, issueShowByNodeQuery, map[string]any{
 		"id":          nodeID,
 		"workspaceId": workspaceID,
 	})
@@ -1281,7 +1356,7 @@ func runIssueShowByNode(client *api.Client, ghClient *gh.Client, workspaceID, no
 		return exitcode.NotFoundError(fmt.Sprintf("issue %q not found", nodeID))
 	}
 
-	ghData := fetchGitHubIssueData(ghClient, resp.Node)
+	ghData := fetchGitHubIssueData(ctx, ghClient, resp.Node)
 	return renderIssueDetail(w, resp.Node, ghData)
 }
 
@@ -1547,7 +1622,7 @@ func formatIssueShowState(state string, isPR bool, ghData *issueGitHubData) stri
 
 // fetchGitHubIssueData fetches supplementary data from GitHub.
 // Returns nil if GitHub client is not configured.
-func fetchGitHubIssueData(ghClient *gh.Client, issue *issueDetailNode) *issueGitHubData {
+func fetchGitHubIssueData(ctx context.Context, ghClient *gh.Client, issue *issueDetailNode) *issueGitHubData {
 	if ghClient == nil {
 		return nil
 	}
@@ -1555,7 +1630,7 @@ func fetchGitHubIssueData(ghClient *gh.Client, issue *issueDetailNode) *issueGit
 	owner := issue.Repository.Owner.Login
 	repo := issue.Repository.Name
 
-	data, err := ghClient.Execute(issueShowGitHubQuery, map[string]any{
+	data, err := ghClient.Execute(ctx, issueShowGitHubQuery, map[string]any{
 		"owner":  owner,
 		"repo":   repo,
 		"number": issue.Number,
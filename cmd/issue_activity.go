@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"sort"
@@ -90,6 +91,8 @@ func resetIssueActivityFlags() {
 
 // runIssueActivity implements `zh issue activity <issue>`.
 func runIssueActivity(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+
 	cfg, err := requireWorkspace()
 	if err != nil {
 		return err
@@ -111,7 +114,7 @@ func runIssueActivity(cmd *cobra.Command, args []string) error {
 	var zhEvents []activityEvent
 
 	if parseErr == nil && parsed.ZenHubID != "" {
-		info, events, err := fetchZenHubTimelineByNode(client, parsed.ZenHubID)
+		info, events, err := fetchZenHubTimelineByNode(ctx, client, parsed.ZenHubID)
 		if err != nil {
 			return err
 		}
@@ -122,7 +125,7 @@ func runIssueActivity(cmd *cobra.Command, args []string) error {
 		issueInfo.RepoOwner = info.RepoOwner
 		zhEvents = events
 	} else {
-		resolved, err := resolve.Issue(client, cfg.Workspace, args[0], &resolve.IssueOptions{
+		resolved, err := resolve.Issue(ctx, client, cfg.Workspace, args[0], &resolve.IssueOptions{
 			RepoFlag:     issueActivityRepo,
 			GitHubClient: ghClient,
 		})
@@ -130,7 +133,7 @@ func runIssueActivity(cmd *cobra.Command, args []string) error {
 			return err
 		}
 
-		info, events, err := fetchZenHubTimeline(client, resolved.RepoGhID, resolved.Number)
+		info, events, err := fetchZenHubTimeline(ctx, client, resolved.RepoGhID, resolved.Number)
 		if err != nil {
 			return err
 		}
@@ -149,7 +152,7 @@ func runIssueActivity(cmd *cobra.Command, args []string) error {
 		if ghClient == nil {
 			fmt.Fprintln(cmd.ErrOrStderr(), output.Yellow("Warning: --github flag ignored — GitHub access not configured"))
 		} else {
-			ghResult, err := fetchGitHubTimeline(ghClient, issueInfo.RepoOwner, issueInfo.RepoName, issueInfo.Number)
+			ghResult, err := fetchGitHubTimeline(ctx, ghClient, issueInfo.RepoOwner, issueInfo.RepoName, issueInfo.Number)
 			if err != nil {
 				fmt.Fprintf(cmd.ErrOrStderr(), "%s\n", output.Yellow("Warning: failed to fetch GitHub timeline: "+err.Error()))
 			} else {
@@ -206,20 +209,20 @@ func runIssueActivity(cmd *cobra.Command, args []string) error {
 	var connectedPRs []connectedPR
 
 	if issueActivityPRs && issueInfo.ID != "" {
-		prRefs := fetchIssueConnectedPRs(client, issueInfo.ID)
+		prRefs := fetchIssueConnectedPRs(ctx, client, issueInfo.ID)
 		for _, pr := range prRefs {
-			repo, err := resolve.LookupRepoWithRefresh(client, cfg.Workspace, pr.RepoName)
+			repo, err := resolve.LookupRepoWithRefresh(ctx, client, cfg.Workspace, pr.RepoName)
 			if err != nil {
 				continue
 			}
-			_, prEvents, err := fetchZenHubTimeline(client, repo.GhID, pr.Number)
+			_, prEvents, err := fetchZenHubTimeline(ctx, client, repo.GhID, pr.Number)
 			if err != nil {
 				continue
 			}
 
 			var headBranch string
 			if issueActivityGitHub && ghClient != nil {
-				ghResult, err := fetchGitHubTimeline(ghClient, pr.RepoOwner, pr.RepoName, pr.Number)
+				ghResult, err := fetchGitHubTimeline(ctx, ghClient, pr.RepoOwner, pr.RepoName, pr.Number)
 				if err == nil {
 					prEvents = append(prEvents, ghResult.Events...)
 					headBranch = ghResult.HeadBranch
@@ -341,7 +344,7 @@ func runIssueActivity(cmd *cobra.Command, args []string) error {
 }
 
 // fetchZenHubTimeline fetches ZenHub timeline items using repo GH ID and issue number.
-func fetchZenHubTimeline(client *api.Client, repoGhID, issueNumber int) (struct {
+func fetchZenHubTimeline(ctx context.Context, client *api.Client, repoGhID, issueNumber int) (struct {
 	ID        string
 	Number    int
 	Title     string
@@ -370,7 +373,7 @@ func fetchZenHubTimeline(client *api.Client, repoGhID, issueNumber int) (struct
 			vars["after"] = *cursor
 		}
 
-		data, err := client.Execute(issueTimelineQuery, vars)
+		data, err := client.Execute(ctx, issueTimelineQuery, vars)
 		if err != nil {
 			return info, nil, exitcode.General("fetching issue timeline", err)
 		}
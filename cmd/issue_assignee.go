@@ -0,0 +1,380 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/dslh/zh/internal/api"
+	"github.com/dslh/zh/internal/exitcode"
+	"github.com/dslh/zh/internal/output"
+	"github.com/dslh/zh/internal/resolve"
+	"github.com/spf13/cobra"
+)
+
+// GraphQL mutations for issue assignee
+
+const addAssigneesToIssuesMutation = `mutation AddAssigneesToIssues($input: AddAssigneesToIssuesInput!) {
+  addAssigneesToIssues(input: $input) {
+    successCount
+    failedIssues {
+      id
+      number
+      title
+    }
+  }
+}`
+
+const removeAssigneesFromIssuesMutation = `mutation RemoveAssigneesFromIssues($input: RemoveAssigneesFromIssuesInput!) {
+  removeAssigneesFromIssues(input: $input) {
+    successCount
+    failedIssues {
+      id
+      number
+      title
+    }
+  }
+}`
+
+// Commands
+
+var issueAssigneeCmd = &cobra.Command{
+	Use:   "assignee",
+	Short: "Add or remove assignees from issues",
+	Long: `Add or remove assignees from issues.
+
+Use -- to separate issue identifiers from users.
+
+Examples:
+  zh issue assignee add task-tracker#1 -- johndoe
+  zh issue assignee add task-tracker#1 task-tracker#2 -- johndoe janedoe
+  zh issue assignee remove task-tracker#1 -- johndoe`,
+}
+
+var issueAssigneeAddCmd = &cobra.Command{
+	Use:   "add <issue>... -- <user>...",
+	Short: "Add assignees to issues",
+	Long: `Add one or more assignees to one or more issues.
+
+Use -- to separate issue identifiers from users. Arguments before -- are
+issue identifiers; arguments after -- are users.
+
+Issues can be specified as repo#number, owner/repo#number, ZenHub IDs,
+or bare numbers with --repo. Users can be specified by GitHub login,
+display name, or ZenHub user ID; the @ prefix is optional.
+
+Examples:
+  zh issue assignee add task-tracker#1 -- johndoe
+  zh issue assignee add task-tracker#1 task-tracker#2 -- @johndoe @janedoe
+  zh issue assignee add --repo=task-tracker 1 2 -- johndoe`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runIssueAssigneeAdd,
+}
+
+var issueAssigneeRemoveCmd = &cobra.Command{
+	Use:   "remove <issue>... -- <user>...",
+	Short: "Remove assignees from issues",
+	Long: `Remove one or more assignees from one or more issues.
+
+Use -- to separate issue identifiers from users. Arguments before -- are
+issue identifiers; arguments after -- are users.
+
+Issues can be specified as repo#number, owner/repo#number, ZenHub IDs,
+or bare numbers with --repo. Users can be specified by GitHub login,
+display name, or ZenHub user ID; the @ prefix is optional.
+
+Examples:
+  zh issue assignee remove task-tracker#1 -- johndoe
+  zh issue assignee remove task-tracker#1 task-tracker#2 -- @johndoe @janedoe
+  zh issue assignee remove --repo=task-tracker 1 2 -- janedoe`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runIssueAssigneeRemove,
+}
+
+var (
+	issueAssigneeAddDryRun          bool
+	issueAssigneeAddRepo            string
+	issueAssigneeAddContinueOnError bool
+
+	issueAssigneeRemoveDryRun          bool
+	issueAssigneeRemoveRepo            string
+	issueAssigneeRemoveContinueOnError bool
+)
+
+func init() {
+	issueAssigneeAddCmd.Flags().BoolVar(&issueAssigneeAddDryRun, "dry-run", false, "Show what would be changed without executing")
+	issueAssigneeAddCmd.Flags().StringVar(&issueAssigneeAddRepo, "repo", "", "Repository context for bare issue numbers")
+	issueAssigneeAddCmd.Flags().BoolVar(&issueAssigneeAddContinueOnError, "continue-on-error", false, "Continue processing remaining issues after a resolution error")
+
+	issueAssigneeRemoveCmd.Flags().BoolVar(&issueAssigneeRemoveDryRun, "dry-run", false, "Show what would be changed without executing")
+	issueAssigneeRemoveCmd.Flags().StringVar(&issueAssigneeRemoveRepo, "repo", "", "Repository context for bare issue numbers")
+	issueAssigneeRemoveCmd.Flags().BoolVar(&issueAssigneeRemoveContinueOnError, "continue-on-error", false, "Continue processing remaining issues after a resolution error")
+
+	issueAssigneeCmd.AddCommand(issueAssigneeAddCmd)
+	issueAssigneeCmd.AddCommand(issueAssigneeRemoveCmd)
+	issueCmd.AddCommand(issueAssigneeCmd)
+}
+
+func resetIssueAssigneeFlags() {
+	issueAssigneeAddDryRun = false
+	issueAssigneeAddRepo = ""
+	issueAssigneeAddContinueOnError = false
+	issueAssigneeRemoveDryRun = false
+	issueAssigneeRemoveRepo = ""
+	issueAssigneeRemoveContinueOnError = false
+}
+
+// splitIssuesAndUsers separates issue identifiers from users using the
+// "--" separator. Arguments before -- are issue identifiers; arguments
+// after -- are users.
+func splitIssuesAndUsers(cmd *cobra.Command, args []string) (issueArgs, userArgs []string, err error) {
+	dash := cmd.ArgsLenAtDash()
+	if dash == -1 {
+		return nil, nil, exitcode.Usage("use -- to separate issue identifiers from users\n\nExample: zh issue assignee add task-tracker#1 -- johndoe")
+	}
+
+	issueArgs = args[:dash]
+	userArgs = args[dash:]
+
+	if len(issueArgs) == 0 {
+		return nil, nil, exitcode.Usage("at least one issue identifier is required")
+	}
+	if len(userArgs) == 0 {
+		return nil, nil, exitcode.Usage("at least one user is required")
+	}
+
+	return issueArgs, userArgs, nil
+}
+
+func runIssueAssigneeAdd(cmd *cobra.Command, args []string) error {
+	issueArgs, userArgs, err := splitIssuesAndUsers(cmd, args)
+	if err != nil {
+		return err
+	}
+
+	return runIssueAssigneeOp(cmd, issueArgs, userArgs, "add",
+		issueAssigneeAddRepo, issueAssigneeAddDryRun, issueAssigneeAddContinueOnError)
+}
+
+func runIssueAssigneeRemove(cmd *cobra.Command, args []string) error {
+	issueArgs, userArgs, err := splitIssuesAndUsers(cmd, args)
+	if err != nil {
+		return err
+	}
+
+	return runIssueAssigneeOp(cmd, issueArgs, userArgs, "remove",
+		issueAssigneeRemoveRepo, issueAssigneeRemoveDryRun, issueAssigneeRemoveContinueOnError)
+}
+
+func runIssueAssigneeOp(cmd *cobra.Command, issueArgs, userArgs []string, op, repoFlag string, dryRun, continueOnError bool) error {
+	ctx := commandContext(cmd)
+
+	cfg, err := requireWorkspace()
+	if err != nil {
+		return err
+	}
+
+	client := newClient(cfg, cmd)
+	w := cmd.OutOrStdout()
+	ghClient := newGitHubClient(cfg, cmd)
+
+	// Resolve each issue
+	var resolved []resolvedLabelIssue
+	var resolveFailed []output.FailedItem
+
+	for _, arg := range issueArgs {
+		issue, err := resolveForLabel(ctx, client, cfg.Workspace, arg, repoFlag, ghClient)
+		if err != nil {
+			if continueOnError {
+				resolveFailed = append(resolveFailed, output.FailedItem{
+					Ref:    arg,
+					Reason: err.Error(),
+				})
+				continue
+			}
+			return err
+		}
+		resolved = append(resolved, *issue)
+	}
+
+	if len(resolved) == 0 && len(resolveFailed) > 0 {
+		return exitcode.Generalf("all issues failed to resolve")
+	}
+
+	// Resolve users
+	users, err := resolve.Users(ctx, client, cfg.Workspace, userArgs)
+	if err != nil {
+		return err
+	}
+
+	userNames := make([]string, len(users))
+	for i, u := range users {
+		userNames[i] = u.DisplayName()
+	}
+	userDisplay := strings.Join(userNames, ", ")
+
+	// Dry run
+	if dryRun {
+		return renderIssueAssigneeDryRun(w, resolved, resolveFailed, userNames, op)
+	}
+
+	issueIDs := make([]string, len(resolved))
+	for i, r := range resolved {
+		issueIDs[i] = r.IssueID
+	}
+
+	resp, err := executeIssueAssigneeMutation(ctx, client, op, issueIDs, users)
+	if err != nil {
+		return err
+	}
+
+	// Build succeeded/failed lists from mutation response
+	failedIDs := make(map[string]bool)
+	var mutationFailed []output.FailedItem
+	for _, f := range resp.FailedIssues {
+		failedIDs[f.ID] = true
+		mutationFailed = append(mutationFailed, output.FailedItem{
+			Ref:    fmt.Sprintf("#%d", f.Number),
+			Reason: fmt.Sprintf("failed to %s assignees", op),
+		})
+	}
+
+	var succeeded []output.MutationItem
+	for _, r := range resolved {
+		if failedIDs[r.IssueID] {
+			continue
+		}
+		succeeded = append(succeeded, output.MutationItem{
+			Ref:   r.Ref(),
+			Title: truncateTitle(r.Title),
+		})
+	}
+
+	allFailed := append(resolveFailed, mutationFailed...)
+
+	// JSON output
+	if output.IsJSON(outputFormat) {
+		return output.JSON(w, map[string]any{
+			"operation":    op,
+			"users":        formatUserItemsJSON(users),
+			"succeeded":    formatMutationItemsJSON(succeeded),
+			"failed":       allFailed,
+			"successCount": resp.SuccessCount,
+		})
+	}
+
+	// Render output
+	verb := "Added"
+	preposition := "to"
+	if op == "remove" {
+		verb = "Removed"
+		preposition = "from"
+	}
+
+	totalAttempted := len(succeeded) + len(allFailed)
+	if len(allFailed) > 0 {
+		header := output.Green(fmt.Sprintf("%s assignee(s) %s %s %d of %d issue(s).", verb, userDisplay, preposition, len(succeeded), totalAttempted))
+		output.MutationPartialFailure(w, header, succeeded, allFailed)
+	} else if len(succeeded) == 1 {
+		output.MutationSingle(w, output.Green(fmt.Sprintf(
+			"%s assignee(s) %s %s %s.", verb, userDisplay, preposition, succeeded[0].Ref,
+		)))
+	} else {
+		header := output.Green(fmt.Sprintf("%s assignee(s) %s %s %d issue(s).", verb, userDisplay, preposition, len(succeeded)))
+		output.MutationBatch(w, header, succeeded)
+	}
+
+	if len(allFailed) > 0 {
+		return exitcode.Generalf("some issues failed")
+	}
+
+	return nil
+}
+
+// issueAssigneeMutationResult is the common response shape of the issue
+// assignee mutations.
+type issueAssigneeMutationResult struct {
+	SuccessCount int `json:"successCount"`
+	FailedIssues []struct {
+		ID     string `json:"id"`
+		Number int    `json:"number"`
+		Title  string `json:"title"`
+	} `json:"failedIssues"`
+}
+
+// executeIssueAssigneeMutation adds or removes assignees on issues. The
+// assignee IDs sent are the ZenHub IDs of the users' linked GitHub accounts.
+func executeIssueAssigneeMutation(ctx context.Context, client *api.Client, op string, issueIDs []string, users []*resolve.UserResult) (*issueAssigneeMutationResult, error) {
+	assigneeIDs := make([]string, len(users))
+	for i, u := range users {
+		if u.GithubID == "" {
+			return nil, exitcode.Usage(fmt.Sprintf("user %s has no linked GitHub account and cannot be assigned to issues", u.DisplayName()))
+		}
+		assigneeIDs[i] = u.GithubID
+	}
+
+	mutation := addAssigneesToIssuesMutation
+	mutationKey := "addAssigneesToIssues"
+	action := "adding assignees"
+	if op == "remove" {
+		mutation = removeAssigneesFromIssuesMutation
+		mutationKey = "removeAssigneesFromIssues"
+		action = "removing assignees"
+	}
+
+	data, err := client.Execute(ctx, mutation, map[string]any{
+		"input": map[string]any{
+			"issueIds":    issueIDs,
+			"assigneeIds": assigneeIDs,
+		},
+	})
+	if err != nil {
+		return nil, exitcode.General(action, err)
+	}
+
+	// The response is nested under the mutation name
+	var resp issueAssigneeMutationResult
+	var rawResp map[string]json.RawMessage
+	if err := json.Unmarshal(data, &rawResp); err != nil {
+		return nil, exitcode.General("parsing assignee response", err)
+	}
+	if mutData, ok := rawResp[mutationKey]; ok {
+		if err := json.Unmarshal(mutData, &resp); err != nil {
+			return nil, exitcode.General("parsing assignee response", err)
+		}
+	}
+
+	return &resp, nil
+}
+
+func renderIssueAssigneeDryRun(w writerFlusher, resolved []resolvedLabelIssue, resolveFailed []output.FailedItem, users []string, op string) error {
+	items := make([]output.MutationItem, len(resolved))
+	for i, r := range resolved {
+		items[i] = output.MutationItem{
+			Ref:   r.Ref(),
+			Title: truncateTitle(r.Title),
+		}
+	}
+
+	userDisplay := strings.Join(users, ", ")
+	var header string
+	if op == "add" {
+		header = fmt.Sprintf("Would add assignee(s) %s to %d issue(s)", userDisplay, len(resolved))
+	} else {
+		header = fmt.Sprintf("Would remove assignee(s) %s from %d issue(s)", userDisplay, len(resolved))
+	}
+
+	output.MutationDryRun(w, header, items)
+
+	if len(resolveFailed) > 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, output.Red("Failed to resolve:"))
+		fmt.Fprintln(w)
+		for _, f := range resolveFailed {
+			fmt.Fprintf(w, "  %s  %s\n", f.Ref, output.Red(f.Reason))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,274 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/dslh/zh/internal/testutil"
+)
+
+// --- issue assignee add ---
+
+func TestIssueAssigneeAdd(t *testing.T) {
+	resetIssueFlags()
+	resetIssueAssigneeFlags()
+
+	ms := setupIssueAssigneeServer(t)
+	var input map[string]any
+	ms.Handle(
+		func(req testutil.GraphQLRequest) bool { return strings.Contains(req.Query, "AddAssigneesToIssues") },
+		func(w http.ResponseWriter, req testutil.GraphQLRequest) {
+			input = mutationInputVar(req)
+			data, _ := json.Marshal(assigneeMutationResponse("addAssigneesToIssues", 1))
+			w.Write(data)
+		},
+	)
+	setupIssueTestEnv(t, ms)
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"issue", "assignee", "add", "task-tracker#1", "--", "johndoe"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("issue assignee add returned error: %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "Added assignee(s) @johndoe to task-tracker#1") {
+		t.Errorf("output should confirm add, got: %s", out)
+	}
+
+	ids, _ := input["assigneeIds"].([]any)
+	if len(ids) != 1 || ids[0] != "gh-user-1" {
+		t.Errorf("assigneeIds = %v, want GitHub user ID [gh-user-1]", input["assigneeIds"])
+	}
+	issueIDs, _ := input["issueIds"].([]any)
+	if len(issueIDs) != 1 || issueIDs[0] != "i1" {
+		t.Errorf("issueIds = %v, want [i1]", input["issueIds"])
+	}
+}
+
+func TestIssueAssigneeAddMultipleUsers(t *testing.T) {
+	resetIssueFlags()
+	resetIssueAssigneeFlags()
+
+	ms := setupIssueAssigneeServer(t)
+	ms.HandleQuery("AddAssigneesToIssues", assigneeMutationResponse("addAssigneesToIssues", 1))
+	setupIssueTestEnv(t, ms)
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"issue", "assignee", "add", "task-tracker#1", "--", "@johndoe", "janedoe"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("issue assignee add returned error: %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "@johndoe, @janedoe") {
+		t.Errorf("output should list both users, got: %s", out)
+	}
+}
+
+func TestIssueAssigneeAddDryRun(t *testing.T) {
+	resetIssueFlags()
+	resetIssueAssigneeFlags()
+
+	ms := setupIssueAssigneeServer(t)
+	setupIssueTestEnv(t, ms)
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"issue", "assignee", "add", "task-tracker#1", "--dry-run", "--", "johndoe"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("issue assignee add --dry-run returned error: %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "Would add assignee(s) @johndoe to 1 issue(s)") {
+		t.Errorf("dry run should describe add, got: %s", out)
+	}
+	if !strings.Contains(out, "task-tracker#1") {
+		t.Errorf("dry run should list issue, got: %s", out)
+	}
+}
+
+func TestIssueAssigneeAddContinueOnError(t *testing.T) {
+	resetIssueFlags()
+	resetIssueAssigneeFlags()
+
+	ms := testutil.NewMockServer(t)
+	ms.HandleQuery("ListRepos", repoResolutionResponse())
+	callCount := 0
+	ms.Handle(
+		func(req testutil.GraphQLRequest) bool {
+			return strings.Contains(req.Query, "IssueByInfo")
+		},
+		func(w http.ResponseWriter, req testutil.GraphQLRequest) {
+			callCount++
+			resp := issueByInfoResolutionResponse()
+			if callCount > 1 {
+				resp = map[string]any{"data": map[string]any{"issueByInfo": nil}}
+			}
+			data, _ := json.Marshal(resp)
+			w.Write(data)
+		},
+	)
+	ms.HandleQuery("GetIssueForLabel", issueLabelResolveResponseHelper("i1", 1, "Fix login button alignment"))
+	ms.HandleQuery("ListZenhubUsers", zenhubUsersResponse())
+	ms.HandleQuery("AddAssigneesToIssues", assigneeMutationResponse("addAssigneesToIssues", 1))
+	setupIssueTestEnv(t, ms)
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(new(bytes.Buffer))
+	rootCmd.SetArgs([]string{"issue", "assignee", "add", "task-tracker#1", "task-tracker#999", "--continue-on-error", "--", "johndoe"})
+
+	err := rootCmd.Execute()
+	if err == nil {
+		t.Fatal("expected error when some issues fail")
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "1 of 2 issue(s)") {
+		t.Errorf("output should show partial success, got: %s", out)
+	}
+	if !strings.Contains(out, "task-tracker#999") {
+		t.Errorf("output should list failed issue, got: %s", out)
+	}
+}
+
+func TestIssueAssigneeAddUnknownUser(t *testing.T) {
+	resetIssueFlags()
+	resetIssueAssigneeFlags()
+
+	ms := setupIssueAssigneeServer(t)
+	setupIssueTestEnv(t, ms)
+
+	rootCmd.SetOut(new(bytes.Buffer))
+	rootCmd.SetErr(new(bytes.Buffer))
+	rootCmd.SetArgs([]string{"issue", "assignee", "add", "task-tracker#1", "--", "nobody"})
+
+	err := rootCmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "nobody") {
+		t.Errorf("expected user not found error, got: %v", err)
+	}
+}
+
+// --- issue assignee remove ---
+
+func TestIssueAssigneeRemove(t *testing.T) {
+	resetIssueFlags()
+	resetIssueAssigneeFlags()
+
+	ms := setupIssueAssigneeServer(t)
+	ms.HandleQuery("RemoveAssigneesFromIssues", assigneeMutationResponse("removeAssigneesFromIssues", 1))
+	setupIssueTestEnv(t, ms)
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"issue", "assignee", "remove", "task-tracker#1", "--", "janedoe"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("issue assignee remove returned error: %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "Removed assignee(s) @janedoe from task-tracker#1") {
+		t.Errorf("output should confirm removal, got: %s", out)
+	}
+}
+
+func TestIssueAssigneeRemoveError(t *testing.T) {
+	resetIssueFlags()
+	resetIssueAssigneeFlags()
+
+	ms := setupIssueAssigneeServer(t)
+	ms.HandleQuery("RemoveAssigneesFromIssues", map[string]any{"errors": []any{map[string]any{"message": "boom"}}})
+	setupIssueTestEnv(t, ms)
+
+	rootCmd.SetOut(new(bytes.Buffer))
+	rootCmd.SetArgs([]string{"issue", "assignee", "remove", "task-tracker#1", "--", "janedoe"})
+
+	err := rootCmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "removing assignees") {
+		t.Errorf("expected a removing assignees error, got %v", err)
+	}
+}
+
+func TestIssueAssigneeRemoveJSON(t *testing.T) {
+	resetIssueFlags()
+	resetIssueAssigneeFlags()
+
+	ms := setupIssueAssigneeServer(t)
+	ms.HandleQuery("RemoveAssigneesFromIssues", assigneeMutationResponse("removeAssigneesFromIssues", 1))
+	setupIssueTestEnv(t, ms)
+
+	outputFormat = "json"
+	defer func() { outputFormat = "" }()
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"issue", "assignee", "remove", "task-tracker#1", "--", "janedoe"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("issue assignee remove --output=json returned error: %v", err)
+	}
+
+	var result map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+		t.Fatalf("invalid JSON: %v\nOutput: %s", err, buf.String())
+	}
+	if result["operation"] != "remove" {
+		t.Errorf("operation = %v, want remove", result["operation"])
+	}
+	succeeded, _ := result["succeeded"].([]any)
+	if len(succeeded) != 1 {
+		t.Errorf("succeeded should have 1 item, got: %v", result["succeeded"])
+	}
+}
+
+func TestIssueAssigneeHelp(t *testing.T) {
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"issue", "assignee", "add", "--help"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("help returned error: %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{"--dry-run", "--continue-on-error", "--repo", "--"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("help should mention %s", want)
+		}
+	}
+}
+
+// --- helpers ---
+
+func setupIssueAssigneeServer(t *testing.T) *testutil.MockServer {
+	t.Helper()
+
+	ms := testutil.NewMockServer(t)
+	ms.HandleQuery("ListRepos", repoResolutionResponse())
+	ms.HandleQuery("IssueByInfo", issueByInfoResolutionResponse())
+	ms.HandleQuery("GetIssueForLabel", issueLabelResolveResponseHelper("i1", 1, "Fix login button alignment"))
+	ms.HandleQuery("ListZenhubUsers", zenhubUsersResponse())
+	return ms
+}
+
+func assigneeMutationResponse(mutationKey string, successCount int) map[string]any {
+	return map[string]any{
+		"data": map[string]any{
+			mutationKey: map[string]any{
+				"successCount": successCount,
+				"failedIssues": []any{},
+			},
+		},
+	}
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -203,6 +204,8 @@ type blockItem struct {
 	Title     string
 	RepoName  string
 	RepoOwner string
+	RepoGhID  int // issues only
+	Number    int // issues only
 }
 
 // blockDependencyNode represents a blocking/blocked item from the API response.
@@ -235,8 +238,9 @@ var issueBlockCmd = &cobra.Command{
 Both arguments default to issues. Use --blocker-type=epic or --blocked-type=epic
 to specify that either side is a ZenHub epic.
 
-Note: Blocks cannot be removed via the API. Use ZenHub's web UI to remove
-blocking relationships.
+Issue-to-issue blocks can be removed with 'zh issue unblock'. Blocks
+involving an epic cannot be removed via the API; use ZenHub's web UI to
+remove them.
 
 Examples:
   zh issue block task-tracker#1 task-tracker#2
@@ -310,6 +314,8 @@ func resetIssueBlockingFlags() {
 }
 
 func runIssueBlock(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+
 	cfg, err := requireWorkspace()
 	if err != nil {
 		return err
@@ -330,13 +336,13 @@ func runIssueBlock(cmd *cobra.Command, args []string) error {
 	}
 
 	// Resolve blocker
-	blocker, err := resolveBlockItem(client, cfg, args[0], blockerType, issueBlockRepo, ghClient)
+	blocker, err := resolveBlockItem(ctx, client, cfg, args[0], blockerType, issueBlockRepo, ghClient)
 	if err != nil {
 		return fmt.Errorf("resolving blocker: %w", err)
 	}
 
 	// Resolve blocked
-	blocked, err := resolveBlockItem(client, cfg, args[1], blockedType, issueBlockRepo, ghClient)
+	blocked, err := resolveBlockItem(ctx, client, cfg, args[1], blockedType, issueBlockRepo, ghClient)
 	if err != nil {
 		return fmt.Errorf("resolving blocked: %w", err)
 	}
@@ -359,7 +365,7 @@ func runIssueBlock(cmd *cobra.Command, args []string) error {
 	}
 
 	// Execute mutation
-	data, err := client.Execute(createBlockageMutation, map[string]any{
+	data, err := client.Execute(ctx, createBlockageMutation, map[string]any{
 		"input": map[string]any{
 			"blocking": map[string]any{
 				"id":   blocker.ID,
@@ -404,12 +410,18 @@ func runIssueBlock(cmd *cobra.Command, args []string) error {
 		blocker.Ref, blocked.Ref,
 	)))
 	fmt.Fprintln(w)
-	fmt.Fprintln(w, output.Dim("Note: Blocks cannot be removed via the API. Use ZenHub's web UI to remove blocking relationships."))
+	if blocker.Type == "ISSUE" && blocked.Type == "ISSUE" {
+		fmt.Fprintln(w, output.Dim(fmt.Sprintf("To undo: zh issue unblock %s %s", blocker.Ref, blocked.Ref)))
+	} else {
+		fmt.Fprintln(w, output.Dim("Note: Blocks involving an epic cannot be removed via the API. Use ZenHub's web UI to remove them."))
+	}
 
 	return nil
 }
 
 func runIssueBlockers(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+
 	cfg, err := requireWorkspace()
 	if err != nil {
 		return err
@@ -431,7 +443,7 @@ func runIssueBlockers(cmd *cobra.Command, args []string) error {
 	}
 
 	if parseErr == nil && parsed.ZenHubID != "" {
-		data, err := client.Execute(issueBlockersByNodeQuery, map[string]any{"id": parsed.ZenHubID})
+		data, err := client.Execute(ctx, issueBlockersByNodeQuery, map[string]any{"id": parsed.ZenHubID})
 		if err != nil {
 			return exitcode.General("fetching blockers", err)
 		}
@@ -462,7 +474,7 @@ func runIssueBlockers(cmd *cobra.Command, args []string) error {
 		issueData.RepoOwner = resp.Node.Repository.OwnerName
 		issueData.Blockers = resp.Node.BlockingItems.Nodes
 	} else {
-		result, err := resolve.Issue(client, cfg.Workspace, args[0], &resolve.IssueOptions{
+		result, err := resolve.Issue(ctx, client, cfg.Workspace, args[0], &resolve.IssueOptions{
 			RepoFlag:     issueBlockersRepo,
 			GitHubClient: ghClient,
 		})
@@ -470,7 +482,7 @@ func runIssueBlockers(cmd *cobra.Command, args []string) error {
 			return err
 		}
 
-		data, err := client.Execute(issueBlockersQuery, map[string]any{
+		data, err := client.Execute(ctx, issueBlockersQuery, map[string]any{
 			"repositoryGhId": result.RepoGhID,
 			"issueNumber":    result.Number,
 		})
@@ -528,6 +540,8 @@ func runIssueBlockers(cmd *cobra.Command, args []string) error {
 }
 
 func runIssueBlocking(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+
 	cfg, err := requireWorkspace()
 	if err != nil {
 		return err
@@ -549,7 +563,7 @@ func runIssueBlocking(cmd *cobra.Command, args []string) error {
 	}
 
 	if parseErr == nil && parsed.ZenHubID != "" {
-		data, err := client.Execute(issueBlockingByNodeQuery, map[string]any{"id": parsed.ZenHubID})
+		data, err := client.Execute(ctx, issueBlockingByNodeQuery, map[string]any{"id": parsed.ZenHubID})
 		if err != nil {
 			return exitcode.General("fetching blocking items", err)
 		}
@@ -580,7 +594,7 @@ func runIssueBlocking(cmd *cobra.Command, args []string) error {
 		issueData.RepoOwner = resp.Node.Repository.OwnerName
 		issueData.Blocking = resp.Node.BlockedItems.Nodes
 	} else {
-		result, err := resolve.Issue(client, cfg.Workspace, args[0], &resolve.IssueOptions{
+		result, err := resolve.Issue(ctx, client, cfg.Workspace, args[0], &resolve.IssueOptions{
 			RepoFlag:     issueBlockingRepo,
 			GitHubClient: ghClient,
 		})
@@ -588,7 +602,7 @@ func runIssueBlocking(cmd *cobra.Command, args []string) error {
 			return err
 		}
 
-		data, err := client.Execute(issueBlockingQuery, map[string]any{
+		data, err := client.Execute(ctx, issueBlockingQuery, map[string]any{
 			"repositoryGhId": result.RepoGhID,
 			"issueNumber":    result.Number,
 		})
@@ -646,9 +660,9 @@ func runIssueBlocking(cmd *cobra.Command, args []string) error {
 }
 
 // resolveBlockItem resolves a blocker/blocked item by type (issue or epic).
-func resolveBlockItem(client *api.Client, cfg *config.Config, identifier, itemType, repoFlag string, ghClient *gh.Client) (*blockItem, error) {
+func resolveBlockItem(ctx context.Context, client *api.Client, cfg *config.Config, identifier, itemType, repoFlag string, ghClient *gh.Client) (*blockItem, error) {
 	if itemType == "epic" {
-		epic, err := resolve.Epic(client, cfg.Workspace, identifier, cfg.Aliases.Epics)
+		epic, err := resolve.Epic(ctx, client, cfg.Workspace, identifier, cfg.Aliases.Epics)
 		if err != nil {
 			return nil, err
 		}
@@ -661,7 +675,7 @@ func resolveBlockItem(client *api.Client, cfg *config.Config, identifier, itemTy
 	}
 
 	// Default: issue
-	result, err := resolve.Issue(client, cfg.Workspace, identifier, &resolve.IssueOptions{
+	result, err := resolve.Issue(ctx, client, cfg.Workspace, identifier, &resolve.IssueOptions{
 		RepoFlag:     repoFlag,
 		GitHubClient: ghClient,
 	})
@@ -676,6 +690,8 @@ func resolveBlockItem(client *api.Client, cfg *config.Config, identifier, itemTy
 		Title:     "", // We'll fill this in from the resolve query if needed
 		RepoName:  result.RepoName,
 		RepoOwner: result.RepoOwner,
+		RepoGhID:  result.RepoGhID,
+		Number:    result.Number,
 	}, nil
 }
 
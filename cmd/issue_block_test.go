@@ -35,8 +35,8 @@ func TestIssueBlockIssueToIssue(t *testing.T) {
 	if !strings.Contains(out, "blocking") {
 		t.Errorf("output should mention blocking, got: %s", out)
 	}
-	if !strings.Contains(out, "cannot be removed") {
-		t.Errorf("output should contain API limitation note, got: %s", out)
+	if !strings.Contains(out, "To undo: zh issue unblock") {
+		t.Errorf("output should show how to undo the block, got: %s", out)
 	}
 }
 
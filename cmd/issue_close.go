@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -82,12 +83,14 @@ var (
 	issueCloseDryRun          bool
 	issueCloseRepo            string
 	issueCloseContinueOnError bool
+	issueClosePlanOut         string
 )
 
 func init() {
 	issueCloseCmd.Flags().BoolVar(&issueCloseDryRun, "dry-run", false, "Show what would be closed without executing")
 	issueCloseCmd.Flags().StringVar(&issueCloseRepo, "repo", "", "Repository context for bare issue numbers")
 	issueCloseCmd.Flags().BoolVar(&issueCloseContinueOnError, "continue-on-error", false, "Continue processing remaining issues after a resolution error")
+	issueCloseCmd.Flags().StringVar(&issueClosePlanOut, "plan-out", "", "Write the resolved change to a plan file for zh apply instead of executing")
 
 	issueCmd.AddCommand(issueCloseCmd)
 }
@@ -96,9 +99,12 @@ func resetIssueCloseFlags() {
 	issueCloseDryRun = false
 	issueCloseRepo = ""
 	issueCloseContinueOnError = false
+	issueClosePlanOut = ""
 }
 
 func runIssueClose(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+
 	cfg, err := requireWorkspace()
 	if err != nil {
 		return err
@@ -114,7 +120,7 @@ func runIssueClose(cmd *cobra.Command, args []string) error {
 	var alreadyClosed []resolvedCloseIssue
 
 	for _, arg := range args {
-		issue, err := resolveForClose(client, cfg.Workspace, arg, issueCloseRepo, ghClient)
+		issue, err := resolveForClose(ctx, client, cfg.Workspace, arg, issueCloseRepo, ghClient)
 		if err != nil {
 			if issueCloseContinueOnError {
 				resolveFailed = append(resolveFailed, output.FailedItem{
@@ -139,11 +145,24 @@ func runIssueClose(cmd *cobra.Command, args []string) error {
 	}
 
 	// Dry run
-	if issueCloseDryRun {
+	if issueCloseDryRun || issueClosePlanOut != "" {
 		if output.IsJSON(outputFormat) {
-			return renderCloseDryRunJSON(w, resolved, alreadyClosed, resolveFailed)
+			err = renderCloseDryRunJSON(w, resolved, alreadyClosed, resolveFailed)
+		} else {
+			err = renderCloseDryRun(w, resolved, alreadyClosed, resolveFailed)
+		}
+		if err != nil {
+			return err
+		}
+
+		issueIDs := make([]string, len(resolved))
+		for i, r := range resolved {
+			issueIDs[i] = r.IssueID
 		}
-		return renderCloseDryRun(w, resolved, alreadyClosed, resolveFailed)
+		return savePlan(ctx, client, cfg.Workspace, w, issueClosePlanOut, &mutationPlan{
+			Command: "issue close",
+			Summary: fmt.Sprintf("close %d issue(s)", len(resolved)),
+		}, issueIDs)
 	}
 
 	if len(resolved) == 0 {
@@ -168,7 +187,7 @@ func runIssueClose(cmd *cobra.Command, args []string) error {
 		issueIDs[i] = r.IssueID
 	}
 
-	data, err := client.Execute(closeIssuesMutation, map[string]any{
+	data, err := client.Execute(ctx, closeIssuesMutation, map[string]any{
 		"input": map[string]any{
 			"issueIds": issueIDs,
 		},
@@ -256,8 +275,8 @@ func runIssueClose(cmd *cobra.Command, args []string) error {
 }
 
 // resolveForClose resolves an issue identifier and fetches its state.
-func resolveForClose(client *api.Client, workspaceID, identifier, repoFlag string, ghClient *gh.Client) (*resolvedCloseIssue, error) {
-	result, err := resolve.Issue(client, workspaceID, identifier, &resolve.IssueOptions{
+func resolveForClose(ctx context.Context, client *api.Client, workspaceID, identifier, repoFlag string, ghClient *gh.Client) (*resolvedCloseIssue, error) {
+	result, err := resolve.Issue(ctx, client, workspaceID, identifier, &resolve.IssueOptions{
 		RepoFlag:     repoFlag,
 		GitHubClient: ghClient,
 	})
@@ -266,7 +285,7 @@ func resolveForClose(client *api.Client, workspaceID, identifier, repoFlag strin
 	}
 
 	// Fetch current state
-	data, err := client.Execute(issueCloseResolveQuery, map[string]any{
+	data, err := client.Execute(ctx, issueCloseResolveQuery, map[string]any{
 		"issueId": result.ID,
 	})
 	if err != nil {
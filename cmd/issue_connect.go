@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 
@@ -147,6 +148,8 @@ func resetIssueDisconnectFlags() {
 }
 
 func runIssueConnect(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+
 	cfg, err := requireWorkspace()
 	if err != nil {
 		return err
@@ -157,12 +160,12 @@ func runIssueConnect(cmd *cobra.Command, args []string) error {
 	w := cmd.OutOrStdout()
 
 	// Resolve both identifiers
-	issue, err := resolveForConnect(client, cfg.Workspace, args[0], issueConnectRepo, ghClient)
+	issue, err := resolveForConnect(ctx, client, cfg.Workspace, args[0], issueConnectRepo, ghClient)
 	if err != nil {
 		return fmt.Errorf("resolving issue: %w", err)
 	}
 
-	pr, err := resolveForConnect(client, cfg.Workspace, args[1], issueConnectRepo, ghClient)
+	pr, err := resolveForConnect(ctx, client, cfg.Workspace, args[1], issueConnectRepo, ghClient)
 	if err != nil {
 		return fmt.Errorf("resolving PR: %w", err)
 	}
@@ -193,7 +196,7 @@ func runIssueConnect(cmd *cobra.Command, args []string) error {
 	}
 
 	// Execute mutation
-	data, err := client.Execute(createIssuePrConnectionMutation, map[string]any{
+	data, err := client.Execute(ctx, createIssuePrConnectionMutation, map[string]any{
 		"input": map[string]any{
 			"issueId":       issue.ID,
 			"pullRequestId": pr.ID,
@@ -240,6 +243,8 @@ func runIssueConnect(cmd *cobra.Command, args []string) error {
 }
 
 func runIssueDisconnect(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+
 	cfg, err := requireWorkspace()
 	if err != nil {
 		return err
@@ -250,12 +255,12 @@ func runIssueDisconnect(cmd *cobra.Command, args []string) error {
 	w := cmd.OutOrStdout()
 
 	// Resolve both identifiers
-	issue, err := resolveForConnect(client, cfg.Workspace, args[0], issueDisconnectRepo, ghClient)
+	issue, err := resolveForConnect(ctx, client, cfg.Workspace, args[0], issueDisconnectRepo, ghClient)
 	if err != nil {
 		return fmt.Errorf("resolving issue: %w", err)
 	}
 
-	pr, err := resolveForConnect(client, cfg.Workspace, args[1], issueDisconnectRepo, ghClient)
+	pr, err := resolveForConnect(ctx, client, cfg.Workspace, args[1], issueDisconnectRepo, ghClient)
 	if err != nil {
 		return fmt.Errorf("resolving PR: %w", err)
 	}
@@ -286,7 +291,7 @@ func runIssueDisconnect(cmd *cobra.Command, args []string) error {
 	}
 
 	// Execute mutation
-	data, err := client.Execute(deleteIssuePrConnectionMutation, map[string]any{
+	data, err := client.Execute(ctx, deleteIssuePrConnectionMutation, map[string]any{
 		"input": map[string]any{
 			"issueId":       issue.ID,
 			"pullRequestId": pr.ID,
@@ -330,16 +335,16 @@ func runIssueDisconnect(cmd *cobra.Command, args []string) error {
 }
 
 // resolveForConnect resolves an issue/PR identifier and fetches its type (issue vs PR).
-func resolveForConnect(client *api.Client, workspaceID, identifier, repoFlag string, ghClient *gh.Client) (*resolvedConnectItem, error) {
+func resolveForConnect(ctx context.Context, client *api.Client, workspaceID, identifier, repoFlag string, ghClient *gh.Client) (*resolvedConnectItem, error) {
 	parsed, parseErr := resolve.ParseIssueRef(identifier)
 
 	// If it's a ZenHub ID, use the node query directly
 	if parseErr == nil && parsed.ZenHubID != "" {
-		return resolveConnectByNode(client, parsed.ZenHubID)
+		return resolveConnectByNode(ctx, client, parsed.ZenHubID)
 	}
 
 	// Resolve to get repo GH ID and issue number
-	result, err := resolve.Issue(client, workspaceID, identifier, &resolve.IssueOptions{
+	result, err := resolve.Issue(ctx, client, workspaceID, identifier, &resolve.IssueOptions{
 		RepoFlag:     repoFlag,
 		GitHubClient: ghClient,
 	})
@@ -347,11 +352,11 @@ func resolveForConnect(client *api.Client, workspaceID, identifier, repoFlag str
 		return nil, err
 	}
 
-	return resolveConnectByInfo(client, result.RepoGhID, result.Number)
+	return resolveConnectByInfo(ctx, client, result.RepoGhID, result.Number)
 }
 
-func resolveConnectByInfo(client *api.Client, repoGhID, issueNumber int) (*resolvedConnectItem, error) {
-	data, err := client.Execute(issueConnectResolveQuery, map[string]any{
+func resolveConnectByInfo(ctx context.Context, client *api.Client, repoGhID, issueNumber int) (*resolvedConnectItem, error) {
+	data, err := client.Execute(ctx, issueConnectResolveQuery, map[string]any{
 		"repositoryGhId": repoGhID,
 		"issueNumber":    issueNumber,
 	})
@@ -389,8 +394,8 @@ func resolveConnectByInfo(client *api.Client, repoGhID, issueNumber int) (*resol
 	}, nil
 }
 
-func resolveConnectByNode(client *api.Client, nodeID string) (*resolvedConnectItem, error) {
-	data, err := client.Execute(issueConnectResolveByNodeQuery, map[string]any{
+func resolveConnectByNode(ctx context.Context, client *api.Client, nodeID string) (*resolvedConnectItem, error) {
+	data, err := client.Execute(ctx, issueConnectResolveByNodeQuery, map[string]any{
 		"id": nodeID,
 	})
 	if err != nil {
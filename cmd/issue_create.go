@@ -0,0 +1,597 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dslh/zh/internal/api"
+	"github.com/dslh/zh/internal/exitcode"
+	"github.com/dslh/zh/internal/output"
+	"github.com/dslh/zh/internal/resolve"
+	"github.com/spf13/cobra"
+)
+
+// GraphQL queries and mutations for issue create
+
+const createIssueMutation = `mutation CreateIssue($input: CreateIssueInput!, $workspaceId: ID!) {
+  createIssue(input: $input) {
+    issue {
+      id
+      number
+      title
+      htmlUrl
+      repository {
+        id
+        ghId
+        name
+        ownerName
+      }
+      pipelineIssue(workspaceId: $workspaceId) {
+        id
+        pipeline {
+          id
+          name
+        }
+      }
+    }
+  }
+}`
+
+const repoEstimateSetQuery = `query GetRepoEstimateSets($workspaceId: ID!) {
+  workspace(id: $workspaceId) {
+    repositoriesConnection(first: 100) {
+      nodes {
+        id
+        estimateSet {
+          values
+        }
+      }
+    }
+  }
+}`
+
+// Commands
+
+var issueCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create an issue and place it on the board",
+	Long: `Create a new GitHub issue in a workspace repository and apply its ZenHub
+placement in one step.
+
+Every placement flag is resolved before the issue is created, so a typo in
+a pipeline, label or user name fails without creating anything. Placements
+are applied after creation; if any of them fail, the issue is still created
+and the failures are reported.
+
+Examples:
+  zh issue create --repo=task-tracker --title="Fix login timeout"
+  zh issue create --repo=task-tracker --title="Fix login timeout" \
+    --pipeline="In Development" --estimate=3 --priority=high \
+    --label=bug --assignee=johndoe --epic="Q1 Roadmap" --sprint=current`,
+	Args: cobra.NoArgs,
+	RunE: runIssueCreate,
+}
+
+var (
+	issueCreateRepo      string
+	issueCreateTitle     string
+	issueCreateBody      string
+	issueCreatePipeline  string
+	issueCreateEstimate  string
+	issueCreatePriority  string
+	issueCreateLabels    []string
+	issueCreateAssignees []string
+	issueCreateEpic      string
+	issueCreateSprint    string
+	issueCreateDryRun    bool
+)
+
+func init() {
+	issueCreateCmd.Flags().StringVar(&issueCreateRepo, "repo", "", "Repository to create the issue in (required)")
+	issueCreateCmd.Flags().StringVar(&issueCreateTitle, "title", "", "Issue title (required)")
+	issueCreateCmd.Flags().StringVar(&issueCreateBody, "body", "", "Issue body (markdown)")
+	issueCreateCmd.Flags().StringVar(&issueCreatePipeline, "pipeline", "", "Pipeline to place the issue in")
+	issueCreateCmd.Flags().StringVar(&issueCreateEstimate, "estimate", "", "Estimate value")
+	issueCreateCmd.Flags().StringVar(&issueCreatePriority, "priority", "", "Priority name")
+	issueCreateCmd.Flags().StringSliceVar(&issueCreateLabels, "label", nil, "Label to add (repeatable or comma-separated)")
+	issueCreateCmd.Flags().StringSliceVar(&issueCreateAssignees, "assignee", nil, "User to assign (repeatable or comma-separated)")
+	issueCreateCmd.Flags().StringVar(&issueCreateEpic, "epic", "", "Epic to add the issue to")
+	issueCreateCmd.Flags().StringVar(&issueCreateSprint, "sprint", "", "Sprint to add the issue to (name, ID, or current/next/previous)")
+	issueCreateCmd.Flags().BoolVar(&issueCreateDryRun, "dry-run", false, "Show what would be changed without executing")
+
+	issueCmd.AddCommand(issueCreateCmd)
+}
+
+func resetIssueCreateFlags() {
+	issueCreateRepo = ""
+	issueCreateTitle = ""
+	issueCreateBody = ""
+	issueCreatePipeline = ""
+	issueCreateEstimate = ""
+	issueCreatePriority = ""
+	issueCreateLabels = nil
+	issueCreateAssignees = nil
+	issueCreateEpic = ""
+	issueCreateSprint = ""
+	issueCreateDryRun = false
+}
+
+// issueCreatePlan holds every placement resolved before the issue is created.
+type issueCreatePlan struct {
+	Repo      *resolve.CachedRepo
+	Pipeline  *resolve.PipelineResult
+	Estimate  *float64
+	Priority  *resolve.PriorityResult
+	Labels    []*resolve.LabelResult
+	Assignees []*resolve.UserResult
+	Epic      *resolve.EpicResult
+	EpicRepo  *resolve.CachedRepo // backing repo for legacy epics
+	Sprint    *resolve.SprintResult
+}
+
+// createdIssue is the issue returned by the createIssue mutation.
+type createdIssue struct {
+	ID         string `json:"id"`
+	Number     int    `json:"number"`
+	Title      string `json:"title"`
+	HtmlUrl    string `json:"htmlUrl"`
+	Repository struct {
+		ID        string `json:"id"`
+		GhID      int    `json:"ghId"`
+		Name      string `json:"name"`
+		OwnerName string `json:"ownerName"`
+	} `json:"repository"`
+	PipelineIssue *struct {
+		ID       string `json:"id"`
+		Pipeline struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+		} `json:"pipeline"`
+	} `json:"pipelineIssue"`
+}
+
+func (c *createdIssue) Ref() string {
+	return fmt.Sprintf("%s#%d", c.Repository.Name, c.Number)
+}
+
+// runIssueCreate implements `zh issue create`.
+func runIssueCreate(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+
+	if issueCreateRepo == "" {
+		return exitcode.Usage("--repo is required")
+	}
+	if strings.TrimSpace(issueCreateTitle) == "" {
+		return exitcode.Usage("--title is required")
+	}
+
+	cfg, err := requireWorkspace()
+	if err != nil {
+		return err
+	}
+
+	client := newClient(cfg, cmd)
+	w := cmd.OutOrStdout()
+
+	plan, err := resolveIssueCreatePlan(ctx, client, cfg.Workspace, cfg.Aliases.Pipelines, cfg.Aliases.Epics)
+	if err != nil {
+		return err
+	}
+
+	repoRef := fmt.Sprintf("%s/%s", plan.Repo.OwnerName, plan.Repo.Name)
+
+	if issueCreateDryRun {
+		msg := fmt.Sprintf("Would create issue %q in %s.", issueCreateTitle, repoRef)
+		output.MutationDryRunDetail(w, msg, plan.details(issueCreateBody))
+		return nil
+	}
+
+	// Create the issue
+	input := map[string]any{
+		"repositoryId": plan.Repo.ID,
+		"title":        issueCreateTitle,
+	}
+	if issueCreateBody != "" {
+		input["body"] = issueCreateBody
+	}
+	if len(plan.Labels) > 0 {
+		input["labels"] = plan.labelNames()
+	}
+	if len(plan.Assignees) > 0 {
+		input["assignees"] = plan.assigneeLogins()
+	}
+
+	data, err := client.Execute(ctx, createIssueMutation, map[string]any{
+		"input":       input,
+		"workspaceId": cfg.Workspace,
+	})
+	if err != nil {
+		return exitcode.General("creating issue", err)
+	}
+
+	var resp struct {
+		CreateIssue struct {
+			Issue createdIssue `json:"issue"`
+		} `json:"createIssue"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return exitcode.General("parsing create issue response", err)
+	}
+
+	created := &resp.CreateIssue.Issue
+	if created.Repository.GhID == 0 {
+		created.Repository.GhID = plan.Repo.GhID
+	}
+
+	// Apply ZenHub placement
+	failed := applyIssueCreatePlan(ctx, client, cfg.Workspace, created, plan)
+
+	if output.IsJSON(outputFormat) {
+		if err := output.JSON(w, formatCreatedIssueJSON(created, plan, failed)); err != nil {
+			return err
+		}
+	} else {
+		renderIssueCreated(w, created, plan, failed)
+	}
+
+	if len(failed) > 0 {
+		return exitcode.Generalf("issue %s was created but some placements failed", created.Ref())
+	}
+	return nil
+}
+
+// resolveIssueCreatePlan resolves every placement flag up front, so that
+// nothing is created when an identifier fails to resolve.
+func resolveIssueCreatePlan(ctx context.Context, client *api.Client, workspaceID string, pipelineAliases, epicAliases map[string]string) (*issueCreatePlan, error) {
+	plan := &issueCreatePlan{}
+
+	repo, err := resolve.LookupRepoWithRefresh(ctx, client, workspaceID, issueCreateRepo)
+	if err != nil {
+		return nil, err
+	}
+	plan.Repo = repo
+
+	if issueCreatePipeline != "" {
+		plan.Pipeline, err = resolve.Pipeline(ctx, client, workspaceID, issueCreatePipeline, pipelineAliases)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if issueCreateEstimate != "" {
+		v, err := strconv.ParseFloat(issueCreateEstimate, 64)
+		if err != nil {
+			return nil, exitcode.Usage(fmt.Sprintf("invalid estimate value %q — must be a number", issueCreateEstimate))
+		}
+		valid, err := fetchRepoEstimateValues(ctx, client, workspaceID, repo.ID)
+		if err != nil {
+			return nil, err
+		}
+		if len(valid) > 0 && !isValidEstimate(v, valid) {
+			return nil, exitcode.Usage(fmt.Sprintf(
+				"invalid estimate value %s — valid values are: %s",
+				formatEstimate(v), formatEstimateList(valid),
+			))
+		}
+		plan.Estimate = &v
+	}
+
+	if issueCreatePriority != "" {
+		plan.Priority, err = resolve.Priority(ctx, client, workspaceID, issueCreatePriority)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(issueCreateLabels) > 0 {
+		plan.Labels, err = resolve.Labels(ctx, client, workspaceID, issueCreateLabels)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(issueCreateAssignees) > 0 {
+		plan.Assignees, err = resolve.Users(ctx, client, workspaceID, issueCreateAssignees)
+		if err != nil {
+			return nil, err
+		}
+		for _, u := range plan.Assignees {
+			if u.Login == "" {
+				return nil, exitcode.Usage(fmt.Sprintf("user %q has no linked GitHub account and cannot be assigned to issues", u.DisplayName()))
+			}
+		}
+	}
+
+	if issueCreateEpic != "" {
+		plan.Epic, err = resolve.Epic(ctx, client, workspaceID, issueCreateEpic, epicAliases)
+		if err != nil {
+			return nil, err
+		}
+		if plan.Epic.Type == "legacy" {
+			plan.EpicRepo, err = resolve.LookupRepoWithRefresh(ctx, client, workspaceID, plan.Epic.RepoOwner+"/"+plan.Epic.RepoName)
+			if err != nil {
+				return nil, exitcode.General(fmt.Sprintf("resolving repository for legacy epic %s", legacyEpicRef(plan.Epic)), err)
+			}
+		}
+	}
+
+	if issueCreateSprint != "" {
+		plan.Sprint, err = resolve.Sprint(ctx, client, workspaceID, issueCreateSprint)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return plan, nil
+}
+
+// fetchRepoEstimateValues returns the valid estimate values for a repository.
+func fetchRepoEstimateValues(ctx context.Context, client *api.Client, workspaceID, repoID string) ([]float64, error) {
+	data, err := client.Execute(ctx, repoEstimateSetQuery, map[string]any{
+		"workspaceId": workspaceID,
+	})
+	if err != nil {
+		return nil, exitcode.General("fetching estimate values", err)
+	}
+
+	var resp struct {
+		Workspace struct {
+			RepositoriesConnection struct {
+				Nodes []struct {
+					ID          string `json:"id"`
+					EstimateSet struct {
+						Values []float64 `json:"values"`
+					} `json:"estimateSet"`
+				} `json:"nodes"`
+			} `json:"repositoriesConnection"`
+		} `json:"workspace"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, exitcode.General("parsing estimate values", err)
+	}
+
+	for _, r := range resp.Workspace.RepositoriesConnection.Nodes {
+		if r.ID == repoID {
+			return r.EstimateSet.Values, nil
+		}
+	}
+	return nil, nil
+}
+
+// applyIssueCreatePlan applies each placement to a newly created issue,
+// returning the placements that failed.
+func applyIssueCreatePlan(ctx context.Context, client *api.Client, workspaceID string, issue *createdIssue, plan *issueCreatePlan) []output.FailedItem {
+	var failed []output.FailedItem
+	fail := func(step string, err error) {
+		failed = append(failed, output.FailedItem{Ref: step, Reason: err.Error()})
+	}
+
+	if plan.Pipeline != nil {
+		move := resolvedMoveIssue{
+			IssueID:   issue.ID,
+			Number:    issue.Number,
+			Title:     issue.Title,
+			RepoName:  issue.Repository.Name,
+			RepoOwner: issue.Repository.OwnerName,
+		}
+		alreadyThere := false
+		if issue.PipelineIssue != nil {
+			move.PipelineIssueID = issue.PipelineIssue.ID
+			move.CurrentPipeline = issue.PipelineIssue.Pipeline.Name
+			alreadyThere = issue.PipelineIssue.Pipeline.ID == plan.Pipeline.ID
+		}
+		if !alreadyThere {
+			posType := posDefault
+			if move.PipelineIssueID == "" {
+				// Without a pipeline issue ID only the positional mutation works
+				posType = posNumeric
+			}
+			if err := executeMoveIssue(ctx, client, move, plan.Pipeline.ID, posType, 0); err != nil {
+				fail("pipeline", err)
+			}
+		}
+	}
+
+	if plan.Estimate != nil {
+		_, err := client.Execute(ctx, setEstimateMutation, map[string]any{
+			"input": map[string]any{
+				"issueId": issue.ID,
+				"value":   *plan.Estimate,
+			},
+		})
+		if err != nil {
+			fail("estimate", exitcode.General("setting estimate", err))
+		}
+	}
+
+	if plan.Priority != nil {
+		target := []resolvedPriorityIssue{{
+			IssueID:  issue.ID,
+			Number:   issue.Number,
+			RepoGhID: issue.Repository.GhID,
+		}}
+		if err := executeSetPriority(ctx, client, target, plan.Priority.ID); err != nil {
+			fail("priority", err)
+		}
+	}
+
+	if plan.Epic != nil {
+		var err error
+		if plan.Epic.Type == "legacy" {
+			err = client.UpdateEpicIssues(ctx, plan.EpicRepo.GhID, plan.Epic.IssueNumber, []api.RESTIssueRef{
+				{RepoID: issue.Repository.GhID, IssueNumber: issue.Number},
+			}, nil)
+		} else {
+			_, err = client.Execute(ctx, addIssuesToZenhubEpicsMutation, map[string]any{
+				"input": map[string]any{
+					"zenhubEpicIds": []string{plan.Epic.ID},
+					"issueIds":      []string{issue.ID},
+				},
+			})
+		}
+		if err != nil {
+			fail("epic", exitcode.General("adding issue to epic", err))
+		}
+	}
+
+	if plan.Sprint != nil {
+		_, err := client.Execute(ctx, addIssuesToSprintsMutation, map[string]any{
+			"input": map[string]any{
+				"issueIds":  []string{issue.ID},
+				"sprintIds": []string{plan.Sprint.ID},
+			},
+		})
+		if err != nil {
+			fail("sprint", exitcode.General("adding issue to sprint", err))
+		}
+	}
+
+	return failed
+}
+
+func (p *issueCreatePlan) labelNames() []string {
+	names := make([]string, len(p.Labels))
+	for i, l := range p.Labels {
+		names[i] = l.Name
+	}
+	return names
+}
+
+func (p *issueCreatePlan) assigneeLogins() []string {
+	logins := make([]string, len(p.Assignees))
+	for i, u := range p.Assignees {
+		logins[i] = u.Login
+	}
+	return logins
+}
+
+func (p *issueCreatePlan) assigneeNames() []string {
+	names := make([]string, len(p.Assignees))
+	for i, u := range p.Assignees {
+		names[i] = u.DisplayName()
+	}
+	return names
+}
+
+func (p *issueCreatePlan) epicDisplay() string {
+	if p.Epic.Type == "legacy" {
+		return fmt.Sprintf("%s (%s)", p.Epic.Title, legacyEpicRef(p.Epic))
+	}
+	return p.Epic.Title
+}
+
+// details returns the placement as dry-run/confirmation detail lines.
+func (p *issueCreatePlan) details(body string) []output.DetailLine {
+	var details []output.DetailLine
+	if body != "" {
+		details = append(details, output.DetailLine{Key: "Body", Value: truncateBody(body)})
+	}
+	if p.Pipeline != nil {
+		details = append(details, output.DetailLine{Key: "Pipeline", Value: p.Pipeline.Name})
+	}
+	if p.Estimate != nil {
+		details = append(details, output.DetailLine{Key: "Estimate", Value: formatEstimate(*p.Estimate)})
+	}
+	if p.Priority != nil {
+		details = append(details, output.DetailLine{Key: "Priority", Value: p.Priority.Name})
+	}
+	if len(p.Labels) > 0 {
+		details = append(details, output.DetailLine{Key: "Labels", Value: strings.Join(p.labelNames(), ", ")})
+	}
+	if len(p.Assignees) > 0 {
+		details = append(details, output.DetailLine{Key: "Assignees", Value: strings.Join(p.assigneeNames(), ", ")})
+	}
+	if p.Epic != nil {
+		details = append(details, output.DetailLine{Key: "Epic", Value: p.epicDisplay()})
+	}
+	if p.Sprint != nil {
+		details = append(details, output.DetailLine{Key: "Sprint", Value: p.Sprint.Name})
+	}
+	return details
+}
+
+func renderIssueCreated(w writerFlusher, issue *createdIssue, plan *issueCreatePlan, failed []output.FailedItem) {
+	output.MutationSingle(w, output.Green(fmt.Sprintf("Created issue %s %q.", issue.Ref(), issue.Title)))
+	fmt.Fprintln(w)
+
+	failedSteps := make(map[string]bool)
+	for _, f := range failed {
+		failedSteps[f.Ref] = true
+	}
+
+	lines := []output.DetailLine{{Key: "ID", Value: output.Cyan(issue.ID)}}
+	if issue.HtmlUrl != "" {
+		lines = append(lines, output.DetailLine{Key: "URL", Value: output.Cyan(issue.HtmlUrl)})
+	}
+	for _, d := range plan.details("") {
+		if failedSteps[strings.ToLower(d.Key)] {
+			continue
+		}
+		lines = append(lines, d)
+	}
+
+	maxKey := 0
+	for _, l := range lines {
+		if len(l.Key) > maxKey {
+			maxKey = len(l.Key)
+		}
+	}
+	for _, l := range lines {
+		fmt.Fprintf(w, "  %-*s %s\n", maxKey+1, l.Key+":", l.Value)
+	}
+
+	if len(failed) > 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, output.Red("Failed:"))
+		fmt.Fprintln(w)
+		for _, f := range failed {
+			fmt.Fprintf(w, "  %s  %s\n", f.Ref, output.Red(f.Reason))
+		}
+	}
+}
+
+func formatCreatedIssueJSON(issue *createdIssue, plan *issueCreatePlan, failed []output.FailedItem) map[string]any {
+	result := map[string]any{
+		"issue": map[string]any{
+			"id":         issue.ID,
+			"number":     issue.Number,
+			"title":      issue.Title,
+			"repository": fmt.Sprintf("%s/%s", issue.Repository.OwnerName, issue.Repository.Name),
+			"htmlUrl":    issue.HtmlUrl,
+		},
+		"pipeline":  nil,
+		"estimate":  formatEstimateJSON(plan.Estimate),
+		"priority":  nil,
+		"labels":    plan.labelNames(),
+		"assignees": plan.assigneeLogins(),
+		"epic":      nil,
+		"sprint":    nil,
+		"failed":    failed,
+	}
+	if plan.Pipeline != nil {
+		result["pipeline"] = map[string]any{"id": plan.Pipeline.ID, "name": plan.Pipeline.Name}
+	}
+	if plan.Priority != nil {
+		result["priority"] = map[string]any{"id": plan.Priority.ID, "name": plan.Priority.Name}
+	}
+	if plan.Epic != nil {
+		result["epic"] = map[string]any{"id": plan.Epic.ID, "title": plan.Epic.Title}
+	}
+	if plan.Sprint != nil {
+		result["sprint"] = map[string]any{"id": plan.Sprint.ID, "name": plan.Sprint.Name}
+	}
+	return result
+}
+
+// truncateBody shortens an issue body for dry-run and confirmation output.
+// It counts runes rather than bytes so multi-byte characters aren't split.
+func truncateBody(body string) string {
+	runes := []rune(body)
+	if len(runes) > 60 {
+		return string(runes[:57]) + "..."
+	}
+	return body
+}
@@ -0,0 +1,422 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/dslh/zh/internal/cache"
+	"github.com/dslh/zh/internal/resolve"
+	"github.com/dslh/zh/internal/testutil"
+)
+
+// --- issue create ---
+
+func TestIssueCreate(t *testing.T) {
+	resetIssueFlags()
+	resetIssueCreateFlags()
+
+	ms := testutil.NewMockServer(t)
+	var createInput map[string]any
+	ms.Handle(
+		func(req testutil.GraphQLRequest) bool {
+			return strings.Contains(req.Query, "CreateIssue")
+		},
+		func(w http.ResponseWriter, req testutil.GraphQLRequest) {
+			createInput = mutationInputVar(req)
+			data, _ := json.Marshal(createIssueResponse(nil))
+			w.Write(data)
+		},
+	)
+	setupIssueTestEnv(t, ms)
+	seedIssueCreateRepoCache()
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"issue", "create", "--repo=task-tracker", "--title=Fix login timeout", "--body=Users are logged out"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("issue create returned error: %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "Created issue task-tracker#5") {
+		t.Errorf("output should confirm creation, got: %s", out)
+	}
+	if !strings.Contains(out, "https://github.com/dlakehammond/task-tracker/issues/5") {
+		t.Errorf("output should contain issue URL, got: %s", out)
+	}
+
+	if createInput["repositoryId"] != "r1" {
+		t.Errorf("repositoryId = %v, want r1", createInput["repositoryId"])
+	}
+	if createInput["body"] != "Users are logged out" {
+		t.Errorf("body = %v, want body text", createInput["body"])
+	}
+	if _, ok := createInput["labels"]; ok {
+		t.Error("labels should be omitted when no --label given")
+	}
+}
+
+func TestIssueCreateWithPlacement(t *testing.T) {
+	resetIssueFlags()
+	resetIssueCreateFlags()
+
+	ms := testutil.NewMockServer(t)
+	var createInput map[string]any
+	var estimateValue any
+	sprintCalled, epicCalled, priorityCalled, moveCalled := false, false, false, false
+
+	ms.HandleQuery("GetWorkspaceLabels", workspaceLabelsResponse())
+	ms.HandleQuery("ListZenhubUsers", zenhubUsersResponse())
+	ms.HandleQuery("GetWorkspacePriorities", prioritiesResponse())
+	ms.HandleQuery("ListSprints", sprintResolutionResponse())
+	ms.HandleQuery("GetRepoEstimateSets", repoEstimateSetsResponse())
+	ms.Handle(
+		func(req testutil.GraphQLRequest) bool {
+			return strings.Contains(req.Query, "CreateIssue")
+		},
+		func(w http.ResponseWriter, req testutil.GraphQLRequest) {
+			createInput = mutationInputVar(req)
+			data, _ := json.Marshal(createIssueResponse(map[string]any{
+				"id":       "pi5",
+				"pipeline": map[string]any{"id": "p1", "name": "New Issues"},
+			}))
+			w.Write(data)
+		},
+	)
+	ms.Handle(
+		func(req testutil.GraphQLRequest) bool { return strings.Contains(req.Query, "MovePipelineIssues") },
+		func(w http.ResponseWriter, req testutil.GraphQLRequest) {
+			moveCalled = true
+			data, _ := json.Marshal(movePipelineIssuesResponse())
+			w.Write(data)
+		},
+	)
+	ms.Handle(
+		func(req testutil.GraphQLRequest) bool { return strings.Contains(req.Query, "SetEstimate") },
+		func(w http.ResponseWriter, req testutil.GraphQLRequest) {
+			input := mutationInputVar(req)
+			estimateValue = input["value"]
+			data, _ := json.Marshal(setEstimateSuccessResponse())
+			w.Write(data)
+		},
+	)
+	ms.Handle(
+		func(req testutil.GraphQLRequest) bool { return strings.Contains(req.Query, "SetIssuePriority") },
+		func(w http.ResponseWriter, req testutil.GraphQLRequest) {
+			priorityCalled = true
+			data, _ := json.Marshal(setPriorityResponse())
+			w.Write(data)
+		},
+	)
+	ms.Handle(
+		func(req testutil.GraphQLRequest) bool { return strings.Contains(req.Query, "AddIssuesToZenhubEpics") },
+		func(w http.ResponseWriter, req testutil.GraphQLRequest) {
+			epicCalled = true
+			data, _ := json.Marshal(addIssuesToEpicsResponse())
+			w.Write(data)
+		},
+	)
+	ms.Handle(
+		func(req testutil.GraphQLRequest) bool { return strings.Contains(req.Query, "AddIssuesToSprints") },
+		func(w http.ResponseWriter, req testutil.GraphQLRequest) {
+			sprintCalled = true
+			data, _ := json.Marshal(addIssuesToSprintsResponse())
+			w.Write(data)
+		},
+	)
+	setupIssueTestEnv(t, ms)
+	seedIssueCreateRepoCache()
+	seedIssueCreateEpicCache()
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{
+		"issue", "create", "--repo=task-tracker", "--title=Fix login timeout",
+		"--pipeline=In Development", "--estimate=3", "--priority=high",
+		"--label=bug", "--assignee=johndoe", "--epic=Q1 Roadmap", "--sprint=current",
+	})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("issue create returned error: %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{"Created issue task-tracker#5", "In Development", "High priority", "bug", "Q1 Roadmap", "Sprint 47"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output should contain %q, got: %s", want, out)
+		}
+	}
+
+	labels, _ := createInput["labels"].([]any)
+	if len(labels) != 1 || labels[0] != "bug" {
+		t.Errorf("labels = %v, want [bug]", createInput["labels"])
+	}
+	assignees, _ := createInput["assignees"].([]any)
+	if len(assignees) != 1 || assignees[0] != "johndoe" {
+		t.Errorf("assignees = %v, want [johndoe]", createInput["assignees"])
+	}
+	if estimateValue != 3.0 {
+		t.Errorf("estimate value = %v, want 3", estimateValue)
+	}
+	if !moveCalled || !priorityCalled || !epicCalled || !sprintCalled {
+		t.Errorf("all placements should be applied: move=%v priority=%v epic=%v sprint=%v",
+			moveCalled, priorityCalled, epicCalled, sprintCalled)
+	}
+}
+
+func TestIssueCreateDryRun(t *testing.T) {
+	resetIssueFlags()
+	resetIssueCreateFlags()
+
+	ms := testutil.NewMockServer(t)
+	ms.HandleQuery("ListSprints", sprintResolutionResponse())
+	ms.Handle(
+		func(req testutil.GraphQLRequest) bool { return strings.Contains(req.Query, "CreateIssue") },
+		func(w http.ResponseWriter, req testutil.GraphQLRequest) {
+			t.Error("dry run should not create an issue")
+		},
+	)
+	setupIssueTestEnv(t, ms)
+	seedIssueCreateRepoCache()
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{
+		"issue", "create", "--repo=task-tracker", "--title=Fix login timeout",
+		"--pipeline=Done", "--sprint=next", "--dry-run",
+	})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("issue create --dry-run returned error: %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, `Would create issue "Fix login timeout" in dlakehammond/task-tracker`) {
+		t.Errorf("dry run should describe the issue, got: %s", out)
+	}
+	if !strings.Contains(out, "Done") {
+		t.Errorf("dry run should show pipeline, got: %s", out)
+	}
+	if !strings.Contains(out, "Sprint 48") {
+		t.Errorf("dry run should show sprint, got: %s", out)
+	}
+}
+
+func TestIssueCreateInvalidEstimate(t *testing.T) {
+	resetIssueFlags()
+	resetIssueCreateFlags()
+
+	ms := testutil.NewMockServer(t)
+	ms.HandleQuery("GetRepoEstimateSets", repoEstimateSetsResponse())
+	setupIssueTestEnv(t, ms)
+	seedIssueCreateRepoCache()
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(new(bytes.Buffer))
+	rootCmd.SetArgs([]string{"issue", "create", "--repo=task-tracker", "--title=Fix", "--estimate=4"})
+
+	err := rootCmd.Execute()
+	if err == nil {
+		t.Fatal("expected error for invalid estimate")
+	}
+	if !strings.Contains(err.Error(), "valid values are") {
+		t.Errorf("error should list valid values, got: %v", err)
+	}
+}
+
+func TestIssueCreateRequiresTitle(t *testing.T) {
+	resetIssueFlags()
+	resetIssueCreateFlags()
+
+	ms := testutil.NewMockServer(t)
+	setupIssueTestEnv(t, ms)
+
+	rootCmd.SetOut(new(bytes.Buffer))
+	rootCmd.SetErr(new(bytes.Buffer))
+	rootCmd.SetArgs([]string{"issue", "create", "--repo=task-tracker"})
+
+	err := rootCmd.Execute()
+	if err == nil {
+		t.Fatal("expected error when --title is missing")
+	}
+	if !strings.Contains(err.Error(), "--title is required") {
+		t.Errorf("error should mention --title, got: %v", err)
+	}
+}
+
+func TestIssueCreatePartialFailure(t *testing.T) {
+	resetIssueFlags()
+	resetIssueCreateFlags()
+
+	ms := testutil.NewMockServer(t)
+	ms.HandleQuery("ListSprints", sprintResolutionResponse())
+	ms.HandleQuery("CreateIssue", createIssueResponse(nil))
+	ms.HandleQuery("AddIssuesToSprints", map[string]any{
+		"errors": []any{map[string]any{"message": "sprint is closed"}},
+	})
+	setupIssueTestEnv(t, ms)
+	seedIssueCreateRepoCache()
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(new(bytes.Buffer))
+	rootCmd.SetArgs([]string{"issue", "create", "--repo=task-tracker", "--title=Fix login timeout", "--sprint=current"})
+
+	err := rootCmd.Execute()
+	if err == nil {
+		t.Fatal("expected error when a placement fails")
+	}
+	if !strings.Contains(err.Error(), "was created but some placements failed") {
+		t.Errorf("error should mention partial failure, got: %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "Created issue task-tracker#5") {
+		t.Errorf("output should still confirm creation, got: %s", out)
+	}
+	if !strings.Contains(out, "Failed:") || !strings.Contains(out, "sprint") {
+		t.Errorf("output should list failed placement, got: %s", out)
+	}
+}
+
+func TestIssueCreateJSON(t *testing.T) {
+	resetIssueFlags()
+	resetIssueCreateFlags()
+
+	ms := testutil.NewMockServer(t)
+	ms.HandleQuery("CreateIssue", createIssueResponse(nil))
+	setupIssueTestEnv(t, ms)
+	seedIssueCreateRepoCache()
+
+	outputFormat = "json"
+	defer func() { outputFormat = "" }()
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"issue", "create", "--repo=task-tracker", "--title=Fix login timeout"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("issue create --output=json returned error: %v", err)
+	}
+
+	var result map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+		t.Fatalf("invalid JSON: %v\nOutput: %s", err, buf.String())
+	}
+
+	issue, ok := result["issue"].(map[string]any)
+	if !ok {
+		t.Fatalf("JSON should contain issue object, got: %v", result)
+	}
+	if issue["number"] != float64(5) {
+		t.Errorf("issue number = %v, want 5", issue["number"])
+	}
+	if issue["repository"] != "dlakehammond/task-tracker" {
+		t.Errorf("repository = %v, want dlakehammond/task-tracker", issue["repository"])
+	}
+}
+
+func TestIssueCreateHelp(t *testing.T) {
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"issue", "create", "--help"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("help returned error: %v", err)
+	}
+
+	out := buf.String()
+	for _, flag := range []string{"--repo", "--title", "--pipeline", "--estimate", "--priority", "--label", "--assignee", "--epic", "--sprint", "--dry-run"} {
+		if !strings.Contains(out, flag) {
+			t.Errorf("help should mention %s", flag)
+		}
+	}
+}
+
+// --- helpers ---
+
+func seedIssueCreateRepoCache() {
+	_ = cache.Set(resolve.RepoCacheKey("ws-123"), []resolve.CachedRepo{
+		{ID: "r1", GhID: 12345, Name: "task-tracker", OwnerName: "dlakehammond"},
+	})
+}
+
+func seedIssueCreateEpicCache() {
+	_ = cache.Set(resolve.EpicCacheKey("ws-123"), []resolve.CachedEpic{
+		{ID: "epic-1", Title: "Q1 Roadmap", Type: "zenhub"},
+	})
+}
+
+func createIssueResponse(pipelineIssue map[string]any) map[string]any {
+	var pi any
+	if pipelineIssue != nil {
+		pi = pipelineIssue
+	}
+	return map[string]any{
+		"data": map[string]any{
+			"createIssue": map[string]any{
+				"issue": map[string]any{
+					"id":      "i5",
+					"number":  5,
+					"title":   "Fix login timeout",
+					"htmlUrl": "https://github.com/dlakehammond/task-tracker/issues/5",
+					"repository": map[string]any{
+						"id":        "r1",
+						"ghId":      12345,
+						"name":      "task-tracker",
+						"ownerName": "dlakehammond",
+					},
+					"pipelineIssue": pi,
+				},
+			},
+		},
+	}
+}
+
+func repoEstimateSetsResponse() map[string]any {
+	return map[string]any{
+		"data": map[string]any{
+			"workspace": map[string]any{
+				"repositoriesConnection": map[string]any{
+					"nodes": []any{
+						map[string]any{
+							"id": "r1",
+							"estimateSet": map[string]any{
+								"values": []any{1, 2, 3, 5, 8},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// mutationInputVar decodes the "input" variable of a mutation request.
+func mutationInputVar(req testutil.GraphQLRequest) map[string]any {
+	var vars struct {
+		Input map[string]any `json:"input"`
+	}
+	_ = json.Unmarshal(req.Variables, &vars)
+	return vars.Input
+}
+
+func TestTruncateBody(t *testing.T) {
+	if got := truncateBody("short"); got != "short" {
+		t.Errorf("truncateBody(short) = %q", got)
+	}
+	long := strings.Repeat("é", 70)
+	got := truncateBody(long)
+	if want := strings.Repeat("é", 57) + "..."; got != want {
+		t.Errorf("truncateBody = %q, want %q", got, want)
+	}
+	if !utf8.ValidString(got) {
+		t.Errorf("truncateBody produced invalid UTF-8: %q", got)
+	}
+}
@@ -0,0 +1,541 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/dslh/zh/internal/api"
+	"github.com/dslh/zh/internal/exitcode"
+	"github.com/dslh/zh/internal/gh"
+	"github.com/dslh/zh/internal/output"
+	"github.com/dslh/zh/internal/resolve"
+	"github.com/spf13/cobra"
+)
+
+// GraphQL queries and mutations for issue edit
+
+const issueEditResolveQuery = `query GetIssueForEdit($issueId: ID!) {
+  node(id: $issueId) {
+    ... on Issue {
+      id
+      number
+      title
+      body
+      pullRequest
+      repository {
+        name
+        ownerName
+        ghId
+      }
+      assignees(first: 50) {
+        nodes { login }
+      }
+    }
+  }
+}`
+
+const updateZenhubIssueMutation = `mutation UpdateZenhubIssue($input: UpdateIssueInput!) {
+  updateIssue(input: $input) {
+    issue {
+      id
+      number
+      title
+      body
+    }
+  }
+}`
+
+// Commands
+
+var issueEditCmd = &cobra.Command{
+	Use:   "edit <issue>",
+	Short: "Edit an issue's title, body or assignees",
+	Long: `Edit the title, body or assignees of an issue.
+
+With --title, --body or --body-file, only the given fields are changed.
+With no flags, the issue is opened in $VISUAL or $EDITOR as a markdown
+buffer with a front-matter header holding the title and assignees. Only
+the fields that changed are sent.
+
+When GitHub access is configured, the title and body are updated through
+the GitHub API; otherwise they are updated through ZenHub.
+
+Examples:
+  zh issue edit task-tracker#1 --title="Fix login button alignment"
+  zh issue edit task-tracker#1 --body-file=description.md
+  zh issue edit task-tracker#1`,
+	Args: cobra.ExactArgs(1),
+	RunE: runIssueEdit,
+}
+
+var (
+	issueEditTitle    string
+	issueEditBody     string
+	issueEditBodyFile string
+	issueEditRepo     string
+	issueEditDryRun   bool
+)
+
+func init() {
+	issueEditCmd.Flags().StringVar(&issueEditTitle, "title", "", "New issue title")
+	issueEditCmd.Flags().StringVar(&issueEditBody, "body", "", "New issue body (markdown)")
+	issueEditCmd.Flags().StringVar(&issueEditBodyFile, "body-file", "", "Read the new body from a file (\"-\" for stdin)")
+	issueEditCmd.Flags().StringVar(&issueEditRepo, "repo", "", "Repository context for bare issue numbers")
+	issueEditCmd.Flags().BoolVar(&issueEditDryRun, "dry-run", false, "Show what would be changed without executing")
+
+	issueCmd.AddCommand(issueEditCmd)
+}
+
+func resetIssueEditFlags() {
+	// Flag mode is chosen by Changed, so it must be reset too
+	resetFlagDefaults(issueEditCmd.Flags())
+	issueEditTitle = ""
+	issueEditBody = ""
+	issueEditBodyFile = ""
+	issueEditRepo = ""
+	issueEditDryRun = false
+}
+
+// resolvedEditIssue holds the current state of an issue being edited.
+type resolvedEditIssue struct {
+	IssueID   string
+	Number    int
+	Title     string
+	Body      string
+	IsPR      bool
+	RepoName  string
+	RepoOwner string
+	RepoGhID  int
+	Assignees []string // GitHub logins
+}
+
+func (r *resolvedEditIssue) Ref() string {
+	return fmt.Sprintf("%s#%d", r.RepoName, r.Number)
+}
+
+// issueEditChanges describes the fields that differ from the current issue.
+type issueEditChanges struct {
+	Title            *string
+	Body             *string
+	AssigneesAdded   []string
+	AssigneesRemoved []string
+}
+
+func (c *issueEditChanges) empty() bool {
+	return c.Title == nil && c.Body == nil && len(c.AssigneesAdded) == 0 && len(c.AssigneesRemoved) == 0
+}
+
+// runIssueEdit implements `zh issue edit <issue>`.
+func runIssueEdit(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+
+	flags := cmd.Flags()
+	if flags.Changed("body") && flags.Changed("body-file") {
+		return exitcode.Usage("--body and --body-file cannot be used together")
+	}
+	if flags.Changed("title") && strings.TrimSpace(issueEditTitle) == "" {
+		return exitcode.Usage("title cannot be empty")
+	}
+
+	cfg, err := requireWorkspace()
+	if err != nil {
+		return err
+	}
+
+	client := newClient(cfg, cmd)
+	w := cmd.OutOrStdout()
+	ghClient := newGitHubClient(cfg, cmd)
+
+	resolved, err := resolveForEdit(ctx, client, cfg.Workspace, args[0], ghClient)
+	if err != nil {
+		return err
+	}
+
+	var changes *issueEditChanges
+	if flags.Changed("title") || flags.Changed("body") || flags.Changed("body-file") {
+		changes, err = issueEditChangesFromFlags(resolved, cmd)
+	} else {
+		changes, err = issueEditChangesFromEditor(resolved)
+	}
+	if err != nil {
+		return err
+	}
+
+	if changes.empty() {
+		fmt.Fprintf(w, "No changes to %s.\n", resolved.Ref())
+		return nil
+	}
+
+	// Resolve assignee changes to user IDs before changing anything
+	var added, removed []*resolve.UserResult
+	if len(changes.AssigneesAdded) > 0 {
+		added, err = resolve.Users(ctx, client, cfg.Workspace, changes.AssigneesAdded)
+		if err != nil {
+			return err
+		}
+	}
+	if len(changes.AssigneesRemoved) > 0 {
+		removed, err = resolve.Users(ctx, client, cfg.Workspace, changes.AssigneesRemoved)
+		if err != nil {
+			return err
+		}
+	}
+
+	viaGitHub := ghClient != nil && (changes.Title != nil || changes.Body != nil)
+
+	if issueEditDryRun {
+		msg := fmt.Sprintf("Would update issue %s.", resolved.Ref())
+		if viaGitHub {
+			msg = fmt.Sprintf("Would update issue %s via GitHub.", resolved.Ref())
+		}
+		output.MutationDryRunDetail(w, msg, issueEditDetails(changes))
+		return nil
+	}
+
+	if changes.Title != nil || changes.Body != nil {
+		if viaGitHub {
+			err = updateIssueViaGitHub(ctx, ghClient, resolved, changes)
+		} else {
+			err = updateIssueViaZenhub(ctx, client, resolved, changes)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(added) > 0 {
+		res, err := executeIssueAssigneeMutation(ctx, client, "add", []string{resolved.IssueID}, added)
+		if err != nil {
+			return err
+		}
+		if len(res.FailedIssues) > 0 {
+			return exitcode.Generalf("adding assignees on %s failed", resolved.Ref())
+		}
+	}
+	if len(removed) > 0 {
+		res, err := executeIssueAssigneeMutation(ctx, client, "remove", []string{resolved.IssueID}, removed)
+		if err != nil {
+			return err
+		}
+		if len(res.FailedIssues) > 0 {
+			return exitcode.Generalf("removing assignees on %s failed", resolved.Ref())
+		}
+	}
+
+	if output.IsJSON(outputFormat) {
+		result := map[string]any{
+			"issue":            resolved.Ref(),
+			"title":            nil,
+			"bodyUpdated":      changes.Body != nil,
+			"assigneesAdded":   formatUserItemsJSON(added),
+			"assigneesRemoved": formatUserItemsJSON(removed),
+		}
+		if changes.Title != nil {
+			result["title"] = *changes.Title
+		}
+		return output.JSON(w, result)
+	}
+
+	output.MutationSingle(w, output.Green(fmt.Sprintf("Updated issue %s.", resolved.Ref())))
+	fmt.Fprintln(w)
+	if changes.Title != nil {
+		fmt.Fprintf(w, "  Title:     %s\n", *changes.Title)
+	}
+	if changes.Body != nil {
+		fmt.Fprintf(w, "  Body:      updated\n")
+	}
+	if len(changes.AssigneesAdded) > 0 || len(changes.AssigneesRemoved) > 0 {
+		fmt.Fprintf(w, "  Assignees: %s\n", formatAssigneeDiff(changes))
+	}
+
+	return nil
+}
+
+// resolveForEdit resolves an issue identifier and fetches its current
+// title, body and assignees.
+func resolveForEdit(ctx context.Context, client *api.Client, workspaceID, identifier string, ghClient *gh.Client) (*resolvedEditIssue, error) {
+	result, err := resolve.Issue(ctx, client, workspaceID, identifier, &resolve.IssueOptions{
+		RepoFlag:     issueEditRepo,
+		GitHubClient: ghClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := client.Execute(ctx, issueEditResolveQuery, map[string]any{
+		"issueId": result.ID,
+	})
+	if err != nil {
+		return nil, exitcode.General("fetching issue details", err)
+	}
+
+	var resp struct {
+		Node *struct {
+			ID          string `json:"id"`
+			Number      int    `json:"number"`
+			Title       string `json:"title"`
+			Body        string `json:"body"`
+			PullRequest bool   `json:"pullRequest"`
+			Repository  struct {
+				Name      string `json:"name"`
+				OwnerName string `json:"ownerName"`
+				GhID      int    `json:"ghId"`
+			} `json:"repository"`
+			Assignees struct {
+				Nodes []struct {
+					Login string `json:"login"`
+				} `json:"nodes"`
+			} `json:"assignees"`
+		} `json:"node"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, exitcode.General("parsing issue details response", err)
+	}
+
+	if resp.Node == nil {
+		return nil, exitcode.NotFoundError(fmt.Sprintf("issue %q not found", identifier))
+	}
+
+	resolved := &resolvedEditIssue{
+		IssueID:   resp.Node.ID,
+		Number:    resp.Node.Number,
+		Title:     resp.Node.Title,
+		Body:      resp.Node.Body,
+		IsPR:      resp.Node.PullRequest,
+		RepoName:  resp.Node.Repository.Name,
+		RepoOwner: resp.Node.Repository.OwnerName,
+		RepoGhID:  resp.Node.Repository.GhID,
+	}
+	for _, a := range resp.Node.Assignees.Nodes {
+		resolved.Assignees = append(resolved.Assignees, a.Login)
+	}
+
+	return resolved, nil
+}
+
+// issueEditChangesFromFlags builds the change set from --title, --body and
+// --body-file, dropping fields that match the current values. An explicit
+// empty --body clears the body.
+func issueEditChangesFromFlags(resolved *resolvedEditIssue, cmd *cobra.Command) (*issueEditChanges, error) {
+	changes := &issueEditChanges{}
+	flags := cmd.Flags()
+
+	if flags.Changed("title") && issueEditTitle != resolved.Title {
+		title := issueEditTitle
+		changes.Title = &title
+	}
+
+	if !flags.Changed("body") && !flags.Changed("body-file") {
+		return changes, nil
+	}
+
+	body := issueEditBody
+	if flags.Changed("body-file") {
+		var data []byte
+		var err error
+		if issueEditBodyFile == "-" {
+			data, err = io.ReadAll(cmd.InOrStdin())
+		} else {
+			data, err = os.ReadFile(issueEditBodyFile)
+		}
+		if err != nil {
+			return nil, exitcode.General("reading body file", err)
+		}
+		body = string(data)
+	}
+	if strings.TrimRight(body, "\n") != strings.TrimRight(resolved.Body, "\n") {
+		changes.Body = &body
+	}
+
+	return changes, nil
+}
+
+// issueEditChangesFromEditor opens the issue in the user's editor and
+// diffs the result against the current values.
+func issueEditChangesFromEditor(resolved *resolvedEditIssue) (*issueEditChanges, error) {
+	edited, err := editFunc(formatIssueEditBuffer(resolved), "zh-issue-*.md")
+	if err != nil {
+		return nil, err
+	}
+
+	title, assignees, body, err := parseIssueEditBuffer(edited)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(title) == "" {
+		return nil, exitcode.Usage("title cannot be empty")
+	}
+
+	changes := &issueEditChanges{}
+	if title != resolved.Title {
+		changes.Title = &title
+	}
+	if strings.TrimRight(body, "\n") != strings.TrimRight(resolved.Body, "\n") {
+		changes.Body = &body
+	}
+
+	current := make(map[string]bool)
+	for _, login := range resolved.Assignees {
+		current[strings.ToLower(login)] = true
+	}
+	wanted := make(map[string]bool)
+	for _, login := range assignees {
+		key := strings.ToLower(login)
+		wanted[key] = true
+		if !current[key] {
+			changes.AssigneesAdded = append(changes.AssigneesAdded, login)
+		}
+	}
+	for _, login := range resolved.Assignees {
+		if !wanted[strings.ToLower(login)] {
+			changes.AssigneesRemoved = append(changes.AssigneesRemoved, login)
+		}
+	}
+
+	return changes, nil
+}
+
+// formatIssueEditBuffer renders an issue as front matter plus markdown body.
+func formatIssueEditBuffer(resolved *resolvedEditIssue) string {
+	var b strings.Builder
+	b.WriteString("---\n")
+	fmt.Fprintf(&b, "title: %s\n", resolved.Title)
+	fmt.Fprintf(&b, "assignees: %s\n", strings.Join(resolved.Assignees, ", "))
+	b.WriteString("---\n\n")
+	b.WriteString(resolved.Body)
+	if resolved.Body != "" && !strings.HasSuffix(resolved.Body, "\n") {
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// parseIssueEditBuffer parses an edited front-matter buffer into its title,
+// assignee logins and body.
+func parseIssueEditBuffer(buf string) (title string, assignees []string, body string, err error) {
+	buf = strings.ReplaceAll(buf, "\r\n", "\n")
+	if !strings.HasPrefix(buf, "---\n") {
+		return "", nil, "", exitcode.Usage("edited issue must start with a '---' front-matter header")
+	}
+
+	rest := buf[len("---\n"):]
+	end := strings.Index(rest, "\n---\n")
+	if end < 0 {
+		if strings.HasSuffix(rest, "\n---") {
+			end = len(rest) - len("\n---")
+		} else {
+			return "", nil, "", exitcode.Usage("edited issue is missing the closing '---' of its front-matter header")
+		}
+	}
+
+	header := rest[:end]
+	body = strings.TrimPrefix(rest[min(end+len("\n---\n"), len(rest)):], "\n")
+
+	for _, line := range strings.Split(header, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			return "", nil, "", exitcode.Usage(fmt.Sprintf("invalid front-matter line %q — expected 'key: value'", line))
+		}
+		value = strings.TrimSpace(value)
+		switch strings.TrimSpace(strings.ToLower(key)) {
+		case "title":
+			title = value
+		case "assignees":
+			for _, a := range strings.Split(value, ",") {
+				a = strings.TrimPrefix(strings.TrimSpace(a), "@")
+				if a != "" && !slices.Contains(assignees, a) {
+					assignees = append(assignees, a)
+				}
+			}
+		default:
+			return "", nil, "", exitcode.Usage(fmt.Sprintf("unknown front-matter field %q — expected title or assignees", strings.TrimSpace(key)))
+		}
+	}
+
+	return title, assignees, body, nil
+}
+
+// updateIssueViaZenhub updates an issue's title and body through ZenHub.
+func updateIssueViaZenhub(ctx context.Context, client *api.Client, resolved *resolvedEditIssue, changes *issueEditChanges) error {
+	input := map[string]any{
+		"issueId": resolved.IssueID,
+	}
+	if changes.Title != nil {
+		input["title"] = *changes.Title
+	}
+	if changes.Body != nil {
+		input["body"] = *changes.Body
+	}
+
+	_, err := client.Execute(ctx, updateZenhubIssueMutation, map[string]any{
+		"input": input,
+	})
+	if err != nil {
+		return exitcode.General("updating issue", err)
+	}
+	return nil
+}
+
+// updateIssueViaGitHub updates an issue's title and body through the
+// GitHub API.
+func updateIssueViaGitHub(ctx context.Context, ghClient *gh.Client, resolved *resolvedEditIssue, changes *issueEditChanges) error {
+	if resolved.IsPR {
+		return exitcode.Usage(fmt.Sprintf("%s is a pull request — edit it on GitHub", resolved.Ref()))
+	}
+
+	ghNodeID, err := requireGitHubIssueID(ctx, ghClient, resolved.RepoOwner, resolved.RepoName, resolved.Number)
+	if err != nil {
+		return err
+	}
+
+	input := map[string]any{
+		"id": ghNodeID,
+	}
+	if changes.Title != nil {
+		input["title"] = *changes.Title
+	}
+	if changes.Body != nil {
+		input["body"] = *changes.Body
+	}
+
+	_, err = ghClient.Execute(ctx, legacyEpicUpdateIssueMutation, map[string]any{
+		"input": input,
+	})
+	if err != nil {
+		return exitcode.General("updating issue via GitHub", err)
+	}
+	return nil
+}
+
+func issueEditDetails(changes *issueEditChanges) []output.DetailLine {
+	var details []output.DetailLine
+	if changes.Title != nil {
+		details = append(details, output.DetailLine{Key: "Title", Value: *changes.Title})
+	}
+	if changes.Body != nil {
+		details = append(details, output.DetailLine{Key: "Body", Value: truncateBody(*changes.Body)})
+	}
+	if len(changes.AssigneesAdded) > 0 || len(changes.AssigneesRemoved) > 0 {
+		details = append(details, output.DetailLine{Key: "Assignees", Value: formatAssigneeDiff(changes)})
+	}
+	return details
+}
+
+// formatAssigneeDiff renders assignee changes as "+added, -removed".
+func formatAssigneeDiff(changes *issueEditChanges) string {
+	var parts []string
+	for _, a := range changes.AssigneesAdded {
+		parts = append(parts, "+"+a)
+	}
+	for _, r := range changes.AssigneesRemoved {
+		parts = append(parts, "-"+r)
+	}
+	return strings.Join(parts, ", ")
+}
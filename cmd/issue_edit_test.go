@@ -0,0 +1,319 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/dslh/zh/internal/testutil"
+)
+
+// --- issue edit ---
+
+func TestIssueEditTitle(t *testing.T) {
+	resetIssueFlags()
+	resetIssueEditFlags()
+
+	ms := setupIssueEditServer(t)
+	var updateInput map[string]any
+	ms.Handle(
+		func(req testutil.GraphQLRequest) bool { return strings.Contains(req.Query, "UpdateZenhubIssue") },
+		func(w http.ResponseWriter, req testutil.GraphQLRequest) {
+			updateInput = mutationInputVar(req)
+			data, _ := json.Marshal(updateZenhubIssueResponse())
+			w.Write(data)
+		},
+	)
+	setupIssueTestEnv(t, ms)
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"issue", "edit", "task-tracker#1", "--title=Fix login button"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("issue edit returned error: %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "Updated issue task-tracker#1") {
+		t.Errorf("output should confirm update, got: %s", out)
+	}
+	if !strings.Contains(out, "Fix login button") {
+		t.Errorf("output should show new title, got: %s", out)
+	}
+	if updateInput["title"] != "Fix login button" {
+		t.Errorf("title = %v, want new title", updateInput["title"])
+	}
+	if _, ok := updateInput["body"]; ok {
+		t.Error("body should not be sent when unchanged")
+	}
+}
+
+func TestIssueEditClearBody(t *testing.T) {
+	resetIssueFlags()
+	resetIssueEditFlags()
+
+	ms := setupIssueEditServer(t)
+	var updateInput map[string]any
+	ms.Handle(
+		func(req testutil.GraphQLRequest) bool { return strings.Contains(req.Query, "UpdateZenhubIssue") },
+		func(w http.ResponseWriter, req testutil.GraphQLRequest) {
+			updateInput = mutationInputVar(req)
+			data, _ := json.Marshal(updateZenhubIssueResponse())
+			w.Write(data)
+		},
+	)
+	setupIssueTestEnv(t, ms)
+
+	origEdit := editFunc
+	editFunc = func(content, pattern string) (string, error) {
+		t.Error("an explicit --body should not open the editor")
+		return content, nil
+	}
+	t.Cleanup(func() { editFunc = origEdit })
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"issue", "edit", "task-tracker#1", "--body="})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("issue edit returned error: %v", err)
+	}
+
+	if body, ok := updateInput["body"]; !ok || body != "" {
+		t.Errorf("body = %v (sent: %v), want it cleared", body, ok)
+	}
+	if _, ok := updateInput["title"]; ok {
+		t.Error("title should not be sent when not given")
+	}
+}
+
+func TestIssueEditEditor(t *testing.T) {
+	resetIssueFlags()
+	resetIssueEditFlags()
+
+	ms := setupIssueEditServer(t)
+	var updateInput, assigneeInput map[string]any
+	ms.Handle(
+		func(req testutil.GraphQLRequest) bool { return strings.Contains(req.Query, "UpdateZenhubIssue") },
+		func(w http.ResponseWriter, req testutil.GraphQLRequest) {
+			updateInput = mutationInputVar(req)
+			data, _ := json.Marshal(updateZenhubIssueResponse())
+			w.Write(data)
+		},
+	)
+	ms.Handle(
+		func(req testutil.GraphQLRequest) bool { return strings.Contains(req.Query, "AddAssigneesToIssues") },
+		func(w http.ResponseWriter, req testutil.GraphQLRequest) {
+			assigneeInput = mutationInputVar(req)
+			data, _ := json.Marshal(assigneeMutationResponse("addAssigneesToIssues", 1))
+			w.Write(data)
+		},
+	)
+	ms.HandleQuery("ListZenhubUsers", zenhubUsersResponse())
+	setupIssueTestEnv(t, ms)
+
+	var seen string
+	origEdit := editFunc
+	editFunc = func(content, pattern string) (string, error) {
+		seen = content
+		return strings.Replace(content, "assignees: \n", "assignees: janedoe\n", 1) + "Extra detail.\n", nil
+	}
+	t.Cleanup(func() { editFunc = origEdit })
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"issue", "edit", "task-tracker#1"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("issue edit returned error: %v", err)
+	}
+
+	if !strings.Contains(seen, "title: Fix login button alignment\n") {
+		t.Errorf("editor buffer should hold current title, got: %s", seen)
+	}
+	if _, ok := updateInput["title"]; ok {
+		t.Error("title should not be sent when unchanged")
+	}
+	if body, _ := updateInput["body"].(string); !strings.Contains(body, "Extra detail.") {
+		t.Errorf("body = %q, want edited body", body)
+	}
+	ids, _ := assigneeInput["assigneeIds"].([]any)
+	if len(ids) != 1 || ids[0] != "gh-user-2" {
+		t.Errorf("assigneeIds = %v, want [gh-user-2]", assigneeInput["assigneeIds"])
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "+janedoe") {
+		t.Errorf("output should show assignee change, got: %s", out)
+	}
+}
+
+func TestIssueEditNoChanges(t *testing.T) {
+	resetIssueFlags()
+	resetIssueEditFlags()
+
+	ms := setupIssueEditServer(t)
+	setupIssueTestEnv(t, ms)
+
+	origEdit := editFunc
+	editFunc = func(content, pattern string) (string, error) { return content, nil }
+	t.Cleanup(func() { editFunc = origEdit })
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"issue", "edit", "task-tracker#1"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("issue edit returned error: %v", err)
+	}
+
+	if !strings.Contains(buf.String(), "No changes to task-tracker#1") {
+		t.Errorf("output should report no changes, got: %s", buf.String())
+	}
+}
+
+func TestIssueEditDryRun(t *testing.T) {
+	resetIssueFlags()
+	resetIssueEditFlags()
+
+	ms := setupIssueEditServer(t)
+	ms.Handle(
+		func(req testutil.GraphQLRequest) bool { return strings.Contains(req.Query, "UpdateZenhubIssue") },
+		func(w http.ResponseWriter, req testutil.GraphQLRequest) {
+			t.Error("dry run should not update the issue")
+		},
+	)
+	setupIssueTestEnv(t, ms)
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"issue", "edit", "task-tracker#1", "--title=Fix login button", "--body=New body", "--dry-run"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("issue edit --dry-run returned error: %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "Would update issue task-tracker#1.") {
+		t.Errorf("dry run should describe update, got: %s", out)
+	}
+	if !strings.Contains(out, "New body") {
+		t.Errorf("dry run should show body, got: %s", out)
+	}
+}
+
+func TestIssueEditViaGitHub(t *testing.T) {
+	resetIssueFlags()
+	resetIssueEditFlags()
+
+	ms := setupIssueEditServer(t)
+	ghMs := testutil.NewMockServer(t)
+	ghMs.HandleQuery("GetGitHubIssue", ghIssueNodeResponse())
+	ghMs.HandleQuery("UpdateIssue", ghUpdateIssueResponse("Fix login button", "OPEN"))
+	setupIssueTestEnvWithGitHub(t, ms, ghMs)
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"issue", "edit", "task-tracker#1", "--title=Fix login button"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("issue edit via GitHub returned error: %v", err)
+	}
+
+	if !strings.Contains(buf.String(), "Updated issue task-tracker#1") {
+		t.Errorf("output should confirm update, got: %s", buf.String())
+	}
+}
+
+func TestIssueEditBodyConflict(t *testing.T) {
+	resetIssueFlags()
+	resetIssueEditFlags()
+
+	ms := testutil.NewMockServer(t)
+	setupIssueTestEnv(t, ms)
+
+	rootCmd.SetOut(new(bytes.Buffer))
+	rootCmd.SetErr(new(bytes.Buffer))
+	rootCmd.SetArgs([]string{"issue", "edit", "task-tracker#1", "--body=x", "--body-file=y.md"})
+
+	err := rootCmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "cannot be used together") {
+		t.Errorf("expected --body/--body-file conflict error, got: %v", err)
+	}
+}
+
+func TestParseIssueEditBuffer(t *testing.T) {
+	buf := "---\ntitle: New title\nassignees: @johndoe, janedoe\n---\n\nBody line one\n\n---\n\nStill body\n"
+
+	title, assignees, body, err := parseIssueEditBuffer(buf)
+	if err != nil {
+		t.Fatalf("parse returned error: %v", err)
+	}
+	if title != "New title" {
+		t.Errorf("title = %q", title)
+	}
+	if len(assignees) != 2 || assignees[0] != "johndoe" || assignees[1] != "janedoe" {
+		t.Errorf("assignees = %v", assignees)
+	}
+	if body != "Body line one\n\n---\n\nStill body\n" {
+		t.Errorf("body = %q", body)
+	}
+
+	if _, _, _, err := parseIssueEditBuffer("title: x\n"); err == nil {
+		t.Error("expected error for missing front matter")
+	}
+	if _, _, _, err := parseIssueEditBuffer("---\nmilestone: 3\n---\n"); err == nil {
+		t.Error("expected error for unknown field")
+	}
+}
+
+// --- helpers ---
+
+func setupIssueEditServer(t *testing.T) *testutil.MockServer {
+	t.Helper()
+	ms := testutil.NewMockServer(t)
+	ms.HandleQuery("ListRepos", repoResolutionResponse())
+	ms.HandleQuery("IssueByInfo", issueByInfoResolutionResponse())
+	ms.HandleQuery("GetIssueForEdit", issueEditResolveResponse())
+	return ms
+}
+
+func issueEditResolveResponse() map[string]any {
+	return map[string]any{
+		"data": map[string]any{
+			"node": map[string]any{
+				"id":          "i1",
+				"number":      1,
+				"title":       "Fix login button alignment",
+				"body":        "The login button is misaligned on mobile.",
+				"pullRequest": false,
+				"repository": map[string]any{
+					"name":      "task-tracker",
+					"ownerName": "dlakehammond",
+					"ghId":      12345,
+				},
+				"assignees": map[string]any{
+					"nodes": []any{},
+				},
+			},
+		},
+	}
+}
+
+func updateZenhubIssueResponse() map[string]any {
+	return map[string]any{
+		"data": map[string]any{
+			"updateIssue": map[string]any{
+				"issue": map[string]any{
+					"id":     "i1",
+					"number": 1,
+					"title":  "Fix login button",
+					"body":   "",
+				},
+			},
+		},
+	}
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strconv"
@@ -8,6 +9,7 @@ import (
 	"github.com/dslh/zh/internal/api"
 	"github.com/dslh/zh/internal/exitcode"
 	"github.com/dslh/zh/internal/gh"
+	"github.com/dslh/zh/internal/history"
 	"github.com/dslh/zh/internal/output"
 	"github.com/dslh/zh/internal/resolve"
 	"github.com/spf13/cobra"
@@ -122,6 +124,8 @@ func resetIssueEstimateFlags() {
 }
 
 func runIssueEstimate(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+
 	cfg, err := requireWorkspace()
 	if err != nil {
 		return err
@@ -142,7 +146,7 @@ func runIssueEstimate(cmd *cobra.Command, args []string) error {
 
 	// Resolve the issue and fetch current estimate + valid values
 	ghClient := newGitHubClient(cfg, cmd)
-	resolved, err := resolveForEstimate(client, cfg.Workspace, args[0], ghClient)
+	resolved, err := resolveForEstimate(ctx, client, cfg.Workspace, args[0], ghClient)
 	if err != nil {
 		return err
 	}
@@ -164,19 +168,21 @@ func runIssueEstimate(cmd *cobra.Command, args []string) error {
 	}
 
 	// Execute mutation
-	input := map[string]any{
-		"issueId": resolved.IssueID,
-	}
-	if newValue != nil {
-		input["value"] = *newValue
-	} else {
-		input["value"] = nil
+	data, err := executeSetEstimate(ctx, client, resolved, newValue)
+	if err != nil {
+		return err
 	}
 
-	data, err := client.Execute(setEstimateMutation, map[string]any{"input": input})
-	if err != nil {
-		return exitcode.General(fmt.Sprintf("setting estimate on %s", resolved.Ref()), err)
+	summary := fmt.Sprintf("Cleared estimate from %s", resolved.Ref())
+	if newValue != nil {
+		summary = fmt.Sprintf("Set estimate on %s to %s", resolved.Ref(), formatEstimate(*newValue))
 	}
+	recordHistory(cmd.ErrOrStderr(), cfg.Workspace, "issue estimate", summary, []history.Change{{
+		Kind:   history.KindEstimate,
+		Issue:  history.Issue{ID: resolved.IssueID, Number: resolved.Number, RepoName: resolved.RepoName, RepoOwner: resolved.RepoOwner},
+		Before: estimateHistoryValue(resolved.CurrentEstimate),
+		After:  estimateHistoryValue(newValue),
+	}})
 
 	// Parse response for JSON output
 	var resp struct {
@@ -232,17 +238,66 @@ func runIssueEstimate(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// executeSetEstimate sets the estimate on an issue, or clears it if value is nil.
+func executeSetEstimate(ctx context.Context, client *api.Client, issue *resolvedEstimateIssue, value *float64) (json.RawMessage, error) {
+	input := map[string]any{
+		"issueId": issue.IssueID,
+	}
+	if value != nil {
+		input["value"] = *value
+	} else {
+		input["value"] = nil
+	}
+
+	data, err := client.Execute(ctx, setEstimateMutation, map[string]any{"input": input})
+	if err != nil {
+		return nil, exitcode.General(fmt.Sprintf("setting estimate on %s", issue.Ref()), err)
+	}
+	return data, nil
+}
+
+// setEstimateByNode sets the estimate on the issue with the given ZenHub ID,
+// or clears it if value is blank. The value is checked against the
+// repository's estimate set. Returns the issue as it was before the change
+// and the new estimate.
+func setEstimateByNode(ctx context.Context, client *api.Client, issueID, value string) (*resolvedEstimateIssue, *float64, error) {
+	var newValue *float64
+	if value != "" {
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, nil, exitcode.Usage(fmt.Sprintf("invalid estimate value %q — must be a number", value))
+		}
+		newValue = &v
+	}
+
+	resolved, err := resolveEstimateByNode(ctx, client, issueID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if newValue != nil && len(resolved.ValidEstimates) > 0 && !isValidEstimate(*newValue, resolved.ValidEstimates) {
+		return nil, nil, exitcode.Usage(fmt.Sprintf(
+			"invalid estimate value %s — valid values are: %s",
+			formatEstimate(*newValue), formatEstimateList(resolved.ValidEstimates),
+		))
+	}
+
+	if _, err := executeSetEstimate(ctx, client, resolved, newValue); err != nil {
+		return nil, nil, err
+	}
+	return resolved, newValue, nil
+}
+
 // resolveForEstimate resolves an issue identifier and fetches current estimate + valid values.
-func resolveForEstimate(client *api.Client, workspaceID, identifier string, ghClient *gh.Client) (*resolvedEstimateIssue, error) {
+func resolveForEstimate(ctx context.Context, client *api.Client, workspaceID, identifier string, ghClient *gh.Client) (*resolvedEstimateIssue, error) {
 	parsed, parseErr := resolve.ParseIssueRef(identifier)
 
 	// If it's a ZenHub ID, use the node query directly
 	if parseErr == nil && parsed.ZenHubID != "" {
-		return resolveEstimateByNode(client, parsed.ZenHubID)
+		return resolveEstimateByNode(ctx, client, parsed.ZenHubID)
 	}
 
 	// Resolve to get repo GH ID and issue number
-	result, err := resolve.Issue(client, workspaceID, identifier, &resolve.IssueOptions{
+	result, err := resolve.Issue(ctx, client, workspaceID, identifier, &resolve.IssueOptions{
 		RepoFlag:     issueEstimateRepo,
 		GitHubClient: ghClient,
 	})
@@ -250,11 +305,11 @@ func resolveForEstimate(client *api.Client, workspaceID, identifier string, ghCl
 		return nil, err
 	}
 
-	return resolveEstimateByInfo(client, result.RepoGhID, result.Number)
+	return resolveEstimateByInfo(ctx, client, result.RepoGhID, result.Number)
 }
 
-func resolveEstimateByInfo(client *api.Client, repoGhID, issueNumber int) (*resolvedEstimateIssue, error) {
-	data, err := client.Execute(issueEstimateQuery, map[string]any{
+func resolveEstimateByInfo(ctx context.Context, client *api.Client, repoGhID, issueNumber int) (*resolvedEstimateIssue, error) {
+	data, err := client.Execute(ctx, issueEstimateQuery, map[string]any{
 		"repositoryGhId": repoGhID,
 		"issueNumber":    issueNumber,
 	})
@@ -302,8 +357,8 @@ func resolveEstimateByInfo(client *api.Client, repoGhID, issueNumber int) (*reso
 		estVal, validEst), nil
 }
 
-func resolveEstimateByNode(client *api.Client, nodeID string) (*resolvedEstimateIssue, error) {
-	data, err := client.Execute(issueEstimateByNodeQuery, map[string]any{
+func resolveEstimateByNode(ctx context.Context, client *api.Client, nodeID string) (*resolvedEstimateIssue, error) {
+	data, err := client.Execute(ctx, issueEstimateByNodeQuery, map[string]any{
 		"id": nodeID,
 	})
 	if err != nil {
@@ -1,13 +1,16 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/dslh/zh/internal/api"
 	"github.com/dslh/zh/internal/exitcode"
 	"github.com/dslh/zh/internal/gh"
+	"github.com/dslh/zh/internal/history"
 	"github.com/dslh/zh/internal/output"
 	"github.com/dslh/zh/internal/resolve"
 	"github.com/spf13/cobra"
@@ -56,6 +59,7 @@ type resolvedLabelIssue struct {
 	Title     string
 	RepoName  string
 	RepoOwner string
+	LabelIDs  []string // labels on the issue before the change
 }
 
 func (r *resolvedLabelIssue) Ref() string {
@@ -120,20 +124,24 @@ var (
 	issueLabelAddDryRun          bool
 	issueLabelAddRepo            string
 	issueLabelAddContinueOnError bool
+	issueLabelAddPlanOut         string
 
 	issueLabelRemoveDryRun          bool
 	issueLabelRemoveRepo            string
 	issueLabelRemoveContinueOnError bool
+	issueLabelRemovePlanOut         string
 )
 
 func init() {
 	issueLabelAddCmd.Flags().BoolVar(&issueLabelAddDryRun, "dry-run", false, "Show what would be changed without executing")
 	issueLabelAddCmd.Flags().StringVar(&issueLabelAddRepo, "repo", "", "Repository context for bare issue numbers")
 	issueLabelAddCmd.Flags().BoolVar(&issueLabelAddContinueOnError, "continue-on-error", false, "Continue processing remaining issues after a resolution error")
+	issueLabelAddCmd.Flags().StringVar(&issueLabelAddPlanOut, "plan-out", "", "Write the resolved change to a plan file for zh apply instead of executing")
 
 	issueLabelRemoveCmd.Flags().BoolVar(&issueLabelRemoveDryRun, "dry-run", false, "Show what would be changed without executing")
 	issueLabelRemoveCmd.Flags().StringVar(&issueLabelRemoveRepo, "repo", "", "Repository context for bare issue numbers")
 	issueLabelRemoveCmd.Flags().BoolVar(&issueLabelRemoveContinueOnError, "continue-on-error", false, "Continue processing remaining issues after a resolution error")
+	issueLabelRemoveCmd.Flags().StringVar(&issueLabelRemovePlanOut, "plan-out", "", "Write the resolved change to a plan file for zh apply instead of executing")
 
 	issueLabelCmd.AddCommand(issueLabelAddCmd)
 	issueLabelCmd.AddCommand(issueLabelRemoveCmd)
@@ -144,9 +152,11 @@ func resetIssueLabelFlags() {
 	issueLabelAddDryRun = false
 	issueLabelAddRepo = ""
 	issueLabelAddContinueOnError = false
+	issueLabelAddPlanOut = ""
 	issueLabelRemoveDryRun = false
 	issueLabelRemoveRepo = ""
 	issueLabelRemoveContinueOnError = false
+	issueLabelRemovePlanOut = ""
 }
 
 // splitIssuesAndLabels separates issue identifiers from label names
@@ -178,7 +188,7 @@ func runIssueLabelAdd(cmd *cobra.Command, args []string) error {
 	}
 
 	return runIssueLabelOp(cmd, issueArgs, labelArgs, "add",
-		issueLabelAddRepo, issueLabelAddDryRun, issueLabelAddContinueOnError)
+		issueLabelAddRepo, issueLabelAddDryRun, issueLabelAddContinueOnError, issueLabelAddPlanOut)
 }
 
 func runIssueLabelRemove(cmd *cobra.Command, args []string) error {
@@ -188,10 +198,12 @@ func runIssueLabelRemove(cmd *cobra.Command, args []string) error {
 	}
 
 	return runIssueLabelOp(cmd, issueArgs, labelArgs, "remove",
-		issueLabelRemoveRepo, issueLabelRemoveDryRun, issueLabelRemoveContinueOnError)
+		issueLabelRemoveRepo, issueLabelRemoveDryRun, issueLabelRemoveContinueOnError, issueLabelRemovePlanOut)
 }
 
-func runIssueLabelOp(cmd *cobra.Command, issueArgs, labelNames []string, op, repoFlag string, dryRun, continueOnError bool) error {
+func runIssueLabelOp(cmd *cobra.Command, issueArgs, labelNames []string, op, repoFlag string, dryRun, continueOnError bool, planOut string) error {
+	ctx := commandContext(cmd)
+
 	cfg, err := requireWorkspace()
 	if err != nil {
 		return err
@@ -206,7 +218,7 @@ func runIssueLabelOp(cmd *cobra.Command, issueArgs, labelNames []string, op, rep
 	var resolveFailed []output.FailedItem
 
 	for _, arg := range issueArgs {
-		issue, err := resolveForLabel(client, cfg.Workspace, arg, repoFlag, ghClient)
+		issue, err := resolveForLabel(ctx, client, cfg.Workspace, arg, repoFlag, ghClient)
 		if err != nil {
 			if continueOnError {
 				resolveFailed = append(resolveFailed, output.FailedItem{
@@ -225,7 +237,7 @@ func runIssueLabelOp(cmd *cobra.Command, issueArgs, labelNames []string, op, rep
 	}
 
 	// Resolve label names to IDs
-	resolvedLabels, err := resolve.Labels(client, cfg.Workspace, labelNames)
+	resolvedLabels, err := resolve.Labels(ctx, client, cfg.Workspace, labelNames)
 	if err != nil {
 		return err
 	}
@@ -238,8 +250,27 @@ func runIssueLabelOp(cmd *cobra.Command, issueArgs, labelNames []string, op, rep
 	labelDisplay := strings.Join(resolvedLabelNames, ", ")
 
 	// Dry run
-	if dryRun {
-		return renderLabelDryRun(w, resolved, resolveFailed, resolvedLabelNames, op)
+	if dryRun || planOut != "" {
+		if err := renderLabelDryRun(w, resolved, resolveFailed, resolvedLabelNames, op); err != nil {
+			return err
+		}
+
+		plan := &mutationPlan{
+			Command: "issue label " + op,
+			Summary: fmt.Sprintf("add label(s) %s to %d issue(s)", labelDisplay, len(resolved)),
+			Labels:  make([]planEntity, len(resolvedLabels)),
+		}
+		if op == "remove" {
+			plan.Summary = fmt.Sprintf("remove label(s) %s from %d issue(s)", labelDisplay, len(resolved))
+		}
+		for i, l := range resolvedLabels {
+			plan.Labels[i] = planEntity{ID: l.ID, Name: l.Name}
+		}
+		issueIDs := make([]string, len(resolved))
+		for i, r := range resolved {
+			issueIDs[i] = r.IssueID
+		}
+		return savePlan(ctx, client, cfg.Workspace, w, planOut, plan, issueIDs)
 	}
 
 	// Build issue IDs
@@ -265,7 +296,7 @@ func runIssueLabelOp(cmd *cobra.Command, issueArgs, labelNames []string, op, rep
 		mutationKey = "removeLabelsFromIssues"
 	}
 
-	data, err := client.Execute(mutation, map[string]any{
+	data, err := client.Execute(ctx, mutation, map[string]any{
 		"input": map[string]any{
 			"issueIds": issueIDs,
 			"labelIds": labelIDs,
@@ -313,6 +344,7 @@ func runIssueLabelOp(cmd *cobra.Command, issueArgs, labelNames []string, op, rep
 	}
 
 	var succeeded []output.MutationItem
+	var changes []history.Change
 	for _, r := range resolved {
 		if failedIDs[r.IssueID] {
 			continue
@@ -321,7 +353,32 @@ func runIssueLabelOp(cmd *cobra.Command, issueArgs, labelNames []string, op, rep
 			Ref:   r.Ref(),
 			Title: truncateTitle(r.Title),
 		})
+
+		// Only labels that actually changed are recorded, so that undo
+		// doesn't remove a label the issue already had.
+		for _, l := range resolvedLabels {
+			if slices.Contains(r.LabelIDs, l.ID) == (op == "add") {
+				continue
+			}
+			change := history.Change{
+				Kind:  history.KindLabel,
+				Issue: history.Issue{ID: r.IssueID, Number: r.Number, RepoName: r.RepoName, RepoOwner: r.RepoOwner},
+			}
+			if op == "add" {
+				change.After = &history.Value{ID: l.ID, Name: l.Name}
+			} else {
+				change.Before = &history.Value{ID: l.ID, Name: l.Name}
+			}
+			changes = append(changes, change)
+		}
+	}
+
+	verb, preposition := "Added", "to"
+	if op == "remove" {
+		verb, preposition = "Removed", "from"
 	}
+	recordHistory(cmd.ErrOrStderr(), cfg.Workspace, "issue label "+op,
+		fmt.Sprintf("%s label(s) %s %s %d issue(s)", verb, labelDisplay, preposition, len(succeeded)), changes)
 
 	allFailed := append(resolveFailed, mutationFailed...)
 
@@ -337,13 +394,6 @@ func runIssueLabelOp(cmd *cobra.Command, issueArgs, labelNames []string, op, rep
 	}
 
 	// Render output
-	verb := "Added"
-	preposition := "to"
-	if op == "remove" {
-		verb = "Removed"
-		preposition = "from"
-	}
-
 	totalAttempted := len(succeeded) + len(allFailed)
 	if len(allFailed) > 0 {
 		header := output.Green(fmt.Sprintf("%s label(s) %s %s %d of %d issue(s).", verb, labelDisplay, preposition, len(succeeded), totalAttempted))
@@ -364,9 +414,41 @@ func runIssueLabelOp(cmd *cobra.Command, issueArgs, labelNames []string, op, rep
 	return nil
 }
 
+// executeIssueLabelChange adds a label to a single issue, or removes it if
+// add is false.
+func executeIssueLabelChange(ctx context.Context, client *api.Client, issueID, ref, labelID string, add bool) error {
+	mutation, key := addLabelsToIssuesMutation, "addLabelsToIssues"
+	if !add {
+		mutation, key = removeLabelsFromIssuesMutation, "removeLabelsFromIssues"
+	}
+
+	data, err := client.Execute(ctx, mutation, map[string]any{
+		"input": map[string]any{
+			"issueIds": []string{issueID},
+			"labelIds": []string{labelID},
+		},
+	})
+	if err != nil {
+		return exitcode.General(fmt.Sprintf("updating labels on %s", ref), err)
+	}
+
+	var resp map[string]struct {
+		FailedIssues []struct {
+			ID string `json:"id"`
+		} `json:"failedIssues"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return exitcode.General("parsing label response", err)
+	}
+	if len(resp[key].FailedIssues) > 0 {
+		return exitcode.Generalf("failed to update labels on %s", ref)
+	}
+	return nil
+}
+
 // resolveForLabel resolves an issue identifier and fetches basic info.
-func resolveForLabel(client *api.Client, workspaceID, identifier, repoFlag string, ghClient *gh.Client) (*resolvedLabelIssue, error) {
-	result, err := resolve.Issue(client, workspaceID, identifier, &resolve.IssueOptions{
+func resolveForLabel(ctx context.Context, client *api.Client, workspaceID, identifier, repoFlag string, ghClient *gh.Client) (*resolvedLabelIssue, error) {
+	result, err := resolve.Issue(ctx, client, workspaceID, identifier, &resolve.IssueOptions{
 		RepoFlag:     repoFlag,
 		GitHubClient: ghClient,
 	})
@@ -375,7 +457,7 @@ func resolveForLabel(client *api.Client, workspaceID, identifier, repoFlag strin
 	}
 
 	// Fetch issue details for display
-	data, err := client.Execute(issueLabelResolveQuery, map[string]any{
+	data, err := client.Execute(ctx, issueLabelResolveQuery, map[string]any{
 		"issueId": result.ID,
 	})
 	if err != nil {
@@ -391,6 +473,11 @@ func resolveForLabel(client *api.Client, workspaceID, identifier, repoFlag strin
 				Name      string `json:"name"`
 				OwnerName string `json:"ownerName"`
 			} `json:"repository"`
+			Labels struct {
+				Nodes []struct {
+					ID string `json:"id"`
+				} `json:"nodes"`
+			} `json:"labels"`
 		} `json:"node"`
 	}
 	if err := json.Unmarshal(data, &resp); err != nil {
@@ -401,12 +488,18 @@ func resolveForLabel(client *api.Client, workspaceID, identifier, repoFlag strin
 		return nil, exitcode.NotFoundError(fmt.Sprintf("issue %q not found", identifier))
 	}
 
+	labelIDs := make([]string, len(resp.Node.Labels.Nodes))
+	for i, l := range resp.Node.Labels.Nodes {
+		labelIDs[i] = l.ID
+	}
+
 	return &resolvedLabelIssue{
 		IssueID:   resp.Node.ID,
 		Number:    resp.Node.Number,
 		Title:     resp.Node.Title,
 		RepoName:  resp.Node.Repository.Name,
 		RepoOwner: resp.Node.Repository.OwnerName,
+		LabelIDs:  labelIDs,
 	}, nil
 }
 
@@ -420,6 +513,11 @@ const issueLabelResolveQuery = `query GetIssueForLabel($issueId: ID!) {
         name
         ownerName
       }
+      labels(first: 50) {
+        nodes {
+          id
+        }
+      }
     }
   }
 }`
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strconv"
@@ -9,6 +10,7 @@ import (
 	"github.com/dslh/zh/internal/api"
 	"github.com/dslh/zh/internal/exitcode"
 	"github.com/dslh/zh/internal/gh"
+	"github.com/dslh/zh/internal/history"
 	"github.com/dslh/zh/internal/output"
 	"github.com/dslh/zh/internal/resolve"
 	"github.com/spf13/cobra"
@@ -28,6 +30,7 @@ const pipelineIssueIDQuery = `query GetPipelineIssueId($issueId: ID!, $workspace
       }
       pipelineIssue(workspaceId: $workspaceId) {
         id
+        relativePosition
         pipeline {
           id
           name
@@ -66,13 +69,15 @@ const movePipelineIssuesMutation = `mutation MovePipelineIssues($input: MovePipe
 
 // resolvedMoveIssue holds the info needed to move a single issue.
 type resolvedMoveIssue struct {
-	IssueID         string
-	PipelineIssueID string
-	Number          int
-	Title           string
-	RepoName        string
-	RepoOwner       string
-	CurrentPipeline string
+	IssueID           string
+	PipelineIssueID   string
+	Number            int
+	Title             string
+	RepoName          string
+	RepoOwner         string
+	CurrentPipeline   string
+	CurrentPipelineID string
+	CurrentPosition   *int // relativePosition in the current pipeline
 }
 
 func (r *resolvedMoveIssue) Ref() string {
@@ -104,6 +109,7 @@ var (
 	issueMoveDryRun          bool
 	issueMoveRepo            string
 	issueMoveContinueOnError bool
+	issueMovePlanOut         string
 )
 
 func init() {
@@ -111,6 +117,7 @@ func init() {
 	issueMoveCmd.Flags().BoolVar(&issueMoveDryRun, "dry-run", false, "Show what would be moved without executing")
 	issueMoveCmd.Flags().StringVar(&issueMoveRepo, "repo", "", "Repository context for bare issue numbers")
 	issueMoveCmd.Flags().BoolVar(&issueMoveContinueOnError, "continue-on-error", false, "Continue processing remaining issues after an error")
+	issueMoveCmd.Flags().StringVar(&issueMovePlanOut, "plan-out", "", "Write the resolved moves to a plan file for zh apply instead of executing")
 
 	issueCmd.AddCommand(issueMoveCmd)
 }
@@ -120,9 +127,12 @@ func resetIssueMoveFlags() {
 	issueMoveDryRun = false
 	issueMoveRepo = ""
 	issueMoveContinueOnError = false
+	issueMovePlanOut = ""
 }
 
 func runIssueMove(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+
 	cfg, err := requireWorkspace()
 	if err != nil {
 		return err
@@ -136,7 +146,7 @@ func runIssueMove(cmd *cobra.Command, args []string) error {
 	issueArgs := args[:len(args)-1]
 
 	// Resolve target pipeline
-	targetPipeline, err := resolve.Pipeline(client, cfg.Workspace, pipelineName, cfg.Aliases.Pipelines)
+	targetPipeline, err := resolve.Pipeline(ctx, client, cfg.Workspace, pipelineName, cfg.Aliases.Pipelines)
 	if err != nil {
 		return err
 	}
@@ -147,7 +157,7 @@ func runIssueMove(cmd *cobra.Command, args []string) error {
 	var failed []output.FailedItem
 
 	for _, arg := range issueArgs {
-		issue, err := resolveForMove(client, cfg.Workspace, arg, ghClient)
+		issue, err := resolveForMove(ctx, client, cfg.Workspace, arg, ghClient)
 		if err != nil {
 			if issueMoveContinueOnError {
 				failed = append(failed, output.FailedItem{
@@ -177,17 +187,17 @@ func runIssueMove(cmd *cobra.Command, args []string) error {
 	}
 
 	// Dry run
-	if issueMoveDryRun {
+	if issueMoveDryRun || issueMovePlanOut != "" {
 		items := make([]output.MutationItem, len(resolved))
 		for i, r := range resolved {
-			ctx := ""
+			current := ""
 			if r.CurrentPipeline != "" {
-				ctx = fmt.Sprintf("(currently in %q)", r.CurrentPipeline)
+				current = fmt.Sprintf("(currently in %q)", r.CurrentPipeline)
 			}
 			items[i] = output.MutationItem{
 				Ref:     r.Ref(),
 				Title:   truncateTitle(r.Title),
-				Context: ctx,
+				Context: current,
 			}
 		}
 
@@ -209,13 +219,24 @@ func runIssueMove(cmd *cobra.Command, args []string) error {
 				fmt.Fprintf(w, "  %s  %s\n", f.Ref, output.Red(f.Reason))
 			}
 		}
-		return nil
+
+		issueIDs := make([]string, len(resolved))
+		for i, r := range resolved {
+			issueIDs[i] = r.IssueID
+		}
+		return savePlan(ctx, client, cfg.Workspace, w, issueMovePlanOut, &mutationPlan{
+			Command:  "issue move",
+			Summary:  strings.TrimPrefix(header, "Would "),
+			Pipeline: &planEntity{ID: targetPipeline.ID, Name: targetPipeline.Name},
+			Position: issueMovePosition,
+		}, issueIDs)
 	}
 
 	// Execute moves
 	var succeeded []output.MutationItem
+	var changes []history.Change
 	for _, r := range resolved {
-		err := executeMoveIssue(client, r, targetPipeline.ID, posType, posNum)
+		err := executeMoveIssue(ctx, client, r, targetPipeline.ID, posType, posNum)
 		if err != nil {
 			if issueMoveContinueOnError {
 				failed = append(failed, output.FailedItem{
@@ -224,14 +245,27 @@ func runIssueMove(cmd *cobra.Command, args []string) error {
 				})
 				continue
 			}
+			recordHistory(cmd.ErrOrStderr(), cfg.Workspace, "issue move",
+				fmt.Sprintf("Moved %d issue(s) to %q", len(succeeded), targetPipeline.Name), changes)
 			return err
 		}
 		succeeded = append(succeeded, output.MutationItem{
 			Ref:   r.Ref(),
 			Title: truncateTitle(r.Title),
 		})
+		if r.CurrentPipelineID != "" {
+			changes = append(changes, history.Change{
+				Kind:   history.KindMove,
+				Issue:  history.Issue{ID: r.IssueID, Number: r.Number, RepoName: r.RepoName, RepoOwner: r.RepoOwner},
+				Before: &history.Value{ID: r.CurrentPipelineID, Name: r.CurrentPipeline, Position: r.CurrentPosition},
+				After:  &history.Value{ID: targetPipeline.ID, Name: targetPipeline.Name},
+			})
+		}
 	}
 
+	recordHistory(cmd.ErrOrStderr(), cfg.Workspace, "issue move",
+		fmt.Sprintf("Moved %d issue(s) to %q", len(succeeded), targetPipeline.Name), changes)
+
 	if output.IsJSON(outputFormat) {
 		return output.JSON(w, map[string]any{
 			"moved":    succeeded,
@@ -254,9 +288,9 @@ func runIssueMove(cmd *cobra.Command, args []string) error {
 }
 
 // resolveForMove resolves an issue identifier and fetches its PipelineIssue ID.
-func resolveForMove(client *api.Client, workspaceID, identifier string, ghClient *gh.Client) (*resolvedMoveIssue, error) {
+func resolveForMove(ctx context.Context, client *api.Client, workspaceID, identifier string, ghClient *gh.Client) (*resolvedMoveIssue, error) {
 	// Resolve the issue
-	result, err := resolve.Issue(client, workspaceID, identifier, &resolve.IssueOptions{
+	result, err := resolve.Issue(ctx, client, workspaceID, identifier, &resolve.IssueOptions{
 		RepoFlag:     issueMoveRepo,
 		GitHubClient: ghClient,
 	})
@@ -265,7 +299,7 @@ func resolveForMove(client *api.Client, workspaceID, identifier string, ghClient
 	}
 
 	// Fetch the PipelineIssue ID for this issue
-	data, err := client.Execute(pipelineIssueIDQuery, map[string]any{
+	data, err := client.Execute(ctx, pipelineIssueIDQuery, map[string]any{
 		"issueId":     result.ID,
 		"workspaceId": workspaceID,
 	})
@@ -283,8 +317,9 @@ func resolveForMove(client *api.Client, workspaceID, identifier string, ghClient
 				OwnerName string `json:"ownerName"`
 			} `json:"repository"`
 			PipelineIssue *struct {
-				ID       string `json:"id"`
-				Pipeline struct {
+				ID               string `json:"id"`
+				RelativePosition *int   `json:"relativePosition"`
+				Pipeline         struct {
 					ID   string `json:"id"`
 					Name string `json:"name"`
 				} `json:"pipeline"`
@@ -310,6 +345,8 @@ func resolveForMove(client *api.Client, workspaceID, identifier string, ghClient
 	if resp.Node.PipelineIssue != nil {
 		resolved.PipelineIssueID = resp.Node.PipelineIssue.ID
 		resolved.CurrentPipeline = resp.Node.PipelineIssue.Pipeline.Name
+		resolved.CurrentPipelineID = resp.Node.PipelineIssue.Pipeline.ID
+		resolved.CurrentPosition = resp.Node.PipelineIssue.RelativePosition
 	}
 
 	return resolved, nil
@@ -345,7 +382,7 @@ func parsePosition(s string) (positionType, int, error) {
 }
 
 // executeMoveIssue performs the actual move API call for a single issue.
-func executeMoveIssue(client *api.Client, issue resolvedMoveIssue, targetPipelineID string, posType positionType, posNum int) error {
+func executeMoveIssue(ctx context.Context, client *api.Client, issue resolvedMoveIssue, targetPipelineID string, posType positionType, posNum int) error {
 	// Use moveIssue for numeric position, moveIssueRelativeTo for symbolic
 	if posType == posNumeric {
 		input := map[string]any{
@@ -354,7 +391,7 @@ func executeMoveIssue(client *api.Client, issue resolvedMoveIssue, targetPipelin
 			"position":   posNum,
 		}
 
-		_, err := client.Execute(moveIssueMutation, map[string]any{"input": input})
+		_, err := client.Execute(ctx, moveIssueMutation, map[string]any{"input": input})
 		if err != nil {
 			return exitcode.General(fmt.Sprintf("moving %s", issue.Ref()), err)
 		}
@@ -374,7 +411,7 @@ func executeMoveIssue(client *api.Client, issue resolvedMoveIssue, targetPipelin
 		input["position"] = "END"
 	}
 
-	_, err := client.Execute(movePipelineIssuesMutation, map[string]any{"input": input})
+	_, err := client.Execute(ctx, movePipelineIssuesMutation, map[string]any{"input": input})
 	if err != nil {
 		return exitcode.General(fmt.Sprintf("moving %s", issue.Ref()), err)
 	}
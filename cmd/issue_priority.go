@@ -1,12 +1,14 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 
 	"github.com/dslh/zh/internal/api"
 	"github.com/dslh/zh/internal/exitcode"
 	"github.com/dslh/zh/internal/gh"
+	"github.com/dslh/zh/internal/history"
 	"github.com/dslh/zh/internal/output"
 	"github.com/dslh/zh/internal/resolve"
 	"github.com/spf13/cobra"
@@ -80,13 +82,14 @@ const removeIssuePrioritiesMutation = `mutation RemoveIssuePriority($input: Remo
 
 // resolvedPriorityIssue holds the info needed to set/clear priority on a single issue.
 type resolvedPriorityIssue struct {
-	IssueID         string
-	Number          int
-	Title           string
-	RepoName        string
-	RepoOwner       string
-	RepoGhID        int
-	CurrentPriority string // empty if none
+	IssueID           string
+	Number            int
+	Title             string
+	RepoName          string
+	RepoOwner         string
+	RepoGhID          int
+	CurrentPriority   string // empty if none
+	CurrentPriorityID string
 }
 
 func (r *resolvedPriorityIssue) Ref() string {
@@ -121,6 +124,7 @@ var (
 	issuePriorityRepo            string
 	issuePriorityContinueOnError bool
 	issuePriorityClear           bool
+	issuePriorityPlanOut         string
 )
 
 func init() {
@@ -128,6 +132,7 @@ func init() {
 	issuePriorityCmd.Flags().StringVar(&issuePriorityRepo, "repo", "", "Repository context for bare issue numbers")
 	issuePriorityCmd.Flags().BoolVar(&issuePriorityContinueOnError, "continue-on-error", false, "Continue processing remaining issues after a resolution error")
 	issuePriorityCmd.Flags().BoolVar(&issuePriorityClear, "clear", false, "Clear priority from specified issues")
+	issuePriorityCmd.Flags().StringVar(&issuePriorityPlanOut, "plan-out", "", "Write the resolved change to a plan file for zh apply instead of executing")
 
 	issueCmd.AddCommand(issuePriorityCmd)
 }
@@ -137,9 +142,12 @@ func resetIssuePriorityFlags() {
 	issuePriorityRepo = ""
 	issuePriorityContinueOnError = false
 	issuePriorityClear = false
+	issuePriorityPlanOut = ""
 }
 
 func runIssuePriority(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+
 	cfg, err := requireWorkspace()
 	if err != nil {
 		return err
@@ -162,7 +170,7 @@ func runIssuePriority(cmd *cobra.Command, args []string) error {
 	} else {
 		// Try the last arg as a priority
 		lastArg := args[len(args)-1]
-		p, err := resolve.Priority(client, cfg.Workspace, lastArg)
+		p, err := resolve.Priority(ctx, client, cfg.Workspace, lastArg)
 		if err == nil {
 			priority = p
 			issueArgs = args[:len(args)-1]
@@ -184,7 +192,7 @@ func runIssuePriority(cmd *cobra.Command, args []string) error {
 	var resolveFailed []output.FailedItem
 
 	for _, arg := range issueArgs {
-		issue, err := resolveForPriority(client, cfg.Workspace, arg, ghClient)
+		issue, err := resolveForPriority(ctx, client, cfg.Workspace, arg, ghClient)
 		if err != nil {
 			if issuePriorityContinueOnError {
 				resolveFailed = append(resolveFailed, output.FailedItem{
@@ -203,20 +211,60 @@ func runIssuePriority(cmd *cobra.Command, args []string) error {
 	}
 
 	// Dry run
-	if issuePriorityDryRun {
-		return renderPriorityDryRun(w, resolved, resolveFailed, priority)
+	if issuePriorityDryRun || issuePriorityPlanOut != "" {
+		if err := renderPriorityDryRun(w, resolved, resolveFailed, priority); err != nil {
+			return err
+		}
+
+		plan := &mutationPlan{
+			Command: "issue priority",
+			Summary: fmt.Sprintf("clear priority from %d issue(s)", len(resolved)),
+		}
+		if priority != nil {
+			plan.Summary = fmt.Sprintf("set priority %q on %d issue(s)", priority.Name, len(resolved))
+			plan.Priority = &planEntity{ID: priority.ID, Name: priority.Name}
+		}
+		issueIDs := make([]string, len(resolved))
+		for i, r := range resolved {
+			issueIDs[i] = r.IssueID
+		}
+		return savePlan(ctx, client, cfg.Workspace, w, issuePriorityPlanOut, plan, issueIDs)
 	}
 
 	// Execute mutation
 	if priority != nil {
-		err = executeSetPriority(client, resolved, priority.ID)
+		err = executeSetPriority(ctx, client, resolved, priority.ID)
 	} else {
-		err = executeClearPriority(client, cfg.Workspace, resolved)
+		err = executeClearPriority(ctx, client, cfg.Workspace, resolved)
 	}
 	if err != nil {
 		return err
 	}
 
+	var after *history.Value
+	summary := fmt.Sprintf("Cleared priority from %d issue(s)", len(resolved))
+	if priority != nil {
+		after = &history.Value{ID: priority.ID, Name: priority.Name}
+		summary = fmt.Sprintf("Set priority %q on %d issue(s)", priority.Name, len(resolved))
+	}
+	var changes []history.Change
+	for _, r := range resolved {
+		var before *history.Value
+		if r.CurrentPriorityID != "" {
+			before = &history.Value{ID: r.CurrentPriorityID, Name: r.CurrentPriority}
+		}
+		if (before == nil) == (after == nil) && (before == nil || before.ID == after.ID) {
+			continue
+		}
+		changes = append(changes, history.Change{
+			Kind:   history.KindPriority,
+			Issue:  history.Issue{ID: r.IssueID, Number: r.Number, RepoName: r.RepoName, RepoOwner: r.RepoOwner, RepoGhID: r.RepoGhID},
+			Before: before,
+			After:  after,
+		})
+	}
+	recordHistory(cmd.ErrOrStderr(), cfg.Workspace, "issue priority", summary, changes)
+
 	// JSON output
 	if output.IsJSON(outputFormat) {
 		jsonResp := map[string]any{
@@ -278,8 +326,8 @@ func runIssuePriority(cmd *cobra.Command, args []string) error {
 }
 
 // resolveForPriority resolves an issue identifier and fetches its current priority.
-func resolveForPriority(client *api.Client, workspaceID, identifier string, ghClient *gh.Client) (*resolvedPriorityIssue, error) {
-	result, err := resolve.Issue(client, workspaceID, identifier, &resolve.IssueOptions{
+func resolveForPriority(ctx context.Context, client *api.Client, workspaceID, identifier string, ghClient *gh.Client) (*resolvedPriorityIssue, error) {
+	result, err := resolve.Issue(ctx, client, workspaceID, identifier, &resolve.IssueOptions{
 		RepoFlag:     issuePriorityRepo,
 		GitHubClient: ghClient,
 	})
@@ -287,9 +335,13 @@ func resolveForPriority(client *api.Client, workspaceID, identifier string, ghCl
 		return nil, err
 	}
 
-	// Fetch current priority
-	data, err := client.Execute(issuePriorityResolveQuery, map[string]any{
-		"issueId":     result.ID,
+	return resolvePriorityByNode(ctx, client, workspaceID, result.ID)
+}
+
+// resolvePriorityByNode fetches an issue's current priority by ZenHub ID.
+func resolvePriorityByNode(ctx context.Context, client *api.Client, workspaceID, issueID string) (*resolvedPriorityIssue, error) {
+	data, err := client.Execute(ctx, issuePriorityResolveQuery, map[string]any{
+		"issueId":     issueID,
 		"workspaceId": workspaceID,
 	})
 	if err != nil {
@@ -319,7 +371,7 @@ func resolveForPriority(client *api.Client, workspaceID, identifier string, ghCl
 	}
 
 	if resp.Node == nil {
-		return nil, exitcode.NotFoundError(fmt.Sprintf("issue %q not found", identifier))
+		return nil, exitcode.NotFoundError(fmt.Sprintf("issue %q not found", issueID))
 	}
 
 	resolved := &resolvedPriorityIssue{
@@ -333,12 +385,13 @@ func resolveForPriority(client *api.Client, workspaceID, identifier string, ghCl
 
 	if resp.Node.PipelineIssue != nil && resp.Node.PipelineIssue.Priority != nil {
 		resolved.CurrentPriority = resp.Node.PipelineIssue.Priority.Name
+		resolved.CurrentPriorityID = resp.Node.PipelineIssue.Priority.ID
 	}
 
 	return resolved, nil
 }
 
-func executeSetPriority(client *api.Client, issues []resolvedPriorityIssue, priorityID string) error {
+func executeSetPriority(ctx context.Context, client *api.Client, issues []resolvedPriorityIssue, priorityID string) error {
 	issueInfos := make([]map[string]any, len(issues))
 	for i, iss := range issues {
 		issueInfos[i] = map[string]any{
@@ -347,7 +400,7 @@ func executeSetPriority(client *api.Client, issues []resolvedPriorityIssue, prio
 		}
 	}
 
-	_, err := client.Execute(setIssuePrioritiesMutation, map[string]any{
+	_, err := client.Execute(ctx, setIssuePrioritiesMutation, map[string]any{
 		"input": map[string]any{
 			"priorityId": priorityID,
 			"issues":     issueInfos,
@@ -360,7 +413,7 @@ func executeSetPriority(client *api.Client, issues []resolvedPriorityIssue, prio
 	return nil
 }
 
-func executeClearPriority(client *api.Client, workspaceID string, issues []resolvedPriorityIssue) error {
+func executeClearPriority(ctx context.Context, client *api.Client, workspaceID string, issues []resolvedPriorityIssue) error {
 	issueInfos := make([]map[string]any, len(issues))
 	for i, iss := range issues {
 		issueInfos[i] = map[string]any{
@@ -369,7 +422,7 @@ func executeClearPriority(client *api.Client, workspaceID string, issues []resol
 		}
 	}
 
-	_, err := client.Execute(removeIssuePrioritiesMutation, map[string]any{
+	_, err := client.Execute(ctx, removeIssuePrioritiesMutation, map[string]any{
 		"input": map[string]any{
 			"workspaceId": workspaceID,
 			"issues":      issueInfos,
@@ -382,6 +435,30 @@ func executeClearPriority(client *api.Client, workspaceID string, issues []resol
 	return nil
 }
 
+// setPriorityByNode sets the priority on the issue with the given ZenHub ID,
+// or clears it if name is blank. Returns the issue as it was before the
+// change and the resolved priority, which is nil if it was cleared.
+func setPriorityByNode(ctx context.Context, client *api.Client, workspaceID, issueID, name string) (*resolvedPriorityIssue, *resolve.PriorityResult, error) {
+	resolved, err := resolvePriorityByNode(ctx, client, workspaceID, issueID)
+	if err != nil {
+		return nil, nil, err
+	}
+	issues := []resolvedPriorityIssue{*resolved}
+
+	if name == "" {
+		return resolved, nil, executeClearPriority(ctx, client, workspaceID, issues)
+	}
+
+	priority, err := resolve.Priority(ctx, client, workspaceID, name)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := executeSetPriority(ctx, client, issues, priority.ID); err != nil {
+		return nil, nil, err
+	}
+	return resolved, priority, nil
+}
+
 func renderPriorityDryRun(w writerFlusher, resolved []resolvedPriorityIssue, resolveFailed []output.FailedItem, priority *resolve.PriorityResult) error {
 	items := make([]output.MutationItem, len(resolved))
 	for i, r := range resolved {
@@ -56,6 +56,7 @@ var (
 	issueReopenDryRun          bool
 	issueReopenRepo            string
 	issueReopenContinueOnError bool
+	issueReopenPlanOut         string
 )
 
 func init() {
@@ -65,6 +66,7 @@ func init() {
 	issueReopenCmd.Flags().BoolVar(&issueReopenDryRun, "dry-run", false, "Show what would be reopened without executing")
 	issueReopenCmd.Flags().StringVar(&issueReopenRepo, "repo", "", "Repository context for bare issue numbers")
 	issueReopenCmd.Flags().BoolVar(&issueReopenContinueOnError, "continue-on-error", false, "Continue processing remaining issues after a resolution error")
+	issueReopenCmd.Flags().StringVar(&issueReopenPlanOut, "plan-out", "", "Write the resolved change to a plan file for zh apply instead of executing")
 
 	issueCmd.AddCommand(issueReopenCmd)
 }
@@ -75,9 +77,12 @@ func resetIssueReopenFlags() {
 	issueReopenDryRun = false
 	issueReopenRepo = ""
 	issueReopenContinueOnError = false
+	issueReopenPlanOut = ""
 }
 
 func runIssueReopen(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+
 	cfg, err := requireWorkspace()
 	if err != nil {
 		return err
@@ -88,7 +93,7 @@ func runIssueReopen(cmd *cobra.Command, args []string) error {
 	ghClient := newGitHubClient(cfg, cmd)
 
 	// Resolve target pipeline
-	targetPipeline, err := resolve.Pipeline(client, cfg.Workspace, issueReopenPipeline, cfg.Aliases.Pipelines)
+	targetPipeline, err := resolve.Pipeline(ctx, client, cfg.Workspace, issueReopenPipeline, cfg.Aliases.Pipelines)
 	if err != nil {
 		return err
 	}
@@ -112,7 +117,7 @@ func runIssueReopen(cmd *cobra.Command, args []string) error {
 	var alreadyOpen []resolvedCloseIssue
 
 	for _, arg := range args {
-		issue, err := resolveForClose(client, cfg.Workspace, arg, issueReopenRepo, ghClient)
+		issue, err := resolveForClose(ctx, client, cfg.Workspace, arg, issueReopenRepo, ghClient)
 		if err != nil {
 			if issueReopenContinueOnError {
 				resolveFailed = append(resolveFailed, output.FailedItem{
@@ -137,11 +142,30 @@ func runIssueReopen(cmd *cobra.Command, args []string) error {
 	}
 
 	// Dry run
-	if issueReopenDryRun {
+	if issueReopenDryRun || issueReopenPlanOut != "" {
 		if output.IsJSON(outputFormat) {
-			return renderReopenDryRunJSON(w, resolved, alreadyOpen, resolveFailed, targetPipeline, position)
+			err = renderReopenDryRunJSON(w, resolved, alreadyOpen, resolveFailed, targetPipeline, position)
+		} else {
+			err = renderReopenDryRun(w, resolved, alreadyOpen, resolveFailed, targetPipeline.Name, position)
+		}
+		if err != nil {
+			return err
+		}
+
+		posLabel := "bottom"
+		if position == "START" {
+			posLabel = "top"
+		}
+		issueIDs := make([]string, len(resolved))
+		for i, r := range resolved {
+			issueIDs[i] = r.IssueID
 		}
-		return renderReopenDryRun(w, resolved, alreadyOpen, resolveFailed, targetPipeline.Name, position)
+		return savePlan(ctx, client, cfg.Workspace, w, issueReopenPlanOut, &mutationPlan{
+			Command:  "issue reopen",
+			Summary:  fmt.Sprintf("reopen %d issue(s) into %q at %s", len(resolved), targetPipeline.Name, posLabel),
+			Pipeline: &planEntity{ID: targetPipeline.ID, Name: targetPipeline.Name},
+			Position: posLabel,
+		}, issueIDs)
 	}
 
 	if len(resolved) == 0 {
@@ -167,7 +191,7 @@ func runIssueReopen(cmd *cobra.Command, args []string) error {
 		issueIDs[i] = r.IssueID
 	}
 
-	data, err := client.Execute(reopenIssuesMutation, map[string]any{
+	data, err := client.Execute(ctx, reopenIssuesMutation, map[string]any{
 		"input": map[string]any{
 			"issueIds":   issueIDs,
 			"pipelineId": targetPipeline.ID,
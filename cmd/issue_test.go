@@ -3,6 +3,7 @@ package cmd
 import (
 	"bytes"
 	"encoding/json"
+	"net/http"
 	"strings"
 	"testing"
 
@@ -95,6 +96,74 @@ func TestIssueListJSON(t *testing.T) {
 	}
 }
 
+func TestIssueListNDJSON(t *testing.T) {
+	resetIssueFlags()
+
+	ms := testutil.NewMockServer(t)
+	ms.HandleQuery("ListPipelines", pipelineResolutionResponse())
+	ms.HandleQuery("ListIssuesByPipeline", issueListByPipelineResponse())
+
+	setupIssueTestEnv(t, ms)
+	defer resetOutputFlags()
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"issue", "list", "--output=ndjson"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("issue list --output=ndjson returned error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	if len(lines) != 6 {
+		t.Fatalf("expected 6 lines (2 per pipeline * 3 pipelines), got %d:\n%s", len(lines), buf.String())
+	}
+	for _, line := range lines {
+		var issue map[string]any
+		if err := json.Unmarshal([]byte(line), &issue); err != nil {
+			t.Fatalf("line is not a JSON object: %v\nLine: %s", err, line)
+		}
+		if issue["number"] == nil {
+			t.Errorf("line should be an issue, got: %s", line)
+		}
+	}
+}
+
+func TestIssueListNDJSONLimit(t *testing.T) {
+	resetIssueFlags()
+
+	ms := testutil.NewMockServer(t)
+	ms.HandleQuery("ListPipelines", pipelineResolutionResponse())
+	queries := 0
+	ms.Handle(
+		func(req testutil.GraphQLRequest) bool { return strings.Contains(req.Query, "ListIssuesByPipeline") },
+		func(w http.ResponseWriter, req testutil.GraphQLRequest) {
+			queries++
+			data, _ := json.Marshal(issueListByPipelineResponse())
+			w.Write(data)
+		},
+	)
+
+	setupIssueTestEnv(t, ms)
+	defer resetOutputFlags()
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"issue", "list", "--output=ndjson", "--limit=3"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("issue list --output=ndjson --limit=3 returned error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	if len(lines) != 3 {
+		t.Errorf("expected 3 lines, got %d:\n%s", len(lines), buf.String())
+	}
+	if queries != 2 {
+		t.Errorf("expected 2 pipeline queries before reaching the limit, got %d", queries)
+	}
+}
+
 func TestIssueListEmpty(t *testing.T) {
 	resetIssueFlags()
 
@@ -0,0 +1,248 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/dslh/zh/internal/api"
+	"github.com/dslh/zh/internal/config"
+	"github.com/dslh/zh/internal/exitcode"
+	"github.com/dslh/zh/internal/output"
+	"github.com/dslh/zh/internal/resolve"
+	"github.com/spf13/cobra"
+)
+
+// GraphQL mutation for removing issue dependencies
+
+const deleteIssueDependencyMutation = `mutation DeleteIssueDependency($input: DeleteIssueDependencyInput!) {
+  deleteIssueDependency(input: $input) {
+    blockedIssue {
+      id
+      number
+    }
+    blockingIssue {
+      id
+      number
+    }
+  }
+}`
+
+// Commands
+
+var issueUnblockCmd = &cobra.Command{
+	Use:   "unblock <blocker> <blocked>",
+	Short: "Remove a blocking relationship between two issues",
+	Long: `Remove the blocking relationship where the first issue blocks the second.
+
+Only issue-to-issue blocks can be removed. ZenHub's API has no way to remove
+a block where either side is an epic; use ZenHub's web UI for those. An
+argument that names a ZenHub epic is reported as such even without
+--blocker-type or --blocked-type.
+
+Examples:
+  zh issue unblock task-tracker#1 task-tracker#2
+  zh issue unblock --repo=task-tracker 1 2
+  zh issue unblock task-tracker#1 task-tracker#2 --dry-run`,
+	Args: cobra.ExactArgs(2),
+	RunE: runIssueUnblock,
+}
+
+var (
+	issueUnblockDryRun      bool
+	issueUnblockRepo        string
+	issueUnblockBlockerType string
+	issueUnblockBlockedType string
+)
+
+func init() {
+	issueUnblockCmd.Flags().BoolVar(&issueUnblockDryRun, "dry-run", false, "Show what would be unblocked without executing")
+	issueUnblockCmd.Flags().StringVar(&issueUnblockRepo, "repo", "", "Repository context for bare issue numbers")
+	issueUnblockCmd.Flags().StringVar(&issueUnblockBlockerType, "blocker-type", "issue", "Type of the blocker: issue or epic")
+	issueUnblockCmd.Flags().StringVar(&issueUnblockBlockedType, "blocked-type", "issue", "Type of the blocked item: issue or epic")
+
+	issueCmd.AddCommand(issueUnblockCmd)
+}
+
+func resetIssueUnblockFlags() {
+	issueUnblockDryRun = false
+	issueUnblockRepo = ""
+	issueUnblockBlockerType = "issue"
+	issueUnblockBlockedType = "issue"
+}
+
+func runIssueUnblock(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+
+	// Validate type flags before touching the API. The type flags exist so
+	// that a user mirroring their 'zh issue block' invocation gets a precise
+	// explanation rather than a confusing resolution error.
+	blockerType := strings.ToLower(issueUnblockBlockerType)
+	blockedType := strings.ToLower(issueUnblockBlockedType)
+	if blockerType != "issue" && blockerType != "epic" {
+		return exitcode.Usage(fmt.Sprintf("invalid --blocker-type %q — must be 'issue' or 'epic'", issueUnblockBlockerType))
+	}
+	if blockedType != "issue" && blockedType != "epic" {
+		return exitcode.Usage(fmt.Sprintf("invalid --blocked-type %q — must be 'issue' or 'epic'", issueUnblockBlockedType))
+	}
+	if err := unblockEpicError(args, blockerType, blockedType); err != nil {
+		return err
+	}
+
+	cfg, err := requireWorkspace()
+	if err != nil {
+		return err
+	}
+
+	client := newClient(cfg, cmd)
+	ghClient := newGitHubClient(cfg, cmd)
+	w := cmd.OutOrStdout()
+
+	// Arguments that aren't issue references may name epics. Detect those
+	// before issue resolution, which would fail with a less useful error.
+	blockerType = unblockItemType(ctx, client, cfg, args[0])
+	blockedType = unblockItemType(ctx, client, cfg, args[1])
+	if err := unblockEpicError(args, blockerType, blockedType); err != nil {
+		return err
+	}
+
+	blocker, err := resolveBlockItem(ctx, client, cfg, args[0], "issue", issueUnblockRepo, ghClient)
+	if err != nil {
+		return fmt.Errorf("resolving blocker: %w", err)
+	}
+
+	blocked, err := resolveBlockItem(ctx, client, cfg, args[1], "issue", issueUnblockRepo, ghClient)
+	if err != nil {
+		return fmt.Errorf("resolving blocked: %w", err)
+	}
+
+	// Confirm the relationship exists so that we can report a clear error
+	// and fill in titles for display.
+	blockers, err := fetchIssueBlockingItems(ctx, client, blocked)
+	if err != nil {
+		return err
+	}
+	var found *blockDependencyNode
+	for i := range blockers {
+		if blockers[i].ID == blocker.ID {
+			found = &blockers[i]
+			break
+		}
+	}
+	if found == nil {
+		return exitcode.NotFoundError(fmt.Sprintf("%s does not block %s", blocker.Ref, blocked.Ref))
+	}
+	blocker.Title = found.Title
+
+	// Dry run
+	if issueUnblockDryRun {
+		if output.IsJSON(outputFormat) {
+			return output.JSON(w, map[string]any{
+				"dryRun":   true,
+				"blocking": formatBlockItemJSON(blocker),
+				"blocked":  formatBlockItemJSON(blocked),
+			})
+		}
+		msg := fmt.Sprintf("Would remove block: %s blocking %s", blocker.Ref, blocked.Ref)
+		output.MutationDryRun(w, msg, []output.MutationItem{
+			{Ref: blocker.Ref, Title: blockItemDryRunTitle(blocker), Context: "(blocking)"},
+			{Ref: blocked.Ref, Title: blockItemDryRunTitle(blocked), Context: "(blocked)"},
+		})
+		return nil
+	}
+
+	_, err = client.Execute(ctx, deleteIssueDependencyMutation, map[string]any{
+		"input": map[string]any{
+			"blockingIssue": map[string]any{
+				"repositoryGhId": blocker.RepoGhID,
+				"issueNumber":    blocker.Number,
+			},
+			"blockedIssue": map[string]any{
+				"repositoryGhId": blocked.RepoGhID,
+				"issueNumber":    blocked.Number,
+			},
+		},
+	})
+	if err != nil {
+		return exitcode.General("removing blockage", err)
+	}
+
+	if output.IsJSON(outputFormat) {
+		return output.JSON(w, map[string]any{
+			"blocking": formatBlockItemJSON(blocker),
+			"blocked":  formatBlockItemJSON(blocked),
+		})
+	}
+
+	output.MutationSingle(w, output.Green(fmt.Sprintf(
+		"Removed block: %s no longer blocks %s.",
+		blocker.Ref, blocked.Ref,
+	)))
+
+	return nil
+}
+
+// unblockEpicError returns a usage error naming the epic side(s) of the
+// requested blockage, or nil if both sides are issues.
+func unblockEpicError(args []string, blockerType, blockedType string) error {
+	var side string
+	switch {
+	case blockerType == "epic" && blockedType == "epic":
+		side = fmt.Sprintf("both %q and %q are epics", args[0], args[1])
+	case blockerType == "epic":
+		side = fmt.Sprintf("the blocker %q is an epic", args[0])
+	case blockedType == "epic":
+		side = fmt.Sprintf("the blocked item %q is an epic", args[1])
+	default:
+		return nil
+	}
+	return exitcode.Usage(fmt.Sprintf(
+		"cannot remove this block: %s — blocks involving epics cannot be removed via the API; use ZenHub's web UI instead",
+		side,
+	))
+}
+
+// unblockItemType returns "epic" if the identifier names a ZenHub epic, and
+// "issue" otherwise. repo#number references and bare numbers are always
+// issues (a legacy epic is an issue, so its blocks can be removed); ZenHub
+// IDs and titles are checked against the workspace's epics.
+func unblockItemType(ctx context.Context, client *api.Client, cfg *config.Config, identifier string) string {
+	if parsed, err := resolve.ParseIssueRef(identifier); err == nil && parsed.ZenHubID == "" {
+		return "issue"
+	}
+	epic, err := resolve.Epic(ctx, client, cfg.Workspace, identifier, cfg.Aliases.Epics)
+	if err == nil && epic.Type == "zenhub" {
+		return "epic"
+	}
+	return "issue"
+}
+
+// fetchIssueBlockingItems returns the items currently blocking the given issue.
+func fetchIssueBlockingItems(ctx context.Context, client *api.Client, item *blockItem) ([]blockDependencyNode, error) {
+	data, err := client.Execute(ctx, issueBlockersQuery, map[string]any{
+		"repositoryGhId": item.RepoGhID,
+		"issueNumber":    item.Number,
+	})
+	if err != nil {
+		return nil, exitcode.General("fetching blockers", err)
+	}
+
+	var resp struct {
+		IssueByInfo *struct {
+			Title         string `json:"title"`
+			BlockingItems struct {
+				Nodes []blockDependencyNode `json:"nodes"`
+			} `json:"blockingItems"`
+		} `json:"issueByInfo"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, exitcode.General("parsing blockers response", err)
+	}
+	if resp.IssueByInfo == nil {
+		return nil, exitcode.NotFoundError(fmt.Sprintf("issue %s not found", item.Ref))
+	}
+
+	item.Title = resp.IssueByInfo.Title
+	return resp.IssueByInfo.BlockingItems.Nodes, nil
+}
@@ -0,0 +1,287 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/dslh/zh/internal/testutil"
+)
+
+// --- issue unblock ---
+
+func TestIssueUnblock(t *testing.T) {
+	resetIssueFlags()
+	resetIssueUnblockFlags()
+
+	ms := setupIssueUnblockServer(t, true)
+	var input map[string]any
+	ms.Handle(
+		func(req testutil.GraphQLRequest) bool { return strings.Contains(req.Query, "DeleteIssueDependency") },
+		func(w http.ResponseWriter, req testutil.GraphQLRequest) {
+			input = mutationInputVar(req)
+			data, _ := json.Marshal(deleteIssueDependencyResponse())
+			w.Write(data)
+		},
+	)
+	setupIssueTestEnv(t, ms)
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"issue", "unblock", "task-tracker#1", "task-tracker#2"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("issue unblock returned error: %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "Removed block: task-tracker#1 no longer blocks task-tracker#2.") {
+		t.Errorf("output should confirm removal, got: %s", out)
+	}
+
+	blocking, _ := input["blockingIssue"].(map[string]any)
+	blocked, _ := input["blockedIssue"].(map[string]any)
+	if blocking["issueNumber"] != float64(1) || blocking["repositoryGhId"] != float64(12345) {
+		t.Errorf("blockingIssue = %v, want task-tracker#1", blocking)
+	}
+	if blocked["issueNumber"] != float64(2) {
+		t.Errorf("blockedIssue = %v, want task-tracker#2", blocked)
+	}
+}
+
+func TestIssueUnblockDryRun(t *testing.T) {
+	resetIssueFlags()
+	resetIssueUnblockFlags()
+
+	ms := setupIssueUnblockServer(t, true)
+	ms.Handle(
+		func(req testutil.GraphQLRequest) bool { return strings.Contains(req.Query, "DeleteIssueDependency") },
+		func(w http.ResponseWriter, req testutil.GraphQLRequest) {
+			t.Error("dry run should not remove the block")
+		},
+	)
+	setupIssueTestEnv(t, ms)
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"issue", "unblock", "task-tracker#1", "task-tracker#2", "--dry-run"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("issue unblock --dry-run returned error: %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "Would remove block: task-tracker#1 blocking task-tracker#2") {
+		t.Errorf("dry run should describe removal, got: %s", out)
+	}
+	if !strings.Contains(out, "Fix login button alignment") {
+		t.Errorf("dry run should show blocker title, got: %s", out)
+	}
+}
+
+func TestIssueUnblockJSON(t *testing.T) {
+	resetIssueFlags()
+	resetIssueUnblockFlags()
+
+	ms := setupIssueUnblockServer(t, true)
+	ms.HandleQuery("DeleteIssueDependency", deleteIssueDependencyResponse())
+	setupIssueTestEnv(t, ms)
+
+	outputFormat = "json"
+	defer func() { outputFormat = "" }()
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"issue", "unblock", "task-tracker#1", "task-tracker#2"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("issue unblock --output=json returned error: %v", err)
+	}
+
+	var result map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+		t.Fatalf("invalid JSON: %v\nOutput: %s", err, buf.String())
+	}
+	blocking, _ := result["blocking"].(map[string]any)
+	if blocking["ref"] != "task-tracker#1" {
+		t.Errorf("blocking.ref = %v, want task-tracker#1", blocking["ref"])
+	}
+	blocked, _ := result["blocked"].(map[string]any)
+	if blocked["ref"] != "task-tracker#2" {
+		t.Errorf("blocked.ref = %v, want task-tracker#2", blocked["ref"])
+	}
+}
+
+func TestIssueUnblockNotBlocking(t *testing.T) {
+	resetIssueFlags()
+	resetIssueUnblockFlags()
+
+	ms := setupIssueUnblockServer(t, false)
+	setupIssueTestEnv(t, ms)
+
+	rootCmd.SetOut(new(bytes.Buffer))
+	rootCmd.SetErr(new(bytes.Buffer))
+	rootCmd.SetArgs([]string{"issue", "unblock", "task-tracker#1", "task-tracker#2"})
+
+	err := rootCmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "task-tracker#1 does not block task-tracker#2") {
+		t.Errorf("expected not-blocking error, got: %v", err)
+	}
+}
+
+func TestIssueUnblockEpic(t *testing.T) {
+	resetIssueFlags()
+	resetIssueUnblockFlags()
+
+	ms := testutil.NewMockServer(t)
+	setupIssueTestEnv(t, ms)
+
+	rootCmd.SetOut(new(bytes.Buffer))
+	rootCmd.SetErr(new(bytes.Buffer))
+	rootCmd.SetArgs([]string{"issue", "unblock", "Auth Epic", "task-tracker#2", "--blocker-type=epic"})
+
+	err := rootCmd.Execute()
+	if err == nil {
+		t.Fatal("expected error for epic blocker")
+	}
+	for _, want := range []string{`the blocker "Auth Epic" is an epic`, "web UI"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error should contain %q, got: %v", want, err)
+		}
+	}
+}
+
+func TestIssueUnblockDetectsEpic(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"blocker", []string{"Q1 Platform", "task-tracker#2"}, `the blocker "Q1 Platform" is an epic`},
+		{"blocked", []string{"task-tracker#1", "epic-zen-1"}, `the blocked item "epic-zen-1" is an epic`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetIssueFlags()
+			resetIssueUnblockFlags()
+
+			ms := testutil.NewMockServer(t)
+			handleEpicResolutionForMutations(ms)
+			resolvedIssue := false
+			ms.Handle(
+				func(req testutil.GraphQLRequest) bool { return strings.Contains(req.Query, "issueByInfo") },
+				func(w http.ResponseWriter, req testutil.GraphQLRequest) { resolvedIssue = true },
+			)
+			setupIssueTestEnv(t, ms)
+
+			rootCmd.SetOut(new(bytes.Buffer))
+			rootCmd.SetErr(new(bytes.Buffer))
+			rootCmd.SetArgs(append([]string{"issue", "unblock"}, tt.args...))
+
+			err := rootCmd.Execute()
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got: %v", tt.want, err)
+			}
+			if resolvedIssue {
+				t.Error("epics should be detected before resolving issues")
+			}
+		})
+	}
+}
+
+func TestIssueUnblockHelp(t *testing.T) {
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"issue", "unblock", "--help"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("help returned error: %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{"--dry-run", "--repo", "epic"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("help should mention %s", want)
+		}
+	}
+}
+
+// --- helpers ---
+
+func setupIssueUnblockServer(t *testing.T, blocked bool) *testutil.MockServer {
+	t.Helper()
+
+	ms := testutil.NewMockServer(t)
+	ms.HandleQuery("ListRepos", repoResolutionResponse())
+	ms.Handle(
+		func(req testutil.GraphQLRequest) bool { return strings.Contains(req.Query, "IssueByInfo") },
+		func(w http.ResponseWriter, req testutil.GraphQLRequest) {
+			var vars struct {
+				IssueNumber int `json:"issueNumber"`
+			}
+			_ = json.Unmarshal(req.Variables, &vars)
+			data, _ := json.Marshal(map[string]any{
+				"data": map[string]any{
+					"issueByInfo": map[string]any{
+						"id":     fmt.Sprintf("i%d", vars.IssueNumber),
+						"number": vars.IssueNumber,
+						"repository": map[string]any{
+							"ghId":      12345,
+							"name":      "task-tracker",
+							"ownerName": "dlakehammond",
+						},
+					},
+				},
+			})
+			w.Write(data)
+		},
+	)
+
+	var nodes []any
+	if blocked {
+		nodes = []any{
+			map[string]any{
+				"__typename": "Issue",
+				"id":         "i1",
+				"number":     1,
+				"title":      "Fix login button alignment",
+				"state":      "OPEN",
+				"repository": map[string]any{
+					"name":      "task-tracker",
+					"ownerName": "dlakehammond",
+				},
+			},
+		}
+	}
+	ms.HandleQuery("GetIssueBlockers", map[string]any{
+		"data": map[string]any{
+			"issueByInfo": map[string]any{
+				"id":     "i2",
+				"number": 2,
+				"title":  "Add error handling",
+				"repository": map[string]any{
+					"name":      "task-tracker",
+					"ownerName": "dlakehammond",
+				},
+				"blockingItems": map[string]any{
+					"nodes": nodes,
+				},
+			},
+		},
+	})
+	return ms
+}
+
+func deleteIssueDependencyResponse() map[string]any {
+	return map[string]any{
+		"data": map[string]any{
+			"deleteIssueDependency": map[string]any{
+				"blockedIssue":  map[string]any{"id": "i2", "number": 2},
+				"blockingIssue": map[string]any{"id": "i1", "number": 1},
+			},
+		},
+	}
+}
@@ -19,10 +19,11 @@ var labelCmd = &cobra.Command{
 }
 
 var labelListCmd = &cobra.Command{
-	Use:   "list",
-	Short: "List all labels in the workspace",
-	Long:  `List all labels aggregated across all repositories in the current workspace. Labels with the same name across repos are deduplicated.`,
-	RunE:  runLabelList,
+	Use:         "list",
+	Short:       "List all labels in the workspace",
+	Long:        `List all labels aggregated across all repositories in the current workspace. Labels with the same name across repos are deduplicated.`,
+	RunE:        runLabelList,
+	Annotations: map[string]string{tabularAnnotation: "true"},
 }
 
 func init() {
@@ -32,6 +33,8 @@ func init() {
 
 // runLabelList implements `zh label list`.
 func runLabelList(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+
 	cfg, err := requireWorkspace()
 	if err != nil {
 		return err
@@ -40,7 +43,12 @@ func runLabelList(cmd *cobra.Command, args []string) error {
 	client := newClient(cfg, cmd)
 	w := cmd.OutOrStdout()
 
-	labels, err := resolve.FetchLabels(client, cfg.Workspace)
+	var labels []resolve.CachedLabel
+	if offline {
+		labels, err = readOffline[[]resolve.CachedLabel](cmd, resolve.LabelCacheKey(cfg.Workspace), "labels")
+	} else {
+		labels, err = resolve.FetchLabels(ctx, client, cfg.Workspace)
+	}
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,21 @@
+package cmd
+
+import (
+	"os"
+	"testing"
+)
+
+// TestMain keeps the mutation journal written by commands under test out of
+// the real data directory. Tests that inspect the journal set their own
+// XDG_DATA_HOME.
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "zh-test-data")
+	if err != nil {
+		panic(err)
+	}
+	os.Setenv("XDG_DATA_HOME", dir)
+
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
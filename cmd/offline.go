@@ -0,0 +1,265 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/dslh/zh/internal/cache"
+	"github.com/dslh/zh/internal/exitcode"
+	"github.com/dslh/zh/internal/outbox"
+	"github.com/dslh/zh/internal/output"
+	"github.com/dslh/zh/internal/resolve"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// Offline mode: API clients refuse every request, the read commands that
+// have a cached equivalent answer from the cache, and mutations are refused
+// or, with --queue, added to the outbox.
+
+var (
+	offlineFlag bool // --offline
+	queueFlag   bool // --queue
+
+	// offline is set for the running command from --offline or ZH_OFFLINE.
+	offline bool
+)
+
+// errQueued is returned from the root pre-run hook when a mutation has been
+// added to the outbox instead of running. Execute treats it as success.
+var errQueued = errors.New("command queued in the outbox")
+
+func resetOfflineFlags() {
+	offlineFlag = false
+	queueFlag = false
+	offline = false
+}
+
+// applyOffline sets offline mode for the command and stops mutations from
+// running while offline, queueing them if --queue was given.
+func applyOffline(cmd *cobra.Command, args []string) error {
+	offline = offlineFlag
+	if env := os.Getenv("ZH_OFFLINE"); env != "" && !offline {
+		on, err := strconv.ParseBool(env)
+		if err != nil {
+			return exitcode.Usage(fmt.Sprintf("invalid ZH_OFFLINE %q — use 1 or 0", env))
+		}
+		offline = on
+	}
+
+	if queueFlag && !offline {
+		return exitcode.Usage("--queue only applies offline — use it with --offline or ZH_OFFLINE=1")
+	}
+	if !offline || !isMutationCommand(cmd) {
+		return nil
+	}
+
+	path := strings.TrimPrefix(cmd.CommandPath(), "zh ")
+	if path == "outbox push" {
+		return exitcode.OfflineError("can't push the outbox while offline")
+	}
+	if dryRun, _ := cmd.Flags().GetBool("dry-run"); dryRun {
+		return exitcode.OfflineError(fmt.Sprintf("zh %s --dry-run needs the API — run it again when you're back online", path))
+	}
+	if !queueFlag {
+		return exitcode.OfflineError(fmt.Sprintf("zh %s changes the workspace and can't run offline — use --queue to add it to the outbox", path))
+	}
+
+	cfg, err := requireWorkspace()
+	if err != nil {
+		return err
+	}
+	entry, err := outbox.Append(cfg.Workspace, outbox.Entry{
+		Command: path,
+		Args:    queuedArgs(cmd, args),
+	})
+	if err != nil {
+		return exitcode.General("queueing command", err)
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Queued #%d: %s\n", entry.ID, entry.CommandLine())
+	fmt.Fprintln(cmd.OutOrStdout(), "Run 'zh outbox push' when you're back online.")
+	return errQueued
+}
+
+// isMutationCommand reports whether a command changes the workspace. Every
+// such command has --dry-run.
+func isMutationCommand(cmd *cobra.Command) bool {
+	return cmd.LocalFlags().Lookup("dry-run") != nil
+}
+
+// queuedArgs rebuilds the command line of a command being queued: its path,
+// the flags it was given and its arguments. Global flags such as --output
+// are left out, so that they can be chosen when the outbox is pushed.
+func queuedArgs(cmd *cobra.Command, args []string) []string {
+	queued := strings.Fields(strings.TrimPrefix(cmd.CommandPath(), "zh"))
+	cmd.NonInheritedFlags().Visit(func(f *pflag.Flag) {
+		if sv, ok := f.Value.(pflag.SliceValue); ok {
+			for _, v := range sv.GetSlice() {
+				queued = append(queued, "--"+f.Name+"="+v)
+			}
+			return
+		}
+		queued = append(queued, "--"+f.Name+"="+f.Value.String())
+	})
+	if slices.ContainsFunc(args, func(a string) bool { return strings.HasPrefix(a, "-") }) {
+		queued = append(queued, "--")
+	}
+	return append(queued, args...)
+}
+
+// readOffline reads a cached resource for an offline command, regardless of
+// its TTL, and prints a banner saying how old it is.
+func readOffline[T any](cmd *cobra.Command, key cache.Key, what string) (T, error) {
+	value, ok := cache.GetStale[T](key)
+	info, _ := cache.Stat(key)
+	if !ok {
+		var zero T
+		return zero, exitcode.OfflineError(fmt.Sprintf("no cached %s — run 'zh cache refresh' while online", what))
+	}
+
+	banner := fmt.Sprintf("Offline: showing %s cached %s.", what, output.FormatTimeAgo(info.FetchedAt))
+	if info.Stale() {
+		banner = fmt.Sprintf("Offline: showing %s cached %s, which may be out of date.", what, output.FormatTimeAgo(info.FetchedAt))
+	}
+	fmt.Fprintln(cmd.ErrOrStderr(), output.Yellow(banner))
+	return value, nil
+}
+
+// runPipelineListOffline lists the cached pipelines. Issue counts and stages
+// aren't cached, so only names are shown.
+func runPipelineListOffline(cmd *cobra.Command, workspaceID string) error {
+	pipelines, err := readOffline[[]resolve.CachedPipeline](cmd, resolve.PipelineCacheKey(workspaceID), "pipelines")
+	if err != nil {
+		return err
+	}
+
+	w := cmd.OutOrStdout()
+	if output.IsJSON(outputFormat) {
+		return output.JSON(w, pipelines)
+	}
+	if len(pipelines) == 0 {
+		fmt.Fprintln(w, "No pipelines found.")
+		return nil
+	}
+
+	lw := output.NewListWriter(w, "#", "PIPELINE")
+	for i, p := range pipelines {
+		lw.Row(fmt.Sprintf("%d", i+1), p.Name)
+	}
+	lw.FlushWithFooter(fmt.Sprintf("Total: %d pipeline(s)", len(pipelines)))
+	return nil
+}
+
+// runSprintListOffline lists the cached sprints, newest first, applying
+// --state and --limit.
+func runSprintListOffline(cmd *cobra.Command, workspaceID string) error {
+	cached, err := readOffline[[]resolve.CachedSprint](cmd, resolve.SprintCacheKey(workspaceID), "sprints")
+	if err != nil {
+		return err
+	}
+	accessors, _ := cache.GetStale[struct{ ActiveID string }](resolve.SprintAccessorsCacheKey(workspaceID))
+
+	var sprints []resolve.CachedSprint
+	for _, s := range cached {
+		if sprintListState == "" || sprintListState == "all" || strings.EqualFold(s.State, sprintListState) {
+			sprints = append(sprints, s)
+		}
+	}
+	slices.SortStableFunc(sprints, func(a, b resolve.CachedSprint) int {
+		return strings.Compare(b.StartAt, a.StartAt)
+	})
+	total := len(sprints)
+	if limit := output.EffectiveLimit(sprintListLimit, sprintListAll); limit > 0 && len(sprints) > limit {
+		sprints = sprints[:limit]
+	}
+
+	w := cmd.OutOrStdout()
+	if output.IsJSON(outputFormat) {
+		return output.JSON(w, sprints)
+	}
+	if len(sprints) == 0 {
+		fmt.Fprintln(w, "No sprints found.")
+		return nil
+	}
+
+	lw := output.NewListWriter(w, "STATE", "NAME", "DATES")
+	for _, s := range sprints {
+		lw.Row(formatSprintState(s.State, s.ID, accessors.ActiveID), s.DisplayName(), formatSprintDates(s.StartAt, s.EndAt))
+	}
+
+	footer := fmt.Sprintf("Showing %d", len(sprints))
+	if total > len(sprints) {
+		footer += fmt.Sprintf(" of %d", total)
+	}
+	lw.FlushWithFooter(footer + " sprint(s)")
+	return nil
+}
+
+// runEpicListOffline lists the cached epics, applying --limit.
+func runEpicListOffline(cmd *cobra.Command, workspaceID string) error {
+	epics, err := readOffline[[]resolve.CachedEpic](cmd, resolve.EpicCacheKey(workspaceID), "epics")
+	if err != nil {
+		return err
+	}
+	total := len(epics)
+	if limit := output.EffectiveLimit(epicListLimit, epicListAll); limit > 0 && len(epics) > limit {
+		epics = epics[:limit]
+	}
+
+	w := cmd.OutOrStdout()
+	if output.IsJSON(outputFormat) {
+		return output.JSON(w, epics)
+	}
+	if len(epics) == 0 {
+		fmt.Fprintln(w, "No epics found.")
+		return nil
+	}
+
+	lw := output.NewListWriter(w, "TYPE", "TITLE")
+	for _, e := range epics {
+		title := e.Title
+		if e.Type == "legacy" && e.RepoName != "" {
+			title = fmt.Sprintf("%s (%s#%d)", e.Title, e.RepoName, e.IssueNumber)
+		}
+		if len(title) > 50 {
+			title = title[:47] + "..."
+		}
+		lw.Row(e.Type, title)
+	}
+
+	footer := fmt.Sprintf("Showing %d", len(epics))
+	if total > len(epics) {
+		footer += fmt.Sprintf(" of %d", total)
+	}
+	lw.FlushWithFooter(footer + " epic(s)")
+	return nil
+}
+
+// runWorkspaceReposOffline lists the cached repositories.
+func runWorkspaceReposOffline(cmd *cobra.Command, workspaceID string) error {
+	repos, err := readOffline[[]resolve.CachedRepo](cmd, resolve.RepoCacheKey(workspaceID), "repositories")
+	if err != nil {
+		return err
+	}
+
+	w := cmd.OutOrStdout()
+	if output.IsJSON(outputFormat) {
+		return output.JSON(w, repos)
+	}
+	if len(repos) == 0 {
+		fmt.Fprintln(w, "No repositories connected to this workspace.")
+		return nil
+	}
+
+	lw := output.NewListWriter(w, "REPO", "GITHUB ID")
+	for _, r := range repos {
+		lw.Row(r.OwnerName+"/"+r.Name, fmt.Sprintf("%d", r.GhID))
+	}
+	lw.FlushWithFooter(fmt.Sprintf("Total: %d repo(s)", len(repos)))
+	return nil
+}
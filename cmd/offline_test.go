@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/dslh/zh/internal/cache"
+	"github.com/dslh/zh/internal/exitcode"
+	"github.com/dslh/zh/internal/resolve"
+	"github.com/dslh/zh/internal/testutil"
+)
+
+func TestOfflineListsFromCache(t *testing.T) {
+	resetOfflineFlags()
+	defer resetOfflineFlags()
+
+	ms := testutil.NewMockServer(t)
+	setupIssueTestEnv(t, ms)
+	_ = cache.Set(resolve.LabelCacheKey("ws-123"), []resolve.CachedLabel{{ID: "l1", Name: "bug", Color: "d73a4a"}})
+
+	tests := []struct {
+		args []string
+		want []string
+	}{
+		{[]string{"--offline", "pipeline", "list"}, []string{"New Issues", "In Development", "Total: 3 pipeline(s)"}},
+		{[]string{"--offline", "label", "list"}, []string{"bug", "#d73a4a"}},
+	}
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.args[1:], " "), func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			errBuf := new(bytes.Buffer)
+			rootCmd.SetOut(buf)
+			rootCmd.SetErr(errBuf)
+			defer rootCmd.SetErr(nil)
+			rootCmd.SetArgs(tt.args)
+
+			if err := rootCmd.Execute(); err != nil {
+				t.Fatalf("returned error: %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(buf.String(), want) {
+					t.Errorf("output missing %q:\n%s", want, buf.String())
+				}
+			}
+			if !strings.Contains(errBuf.String(), "Offline: showing") || !strings.Contains(errBuf.String(), "cached just now") {
+				t.Errorf("stderr should show the staleness banner, got: %q", errBuf.String())
+			}
+		})
+	}
+}
+
+func TestOfflineFromEnvironment(t *testing.T) {
+	resetOfflineFlags()
+	defer resetOfflineFlags()
+
+	ms := testutil.NewMockServer(t)
+	setupIssueTestEnv(t, ms)
+	t.Setenv("ZH_OFFLINE", "1")
+
+	rootCmd.SetOut(new(bytes.Buffer))
+	rootCmd.SetErr(new(bytes.Buffer))
+	defer rootCmd.SetErr(nil)
+	rootCmd.SetArgs([]string{"sprint", "list"})
+
+	// Nothing has cached sprints, and the API must not be called.
+	err := rootCmd.Execute()
+	if exitcode.ExitCode(err) != exitcode.Offline {
+		t.Fatalf("exit code = %d, want %d (err: %v)", exitcode.ExitCode(err), exitcode.Offline, err)
+	}
+	if !strings.Contains(err.Error(), "zh cache refresh") {
+		t.Errorf("error should suggest zh cache refresh, got: %v", err)
+	}
+}
+
+func TestOfflineBoardSnapshot(t *testing.T) {
+	resetOfflineFlags()
+	resetBoardFlags()
+	defer resetOfflineFlags()
+
+	ms := testutil.NewMockServer(t)
+	ms.HandleQuery("GetBoard", boardResponse())
+	setupIssueTestEnv(t, ms)
+
+	online := new(bytes.Buffer)
+	rootCmd.SetOut(online)
+	rootCmd.SetArgs([]string{"board"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("board returned error: %v", err)
+	}
+
+	offlineOut := new(bytes.Buffer)
+	errBuf := new(bytes.Buffer)
+	rootCmd.SetOut(offlineOut)
+	rootCmd.SetErr(errBuf)
+	defer rootCmd.SetErr(nil)
+	rootCmd.SetArgs([]string{"--offline", "board"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("offline board returned error: %v", err)
+	}
+
+	if offlineOut.String() != online.String() {
+		t.Errorf("offline board differs from the snapshot:\n%s\nwant:\n%s", offlineOut.String(), online.String())
+	}
+	if !strings.Contains(errBuf.String(), "Offline: showing board") {
+		t.Errorf("stderr should show the staleness banner, got: %q", errBuf.String())
+	}
+}
+
+func TestOfflineRefusesMutations(t *testing.T) {
+	resetOfflineFlags()
+	defer resetOfflineFlags()
+
+	ms := testutil.NewMockServer(t)
+	setupIssueTestEnv(t, ms)
+
+	rootCmd.SetOut(new(bytes.Buffer))
+	rootCmd.SetArgs([]string{"--offline", "pipeline", "delete", "Done"})
+
+	err := rootCmd.Execute()
+	if exitcode.ExitCode(err) != exitcode.Offline {
+		t.Fatalf("exit code = %d, want %d (err: %v)", exitcode.ExitCode(err), exitcode.Offline, err)
+	}
+	if !strings.Contains(err.Error(), "--queue") {
+		t.Errorf("error should mention --queue, got: %v", err)
+	}
+}
+
+func TestIsMutationCommand(t *testing.T) {
+	if !isMutationCommand(sprintConfigSetCmd) {
+		t.Error("sprint config set saves through the API and should be a mutation")
+	}
+	if isMutationCommand(sprintConfigShowCmd) {
+		t.Error("sprint config show should not be a mutation")
+	}
+}
+
+func TestOfflineAPIErrorsExitOffline(t *testing.T) {
+	resetOfflineFlags()
+	defer resetOfflineFlags()
+
+	ms := testutil.NewMockServer(t)
+	setupIssueTestEnv(t, ms)
+
+	rootCmd.SetOut(new(bytes.Buffer))
+	rootCmd.SetArgs([]string{"--offline", "issue", "show", "task-tracker#1"})
+
+	// Execute leaves its cancelled signal context on the root command.
+	defer rootCmd.SetContext(context.Background())
+	err := Execute()
+	if exitcode.ExitCode(err) != exitcode.Offline {
+		t.Fatalf("exit code = %d, want %d (err: %v)", exitcode.ExitCode(err), exitcode.Offline, err)
+	}
+}
+
+func TestQueueRequiresOffline(t *testing.T) {
+	resetOfflineFlags()
+	defer resetOfflineFlags()
+
+	ms := testutil.NewMockServer(t)
+	setupIssueTestEnv(t, ms)
+
+	rootCmd.SetOut(new(bytes.Buffer))
+	rootCmd.SetArgs([]string{"--queue", "pipeline", "list"})
+
+	err := rootCmd.Execute()
+	if exitcode.ExitCode(err) != exitcode.UsageError {
+		t.Fatalf("exit code = %d, want usage error (err: %v)", exitcode.ExitCode(err), err)
+	}
+}
@@ -0,0 +1,206 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/dslh/zh/internal/exitcode"
+	"github.com/dslh/zh/internal/outbox"
+	"github.com/dslh/zh/internal/output"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// Commands
+
+var outboxCmd = &cobra.Command{
+	Use:   "outbox",
+	Short: "List changes queued while offline",
+	Long: `List the changes queued with --offline --queue in the current workspace,
+oldest first. Use zh outbox push to run them once you're back online.
+
+Queued commands are stored as typed and are resolved when they are pushed,
+so names are matched against the workspace as it is then.
+
+Examples:
+  zh --offline --queue issue move api#12 "In Review"
+  zh outbox
+  zh outbox push`,
+	Args:        cobra.NoArgs,
+	RunE:        runOutboxList,
+	Annotations: map[string]string{tabularAnnotation: "true"},
+}
+
+var outboxPushCmd = &cobra.Command{
+	Use:   "push",
+	Short: "Run the queued changes",
+	Long: `Run the commands in the outbox, oldest first. Each command is removed
+from the outbox once it succeeds. Pushing stops at the first command that
+fails, leaving it and the commands after it in the outbox.
+
+Use --dry-run to preview every queued command without running any.`,
+	Args: cobra.NoArgs,
+	RunE: runOutboxPush,
+}
+
+var outboxClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Drop every queued change",
+	Args:  cobra.NoArgs,
+	RunE:  runOutboxClear,
+}
+
+var outboxPushDryRun bool
+
+func init() {
+	outboxPushCmd.Flags().BoolVar(&outboxPushDryRun, "dry-run", false, "Preview the queued commands without running them")
+
+	outboxCmd.AddCommand(outboxPushCmd)
+	outboxCmd.AddCommand(outboxClearCmd)
+	rootCmd.AddCommand(outboxCmd)
+}
+
+func resetOutboxFlags() {
+	outboxPushDryRun = false
+}
+
+// runOutboxList implements `zh outbox`.
+func runOutboxList(cmd *cobra.Command, args []string) error {
+	cfg, err := requireWorkspace()
+	if err != nil {
+		return err
+	}
+
+	w := cmd.OutOrStdout()
+
+	entries, err := outbox.Load(cfg.Workspace)
+	if err != nil {
+		return exitcode.General("loading outbox", err)
+	}
+
+	if output.IsJSON(outputFormat) {
+		if entries == nil {
+			entries = []outbox.Entry{}
+		}
+		return output.JSON(w, entries)
+	}
+
+	if len(entries) == 0 {
+		fmt.Fprintln(w, "The outbox is empty.")
+		return nil
+	}
+
+	lw := output.NewListWriter(w, "ID", "QUEUED", "COMMAND")
+	for _, e := range entries {
+		lw.Row(strconv.Itoa(e.ID), output.FormatTimeAgo(e.Time), e.CommandLine())
+	}
+	lw.FlushWithFooter(fmt.Sprintf("%d queued command(s) — run 'zh outbox push' to send them", len(entries)))
+	return nil
+}
+
+// runOutboxPush implements `zh outbox push`.
+func runOutboxPush(cmd *cobra.Command, args []string) error {
+	cfg, err := requireWorkspace()
+	if err != nil {
+		return err
+	}
+
+	w := cmd.OutOrStdout()
+
+	entries, err := outbox.Load(cfg.Workspace)
+	if err != nil {
+		return exitcode.General("loading outbox", err)
+	}
+	if len(entries) == 0 {
+		fmt.Fprintln(w, "The outbox is empty.")
+		return nil
+	}
+
+	for i, entry := range entries {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintln(w, output.Cyan(fmt.Sprintf("#%d %s", entry.ID, entry.CommandLine())))
+
+		if err := replayOutboxEntry(cmd, entry); err != nil {
+			return exitcode.General(fmt.Sprintf("#%d failed — it and %d later command(s) are still in the outbox", entry.ID, len(entries)-i-1), err)
+		}
+		if outboxPushDryRun {
+			continue
+		}
+		if err := outbox.Remove(cfg.Workspace, entry.ID); err != nil {
+			return exitcode.General("updating outbox", err)
+		}
+	}
+
+	fmt.Fprintln(w)
+	if outboxPushDryRun {
+		fmt.Fprintf(w, "Previewed %d queued command(s). The outbox is unchanged.\n", len(entries))
+		return nil
+	}
+	fmt.Fprintf(w, "Pushed %d queued command(s).\n", len(entries))
+	return nil
+}
+
+// replayOutboxEntry runs a queued command as if it had been typed, with its
+// flags reset to their defaults first. With --dry-run, the command is run
+// with its own --dry-run.
+func replayOutboxEntry(cmd *cobra.Command, entry outbox.Entry) error {
+	target, rest, err := cmd.Root().Find(entry.Args)
+	if err != nil || target == cmd.Root() || target.RunE == nil {
+		return exitcode.Usage(fmt.Sprintf("unknown command %q", entry.Command))
+	}
+
+	flags := target.LocalFlags()
+	resetFlagDefaults(flags)
+	defer resetFlagDefaults(flags)
+
+	if err := target.ParseFlags(rest); err != nil {
+		return exitcode.Usage(err.Error())
+	}
+	if outboxPushDryRun {
+		if err := target.Flags().Set("dry-run", "true"); err != nil {
+			return exitcode.General("enabling --dry-run", err)
+		}
+	}
+	positional := target.Flags().Args()
+	if err := target.ValidateArgs(positional); err != nil {
+		return exitcode.Usage(err.Error())
+	}
+
+	target.SetContext(cmd.Context())
+	return target.RunE(target, positional)
+}
+
+// resetFlagDefaults sets every changed flag back to its default value.
+func resetFlagDefaults(flags *pflag.FlagSet) {
+	flags.VisitAll(func(f *pflag.Flag) {
+		if !f.Changed {
+			return
+		}
+		if sv, ok := f.Value.(pflag.SliceValue); ok {
+			_ = sv.Replace(nil)
+		} else {
+			_ = f.Value.Set(f.DefValue)
+		}
+		f.Changed = false
+	})
+}
+
+// runOutboxClear implements `zh outbox clear`.
+func runOutboxClear(cmd *cobra.Command, args []string) error {
+	cfg, err := requireWorkspace()
+	if err != nil {
+		return err
+	}
+
+	entries, err := outbox.Load(cfg.Workspace)
+	if err != nil {
+		return exitcode.General("loading outbox", err)
+	}
+	if err := outbox.Clear(cfg.Workspace); err != nil {
+		return exitcode.General("clearing outbox", err)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Dropped %d queued command(s).\n", len(entries))
+	return nil
+}
@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/dslh/zh/internal/outbox"
+	"github.com/dslh/zh/internal/testutil"
+)
+
+// clearHelpFlag resets --help on a command, which leaks from earlier tests
+// that ran it with --help.
+func clearHelpFlag(t *testing.T, path ...string) {
+	t.Helper()
+	c, _, err := rootCmd.Find(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = c.Flags().Set("help", "false")
+}
+
+// queueEstimate queues `zh issue estimate task-tracker#1 5` while offline.
+func queueEstimate(t *testing.T) {
+	t.Helper()
+	resetOfflineFlags()
+	defer resetOfflineFlags()
+	clearHelpFlag(t, "issue", "estimate")
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"--offline", "--queue", "issue", "estimate", "task-tracker#1", "5"})
+
+	if err := rootCmd.Execute(); !errors.Is(err, errQueued) {
+		t.Fatalf("queued command returned %v, want errQueued", err)
+	}
+	if !strings.Contains(buf.String(), "Queued #1: zh issue estimate task-tracker#1 5") {
+		t.Errorf("output should confirm the command was queued, got: %s", buf.String())
+	}
+}
+
+// countSetEstimate counts the SetEstimate mutations sent to the mock server.
+func countSetEstimate(ms *testutil.MockServer) *int {
+	count := new(int)
+	ms.Handle(func(req testutil.GraphQLRequest) bool {
+		return strings.Contains(req.Query, "SetEstimate")
+	}, func(w http.ResponseWriter, req testutil.GraphQLRequest) {
+		*count++
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(setEstimateSuccessResponse())
+	})
+	return count
+}
+
+func TestOutboxQueueAndPush(t *testing.T) {
+	resetIssueFlags()
+	resetIssueEstimateFlags()
+	resetOutboxFlags()
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+
+	ms := testutil.NewMockServer(t)
+	sets := countSetEstimate(ms)
+	ms.HandleQuery("ListRepos", repoResolutionResponse())
+	ms.HandleQuery("IssueByInfo", issueByInfoResolutionResponse())
+	ms.HandleQuery("GetIssueForEstimate", issueEstimateResponse(3))
+	setupIssueTestEnv(t, ms)
+
+	queueEstimate(t)
+	if *sets != 0 {
+		t.Fatalf("queueing should not call the API, got %d mutation(s)", *sets)
+	}
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"outbox"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("outbox returned error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "zh issue estimate task-tracker#1 5") || !strings.Contains(buf.String(), "1 queued command(s)") {
+		t.Errorf("outbox should list the queued command, got: %s", buf.String())
+	}
+
+	// A dry run previews the command and leaves it queued.
+	buf.Reset()
+	rootCmd.SetArgs([]string{"outbox", "push", "--dry-run"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("outbox push --dry-run returned error: %v", err)
+	}
+	resetOutboxFlags()
+	if *sets != 0 {
+		t.Errorf("dry run should not send mutations, got %d", *sets)
+	}
+	if entries, _ := outbox.Load("ws-123"); len(entries) != 1 {
+		t.Errorf("dry run should leave the outbox unchanged, got %d entries", len(entries))
+	}
+
+	buf.Reset()
+	rootCmd.SetArgs([]string{"outbox", "push"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("outbox push returned error: %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "Set estimate") || !strings.Contains(out, "Pushed 1 queued command(s).") {
+		t.Errorf("push should run the command, got: %s", out)
+	}
+	if *sets != 1 {
+		t.Errorf("push sent %d SetEstimate mutation(s), want 1", *sets)
+	}
+	if entries, _ := outbox.Load("ws-123"); len(entries) != 0 {
+		t.Errorf("pushed commands should leave the outbox, got %+v", entries)
+	}
+}
+
+func TestOutboxPushStopsAtFailure(t *testing.T) {
+	resetIssueFlags()
+	resetIssueEstimateFlags()
+	resetOutboxFlags()
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+
+	ms := testutil.NewMockServer(t)
+	setupIssueTestEnv(t, ms)
+
+	if _, err := outbox.Append("ws-123", outbox.Entry{Command: "issue close", Args: []string{"issue", "close", "nope#1"}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := outbox.Append("ws-123", outbox.Entry{Command: "issue reopen", Args: []string{"issue", "reopen", "nope#1"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	rootCmd.SetOut(new(bytes.Buffer))
+	rootCmd.SetArgs([]string{"outbox", "push"})
+
+	err := rootCmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "#1 failed — it and 1 later command(s) are still in the outbox") {
+		t.Fatalf("outbox push error = %v", err)
+	}
+	if entries, _ := outbox.Load("ws-123"); len(entries) != 2 {
+		t.Errorf("failed commands should stay queued, got %d entries", len(entries))
+	}
+}
+
+func TestOutboxClear(t *testing.T) {
+	resetOutboxFlags()
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+
+	ms := testutil.NewMockServer(t)
+	setupIssueTestEnv(t, ms)
+	_, _ = outbox.Append("ws-123", outbox.Entry{Command: "issue close", Args: []string{"issue", "close", "api#1"}})
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"outbox", "clear"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("outbox clear returned error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "Dropped 1 queued command(s).") {
+		t.Errorf("unexpected output: %s", buf.String())
+	}
+	if entries, _ := outbox.Load("ws-123"); len(entries) != 0 {
+		t.Errorf("outbox should be empty, got %+v", entries)
+	}
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -300,10 +301,11 @@ var pipelineCmd = &cobra.Command{
 }
 
 var pipelineListCmd = &cobra.Command{
-	Use:   "list",
-	Short: "List all pipelines in the workspace",
-	Long:  `List all pipelines in the current workspace with position order.`,
-	RunE:  runPipelineList,
+	Use:         "list",
+	Short:       "List all pipelines in the workspace",
+	Long:        `List all pipelines in the current workspace with position order.`,
+	RunE:        runPipelineList,
+	Annotations: map[string]string{tabularAnnotation: "true"},
 }
 
 var pipelineShowCmd = &cobra.Command{
@@ -344,15 +346,20 @@ func init() {
 
 // runPipelineList implements `zh pipeline list`.
 func runPipelineList(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+
 	cfg, err := requireWorkspace()
 	if err != nil {
 		return err
 	}
+	if offline {
+		return runPipelineListOffline(cmd, cfg.Workspace)
+	}
 
 	client := newClient(cfg, cmd)
 	w := cmd.OutOrStdout()
 
-	data, err := client.Execute(listPipelinesFullQuery, map[string]any{
+	data, err := client.Execute(ctx, listPipelinesFullQuery, map[string]any{
 		"workspaceId": cfg.Workspace,
 	})
 	if err != nil {
@@ -424,6 +431,8 @@ func cachePipelinesFromList(pipelines []pipelineListEntry, workspaceID string) {
 
 // runPipelineShow implements `zh pipeline show <name>`.
 func runPipelineShow(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+
 	cfg, err := requireWorkspace()
 	if err != nil {
 		return err
@@ -435,7 +444,7 @@ func runPipelineShow(cmd *cobra.Command, args []string) error {
 	var identifier string
 	if pipelineShowInteractive {
 		identifier, err = interactiveOrArg(cmd, nil, true, func() ([]selectItem, error) {
-			return fetchPipelineSelectItems(client, cfg.Workspace)
+			return fetchPipelineSelectItems(ctx, client, cfg.Workspace)
 		}, "Select a pipeline")
 		if err != nil {
 			return err
@@ -448,13 +457,13 @@ func runPipelineShow(cmd *cobra.Command, args []string) error {
 	}
 
 	// Resolve the pipeline
-	resolved, err := resolve.Pipeline(client, cfg.Workspace, identifier, cfg.Aliases.Pipelines)
+	resolved, err := resolve.Pipeline(ctx, client, cfg.Workspace, identifier, cfg.Aliases.Pipelines)
 	if err != nil {
 		return err
 	}
 
 	// Fetch full pipeline details
-	data, err := client.Execute(pipelineDetailQuery, map[string]any{
+	data, err := client.Execute(ctx, pipelineDetailQuery, map[string]any{
 		"pipelineId": resolved.ID,
 	})
 	if err != nil {
@@ -475,7 +484,7 @@ func runPipelineShow(cmd *cobra.Command, args []string) error {
 	if pipelineShowAll {
 		limit = 0 // fetch all
 	}
-	issues, _, err := fetchPipelineIssues(client, resolved.ID, cfg.Workspace, limit)
+	issues, _, err := fetchPipelineIssues(ctx, client, resolved.ID, cfg.Workspace, limit)
 	if err != nil {
 		return err
 	}
@@ -637,8 +646,8 @@ func runPipelineShow(cmd *cobra.Command, args []string) error {
 }
 
 // fetchPipelineSelectItems fetches pipelines and converts them to selectItems for interactive mode.
-func fetchPipelineSelectItems(client *api.Client, workspaceID string) ([]selectItem, error) {
-	data, err := client.Execute(listPipelinesFullQuery, map[string]any{
+func fetchPipelineSelectItems(ctx context.Context, client *api.Client, workspaceID string) ([]selectItem, error) {
+	data, err := client.Execute(ctx, listPipelinesFullQuery, map[string]any{
 		"workspaceId": workspaceID,
 	})
 	if err != nil {
@@ -674,7 +683,7 @@ func fetchPipelineSelectItems(client *api.Client, workspaceID string) ([]selectI
 
 // fetchPipelineIssues fetches issues in a pipeline with pagination.
 // If limit is 0, fetches all issues.
-func fetchPipelineIssues(client *api.Client, pipelineID, workspaceID string, limit int) ([]pipelineIssueNode, int, error) {
+func fetchPipelineIssues(ctx context.Context, client *api.Client, pipelineID, workspaceID string, limit int) ([]pipelineIssueNode, int, error) {
 	var allIssues []pipelineIssueNode
 	var cursor *string
 	totalCount := 0
@@ -701,7 +710,7 @@ func fetchPipelineIssues(client *api.Client, pipelineID, workspaceID string, lim
 			vars["after"] = *cursor
 		}
 
-		data, err := client.Execute(pipelineIssuesQuery, vars)
+		data, err := client.Execute(ctx, pipelineIssuesQuery, vars)
 		if err != nil {
 			return nil, 0, exitcode.General("fetching pipeline issues", err)
 		}
@@ -770,6 +779,8 @@ func repoNamesAmbiguous(issues []pipelineIssueNode) bool {
 
 // runPipelineAutomations implements `zh pipeline automations <name>`.
 func runPipelineAutomations(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+
 	cfg, err := requireWorkspace()
 	if err != nil {
 		return err
@@ -779,13 +790,13 @@ func runPipelineAutomations(cmd *cobra.Command, args []string) error {
 	w := cmd.OutOrStdout()
 
 	// Resolve the pipeline
-	resolved, err := resolve.Pipeline(client, cfg.Workspace, args[0], cfg.Aliases.Pipelines)
+	resolved, err := resolve.Pipeline(ctx, client, cfg.Workspace, args[0], cfg.Aliases.Pipelines)
 	if err != nil {
 		return err
 	}
 
 	// Fetch all pipelines with automation data (API doesn't support single-pipeline query)
-	data, err := client.Execute(pipelineAutomationsQuery, map[string]any{
+	data, err := client.Execute(ctx, pipelineAutomationsQuery, map[string]any{
 		"workspaceId": cfg.Workspace,
 	})
 	if err != nil {
@@ -99,8 +99,9 @@ future commands. Aliases are stored in the config file.
 
 Use --delete to remove an existing alias. Use --list to show all
 pipeline aliases.`,
-	Args: cobra.RangeArgs(0, 2),
-	RunE: runPipelineAlias,
+	Args:        cobra.RangeArgs(0, 2),
+	RunE:        runPipelineAlias,
+	Annotations: map[string]string{tabularAnnotation: "true"},
 }
 
 // Flag variables
@@ -165,6 +166,8 @@ func resetPipelineMutationFlags() {
 
 // runPipelineCreate implements `zh pipeline create <name>`.
 func runPipelineCreate(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+
 	cfg, err := requireWorkspace()
 	if err != nil {
 		return err
@@ -200,7 +203,7 @@ func runPipelineCreate(cmd *cobra.Command, args []string) error {
 		input["description"] = pipelineCreateDescription
 	}
 
-	data, err := client.Execute(createPipelineMutation, map[string]any{
+	data, err := client.Execute(ctx, createPipelineMutation, map[string]any{
 		"input": input,
 	})
 	if err != nil {
@@ -250,6 +253,8 @@ func runPipelineCreate(cmd *cobra.Command, args []string) error {
 
 // runPipelineEdit implements `zh pipeline edit <name>`.
 func runPipelineEdit(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+
 	cfg, err := requireWorkspace()
 	if err != nil {
 		return err
@@ -268,7 +273,7 @@ func runPipelineEdit(cmd *cobra.Command, args []string) error {
 	}
 
 	// Resolve the pipeline
-	resolved, err := resolve.Pipeline(client, cfg.Workspace, args[0], cfg.Aliases.Pipelines)
+	resolved, err := resolve.Pipeline(ctx, client, cfg.Workspace, args[0], cfg.Aliases.Pipelines)
 	if err != nil {
 		return err
 	}
@@ -306,7 +311,7 @@ func runPipelineEdit(cmd *cobra.Command, args []string) error {
 		input["description"] = pipelineEditDescription
 	}
 
-	data, err := client.Execute(updatePipelineMutation, map[string]any{
+	data, err := client.Execute(ctx, updatePipelineMutation, map[string]any{
 		"input": input,
 	})
 	if err != nil {
@@ -344,6 +349,8 @@ func runPipelineEdit(cmd *cobra.Command, args []string) error {
 
 // runPipelineDelete implements `zh pipeline delete <name> --into=<name>`.
 func runPipelineDelete(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+
 	cfg, err := requireWorkspace()
 	if err != nil {
 		return err
@@ -353,13 +360,13 @@ func runPipelineDelete(cmd *cobra.Command, args []string) error {
 	w := cmd.OutOrStdout()
 
 	// Resolve the pipeline to delete
-	source, err := resolve.Pipeline(client, cfg.Workspace, args[0], cfg.Aliases.Pipelines)
+	source, err := resolve.Pipeline(ctx, client, cfg.Workspace, args[0], cfg.Aliases.Pipelines)
 	if err != nil {
 		return err
 	}
 
 	// Resolve the destination pipeline
-	dest, err := resolve.Pipeline(client, cfg.Workspace, pipelineDeleteInto, cfg.Aliases.Pipelines)
+	dest, err := resolve.Pipeline(ctx, client, cfg.Workspace, pipelineDeleteInto, cfg.Aliases.Pipelines)
 	if err != nil {
 		return err
 	}
@@ -370,7 +377,7 @@ func runPipelineDelete(cmd *cobra.Command, args []string) error {
 	}
 
 	// Get issue count for the source pipeline
-	detailData, err := client.Execute(pipelineDetailQuery, map[string]any{
+	detailData, err := client.Execute(ctx, pipelineDetailQuery, map[string]any{
 		"pipelineId": source.ID,
 	})
 	if err != nil {
@@ -399,7 +406,7 @@ func runPipelineDelete(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	data, err := client.Execute(deletePipelineMutation, map[string]any{
+	data, err := client.Execute(ctx, deletePipelineMutation, map[string]any{
 		"input": map[string]any{
 			"pipelineId":            source.ID,
 			"destinationPipelineId": dest.ID,
@@ -445,6 +452,8 @@ func runPipelineDelete(cmd *cobra.Command, args []string) error {
 
 // runPipelineAlias implements `zh pipeline alias <name> <alias>`.
 func runPipelineAlias(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+
 	cfg, err := requireWorkspace()
 	if err != nil {
 		return err
@@ -471,6 +480,11 @@ func runPipelineAlias(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	// Setting and deleting aliases print a confirmation, not a table
+	if output.IsTabular(outputFormat) {
+		return tabularFormatError(cmd)
+	}
+
 	// --delete: remove an alias
 	if pipelineAliasDelete {
 		if len(args) != 1 {
@@ -505,7 +519,7 @@ func runPipelineAlias(cmd *cobra.Command, args []string) error {
 
 	// Validate the pipeline exists
 	client := newClient(cfg, cmd)
-	resolved, err := resolve.Pipeline(client, cfg.Workspace, pipelineName, cfg.Aliases.Pipelines)
+	resolved, err := resolve.Pipeline(ctx, client, cfg.Workspace, pipelineName, cfg.Aliases.Pipelines)
 	if err != nil {
 		return err
 	}
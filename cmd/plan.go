@@ -0,0 +1,293 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/dslh/zh/internal/api"
+	"github.com/dslh/zh/internal/exitcode"
+	"github.com/dslh/zh/internal/output"
+	"github.com/dslh/zh/internal/resolve"
+)
+
+// Plans record a batch mutation with everything already resolved, so that
+// the change reviewed with --plan-out is exactly the change `zh apply` makes.
+
+// planVersion is the current plan file format.
+const planVersion = 1
+
+const planIssueStateQuery = `query GetIssueStateForPlan($issueId: ID!, $workspaceId: ID!) {
+  node(id: $issueId) {
+    ... on Issue {
+      id
+      number
+      title
+      state
+      estimate {
+        value
+      }
+      repository {
+        ghId
+        name
+        ownerName
+      }
+      labels(first: 50) {
+        nodes {
+          id
+        }
+      }
+      pipelineIssue(workspaceId: $workspaceId) {
+        id
+        relativePosition
+        pipeline {
+          id
+          name
+        }
+        priority {
+          id
+          name
+        }
+      }
+    }
+  }
+}`
+
+// mutationPlan is the file written by --plan-out and read by `zh apply`.
+// Only the target fields used by Command are set.
+type mutationPlan struct {
+	Version   int         `json:"version"`
+	Workspace string      `json:"workspace"`
+	CreatedAt time.Time   `json:"createdAt"`
+	Command   string      `json:"command"` // e.g. "issue move"
+	Summary   string      `json:"summary"` // e.g. `move 2 issue(s) to "Done"`
+	Issues    []planIssue `json:"issues"`
+
+	Pipeline *planEntity  `json:"pipeline,omitempty"` // issue move, issue reopen
+	Position string       `json:"position,omitempty"` // issue move, issue reopen
+	Priority *planEntity  `json:"priority,omitempty"` // issue priority; absent clears it
+	Labels   []planEntity `json:"labels,omitempty"`   // issue label add/remove
+	Sprint   *planEntity  `json:"sprint,omitempty"`   // sprint add/remove
+	Epic     *planEpic    `json:"epic,omitempty"`     // epic add/remove
+}
+
+type planEntity struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type planEpic struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	Type  string `json:"type"`
+
+	// Legacy epics only
+	IssueNumber int    `json:"issueNumber,omitempty"`
+	RepoName    string `json:"repoName,omitempty"`
+	RepoOwner   string `json:"repoOwner,omitempty"`
+}
+
+func (e *planEpic) result() *resolve.EpicResult {
+	return &resolve.EpicResult{
+		ID:          e.ID,
+		Title:       e.Title,
+		Type:        e.Type,
+		IssueNumber: e.IssueNumber,
+		RepoName:    e.RepoName,
+		RepoOwner:   e.RepoOwner,
+	}
+}
+
+func planEpicFrom(epic *resolve.EpicResult) *planEpic {
+	return &planEpic{
+		ID:          epic.ID,
+		Title:       epic.Title,
+		Type:        epic.Type,
+		IssueNumber: epic.IssueNumber,
+		RepoName:    epic.RepoName,
+		RepoOwner:   epic.RepoOwner,
+	}
+}
+
+// planIssue is an issue in a plan along with the state it was in when the
+// plan was made. `zh apply` compares the state with the issue's current state
+// to detect drift.
+type planIssue struct {
+	ID              string      `json:"id"`
+	Number          int         `json:"number"`
+	Title           string      `json:"title"`
+	RepoName        string      `json:"repoName"`
+	RepoOwner       string      `json:"repoOwner"`
+	RepoGhID        int         `json:"repoGhId"`
+	PipelineIssueID string      `json:"pipelineIssueId,omitempty"`
+	State           string      `json:"state"`
+	Pipeline        *planEntity `json:"pipeline"`
+	Estimate        *float64    `json:"estimate"`
+
+	Position *int        `json:"-"` // relativePosition in the pipeline, used by zh undo
+	Priority *planEntity `json:"-"` // used to record history by zh apply
+	LabelIDs []string    `json:"-"` // used to record history by zh apply
+}
+
+func (p *planIssue) Ref() string {
+	return fmt.Sprintf("%s#%d", p.RepoName, p.Number)
+}
+
+// fetchPlanIssueState fetches the current state of an issue by ZenHub ID.
+func fetchPlanIssueState(ctx context.Context, client *api.Client, workspaceID, issueID string) (*planIssue, error) {
+	data, err := client.Execute(ctx, planIssueStateQuery, map[string]any{
+		"issueId":     issueID,
+		"workspaceId": workspaceID,
+	})
+	if err != nil {
+		return nil, exitcode.General("fetching issue state", err)
+	}
+
+	var resp struct {
+		Node *struct {
+			ID       string `json:"id"`
+			Number   int    `json:"number"`
+			Title    string `json:"title"`
+			State    string `json:"state"`
+			Estimate *struct {
+				Value float64 `json:"value"`
+			} `json:"estimate"`
+			Repository struct {
+				GhID      int    `json:"ghId"`
+				Name      string `json:"name"`
+				OwnerName string `json:"ownerName"`
+			} `json:"repository"`
+			Labels struct {
+				Nodes []struct {
+					ID string `json:"id"`
+				} `json:"nodes"`
+			} `json:"labels"`
+			PipelineIssue *struct {
+				ID               string `json:"id"`
+				RelativePosition *int   `json:"relativePosition"`
+				Pipeline         struct {
+					ID   string `json:"id"`
+					Name string `json:"name"`
+				} `json:"pipeline"`
+				Priority *planEntity `json:"priority"`
+			} `json:"pipelineIssue"`
+		} `json:"node"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, exitcode.General("parsing issue state", err)
+	}
+
+	if resp.Node == nil {
+		return nil, exitcode.NotFoundError(fmt.Sprintf("issue %q not found", issueID))
+	}
+
+	issue := &planIssue{
+		ID:        resp.Node.ID,
+		Number:    resp.Node.Number,
+		Title:     resp.Node.Title,
+		RepoName:  resp.Node.Repository.Name,
+		RepoOwner: resp.Node.Repository.OwnerName,
+		RepoGhID:  resp.Node.Repository.GhID,
+		State:     strings.ToUpper(resp.Node.State),
+	}
+	if resp.Node.Estimate != nil {
+		v := resp.Node.Estimate.Value
+		issue.Estimate = &v
+	}
+	if pi := resp.Node.PipelineIssue; pi != nil {
+		issue.PipelineIssueID = pi.ID
+		issue.Position = pi.RelativePosition
+		issue.Pipeline = &planEntity{ID: pi.Pipeline.ID, Name: pi.Pipeline.Name}
+		issue.Priority = pi.Priority
+	}
+	for _, l := range resp.Node.Labels.Nodes {
+		issue.LabelIDs = append(issue.LabelIDs, l.ID)
+	}
+	return issue, nil
+}
+
+// savePlan records the current state of each issue in the plan and writes it
+// to path. It does nothing if path is empty, so callers can pass their
+// --plan-out flag straight through.
+func savePlan(ctx context.Context, client *api.Client, workspaceID string, w writerFlusher, path string, plan *mutationPlan, issueIDs []string) error {
+	if path == "" {
+		return nil
+	}
+
+	plan.Version = planVersion
+	plan.Workspace = workspaceID
+	plan.CreatedAt = time.Now().UTC().Truncate(time.Second)
+	plan.Issues = make([]planIssue, 0, len(issueIDs))
+	for _, id := range issueIDs {
+		issue, err := fetchPlanIssueState(ctx, client, workspaceID, id)
+		if err != nil {
+			return err
+		}
+		plan.Issues = append(plan.Issues, *issue)
+	}
+
+	data, err := json.MarshalIndent(plan, "", "  ")
+	if err != nil {
+		return exitcode.General("encoding plan", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return exitcode.General("writing plan", err)
+	}
+
+	if !output.IsJSON(outputFormat) {
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "Wrote plan to %s. Apply it with: zh apply %s\n", path, path)
+	}
+	return nil
+}
+
+// loadPlan reads and validates a plan file.
+func loadPlan(path string) (*mutationPlan, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, exitcode.General("reading plan", err)
+	}
+
+	var plan mutationPlan
+	if err := json.Unmarshal(data, &plan); err != nil {
+		return nil, exitcode.Usage(fmt.Sprintf("%s is not a valid plan file: %v", path, err))
+	}
+	if plan.Version != planVersion {
+		return nil, exitcode.Usage(fmt.Sprintf("%s has plan version %d — this version of zh reads version %d", path, plan.Version, planVersion))
+	}
+	if _, ok := planExecutors[plan.Command]; !ok {
+		return nil, exitcode.Usage(fmt.Sprintf("%s has unsupported command %q", path, plan.Command))
+	}
+	if field := missingPlanField(&plan); field != "" {
+		return nil, exitcode.Usage(fmt.Sprintf("%s is missing %s for %s", path, field, plan.Command))
+	}
+	return &plan, nil
+}
+
+// missingPlanField returns the name of a field the plan's command needs but
+// the plan doesn't have, or "" if it is complete. Plans may have been edited
+// by hand, so this is checked before any of them are applied.
+func missingPlanField(plan *mutationPlan) string {
+	switch plan.Command {
+	case "issue move", "issue reopen":
+		if plan.Pipeline == nil {
+			return "pipeline"
+		}
+	case "issue label add", "issue label remove":
+		if len(plan.Labels) == 0 {
+			return "labels"
+		}
+	case "sprint add", "sprint remove":
+		if plan.Sprint == nil {
+			return "sprint"
+		}
+	case "epic add", "epic remove":
+		if plan.Epic == nil {
+			return "epic"
+		}
+	}
+	return ""
+}
@@ -18,10 +18,11 @@ var priorityCmd = &cobra.Command{
 }
 
 var priorityListCmd = &cobra.Command{
-	Use:   "list",
-	Short: "List workspace priorities with their colors",
-	Long:  `List all priorities configured for the current workspace, including their colors.`,
-	RunE:  runPriorityList,
+	Use:         "list",
+	Short:       "List workspace priorities with their colors",
+	Long:        `List all priorities configured for the current workspace, including their colors.`,
+	RunE:        runPriorityList,
+	Annotations: map[string]string{tabularAnnotation: "true"},
 }
 
 func init() {
@@ -31,6 +32,8 @@ func init() {
 
 // runPriorityList implements `zh priority list`.
 func runPriorityList(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+
 	cfg, err := requireWorkspace()
 	if err != nil {
 		return err
@@ -39,7 +42,12 @@ func runPriorityList(cmd *cobra.Command, args []string) error {
 	client := newClient(cfg, cmd)
 	w := cmd.OutOrStdout()
 
-	priorities, err := resolve.FetchPriorities(client, cfg.Workspace)
+	var priorities []resolve.CachedPriority
+	if offline {
+		priorities, err = readOffline[[]resolve.CachedPriority](cmd, resolve.PriorityCacheKey(cfg.Workspace), "priorities")
+	} else {
+		priorities, err = resolve.FetchPriorities(ctx, client, cfg.Workspace)
+	}
 	if err != nil {
 		return err
 	}
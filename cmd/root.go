@@ -1,15 +1,31 @@
 package cmd
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
 	"strings"
+	"syscall"
+	"time"
 
+	"github.com/dslh/zh/internal/api"
 	"github.com/dslh/zh/internal/exitcode"
+	"github.com/dslh/zh/internal/output"
 	"github.com/spf13/cobra"
 )
 
 var (
-	verbose      bool
-	outputFormat string
+	verbose        bool
+	outputFormat   string
+	commandTimeout time.Duration
+	jsonFields     []string
+	jqExpr         string
+	templateStr    string
+
+	// cancelCommandContext releases the timeout applied by --timeout.
+	cancelCommandContext context.CancelFunc = func() {}
 )
 
 var rootCmd = &cobra.Command{
@@ -18,18 +34,41 @@ var rootCmd = &cobra.Command{
 	Long:              `zh is a command-line tool for interacting with ZenHub. Manage your board, issues, epics, sprints, and more from the terminal.`,
 	SilenceUsage:      true,
 	SilenceErrors:     true,
-	PersistentPreRunE: setupPersistentPreRun,
+	PersistentPreRunE: rootPersistentPreRun,
 	RunE:              runRoot,
 }
 
 func init() {
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Enable verbose output (log API requests/responses to stderr)")
-	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "", "Output format: json")
+	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "", "Output format: "+strings.Join(output.Formats, ", "))
+	rootCmd.PersistentFlags().DurationVar(&commandTimeout, "timeout", 0, "Abort the command if it takes longer than this (e.g. 30s, 2m)")
+	rootCmd.PersistentFlags().StringSliceVar(&jsonFields, "json", nil, "Output JSON with only the given comma-separated fields")
+	rootCmd.PersistentFlags().StringVarP(&jqExpr, "jq", "q", "", "Filter JSON output using a jq expression")
+	rootCmd.PersistentFlags().StringVarP(&templateStr, "template", "t", "", "Format JSON output using a Go template")
+	rootCmd.PersistentFlags().BoolVar(&offlineFlag, "offline", false, "Answer from the local cache without calling the API (or set ZH_OFFLINE=1)")
+	rootCmd.PersistentFlags().BoolVar(&queueFlag, "queue", false, "When offline, add changes to the outbox for 'zh outbox push' instead of refusing them")
 }
 
 func Execute() error {
-	err := rootCmd.Execute()
+	// Cancel in-flight API requests (and kill any gh subprocess) on Ctrl-C.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	defer func() { cancelCommandContext() }()
+
+	err := rootCmd.ExecuteContext(ctx)
+	if ctx.Err() != nil {
+		return exitcode.Generalf("interrupted")
+	}
+	if errors.Is(err, errQueued) {
+		return nil
+	}
+	if errors.Is(err, api.ErrOffline) {
+		return exitcode.OfflineError(err.Error())
+	}
 	if err != nil {
+		if isTimeout(err) {
+			return exitcode.Generalf("timed out after %s", commandTimeout)
+		}
 		// Cobra's built-in validators (ExactArgs, MinimumNArgs, etc.) and
 		// flag parsing errors return plain errors. Wrap them as usage errors
 		// so they exit with code 2.
@@ -40,6 +79,109 @@ func Execute() error {
 	return err
 }
 
+// rootPersistentPreRun applies --timeout to the command's context before
+// running the first-run setup check.
+func rootPersistentPreRun(cmd *cobra.Command, args []string) error {
+	cancelCommandContext()
+	cancelCommandContext = func() {}
+
+	if err := applyOutputFormat(cmd); err != nil {
+		return err
+	}
+
+	if commandTimeout < 0 {
+		return exitcode.Usage(fmt.Sprintf("invalid --timeout %s — must not be negative", commandTimeout))
+	}
+	// The bare root command only shows help or the setup wizard, so the
+	// deadline is applied to subcommands only. Deriving from the root
+	// context means a deadline from a previous execution (e.g. in tests)
+	// never leaks into this one.
+	if cmd.HasParent() {
+		ctx := commandContext(cmd.Root())
+		if commandTimeout > 0 {
+			ctx, cancelCommandContext = context.WithTimeout(ctx, commandTimeout)
+		}
+		cmd.SetContext(ctx)
+	}
+
+	if err := applyOffline(cmd, args); err != nil {
+		return err
+	}
+
+	return setupPersistentPreRun(cmd, args)
+}
+
+// tabularAnnotation marks a command whose text output is a single table
+// written with output.ListWriter, so it can also be written as csv, tsv or
+// markdown. Other commands reject those formats.
+const tabularAnnotation = "zh:tabular"
+
+// applyOutputFormat configures the output package from --output and the
+// --json, --jq and --template flags. The shaping flags imply --output=json
+// (unless yaml was asked for), so commands take their structured code path
+// and the shaping is applied to whatever they emit.
+func applyOutputFormat(cmd *cobra.Command) error {
+	if err := output.ValidateFormat(outputFormat); err != nil {
+		return exitcode.Usage(err.Error())
+	}
+	if output.IsTabular(outputFormat) && cmd.HasParent() {
+		if _, ok := cmd.Annotations[tabularAnnotation]; !ok {
+			return tabularFormatError(cmd)
+		}
+	}
+
+	shaped := len(jsonFields) > 0 || jqExpr != "" || templateStr != ""
+	if shaped {
+		if outputFormat != "" && !output.IsJSON(outputFormat) {
+			return exitcode.Usage(fmt.Sprintf("--json, --jq and --template cannot be combined with --output=%s", outputFormat))
+		}
+		if outputFormat == "" {
+			outputFormat = "json"
+		}
+	}
+	output.SetFormat(outputFormat)
+
+	err := output.SetShaping(output.Shaping{
+		Fields:   jsonFields,
+		JQ:       jqExpr,
+		Template: templateStr,
+	})
+	if err != nil {
+		return exitcode.Usage(err.Error())
+	}
+	return nil
+}
+
+// tabularFormatError is the usage error for --output=csv, tsv or markdown on
+// a command, or a mode of one, that doesn't render a single table.
+func tabularFormatError(cmd *cobra.Command) error {
+	return exitcode.Usage(fmt.Sprintf("zh %s cannot write --output=%s — csv, tsv and markdown are only supported by list views; use json or yaml",
+		strings.TrimPrefix(cmd.CommandPath(), "zh "), outputFormat))
+}
+
+func resetOutputFlags() {
+	outputFormat = ""
+	jsonFields = nil
+	jqExpr = ""
+	templateStr = ""
+	output.SetFormat(output.FormatText)
+	_ = output.SetShaping(output.Shaping{})
+}
+
+// commandContext returns the command's context, or context.Background if the
+// command was not run through Execute.
+func commandContext(cmd *cobra.Command) context.Context {
+	if cmd != nil && cmd.Context() != nil {
+		return cmd.Context()
+	}
+	return context.Background()
+}
+
+// isTimeout reports whether err was caused by the --timeout deadline.
+func isTimeout(err error) bool {
+	return errors.Is(err, context.DeadlineExceeded)
+}
+
 // isCobraUsageError returns true if the error looks like a Cobra argument
 // validation or flag parsing error.
 func isCobraUsageError(err error) bool {
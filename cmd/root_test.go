@@ -2,8 +2,14 @@ package cmd
 
 import (
 	"bytes"
+	"encoding/json"
+	"net/http"
 	"strings"
 	"testing"
+
+	"github.com/dslh/zh/internal/exitcode"
+	"github.com/dslh/zh/internal/output"
+	"github.com/dslh/zh/internal/testutil"
 )
 
 func TestRootHelp(t *testing.T) {
@@ -29,6 +35,9 @@ func TestRootHelp(t *testing.T) {
 	if !strings.Contains(out, "--output") {
 		t.Error("help output should mention --output flag")
 	}
+	if !strings.Contains(out, "--timeout") {
+		t.Error("help output should mention --timeout flag")
+	}
 }
 
 func TestVersionSubcommand(t *testing.T) {
@@ -59,3 +68,281 @@ func TestUnknownCommand(t *testing.T) {
 		t.Fatal("expected error for unknown command")
 	}
 }
+
+func TestTimeoutAbortsRequest(t *testing.T) {
+	resetIssueFlags()
+
+	ms := testutil.NewMockServer(t)
+	release := make(chan struct{})
+	ms.Handle(
+		func(req testutil.GraphQLRequest) bool { return true },
+		func(w http.ResponseWriter, req testutil.GraphQLRequest) { <-release },
+	)
+	setupIssueTestEnv(t, ms)
+	t.Cleanup(func() { close(release) })
+	defer func() { commandTimeout = 0 }()
+
+	rootCmd.SetOut(new(bytes.Buffer))
+	rootCmd.SetErr(new(bytes.Buffer))
+	rootCmd.SetArgs([]string{"issue", "list", "--timeout=50ms"})
+
+	err := rootCmd.Execute()
+	if err == nil {
+		t.Fatal("expected error when --timeout expires")
+	}
+	if !isTimeout(err) {
+		t.Errorf("error should be a timeout, got: %v", err)
+	}
+}
+
+// --- output shaping ---
+
+func TestJSONFieldsFlag(t *testing.T) {
+	ms := testutil.NewMockServer(t)
+	ms.HandleQuery("GetWorkspacePriorities", priorityListResponse())
+	setupPriorityTest(t, ms)
+	defer resetOutputFlags()
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"priority", "list", "--json", "name,color"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("priority list --json returned error: %v", err)
+	}
+
+	var result []map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+		t.Fatalf("invalid JSON: %v\nOutput: %s", err, buf.String())
+	}
+	if len(result) != 4 {
+		t.Fatalf("expected 4 priorities, got %d", len(result))
+	}
+	if len(result[0]) != 2 || result[0]["name"] != "Urgent" || result[0]["color"] != "ff5630" {
+		t.Errorf("expected only name and color, got %v", result[0])
+	}
+}
+
+func TestJSONFieldsFlagUnknownField(t *testing.T) {
+	ms := testutil.NewMockServer(t)
+	ms.HandleQuery("GetWorkspacePriorities", priorityListResponse())
+	setupPriorityTest(t, ms)
+	defer resetOutputFlags()
+
+	rootCmd.SetOut(new(bytes.Buffer))
+	rootCmd.SetArgs([]string{"priority", "list", "--json", "nmae"})
+
+	err := rootCmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), `unknown JSON field "nmae"`) || !strings.Contains(err.Error(), "name") {
+		t.Errorf("expected unknown field error listing available fields, got: %v", err)
+	}
+}
+
+func TestJQFlag(t *testing.T) {
+	ms := testutil.NewMockServer(t)
+	ms.HandleQuery("GetWorkspacePriorities", priorityListResponse())
+	setupPriorityTest(t, ms)
+	defer resetOutputFlags()
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"priority", "list", "--jq", ".[] | select(.name != \"Low\") | .name"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("priority list --jq returned error: %v", err)
+	}
+
+	if got, want := buf.String(), "Urgent\nHigh\nMedium\n"; got != want {
+		t.Errorf("--jq output = %q, want %q", got, want)
+	}
+}
+
+func TestTemplateFlag(t *testing.T) {
+	ms := testutil.NewMockServer(t)
+	ms.HandleQuery("GetWorkspacePriorities", priorityListResponse())
+	setupPriorityTest(t, ms)
+	defer resetOutputFlags()
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"priority", "list", "--template", `{{range .}}{{tablerow .name .description}}{{end}}`})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("priority list --template returned error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("expected 4 rows, got %d: %q", len(lines), buf.String())
+	}
+	if lines[0] != "Urgent    Needs immediate attention" {
+		t.Errorf("row should be column-aligned, got %q", lines[0])
+	}
+}
+
+func TestOutputShapingConflicts(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"jq and template", []string{"--jq", ".", "--template", "{{.}}"}, "cannot be used together"},
+		{"invalid jq", []string{"--jq", ".["}, "invalid --jq expression"},
+		{"invalid template", []string{"--template", "{{.name"}, "invalid --template"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms := testutil.NewMockServer(t)
+			setupPriorityTest(t, ms)
+			defer resetOutputFlags()
+
+			rootCmd.SetOut(new(bytes.Buffer))
+			rootCmd.SetArgs(append([]string{"priority", "list"}, tt.args...))
+
+			err := rootCmd.Execute()
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got: %v", tt.want, err)
+			}
+		})
+	}
+}
+
+// --- output formats ---
+
+func TestOutputFormatCSV(t *testing.T) {
+	ms := testutil.NewMockServer(t)
+	ms.HandleQuery("GetWorkspacePriorities", priorityListResponse())
+	setupPriorityTest(t, ms)
+	defer resetOutputFlags()
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"priority", "list", "--output=csv"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("priority list --output=csv returned error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	if len(lines) != 5 {
+		t.Fatalf("expected header and 4 rows with no footer, got: %q", buf.String())
+	}
+	if lines[0] != "PRIORITY,COLOR" {
+		t.Errorf("header = %q, want PRIORITY,COLOR", lines[0])
+	}
+	if !strings.HasPrefix(lines[1], "Urgent,") {
+		t.Errorf("first row = %q, want Urgent", lines[1])
+	}
+}
+
+func TestOutputFormatMarkdown(t *testing.T) {
+	ms := testutil.NewMockServer(t)
+	ms.HandleQuery("GetWorkspacePriorities", priorityListResponse())
+	setupPriorityTest(t, ms)
+	defer resetOutputFlags()
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"priority", "list", "--output=markdown"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("priority list --output=markdown returned error: %v", err)
+	}
+
+	out := buf.String()
+	if !strings.HasPrefix(out, "| PRIORITY | COLOR |\n| --- | --- |\n| Urgent |") {
+		t.Errorf("expected markdown table, got:\n%s", out)
+	}
+	if !strings.Contains(out, "Total: 4 priority(s)") {
+		t.Errorf("markdown output should keep the footer, got:\n%s", out)
+	}
+}
+
+func TestOutputFormatYAML(t *testing.T) {
+	ms := testutil.NewMockServer(t)
+	ms.HandleQuery("GetWorkspacePriorities", priorityListResponse())
+	setupPriorityTest(t, ms)
+	defer resetOutputFlags()
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"priority", "list", "--output=yaml", "--json", "name,description"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("priority list --output=yaml returned error: %v", err)
+	}
+
+	out := buf.String()
+	if !strings.HasPrefix(out, "- description: Needs immediate attention\n  name: Urgent\n") {
+		t.Errorf("expected YAML list of selected fields, got:\n%s", out)
+	}
+}
+
+func TestOutputFormatInvalid(t *testing.T) {
+	ms := testutil.NewMockServer(t)
+	setupPriorityTest(t, ms)
+	defer resetOutputFlags()
+
+	rootCmd.SetOut(new(bytes.Buffer))
+	rootCmd.SetArgs([]string{"priority", "list", "--output=xml"})
+
+	err := rootCmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), `invalid output format "xml"`) {
+		t.Errorf("expected invalid format error, got: %v", err)
+	}
+}
+
+func TestOutputFlagHelpListsFormats(t *testing.T) {
+	usage := rootCmd.PersistentFlags().Lookup("output").Usage
+	for _, f := range output.Formats {
+		if !strings.Contains(usage, f) {
+			t.Errorf("--output help %q should list %s", usage, f)
+		}
+	}
+}
+
+func TestOutputFormatShapingConflict(t *testing.T) {
+	ms := testutil.NewMockServer(t)
+	setupPriorityTest(t, ms)
+	defer resetOutputFlags()
+
+	rootCmd.SetOut(new(bytes.Buffer))
+	rootCmd.SetArgs([]string{"priority", "list", "--output=csv", "--jq", ".[]"})
+
+	err := rootCmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "cannot be combined with --output=csv") {
+		t.Errorf("expected conflict error, got: %v", err)
+	}
+}
+
+func TestOutputFormatTabularUnsupported(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"detail view", []string{"workspace", "show", "--output=csv"}},
+		{"mutation", []string{"sprint", "add", "task-tracker#1", "--output=tsv"}},
+		{"list command detail mode", []string{"history", "task-tracker#1", "--output=markdown"}},
+		{"list command mutation mode", []string{"pipeline", "alias", "Done", "done", "--output=csv"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms := testutil.NewMockServer(t)
+			setupPriorityTest(t, ms)
+			defer resetOutputFlags()
+
+			rootCmd.SetOut(new(bytes.Buffer))
+			rootCmd.SetArgs(tt.args)
+
+			err := rootCmd.Execute()
+			if err == nil || !strings.Contains(err.Error(), "cannot write --output=") {
+				t.Fatalf("expected unsupported format error, got: %v", err)
+			}
+			if exitcode.ExitCode(err) != exitcode.UsageError {
+				t.Errorf("exit code = %d, want usage error", exitcode.ExitCode(err))
+			}
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/dslh/zh/internal/exitcode"
+	"github.com/dslh/zh/internal/index"
+	"github.com/dslh/zh/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// Commands
+
+var searchCmd = &cobra.Command{
+	Use:   "search [query]",
+	Short: "Search issues in the local index",
+	Long: `Search the issues mirrored by zh sync. Searching is offline and needs no
+API calls.
+
+Every word in the query must appear in the issue's title, body, labels,
+assignees or reference. Results are ranked by relevance: matches in the
+title count for most, then labels and assignees, then mentions in the
+body. Ties go to the most recently updated issue.
+
+Run zh sync to bring the index up to date.
+
+Examples:
+  zh search "login timeout"
+  zh search "login timeout" --label=bug --pipeline=Review
+  zh search --assignee=alice --state=all`,
+	Args:        cobra.MaximumNArgs(1),
+	RunE:        runSearch,
+	Annotations: map[string]string{tabularAnnotation: "true"},
+}
+
+var (
+	searchLabel    string
+	searchPipeline string
+	searchAssignee string
+	searchRepo     string
+	searchState    string
+	searchLimit    int
+)
+
+func init() {
+	searchCmd.Flags().StringVar(&searchLabel, "label", "", "Filter by label name")
+	searchCmd.Flags().StringVar(&searchPipeline, "pipeline", "", "Filter by pipeline name")
+	searchCmd.Flags().StringVar(&searchAssignee, "assignee", "", "Filter by assignee login")
+	searchCmd.Flags().StringVar(&searchRepo, "repo", "", "Filter by repository name")
+	searchCmd.Flags().StringVar(&searchState, "state", "open", "Filter by state: open, closed or all")
+	searchCmd.Flags().IntVar(&searchLimit, "limit", 20, "Maximum number of results")
+
+	rootCmd.AddCommand(searchCmd)
+}
+
+func resetSearchFlags() {
+	searchLabel = ""
+	searchPipeline = ""
+	searchAssignee = ""
+	searchRepo = ""
+	searchState = "open"
+	searchLimit = 20
+}
+
+// runSearch implements `zh search [query]`.
+func runSearch(cmd *cobra.Command, args []string) error {
+	cfg, err := requireWorkspace()
+	if err != nil {
+		return err
+	}
+
+	w := cmd.OutOrStdout()
+
+	switch searchState {
+	case "open", "closed", "all":
+	default:
+		return exitcode.Usage(fmt.Sprintf("invalid --state %q — use open, closed or all", searchState))
+	}
+
+	idx, ok := index.Load(cfg.Workspace)
+	if !ok {
+		return exitcode.Usage("no local index for this workspace — run 'zh sync' first")
+	}
+
+	pipeline := searchPipeline
+	if target, ok := cfg.Aliases.Pipelines[pipeline]; ok {
+		pipeline = target
+	}
+
+	var candidates []index.Issue
+	for _, issue := range idx.Issues {
+		if searchMatchesFilters(issue, pipeline) {
+			candidates = append(candidates, issue)
+		}
+	}
+
+	query := ""
+	if len(args) == 1 {
+		query = args[0]
+	}
+	results := index.Search(candidates, query)
+	total := len(results)
+	if searchLimit > 0 && len(results) > searchLimit {
+		results = results[:searchLimit]
+	}
+
+	if output.IsJSON(outputFormat) {
+		type jsonResult struct {
+			index.Issue
+			Score int `json:"score"`
+		}
+		out := make([]jsonResult, len(results))
+		for i, r := range results {
+			out[i] = jsonResult{Issue: r.Issue, Score: r.Score}
+		}
+		return output.JSON(w, out)
+	}
+
+	synced := "index synced " + output.FormatTimeAgo(idx.SyncedAt)
+	if len(results) == 0 {
+		fmt.Fprintf(w, "No issues found (%s).\n", synced)
+		return nil
+	}
+
+	lw := output.NewListWriter(w, "ISSUE", "TITLE", "PIPELINE", "ASSIGNEE", "LABELS")
+	for _, r := range results {
+		issue := r.Issue
+		title := issue.Title
+		if len(title) > 50 {
+			title = title[:47] + "..."
+		}
+
+		pipeline := output.TableMissing
+		switch {
+		case issue.State == "CLOSED":
+			pipeline = "Closed"
+		case issue.Pipeline != "":
+			pipeline = issue.Pipeline
+		}
+
+		assignee := output.TableMissing
+		if len(issue.Assignees) > 0 {
+			assignee = strings.Join(issue.Assignees, ", ")
+		}
+
+		labels := output.TableMissing
+		if len(issue.Labels) > 0 {
+			labels = strings.Join(issue.Labels, ", ")
+		}
+
+		lw.Row(output.Cyan(issue.Ref()), title, pipeline, assignee, labels)
+	}
+
+	footer := fmt.Sprintf("Showing %d", len(results))
+	if total > len(results) {
+		footer += fmt.Sprintf(" of %d", total)
+	}
+	footer += fmt.Sprintf(" issue(s) · %s", synced)
+	lw.FlushWithFooter(footer)
+	return nil
+}
+
+// searchMatchesFilters reports whether an indexed issue passes the search
+// filter flags. pipeline is the --pipeline value with any alias resolved.
+func searchMatchesFilters(issue index.Issue, pipeline string) bool {
+	closed := issue.State == "CLOSED"
+	if (searchState == "open" && closed) || (searchState == "closed" && !closed) {
+		return false
+	}
+	if searchLabel != "" && !slices.ContainsFunc(issue.Labels, func(l string) bool { return strings.EqualFold(l, searchLabel) }) {
+		return false
+	}
+	if searchAssignee != "" && !slices.ContainsFunc(issue.Assignees, func(a string) bool { return strings.EqualFold(a, searchAssignee) }) {
+		return false
+	}
+	if searchRepo != "" && !strings.EqualFold(issue.RepoName, searchRepo) && !strings.EqualFold(issue.RepoOwner+"/"+issue.RepoName, searchRepo) {
+		return false
+	}
+	if pipeline != "" && issue.PipelineID != pipeline && !strings.Contains(strings.ToLower(issue.Pipeline), strings.ToLower(pipeline)) {
+		return false
+	}
+	return true
+}
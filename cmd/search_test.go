@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dslh/zh/internal/exitcode"
+	"github.com/dslh/zh/internal/index"
+)
+
+func setupSearchIndex(t *testing.T) {
+	t.Helper()
+	resetSearchFlags()
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("ZH_API_KEY", "test-key")
+	t.Setenv("ZH_WORKSPACE", "ws-123")
+
+	day := func(d int) time.Time { return time.Date(2026, 10, d, 0, 0, 0, 0, time.UTC) }
+	_ = index.Save(&index.Index{
+		WorkspaceID: "ws-123",
+		SyncedAt:    time.Now().Add(-2 * time.Hour),
+		Issues: []index.Issue{
+			{ID: "i1", Number: 1, RepoName: "api", RepoOwner: "acme", Title: "Login timeout on slow networks", State: "OPEN", PipelineID: "p2", Pipeline: "Review", Labels: []string{"bug"}, UpdatedAt: day(1)},
+			{ID: "i2", Number: 2, RepoName: "api", RepoOwner: "acme", Title: "Add SSO", Body: "The login timeout should be configurable", State: "OPEN", PipelineID: "p2", Pipeline: "Review", Labels: []string{"bug"}, UpdatedAt: day(2)},
+			{ID: "i3", Number: 3, RepoName: "web", RepoOwner: "acme", Title: "Login timeout in the app", State: "OPEN", PipelineID: "p1", Pipeline: "Backlog", Labels: []string{"bug"}, Assignees: []string{"alice"}, UpdatedAt: day(3)},
+			{ID: "i4", Number: 4, RepoName: "api", RepoOwner: "acme", Title: "Login timeout regression", State: "CLOSED", Labels: []string{"bug"}, UpdatedAt: day(4)},
+		},
+	})
+}
+
+func TestSearch(t *testing.T) {
+	setupSearchIndex(t)
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"search", "login timeout", "--label=bug", "--pipeline=review"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("search returned error: %v", err)
+	}
+
+	out := buf.String()
+	first, second := strings.Index(out, "api#1"), strings.Index(out, "api#2")
+	if first < 0 || second < 0 || first > second {
+		t.Errorf("a title match should rank above a body match, got:\n%s", out)
+	}
+	if strings.Contains(out, "web#3") || strings.Contains(out, "api#4") {
+		t.Errorf("other pipelines and closed issues should be filtered out, got:\n%s", out)
+	}
+	if !strings.Contains(out, "Showing 2 issue(s) · index synced 2h ago") {
+		t.Errorf("footer should show the index age, got:\n%s", out)
+	}
+}
+
+func TestSearchStateAndJSON(t *testing.T) {
+	setupSearchIndex(t)
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"search", "timeout", "--state=all", "--limit=2", "--output=json"})
+	defer func() { outputFormat = "" }()
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("search returned error: %v", err)
+	}
+
+	var results []struct {
+		Number int `json:"number"`
+		Score  int `json:"score"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &results); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+	// Equal scores: newest first
+	if len(results) != 2 || results[0].Number != 4 || results[1].Number != 3 {
+		t.Errorf("unexpected results: %+v", results)
+	}
+}
+
+func TestSearchErrors(t *testing.T) {
+	setupSearchIndex(t)
+
+	rootCmd.SetOut(new(bytes.Buffer))
+	rootCmd.SetArgs([]string{"search", "x", "--state=done"})
+	if err := rootCmd.Execute(); exitcode.ExitCode(err) != exitcode.UsageError {
+		t.Errorf("invalid --state should be a usage error, got %v", err)
+	}
+
+	resetSearchFlags()
+	t.Setenv("ZH_WORKSPACE", "ws-unsynced")
+	rootCmd.SetArgs([]string{"search", "x"})
+	err := rootCmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "run 'zh sync' first") {
+		t.Errorf("expected a missing index error, got %v", err)
+	}
+}
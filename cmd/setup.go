@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -59,7 +60,7 @@ func runSetup(cmd *cobra.Command, args []string) error {
 		return exitcode.General("setup requires an interactive terminal — set ZH_API_KEY and ZH_WORKSPACE environment variables for non-interactive use", nil)
 	}
 
-	m := newSetupModel()
+	m := newSetupModel(commandContext(cmd))
 	p := tea.NewProgram(m, tea.WithOutput(cmd.ErrOrStderr()))
 	finalModel, err := p.Run()
 	if err != nil {
@@ -116,6 +117,7 @@ const (
 )
 
 type setupModel struct {
+	ctx  context.Context
 	step setupStep
 	err  error
 
@@ -168,7 +170,7 @@ type githubValidatedMsg struct {
 	err error
 }
 
-func newSetupModel() setupModel {
+func newSetupModel(ctx context.Context) setupModel {
 	apiKey := textinput.New()
 	apiKey.Placeholder = "zh_xxx..."
 	apiKey.Focus()
@@ -181,6 +183,7 @@ func newSetupModel() setupModel {
 	pat.Width = 50
 
 	return setupModel{
+		ctx:           ctx,
 		step:          stepAPIKey,
 		apiKeyInput:   apiKey,
 		patInput:      pat,
@@ -342,7 +345,7 @@ func (m setupModel) updateGitHubPAT(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 func (m setupModel) validateAPIKey() tea.Msg {
 	client := apiNewFunc(m.apiKey)
-	workspaces, err := fetchAllWorkspaces(client)
+	workspaces, err := fetchAllWorkspaces(m.ctx, client)
 	if err != nil {
 		return apiKeyValidatedMsg{err: err}
 	}
@@ -521,9 +524,9 @@ func setupPersistentPreRun(cmd *cobra.Command, args []string) error {
 
 // validateAPIKeyNonInteractive validates an API key by making a test API call.
 // It's exported for use by tests of the model logic.
-func validateAPIKeyNonInteractive(apiKey string) ([]workspaceChoice, error) {
+func validateAPIKeyNonInteractive(ctx context.Context, apiKey string) ([]workspaceChoice, error) {
 	client := apiNewFunc(apiKey)
-	data, err := client.Execute(listWorkspacesQuery, nil)
+	data, err := client.Execute(ctx, listWorkspacesQuery, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"bytes"
 	"net/http"
 	"strings"
@@ -49,7 +50,7 @@ func TestValidateAPIKeySuccess(t *testing.T) {
 	}
 	defer func() { apiNewFunc = origNew }()
 
-	choices, err := validateAPIKeyNonInteractive("test-key")
+	choices, err := validateAPIKeyNonInteractive(context.Background(), "test-key")
 	if err != nil {
 		t.Fatalf("validateAPIKeyNonInteractive returned error: %v", err)
 	}
@@ -85,7 +86,7 @@ func TestValidateAPIKeyAuthFailure(t *testing.T) {
 	}
 	defer func() { apiNewFunc = origNew }()
 
-	_, err := validateAPIKeyNonInteractive("bad-key")
+	_, err := validateAPIKeyNonInteractive(context.Background(), "bad-key")
 	if err == nil {
 		t.Fatal("validateAPIKeyNonInteractive should return error for bad key")
 	}
@@ -94,7 +95,7 @@ func TestValidateAPIKeyAuthFailure(t *testing.T) {
 // --- setupModel logic ---
 
 func TestSetupModelInit(t *testing.T) {
-	m := newSetupModel()
+	m := newSetupModel(context.Background())
 	if m.step != stepAPIKey {
 		t.Errorf("initial step = %d, want stepAPIKey (%d)", m.step, stepAPIKey)
 	}
@@ -104,7 +105,7 @@ func TestSetupModelInit(t *testing.T) {
 }
 
 func TestSetupModelGitHubChoices(t *testing.T) {
-	m := newSetupModel()
+	m := newSetupModel(context.Background())
 	if len(m.githubChoices) != 3 {
 		t.Fatalf("expected 3 github choices, got %d", len(m.githubChoices))
 	}
@@ -265,7 +266,7 @@ func TestGhAuthCheckSuccess(t *testing.T) {
 	ghAuthCheckFunc = func() error { return nil }
 	defer func() { ghAuthCheckFunc = origCheck }()
 
-	m := newSetupModel()
+	m := newSetupModel(context.Background())
 	msg := m.validateGhCLI()
 	result, ok := msg.(githubValidatedMsg)
 	if !ok {
@@ -281,7 +282,7 @@ func TestGhAuthCheckFailure(t *testing.T) {
 	ghAuthCheckFunc = func() error { return &execError{} }
 	defer func() { ghAuthCheckFunc = origCheck }()
 
-	m := newSetupModel()
+	m := newSetupModel(context.Background())
 	msg := m.validateGhCLI()
 	result, ok := msg.(githubValidatedMsg)
 	if !ok {
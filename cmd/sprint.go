@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"math"
@@ -234,8 +235,9 @@ Use --state to filter by sprint state:
   --state=open     Show only open (active/upcoming) sprints
   --state=closed   Show only closed sprints
   --state=all      Show all sprints`,
-	Args: cobra.NoArgs,
-	RunE: runSprintList,
+	Args:        cobra.NoArgs,
+	RunE:        runSprintList,
+	Annotations: map[string]string{tabularAnnotation: "true"},
 }
 
 var sprintShowCmd = &cobra.Command{
@@ -288,17 +290,22 @@ func resetSprintFlags() {
 
 // runSprintList implements `zh sprint list`.
 func runSprintList(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+
 	cfg, err := requireWorkspace()
 	if err != nil {
 		return err
 	}
+	if offline {
+		return runSprintListOffline(cmd, cfg.Workspace)
+	}
 
 	client := newClient(cfg, cmd)
 	w := cmd.OutOrStdout()
 
 	limit := output.EffectiveLimit(sprintListLimit, sprintListAll)
 
-	sprints, activeID, totalCount, err := fetchSprintList(client, cfg.Workspace, limit, sprintListState)
+	sprints, activeID, totalCount, err := fetchSprintList(ctx, client, cfg.Workspace, limit, sprintListState)
 	if err != nil {
 		return err
 	}
@@ -342,7 +349,7 @@ func runSprintList(cmd *cobra.Command, args []string) error {
 
 // fetchSprintList fetches sprints from the workspace with pagination.
 // Returns the sprints, the active sprint ID, and the total count.
-func fetchSprintList(client *api.Client, workspaceID string, limit int, stateFilter string) ([]sprintListEntry, string, int, error) {
+func fetchSprintList(ctx context.Context, client *api.Client, workspaceID string, limit int, stateFilter string) ([]sprintListEntry, string, int, error) {
 	var allSprints []sprintListEntry
 	var cursor *string
 	var activeID string
@@ -384,7 +391,7 @@ func fetchSprintList(client *api.Client, workspaceID string, limit int, stateFil
 			return nil, "", 0, exitcode.Usage(fmt.Sprintf("invalid --state value %q: must be open, closed, or all", stateFilter))
 		}
 
-		data, err := client.Execute(sprintListQuery, vars)
+		data, err := client.Execute(ctx, sprintListQuery, vars)
 		if err != nil {
 			return nil, "", 0, exitcode.General("fetching sprints", err)
 		}
@@ -448,6 +455,8 @@ func cacheSprintsFromList(sprints []sprintListEntry, workspaceID string) {
 
 // runSprintShow implements `zh sprint show [sprint]`.
 func runSprintShow(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+
 	cfg, err := requireWorkspace()
 	if err != nil {
 		return err
@@ -460,7 +469,7 @@ func runSprintShow(cmd *cobra.Command, args []string) error {
 	var identifier string
 	if sprintShowInteractive {
 		identifier, err = interactiveOrArg(cmd, nil, true, func() ([]selectItem, error) {
-			return fetchSprintSelectItems(client, cfg.Workspace)
+			return fetchSprintSelectItems(ctx, client, cfg.Workspace)
 		}, "Select a sprint")
 		if err != nil {
 			return err
@@ -473,13 +482,13 @@ func runSprintShow(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	resolved, err := resolve.Sprint(client, cfg.Workspace, identifier)
+	resolved, err := resolve.Sprint(ctx, client, cfg.Workspace, identifier)
 	if err != nil {
 		return err
 	}
 
 	// Fetch sprint detail
-	data, err := client.Execute(sprintShowQuery, map[string]any{
+	data, err := client.Execute(ctx, sprintShowQuery, map[string]any{
 		"sprintId": resolved.ID,
 	})
 	if err != nil {
@@ -510,7 +519,7 @@ func runSprintShow(cmd *cobra.Command, args []string) error {
 				"after":    cursor,
 			}
 
-			pageData, err := client.Execute(sprintShowIssuesPageQuery, pageVars)
+			pageData, err := client.Execute(ctx, sprintShowIssuesPageQuery, pageVars)
 			if err != nil {
 				break // partial data is better than no data
 			}
@@ -550,8 +559,8 @@ func runSprintShow(cmd *cobra.Command, args []string) error {
 }
 
 // fetchSprintSelectItems fetches sprints and converts them to selectItems for interactive mode.
-func fetchSprintSelectItems(client *api.Client, workspaceID string) ([]selectItem, error) {
-	sprints, activeID, _, err := fetchSprintList(client, workspaceID, 0, "")
+func fetchSprintSelectItems(ctx context.Context, client *api.Client, workspaceID string) ([]selectItem, error) {
+	sprints, activeID, _, err := fetchSprintList(ctx, client, workspaceID, 0, "")
 	if err != nil {
 		return nil, err
 	}
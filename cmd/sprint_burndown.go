@@ -0,0 +1,425 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/dslh/zh/internal/exitcode"
+	"github.com/dslh/zh/internal/gh"
+	"github.com/dslh/zh/internal/output"
+	"github.com/dslh/zh/internal/resolve"
+	"github.com/spf13/cobra"
+)
+
+// GitHub query for the close history of one issue
+
+const burndownCloseEventsQuery = `query BurndownCloseEvents($owner: String!, $repo: String!, $number: Int!) {
+  repository(owner: $owner, name: $repo) {
+    issueOrPullRequest(number: $number) {
+      ... on Issue {
+        timelineItems(first: 100, itemTypes: [CLOSED_EVENT, REOPENED_EVENT]) {
+          nodes {
+            __typename
+            ... on ClosedEvent { createdAt }
+            ... on ReopenedEvent { createdAt }
+          }
+        }
+      }
+      ... on PullRequest {
+        timelineItems(first: 100, itemTypes: [CLOSED_EVENT, REOPENED_EVENT]) {
+          nodes {
+            __typename
+            ... on ClosedEvent { createdAt }
+            ... on ReopenedEvent { createdAt }
+          }
+        }
+      }
+    }
+  }
+}`
+
+// burndownTimelineConcurrency caps the GitHub timeline requests in flight.
+const burndownTimelineConcurrency = 8
+
+// Command
+
+var sprintBurndownCmd = &cobra.Command{
+	Use:   "burndown [sprint]",
+	Short: "Chart a sprint's remaining points day by day",
+	Long: `Chart the points remaining in a sprint for each day since it started,
+against an ideal line from the starting scope down to zero at the sprint's
+end. Defaults to the active sprint.
+
+Daily scope is rebuilt from the sprint's scope changes, using each issue's
+estimate when it was added. An issue counts as done from the day it was
+closed. Close and reopen times come from GitHub issue timelines when GitHub
+access is configured, and from ZenHub's last close time otherwise.
+
+Use --burnup to chart completed points against the sprint's scope instead.
+JSON output is the daily series, for use in dashboards.
+
+The sprint can be specified as:
+  - ZenHub ID
+  - sprint name or unique name substring
+  - relative reference: current, next, previous
+
+Examples:
+  zh sprint burndown
+  zh sprint burndown previous --burnup
+  zh sprint burndown --output=json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runSprintBurndown,
+}
+
+var (
+	burndownBurnup bool
+	burndownHeight int
+)
+
+func init() {
+	sprintBurndownCmd.Flags().BoolVar(&burndownBurnup, "burnup", false, "Chart completed points and scope instead of remaining points")
+	sprintBurndownCmd.Flags().IntVar(&burndownHeight, "height", 12, "Height of the chart in lines")
+
+	sprintCmd.AddCommand(sprintBurndownCmd)
+}
+
+func resetSprintBurndownFlags() {
+	burndownBurnup = false
+	burndownHeight = 12
+}
+
+// burndownPoint is the state of a sprint at one point in time. The first
+// point is the sprint's start; each later point is the end of a day, or now
+// for today. Days that haven't happened yet have only an ideal value.
+type burndownPoint struct {
+	Date      string    `json:"date"`
+	At        time.Time `json:"at"`
+	Scope     *float64  `json:"scope"`
+	Completed *float64  `json:"completed"`
+	Remaining *float64  `json:"remaining"`
+	Ideal     float64   `json:"ideal"`
+}
+
+// burndownCloseEvent is an issue being closed or reopened.
+type burndownCloseEvent struct {
+	At     time.Time
+	Closed bool
+}
+
+// runSprintBurndown implements `zh sprint burndown [sprint]`.
+func runSprintBurndown(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+
+	cfg, err := requireWorkspace()
+	if err != nil {
+		return err
+	}
+
+	client := newClient(cfg, cmd)
+	w := cmd.OutOrStdout()
+
+	identifier := "current"
+	if len(args) > 0 {
+		identifier = args[0]
+	}
+
+	resolved, err := resolve.Sprint(ctx, client, cfg.Workspace, identifier)
+	if err != nil {
+		return err
+	}
+
+	events, sprint, _, err := fetchScopeChanges(ctx, client, resolved.ID, 0)
+	if err != nil {
+		return err
+	}
+
+	start, startErr := time.Parse(time.RFC3339, sprint.StartAt)
+	end, endErr := time.Parse(time.RFC3339, sprint.EndAt)
+	if startErr != nil || endErr != nil || !end.After(start) {
+		return exitcode.Generalf("sprint %q has no valid start and end dates", sprint.DisplayName())
+	}
+
+	ghClient := newGitHubClient(cfg, cmd)
+	closes := fetchBurndownCloses(ctx, ghClient, events)
+	source := "zenhub"
+	if ghClient != nil {
+		source = "github"
+	}
+
+	points := computeBurndown(events, closes, start.Local(), end.Local(), time.Now())
+
+	if output.IsJSON(outputFormat) {
+		return output.JSON(w, map[string]any{
+			"sprint": map[string]any{
+				"id":      sprint.ID,
+				"name":    sprint.DisplayName(),
+				"state":   sprint.State,
+				"startAt": sprint.StartAt,
+				"endAt":   sprint.EndAt,
+			},
+			"closeEventsSource": source,
+			"points":            points,
+		})
+	}
+
+	title := "BURNDOWN"
+	if burndownBurnup {
+		title = "BURNUP"
+	}
+	d := output.NewDetailWriter(w, title, sprint.DisplayName())
+
+	if len(events) == 0 {
+		d.Fields([]output.KeyValue{output.KV("Dates", formatSprintDates(sprint.StartAt, sprint.EndAt))})
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "No issues have been added to this sprint.")
+		return nil
+	}
+
+	latest := points[0]
+	for _, p := range points {
+		if p.Remaining != nil {
+			latest = p
+		}
+	}
+	initial := *points[0].Scope
+	d.Fields([]output.KeyValue{
+		output.KV("Dates", formatSprintDates(sprint.StartAt, sprint.EndAt)),
+		output.KV("Scope", fmt.Sprintf("%s pts (%s at start)", formatEstimate(*latest.Scope), formatEstimate(initial))),
+		output.KV("Completed", fmt.Sprintf("%s pts", formatEstimate(*latest.Completed))),
+		output.KV("Remaining", fmt.Sprintf("%s pts", formatEstimate(*latest.Remaining))),
+		output.KV("Pace", formatBurndownPace(*latest.Remaining, latest.Ideal)),
+	})
+
+	d.Section("CHART")
+	fmt.Fprintln(w)
+	xStart := points[0].At.Format("Jan 2")
+	xEnd := end.Local().Add(-time.Nanosecond).Format("Jan 2")
+	if burndownBurnup {
+		output.LineChart(w, burndownHeight, xStart, xEnd,
+			output.ChartSeries{Name: "Completed", Mark: "●", Color: output.Green, Values: burndownValues(points, func(p burndownPoint) float64 { return *p.Completed })},
+			output.ChartSeries{Name: "Scope", Mark: "━", Color: output.Yellow, Values: burndownValues(points, func(p burndownPoint) float64 { return *p.Scope })},
+			output.ChartSeries{Name: "Ideal", Mark: "·", Color: output.Dim, Values: burndownIdeal(points, func(ideal float64) float64 { return initial - ideal })},
+		)
+	} else {
+		output.LineChart(w, burndownHeight, xStart, xEnd,
+			output.ChartSeries{Name: "Remaining", Mark: "●", Color: output.Cyan, Values: burndownValues(points, func(p burndownPoint) float64 { return *p.Remaining })},
+			output.ChartSeries{Name: "Ideal", Mark: "·", Color: output.Dim, Values: burndownIdeal(points, func(ideal float64) float64 { return ideal })},
+		)
+	}
+
+	if source == "zenhub" {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, output.Dim("Close times are from ZenHub. Configure GitHub access to account for reopened issues."))
+	}
+	return nil
+}
+
+// computeBurndown rebuilds the sprint's scope and completed points at its
+// start and at the end of each day until it ends. Points after now have
+// only an ideal value.
+func computeBurndown(events []scopeChangeEvent, closes map[string][]burndownCloseEvent, start, end, now time.Time) []burndownPoint {
+	events = slices.Clone(events)
+	slices.SortStableFunc(events, func(a, b scopeChangeEvent) int {
+		return compareRFC3339(a.EffectiveAt, b.EffectiveAt)
+	})
+
+	stateAt := func(t time.Time) (scope, completed float64) {
+		inScope := map[string]float64{}
+		for _, e := range events {
+			at, err := time.Parse(time.RFC3339, e.EffectiveAt)
+			if err != nil || at.After(t) {
+				continue
+			}
+			switch e.Action {
+			case "ISSUE_ADDED":
+				pts := 0.0
+				if e.EstimateValue != nil {
+					pts = *e.EstimateValue
+				}
+				inScope[e.Issue.ID] = pts
+			case "ISSUE_REMOVED":
+				delete(inScope, e.Issue.ID)
+			}
+		}
+		for id, pts := range inScope {
+			scope += pts
+			closed := false
+			for _, c := range closes[id] {
+				if !c.At.After(t) {
+					closed = c.Closed
+				}
+			}
+			if closed {
+				completed += pts
+			}
+		}
+		return scope, completed
+	}
+
+	firstDay := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, start.Location())
+	lastEnd := end.Add(-time.Nanosecond)
+	lastDay := time.Date(lastEnd.Year(), lastEnd.Month(), lastEnd.Day(), 0, 0, 0, 0, start.Location())
+	days := 1
+	for day := firstDay; day.Before(lastDay); day = day.AddDate(0, 0, 1) {
+		days++
+	}
+
+	point := func(t time.Time, date time.Time) burndownPoint {
+		scope, completed := stateAt(t)
+		remaining := scope - completed
+		return burndownPoint{Date: output.FormatDateISO(date), At: t, Scope: &scope, Completed: &completed, Remaining: &remaining}
+	}
+
+	points := []burndownPoint{point(start, start)}
+	for i := range days {
+		day := firstDay.AddDate(0, 0, i)
+		t := day.AddDate(0, 0, 1).Add(-time.Nanosecond)
+		if t.After(end) {
+			t = end
+		}
+		switch {
+		case day.After(now):
+			points = append(points, burndownPoint{Date: output.FormatDateISO(day), At: t})
+		case t.After(now):
+			points = append(points, point(now, day))
+		default:
+			points = append(points, point(t, day))
+		}
+	}
+
+	initial := *points[0].Scope
+	for i := range points {
+		points[i].Ideal = initial * float64(days-i) / float64(days)
+	}
+	return points
+}
+
+// fetchBurndownCloses returns the close history of each closed issue in the
+// scope changes, keyed by issue ID. Histories come from GitHub timelines when
+// a GitHub client is available, falling back to the issue's last close time
+// from ZenHub.
+func fetchBurndownCloses(ctx context.Context, ghClient *gh.Client, events []scopeChangeEvent) map[string][]burndownCloseEvent {
+	seen := map[string]bool{}
+	var closed []scopeChangeEvent
+	for _, e := range events {
+		if e.Issue.State == "CLOSED" && !seen[e.Issue.ID] {
+			seen[e.Issue.ID] = true
+			closed = append(closed, e)
+		}
+	}
+
+	histories := make([][]burndownCloseEvent, len(closed))
+	if ghClient != nil {
+		var wg sync.WaitGroup
+		sem := make(chan struct{}, burndownTimelineConcurrency)
+		for i, e := range closed {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				sem <- struct{}{}
+				defer func() { <-sem }()
+				histories[i] = fetchGitHubCloseEvents(ctx, ghClient, e.Issue.Repository.OwnerName, e.Issue.Repository.Name, e.Issue.Number)
+			}()
+		}
+		wg.Wait()
+	}
+
+	closes := map[string][]burndownCloseEvent{}
+	for i, e := range closed {
+		history := histories[i]
+		if len(history) == 0 {
+			if at, err := time.Parse(time.RFC3339, e.Issue.ClosedAt); err == nil {
+				history = []burndownCloseEvent{{At: at, Closed: true}}
+			}
+		}
+		closes[e.Issue.ID] = history
+	}
+	return closes
+}
+
+// fetchGitHubCloseEvents fetches an issue's close and reopen events, oldest
+// first. Errors are ignored and return no events, so that the caller can
+// fall back to ZenHub's close time.
+func fetchGitHubCloseEvents(ctx context.Context, ghClient *gh.Client, owner, repo string, number int) []burndownCloseEvent {
+	data, err := ghClient.Execute(ctx, burndownCloseEventsQuery, map[string]any{
+		"owner":  owner,
+		"repo":   repo,
+		"number": number,
+	})
+	if err != nil {
+		return nil
+	}
+
+	var resp struct {
+		Repository *struct {
+			IssueOrPullRequest *struct {
+				TimelineItems struct {
+					Nodes []struct {
+						TypeName  string `json:"__typename"`
+						CreatedAt string `json:"createdAt"`
+					} `json:"nodes"`
+				} `json:"timelineItems"`
+			} `json:"issueOrPullRequest"`
+		} `json:"repository"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil || resp.Repository == nil || resp.Repository.IssueOrPullRequest == nil {
+		return nil
+	}
+
+	var history []burndownCloseEvent
+	for _, n := range resp.Repository.IssueOrPullRequest.TimelineItems.Nodes {
+		at, err := time.Parse(time.RFC3339, n.CreatedAt)
+		if err != nil {
+			continue
+		}
+		history = append(history, burndownCloseEvent{At: at, Closed: n.TypeName == "ClosedEvent"})
+	}
+	return history
+}
+
+// compareRFC3339 orders two RFC3339 timestamps, placing unparseable ones first.
+func compareRFC3339(a, b string) int {
+	ta, _ := time.Parse(time.RFC3339, a)
+	tb, _ := time.Parse(time.RFC3339, b)
+	return ta.Compare(tb)
+}
+
+// burndownValues extracts one series from the points, leaving future days
+// blank.
+func burndownValues(points []burndownPoint, value func(burndownPoint) float64) []float64 {
+	values := make([]float64, len(points))
+	for i, p := range points {
+		values[i] = math.NaN()
+		if p.Remaining != nil {
+			values[i] = value(p)
+		}
+	}
+	return values
+}
+
+// burndownIdeal extracts the ideal line from the points.
+func burndownIdeal(points []burndownPoint, value func(float64) float64) []float64 {
+	values := make([]float64, len(points))
+	for i, p := range points {
+		values[i] = value(p.Ideal)
+	}
+	return values
+}
+
+// formatBurndownPace compares the points remaining with the ideal line.
+func formatBurndownPace(remaining, ideal float64) string {
+	diff := remaining - ideal
+	switch {
+	case math.Abs(diff) < 0.5:
+		return "on track"
+	case diff < 0:
+		return output.Green(fmt.Sprintf("%s pts ahead of the ideal line", formatEstimate(-diff)))
+	default:
+		return output.Yellow(fmt.Sprintf("%s pts behind the ideal line", formatEstimate(diff)))
+	}
+}
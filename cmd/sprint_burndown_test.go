@@ -0,0 +1,206 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/dslh/zh/internal/api"
+	"github.com/dslh/zh/internal/gh"
+	"github.com/dslh/zh/internal/testutil"
+)
+
+func setupBurndownTestEnv(t *testing.T, ms *testutil.MockServer) {
+	t.Helper()
+	resetSprintFlags()
+	resetSprintBurndownFlags()
+
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("ZH_API_KEY", "test-key")
+	t.Setenv("ZH_WORKSPACE", "ws-123")
+	t.Setenv("ZH_GITHUB_TOKEN", "")
+
+	origNew := apiNewFunc
+	apiNewFunc = func(apiKey string, opts ...api.Option) *api.Client {
+		return api.New(apiKey, append(opts, api.WithEndpoint(ms.URL()))...)
+	}
+	t.Cleanup(func() { apiNewFunc = origNew })
+}
+
+func runBurndownJSON(t *testing.T, args ...string) map[string]any {
+	t.Helper()
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs(append([]string{"sprint", "burndown", "Sprint 47", "--output=json"}, args...))
+	defer func() { outputFormat = "" }()
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("sprint burndown returned error: %v", err)
+	}
+
+	var result map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+	return result
+}
+
+// burndownPointOn returns the point for the end of the given day.
+func burndownPointOn(t *testing.T, result map[string]any, date string) map[string]any {
+	t.Helper()
+	points := result["points"].([]any)
+	for _, p := range points[1:] {
+		point := p.(map[string]any)
+		if point["date"] == date {
+			return point
+		}
+	}
+	t.Fatalf("no point for %s", date)
+	return nil
+}
+
+func TestSprintBurndown(t *testing.T) {
+	ms := testutil.NewMockServer(t)
+	ms.HandleQuery("ListSprints", sprintResolutionResponse())
+	ms.HandleQuery("SprintScopeChange", sprintScopeResponse())
+	setupBurndownTestEnv(t, ms)
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"sprint", "burndown", "Sprint 47"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("sprint burndown returned error: %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{
+		"BURNDOWN: Sprint 47",
+		"Scope:  8 pts (8 at start)",
+		"Remaining:  0 pts",
+		"Jan 20",
+		"Feb 2",
+		"● Remaining",
+		"· Ideal",
+		"Close times are from ZenHub",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output should contain %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestSprintBurndownJSON(t *testing.T) {
+	ms := testutil.NewMockServer(t)
+	ms.HandleQuery("ListSprints", sprintResolutionResponse())
+	ms.HandleQuery("SprintScopeChange", sprintScopeResponse())
+	setupBurndownTestEnv(t, ms)
+
+	result := runBurndownJSON(t)
+
+	if result["closeEventsSource"] != "zenhub" {
+		t.Errorf("closeEventsSource = %v, want zenhub", result["closeEventsSource"])
+	}
+
+	// Start, then one point per day from Jan 20 to Feb 2
+	points := result["points"].([]any)
+	if len(points) != 15 {
+		t.Fatalf("expected 15 points, got %d", len(points))
+	}
+	first := points[0].(map[string]any)
+	if first["scope"] != float64(8) || first["remaining"] != float64(8) || first["ideal"] != float64(8) {
+		t.Errorf("unexpected start point: %v", first)
+	}
+
+	tests := []struct {
+		date      string
+		scope     float64
+		remaining float64
+	}{
+		{"2026-01-21", 8, 8},
+		{"2026-01-22", 8, 3},   // issue-1 closed
+		{"2026-01-25", 16, 11}, // issue-3 added
+		{"2026-01-27", 16, 8},  // issue-2 closed
+		{"2026-01-28", 8, 0},   // issue-3 removed
+		{"2026-02-02", 8, 0},
+	}
+	for _, tt := range tests {
+		p := burndownPointOn(t, result, tt.date)
+		if p["scope"] != tt.scope || p["remaining"] != tt.remaining {
+			t.Errorf("%s: scope=%v remaining=%v, want %v and %v", tt.date, p["scope"], p["remaining"], tt.scope, tt.remaining)
+		}
+	}
+
+	last := points[len(points)-1].(map[string]any)
+	if last["ideal"] != float64(0) {
+		t.Errorf("ideal should reach zero at the end, got %v", last["ideal"])
+	}
+}
+
+func TestSprintBurndownGitHubCloseEvents(t *testing.T) {
+	ms := testutil.NewMockServer(t)
+	ms.HandleQuery("ListSprints", sprintResolutionResponse())
+	ms.HandleQuery("SprintScopeChange", sprintScopeResponse())
+	setupBurndownTestEnv(t, ms)
+
+	// On GitHub, issue-1 was closed on Jan 21, reopened on Jan 23 and closed
+	// again on Jan 26. issue-2 has no GitHub history, so its ZenHub close
+	// time is used.
+	issue1Timeline, _ := json.Marshal(map[string]any{"data": map[string]any{"repository": map[string]any{"issueOrPullRequest": map[string]any{
+		"timelineItems": map[string]any{"nodes": []any{
+			map[string]any{"__typename": "ClosedEvent", "createdAt": "2026-01-21T10:00:00Z"},
+			map[string]any{"__typename": "ReopenedEvent", "createdAt": "2026-01-23T10:00:00Z"},
+			map[string]any{"__typename": "ClosedEvent", "createdAt": "2026-01-26T10:00:00Z"},
+		}},
+	}}}})
+
+	ghMs := testutil.NewMockServer(t)
+	ghMs.Handle(
+		func(req testutil.GraphQLRequest) bool {
+			if !strings.Contains(req.Query, "BurndownCloseEvents") {
+				return false
+			}
+			var vars map[string]any
+			_ = json.Unmarshal(req.Variables, &vars)
+			num, _ := vars["number"].(float64)
+			return int(num) == 1
+		},
+		func(w http.ResponseWriter, _ testutil.GraphQLRequest) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write(issue1Timeline)
+		},
+	)
+	ghMs.HandleQuery("BurndownCloseEvents", map[string]any{"data": map[string]any{"repository": map[string]any{"issueOrPullRequest": map[string]any{
+		"timelineItems": map[string]any{"nodes": []any{}},
+	}}}})
+
+	origGh := ghNewFunc
+	ghNewFunc = func(method, token string, opts ...gh.Option) *gh.Client {
+		return gh.New("pat", "test-token", append(opts, gh.WithEndpoint(ghMs.URL()))...)
+	}
+	t.Cleanup(func() { ghNewFunc = origGh })
+
+	result := runBurndownJSON(t)
+
+	if result["closeEventsSource"] != "github" {
+		t.Errorf("closeEventsSource = %v, want github", result["closeEventsSource"])
+	}
+	tests := []struct {
+		date      string
+		remaining float64
+	}{
+		{"2026-01-21", 3},
+		{"2026-01-23", 8},  // issue-1 reopened
+		{"2026-01-26", 11}, // issue-1 closed again, issue-3 added
+		{"2026-01-27", 8},  // issue-2 closed
+	}
+	for _, tt := range tests {
+		p := burndownPointOn(t, result, tt.date)
+		if p["remaining"] != tt.remaining {
+			t.Errorf("%s: remaining=%v, want %v", tt.date, p["remaining"], tt.remaining)
+		}
+	}
+}
@@ -0,0 +1,477 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dslh/zh/internal/api"
+	"github.com/dslh/zh/internal/cache"
+	"github.com/dslh/zh/internal/exitcode"
+	"github.com/dslh/zh/internal/output"
+	"github.com/dslh/zh/internal/resolve"
+	"github.com/spf13/cobra"
+)
+
+// GraphQL queries and mutations for the workspace sprint configuration
+
+const sprintConfigFields = `id
+      name
+      kind
+      period
+      startDay
+      endDay
+      tzIdentifier
+      settings {
+        moveUnfinishedIssues
+      }`
+
+const sprintConfigQuery = `query GetSprintConfig($workspaceId: ID!) {
+  workspace(id: $workspaceId) {
+    id
+    displayName
+    sprintConfig {
+      ` + sprintConfigFields + `
+    }
+  }
+}`
+
+const createSprintConfigMutation = `mutation CreateSprintConfig($input: CreateSprintConfigInput!) {
+  createSprintConfig(input: $input) {
+    sprintConfig {
+      ` + sprintConfigFields + `
+    }
+  }
+}`
+
+const updateSprintConfigMutation = `mutation UpdateSprintConfig($input: UpdateSprintConfigInput!) {
+  updateSprintConfig(input: $input) {
+    sprintConfig {
+      ` + sprintConfigFields + `
+    }
+  }
+}`
+
+// sprintConfigDays are the days of the week accepted by the sprint
+// configuration, in the API's enum form.
+var sprintConfigDays = []string{"SUNDAY", "MONDAY", "TUESDAY", "WEDNESDAY", "THURSDAY", "FRIDAY", "SATURDAY"}
+
+// Commands
+
+var sprintConfigCmd = &cobra.Command{
+	Use:   "config",
+	Short: "View and change the workspace sprint configuration",
+	Long:  `View and change how often sprints are generated for the workspace, which days they start and end on, and whether unfinished issues move to the next sprint automatically.`,
+}
+
+var sprintConfigShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show the workspace sprint configuration",
+	Args:  cobra.NoArgs,
+	RunE:  runSprintConfigShow,
+}
+
+var sprintConfigSetCmd = &cobra.Command{
+	Use:   "set",
+	Short: "Change the workspace sprint configuration",
+	Long: `Change the sprint cadence, schedule, timezone or auto-rollover setting
+for the workspace. Only the settings given as flags are changed.
+
+If sprints are not configured for the workspace yet, the configuration is
+created. That requires --period, --start-day and --tz. The end day defaults
+to the day before the start day.
+
+ZenHub generates upcoming sprints from this configuration, so changing it
+affects sprints that haven't started yet.
+
+Examples:
+  zh sprint config set --period=2w --start-day=monday --end-day=friday
+  zh sprint config set --tz=Europe/Berlin
+  zh sprint config set --auto-rollover=false --dry-run`,
+	Args: cobra.NoArgs,
+	RunE: runSprintConfigSet,
+}
+
+var (
+	sprintConfigSetPeriod       string
+	sprintConfigSetStartDay     string
+	sprintConfigSetEndDay       string
+	sprintConfigSetTz           string
+	sprintConfigSetAutoRollover bool
+	sprintConfigSetDryRun       bool
+)
+
+func init() {
+	sprintConfigSetCmd.Flags().StringVar(&sprintConfigSetPeriod, "period", "", "Sprint length in weeks, e.g. 2w")
+	sprintConfigSetCmd.Flags().StringVar(&sprintConfigSetStartDay, "start-day", "", "Day of the week sprints start on")
+	sprintConfigSetCmd.Flags().StringVar(&sprintConfigSetEndDay, "end-day", "", "Day of the week sprints end on")
+	sprintConfigSetCmd.Flags().StringVar(&sprintConfigSetTz, "tz", "", "IANA timezone for sprint boundaries, e.g. America/New_York")
+	sprintConfigSetCmd.Flags().BoolVar(&sprintConfigSetAutoRollover, "auto-rollover", false, "Move unfinished issues to the next sprint when a sprint ends")
+	sprintConfigSetCmd.Flags().BoolVar(&sprintConfigSetDryRun, "dry-run", false, "Show what would be changed without executing")
+
+	sprintConfigCmd.AddCommand(sprintConfigShowCmd)
+	sprintConfigCmd.AddCommand(sprintConfigSetCmd)
+	sprintCmd.AddCommand(sprintConfigCmd)
+}
+
+func resetSprintConfigFlags() {
+	// --auto-rollover is only applied when given, so Changed must be reset too
+	resetFlagDefaults(sprintConfigSetCmd.Flags())
+	sprintConfigSetPeriod = ""
+	sprintConfigSetStartDay = ""
+	sprintConfigSetEndDay = ""
+	sprintConfigSetTz = ""
+	sprintConfigSetAutoRollover = false
+	sprintConfigSetDryRun = false
+}
+
+type sprintConfigSettings struct {
+	MoveUnfinishedIssues bool `json:"moveUnfinishedIssues"`
+}
+
+// sprintConfigDetail is a sprint configuration with its settings.
+type sprintConfigDetail struct {
+	sprintConfigNode
+	Settings sprintConfigSettings `json:"settings"`
+}
+
+// fetchSprintConfig returns the workspace name and its sprint configuration,
+// which is nil if sprints are not configured.
+func fetchSprintConfig(ctx context.Context, client *api.Client, workspaceID string) (string, *sprintConfigDetail, error) {
+	data, err := client.Execute(ctx, sprintConfigQuery, map[string]any{
+		"workspaceId": workspaceID,
+	})
+	if err != nil {
+		return "", nil, exitcode.General("fetching sprint configuration", err)
+	}
+
+	var resp struct {
+		Workspace struct {
+			DisplayName  string              `json:"displayName"`
+			SprintConfig *sprintConfigDetail `json:"sprintConfig"`
+		} `json:"workspace"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return "", nil, exitcode.General("parsing sprint configuration", err)
+	}
+
+	return resp.Workspace.DisplayName, resp.Workspace.SprintConfig, nil
+}
+
+// ── sprint config show ───────────────────────────────────────────────────
+
+func runSprintConfigShow(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+
+	cfg, err := requireWorkspace()
+	if err != nil {
+		return err
+	}
+
+	client := newClient(cfg, cmd)
+	w := cmd.OutOrStdout()
+
+	name, sc, err := fetchSprintConfig(ctx, client, cfg.Workspace)
+	if err != nil {
+		return err
+	}
+
+	if output.IsJSON(outputFormat) {
+		return output.JSON(w, sc)
+	}
+
+	if sc == nil {
+		fmt.Fprintln(w, "Sprints are not configured for this workspace.")
+		fmt.Fprintln(w, output.Dim("Set them up with 'zh sprint config set --period=2w --start-day=monday --tz=<timezone>'."))
+		return nil
+	}
+
+	d := output.NewDetailWriter(w, "SPRINT CONFIG", name)
+	d.Fields([]output.KeyValue{
+		output.KV("Name", sc.Name),
+		output.KV("Cadence", formatSprintCadence(sc.Period, formatDay(sc.StartDay), formatDay(sc.EndDay))),
+		output.KV("Timezone", sc.TzIdentifier),
+		output.KV("Auto-rollover", formatOnOff(sc.Settings.MoveUnfinishedIssues)),
+	})
+
+	return nil
+}
+
+// ── sprint config set ────────────────────────────────────────────────────
+
+// sprintConfigInput is the sprint configuration sent to the create and
+// update mutations.
+type sprintConfigInput struct {
+	Name         string               `json:"name"`
+	Kind         string               `json:"kind"`
+	Period       int                  `json:"period"`
+	StartDay     string               `json:"startDay"`
+	EndDay       string               `json:"endDay"`
+	TzIdentifier string               `json:"tzIdentifier"`
+	Settings     sprintConfigSettings `json:"settings"`
+}
+
+func runSprintConfigSet(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+
+	flags := cmd.Flags()
+	hasPeriod := flags.Changed("period")
+	hasStartDay := flags.Changed("start-day")
+	hasEndDay := flags.Changed("end-day")
+	hasTz := flags.Changed("tz")
+	hasAutoRollover := flags.Changed("auto-rollover")
+
+	if !hasPeriod && !hasStartDay && !hasEndDay && !hasTz && !hasAutoRollover {
+		return exitcode.Usage("no changes specified — use --period, --start-day, --end-day, --tz, or --auto-rollover")
+	}
+
+	// Validate the flags before making any requests
+	var period int
+	var startDay, endDay string
+	var err error
+	if hasPeriod {
+		if period, err = parseSprintPeriod(sprintConfigSetPeriod); err != nil {
+			return err
+		}
+	}
+	if hasStartDay {
+		if startDay, err = parseSprintDay(sprintConfigSetStartDay); err != nil {
+			return err
+		}
+	}
+	if hasEndDay {
+		if endDay, err = parseSprintDay(sprintConfigSetEndDay); err != nil {
+			return err
+		}
+	}
+	if hasTz {
+		// LoadLocation accepts "" and "Local", which ZenHub can't store
+		if _, err := time.LoadLocation(sprintConfigSetTz); err != nil || sprintConfigSetTz == "" || sprintConfigSetTz == "Local" {
+			return exitcode.Usage(fmt.Sprintf("invalid timezone %q — use an IANA name like America/New_York", sprintConfigSetTz))
+		}
+	}
+
+	cfg, err := requireWorkspace()
+	if err != nil {
+		return err
+	}
+
+	client := newClient(cfg, cmd)
+	w := cmd.OutOrStdout()
+
+	_, current, err := fetchSprintConfig(ctx, client, cfg.Workspace)
+	if err != nil {
+		return err
+	}
+
+	var input sprintConfigInput
+	if current != nil {
+		input = sprintConfigInput{
+			Name:         current.Name,
+			Kind:         current.Kind,
+			Period:       current.Period,
+			StartDay:     current.StartDay,
+			EndDay:       current.EndDay,
+			TzIdentifier: current.TzIdentifier,
+			Settings:     current.Settings,
+		}
+	} else {
+		if !hasPeriod || !hasStartDay || !hasTz {
+			return exitcode.Usage("sprints are not configured for this workspace — --period, --start-day and --tz are required to set them up")
+		}
+		input = sprintConfigInput{Name: "Sprint", Kind: "weekly"}
+	}
+
+	if hasPeriod {
+		input.Period = period
+		// --period is in weeks, so a monthly cadence becomes weekly
+		if strings.EqualFold(input.Kind, "monthly") {
+			input.Kind = "weekly"
+		}
+	}
+	if hasStartDay {
+		input.StartDay = startDay
+		if current == nil && !hasEndDay {
+			input.EndDay = dayBefore(startDay)
+		}
+	}
+	if hasEndDay {
+		input.EndDay = endDay
+	}
+	if hasTz {
+		input.TzIdentifier = sprintConfigSetTz
+	}
+	if hasAutoRollover {
+		input.Settings.MoveUnfinishedIssues = sprintConfigSetAutoRollover
+	}
+
+	if sprintConfigSetDryRun {
+		output.MutationDryRunDetail(w, sprintConfigDryRunMessage(current), sprintConfigChanges(current, input))
+		return nil
+	}
+
+	var data json.RawMessage
+	if current == nil {
+		data, err = client.Execute(ctx, createSprintConfigMutation, map[string]any{
+			"input": map[string]any{
+				"workspaceId":  cfg.Workspace,
+				"sprintConfig": input,
+			},
+		})
+	} else {
+		data, err = client.Execute(ctx, updateSprintConfigMutation, map[string]any{
+			"input": map[string]any{
+				"sprintConfigId": current.ID,
+				"sprintConfig":   input,
+			},
+		})
+	}
+	if err != nil {
+		return exitcode.General("updating sprint configuration", err)
+	}
+
+	var resp struct {
+		CreateSprintConfig *struct {
+			SprintConfig sprintConfigDetail `json:"sprintConfig"`
+		} `json:"createSprintConfig"`
+		UpdateSprintConfig *struct {
+			SprintConfig sprintConfigDetail `json:"sprintConfig"`
+		} `json:"updateSprintConfig"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return exitcode.General("parsing sprint configuration response", err)
+	}
+
+	var updated sprintConfigDetail
+	switch {
+	case resp.CreateSprintConfig != nil:
+		updated = resp.CreateSprintConfig.SprintConfig
+	case resp.UpdateSprintConfig != nil:
+		updated = resp.UpdateSprintConfig.SprintConfig
+	}
+
+	// Upcoming sprints are regenerated from the new configuration
+	_ = cache.Clear(resolve.SprintCacheKey(cfg.Workspace))
+	_ = cache.Clear(resolve.SprintAccessorsCacheKey(cfg.Workspace))
+
+	if output.IsJSON(outputFormat) {
+		return output.JSON(w, updated)
+	}
+
+	msg := "Updated sprint configuration."
+	if current == nil {
+		msg = "Created sprint configuration."
+	}
+	output.MutationSingle(w, output.Green(msg))
+
+	return nil
+}
+
+func sprintConfigDryRunMessage(current *sprintConfigDetail) string {
+	if current == nil {
+		return "Would create sprint configuration:"
+	}
+	return "Would update sprint configuration:"
+}
+
+// sprintConfigChanges lists the settings that differ between the current
+// configuration and the input, as "old -> new".
+func sprintConfigChanges(current *sprintConfigDetail, input sprintConfigInput) []output.DetailLine {
+	var before sprintConfigInput
+	if current != nil {
+		before = sprintConfigInput{
+			Kind:         current.Kind,
+			Period:       current.Period,
+			StartDay:     current.StartDay,
+			EndDay:       current.EndDay,
+			TzIdentifier: current.TzIdentifier,
+			Settings:     current.Settings,
+		}
+	}
+
+	change := func(from, to string) string {
+		if current == nil {
+			return "-> " + to
+		}
+		return from + " -> " + to
+	}
+
+	var details []output.DetailLine
+	if current == nil || before.Period != input.Period {
+		details = append(details, output.DetailLine{Key: "Period", Value: change(formatSprintPeriod(before.Period), formatSprintPeriod(input.Period))})
+	}
+	if current == nil || before.StartDay != input.StartDay {
+		details = append(details, output.DetailLine{Key: "Start day", Value: change(formatDay(before.StartDay), formatDay(input.StartDay))})
+	}
+	if current == nil || before.EndDay != input.EndDay {
+		details = append(details, output.DetailLine{Key: "End day", Value: change(formatDay(before.EndDay), formatDay(input.EndDay))})
+	}
+	if current == nil || before.TzIdentifier != input.TzIdentifier {
+		details = append(details, output.DetailLine{Key: "Timezone", Value: change(before.TzIdentifier, input.TzIdentifier)})
+	}
+	if current == nil || before.Settings != input.Settings {
+		details = append(details, output.DetailLine{Key: "Auto-rollover", Value: change(formatOnOff(before.Settings.MoveUnfinishedIssues), formatOnOff(input.Settings.MoveUnfinishedIssues))})
+	}
+	if len(details) == 0 {
+		details = append(details, output.DetailLine{Key: "Changes", Value: "(none)"})
+	}
+	return details
+}
+
+// parseSprintPeriod parses a sprint length in weeks, given as "2w", "2" or
+// "2 weeks".
+func parseSprintPeriod(s string) (int, error) {
+	v := strings.ToLower(strings.TrimSpace(s))
+	for _, suffix := range []string{"weeks", "week", "w"} {
+		if trimmed, ok := strings.CutSuffix(v, suffix); ok {
+			v = strings.TrimSpace(trimmed)
+			break
+		}
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 1 {
+		return 0, exitcode.Usage(fmt.Sprintf("invalid period %q — use a number of weeks, e.g. 2w", s))
+	}
+	return n, nil
+}
+
+// parseSprintDay parses a day of the week, full or abbreviated to at least
+// three letters, into the API's enum form.
+func parseSprintDay(s string) (string, error) {
+	v := strings.ToUpper(strings.TrimSpace(s))
+	if len(v) >= 3 {
+		for _, day := range sprintConfigDays {
+			if strings.HasPrefix(day, v) {
+				return day, nil
+			}
+		}
+	}
+	return "", exitcode.Usage(fmt.Sprintf("invalid day %q — use a day of the week, e.g. monday", s))
+}
+
+// dayBefore returns the day of the week before the given one.
+func dayBefore(day string) string {
+	i := slices.Index(sprintConfigDays, strings.ToUpper(day))
+	if i < 0 {
+		return day
+	}
+	return sprintConfigDays[(i+len(sprintConfigDays)-1)%len(sprintConfigDays)]
+}
+
+func formatSprintPeriod(period int) string {
+	if period == 1 {
+		return "1 week"
+	}
+	return fmt.Sprintf("%d weeks", period)
+}
+
+func formatOnOff(on bool) string {
+	if on {
+		return "on"
+	}
+	return "off"
+}
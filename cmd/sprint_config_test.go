@@ -0,0 +1,299 @@
+package cmd
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/dslh/zh/internal/api"
+	"github.com/dslh/zh/internal/cache"
+	"github.com/dslh/zh/internal/resolve"
+	"github.com/dslh/zh/internal/testutil"
+)
+
+// sprintConfigMutation records a create or update sprint config mutation.
+type sprintConfigMutation struct {
+	Name  string
+	Input struct {
+		WorkspaceID    string            `json:"workspaceId"`
+		SprintConfigID string            `json:"sprintConfigId"`
+		SprintConfig   sprintConfigInput `json:"sprintConfig"`
+	}
+}
+
+// setupSprintConfigTest serves the given sprint configuration (nil when
+// sprints are not configured) and returns the mutations made.
+func setupSprintConfigTest(t *testing.T, sprintConfig map[string]any) *[]sprintConfigMutation {
+	t.Helper()
+	resetSprintConfigFlags()
+
+	ms := testutil.NewMockServer(t)
+	var sc any
+	if sprintConfig != nil {
+		sc = sprintConfig
+	}
+	ms.HandleQuery("GetSprintConfig", map[string]any{
+		"data": map[string]any{
+			"workspace": map[string]any{
+				"id":           "ws-123",
+				"displayName":  "Dev Test",
+				"sprintConfig": sc,
+			},
+		},
+	})
+
+	var mutations []sprintConfigMutation
+	for _, name := range []string{"CreateSprintConfig", "UpdateSprintConfig"} {
+		ms.Handle(
+			func(req testutil.GraphQLRequest) bool { return strings.Contains(req.Query, "mutation "+name) },
+			func(w http.ResponseWriter, req testutil.GraphQLRequest) {
+				m := sprintConfigMutation{Name: name}
+				var vars struct {
+					Input json.RawMessage `json:"input"`
+				}
+				_ = json.Unmarshal(req.Variables, &vars)
+				_ = json.Unmarshal(vars.Input, &m.Input)
+				mutations = append(mutations, m)
+
+				// Echo the input back as the saved configuration
+				result := sprintConfigDetail{Settings: m.Input.SprintConfig.Settings}
+				result.ID = cmp.Or(m.Input.SprintConfigID, "sc-new")
+				result.Name = m.Input.SprintConfig.Name
+				result.Kind = m.Input.SprintConfig.Kind
+				result.Period = m.Input.SprintConfig.Period
+				result.StartDay = m.Input.SprintConfig.StartDay
+				result.EndDay = m.Input.SprintConfig.EndDay
+				result.TzIdentifier = m.Input.SprintConfig.TzIdentifier
+				field := strings.ToLower(name[:1]) + name[1:]
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(map[string]any{
+					"data": map[string]any{field: map[string]any{"sprintConfig": result}},
+				})
+			},
+		)
+	}
+
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("ZH_API_KEY", "test-key")
+	t.Setenv("ZH_WORKSPACE", "ws-123")
+	t.Setenv("ZH_GITHUB_TOKEN", "")
+
+	origNew := apiNewFunc
+	apiNewFunc = func(apiKey string, opts ...api.Option) *api.Client {
+		return api.New(apiKey, append(opts, api.WithEndpoint(ms.URL()))...)
+	}
+	t.Cleanup(func() { apiNewFunc = origNew })
+
+	return &mutations
+}
+
+func sprintConfigFixture() map[string]any {
+	return map[string]any{
+		"id":           "sc-1",
+		"name":         "Sprint",
+		"kind":         "weekly",
+		"period":       2,
+		"startDay":     "MONDAY",
+		"endDay":       "FRIDAY",
+		"tzIdentifier": "America/New_York",
+		"settings":     map[string]any{"moveUnfinishedIssues": true},
+	}
+}
+
+func TestSprintConfigShow(t *testing.T) {
+	setupSprintConfigTest(t, sprintConfigFixture())
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"sprint", "config", "show"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("sprint config show returned error: %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{
+		"SPRINT CONFIG: Dev Test",
+		"2-week (Monday - Friday)",
+		"America/New_York",
+		"Auto-rollover",
+		"on",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output should contain %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestSprintConfigShowNotConfigured(t *testing.T) {
+	setupSprintConfigTest(t, nil)
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"sprint", "config", "show"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("sprint config show returned error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "Sprints are not configured") {
+		t.Errorf("expected not configured message, got: %s", buf.String())
+	}
+}
+
+func TestSprintConfigSetDryRun(t *testing.T) {
+	mutations := setupSprintConfigTest(t, sprintConfigFixture())
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"sprint", "config", "set", "--period=1w", "--start-day=tue", "--auto-rollover=false", "--dry-run"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("sprint config set returned error: %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{
+		"Would update sprint configuration:",
+		"2 weeks -> 1 week",
+		"Monday -> Tuesday",
+		"on -> off",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output should contain %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "Timezone") || strings.Contains(out, "End day") {
+		t.Errorf("dry run should only list changed settings:\n%s", out)
+	}
+	if len(*mutations) != 0 {
+		t.Errorf("dry run should not make mutations, made %+v", *mutations)
+	}
+}
+
+func TestSprintConfigSetUpdate(t *testing.T) {
+	mutations := setupSprintConfigTest(t, sprintConfigFixture())
+	_ = cache.Set(resolve.SprintCacheKey("ws-123"), []resolve.CachedSprint{{ID: "sprint-1"}})
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"sprint", "config", "set", "--period=3", "--tz=Europe/Berlin"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("sprint config set returned error: %v", err)
+	}
+
+	if len(*mutations) != 1 || (*mutations)[0].Name != "UpdateSprintConfig" {
+		t.Fatalf("expected one UpdateSprintConfig mutation, got %+v", *mutations)
+	}
+	m := (*mutations)[0].Input
+	if m.SprintConfigID != "sc-1" {
+		t.Errorf("sprintConfigId = %q, want sc-1", m.SprintConfigID)
+	}
+	// Settings not given as flags keep their current values
+	want := sprintConfigInput{
+		Name:         "Sprint",
+		Kind:         "weekly",
+		Period:       3,
+		StartDay:     "MONDAY",
+		EndDay:       "FRIDAY",
+		TzIdentifier: "Europe/Berlin",
+		Settings:     sprintConfigSettings{MoveUnfinishedIssues: true},
+	}
+	if m.SprintConfig != want {
+		t.Errorf("sprintConfig = %+v, want %+v", m.SprintConfig, want)
+	}
+
+	if !strings.Contains(buf.String(), "Updated sprint configuration.") {
+		t.Errorf("output should confirm the update:\n%s", buf.String())
+	}
+
+	if _, ok := cache.Get[[]resolve.CachedSprint](resolve.SprintCacheKey("ws-123")); ok {
+		t.Error("sprint cache should be cleared after updating the configuration")
+	}
+}
+
+func TestSprintConfigSetCreate(t *testing.T) {
+	mutations := setupSprintConfigTest(t, nil)
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"sprint", "config", "set", "--period=2w", "--start-day=Monday", "--tz=UTC", "--auto-rollover", "--output=json"})
+	defer func() { outputFormat = "" }()
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("sprint config set returned error: %v", err)
+	}
+
+	if len(*mutations) != 1 || (*mutations)[0].Name != "CreateSprintConfig" {
+		t.Fatalf("expected one CreateSprintConfig mutation, got %+v", *mutations)
+	}
+	m := (*mutations)[0].Input
+	if m.WorkspaceID != "ws-123" {
+		t.Errorf("workspaceId = %q, want ws-123", m.WorkspaceID)
+	}
+	// The end day defaults to the day before the start day
+	if m.SprintConfig.EndDay != "SUNDAY" || !m.SprintConfig.Settings.MoveUnfinishedIssues {
+		t.Errorf("unexpected sprintConfig: %+v", m.SprintConfig)
+	}
+
+	var result struct {
+		ID     string `json:"id"`
+		Period int    `json:"period"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+	if result.ID != "sc-new" || result.Period != 2 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+}
+
+func TestSprintConfigSetErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		config map[string]any
+		args   []string
+		want   string
+	}{
+		{"no flags", sprintConfigFixture(), nil, "no changes specified"},
+		{"bad period", sprintConfigFixture(), []string{"--period=0w"}, "invalid period"},
+		{"bad day", sprintConfigFixture(), []string{"--start-day=mo"}, "invalid day"},
+		{"bad timezone", sprintConfigFixture(), []string{"--tz=Mars/Olympus"}, "invalid timezone"},
+		{"create without tz", nil, []string{"--period=2w", "--start-day=monday"}, "--tz"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mutations := setupSprintConfigTest(t, tt.config)
+
+			rootCmd.SetOut(new(bytes.Buffer))
+			rootCmd.SetArgs(append([]string{"sprint", "config", "set"}, tt.args...))
+
+			err := rootCmd.Execute()
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got %v", tt.want, err)
+			}
+			if len(*mutations) != 0 {
+				t.Errorf("no mutations should be made, made %+v", *mutations)
+			}
+		})
+	}
+}
+
+func TestParseSprintPeriod(t *testing.T) {
+	for input, want := range map[string]int{"2w": 2, "1": 1, "3 weeks": 3, "4W": 4, "1 week": 1} {
+		got, err := parseSprintPeriod(input)
+		if err != nil || got != want {
+			t.Errorf("parseSprintPeriod(%q) = %d, %v; want %d", input, got, err, want)
+		}
+	}
+	for _, input := range []string{"", "w", "-1w", "2d"} {
+		if _, err := parseSprintPeriod(input); err == nil {
+			t.Errorf("parseSprintPeriod(%q) should fail", input)
+		}
+	}
+}
@@ -1,12 +1,15 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 
 	"github.com/dslh/zh/internal/api"
 	"github.com/dslh/zh/internal/exitcode"
 	"github.com/dslh/zh/internal/gh"
+	"github.com/dslh/zh/internal/history"
 	"github.com/dslh/zh/internal/output"
 	"github.com/dslh/zh/internal/resolve"
 	"github.com/spf13/cobra"
@@ -93,11 +96,13 @@ var (
 	sprintAddRepo            string
 	sprintAddDryRun          bool
 	sprintAddContinueOnError bool
+	sprintAddPlanOut         string
 
 	sprintRemoveSprint          string
 	sprintRemoveRepo            string
 	sprintRemoveDryRun          bool
 	sprintRemoveContinueOnError bool
+	sprintRemovePlanOut         string
 )
 
 func init() {
@@ -105,11 +110,13 @@ func init() {
 	sprintAddCmd.Flags().StringVar(&sprintAddRepo, "repo", "", "Repository context for bare issue numbers")
 	sprintAddCmd.Flags().BoolVar(&sprintAddDryRun, "dry-run", false, "Show what would be added without executing")
 	sprintAddCmd.Flags().BoolVar(&sprintAddContinueOnError, "continue-on-error", false, "Continue processing remaining issues after a resolution error")
+	sprintAddCmd.Flags().StringVar(&sprintAddPlanOut, "plan-out", "", "Write the resolved change to a plan file for zh apply instead of executing")
 
 	sprintRemoveCmd.Flags().StringVar(&sprintRemoveSprint, "sprint", "", "Target sprint (default: active). Supports name, ID, or current/next/previous")
 	sprintRemoveCmd.Flags().StringVar(&sprintRemoveRepo, "repo", "", "Repository context for bare issue numbers")
 	sprintRemoveCmd.Flags().BoolVar(&sprintRemoveDryRun, "dry-run", false, "Show what would be removed without executing")
 	sprintRemoveCmd.Flags().BoolVar(&sprintRemoveContinueOnError, "continue-on-error", false, "Continue processing remaining issues after a resolution error")
+	sprintRemoveCmd.Flags().StringVar(&sprintRemovePlanOut, "plan-out", "", "Write the resolved change to a plan file for zh apply instead of executing")
 
 	sprintCmd.AddCommand(sprintAddCmd)
 	sprintCmd.AddCommand(sprintRemoveCmd)
@@ -120,11 +127,13 @@ func resetSprintMutationFlags() {
 	sprintAddRepo = ""
 	sprintAddDryRun = false
 	sprintAddContinueOnError = false
+	sprintAddPlanOut = ""
 
 	sprintRemoveSprint = ""
 	sprintRemoveRepo = ""
 	sprintRemoveDryRun = false
 	sprintRemoveContinueOnError = false
+	sprintRemovePlanOut = ""
 }
 
 // resolvedSprintIssue holds minimal info about an issue resolved for sprint add/remove.
@@ -141,8 +150,8 @@ func (r *resolvedSprintIssue) Ref() string {
 }
 
 // resolveIssueForSprint resolves an issue identifier and fetches its title.
-func resolveIssueForSprint(client *api.Client, workspaceID, identifier, repoFlag string, ghClient *gh.Client) (*resolvedSprintIssue, error) {
-	result, err := resolve.Issue(client, workspaceID, identifier, &resolve.IssueOptions{
+func resolveIssueForSprint(ctx context.Context, client *api.Client, workspaceID, identifier, repoFlag string, ghClient *gh.Client) (*resolvedSprintIssue, error) {
+	result, err := resolve.Issue(ctx, client, workspaceID, identifier, &resolve.IssueOptions{
 		RepoFlag:     repoFlag,
 		GitHubClient: ghClient,
 	})
@@ -151,7 +160,7 @@ func resolveIssueForSprint(client *api.Client, workspaceID, identifier, repoFlag
 	}
 
 	// Fetch title
-	data, err := client.Execute(issueResolveForEpicQuery, map[string]any{
+	data, err := client.Execute(ctx, issueResolveForEpicQuery, map[string]any{
 		"issueId": result.ID,
 	})
 	if err != nil {
@@ -187,16 +196,42 @@ func resolveIssueForSprint(client *api.Client, workspaceID, identifier, repoFlag
 }
 
 // resolveTargetSprint resolves the sprint to target, defaulting to "current".
-func resolveTargetSprint(client *api.Client, workspaceID, sprintFlag string) (*resolve.SprintResult, error) {
+func resolveTargetSprint(ctx context.Context, client *api.Client, workspaceID, sprintFlag string) (*resolve.SprintResult, error) {
 	identifier := "current"
 	if sprintFlag != "" {
 		identifier = sprintFlag
 	}
-	return resolve.Sprint(client, workspaceID, identifier)
+	return resolve.Sprint(ctx, client, workspaceID, identifier)
+}
+
+// recordSprintHistory records issues added to a sprint, or removed from it
+// if add is false.
+func recordSprintHistory(errW io.Writer, workspaceID string, sprint *planEntity, issues []resolvedSprintIssue, add bool, summary string) {
+	value := &history.Value{ID: sprint.ID, Name: sprint.Name}
+
+	command := "sprint remove"
+	if add {
+		command = "sprint add"
+	}
+	changes := make([]history.Change, len(issues))
+	for i, iss := range issues {
+		changes[i] = history.Change{
+			Kind:  history.KindSprint,
+			Issue: history.Issue{ID: iss.ID, Number: iss.Number, RepoName: iss.RepoName, RepoOwner: iss.RepoOwner},
+		}
+		if add {
+			changes[i].After = value
+		} else {
+			changes[i].Before = value
+		}
+	}
+	recordHistory(errW, workspaceID, command, summary, changes)
 }
 
 // runSprintAdd implements `zh sprint add <issue>...`.
 func runSprintAdd(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+
 	cfg, err := requireWorkspace()
 	if err != nil {
 		return err
@@ -207,7 +242,7 @@ func runSprintAdd(cmd *cobra.Command, args []string) error {
 	ghClient := newGitHubClient(cfg, cmd)
 
 	// Resolve target sprint
-	sprint, err := resolveTargetSprint(client, cfg.Workspace, sprintAddSprint)
+	sprint, err := resolveTargetSprint(ctx, client, cfg.Workspace, sprintAddSprint)
 	if err != nil {
 		return err
 	}
@@ -217,7 +252,7 @@ func runSprintAdd(cmd *cobra.Command, args []string) error {
 	var failed []output.FailedItem
 
 	for _, arg := range args {
-		issue, err := resolveIssueForSprint(client, cfg.Workspace, arg, sprintAddRepo, ghClient)
+		issue, err := resolveIssueForSprint(ctx, client, cfg.Workspace, arg, sprintAddRepo, ghClient)
 		if err != nil {
 			if sprintAddContinueOnError {
 				failed = append(failed, output.FailedItem{
@@ -236,17 +271,21 @@ func runSprintAdd(cmd *cobra.Command, args []string) error {
 	}
 
 	// Dry run
-	if sprintAddDryRun {
-		return renderSprintAddDryRun(w, sprint, issues, failed)
+	if sprintAddDryRun || sprintAddPlanOut != "" {
+		if err := renderSprintAddDryRun(w, sprint, issues, failed); err != nil {
+			return err
+		}
+		return savePlan(ctx, client, cfg.Workspace, w, sprintAddPlanOut, &mutationPlan{
+			Command: "sprint add",
+			Summary: fmt.Sprintf("add %d issue(s) to %s", len(issues), sprint.Name),
+			Sprint:  &planEntity{ID: sprint.ID, Name: sprint.Name},
+		}, sprintIssueIDs(issues))
 	}
 
 	// Execute the mutation
-	issueIDs := make([]string, len(issues))
-	for i, iss := range issues {
-		issueIDs[i] = iss.ID
-	}
+	issueIDs := sprintIssueIDs(issues)
 
-	data, err := client.Execute(addIssuesToSprintsMutation, map[string]any{
+	data, err := client.Execute(ctx, addIssuesToSprintsMutation, map[string]any{
 		"input": map[string]any{
 			"issueIds":  issueIDs,
 			"sprintIds": []string{sprint.ID},
@@ -272,6 +311,9 @@ func runSprintAdd(cmd *cobra.Command, args []string) error {
 		return exitcode.General("parsing add issues to sprint response", err)
 	}
 
+	recordSprintHistory(cmd.ErrOrStderr(), cfg.Workspace, &planEntity{ID: sprint.ID, Name: sprint.Name}, issues, true,
+		fmt.Sprintf("Added %d issue(s) to %s", len(issues), sprint.Name))
+
 	// Build succeeded list
 	succeeded := make([]output.MutationItem, len(issues))
 	for i, iss := range issues {
@@ -332,6 +374,8 @@ func renderSprintAddDryRun(w writerFlusher, sprint *resolve.SprintResult, issues
 
 // runSprintRemove implements `zh sprint remove <issue>...`.
 func runSprintRemove(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+
 	cfg, err := requireWorkspace()
 	if err != nil {
 		return err
@@ -342,7 +386,7 @@ func runSprintRemove(cmd *cobra.Command, args []string) error {
 	ghClient := newGitHubClient(cfg, cmd)
 
 	// Resolve target sprint
-	sprint, err := resolveTargetSprint(client, cfg.Workspace, sprintRemoveSprint)
+	sprint, err := resolveTargetSprint(ctx, client, cfg.Workspace, sprintRemoveSprint)
 	if err != nil {
 		return err
 	}
@@ -352,7 +396,7 @@ func runSprintRemove(cmd *cobra.Command, args []string) error {
 	var failed []output.FailedItem
 
 	for _, arg := range args {
-		issue, err := resolveIssueForSprint(client, cfg.Workspace, arg, sprintRemoveRepo, ghClient)
+		issue, err := resolveIssueForSprint(ctx, client, cfg.Workspace, arg, sprintRemoveRepo, ghClient)
 		if err != nil {
 			if sprintRemoveContinueOnError {
 				failed = append(failed, output.FailedItem{
@@ -371,17 +415,21 @@ func runSprintRemove(cmd *cobra.Command, args []string) error {
 	}
 
 	// Dry run
-	if sprintRemoveDryRun {
-		return renderSprintRemoveDryRun(w, sprint, issues, failed)
+	if sprintRemoveDryRun || sprintRemovePlanOut != "" {
+		if err := renderSprintRemoveDryRun(w, sprint, issues, failed); err != nil {
+			return err
+		}
+		return savePlan(ctx, client, cfg.Workspace, w, sprintRemovePlanOut, &mutationPlan{
+			Command: "sprint remove",
+			Summary: fmt.Sprintf("remove %d issue(s) from %s", len(issues), sprint.Name),
+			Sprint:  &planEntity{ID: sprint.ID, Name: sprint.Name},
+		}, sprintIssueIDs(issues))
 	}
 
 	// Execute the mutation
-	issueIDs := make([]string, len(issues))
-	for i, iss := range issues {
-		issueIDs[i] = iss.ID
-	}
+	issueIDs := sprintIssueIDs(issues)
 
-	data, err := client.Execute(removeIssuesFromSprintsMutation, map[string]any{
+	data, err := client.Execute(ctx, removeIssuesFromSprintsMutation, map[string]any{
 		"input": map[string]any{
 			"issueIds":  issueIDs,
 			"sprintIds": []string{sprint.ID},
@@ -409,6 +457,9 @@ func runSprintRemove(cmd *cobra.Command, args []string) error {
 		return exitcode.General("parsing remove issues from sprint response", err)
 	}
 
+	recordSprintHistory(cmd.ErrOrStderr(), cfg.Workspace, &planEntity{ID: sprint.ID, Name: sprint.Name}, issues, false,
+		fmt.Sprintf("Removed %d issue(s) from %s", len(issues), sprint.Name))
+
 	// Build succeeded list
 	succeeded := make([]output.MutationItem, len(issues))
 	for i, iss := range issues {
@@ -467,6 +518,14 @@ func renderSprintRemoveDryRun(w writerFlusher, sprint *resolve.SprintResult, iss
 	return nil
 }
 
+func sprintIssueIDs(issues []resolvedSprintIssue) []string {
+	ids := make([]string, len(issues))
+	for i, iss := range issues {
+		ids[i] = iss.ID
+	}
+	return ids
+}
+
 func formatSprintIssueItemsJSON(issues []resolvedSprintIssue) []map[string]any {
 	result := make([]map[string]any, len(issues))
 	for i, iss := range issues {
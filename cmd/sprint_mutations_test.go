@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/dslh/zh/internal/api"
+	"github.com/dslh/zh/internal/history"
 	"github.com/dslh/zh/internal/testutil"
 )
 
@@ -63,6 +64,32 @@ func TestSprintAdd(t *testing.T) {
 	}
 }
 
+func TestSprintAddRecordsHistory(t *testing.T) {
+	setupHistoryDataDir(t)
+	ms := testutil.NewMockServer(t)
+	ms.HandleQuery("ListSprints", sprintResolutionResponse())
+	ms.HandleQuery("ListRepos", repoListForSprintResponse())
+	ms.HandleQuery("IssueByInfo", issueByInfoForSprintResponse("i1", 1))
+	ms.HandleQuery("GetIssueForEpic", issueDetailForSprintResponse("i1", 1, "Fix login button alignment"))
+	ms.HandleQuery("AddIssuesToSprints", addIssuesToSprintsResponse())
+	setupSprintMutationTest(t, ms)
+
+	rootCmd.SetOut(new(bytes.Buffer))
+	rootCmd.SetArgs([]string{"sprint", "add", "task-tracker#1"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("sprint add returned error: %v", err)
+	}
+
+	entries, _ := history.Load("ws-123")
+	if len(entries) != 1 || entries[0].Command != "sprint add" || len(entries[0].Changes) != 1 {
+		t.Fatalf("expected one recorded sprint add, got %+v", entries)
+	}
+	if c := entries[0].Changes[0]; c.Kind != history.KindSprint || c.Issue.ID != "i1" || c.After == nil || c.After.Name != "Sprint 47" {
+		t.Errorf("unexpected change: %+v", c)
+	}
+}
+
 func TestSprintAddMultiple(t *testing.T) {
 	ms := testutil.NewMockServer(t)
 	ms.HandleQuery("ListSprints", sprintResolutionResponse())
@@ -0,0 +1,649 @@
+package cmd
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+
+	"github.com/dslh/zh/internal/api"
+	"github.com/dslh/zh/internal/exitcode"
+	"github.com/dslh/zh/internal/history"
+	"github.com/dslh/zh/internal/output"
+	"github.com/dslh/zh/internal/resolve"
+	"github.com/spf13/cobra"
+)
+
+// GraphQL queries for sprint planning
+
+const sprintPlanSprintQuery = `query GetSprintPlanSprint($sprintId: ID!) {
+  node(id: $sprintId) {
+    ... on Sprint {
+      id
+      name
+      generatedName
+      state
+      startAt
+      endAt
+      totalPoints
+    }
+  }
+}`
+
+const sprintPlanCandidatesQuery = `query GetSprintPlanCandidates(
+  $pipelineId: ID!
+  $workspaceId: ID!
+  $first: Int
+  $after: String
+) {
+  searchIssuesByPipeline(
+    pipelineId: $pipelineId
+    filters: {}
+    first: $first
+    after: $after
+  ) {
+    pageInfo {
+      hasNextPage
+      endCursor
+    }
+    nodes {
+      id
+      number
+      title
+      state
+      estimate {
+        value
+      }
+      repository {
+        ghId
+        name
+        ownerName
+      }
+      sprints(first: 10) {
+        nodes {
+          id
+        }
+      }
+      parentZenhubEpics(first: 1) {
+        nodes {
+          id
+          title
+        }
+      }
+      blockingItems(first: 20) {
+        nodes {
+          ... on Issue {
+            __typename
+            state
+          }
+          ... on ZenhubEpic {
+            __typename
+            state
+          }
+        }
+      }
+      pipelineIssue(workspaceId: $workspaceId) {
+        relativePosition
+        priority {
+          id
+          name
+        }
+      }
+    }
+  }
+}`
+
+// sprintPlanVelocitySprints is how many closed sprints the default capacity
+// averages over, matching the default for zh sprint velocity.
+const sprintPlanVelocitySprints = 6
+
+// Command
+
+var sprintPlanCmd = &cobra.Command{
+	Use:   "plan",
+	Short: "Fill a sprint to capacity from a pipeline",
+	Long: `Propose a set of issues from a pipeline to fill a sprint, then add them to
+the sprint. Defaults to the next sprint.
+
+Candidates are ordered by priority (highest first, unprioritized last), then
+by their position in the pipeline, then by epic. Issues are taken in that
+order until the next one would go over capacity. Points already in the
+sprint count against the capacity.
+
+Unestimated issues, closed issues, issues that are blocked by an open issue
+or epic, and issues already in the sprint are skipped.
+
+--capacity must be positive. When it is not given, it defaults to the
+average completed points of recent closed sprints, as shown by
+zh sprint velocity.
+
+Use --dry-run to print the proposal without changing anything, and
+--interactive to adjust the proposal in a checklist before it is applied.
+
+Examples:
+  zh sprint plan --from-pipeline=Backlog
+  zh sprint plan --sprint=next --capacity=40 --from-pipeline=Backlog --dry-run
+  zh sprint plan --from-pipeline=Backlog --interactive`,
+	Args: cobra.NoArgs,
+	RunE: runSprintPlan,
+}
+
+var (
+	sprintPlanSprint      string
+	sprintPlanCapacity    float64
+	sprintPlanPipeline    string
+	sprintPlanDryRun      bool
+	sprintPlanInteractive bool
+)
+
+func init() {
+	sprintPlanCmd.Flags().StringVar(&sprintPlanSprint, "sprint", "next", "Sprint to plan. Supports name, ID, or current/next/previous")
+	sprintPlanCmd.Flags().Float64Var(&sprintPlanCapacity, "capacity", 0, "Points to fill the sprint to (default: average recent velocity)")
+	sprintPlanCmd.Flags().StringVar(&sprintPlanPipeline, "from-pipeline", "", "Pipeline to take issues from (required)")
+	sprintPlanCmd.Flags().BoolVar(&sprintPlanDryRun, "dry-run", false, "Show the proposal without adding issues to the sprint")
+	sprintPlanCmd.Flags().BoolVarP(&sprintPlanInteractive, "interactive", "i", false, "Toggle the proposed issues in a checklist before applying")
+
+	sprintCmd.AddCommand(sprintPlanCmd)
+}
+
+func resetSprintPlanFlags() {
+	// Capacity falls back to velocity unless given, so Changed must be reset too
+	resetFlagDefaults(sprintPlanCmd.Flags())
+	sprintPlanSprint = "next"
+	sprintPlanCapacity = 0
+	sprintPlanPipeline = ""
+	sprintPlanDryRun = false
+	sprintPlanInteractive = false
+}
+
+// sprintPlanTarget is the sprint being planned.
+type sprintPlanTarget struct {
+	ID            string  `json:"id"`
+	Name          string  `json:"name"`
+	GeneratedName string  `json:"generatedName"`
+	State         string  `json:"state"`
+	StartAt       string  `json:"startAt"`
+	EndAt         string  `json:"endAt"`
+	TotalPoints   float64 `json:"totalPoints"`
+}
+
+// sprintPlanCandidate is an issue in the source pipeline. Skip is set to the
+// reason when the issue can't be planned.
+type sprintPlanCandidate struct {
+	Issue    resolvedSprintIssue
+	RepoGhID int
+	Estimate *float64
+	Priority *resolve.CachedPriority
+	Position *int
+	Epic     string
+	Skip     string
+}
+
+// runSprintPlan implements `zh sprint plan`.
+func runSprintPlan(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+
+	cfg, err := requireWorkspace()
+	if err != nil {
+		return err
+	}
+
+	if sprintPlanPipeline == "" {
+		return exitcode.Usage("--from-pipeline is required")
+	}
+	capacitySet := cmd.Flags().Changed("capacity")
+	if capacitySet && sprintPlanCapacity <= 0 {
+		return exitcode.Usage("--capacity must be greater than zero")
+	}
+
+	client := newClient(cfg, cmd)
+	w := cmd.OutOrStdout()
+
+	resolvedSprint, err := resolveTargetSprint(ctx, client, cfg.Workspace, sprintPlanSprint)
+	if err != nil {
+		return err
+	}
+	sprint, err := fetchSprintPlanTarget(ctx, client, resolvedSprint.ID)
+	if err != nil {
+		return err
+	}
+	sprint.Name = resolvedSprint.Name
+
+	pipeline, err := resolve.Pipeline(ctx, client, cfg.Workspace, sprintPlanPipeline, cfg.Aliases.Pipelines)
+	if err != nil {
+		return err
+	}
+
+	// Capacity, defaulting to recent velocity
+	capacity, capacitySource := sprintPlanCapacity, "set with --capacity"
+	if !capacitySet {
+		velocity, err := fetchForecastVelocity(ctx, client, cfg.Workspace, sprintPlanVelocitySprints)
+		if err != nil {
+			return err
+		}
+		if len(velocity.Sprints) == 0 {
+			return exitcode.Generalf("no closed sprints to take velocity from — set --capacity")
+		}
+		var sum float64
+		for _, s := range velocity.Sprints {
+			sum += s.CompletedPoints
+		}
+		capacity = sum / float64(len(velocity.Sprints))
+		capacitySource = fmt.Sprintf("average velocity of the last %d sprint(s)", len(velocity.Sprints))
+	}
+
+	priorities, err := resolve.FetchPriorities(ctx, client, cfg.Workspace)
+	if err != nil {
+		return err
+	}
+
+	candidates, err := fetchSprintPlanCandidates(ctx, client, cfg.Workspace, pipeline.ID, sprint.ID, priorities)
+	if err != nil {
+		return err
+	}
+	eligible, skipped := orderSprintPlanCandidates(candidates, priorities)
+	proposed := proposeSprintPlan(eligible, capacity-sprint.TotalPoints)
+
+	if sprintPlanInteractive {
+		proposed, err = selectSprintPlanInteractive(cmd, sprint, eligible, proposed, capacity)
+		if err != nil {
+			return err
+		}
+	}
+
+	points := sprintPlanPoints(proposed)
+	jsonOutput := output.IsJSON(outputFormat)
+
+	// The proposal is printed before anything is changed
+	if !jsonOutput {
+		renderSprintPlan(w, sprint, pipeline.Name, capacity, capacitySource, eligible, proposed, skipped)
+		fmt.Fprintln(w)
+	}
+
+	apply := !sprintPlanDryRun && len(proposed) > 0
+	if apply {
+		issues := make([]resolvedSprintIssue, len(proposed))
+		for i, c := range proposed {
+			issues[i] = c.Issue
+		}
+		_, err := client.Execute(ctx, addIssuesToSprintsMutation, map[string]any{
+			"input": map[string]any{
+				"issueIds":  sprintIssueIDs(issues),
+				"sprintIds": []string{sprint.ID},
+			},
+		})
+		if err != nil {
+			return exitcode.General("adding issues to sprint", err)
+		}
+
+		sprintValue := &history.Value{ID: sprint.ID, Name: sprint.Name}
+		changes := make([]history.Change, len(proposed))
+		for i, c := range proposed {
+			changes[i] = history.Change{
+				Kind: history.KindSprint,
+				Issue: history.Issue{
+					ID:        c.Issue.ID,
+					Number:    c.Issue.Number,
+					RepoName:  c.Issue.RepoName,
+					RepoOwner: c.Issue.RepoOwner,
+					RepoGhID:  c.RepoGhID,
+				},
+				After: sprintValue,
+			}
+		}
+		recordHistory(cmd.ErrOrStderr(), cfg.Workspace, "sprint plan",
+			fmt.Sprintf("Planned %d issue(s) into %s", len(proposed), sprint.Name), changes)
+	}
+
+	if jsonOutput {
+		return output.JSON(w, map[string]any{
+			"sprint": map[string]any{
+				"id":      sprint.ID,
+				"name":    sprint.Name,
+				"startAt": sprint.StartAt,
+				"endAt":   sprint.EndAt,
+			},
+			"pipeline":        map[string]any{"id": pipeline.ID, "name": pipeline.Name},
+			"capacity":        capacity,
+			"committedPoints": sprint.TotalPoints,
+			"proposedPoints":  points,
+			"proposed":        formatSprintPlanCandidatesJSON(proposed),
+			"skipped":         formatSprintPlanCandidatesJSON(skipped),
+			"applied":         apply,
+		})
+	}
+
+	switch {
+	case len(proposed) == 0:
+		fmt.Fprintln(w, "Nothing to add.")
+	case !apply:
+		output.MutationSingle(w, output.Yellow(fmt.Sprintf("Would add %d issue(s) (%s pts) to %s.", len(proposed), formatEstimate(points), sprint.Name)))
+	default:
+		output.MutationSingle(w, output.Green(fmt.Sprintf("Added %d issue(s) (%s pts) to %s.", len(proposed), formatEstimate(points), sprint.Name)))
+	}
+	return nil
+}
+
+// fetchSprintPlanTarget fetches the dates and committed points of a sprint.
+func fetchSprintPlanTarget(ctx context.Context, client *api.Client, sprintID string) (*sprintPlanTarget, error) {
+	data, err := client.Execute(ctx, sprintPlanSprintQuery, map[string]any{"sprintId": sprintID})
+	if err != nil {
+		return nil, exitcode.General("fetching sprint", err)
+	}
+
+	var resp struct {
+		Node *sprintPlanTarget `json:"node"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, exitcode.General("parsing sprint response", err)
+	}
+	if resp.Node == nil || resp.Node.ID == "" {
+		return nil, exitcode.NotFoundError(fmt.Sprintf("sprint %q not found", sprintID))
+	}
+	return resp.Node, nil
+}
+
+// fetchSprintPlanCandidates fetches every issue in a pipeline, marking those
+// that can't be added to the sprint with the reason why.
+func fetchSprintPlanCandidates(ctx context.Context, client *api.Client, workspaceID, pipelineID, sprintID string, priorities []resolve.CachedPriority) ([]sprintPlanCandidate, error) {
+	var candidates []sprintPlanCandidate
+	vars := map[string]any{
+		"pipelineId":  pipelineID,
+		"workspaceId": workspaceID,
+		"first":       100,
+	}
+	err := forEachQueryPage(ctx, client, sprintPlanCandidatesQuery, vars, "pipeline issues", func(data json.RawMessage) (pageInfoNode, error) {
+		var resp struct {
+			SearchIssuesByPipeline struct {
+				PageInfo pageInfoNode `json:"pageInfo"`
+				Nodes    []struct {
+					ID       string `json:"id"`
+					Number   int    `json:"number"`
+					Title    string `json:"title"`
+					State    string `json:"state"`
+					Estimate *struct {
+						Value float64 `json:"value"`
+					} `json:"estimate"`
+					Repository struct {
+						GhID      int    `json:"ghId"`
+						Name      string `json:"name"`
+						OwnerName string `json:"ownerName"`
+					} `json:"repository"`
+					Sprints struct {
+						Nodes []struct {
+							ID string `json:"id"`
+						} `json:"nodes"`
+					} `json:"sprints"`
+					ParentZenhubEpics struct {
+						Nodes []struct {
+							Title string `json:"title"`
+						} `json:"nodes"`
+					} `json:"parentZenhubEpics"`
+					BlockingItems struct {
+						Nodes []struct {
+							State string `json:"state"`
+						} `json:"nodes"`
+					} `json:"blockingItems"`
+					PipelineIssue *struct {
+						RelativePosition *int `json:"relativePosition"`
+						Priority         *struct {
+							ID string `json:"id"`
+						} `json:"priority"`
+					} `json:"pipelineIssue"`
+				} `json:"nodes"`
+			} `json:"searchIssuesByPipeline"`
+		}
+		if err := json.Unmarshal(data, &resp); err != nil {
+			return pageInfoNode{}, err
+		}
+
+		for _, n := range resp.SearchIssuesByPipeline.Nodes {
+			c := sprintPlanCandidate{
+				Issue: resolvedSprintIssue{
+					ID:        n.ID,
+					Number:    n.Number,
+					RepoName:  n.Repository.Name,
+					RepoOwner: n.Repository.OwnerName,
+					Title:     n.Title,
+				},
+				RepoGhID: n.Repository.GhID,
+			}
+			if n.Estimate != nil {
+				c.Estimate = &n.Estimate.Value
+			}
+			if len(n.ParentZenhubEpics.Nodes) > 0 {
+				c.Epic = n.ParentZenhubEpics.Nodes[0].Title
+			}
+			if pi := n.PipelineIssue; pi != nil {
+				c.Position = pi.RelativePosition
+				if pi.Priority != nil {
+					for i := range priorities {
+						if priorities[i].ID == pi.Priority.ID {
+							c.Priority = &priorities[i]
+						}
+					}
+				}
+			}
+
+			inSprint := false
+			for _, s := range n.Sprints.Nodes {
+				inSprint = inSprint || s.ID == sprintID
+			}
+			openBlockers := 0
+			for _, b := range n.BlockingItems.Nodes {
+				if b.State != "CLOSED" {
+					openBlockers++
+				}
+			}
+
+			switch {
+			case n.State == "CLOSED":
+				c.Skip = "closed"
+			case inSprint:
+				c.Skip = "already in sprint"
+			case openBlockers > 0:
+				c.Skip = fmt.Sprintf("blocked by %d open item(s)", openBlockers)
+			case c.Estimate == nil:
+				c.Skip = "not estimated"
+			}
+			candidates = append(candidates, c)
+		}
+		return resp.SearchIssuesByPipeline.PageInfo, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return candidates, nil
+}
+
+// orderSprintPlanCandidates splits candidates into those that can be planned,
+// ordered by priority, pipeline position and epic, and those that are skipped.
+func orderSprintPlanCandidates(candidates []sprintPlanCandidate, priorities []resolve.CachedPriority) (eligible, skipped []sprintPlanCandidate) {
+	for _, c := range candidates {
+		if c.Skip != "" {
+			skipped = append(skipped, c)
+		} else {
+			eligible = append(eligible, c)
+		}
+	}
+
+	rank := func(c sprintPlanCandidate) int {
+		if c.Priority == nil {
+			return len(priorities)
+		}
+		return slices.IndexFunc(priorities, func(p resolve.CachedPriority) bool { return p.ID == c.Priority.ID })
+	}
+	position := func(c sprintPlanCandidate) int {
+		if c.Position == nil {
+			return math.MaxInt
+		}
+		return *c.Position
+	}
+	// Issues without an epic sort after those with one
+	epic := func(c sprintPlanCandidate) string {
+		if c.Epic == "" {
+			return "\uffff"
+		}
+		return c.Epic
+	}
+	slices.SortStableFunc(eligible, func(a, b sprintPlanCandidate) int {
+		return cmp.Or(
+			cmp.Compare(rank(a), rank(b)),
+			cmp.Compare(position(a), position(b)),
+			cmp.Compare(epic(a), epic(b)),
+		)
+	})
+	return eligible, skipped
+}
+
+// proposeSprintPlan takes candidates in order until the next one would go
+// over the remaining capacity.
+func proposeSprintPlan(eligible []sprintPlanCandidate, remaining float64) []sprintPlanCandidate {
+	var proposed []sprintPlanCandidate
+	for _, c := range eligible {
+		if *c.Estimate > remaining {
+			break
+		}
+		remaining -= *c.Estimate
+		proposed = append(proposed, c)
+	}
+	return proposed
+}
+
+// selectSprintPlanInteractive shows the eligible candidates in a checklist,
+// with the proposal checked, and returns the candidates left checked.
+func selectSprintPlanInteractive(cmd *cobra.Command, sprint *sprintPlanTarget, eligible, proposed []sprintPlanCandidate, capacity float64) ([]sprintPlanCandidate, error) {
+	items := make([]checklistItem, len(eligible))
+	for i, c := range eligible {
+		desc := formatEstimate(*c.Estimate) + " pts"
+		if c.Priority != nil {
+			desc += " · " + c.Priority.Name
+		}
+		if c.Epic != "" {
+			desc += " · " + c.Epic
+		}
+		items[i] = checklistItem{
+			id:          c.Issue.ID,
+			title:       c.Issue.Ref() + " " + truncateTitle(c.Issue.Title),
+			description: desc,
+			checked:     i < len(proposed),
+		}
+	}
+
+	status := func(items []checklistItem) string {
+		points := sprint.TotalPoints
+		for i, item := range items {
+			if item.checked {
+				points += *eligible[i].Estimate
+			}
+		}
+		line := fmt.Sprintf("%s of %s pts", formatEstimate(points), formatEstimate(capacity))
+		if points > capacity {
+			return output.Red(line + " — over capacity")
+		}
+		return line
+	}
+
+	items, err := runInteractiveChecklist(cmd, "Plan "+sprint.Name, items, status)
+	if err != nil {
+		return nil, err
+	}
+
+	var selected []sprintPlanCandidate
+	for i, item := range items {
+		if item.checked {
+			selected = append(selected, eligible[i])
+		}
+	}
+	return selected, nil
+}
+
+func sprintPlanPoints(candidates []sprintPlanCandidate) float64 {
+	var points float64
+	for _, c := range candidates {
+		if c.Estimate != nil {
+			points += *c.Estimate
+		}
+	}
+	return points
+}
+
+func renderSprintPlan(w io.Writer, sprint *sprintPlanTarget, pipelineName string, capacity float64, capacitySource string, eligible, proposed, skipped []sprintPlanCandidate) {
+	points := sprintPlanPoints(proposed)
+
+	d := output.NewDetailWriter(w, "SPRINT PLAN", sprint.Name)
+	fields := []output.KeyValue{
+		output.KV("Dates", formatSprintDates(sprint.StartAt, sprint.EndAt)),
+		output.KV("Pipeline", pipelineName),
+		output.KV("Capacity", fmt.Sprintf("%s pts %s", formatEstimate(capacity), output.Dim("("+capacitySource+")"))),
+	}
+	if sprint.TotalPoints > 0 {
+		fields = append(fields, output.KV("Committed", formatEstimate(sprint.TotalPoints)+" pts already in the sprint"))
+	}
+	planned := fmt.Sprintf("%s pts in %d issue(s)", formatEstimate(points), len(proposed))
+	if sprint.TotalPoints+points > capacity {
+		planned += output.Red(fmt.Sprintf(" (%s over capacity)", formatEstimate(sprint.TotalPoints+points-capacity)))
+	}
+	fields = append(fields, output.KV("Proposed", planned))
+	d.Fields(fields)
+
+	d.Section("PROPOSED")
+	if len(proposed) == 0 {
+		fmt.Fprintln(w, "No issues fit in the remaining capacity.")
+	} else {
+		lw := output.NewListWriter(w, "ISSUE", "EST", "PRIORITY", "EPIC", "TITLE")
+		for _, c := range proposed {
+			lw.Row(c.Issue.Ref(), formatEstimate(*c.Estimate), formatSprintPlanPriority(c), c.Epic, truncateTitle(c.Issue.Title))
+		}
+		footer := ""
+		if left := len(eligible) - len(proposed); left > 0 {
+			footer = fmt.Sprintf("%d more eligible issue(s) not proposed", left)
+		}
+		lw.FlushWithFooter(footer)
+	}
+
+	if len(skipped) > 0 {
+		d.Section("SKIPPED")
+		lw := output.NewListWriter(w, "ISSUE", "REASON", "TITLE")
+		for _, c := range skipped {
+			lw.Row(c.Issue.Ref(), output.Dim(c.Skip), truncateTitle(c.Issue.Title))
+		}
+		lw.Flush()
+	}
+}
+
+func formatSprintPlanPriority(c sprintPlanCandidate) string {
+	if c.Priority == nil {
+		return output.Dim("-")
+	}
+	return c.Priority.Name
+}
+
+func formatSprintPlanCandidatesJSON(candidates []sprintPlanCandidate) []map[string]any {
+	result := make([]map[string]any, len(candidates))
+	for i, c := range candidates {
+		item := map[string]any{
+			"id":         c.Issue.ID,
+			"number":     c.Issue.Number,
+			"repository": fmt.Sprintf("%s/%s", c.Issue.RepoOwner, c.Issue.RepoName),
+			"title":      c.Issue.Title,
+			"estimate":   formatEstimateJSON(c.Estimate),
+			"priority":   nil,
+			"epic":       nil,
+		}
+		if c.Priority != nil {
+			item["priority"] = c.Priority.Name
+		}
+		if c.Epic != "" {
+			item["epic"] = c.Epic
+		}
+		if c.Skip != "" {
+			item["reason"] = c.Skip
+		}
+		result[i] = item
+	}
+	return result
+}
@@ -0,0 +1,311 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/dslh/zh/internal/api"
+	"github.com/dslh/zh/internal/exitcode"
+	"github.com/dslh/zh/internal/history"
+	"github.com/dslh/zh/internal/resolve"
+	"github.com/dslh/zh/internal/testutil"
+)
+
+// setupSprintPlanTest serves a pipeline of candidates for Sprint 48 and
+// returns a pointer to the issue IDs added by AddIssuesToSprints.
+func setupSprintPlanTest(t *testing.T) *[]string {
+	t.Helper()
+	resetSprintFlags()
+	resetSprintPlanFlags()
+	setupHistoryDataDir(t)
+
+	ms := testutil.NewMockServer(t)
+	ms.HandleQuery("ListSprints", sprintResolutionResponse())
+	ms.HandleQuery("ListPipelines", pipelineResolutionResponse())
+	ms.HandleQuery("GetWorkspacePriorities", prioritiesResponse())
+	ms.HandleQuery("SprintVelocity", sprintVelocityResponse())
+	ms.HandleQuery("GetSprintPlanSprint", sprintPlanSprintResponse())
+	ms.HandleQuery("GetSprintPlanCandidates", sprintPlanCandidatesResponse())
+
+	var added []string
+	ms.Handle(
+		func(req testutil.GraphQLRequest) bool { return strings.Contains(req.Query, "AddIssuesToSprints") },
+		func(w http.ResponseWriter, req testutil.GraphQLRequest) {
+			var vars struct {
+				Input struct {
+					IssueIDs  []string `json:"issueIds"`
+					SprintIDs []string `json:"sprintIds"`
+				} `json:"input"`
+			}
+			_ = json.Unmarshal(req.Variables, &vars)
+			if slices.Equal(vars.Input.SprintIDs, []string{"sprint-48"}) {
+				added = append(added, vars.Input.IssueIDs...)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"data":{"addIssuesToSprints":{"sprintIssues":[]}}}`))
+		},
+	)
+
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("ZH_API_KEY", "test-key")
+	t.Setenv("ZH_WORKSPACE", "ws-123")
+	t.Setenv("ZH_GITHUB_TOKEN", "")
+
+	origNew := apiNewFunc
+	apiNewFunc = func(apiKey string, opts ...api.Option) *api.Client {
+		return api.New(apiKey, append(opts, api.WithEndpoint(ms.URL()))...)
+	}
+	t.Cleanup(func() { apiNewFunc = origNew })
+
+	return &added
+}
+
+func TestSprintPlanDryRun(t *testing.T) {
+	added := setupSprintPlanTest(t)
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"sprint", "plan", "--from-pipeline=New Issues", "--capacity=40", "--dry-run"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("sprint plan returned error: %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{
+		"SPRINT PLAN: Sprint 48",
+		"40 pts",
+		"13 pts already in the sprint",
+		"13 pts in 2 issue(s)",
+		"task-tracker#11",
+		"task-tracker#10",
+		"3 more eligible issue(s) not proposed",
+		"SKIPPED",
+		"not estimated",
+		"blocked by 1 open item(s)",
+		"already in sprint",
+		"Would add 2 issue(s) (13 pts) to Sprint 48.",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output should contain %q:\n%s", want, out)
+		}
+	}
+
+	// Urgent comes before High priority
+	if strings.Index(out, "task-tracker#11") > strings.Index(out, "task-tracker#10") {
+		t.Errorf("task-tracker#11 should be proposed first:\n%s", out)
+	}
+	if len(*added) != 0 {
+		t.Errorf("dry run should not add issues, added %v", *added)
+	}
+}
+
+func TestSprintPlanApply(t *testing.T) {
+	added := setupSprintPlanTest(t)
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"sprint", "plan", "--from-pipeline=New Issues", "--capacity=40"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("sprint plan returned error: %v", err)
+	}
+
+	if !slices.Equal(*added, []string{"issue-b", "issue-a"}) {
+		t.Errorf("added %v, want [issue-b issue-a]", *added)
+	}
+	if !strings.Contains(buf.String(), "Added 2 issue(s) (13 pts) to Sprint 48.") {
+		t.Errorf("output should confirm the additions:\n%s", buf.String())
+	}
+
+	entries, err := history.Load("ws-123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Command != "sprint plan" || len(entries[0].Changes) != 2 {
+		t.Fatalf("expected one sprint plan entry with 2 changes, got %+v", entries)
+	}
+	if c := entries[0].Changes[0]; c.Kind != history.KindSprint || c.After.ID != "sprint-48" || c.Issue.RepoGhID != 1 {
+		t.Errorf("unexpected change: %+v", c)
+	}
+}
+
+func TestSprintPlanJSON(t *testing.T) {
+	added := setupSprintPlanTest(t)
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"sprint", "plan", "--from-pipeline=New Issues", "--dry-run", "--output=json"})
+	defer func() { outputFormat = "" }()
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("sprint plan returned error: %v", err)
+	}
+
+	var result struct {
+		Capacity float64 `json:"capacity"`
+		Proposed []struct {
+			ID       string  `json:"id"`
+			Priority *string `json:"priority"`
+		} `json:"proposed"`
+		Skipped []struct {
+			ID     string `json:"id"`
+			Reason string `json:"reason"`
+		} `json:"skipped"`
+		Applied bool `json:"applied"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+
+	// Capacity defaults to the average of the closed sprints' velocity
+	if result.Capacity != 42 {
+		t.Errorf("capacity = %v, want 42", result.Capacity)
+	}
+	if len(result.Proposed) != 2 || result.Proposed[0].ID != "issue-b" || result.Proposed[1].ID != "issue-a" {
+		t.Errorf("unexpected proposal: %+v", result.Proposed)
+	}
+	if len(result.Skipped) != 3 {
+		t.Errorf("expected 3 skipped issues, got %+v", result.Skipped)
+	}
+	if result.Applied || len(*added) != 0 {
+		t.Error("dry run should not apply the plan")
+	}
+}
+
+func TestSprintPlanRequiresPipeline(t *testing.T) {
+	setupSprintPlanTest(t)
+
+	rootCmd.SetOut(new(bytes.Buffer))
+	rootCmd.SetArgs([]string{"sprint", "plan", "--capacity=40"})
+
+	err := rootCmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "--from-pipeline") {
+		t.Errorf("expected a --from-pipeline error, got %v", err)
+	}
+}
+
+func TestSprintPlanRejectsNonPositiveCapacity(t *testing.T) {
+	for _, capacity := range []string{"0", "-5"} {
+		t.Run(capacity, func(t *testing.T) {
+			added := setupSprintPlanTest(t)
+
+			rootCmd.SetOut(new(bytes.Buffer))
+			rootCmd.SetArgs([]string{"sprint", "plan", "--from-pipeline=New Issues", "--capacity=" + capacity})
+
+			err := rootCmd.Execute()
+			if exitcode.ExitCode(err) != exitcode.UsageError || !strings.Contains(err.Error(), "--capacity") {
+				t.Errorf("expected a --capacity usage error, got %v", err)
+			}
+			if len(*added) != 0 {
+				t.Errorf("no issues should be added, added %v", *added)
+			}
+		})
+	}
+}
+
+func TestOrderSprintPlanCandidates(t *testing.T) {
+	priorities := []resolve.CachedPriority{{ID: "pri1"}, {ID: "pri2"}}
+	pos := func(p int) *int { return &p }
+	est := 1.0
+	candidates := []sprintPlanCandidate{
+		{Issue: resolvedSprintIssue{ID: "no-priority"}, Estimate: &est, Position: pos(1)},
+		{Issue: resolvedSprintIssue{ID: "low-no-epic"}, Estimate: &est, Priority: &priorities[1], Position: pos(2)},
+		{Issue: resolvedSprintIssue{ID: "low-epic"}, Estimate: &est, Priority: &priorities[1], Position: pos(2), Epic: "Auth"},
+		{Issue: resolvedSprintIssue{ID: "skipped"}, Skip: "closed"},
+		{Issue: resolvedSprintIssue{ID: "high"}, Estimate: &est, Priority: &priorities[0], Position: pos(9)},
+	}
+
+	eligible, skipped := orderSprintPlanCandidates(candidates, priorities)
+
+	var order []string
+	for _, c := range eligible {
+		order = append(order, c.Issue.ID)
+	}
+	if want := []string{"high", "low-epic", "low-no-epic", "no-priority"}; !slices.Equal(order, want) {
+		t.Errorf("order = %v, want %v", order, want)
+	}
+	if len(skipped) != 1 || skipped[0].Issue.ID != "skipped" {
+		t.Errorf("unexpected skipped: %+v", skipped)
+	}
+
+	// Stops at the first issue that doesn't fit, even if later ones would
+	three, one := 3.0, 1.0
+	proposed := proposeSprintPlan([]sprintPlanCandidate{{Estimate: &one}, {Estimate: &three}, {Estimate: &one}}, 3)
+	if len(proposed) != 1 {
+		t.Errorf("expected 1 proposed issue, got %d", len(proposed))
+	}
+}
+
+// ── test fixtures ────────────────────────────────────────────────────────
+
+func sprintPlanSprintResponse() map[string]any {
+	return map[string]any{
+		"data": map[string]any{
+			"node": map[string]any{
+				"id":            "sprint-48",
+				"name":          "",
+				"generatedName": "Sprint 48",
+				"state":         "OPEN",
+				"startAt":       "2026-02-03T00:00:00Z",
+				"endAt":         "2026-02-17T00:00:00Z",
+				"totalPoints":   float64(13),
+			},
+		},
+	}
+}
+
+func sprintPlanCandidateNode(id string, number int, title string, estimate any, priorityID string, position int) map[string]any {
+	var priority any
+	if priorityID != "" {
+		priority = map[string]any{"id": priorityID, "name": priorityID}
+	}
+	var est any
+	if estimate != nil {
+		est = map[string]any{"value": estimate}
+	}
+	return map[string]any{
+		"id":                id,
+		"number":            number,
+		"title":             title,
+		"state":             "OPEN",
+		"estimate":          est,
+		"repository":        map[string]any{"ghId": 1, "name": "task-tracker", "ownerName": "dlakehammond"},
+		"sprints":           map[string]any{"nodes": []any{}},
+		"parentZenhubEpics": map[string]any{"nodes": []any{}},
+		"blockingItems":     map[string]any{"nodes": []any{}},
+		"pipelineIssue": map[string]any{
+			"relativePosition": position,
+			"priority":         priority,
+		},
+	}
+}
+
+func sprintPlanCandidatesResponse() map[string]any {
+	a := sprintPlanCandidateNode("issue-a", 10, "Add login page", 5, "pri2", 3)
+	a["parentZenhubEpics"] = map[string]any{"nodes": []any{map[string]any{"id": "epic-1", "title": "Auth"}}}
+	b := sprintPlanCandidateNode("issue-b", 11, "Fix crash on save", 8, "pri1", 5)
+	c := sprintPlanCandidateNode("issue-c", 12, "Investigate flaky test", nil, "pri1", 6)
+	d := sprintPlanCandidateNode("issue-d", 13, "Ship export", 3, "pri1", 7)
+	d["blockingItems"] = map[string]any{"nodes": []any{map[string]any{"__typename": "Issue", "state": "OPEN"}}}
+	e := sprintPlanCandidateNode("issue-e", 14, "Tidy README", 2, "", 1)
+	f := sprintPlanCandidateNode("issue-f", 15, "Already planned", 13, "pri1", 8)
+	f["sprints"] = map[string]any{"nodes": []any{map[string]any{"id": "sprint-48"}}}
+	g := sprintPlanCandidateNode("issue-g", 16, "Rewrite sync", 20, "pri2", 4)
+	h := sprintPlanCandidateNode("issue-h", 17, "Polish import", 1, "", 2)
+	h["blockingItems"] = map[string]any{"nodes": []any{map[string]any{"__typename": "Issue", "state": "CLOSED"}}}
+
+	return map[string]any{
+		"data": map[string]any{
+			"searchIssuesByPipeline": map[string]any{
+				"pageInfo": map[string]any{"hasNextPage": false, "endCursor": ""},
+				"nodes":    []any{a, b, c, d, e, f, g, h},
+			},
+		},
+	}
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -95,6 +96,7 @@ const sprintScopeChangeQuery = `query SprintScopeChange($sprintId: ID!, $first:
             number
             title
             state
+            closedAt
             estimate {
               value
             }
@@ -239,6 +241,7 @@ type scopeChangeEvent struct {
 		Number   int    `json:"number"`
 		Title    string `json:"title"`
 		State    string `json:"state"`
+		ClosedAt string `json:"closedAt,omitempty"`
 		Estimate *struct {
 			Value float64 `json:"value"`
 		} `json:"estimate"`
@@ -395,6 +398,8 @@ func resetSprintReportFlags() {
 // ── sprint velocity ──────────────────────────────────────────────────────
 
 func runSprintVelocity(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+
 	cfg, err := requireWorkspace()
 	if err != nil {
 		return err
@@ -403,7 +408,7 @@ func runSprintVelocity(cmd *cobra.Command, args []string) error {
 	client := newClient(cfg, cmd)
 	w := cmd.OutOrStdout()
 
-	data, err := client.Execute(sprintVelocityQuery, map[string]any{
+	data, err := client.Execute(ctx, sprintVelocityQuery, map[string]any{
 		"workspaceId": cfg.Workspace,
 		"sprintCount": velocitySprints,
 	})
@@ -552,6 +557,8 @@ func formatSprintCadence(period int, startDay, endDay string) string {
 // ── sprint scope ─────────────────────────────────────────────────────────
 
 func runSprintScope(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+
 	cfg, err := requireWorkspace()
 	if err != nil {
 		return err
@@ -566,7 +573,7 @@ func runSprintScope(cmd *cobra.Command, args []string) error {
 		identifier = args[0]
 	}
 
-	resolved, err := resolve.Sprint(client, cfg.Workspace, identifier)
+	resolved, err := resolve.Sprint(ctx, client, cfg.Workspace, identifier)
 	if err != nil {
 		return err
 	}
@@ -576,7 +583,7 @@ func runSprintScope(cmd *cobra.Command, args []string) error {
 
 	// For JSON output, respect the limit directly
 	if output.IsJSON(outputFormat) {
-		events, sprint, totalCount, err := fetchScopeChanges(client, resolved.ID, limit)
+		events, sprint, totalCount, err := fetchScopeChanges(ctx, client, resolved.ID, limit)
 		if err != nil {
 			return err
 		}
@@ -596,7 +603,7 @@ func runSprintScope(cmd *cobra.Command, args []string) error {
 	}
 
 	// For human-readable output, fetch all events so the summary is accurate
-	allEvents, sprint, totalCount, err := fetchScopeChanges(client, resolved.ID, 0)
+	allEvents, sprint, totalCount, err := fetchScopeChanges(ctx, client, resolved.ID, 0)
 	if err != nil {
 		return err
 	}
@@ -770,7 +777,7 @@ func (s *scopeSprintDetail) DisplayName() string {
 	return s.GeneratedName
 }
 
-func fetchScopeChanges(client *api.Client, sprintID string, limit int) ([]scopeChangeEvent, *scopeSprintDetail, int, error) {
+func fetchScopeChanges(ctx context.Context, client *api.Client, sprintID string, limit int) ([]scopeChangeEvent, *scopeSprintDetail, int, error) {
 	var allEvents []scopeChangeEvent
 	var cursor *string
 	var sprint *scopeSprintDetail
@@ -796,7 +803,7 @@ func fetchScopeChanges(client *api.Client, sprintID string, limit int) ([]scopeC
 			vars["after"] = *cursor
 		}
 
-		data, err := client.Execute(sprintScopeChangeQuery, vars)
+		data, err := client.Execute(ctx, sprintScopeChangeQuery, vars)
 		if err != nil {
 			return nil, nil, 0, exitcode.General("fetching scope changes", err)
 		}
@@ -860,6 +867,8 @@ func fetchScopeChanges(client *api.Client, sprintID string, limit int) ([]scopeC
 // ── sprint review ────────────────────────────────────────────────────────
 
 func runSprintReview(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+
 	cfg, err := requireWorkspace()
 	if err != nil {
 		return err
@@ -874,13 +883,13 @@ func runSprintReview(cmd *cobra.Command, args []string) error {
 		identifier = args[0]
 	}
 
-	resolved, err := resolve.Sprint(client, cfg.Workspace, identifier)
+	resolved, err := resolve.Sprint(ctx, client, cfg.Workspace, identifier)
 	if err != nil {
 		return err
 	}
 
 	// Fetch sprint review
-	data, err := client.Execute(sprintReviewQuery, map[string]any{
+	data, err := client.Execute(ctx, sprintReviewQuery, map[string]any{
 		"sprintId": resolved.ID,
 	})
 	if err != nil {
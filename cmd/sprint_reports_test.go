@@ -704,6 +704,7 @@ func sprintScopeResponse() map[string]any {
 								"number":   1,
 								"title":    "Add due dates to tasks",
 								"state":    "CLOSED",
+								"closedAt": "2026-01-22T15:00:00Z",
 								"estimate": map[string]any{"value": float64(5)},
 								"repository": map[string]any{
 									"name":      "task-tracker",
@@ -720,6 +721,7 @@ func sprintScopeResponse() map[string]any {
 								"number":   2,
 								"title":    "Fix date parsing bug",
 								"state":    "CLOSED",
+								"closedAt": "2026-01-27T09:00:00Z",
 								"estimate": map[string]any{"value": float64(3)},
 								"repository": map[string]any{
 									"name":      "task-tracker",
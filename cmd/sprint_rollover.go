@@ -0,0 +1,490 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/dslh/zh/internal/api"
+	"github.com/dslh/zh/internal/exitcode"
+	"github.com/dslh/zh/internal/history"
+	"github.com/dslh/zh/internal/output"
+	"github.com/dslh/zh/internal/resolve"
+	"github.com/spf13/cobra"
+)
+
+// GraphQL query for the issues in the sprint being rolled over
+
+const sprintRolloverIssuesQuery = `query GetSprintRolloverIssues($sprintId: ID!, $first: Int!, $after: String) {
+  node(id: $sprintId) {
+    ... on Sprint {
+      id
+      name
+      generatedName
+      state
+      startAt
+      endAt
+      sprintIssues(first: $first, after: $after) {
+        pageInfo {
+          hasNextPage
+          endCursor
+        }
+        nodes {
+          issue {
+            id
+            number
+            title
+            state
+            estimate { value }
+            repository { ghId name ownerName }
+            sprints(first: 10) {
+              nodes { id }
+            }
+            labels(first: 50) {
+              nodes { id name }
+            }
+          }
+        }
+      }
+    }
+  }
+}`
+
+// Command
+
+var sprintRolloverCmd = &cobra.Command{
+	Use:   "rollover [from]",
+	Short: "Carry a sprint's unfinished issues over to another sprint",
+	Long: `Add the issues that are still open in a sprint to another sprint, and print
+a carry-over report in issues and points. Rolls the active sprint over to the
+next one by default. Once a sprint has closed, roll it over with
+'zh sprint rollover previous --to=current'.
+
+Issues already in the target sprint are reported but not added again. With
+--remove, carried issues are also removed from the old sprint.
+
+Carried issues are tagged with the label given by --label, or by
+sprint.rollover_label in config.yml. --no-label skips the configured label.
+
+The sprints can be specified as:
+  - ZenHub ID
+  - sprint name or unique name substring
+  - relative reference: current, next, previous
+
+Examples:
+  zh sprint rollover --dry-run
+  zh sprint rollover previous --to=current --remove
+  zh sprint rollover "Sprint 47" --to="Sprint 48" --label=carried-over`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runSprintRollover,
+}
+
+var (
+	sprintRolloverTo      string
+	sprintRolloverRemove  bool
+	sprintRolloverLabel   string
+	sprintRolloverNoLabel bool
+	sprintRolloverDryRun  bool
+)
+
+func init() {
+	sprintRolloverCmd.Flags().StringVar(&sprintRolloverTo, "to", "next", "Sprint to carry issues over to. Supports name, ID, or current/next/previous")
+	sprintRolloverCmd.Flags().BoolVar(&sprintRolloverRemove, "remove", false, "Also remove carried issues from the old sprint")
+	sprintRolloverCmd.Flags().StringVar(&sprintRolloverLabel, "label", "", "Label to add to carried issues (default: sprint.rollover_label from config)")
+	sprintRolloverCmd.Flags().BoolVar(&sprintRolloverNoLabel, "no-label", false, "Don't label carried issues")
+	sprintRolloverCmd.Flags().BoolVar(&sprintRolloverDryRun, "dry-run", false, "Show what would be carried over without executing")
+
+	sprintCmd.AddCommand(sprintRolloverCmd)
+}
+
+func resetSprintRolloverFlags() {
+	sprintRolloverTo = "next"
+	sprintRolloverRemove = false
+	sprintRolloverLabel = ""
+	sprintRolloverNoLabel = false
+	sprintRolloverDryRun = false
+}
+
+// rolloverIssue is an issue in the sprint being rolled over.
+type rolloverIssue struct {
+	Issue    resolvedSprintIssue
+	RepoGhID int
+	Open     bool
+	Estimate *float64
+	// InTarget is set when the issue is already in the target sprint.
+	InTarget bool
+	// Labeled is set when the issue already has the rollover label.
+	Labeled bool
+}
+
+// rolloverSprint is the sprint being rolled over and its issues.
+type rolloverSprint struct {
+	ID      string
+	Name    string
+	StartAt string
+	EndAt   string
+	Issues  []rolloverIssue
+}
+
+// runSprintRollover implements `zh sprint rollover [from]`.
+func runSprintRollover(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+
+	cfg, err := requireWorkspace()
+	if err != nil {
+		return err
+	}
+
+	if sprintRolloverNoLabel && sprintRolloverLabel != "" {
+		return exitcode.Usage("--label and --no-label cannot be used together")
+	}
+
+	client := newClient(cfg, cmd)
+	w := cmd.OutOrStdout()
+
+	fromRef := "current"
+	if len(args) > 0 {
+		fromRef = args[0]
+	}
+	from, err := resolve.Sprint(ctx, client, cfg.Workspace, fromRef)
+	if err != nil {
+		return err
+	}
+	to, err := resolve.Sprint(ctx, client, cfg.Workspace, sprintRolloverTo)
+	if err != nil {
+		return err
+	}
+	if from.ID == to.ID {
+		return exitcode.Usage(fmt.Sprintf("cannot roll %s over into itself — choose another sprint with --to", from.Name))
+	}
+
+	// Resolve the label before changing anything
+	labelName := sprintRolloverLabel
+	if labelName == "" && !sprintRolloverNoLabel {
+		labelName = cfg.Sprint.RolloverLabel
+	}
+	var label *resolve.LabelResult
+	if labelName != "" {
+		if label, err = resolve.Label(ctx, client, cfg.Workspace, labelName); err != nil {
+			return err
+		}
+	}
+
+	sprint, err := fetchRolloverSprint(ctx, client, from.ID, to.ID, label)
+	if err != nil {
+		return err
+	}
+	sprint.Name = from.Name
+
+	var carried []rolloverIssue
+	for _, iss := range sprint.Issues {
+		if iss.Open {
+			carried = append(carried, iss)
+		}
+	}
+
+	jsonOutput := output.IsJSON(outputFormat)
+	if !jsonOutput {
+		renderSprintRollover(w, sprint, to.Name, label, carried)
+		fmt.Fprintln(w)
+	}
+
+	apply := !sprintRolloverDryRun && len(carried) > 0
+	if apply {
+		if err := executeSprintRollover(cmd, client, cfg.Workspace, sprint, to, label, carried); err != nil {
+			return err
+		}
+	}
+
+	points, _ := sumRolloverPoints(carried)
+	if jsonOutput {
+		var labelJSON any
+		if label != nil {
+			labelJSON = map[string]any{"id": label.ID, "name": label.Name}
+		}
+		return output.JSON(w, map[string]any{
+			"from": map[string]any{
+				"id":      sprint.ID,
+				"name":    sprint.Name,
+				"startAt": sprint.StartAt,
+				"endAt":   sprint.EndAt,
+			},
+			"to":            map[string]any{"id": to.ID, "name": to.Name},
+			"totalIssues":   len(sprint.Issues),
+			"carried":       formatRolloverIssuesJSON(carried),
+			"carriedPoints": points,
+			"label":         labelJSON,
+			"removed":       sprintRolloverRemove,
+			"applied":       apply,
+		})
+	}
+
+	switch {
+	case len(carried) == 0:
+		fmt.Fprintf(w, "No open issues to carry over from %s.\n", sprint.Name)
+	case !apply:
+		output.MutationSingle(w, output.Yellow(fmt.Sprintf("Would carry %d issue(s) (%s pts) over to %s.", len(carried), formatEstimate(points), to.Name)))
+	default:
+		output.MutationSingle(w, output.Green(fmt.Sprintf("Carried %d issue(s) (%s pts) over to %s.", len(carried), formatEstimate(points), to.Name)))
+	}
+	return nil
+}
+
+// fetchRolloverSprint fetches every issue in a sprint, noting which are
+// already in the target sprint or already have the rollover label.
+func fetchRolloverSprint(ctx context.Context, client *api.Client, sprintID, targetID string, label *resolve.LabelResult) (*rolloverSprint, error) {
+	sprint := &rolloverSprint{}
+	vars := map[string]any{"sprintId": sprintID, "first": 100}
+	err := forEachQueryPage(ctx, client, sprintRolloverIssuesQuery, vars, "sprint issues", func(data json.RawMessage) (pageInfoNode, error) {
+		var resp struct {
+			Node *struct {
+				ID           string `json:"id"`
+				StartAt      string `json:"startAt"`
+				EndAt        string `json:"endAt"`
+				SprintIssues struct {
+					PageInfo pageInfoNode `json:"pageInfo"`
+					Nodes    []struct {
+						Issue struct {
+							ID       string `json:"id"`
+							Number   int    `json:"number"`
+							Title    string `json:"title"`
+							State    string `json:"state"`
+							Estimate *struct {
+								Value float64 `json:"value"`
+							} `json:"estimate"`
+							Repository struct {
+								GhID      int    `json:"ghId"`
+								Name      string `json:"name"`
+								OwnerName string `json:"ownerName"`
+							} `json:"repository"`
+							Sprints struct {
+								Nodes []struct {
+									ID string `json:"id"`
+								} `json:"nodes"`
+							} `json:"sprints"`
+							Labels struct {
+								Nodes []struct {
+									ID string `json:"id"`
+								} `json:"nodes"`
+							} `json:"labels"`
+						} `json:"issue"`
+					} `json:"nodes"`
+				} `json:"sprintIssues"`
+			} `json:"node"`
+		}
+		if err := json.Unmarshal(data, &resp); err != nil {
+			return pageInfoNode{}, err
+		}
+		if resp.Node == nil {
+			return pageInfoNode{}, exitcode.NotFoundError(fmt.Sprintf("sprint %q not found", sprintID))
+		}
+
+		sprint.ID, sprint.StartAt, sprint.EndAt = resp.Node.ID, resp.Node.StartAt, resp.Node.EndAt
+		for _, n := range resp.Node.SprintIssues.Nodes {
+			iss := n.Issue
+			r := rolloverIssue{
+				Issue: resolvedSprintIssue{
+					ID:        iss.ID,
+					Number:    iss.Number,
+					RepoName:  iss.Repository.Name,
+					RepoOwner: iss.Repository.OwnerName,
+					Title:     iss.Title,
+				},
+				RepoGhID: iss.Repository.GhID,
+				Open:     iss.State != "CLOSED",
+			}
+			if iss.Estimate != nil {
+				r.Estimate = &iss.Estimate.Value
+			}
+			for _, s := range iss.Sprints.Nodes {
+				r.InTarget = r.InTarget || s.ID == targetID
+			}
+			if label != nil {
+				for _, l := range iss.Labels.Nodes {
+					r.Labeled = r.Labeled || l.ID == label.ID
+				}
+			}
+			sprint.Issues = append(sprint.Issues, r)
+		}
+		return resp.Node.SprintIssues.PageInfo, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sprint, nil
+}
+
+// executeSprintRollover adds the carried issues to the target sprint, labels
+// them and, with --remove, removes them from the old sprint. Each step that
+// succeeds is recorded in the history journal, even if a later one fails.
+func executeSprintRollover(cmd *cobra.Command, client *api.Client, workspaceID string, from *rolloverSprint, to *resolve.SprintResult, label *resolve.LabelResult, carried []rolloverIssue) error {
+	ctx := commandContext(cmd)
+
+	var changes []history.Change
+	record := func() {
+		recordHistory(cmd.ErrOrStderr(), workspaceID, "sprint rollover",
+			fmt.Sprintf("Carried %d issue(s) over from %s to %s", len(carried), from.Name, to.Name), changes)
+	}
+	change := func(iss rolloverIssue, kind string, before, after *history.Value) history.Change {
+		return history.Change{
+			Kind: kind,
+			Issue: history.Issue{
+				ID:        iss.Issue.ID,
+				Number:    iss.Issue.Number,
+				RepoName:  iss.Issue.RepoName,
+				RepoOwner: iss.Issue.RepoOwner,
+				RepoGhID:  iss.RepoGhID,
+			},
+			Before: before,
+			After:  after,
+		}
+	}
+
+	var toAdd, toLabel []string
+	for _, iss := range carried {
+		if !iss.InTarget {
+			toAdd = append(toAdd, iss.Issue.ID)
+		}
+		if label != nil && !iss.Labeled {
+			toLabel = append(toLabel, iss.Issue.ID)
+		}
+	}
+
+	if len(toAdd) > 0 {
+		_, err := client.Execute(ctx, addIssuesToSprintsMutation, map[string]any{
+			"input": map[string]any{
+				"issueIds":  toAdd,
+				"sprintIds": []string{to.ID},
+			},
+		})
+		if err != nil {
+			return exitcode.General("adding issues to sprint", err)
+		}
+		toValue := &history.Value{ID: to.ID, Name: to.Name}
+		for _, iss := range carried {
+			if slices.Contains(toAdd, iss.Issue.ID) {
+				changes = append(changes, change(iss, history.KindSprint, nil, toValue))
+			}
+		}
+	}
+
+	if len(toLabel) > 0 {
+		_, err := client.Execute(ctx, addLabelsToIssuesMutation, map[string]any{
+			"input": map[string]any{
+				"issueIds": toLabel,
+				"labelIds": []string{label.ID},
+			},
+		})
+		if err != nil {
+			record()
+			return exitcode.General("adding labels", err)
+		}
+		labelValue := &history.Value{ID: label.ID, Name: label.Name}
+		for _, iss := range carried {
+			if slices.Contains(toLabel, iss.Issue.ID) {
+				changes = append(changes, change(iss, history.KindLabel, nil, labelValue))
+			}
+		}
+	}
+
+	if sprintRolloverRemove {
+		issueIDs := make([]string, len(carried))
+		for i, iss := range carried {
+			issueIDs[i] = iss.Issue.ID
+		}
+		_, err := client.Execute(ctx, removeIssuesFromSprintsMutation, map[string]any{
+			"input": map[string]any{
+				"issueIds":  issueIDs,
+				"sprintIds": []string{from.ID},
+			},
+		})
+		if err != nil {
+			record()
+			return exitcode.General("removing issues from sprint", err)
+		}
+		fromValue := &history.Value{ID: from.ID, Name: from.Name}
+		for _, iss := range carried {
+			changes = append(changes, change(iss, history.KindSprint, fromValue, nil))
+		}
+	}
+
+	record()
+	return nil
+}
+
+// sumRolloverPoints returns the estimate total of the issues and how many
+// of them have no estimate.
+func sumRolloverPoints(issues []rolloverIssue) (points float64, unestimated int) {
+	for _, iss := range issues {
+		if iss.Estimate == nil {
+			unestimated++
+		} else {
+			points += *iss.Estimate
+		}
+	}
+	return points, unestimated
+}
+
+func renderSprintRollover(w io.Writer, sprint *rolloverSprint, toName string, label *resolve.LabelResult, carried []rolloverIssue) {
+	totalPoints, _ := sumRolloverPoints(sprint.Issues)
+	points, unestimated := sumRolloverPoints(carried)
+	closed := len(sprint.Issues) - len(carried)
+
+	d := output.NewDetailWriter(w, "ROLLOVER", sprint.Name+" → "+toName)
+	fields := []output.KeyValue{
+		output.KV("From", fmt.Sprintf("%s %s", sprint.Name, output.Dim("("+formatSprintDates(sprint.StartAt, sprint.EndAt)+")"))),
+		output.KV("To", toName),
+		output.KV("Completed", fmt.Sprintf("%d of %d issue(s), %s of %s pts",
+			closed, len(sprint.Issues), formatEstimate(totalPoints-points), formatEstimate(totalPoints))),
+	}
+	carriedStr := fmt.Sprintf("%d issue(s), %s pts", len(carried), formatEstimate(points))
+	if unestimated > 0 {
+		carriedStr += output.Yellow(fmt.Sprintf(" (%d unestimated)", unestimated))
+	}
+	fields = append(fields, output.KV("Carried over", carriedStr))
+	if label != nil {
+		fields = append(fields, output.KV("Label", label.Name))
+	}
+	if sprintRolloverRemove {
+		fields = append(fields, output.KV("Old sprint", "issues are removed from "+sprint.Name))
+	} else {
+		fields = append(fields, output.KV("Old sprint", "issues stay in "+sprint.Name))
+	}
+	d.Fields(fields)
+
+	if len(carried) == 0 {
+		return
+	}
+
+	d.Section("CARRIED OVER")
+	lw := output.NewListWriter(w, "ISSUE", "EST", "TITLE", "NOTE")
+	for _, iss := range carried {
+		est := output.Dim("-")
+		if iss.Estimate != nil {
+			est = formatEstimate(*iss.Estimate)
+		}
+		note := ""
+		if iss.InTarget {
+			note = output.Dim("already in " + toName)
+		}
+		lw.Row(iss.Issue.Ref(), est, truncateTitle(iss.Issue.Title), note)
+	}
+	lw.Flush()
+}
+
+func formatRolloverIssuesJSON(issues []rolloverIssue) []map[string]any {
+	result := make([]map[string]any, len(issues))
+	for i, iss := range issues {
+		result[i] = map[string]any{
+			"id":              iss.Issue.ID,
+			"number":          iss.Issue.Number,
+			"repository":      fmt.Sprintf("%s/%s", iss.Issue.RepoOwner, iss.Issue.RepoName),
+			"title":           iss.Issue.Title,
+			"estimate":        formatEstimateJSON(iss.Estimate),
+			"alreadyInSprint": iss.InTarget,
+		}
+	}
+	return result
+}
@@ -0,0 +1,261 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/dslh/zh/internal/api"
+	"github.com/dslh/zh/internal/history"
+	"github.com/dslh/zh/internal/testutil"
+)
+
+// rolloverMutation is the input of one mutation made by zh sprint rollover.
+type rolloverMutation struct {
+	IssueIDs  []string `json:"issueIds"`
+	SprintIDs []string `json:"sprintIds"`
+	LabelIDs  []string `json:"labelIds"`
+}
+
+// setupSprintRolloverTest serves Sprint 47's issues and returns the inputs
+// of the mutations made, keyed by mutation name. configYAML is written to
+// config.yml if set.
+func setupSprintRolloverTest(t *testing.T, configYAML string) map[string]rolloverMutation {
+	t.Helper()
+	resetSprintFlags()
+	resetSprintRolloverFlags()
+	setupHistoryDataDir(t)
+
+	ms := testutil.NewMockServer(t)
+	ms.HandleQuery("ListSprints", sprintResolutionResponse())
+	ms.HandleQuery("GetWorkspaceLabels", labelListResponse())
+	ms.HandleQuery("GetSprintRolloverIssues", sprintRolloverIssuesResponse())
+
+	mutations := map[string]rolloverMutation{}
+	for _, name := range []string{"AddIssuesToSprints", "RemoveIssuesFromSprints", "AddLabelsToIssues"} {
+		ms.Handle(
+			func(req testutil.GraphQLRequest) bool { return strings.Contains(req.Query, "mutation "+name) },
+			func(w http.ResponseWriter, req testutil.GraphQLRequest) {
+				var vars struct {
+					Input rolloverMutation `json:"input"`
+				}
+				_ = json.Unmarshal(req.Variables, &vars)
+				mutations[name] = vars.Input
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"data":{}}`))
+			},
+		)
+	}
+
+	configDir := t.TempDir()
+	if configYAML != "" {
+		if err := os.MkdirAll(filepath.Join(configDir, "zh"), 0o700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(configDir, "zh", "config.yml"), []byte(configYAML), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", configDir)
+	t.Setenv("ZH_API_KEY", "test-key")
+	t.Setenv("ZH_WORKSPACE", "ws-123")
+	t.Setenv("ZH_GITHUB_TOKEN", "")
+
+	origNew := apiNewFunc
+	apiNewFunc = func(apiKey string, opts ...api.Option) *api.Client {
+		return api.New(apiKey, append(opts, api.WithEndpoint(ms.URL()))...)
+	}
+	t.Cleanup(func() { apiNewFunc = origNew })
+
+	return mutations
+}
+
+func TestSprintRolloverDryRun(t *testing.T) {
+	mutations := setupSprintRolloverTest(t, "")
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"sprint", "rollover", "--dry-run"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("sprint rollover returned error: %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{
+		"ROLLOVER: Sprint 47 → Sprint 48",
+		"1 of 4 issue(s), 3 of 16 pts",
+		"3 issue(s), 13 pts (1 unestimated)",
+		"issues stay in Sprint 47",
+		"CARRIED OVER",
+		"task-tracker#1",
+		"already in Sprint 48",
+		"Would carry 3 issue(s) (13 pts) over to Sprint 48.",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output should contain %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "task-tracker#2 ") {
+		t.Errorf("closed issue should not be carried over:\n%s", out)
+	}
+	if len(mutations) != 0 {
+		t.Errorf("dry run should not make mutations, made %v", mutations)
+	}
+}
+
+func TestSprintRolloverApply(t *testing.T) {
+	mutations := setupSprintRolloverTest(t, "sprint:\n  rollover_label: enhancement\n")
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"sprint", "rollover", "--remove"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("sprint rollover returned error: %v", err)
+	}
+
+	// task-tracker#3 is already in Sprint 48 and task-tracker#4 is already labeled
+	if m := mutations["AddIssuesToSprints"]; !slices.Equal(m.IssueIDs, []string{"r1", "r4"}) || !slices.Equal(m.SprintIDs, []string{"sprint-48"}) {
+		t.Errorf("unexpected add: %+v", m)
+	}
+	if m := mutations["AddLabelsToIssues"]; !slices.Equal(m.IssueIDs, []string{"r1", "r3"}) || !slices.Equal(m.LabelIDs, []string{"l2"}) {
+		t.Errorf("unexpected label: %+v", m)
+	}
+	if m := mutations["RemoveIssuesFromSprints"]; !slices.Equal(m.IssueIDs, []string{"r1", "r3", "r4"}) || !slices.Equal(m.SprintIDs, []string{"sprint-47"}) {
+		t.Errorf("unexpected remove: %+v", m)
+	}
+	if !strings.Contains(buf.String(), "Carried 3 issue(s) (13 pts) over to Sprint 48.") {
+		t.Errorf("output should confirm the rollover:\n%s", buf.String())
+	}
+
+	entries, err := history.Load("ws-123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Command != "sprint rollover" {
+		t.Fatalf("expected one sprint rollover entry, got %+v", entries)
+	}
+	kinds := map[string]int{}
+	for _, c := range entries[0].Changes {
+		kinds[c.Kind]++
+	}
+	if kinds[history.KindSprint] != 5 || kinds[history.KindLabel] != 2 {
+		t.Errorf("expected 5 sprint changes and 2 label changes, got %v", kinds)
+	}
+}
+
+func TestSprintRolloverJSON(t *testing.T) {
+	mutations := setupSprintRolloverTest(t, "sprint:\n  rollover_label: enhancement\n")
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"sprint", "rollover", "previous", "--to=current", "--no-label", "--dry-run", "--output=json"})
+	defer func() { outputFormat = "" }()
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("sprint rollover returned error: %v", err)
+	}
+
+	var result struct {
+		From struct {
+			ID string `json:"id"`
+		} `json:"from"`
+		To struct {
+			Name string `json:"name"`
+		} `json:"to"`
+		TotalIssues   int              `json:"totalIssues"`
+		Carried       []map[string]any `json:"carried"`
+		CarriedPoints float64          `json:"carriedPoints"`
+		Label         any              `json:"label"`
+		Applied       bool             `json:"applied"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+
+	if result.From.ID != "sprint-47" || result.To.Name != "Sprint 47" {
+		t.Errorf("from=%v to=%v", result.From, result.To)
+	}
+	if result.TotalIssues != 4 || len(result.Carried) != 3 || result.CarriedPoints != 13 {
+		t.Errorf("totalIssues=%d carried=%d carriedPoints=%v, want 4, 3 and 13", result.TotalIssues, len(result.Carried), result.CarriedPoints)
+	}
+	if result.Label != nil {
+		t.Errorf("--no-label should skip the configured label, got %v", result.Label)
+	}
+	if result.Applied || len(mutations) != 0 {
+		t.Error("dry run should not apply the rollover")
+	}
+}
+
+func TestSprintRolloverSameSprint(t *testing.T) {
+	setupSprintRolloverTest(t, "")
+
+	rootCmd.SetOut(new(bytes.Buffer))
+	rootCmd.SetArgs([]string{"sprint", "rollover", "--to=current"})
+
+	err := rootCmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "into itself") {
+		t.Errorf("expected an error rolling a sprint into itself, got %v", err)
+	}
+}
+
+// ── test fixtures ────────────────────────────────────────────────────────
+
+func sprintRolloverIssue(id string, number int, title, state string, estimate any, sprintIDs []string, labelIDs []string) map[string]any {
+	var est any
+	if estimate != nil {
+		est = map[string]any{"value": estimate}
+	}
+	sprints := make([]any, len(sprintIDs))
+	for i, s := range sprintIDs {
+		sprints[i] = map[string]any{"id": s}
+	}
+	labels := make([]any, len(labelIDs))
+	for i, l := range labelIDs {
+		labels[i] = map[string]any{"id": l, "name": l}
+	}
+	return map[string]any{
+		"issue": map[string]any{
+			"id":         id,
+			"number":     number,
+			"title":      title,
+			"state":      state,
+			"estimate":   est,
+			"repository": map[string]any{"ghId": 1, "name": "task-tracker", "ownerName": "dlakehammond"},
+			"sprints":    map[string]any{"nodes": sprints},
+			"labels":     map[string]any{"nodes": labels},
+		},
+	}
+}
+
+func sprintRolloverIssuesResponse() map[string]any {
+	return map[string]any{
+		"data": map[string]any{
+			"node": map[string]any{
+				"id":            "sprint-47",
+				"name":          "",
+				"generatedName": "Sprint 47",
+				"state":         "OPEN",
+				"startAt":       "2026-01-20T00:00:00Z",
+				"endAt":         "2026-02-03T00:00:00Z",
+				"sprintIssues": map[string]any{
+					"pageInfo": map[string]any{"hasNextPage": false, "endCursor": ""},
+					"nodes": []any{
+						sprintRolloverIssue("r1", 1, "Fix login button alignment", "OPEN", 5, []string{"sprint-47"}, nil),
+						sprintRolloverIssue("r2", 2, "Update error messages", "CLOSED", 3, []string{"sprint-47"}, nil),
+						sprintRolloverIssue("r3", 3, "Investigate timeout", "OPEN", nil, []string{"sprint-47", "sprint-48"}, nil),
+						sprintRolloverIssue("r4", 4, "Add rate limiting", "OPEN", 8, []string{"sprint-47"}, []string{"l2"}),
+					},
+				},
+			},
+		},
+	}
+}
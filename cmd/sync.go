@@ -0,0 +1,289 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/dslh/zh/internal/api"
+	"github.com/dslh/zh/internal/exitcode"
+	"github.com/dslh/zh/internal/index"
+	"github.com/dslh/zh/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// GraphQL queries for sync
+
+// syncIssueFields are the issue fields stored in the local index. Issues are
+// ordered by updatedAt, newest first, so that a sync can stop at the
+// watermark.
+const syncIssueFields = `order: { field: updated_at, direction: DESC }
+    first: 100
+    after: $after
+  ) {
+    pageInfo {
+      hasNextPage
+      endCursor
+    }
+    nodes {
+      id
+      number
+      title
+      body
+      state
+      pullRequest
+      updatedAt
+      estimate {
+        value
+      }
+      repository {
+        name
+        ownerName
+      }
+      assignees(first: 20) {
+        nodes {
+          login
+        }
+      }
+      labels(first: 50) {
+        nodes {
+          name
+        }
+      }
+      pipelineIssue(workspaceId: $workspaceId) {
+        pipeline {
+          id
+          name
+        }
+      }
+    }
+  }
+}`
+
+const syncPipelineIssuesQuery = `query SyncPipelineIssues($pipelineId: ID!, $workspaceId: ID!, $after: String) {
+  searchIssuesByPipeline(
+    pipelineId: $pipelineId
+    filters: {}
+    ` + syncIssueFields
+
+const syncClosedIssuesQuery = `query SyncClosedIssues($workspaceId: ID!, $after: String) {
+  searchClosedIssues(
+    workspaceId: $workspaceId
+    filters: {}
+    ` + syncIssueFields
+
+// Commands
+
+var syncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Update the local issue index",
+	Long: `Mirror the workspace's issues, open and closed, into a local index used
+by zh search and by shell completion of issue references.
+
+The first sync fetches every issue. Later syncs only fetch issues updated
+since the last one. Use --full to rebuild the index from scratch, which also
+drops issues that have left the workspace.
+
+The index is stored in the cache directory and is removed by zh cache clear.
+
+Examples:
+  zh sync
+  zh sync --full`,
+	Args: cobra.NoArgs,
+	RunE: runSync,
+}
+
+var syncFull bool
+
+func init() {
+	syncCmd.Flags().BoolVar(&syncFull, "full", false, "Rebuild the index instead of fetching recent changes")
+
+	rootCmd.AddCommand(syncCmd)
+}
+
+func resetSyncFlags() {
+	syncFull = false
+}
+
+// runSync implements `zh sync`.
+func runSync(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+
+	cfg, err := requireWorkspace()
+	if err != nil {
+		return err
+	}
+
+	client := newClient(cfg, cmd)
+	w := cmd.OutOrStdout()
+
+	idx, ok := index.Load(cfg.Workspace)
+	incremental := ok && !syncFull
+	if !incremental {
+		idx = &index.Index{WorkspaceID: cfg.Workspace}
+	}
+
+	issues, err := fetchSyncIssues(ctx, client, cfg.Workspace, idx.Watermark)
+	if err != nil {
+		return err
+	}
+
+	added, updated := idx.Merge(issues)
+	idx.SyncedAt = time.Now().UTC()
+	if err := index.Save(idx); err != nil {
+		return exitcode.General("saving index", err)
+	}
+
+	if output.IsJSON(outputFormat) {
+		return output.JSON(w, map[string]any{
+			"full":      !incremental,
+			"added":     added,
+			"updated":   updated,
+			"issues":    len(idx.Issues),
+			"syncedAt":  idx.SyncedAt,
+			"watermark": idx.Watermark,
+		})
+	}
+
+	switch {
+	case !incremental:
+		fmt.Fprintf(w, "Indexed %d issue(s).\n", len(idx.Issues))
+	case added+updated == 0:
+		fmt.Fprintf(w, "Index is up to date (%d issue(s)).\n", len(idx.Issues))
+	default:
+		fmt.Fprintf(w, "Synced %d issue(s): %d new, %d updated. The index has %d issue(s).\n", added+updated, added, updated, len(idx.Issues))
+	}
+	return nil
+}
+
+// fetchSyncIssues fetches the issues updated since the watermark, or every
+// issue if the watermark is zero. Pipelines are scanned in parallel.
+func fetchSyncIssues(ctx context.Context, client *api.Client, workspaceID string, watermark time.Time) ([]index.Issue, error) {
+	pipelines, err := fetchWorkspacePipelines(ctx, client, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	type scan struct {
+		query string
+		vars  map[string]any
+		what  string
+	}
+	scans := []scan{{syncClosedIssuesQuery, map[string]any{"workspaceId": workspaceID}, "closed issues"}}
+	for _, p := range pipelines {
+		scans = append(scans, scan{syncPipelineIssuesQuery, map[string]any{"pipelineId": p.ID, "workspaceId": workspaceID}, "issues in " + p.Name})
+	}
+
+	results := make([][]index.Issue, len(scans))
+	errs := make([]error, len(scans))
+	var wg sync.WaitGroup
+	for i, s := range scans {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], errs[i] = fetchSyncIssuePages(ctx, client, s.query, s.vars, s.what, watermark)
+		}()
+	}
+	wg.Wait()
+
+	var issues []index.Issue
+	for i := range scans {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		issues = append(issues, results[i]...)
+	}
+	return issues, nil
+}
+
+// fetchSyncIssuePages pages through one issue search, newest first, stopping
+// at the first issue updated before the watermark.
+func fetchSyncIssuePages(ctx context.Context, client *api.Client, query string, vars map[string]any, what string, watermark time.Time) ([]index.Issue, error) {
+	var issues []index.Issue
+	err := forEachQueryPage(ctx, client, query, vars, what, func(data json.RawMessage) (pageInfoNode, error) {
+		var resp map[string]struct {
+			PageInfo pageInfoNode    `json:"pageInfo"`
+			Nodes    []syncIssueNode `json:"nodes"`
+		}
+		if err := json.Unmarshal(data, &resp); err != nil {
+			return pageInfoNode{}, err
+		}
+
+		for _, conn := range resp {
+			for _, n := range conn.Nodes {
+				issue := n.issue()
+				if !watermark.IsZero() && issue.UpdatedAt.Before(watermark) {
+					return pageInfoNode{}, nil
+				}
+				issues = append(issues, issue)
+			}
+			return conn.PageInfo, nil
+		}
+		return pageInfoNode{}, nil
+	})
+	return issues, err
+}
+
+type syncIssueNode struct {
+	ID          string    `json:"id"`
+	Number      int       `json:"number"`
+	Title       string    `json:"title"`
+	Body        string    `json:"body"`
+	State       string    `json:"state"`
+	PullRequest bool      `json:"pullRequest"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+	Estimate    *struct {
+		Value float64 `json:"value"`
+	} `json:"estimate"`
+	Repository struct {
+		Name      string `json:"name"`
+		OwnerName string `json:"ownerName"`
+	} `json:"repository"`
+	Assignees struct {
+		Nodes []struct {
+			Login string `json:"login"`
+		} `json:"nodes"`
+	} `json:"assignees"`
+	Labels struct {
+		Nodes []struct {
+			Name string `json:"name"`
+		} `json:"nodes"`
+	} `json:"labels"`
+	PipelineIssue *struct {
+		Pipeline *struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+		} `json:"pipeline"`
+	} `json:"pipelineIssue"`
+}
+
+// issue converts an issue from the API to its indexed form.
+func (n syncIssueNode) issue() index.Issue {
+	issue := index.Issue{
+		ID:          n.ID,
+		Number:      n.Number,
+		RepoName:    n.Repository.Name,
+		RepoOwner:   n.Repository.OwnerName,
+		Title:       n.Title,
+		Body:        n.Body,
+		State:       n.State,
+		PullRequest: n.PullRequest,
+		UpdatedAt:   n.UpdatedAt,
+	}
+	if n.Estimate != nil {
+		issue.Estimate = &n.Estimate.Value
+	}
+	if n.PipelineIssue != nil && n.PipelineIssue.Pipeline != nil && n.State != "CLOSED" {
+		issue.PipelineID = n.PipelineIssue.Pipeline.ID
+		issue.Pipeline = n.PipelineIssue.Pipeline.Name
+	}
+	for _, a := range n.Assignees.Nodes {
+		issue.Assignees = append(issue.Assignees, a.Login)
+	}
+	for _, l := range n.Labels.Nodes {
+		issue.Labels = append(issue.Labels, l.Name)
+	}
+	return issue
+}
@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dslh/zh/internal/index"
+	"github.com/dslh/zh/internal/testutil"
+)
+
+func syncIssueNodeResponse(id string, number int, title, state, pipelineID, pipelineName, updatedAt string) map[string]any {
+	node := map[string]any{
+		"id":          id,
+		"number":      number,
+		"title":       title,
+		"body":        "Body of " + title,
+		"state":       state,
+		"pullRequest": false,
+		"updatedAt":   updatedAt,
+		"estimate":    nil,
+		"repository":  map[string]any{"name": "api", "ownerName": "acme"},
+		"assignees":   map[string]any{"nodes": []any{map[string]any{"login": "alice"}}},
+		"labels":      map[string]any{"nodes": []any{map[string]any{"name": "bug"}}},
+		"pipelineIssue": map[string]any{
+			"pipeline": map[string]any{"id": pipelineID, "name": pipelineName},
+		},
+	}
+	if pipelineID == "" {
+		node["pipelineIssue"] = nil
+	}
+	return node
+}
+
+// setupSyncServer serves api#1 (updated on the 10th) and api#2 (the 1st) in
+// New Issues, api#3 (the 5th) in In Development and the closed api#4 (the
+// 8th), each search newest first.
+func setupSyncServer(t *testing.T) {
+	t.Helper()
+	resetSyncFlags()
+
+	ms := testutil.NewMockServer(t)
+	ms.HandleQuery("ListPipelinesFull", pipelineListResponse())
+	for pipelineID, nodes := range map[string][]any{
+		"p1": {
+			syncIssueNodeResponse("i1", 1, "Login timeout", "OPEN", "p1", "New Issues", "2026-10-10T00:00:00Z"),
+			syncIssueNodeResponse("i2", 2, "Dark mode", "OPEN", "p1", "New Issues", "2026-10-01T00:00:00Z"),
+		},
+		"p2": {syncIssueNodeResponse("i3", 3, "Session expiry", "OPEN", "p2", "In Development", "2026-10-05T00:00:00Z")},
+		"p3": {},
+	} {
+		resp, _ := json.Marshal(backupPageResponse("searchIssuesByPipeline", nodes...))
+		ms.Handle(
+			func(req testutil.GraphQLRequest) bool {
+				return strings.Contains(req.Query, "SyncPipelineIssues") && strings.Contains(string(req.Variables), `"pipelineId":"`+pipelineID+`"`)
+			},
+			func(w http.ResponseWriter, req testutil.GraphQLRequest) { _, _ = w.Write(resp) },
+		)
+	}
+	ms.HandleQuery("SyncClosedIssues", backupPageResponse("searchClosedIssues",
+		syncIssueNodeResponse("i4", 4, "Old crash", "CLOSED", "", "", "2026-10-08T00:00:00Z"),
+	))
+	setupIssueTestEnv(t, ms)
+}
+
+func TestSync(t *testing.T) {
+	setupSyncServer(t)
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"sync"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("sync returned error: %v", err)
+	}
+
+	if !strings.Contains(buf.String(), "Indexed 4 issue(s).") {
+		t.Errorf("unexpected output: %s", buf.String())
+	}
+
+	idx, ok := index.Load("ws-123")
+	if !ok {
+		t.Fatal("sync should save the index")
+	}
+	if !idx.Watermark.Equal(time.Date(2026, 10, 10, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("watermark = %v, want the latest updatedAt", idx.Watermark)
+	}
+	for _, issue := range idx.Issues {
+		if issue.Ref() == "api#1" && (issue.Pipeline != "New Issues" || issue.Labels[0] != "bug" || issue.Assignees[0] != "alice" || issue.Body != "Body of Login timeout") {
+			t.Errorf("unexpected indexed issue: %+v", issue)
+		}
+		if issue.Ref() == "api#4" && (issue.State != "CLOSED" || issue.Pipeline != "") {
+			t.Errorf("closed issue should have no pipeline: %+v", issue)
+		}
+	}
+}
+
+func TestSyncIncremental(t *testing.T) {
+	setupSyncServer(t)
+
+	// Synced on the 6th: api#1 and api#4 have changed since. api#2 and
+	// api#3 were last updated before then, so are not fetched again.
+	_ = index.Save(&index.Index{
+		WorkspaceID: "ws-123",
+		Watermark:   time.Date(2026, 10, 6, 0, 0, 0, 0, time.UTC),
+		Issues: []index.Issue{
+			{ID: "i1", Number: 1, RepoName: "api", Title: "Login bug", State: "OPEN", UpdatedAt: time.Date(2026, 10, 2, 0, 0, 0, 0, time.UTC)},
+			{ID: "i2", Number: 2, RepoName: "api", Title: "Dark mode", State: "OPEN", UpdatedAt: time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)},
+			{ID: "i4", Number: 4, RepoName: "api", Title: "Old crash", State: "OPEN", UpdatedAt: time.Date(2026, 10, 3, 0, 0, 0, 0, time.UTC)},
+		},
+	})
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"sync"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("sync returned error: %v", err)
+	}
+
+	if !strings.Contains(buf.String(), "Synced 2 issue(s): 0 new, 2 updated. The index has 3 issue(s).") {
+		t.Errorf("only issues updated since the watermark should be fetched, got: %s", buf.String())
+	}
+
+	idx, _ := index.Load("ws-123")
+	if idx.Issues[0].Title != "Login timeout" || idx.Issues[2].State != "CLOSED" {
+		t.Errorf("updated issues should replace their old copies: %+v", idx.Issues)
+	}
+}
+
+func TestSyncFull(t *testing.T) {
+	setupSyncServer(t)
+
+	_ = index.Save(&index.Index{
+		WorkspaceID: "ws-123",
+		Watermark:   time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC),
+		Issues:      []index.Issue{{ID: "gone", Number: 99, RepoName: "api", State: "OPEN"}},
+	})
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"sync", "--full"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("sync returned error: %v", err)
+	}
+
+	idx, _ := index.Load("ws-123")
+	if len(idx.Issues) != 4 {
+		t.Errorf("a full sync should rebuild the index, got %+v", idx.Issues)
+	}
+}
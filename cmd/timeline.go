@@ -4,6 +4,7 @@ package cmd
 // used by both `zh issue activity` and `zh activity`.
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -139,7 +140,7 @@ const githubIssueTimelineQuery = `query GetGitHubTimeline($owner: String!, $repo
 }`
 
 // fetchZenHubTimelineByNode fetches ZenHub timeline items using a ZenHub node ID.
-func fetchZenHubTimelineByNode(client *api.Client, nodeID string) (struct {
+func fetchZenHubTimelineByNode(ctx context.Context, client *api.Client, nodeID string) (struct {
 	Number    int
 	Title     string
 	RepoName  string
@@ -165,7 +166,7 @@ func fetchZenHubTimelineByNode(client *api.Client, nodeID string) (struct {
 			vars["after"] = *cursor
 		}
 
-		data, err := client.Execute(issueTimelineByNodeQuery, vars)
+		data, err := client.Execute(ctx, issueTimelineByNodeQuery, vars)
 		if err != nil {
 			return info, nil, exitcode.General("fetching issue timeline", err)
 		}
@@ -496,7 +497,7 @@ type ghTimelineResult struct {
 }
 
 // fetchGitHubTimeline fetches timeline events from the GitHub API.
-func fetchGitHubTimeline(ghClient *gh.Client, owner, repo string, number int) (*ghTimelineResult, error) {
+func fetchGitHubTimeline(ctx context.Context, ghClient *gh.Client, owner, repo string, number int) (*ghTimelineResult, error) {
 	result := &ghTimelineResult{}
 	var cursor *string
 	pageSize := 100
@@ -512,7 +513,7 @@ func fetchGitHubTimeline(ghClient *gh.Client, owner, repo string, number int) (*
 			vars["after"] = *cursor
 		}
 
-		data, err := ghClient.Execute(githubIssueTimelineQuery, vars)
+		data, err := ghClient.Execute(ctx, githubIssueTimelineQuery, vars)
 		if err != nil {
 			return nil, err
 		}
@@ -0,0 +1,749 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/dslh/zh/internal/api"
+	"github.com/dslh/zh/internal/config"
+	"github.com/dslh/zh/internal/exitcode"
+	"github.com/dslh/zh/internal/gh"
+	"github.com/dslh/zh/internal/history"
+	"github.com/dslh/zh/internal/output"
+	"github.com/dslh/zh/internal/resolve"
+	"github.com/spf13/cobra"
+)
+
+// Commands
+
+var triageCmd = &cobra.Command{
+	Use:   "triage",
+	Short: "Step through a pipeline's issues and triage them",
+	Long: `Step through each open issue in a pipeline, showing its details, and
+set its estimate, priority, labels, epic and pipeline with single keystrokes.
+Defaults to the first pipeline on the board (usually "New Issues").
+
+Keys:
+  e  set estimate (blank clears)     E  add to epic
+  p  set priority (blank clears)     m  move to pipeline
+  t  toggle label                    u  undo the last change
+  n  next issue (skip)               b  previous issue
+  j/k  scroll                        q  finish
+
+A summary of the changes made is printed when triage finishes.
+
+Examples:
+  zh triage
+  zh triage --pipeline=Icebox
+  zh triage --limit=20`,
+	Args: cobra.NoArgs,
+	RunE: runTriage,
+}
+
+var (
+	triagePipeline string
+	triageLimit    int
+)
+
+func init() {
+	triageCmd.Flags().StringVar(&triagePipeline, "pipeline", "", "Pipeline to triage (default: the first pipeline)")
+	triageCmd.Flags().IntVar(&triageLimit, "limit", 100, "Maximum number of issues to step through")
+
+	rootCmd.AddCommand(triageCmd)
+}
+
+func resetTriageFlags() {
+	triagePipeline = ""
+	triageLimit = 100
+}
+
+// Triage types
+
+type triageMode int
+
+const (
+	triageModeNormal triageMode = iota
+	triageModePrompt
+)
+
+type triagePrompt int
+
+const (
+	triagePromptEstimate triagePrompt = iota
+	triagePromptPriority
+	triagePromptLabel
+	triagePromptEpic
+	triagePromptPipeline
+)
+
+// triageChange records a change made during triage, for the summary and
+// so that it can be undone.
+type triageChange struct {
+	issue int // index into triageModel.issues
+	ref   string
+	title string
+	field string // estimate, priority, label, epic or pipeline
+	from  string
+	to    string
+	undo  func() error
+
+	history history.Change // recorded in zh history when triage finishes
+}
+
+func (c triageChange) String() string {
+	switch c.field {
+	case "label":
+		if c.to != "" {
+			return "added label " + c.to
+		}
+		return "removed label " + c.from
+	case "epic":
+		return fmt.Sprintf("added to epic %q", c.to)
+	case "pipeline":
+		return "moved to " + c.to
+	}
+	if c.to == "" {
+		return c.field + " cleared"
+	}
+	return c.field + " " + c.to
+}
+
+// triageMutationMsg reports a change that was applied or undone.
+type triageMutationMsg struct {
+	change triageChange
+	undone bool
+	err    error
+}
+
+// triageDetailMsg carries the rendered detail view for an issue.
+type triageDetailMsg struct {
+	issue   int
+	content string
+	err     error
+}
+
+// triageModel is the Bubble Tea model for `zh triage`.
+type triageModel struct {
+	ctx         context.Context
+	client      *api.Client
+	ghClient    *gh.Client
+	workspaceID string
+	aliases     config.AliasConfig
+
+	pipeline resolve.CachedPipeline
+	issues   []pipelineIssueNode
+	longRef  bool
+	idx      int
+	reviewed int
+
+	detail       string
+	detailScroll int
+
+	mode   triageMode
+	prompt triagePrompt
+	input  textinput.Model
+
+	changes []triageChange
+	busy    bool
+	status  string
+	width   int
+	height  int
+}
+
+const triageHelp = "e estimate  p priority  t label  E epic  m move  u undo  n next  b back  j/k scroll  q finish"
+
+func newTriageModel(ctx context.Context, client *api.Client, ghClient *gh.Client, cfg *config.Config, pipeline resolve.CachedPipeline, issues []pipelineIssueNode) triageModel {
+	input := textinput.New()
+	input.CharLimit = 100
+
+	return triageModel{
+		ctx:         ctx,
+		client:      client,
+		ghClient:    ghClient,
+		workspaceID: cfg.Workspace,
+		aliases:     cfg.Aliases,
+		pipeline:    pipeline,
+		issues:      issues,
+		longRef:     repoNamesAmbiguous(issues),
+		reviewed:    1,
+		input:       input,
+		width:       100,
+		height:      30,
+	}
+}
+
+func (m triageModel) current() pipelineIssueNode {
+	return m.issues[m.idx]
+}
+
+func (m triageModel) ref(i int) string {
+	return formatIssueRef(m.issues[i], m.longRef)
+}
+
+func (m triageModel) Init() tea.Cmd {
+	return m.detailCmd()
+}
+
+func (m triageModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case triageDetailMsg:
+		if msg.issue != m.idx {
+			return m, nil
+		}
+		if msg.err != nil {
+			m.detail = output.Red(msg.err.Error())
+		} else {
+			m.detail = msg.content
+		}
+		return m, nil
+
+	case triageMutationMsg:
+		m.busy = false
+		if msg.err != nil {
+			m.status = output.Red(msg.err.Error())
+			return m, nil
+		}
+		c := msg.change
+		if msg.undone {
+			m.changes = m.changes[:len(m.changes)-1]
+			m.applyLocal(c, true)
+			m.status = output.Green(fmt.Sprintf("Undid %s on %s.", c, c.ref))
+			if c.issue != m.idx {
+				return m.goTo(c.issue)
+			}
+			return m, m.detailCmd()
+		}
+		m.changes = append(m.changes, c)
+		m.applyLocal(c, false)
+		m.status = output.Green(fmt.Sprintf("%s: %s.", c.ref, c))
+		// A moved issue has left the pipeline being triaged
+		if c.field == "pipeline" && c.issue == m.idx {
+			return m.next()
+		}
+		return m, m.detailCmd()
+
+	case tea.KeyMsg:
+		if msg.Type == tea.KeyCtrlC {
+			return m, tea.Quit
+		}
+		if m.mode == triageModePrompt {
+			return m.updatePrompt(msg)
+		}
+		return m.updateNormal(msg)
+	}
+
+	return m, nil
+}
+
+func (m triageModel) updateNormal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "esc":
+		return m, tea.Quit
+	case "n", " ", "right":
+		return m.next()
+	case "b", "left":
+		if m.idx > 0 {
+			return m.goTo(m.idx - 1)
+		}
+	case "down", "j":
+		m.detailScroll = min(m.detailScroll+1, max(0, strings.Count(m.detail, "\n")-1))
+	case "up", "k":
+		m.detailScroll = max(0, m.detailScroll-1)
+	case "u":
+		if len(m.changes) == 0 {
+			m.status = output.Dim("Nothing to undo.")
+			return m, nil
+		}
+		if m.busy {
+			return m, nil
+		}
+		m.busy = true
+		c := m.changes[len(m.changes)-1]
+		m.status = output.Dim(fmt.Sprintf("Undoing %s on %s...", c, c.ref))
+		return m, func() tea.Msg {
+			return triageMutationMsg{change: c, undone: true, err: c.undo()}
+		}
+	case "e":
+		return m.startPrompt(triagePromptEstimate)
+	case "p":
+		return m.startPrompt(triagePromptPriority)
+	case "t":
+		return m.startPrompt(triagePromptLabel)
+	case "E":
+		return m.startPrompt(triagePromptEpic)
+	case "m":
+		return m.startPrompt(triagePromptPipeline)
+	}
+	return m, nil
+}
+
+// next moves to the next issue, finishing triage after the last one.
+func (m triageModel) next() (tea.Model, tea.Cmd) {
+	if m.idx == len(m.issues)-1 {
+		return m, tea.Quit
+	}
+	return m.goTo(m.idx + 1)
+}
+
+func (m triageModel) goTo(idx int) (tea.Model, tea.Cmd) {
+	m.idx = idx
+	m.reviewed = max(m.reviewed, idx+1)
+	m.detail = output.Dim("Loading " + m.ref(idx) + "...")
+	m.detailScroll = 0
+	return m, m.detailCmd()
+}
+
+func (m triageModel) startPrompt(prompt triagePrompt) (tea.Model, tea.Cmd) {
+	if m.busy {
+		return m, nil
+	}
+
+	issue := m.current()
+	m.mode = triageModePrompt
+	m.prompt = prompt
+	m.input.SetValue("")
+	m.input.Placeholder = ""
+	switch prompt {
+	case triagePromptEstimate:
+		m.input.Prompt = "Estimate: "
+		m.input.Placeholder = "blank to clear"
+		if issue.Estimate != nil {
+			m.input.SetValue(formatEstimate(issue.Estimate.Value))
+		}
+	case triagePromptPriority:
+		m.input.Prompt = "Priority: "
+		m.input.Placeholder = "blank to clear"
+		if issue.PipelineIssue != nil && issue.PipelineIssue.Priority != nil {
+			m.input.SetValue(issue.PipelineIssue.Priority.Name)
+		}
+	case triagePromptLabel:
+		m.input.Prompt = "Toggle label: "
+	case triagePromptEpic:
+		m.input.Prompt = "Add to epic: "
+	case triagePromptPipeline:
+		m.input.Prompt = "Move to pipeline: "
+	}
+	m.input.CursorEnd()
+	return m, m.input.Focus()
+}
+
+func (m triageModel) updatePrompt(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.mode = triageModeNormal
+		m.input.Blur()
+		return m, nil
+	case tea.KeyEnter:
+		m.mode = triageModeNormal
+		m.input.Blur()
+		value := strings.TrimSpace(m.input.Value())
+		if value == "" && m.prompt != triagePromptEstimate && m.prompt != triagePromptPriority {
+			return m, nil
+		}
+		m.busy = true
+		m.status = output.Dim("Updating " + m.ref(m.idx) + "...")
+		return m, m.mutationCmd(m.prompt, value)
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+// applyLocal keeps the fields shown in prompts in step with a change that
+// was applied, or undone.
+func (m *triageModel) applyLocal(c triageChange, undone bool) {
+	value := c.to
+	if undone {
+		value = c.from
+	}
+	issue := &m.issues[c.issue]
+	switch c.field {
+	case "estimate":
+		issue.Estimate = nil
+		if value != "" {
+			var v float64
+			_, _ = fmt.Sscan(value, &v)
+			issue.Estimate = &struct {
+				Value float64 `json:"value"`
+			}{Value: v}
+		}
+	case "priority":
+		issue.PipelineIssue = nil
+		if value != "" {
+			issue.PipelineIssue = &struct {
+				Priority *struct {
+					Name string `json:"name"`
+				} `json:"priority"`
+			}{Priority: &struct {
+				Name string `json:"name"`
+			}{Name: value}}
+		}
+	case "label":
+		name := c.to + c.from
+		add := (c.to != "") != undone
+		issue.Labels.Nodes = withLabelNode(issue.Labels.Nodes, name, add)
+	}
+}
+
+// Commands
+
+// mutationCmd applies a change to the current issue. Each change captures
+// the previous value so that it can be undone.
+func (m triageModel) mutationCmd(prompt triagePrompt, value string) tea.Cmd {
+	client, ghClient, workspaceID, aliases := m.client, m.ghClient, m.workspaceID, m.aliases
+	idx := m.idx
+	issue := m.current()
+	change := triageChange{issue: idx, ref: m.ref(idx), title: issue.Title}
+
+	// Position of the issue in the triaged pipeline, for undoing a move
+	position := 0
+	for i := range idx {
+		if !m.movedAway(i) {
+			position++
+		}
+	}
+
+	return func() tea.Msg {
+		fail := func(err error) tea.Msg { return triageMutationMsg{err: err} }
+
+		switch prompt {
+		case triagePromptEstimate:
+			prev, newValue, err := setEstimateByNode(m.ctx, client, issue.ID, value)
+			if err != nil {
+				return fail(err)
+			}
+			change.field = "estimate"
+			change.from = formatOptionalEstimate(prev.CurrentEstimate)
+			change.to = formatOptionalEstimate(newValue)
+			change.history = history.Change{
+				Kind:   history.KindEstimate,
+				Issue:  history.Issue{ID: prev.IssueID, Number: prev.Number, RepoName: prev.RepoName, RepoOwner: prev.RepoOwner},
+				Before: estimateHistoryValue(prev.CurrentEstimate),
+				After:  estimateHistoryValue(newValue),
+			}
+			change.undo = func() error {
+				_, _, err := setEstimateByNode(m.ctx, client, issue.ID, change.from)
+				return err
+			}
+
+		case triagePromptPriority:
+			prev, priority, err := setPriorityByNode(m.ctx, client, workspaceID, issue.ID, value)
+			if err != nil {
+				return fail(err)
+			}
+			change.field = "priority"
+			change.from = prev.CurrentPriority
+			change.history = history.Change{
+				Kind:  history.KindPriority,
+				Issue: history.Issue{ID: prev.IssueID, Number: prev.Number, RepoName: prev.RepoName, RepoOwner: prev.RepoOwner, RepoGhID: prev.RepoGhID},
+			}
+			if prev.CurrentPriorityID != "" {
+				change.history.Before = &history.Value{ID: prev.CurrentPriorityID, Name: prev.CurrentPriority}
+			}
+			if priority != nil {
+				change.to = priority.Name
+				change.history.After = &history.Value{ID: priority.ID, Name: priority.Name}
+			}
+			change.undo = func() error {
+				_, _, err := setPriorityByNode(m.ctx, client, workspaceID, issue.ID, change.from)
+				return err
+			}
+
+		case triagePromptLabel:
+			labels, err := resolve.Labels(m.ctx, client, workspaceID, []string{value})
+			if err != nil {
+				return fail(err)
+			}
+			label := labels[0]
+			add := !hasLabelNode(issue.Labels.Nodes, label.Name)
+			if err := executeIssueLabelChange(m.ctx, client, issue.ID, change.ref, label.ID, add); err != nil {
+				return fail(err)
+			}
+			change.field = "label"
+			change.history = history.Change{
+				Kind:  history.KindLabel,
+				Issue: history.Issue{ID: issue.ID, Number: issue.Number, RepoName: issue.Repository.Name, RepoOwner: issue.Repository.OwnerName},
+			}
+			if add {
+				change.to = label.Name
+				change.history.After = &history.Value{ID: label.ID, Name: label.Name}
+			} else {
+				change.from = label.Name
+				change.history.Before = &history.Value{ID: label.ID, Name: label.Name}
+			}
+			change.undo = func() error {
+				return executeIssueLabelChange(m.ctx, client, issue.ID, change.ref, label.ID, !add)
+			}
+
+		case triagePromptEpic:
+			epic, err := resolve.Epic(m.ctx, client, workspaceID, value, aliases.Epics)
+			if err != nil {
+				return fail(err)
+			}
+			epicIssue, err := resolveIssueForEpic(m.ctx, client, workspaceID, issue.ID, "", ghClient)
+			if err != nil {
+				return fail(err)
+			}
+			if err := executeEpicIssueChange(m.ctx, client, workspaceID, epic, *epicIssue, true); err != nil {
+				return fail(err)
+			}
+			change.field = "epic"
+			change.to = epic.Title
+			change.history = history.Change{
+				Kind:  history.KindEpic,
+				Issue: history.Issue{ID: epicIssue.ID, Number: epicIssue.Number, RepoName: epicIssue.RepoName, RepoOwner: epicIssue.RepoOwner, RepoGhID: epicIssue.RepoGhID},
+				After: epicHistoryValue(epic),
+			}
+			change.undo = func() error {
+				return executeEpicIssueChange(m.ctx, client, workspaceID, epic, *epicIssue, false)
+			}
+
+		case triagePromptPipeline:
+			target, err := resolve.Pipeline(m.ctx, client, workspaceID, value, aliases.Pipelines)
+			if err != nil {
+				return fail(err)
+			}
+			moveIssue, err := resolveForMove(m.ctx, client, workspaceID, issue.ID, ghClient)
+			if err != nil {
+				return fail(err)
+			}
+			if err := executeMoveIssue(m.ctx, client, *moveIssue, target.ID, posDefault, 0); err != nil {
+				return fail(err)
+			}
+			change.field = "pipeline"
+			change.from = moveIssue.CurrentPipeline
+			change.to = target.Name
+			change.history = history.Change{
+				Kind:  history.KindMove,
+				Issue: history.Issue{ID: moveIssue.IssueID, Number: moveIssue.Number, RepoName: moveIssue.RepoName, RepoOwner: moveIssue.RepoOwner},
+				After: &history.Value{ID: target.ID, Name: target.Name},
+			}
+			if moveIssue.CurrentPipelineID != "" {
+				change.history.Before = &history.Value{ID: moveIssue.CurrentPipelineID, Name: moveIssue.CurrentPipeline, Position: moveIssue.CurrentPosition}
+			}
+			from := m.pipeline.ID
+			change.undo = func() error {
+				return executeMoveIssue(m.ctx, client, *moveIssue, from, posNumeric, position)
+			}
+		}
+
+		return triageMutationMsg{change: change}
+	}
+}
+
+// movedAway reports whether an issue has been moved out of the pipeline
+// being triaged.
+func (m triageModel) movedAway(idx int) bool {
+	moved := false
+	for _, c := range m.changes {
+		if c.issue == idx && c.field == "pipeline" {
+			moved = true
+		}
+	}
+	return moved
+}
+
+func (m triageModel) detailCmd() tea.Cmd {
+	client, ghClient, workspaceID := m.client, m.ghClient, m.workspaceID
+	idx := m.idx
+	issueID := m.current().ID
+	return func() tea.Msg {
+		var buf bytes.Buffer
+		err := runIssueShowByNode(m.ctx, client, ghClient, workspaceID, issueID, &buf)
+		return triageDetailMsg{issue: idx, content: buf.String(), err: err}
+	}
+}
+
+// View
+
+func (m triageModel) View() string {
+	var b strings.Builder
+
+	header := lipgloss.NewStyle().Bold(true).Render("Triage: "+m.pipeline.Name) +
+		output.Dim(fmt.Sprintf("  issue %d of %d", m.idx+1, len(m.issues)))
+	if len(m.changes) > 0 {
+		header += output.Dim(fmt.Sprintf("  %d change(s)", len(m.changes)))
+	}
+	b.WriteString(header + "\n")
+	b.WriteString(output.Dim(strings.Repeat("─", max(1, m.width))) + "\n")
+
+	// Leave room for the header, rule, status and help lines.
+	height := max(1, m.height-4)
+	lines := strings.Split(strings.TrimRight(m.detail, "\n"), "\n")
+	start := min(m.detailScroll, max(0, len(lines)-1))
+	end := min(len(lines), start+height)
+	b.WriteString(strings.Join(lines[start:end], "\n") + "\n")
+
+	b.WriteString(m.status + "\n")
+	if m.mode == triageModePrompt {
+		b.WriteString(m.input.View())
+	} else {
+		b.WriteString(lipgloss.NewStyle().Faint(true).Render(ansi.Truncate(triageHelp, m.width, "…")))
+	}
+	return b.String()
+}
+
+// runTriage implements `zh triage`.
+func runTriage(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+
+	cfg, err := requireWorkspace()
+	if err != nil {
+		return err
+	}
+
+	client := newClient(cfg, cmd)
+	ghClient := newGitHubClient(cfg, cmd)
+	w := cmd.OutOrStdout()
+
+	if !isInteractive() {
+		return exitcode.Usage("triage requires a terminal — cannot run in non-TTY environment")
+	}
+
+	pipeline, err := resolveTriagePipeline(ctx, client, cfg)
+	if err != nil {
+		return err
+	}
+
+	issues, err := fetchTriageIssues(ctx, client, cfg.Workspace, pipeline.ID, triageLimit)
+	if err != nil {
+		return err
+	}
+	if len(issues) == 0 {
+		fmt.Fprintf(w, "No open issues in %s.\n", pipeline.Name)
+		return nil
+	}
+
+	m := newTriageModel(ctx, client, ghClient, cfg, *pipeline, issues)
+	p := tea.NewProgram(m, tea.WithOutput(cmd.ErrOrStderr()), tea.WithAltScreen())
+	final, err := p.Run()
+	if err != nil {
+		return exitcode.General("running triage", err)
+	}
+
+	result := final.(triageModel)
+	changes := make([]history.Change, len(result.changes))
+	for i, c := range result.changes {
+		changes[i] = c.history
+	}
+	recordHistory(cmd.ErrOrStderr(), cfg.Workspace, "triage",
+		fmt.Sprintf("Triaged %s: %d change(s)", result.pipeline.Name, len(result.changes)), mergeHistoryChanges(changes))
+
+	return renderTriageSummary(w, result.pipeline, result.reviewed, result.changes)
+}
+
+// resolveTriagePipeline resolves --pipeline, defaulting to the first
+// pipeline on the board.
+func resolveTriagePipeline(ctx context.Context, client *api.Client, cfg *config.Config) (*resolve.CachedPipeline, error) {
+	if triagePipeline != "" {
+		resolved, err := resolve.Pipeline(ctx, client, cfg.Workspace, triagePipeline, cfg.Aliases.Pipelines)
+		if err != nil {
+			return nil, err
+		}
+		return &resolve.CachedPipeline{ID: resolved.ID, Name: resolved.Name}, nil
+	}
+
+	pipelines, err := fetchPipelineIDsForList(ctx, client, cfg.Workspace)
+	if err != nil {
+		return nil, err
+	}
+	if len(pipelines) == 0 {
+		return nil, exitcode.NotFoundError("no pipelines found in workspace")
+	}
+	return &pipelines[0], nil
+}
+
+// fetchTriageIssues fetches the open issues in a pipeline, in board order.
+func fetchTriageIssues(ctx context.Context, client *api.Client, workspaceID, pipelineID string, limit int) ([]pipelineIssueNode, error) {
+	issues, _, err := fetchPipelineIssues(ctx, client, pipelineID, workspaceID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	var open []pipelineIssueNode
+	for _, issue := range issues {
+		if !strings.EqualFold(issue.State, "CLOSED") {
+			open = append(open, issue)
+		}
+	}
+	return open, nil
+}
+
+// renderTriageSummary prints the changes made during triage, grouped by issue.
+func renderTriageSummary(w writerFlusher, pipeline resolve.CachedPipeline, reviewed int, changes []triageChange) error {
+	var order []int
+	byIssue := map[int][]triageChange{}
+	for _, c := range changes {
+		if _, ok := byIssue[c.issue]; !ok {
+			order = append(order, c.issue)
+		}
+		byIssue[c.issue] = append(byIssue[c.issue], c)
+	}
+
+	if output.IsJSON(outputFormat) {
+		jsonChanges := make([]map[string]any, len(changes))
+		for i, c := range changes {
+			jsonChanges[i] = map[string]any{
+				"issue": c.ref,
+				"title": c.title,
+				"field": c.field,
+				"from":  stringOrNil(c.from),
+				"to":    stringOrNil(c.to),
+			}
+		}
+		return output.JSON(w, map[string]any{
+			"pipeline": map[string]any{"id": pipeline.ID, "name": pipeline.Name},
+			"reviewed": reviewed,
+			"changed":  len(order),
+			"changes":  jsonChanges,
+		})
+	}
+
+	if len(changes) == 0 {
+		fmt.Fprintf(w, "Reviewed %d issue(s) in %s. No changes made.\n", reviewed, pipeline.Name)
+		return nil
+	}
+
+	items := make([]output.MutationItem, len(order))
+	for i, idx := range order {
+		descs := make([]string, len(byIssue[idx]))
+		for j, c := range byIssue[idx] {
+			descs[j] = c.String()
+		}
+		items[i] = output.MutationItem{
+			Ref:   byIssue[idx][0].ref,
+			Title: strings.Join(descs, ", "),
+		}
+	}
+
+	header := output.Green(fmt.Sprintf("Reviewed %d issue(s) in %s, changed %d.", reviewed, pipeline.Name, len(order)))
+	output.MutationBatch(w, header, items)
+	return nil
+}
+
+// formatOptionalEstimate formats an estimate, or "" if there is none.
+func formatOptionalEstimate(v *float64) string {
+	if v == nil {
+		return ""
+	}
+	return formatEstimate(*v)
+}
+
+func stringOrNil(s string) any {
+	if s == "" {
+		return nil
+	}
+	return s
+}
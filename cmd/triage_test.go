@@ -0,0 +1,441 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/dslh/zh/internal/api"
+	"github.com/dslh/zh/internal/config"
+	"github.com/dslh/zh/internal/exitcode"
+	"github.com/dslh/zh/internal/history"
+	"github.com/dslh/zh/internal/resolve"
+	"github.com/dslh/zh/internal/testutil"
+)
+
+const (
+	triageIssue1 = "Z2lkOi8vcmFwdG9yL0lzc3VlLzE"
+	triageIssue2 = "Z2lkOi8vcmFwdG9yL0lzc3VlLzI"
+)
+
+// --- triage ---
+
+func TestTriageRequiresTerminal(t *testing.T) {
+	resetTriageFlags()
+	defer resetTriageFlags()
+
+	ms := testutil.NewMockServer(t)
+	setupBoardTUITestEnv(t, ms)
+
+	rootCmd.SetOut(new(bytes.Buffer))
+	rootCmd.SetArgs([]string{"triage"})
+
+	err := rootCmd.Execute()
+	if err == nil {
+		t.Fatal("expected error in non-TTY environment")
+	}
+	if exitcode.ExitCode(err) != exitcode.UsageError {
+		t.Errorf("expected usage error, got: %v", err)
+	}
+	if !strings.Contains(err.Error(), "triage requires a terminal") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestTriageHelp(t *testing.T) {
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"triage", "--help"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("triage --help returned error: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{"--pipeline", "--limit", "undo the last change"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("help should mention %q", want)
+		}
+	}
+}
+
+func TestResolveTriagePipelineDefault(t *testing.T) {
+	resetTriageFlags()
+	defer resetTriageFlags()
+
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	ms := testutil.NewMockServer(t)
+	ms.HandleQuery("ListPipelines", pipelineResolutionResponse())
+	client := api.New("test-key", api.WithEndpoint(ms.URL()))
+	cfg := &config.Config{Workspace: "ws-123"}
+
+	pipeline, err := resolveTriagePipeline(context.Background(), client, cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pipeline.Name != "New Issues" {
+		t.Errorf("default pipeline = %q, want %q", pipeline.Name, "New Issues")
+	}
+
+	triagePipeline = "dev"
+	pipeline, err = resolveTriagePipeline(context.Background(), client, cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pipeline.ID != "p2" {
+		t.Errorf("--pipeline=dev resolved to %q, want p2", pipeline.ID)
+	}
+}
+
+// --- triageModel ---
+
+func TestTriageModelNavigation(t *testing.T) {
+	m := newTriageTestModel(t, nil)
+
+	m = triageKeys(t, m, "n")
+	if m.idx != 1 || m.reviewed != 2 {
+		t.Errorf("n should advance: idx=%d reviewed=%d", m.idx, m.reviewed)
+	}
+
+	m = triageKeys(t, m, "b")
+	if m.idx != 0 || m.reviewed != 2 {
+		t.Errorf("b should go back without changing reviewed: idx=%d reviewed=%d", m.idx, m.reviewed)
+	}
+
+	m = triageKeys(t, m, "n")
+	_, cmd := m.Update(triageKey("n"))
+	if cmd == nil {
+		t.Fatal("n on the last issue should finish")
+	}
+	if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Error("n on the last issue should return tea.Quit")
+	}
+}
+
+func TestTriageEstimateAndUndo(t *testing.T) {
+	ms := testutil.NewMockServer(t)
+	ms.HandleQuery("GetIssueForEstimateByNode", map[string]any{
+		"data": map[string]any{
+			"node": map[string]any{
+				"id":       triageIssue1,
+				"number":   1,
+				"title":    "Fix login button",
+				"estimate": map[string]any{"value": 3},
+				"repository": map[string]any{
+					"name":        "task-tracker",
+					"ownerName":   "dlakehammond",
+					"estimateSet": map[string]any{"values": []any{1, 2, 3, 5, 8}},
+				},
+			},
+		},
+	})
+	var values []string
+	ms.Handle(
+		func(req testutil.GraphQLRequest) bool { return strings.Contains(req.Query, "SetEstimate") },
+		func(w http.ResponseWriter, req testutil.GraphQLRequest) {
+			values = append(values, string(req.Variables))
+			data, _ := json.Marshal(setEstimateSuccessResponse())
+			_, _ = w.Write(data)
+		},
+	)
+	m := newTriageTestModel(t, ms)
+
+	m = triageKeys(t, m, "e")
+	if m.input.Value() != "3" {
+		t.Errorf("prompt should be pre-filled with the current estimate, got %q", m.input.Value())
+	}
+	m = triageSubmit(t, m, "5")
+
+	if len(m.changes) != 1 || m.changes[0].String() != "estimate 5" || m.changes[0].from != "3" {
+		t.Fatalf("unexpected changes: %+v", m.changes)
+	}
+	if h := m.changes[0].history; h.Kind != history.KindEstimate || *h.Before.Estimate != 3 || *h.After.Estimate != 5 {
+		t.Errorf("unexpected history change: %+v", h)
+	}
+
+	m = triageRun(t, m, "u")
+	if len(m.changes) != 0 {
+		t.Errorf("undo should remove the change, got %+v", m.changes)
+	}
+	if len(values) != 2 || !strings.Contains(values[1], `"value":3`) {
+		t.Errorf("undo should restore the previous estimate, got %v", values)
+	}
+	if !strings.Contains(m.status, "Undid estimate 5 on task-tracker#1.") {
+		t.Errorf("unexpected status: %q", m.status)
+	}
+}
+
+func TestTriageLabelToggle(t *testing.T) {
+	ms := testutil.NewMockServer(t)
+	ms.HandleQuery("GetWorkspaceLabels", workspaceLabelsResponse())
+	ms.HandleQuery("AddLabelsToIssues", labelMutationResponse(1))
+	m := newTriageTestModel(t, ms)
+
+	m = triageKeys(t, m, "t")
+	m = triageSubmit(t, m, "bug")
+
+	if len(m.changes) != 1 || m.changes[0].String() != "added label bug" {
+		t.Fatalf("unexpected changes: %+v", m.changes)
+	}
+	if !hasLabelNode(m.issues[0].Labels.Nodes, "bug") {
+		t.Error("label should be recorded on the issue so the next toggle removes it")
+	}
+}
+
+func TestTriageEpicAndUndo(t *testing.T) {
+	ms := testutil.NewMockServer(t)
+	handleEpicResolutionForMutations(ms)
+	ms.HandleQuery("IssueByNode", triageIssueByNodeResponse(triageIssue1, 1))
+	ms.HandleQuery("GetIssueForEpic", map[string]any{
+		"data": map[string]any{
+			"node": map[string]any{
+				"id":         triageIssue1,
+				"number":     1,
+				"title":      "Fix login button",
+				"repository": map[string]any{"name": "task-tracker", "ownerName": "dlakehammond"},
+			},
+		},
+	})
+	var mutations []string
+	ms.Handle(
+		func(req testutil.GraphQLRequest) bool { return strings.Contains(req.Query, "ZenhubEpics(input") },
+		func(w http.ResponseWriter, req testutil.GraphQLRequest) {
+			mutations = append(mutations, req.Query)
+			_, _ = w.Write([]byte(`{"data":{}}`))
+		},
+	)
+	m := newTriageTestModel(t, ms)
+
+	m = triageKeys(t, m, "E")
+	m = triageSubmit(t, m, "Q1 Platform")
+
+	if len(m.changes) != 1 || m.changes[0].String() != `added to epic "Q1 Platform Improvements"` {
+		t.Fatalf("unexpected changes: %+v", m.changes)
+	}
+
+	m = triageRun(t, m, "u")
+	if len(mutations) != 2 || !strings.Contains(mutations[0], "AddIssuesToZenhubEpics") || !strings.Contains(mutations[1], "RemoveIssuesFromZenhubEpics") {
+		t.Errorf("expected add then remove, got %d mutation(s)", len(mutations))
+	}
+}
+
+func TestTriageMoveAdvancesAndUndo(t *testing.T) {
+	ms := testutil.NewMockServer(t)
+	ms.HandleQuery("ListPipelines", pipelineResolutionResponse())
+	ms.HandleQuery("IssueByNode", triageIssueByNodeResponse(triageIssue1, 1))
+	ms.HandleQuery("GetPipelineIssueId", pipelineIssueIDResponse(triageIssue1, 1, "Fix login button", "pi1", "p1", "New Issues"))
+	var moves []string
+	ms.Handle(
+		func(req testutil.GraphQLRequest) bool { return strings.Contains(req.Query, "mutation Move") },
+		func(w http.ResponseWriter, req testutil.GraphQLRequest) {
+			moves = append(moves, string(req.Variables))
+			_, _ = w.Write([]byte(`{"data":{}}`))
+		},
+	)
+	m := newTriageTestModel(t, ms)
+
+	m = triageKeys(t, m, "m")
+	m = triageSubmit(t, m, "Done")
+
+	if m.idx != 1 {
+		t.Errorf("moving an issue should advance to the next one, idx=%d", m.idx)
+	}
+	if len(m.changes) != 1 || m.changes[0].String() != "moved to Done" || m.changes[0].from != "New Issues" {
+		t.Fatalf("unexpected changes: %+v", m.changes)
+	}
+	if len(moves) != 1 || !strings.Contains(moves[0], `"pipelineId":"p3"`) || !strings.Contains(moves[0], `"position":"END"`) {
+		t.Errorf("unexpected move: %v", moves)
+	}
+
+	m = triageRun(t, m, "u")
+	if m.idx != 0 {
+		t.Errorf("undo should return to the changed issue, idx=%d", m.idx)
+	}
+	if len(moves) != 2 || !strings.Contains(moves[1], `"pipelineId":"p1"`) || !strings.Contains(moves[1], `"position":0`) {
+		t.Errorf("undo should move the issue back to its position, got %v", moves)
+	}
+}
+
+func TestTriageMutationError(t *testing.T) {
+	ms := testutil.NewMockServer(t)
+	ms.HandleQuery("GetWorkspaceLabels", workspaceLabelsResponse())
+	m := newTriageTestModel(t, ms)
+
+	m = triageKeys(t, m, "t")
+	m = triageSubmit(t, m, "nonexistent")
+
+	if len(m.changes) != 0 || m.busy {
+		t.Error("a failed change should not be recorded")
+	}
+	if !strings.Contains(m.status, "nonexistent") {
+		t.Errorf("status should show the error, got %q", m.status)
+	}
+}
+
+func TestTriageUndoNothing(t *testing.T) {
+	m := newTriageTestModel(t, nil)
+
+	updated, cmd := m.Update(triageKey("u"))
+	if cmd != nil {
+		t.Error("undo with no changes should do nothing")
+	}
+	if !strings.Contains(updated.(triageModel).status, "Nothing to undo.") {
+		t.Error("expected a nothing to undo message")
+	}
+}
+
+func TestTriageStaleDetailIgnored(t *testing.T) {
+	m := newTriageTestModel(t, nil)
+	m = triageKeys(t, m, "n")
+
+	updated, _ := m.Update(triageDetailMsg{issue: 0, content: "stale"})
+	if strings.Contains(updated.(triageModel).detail, "stale") {
+		t.Error("detail for another issue should be ignored")
+	}
+
+	updated, _ = m.Update(triageDetailMsg{issue: 1, content: "ISSUE: task-tracker#2: Add search"})
+	if !strings.Contains(updated.(triageModel).View(), "ISSUE: task-tracker#2: Add search") {
+		t.Error("view should show the current issue's detail")
+	}
+}
+
+// --- summary ---
+
+func TestTriageSummary(t *testing.T) {
+	pipeline := resolve.CachedPipeline{ID: "p1", Name: "New Issues"}
+	changes := []triageChange{
+		{issue: 0, ref: "task-tracker#1", field: "estimate", from: "", to: "3"},
+		{issue: 1, ref: "task-tracker#2", field: "pipeline", from: "New Issues", to: "Backlog"},
+		{issue: 0, ref: "task-tracker#1", field: "label", to: "bug"},
+		{issue: 0, ref: "task-tracker#1", field: "priority", from: "High priority"},
+	}
+
+	buf := new(bytes.Buffer)
+	if err := renderTriageSummary(buf, pipeline, 3, changes); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "Reviewed 3 issue(s) in New Issues, changed 2.") {
+		t.Errorf("unexpected header:\n%s", out)
+	}
+	if !strings.Contains(out, "task-tracker#1 estimate 3, added label bug, priority cleared") {
+		t.Errorf("changes should be grouped by issue:\n%s", out)
+	}
+	if !strings.Contains(out, "task-tracker#2 moved to Backlog") {
+		t.Errorf("missing move:\n%s", out)
+	}
+
+	buf.Reset()
+	if err := renderTriageSummary(buf, pipeline, 2, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "Reviewed 2 issue(s) in New Issues. No changes made.") {
+		t.Errorf("unexpected output: %s", buf.String())
+	}
+}
+
+func TestTriageSummaryJSON(t *testing.T) {
+	outputFormat = "json"
+	defer func() { outputFormat = "" }()
+
+	buf := new(bytes.Buffer)
+	err := renderTriageSummary(buf, resolve.CachedPipeline{ID: "p1", Name: "New Issues"}, 1, []triageChange{
+		{issue: 0, ref: "task-tracker#1", title: "Fix login button", field: "estimate", to: "3"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var result map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+	if result["changed"] != float64(1) || result["reviewed"] != float64(1) {
+		t.Errorf("unexpected counts: %v", result)
+	}
+	change := result["changes"].([]any)[0].(map[string]any)
+	if change["from"] != nil || change["to"] != "3" || change["issue"] != "task-tracker#1" {
+		t.Errorf("unexpected change: %v", change)
+	}
+}
+
+// --- test helpers ---
+
+func newTriageTestModel(t *testing.T, ms *testutil.MockServer) triageModel {
+	t.Helper()
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	data, _ := json.Marshal([]any{
+		boardIssueData(triageIssue1, 1, "Fix login button", "OPEN", false, 3, "task-tracker", "dlakehammond", "alice"),
+		boardIssueData(triageIssue2, 2, "Add search", "OPEN", false, 0, "task-tracker", "dlakehammond", ""),
+	})
+	var issues []pipelineIssueNode
+	if err := json.Unmarshal(data, &issues); err != nil {
+		t.Fatalf("parsing issues: %v", err)
+	}
+
+	var client *api.Client
+	if ms != nil {
+		client = api.New("test-key", api.WithEndpoint(ms.URL()))
+	}
+	cfg := &config.Config{Workspace: "ws-123"}
+	return newTriageModel(context.Background(), client, nil, cfg, resolve.CachedPipeline{ID: "p1", Name: "New Issues"}, issues)
+}
+
+func triageIssueByNodeResponse(id string, number int) map[string]any {
+	return map[string]any{
+		"data": map[string]any{
+			"node": map[string]any{
+				"id":     id,
+				"number": number,
+				"repository": map[string]any{
+					"ghId":      12345,
+					"name":      "task-tracker",
+					"ownerName": "dlakehammond",
+				},
+			},
+		},
+	}
+}
+
+func triageKey(key string) tea.KeyMsg {
+	return boardTUIKey(key)
+}
+
+func triageKeys(t *testing.T, m triageModel, keys ...string) triageModel {
+	t.Helper()
+	for _, k := range keys {
+		updated, _ := m.Update(triageKey(k))
+		m = updated.(triageModel)
+	}
+	return m
+}
+
+// triageSubmit enters a value at the open prompt and applies the resulting
+// mutation.
+func triageSubmit(t *testing.T, m triageModel, value string) triageModel {
+	t.Helper()
+	m.input.SetValue(value)
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(triageModel)
+	if cmd == nil {
+		t.Fatal("expected a mutation command")
+	}
+	updated, _ = m.Update(cmd())
+	return updated.(triageModel)
+}
+
+// triageRun presses a key and applies the command it returns.
+func triageRun(t *testing.T, m triageModel, key string) triageModel {
+	t.Helper()
+	updated, cmd := m.Update(triageKey(key))
+	m = updated.(triageModel)
+	if cmd == nil {
+		t.Fatalf("expected a command from %q", key)
+	}
+	updated, _ = m.Update(cmd())
+	return updated.(triageModel)
+}
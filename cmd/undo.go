@@ -0,0 +1,508 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/dslh/zh/internal/api"
+	"github.com/dslh/zh/internal/exitcode"
+	"github.com/dslh/zh/internal/history"
+	"github.com/dslh/zh/internal/output"
+	"github.com/dslh/zh/internal/resolve"
+	"github.com/spf13/cobra"
+)
+
+// GraphQL queries for undo
+
+const pipelinePositionsQuery = `query GetPipelinePositions($pipelineId: ID!, $workspaceId: ID!, $after: String) {
+  searchIssuesByPipeline(pipelineId: $pipelineId, filters: {}, first: 100, after: $after) {
+    pageInfo {
+      hasNextPage
+      endCursor
+    }
+    nodes {
+      id
+      pipelineIssue(workspaceId: $workspaceId) {
+        relativePosition
+      }
+    }
+  }
+}`
+
+// Commands
+
+var undoCmd = &cobra.Command{
+	Use:   "undo [id]",
+	Short: "Revert a change listed in zh history",
+	Long: `Revert an operation recorded in zh history. With no ID, the most recent
+operation that hasn't been undone is reverted, so repeated calls step back
+through the history.
+
+Moved issues go back to their previous pipeline and position, estimates
+and priorities are restored, added labels, epic issues and sprint issues
+are removed, and removed ones are added back.
+
+Moves and estimates are checked first: an issue that has moved again or
+whose estimate has changed since is skipped, unless --force is given.
+
+The undo is recorded in the history too, so undoing it makes the original
+change again.
+
+Examples:
+  zh undo
+  zh undo 12 --dry-run
+  zh undo 12 --force`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runUndo,
+}
+
+var (
+	undoDryRun bool
+	undoForce  bool
+)
+
+func init() {
+	undoCmd.Flags().BoolVar(&undoDryRun, "dry-run", false, "Show what would be reverted without executing")
+	undoCmd.Flags().BoolVar(&undoForce, "force", false, "Revert changes even if the issues have changed since")
+
+	rootCmd.AddCommand(undoCmd)
+}
+
+func resetUndoFlags() {
+	undoDryRun = false
+	undoForce = false
+}
+
+// runUndo implements `zh undo [id]`.
+func runUndo(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+
+	cfg, err := requireWorkspace()
+	if err != nil {
+		return err
+	}
+
+	client := newClient(cfg, cmd)
+	w := cmd.OutOrStdout()
+
+	entries, err := history.Load(cfg.Workspace)
+	if err != nil {
+		return exitcode.General("loading history", err)
+	}
+
+	var entry *history.Entry
+	if len(args) == 1 {
+		entry, err = findHistoryEntry(entries, args[0])
+		if err != nil {
+			return err
+		}
+		if entry.UndoneAt != nil {
+			return exitcode.Usage(fmt.Sprintf("#%d was already undone %s", entry.ID, output.FormatTimeAgo(*entry.UndoneAt)))
+		}
+	} else {
+		for i := len(entries) - 1; i >= 0; i-- {
+			if entries[i].UndoneAt == nil && entries[i].UndoOf == 0 {
+				entry = &entries[i]
+				break
+			}
+		}
+		if entry == nil {
+			fmt.Fprintln(w, "Nothing to undo.")
+			return nil
+		}
+	}
+
+	// Work out the reverse of each change, skipping moves and estimates
+	// that have been overwritten since.
+	states := map[string]*planIssue{}
+	var changes []history.Change
+	var skipped []output.FailedItem
+
+	for _, c := range entry.Changes {
+		reverse := history.Change{Kind: c.Kind, Issue: c.Issue, Before: c.After, After: c.Before}
+
+		if c.Kind == history.KindMove || c.Kind == history.KindEstimate {
+			state, ok := states[c.Issue.ID]
+			if !ok {
+				state, err = fetchPlanIssueState(ctx, client, cfg.Workspace, c.Issue.ID)
+				if err != nil {
+					return err
+				}
+				states[c.Issue.ID] = state
+			}
+
+			if reason := undoConflict(c, state); reason != "" && !undoForce {
+				skipped = append(skipped, output.FailedItem{Ref: c.Issue.Ref(), Reason: reason})
+				continue
+			}
+
+			// Record where the issue is now, so the undo can itself be undone
+			if c.Kind == history.KindMove {
+				reverse.Before = nil
+				if state.Pipeline != nil {
+					reverse.Before = &history.Value{ID: state.Pipeline.ID, Name: state.Pipeline.Name, Position: state.Position}
+				}
+			}
+		}
+
+		if c.Kind == history.KindMove && reverse.After == nil {
+			skipped = append(skipped, output.FailedItem{Ref: c.Issue.Ref(), Reason: "was not in a pipeline before"})
+			continue
+		}
+		changes = append(changes, reverse)
+	}
+
+	header := fmt.Sprintf("#%d: %s", entry.ID, entry.Summary)
+
+	// Dry run
+	if undoDryRun {
+		if output.IsJSON(outputFormat) {
+			return output.JSON(w, map[string]any{
+				"dryRun":  true,
+				"id":      entry.ID,
+				"command": entry.Command,
+				"summary": entry.Summary,
+				"changes": changes,
+				"skipped": skipped,
+			})
+		}
+		output.MutationDryRun(w, "Would undo "+header, historyChangeItems(changes))
+		renderUndoSkipped(w, skipped)
+		return nil
+	}
+
+	if len(changes) == 0 {
+		if !output.IsJSON(outputFormat) {
+			renderUndoSkipped(w, skipped)
+		}
+		return exitcode.Generalf("nothing left to undo in #%d — the issues have changed since; use --force to revert anyway", entry.ID)
+	}
+
+	applied, failed := executeHistoryChanges(ctx, client, cfg.Workspace, changes, states)
+
+	if len(applied) > 0 {
+		_, err := history.Append(cfg.Workspace, history.Entry{
+			Command: "undo",
+			Summary: "Undid " + header,
+			Changes: applied,
+			UndoOf:  entry.ID,
+		})
+		if err == nil {
+			err = history.MarkUndone(cfg.Workspace, entry.ID, time.Now())
+		}
+		if err != nil {
+			fmt.Fprintln(cmd.ErrOrStderr(), output.Yellow("Warning: could not record history: "+err.Error()))
+		}
+	}
+
+	if output.IsJSON(outputFormat) {
+		if err := output.JSON(w, map[string]any{
+			"id":      entry.ID,
+			"command": entry.Command,
+			"summary": entry.Summary,
+			"undone":  applied,
+			"skipped": skipped,
+			"failed":  failed,
+		}); err != nil {
+			return err
+		}
+	} else {
+		done := output.Green(fmt.Sprintf("Undid %s.", header))
+		if len(failed) > 0 {
+			output.MutationPartialFailure(w, done, historyChangeItems(applied), failed)
+		} else {
+			output.MutationBatch(w, done, historyChangeItems(applied))
+		}
+		renderUndoSkipped(w, skipped)
+	}
+
+	if len(failed) > 0 {
+		return exitcode.Generalf("some changes failed to undo")
+	}
+	return nil
+}
+
+// undoConflict reports why the change c can no longer be safely reverted
+// given the issue's current state, or returns "" if it can.
+func undoConflict(c history.Change, state *planIssue) string {
+	switch c.Kind {
+	case history.KindMove:
+		if state.Pipeline == nil {
+			return "no longer in a pipeline"
+		}
+		if c.After == nil || state.Pipeline.ID != c.After.ID {
+			return fmt.Sprintf("now in %q, not %s", state.Pipeline.Name, historyValueName(c.After))
+		}
+	case history.KindEstimate:
+		current := estimateHistoryValue(state.Estimate)
+		if historyEstimate(current) != historyEstimate(c.After) {
+			return fmt.Sprintf("estimate is now %s, not %s", historyEstimate(current), historyEstimate(c.After))
+		}
+	}
+	return ""
+}
+
+func historyChangeItems(changes []history.Change) []output.MutationItem {
+	items := make([]output.MutationItem, len(changes))
+	for i, c := range changes {
+		items[i] = output.MutationItem{
+			Ref:   c.Issue.Ref(),
+			Title: describeHistoryChange(c),
+		}
+	}
+	return items
+}
+
+func renderUndoSkipped(w writerFlusher, skipped []output.FailedItem) {
+	if len(skipped) == 0 {
+		return
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, output.Yellow("Skipped (changed since):"))
+	fmt.Fprintln(w)
+	for _, s := range skipped {
+		fmt.Fprintf(w, "  %s  %s\n", s.Ref, output.Yellow(s.Reason))
+	}
+}
+
+// executeHistoryChanges makes each change, setting its After value and
+// continuing past failures. It is shared by undo and workspace restore.
+// Moves are made first, grouped by pipeline so that positions can be
+// restored.
+func executeHistoryChanges(ctx context.Context, client *api.Client, workspaceID string, changes []history.Change, states map[string]*planIssue) ([]history.Change, []output.FailedItem) {
+	var applied []history.Change
+	var failed []output.FailedItem
+
+	var moves []history.Change
+	for _, c := range changes {
+		if c.Kind == history.KindMove {
+			moves = append(moves, c)
+		}
+	}
+	movesApplied, movesFailed := executeHistoryMoves(ctx, client, workspaceID, moves, states)
+	applied = append(applied, movesApplied...)
+	failed = append(failed, movesFailed...)
+
+	for _, c := range changes {
+		if c.Kind == history.KindMove {
+			continue
+		}
+		if err := executeHistoryChange(ctx, client, workspaceID, c); err != nil {
+			failed = append(failed, output.FailedItem{Ref: c.Issue.Ref(), Reason: err.Error()})
+			continue
+		}
+		applied = append(applied, c)
+	}
+	return applied, failed
+}
+
+// executeHistoryMoves moves issues to the pipelines in the moves' After
+// values. Where the After relativePosition is known, each issue is put
+// among the issues currently in the pipeline at the index that position
+// sorts to.
+func executeHistoryMoves(ctx context.Context, client *api.Client, workspaceID string, moves []history.Change, states map[string]*planIssue) ([]history.Change, []output.FailedItem) {
+	var applied []history.Change
+	var failed []output.FailedItem
+
+	var pipelineIDs []string
+	byPipeline := map[string][]history.Change{}
+	for _, m := range moves {
+		if _, ok := byPipeline[m.After.ID]; !ok {
+			pipelineIDs = append(pipelineIDs, m.After.ID)
+		}
+		byPipeline[m.After.ID] = append(byPipeline[m.After.ID], m)
+	}
+
+	for _, pipelineID := range pipelineIDs {
+		group := byPipeline[pipelineID]
+
+		// Restore in order of position, so that each issue's index accounts
+		// for the ones restored before it.
+		slices.SortStableFunc(group, func(a, b history.Change) int {
+			switch {
+			case a.After.Position == nil && b.After.Position == nil:
+				return 0
+			case a.After.Position == nil:
+				return 1
+			case b.After.Position == nil:
+				return -1
+			}
+			return *a.After.Position - *b.After.Position
+		})
+
+		var positions []int
+		if group[0].After.Position != nil {
+			exclude := make([]string, len(group))
+			for i, m := range group {
+				exclude[i] = m.Issue.ID
+			}
+			// Without the current positions, issues go to the bottom instead
+			positions, _ = fetchPipelinePositions(ctx, client, workspaceID, pipelineID, exclude)
+		}
+
+		for _, m := range group {
+			issue := resolvedMoveIssue{
+				IssueID:   m.Issue.ID,
+				Number:    m.Issue.Number,
+				RepoName:  m.Issue.RepoName,
+				RepoOwner: m.Issue.RepoOwner,
+			}
+			if state := states[m.Issue.ID]; state != nil {
+				issue.PipelineIssueID = state.PipelineIssueID
+			}
+
+			posType, posNum := posBottom, 0
+			if m.After.Position != nil && positions != nil {
+				posType = posNumeric
+				for _, p := range positions {
+					if p < *m.After.Position {
+						posNum++
+					}
+				}
+			}
+
+			if err := executeMoveIssue(ctx, client, issue, pipelineID, posType, posNum); err != nil {
+				failed = append(failed, output.FailedItem{Ref: m.Issue.Ref(), Reason: err.Error()})
+				continue
+			}
+			if posType == posNumeric {
+				positions = append(positions, *m.After.Position)
+			}
+			applied = append(applied, m)
+		}
+	}
+	return applied, failed
+}
+
+// fetchPipelinePositions returns the relativePosition of every issue in a
+// pipeline, other than those in exclude.
+func fetchPipelinePositions(ctx context.Context, client *api.Client, workspaceID, pipelineID string, exclude []string) ([]int, error) {
+	positions := []int{}
+	var cursor *string
+
+	for {
+		vars := map[string]any{
+			"pipelineId":  pipelineID,
+			"workspaceId": workspaceID,
+		}
+		if cursor != nil {
+			vars["after"] = *cursor
+		}
+
+		data, err := client.Execute(ctx, pipelinePositionsQuery, vars)
+		if err != nil {
+			return nil, exitcode.General("fetching pipeline positions", err)
+		}
+
+		var resp struct {
+			SearchIssuesByPipeline struct {
+				PageInfo struct {
+					HasNextPage bool   `json:"hasNextPage"`
+					EndCursor   string `json:"endCursor"`
+				} `json:"pageInfo"`
+				Nodes []struct {
+					ID            string `json:"id"`
+					PipelineIssue *struct {
+						RelativePosition *int `json:"relativePosition"`
+					} `json:"pipelineIssue"`
+				} `json:"nodes"`
+			} `json:"searchIssuesByPipeline"`
+		}
+		if err := json.Unmarshal(data, &resp); err != nil {
+			return nil, exitcode.General("parsing pipeline positions", err)
+		}
+
+		for _, n := range resp.SearchIssuesByPipeline.Nodes {
+			if slices.Contains(exclude, n.ID) || n.PipelineIssue == nil || n.PipelineIssue.RelativePosition == nil {
+				continue
+			}
+			positions = append(positions, *n.PipelineIssue.RelativePosition)
+		}
+
+		if !resp.SearchIssuesByPipeline.PageInfo.HasNextPage {
+			break
+		}
+		cursor = &resp.SearchIssuesByPipeline.PageInfo.EndCursor
+	}
+	return positions, nil
+}
+
+// executeHistoryChange makes a single estimate, priority, label, epic or
+// sprint change.
+func executeHistoryChange(ctx context.Context, client *api.Client, workspaceID string, c history.Change) error {
+	ref := c.Issue.Ref()
+
+	switch c.Kind {
+	case history.KindEstimate:
+		var value *float64
+		if c.After != nil {
+			value = c.After.Estimate
+		}
+		_, err := executeSetEstimate(ctx, client, &resolvedEstimateIssue{
+			IssueID:   c.Issue.ID,
+			Number:    c.Issue.Number,
+			RepoName:  c.Issue.RepoName,
+			RepoOwner: c.Issue.RepoOwner,
+		}, value)
+		return err
+
+	case history.KindPriority:
+		issues := []resolvedPriorityIssue{{
+			IssueID:   c.Issue.ID,
+			Number:    c.Issue.Number,
+			RepoName:  c.Issue.RepoName,
+			RepoOwner: c.Issue.RepoOwner,
+			RepoGhID:  c.Issue.RepoGhID,
+		}}
+		if c.After != nil {
+			return executeSetPriority(ctx, client, issues, c.After.ID)
+		}
+		return executeClearPriority(ctx, client, workspaceID, issues)
+
+	case history.KindLabel:
+		if c.After != nil {
+			return executeIssueLabelChange(ctx, client, c.Issue.ID, ref, c.After.ID, true)
+		}
+		return executeIssueLabelChange(ctx, client, c.Issue.ID, ref, c.Before.ID, false)
+
+	case history.KindEpic:
+		value, add := c.After, true
+		if value == nil {
+			value, add = c.Before, false
+		}
+		epic := &resolve.EpicResult{ID: value.ID, Title: value.Name, Type: "zenhub"}
+		if value.Epic != nil {
+			epic.Type = value.Epic.Type
+			epic.IssueNumber = value.Epic.IssueNumber
+			epic.RepoName = value.Epic.RepoName
+			epic.RepoOwner = value.Epic.RepoOwner
+		}
+		return executeEpicIssueChange(ctx, client, workspaceID, epic, resolvedEpicIssue{
+			ID:        c.Issue.ID,
+			Number:    c.Issue.Number,
+			RepoGhID:  c.Issue.RepoGhID,
+			RepoName:  c.Issue.RepoName,
+			RepoOwner: c.Issue.RepoOwner,
+		}, add)
+
+	case history.KindSprint:
+		mutation, value := addIssuesToSprintsMutation, c.After
+		if value == nil {
+			mutation, value = removeIssuesFromSprintsMutation, c.Before
+		}
+		_, err := client.Execute(ctx, mutation, map[string]any{
+			"input": map[string]any{
+				"issueIds":  []string{c.Issue.ID},
+				"sprintIds": []string{value.ID},
+			},
+		})
+		if err != nil {
+			return exitcode.General(fmt.Sprintf("updating sprints of %s", ref), err)
+		}
+		return nil
+	}
+	return exitcode.Generalf("cannot make a %s change", c.Kind)
+}
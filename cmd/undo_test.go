@@ -0,0 +1,278 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dslh/zh/internal/exitcode"
+	"github.com/dslh/zh/internal/history"
+	"github.com/dslh/zh/internal/testutil"
+)
+
+var undoTestIssue = history.Issue{ID: "i1", Number: 1, RepoName: "task-tracker", RepoOwner: "dlakehammond", RepoGhID: 12345}
+
+// undoMoveEntry is a move of task-tracker#1 from In Development, where it
+// had relativePosition 200, to Done.
+func undoMoveEntry() history.Entry {
+	position := 200
+	return history.Entry{
+		Command: "issue move",
+		Summary: `Moved 1 issue(s) to "Done"`,
+		Changes: []history.Change{{
+			Kind:   history.KindMove,
+			Issue:  undoTestIssue,
+			Before: &history.Value{ID: "p2", Name: "In Development", Position: &position},
+			After:  &history.Value{ID: "p3", Name: "Done"},
+		}},
+	}
+}
+
+func pipelinePositionsResponse(positions ...int) map[string]any {
+	nodes := make([]any, len(positions))
+	for i, p := range positions {
+		nodes[i] = map[string]any{
+			"id":            "other" + string(rune('a'+i)),
+			"pipelineIssue": map[string]any{"relativePosition": p},
+		}
+	}
+	return map[string]any{
+		"data": map[string]any{
+			"searchIssuesByPipeline": map[string]any{
+				"pageInfo": map[string]any{"hasNextPage": false, "endCursor": ""},
+				"nodes":    nodes,
+			},
+		},
+	}
+}
+
+func setupUndoServer(t *testing.T, state map[string]any) *[]testutil.GraphQLRequest {
+	t.Helper()
+	setupHistoryDataDir(t)
+
+	ms := testutil.NewMockServer(t)
+	ms.HandleQuery("GetIssueStateForPlan", state)
+	ms.HandleQuery("GetPipelinePositions", pipelinePositionsResponse(100, 300))
+	mutations := recordMutations(ms)
+	setupIssueTestEnv(t, ms)
+	return mutations
+}
+
+func TestUndoMove(t *testing.T) {
+	resetUndoFlags()
+	mutations := setupUndoServer(t, planIssueStateResponse("i1", 1, "p3", "Done", 3, "OPEN"))
+	seedHistory(t, undoMoveEntry())
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"undo"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("undo returned error: %v", err)
+	}
+
+	if len(*mutations) != 1 || !strings.Contains((*mutations)[0].Query, "MoveIssue") {
+		t.Fatalf("expected a MoveIssue mutation, got %v", *mutations)
+	}
+	vars := string((*mutations)[0].Variables)
+	// Between the issues at 100 and 300
+	if !strings.Contains(vars, `"pipelineId":"p2"`) || !strings.Contains(vars, `"position":1`) {
+		t.Errorf("issue should go back to its old position, got: %s", vars)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, `Undid #1: Moved 1 issue(s) to "Done".`) {
+		t.Errorf("output should confirm the undo, got: %s", out)
+	}
+	if !strings.Contains(out, `pipeline: "Done" → "In Development"`) {
+		t.Errorf("output should list the reverted change, got: %s", out)
+	}
+
+	entries, _ := history.Load("ws-123")
+	if len(entries) != 2 {
+		t.Fatalf("expected the undo to be recorded, got %d entries", len(entries))
+	}
+	if entries[0].UndoneAt == nil {
+		t.Error("original entry should be marked undone")
+	}
+	if entries[1].Command != "undo" || entries[1].UndoOf != 1 {
+		t.Errorf("unexpected undo entry: %+v", entries[1])
+	}
+	if c := entries[1].Changes[0]; c.Before.ID != "p3" || c.After.ID != "p2" {
+		t.Errorf("undo entry should record the reverse move, got %+v", c)
+	}
+}
+
+func TestUndoSkipsChangedIssues(t *testing.T) {
+	resetUndoFlags()
+	mutations := setupUndoServer(t, planIssueStateResponse("i1", 1, "p1", "New Issues", 3, "OPEN"))
+	seedHistory(t, undoMoveEntry())
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"undo"})
+	err := rootCmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "--force") {
+		t.Fatalf("expected an error mentioning --force, got: %v", err)
+	}
+	if len(*mutations) != 0 {
+		t.Errorf("changed issues should not be reverted, got %d mutation(s)", len(*mutations))
+	}
+	if !strings.Contains(buf.String(), `now in "New Issues", not "Done"`) {
+		t.Errorf("output should explain the skip, got: %s", buf.String())
+	}
+
+	entries, _ := history.Load("ws-123")
+	if entries[0].UndoneAt != nil {
+		t.Error("entry should not be marked undone")
+	}
+}
+
+func TestUndoForce(t *testing.T) {
+	resetUndoFlags()
+	mutations := setupUndoServer(t, planIssueStateResponse("i1", 1, "p1", "New Issues", 3, "OPEN"))
+	seedHistory(t, undoMoveEntry())
+
+	rootCmd.SetOut(new(bytes.Buffer))
+	rootCmd.SetArgs([]string{"undo", "1", "--force"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("undo --force returned error: %v", err)
+	}
+	if len(*mutations) != 1 || !strings.Contains(string((*mutations)[0].Variables), `"pipelineId":"p2"`) {
+		t.Errorf("expected the move to be reverted, got %v", *mutations)
+	}
+}
+
+func TestUndoDryRun(t *testing.T) {
+	resetUndoFlags()
+	mutations := setupUndoServer(t, planIssueStateResponse("i1", 1, "p3", "Done", 3, "OPEN"))
+	seedHistory(t, undoMoveEntry())
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"undo", "--dry-run"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("undo --dry-run returned error: %v", err)
+	}
+	if len(*mutations) != 0 {
+		t.Errorf("dry run should not execute mutations, got %d", len(*mutations))
+	}
+	if !strings.Contains(buf.String(), `Would undo #1: Moved 1 issue(s) to "Done"`) {
+		t.Errorf("unexpected output: %s", buf.String())
+	}
+
+	entries, _ := history.Load("ws-123")
+	if len(entries) != 1 || entries[0].UndoneAt != nil {
+		t.Error("dry run should not change the history")
+	}
+}
+
+func TestUndoChanges(t *testing.T) {
+	three, five := 3.0, 5.0
+	tests := []struct {
+		name     string
+		change   history.Change
+		mutation string
+		vars     []string
+	}{
+		{
+			name:     "estimate",
+			change:   history.Change{Kind: history.KindEstimate, Issue: undoTestIssue, Before: &history.Value{Estimate: &five}, After: &history.Value{Estimate: &three}},
+			mutation: "SetEstimate",
+			vars:     []string{`"value":5`},
+		},
+		{
+			name:     "priority set",
+			change:   history.Change{Kind: history.KindPriority, Issue: undoTestIssue, Before: &history.Value{ID: "pr1", Name: "High priority"}},
+			mutation: "SetIssuePriority",
+			vars:     []string{`"priorityId":"pr1"`, `"repositoryGhId":12345`},
+		},
+		{
+			name:     "priority cleared",
+			change:   history.Change{Kind: history.KindPriority, Issue: undoTestIssue, After: &history.Value{ID: "pr1", Name: "High priority"}},
+			mutation: "RemoveIssuePriority",
+			vars:     []string{`"issueNumber":1`},
+		},
+		{
+			name:     "label added",
+			change:   history.Change{Kind: history.KindLabel, Issue: undoTestIssue, After: &history.Value{ID: "l1", Name: "bug"}},
+			mutation: "RemoveLabelsFromIssues",
+			vars:     []string{`"labelIds":["l1"]`},
+		},
+		{
+			name:     "label removed",
+			change:   history.Change{Kind: history.KindLabel, Issue: undoTestIssue, Before: &history.Value{ID: "l1", Name: "bug"}},
+			mutation: "AddLabelsToIssues",
+			vars:     []string{`"labelIds":["l1"]`},
+		},
+		{
+			name:     "epic issue removed",
+			change:   history.Change{Kind: history.KindEpic, Issue: undoTestIssue, Before: &history.Value{ID: "e1", Name: "Q1", Epic: &history.Epic{Type: "zenhub"}}},
+			mutation: "AddIssuesToZenhubEpics",
+			vars:     []string{`"zenhubEpicIds":["e1"]`, `"issueIds":["i1"]`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetUndoFlags()
+			mutations := setupUndoServer(t, planIssueStateResponse("i1", 1, "p2", "In Development", 3, "OPEN"))
+			seedHistory(t, history.Entry{Command: "test", Summary: "test", Changes: []history.Change{tt.change}})
+
+			rootCmd.SetOut(new(bytes.Buffer))
+			rootCmd.SetArgs([]string{"undo"})
+			if err := rootCmd.Execute(); err != nil {
+				t.Fatalf("undo returned error: %v", err)
+			}
+			if len(*mutations) != 1 || !strings.Contains((*mutations)[0].Query, tt.mutation) {
+				t.Fatalf("expected a %s mutation, got %v", tt.mutation, *mutations)
+			}
+			vars := string((*mutations)[0].Variables)
+			for _, want := range tt.vars {
+				if !strings.Contains(vars, want) {
+					t.Errorf("variables should contain %s, got: %s", want, vars)
+				}
+			}
+		})
+	}
+}
+
+func TestUndoNothing(t *testing.T) {
+	resetUndoFlags()
+	setupUndoServer(t, planIssueStateResponse("i1", 1, "p3", "Done", 3, "OPEN"))
+
+	// The only entries are an undone operation and the undo itself
+	undone := time.Now()
+	seedHistory(t,
+		history.Entry{Command: "issue move", Summary: "moved", UndoneAt: &undone},
+		history.Entry{Command: "undo", Summary: "Undid #1: moved", UndoOf: 1},
+	)
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"undo"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("undo returned error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "Nothing to undo.") {
+		t.Errorf("unexpected output: %s", buf.String())
+	}
+}
+
+func TestUndoAlreadyUndone(t *testing.T) {
+	resetUndoFlags()
+	setupUndoServer(t, planIssueStateResponse("i1", 1, "p3", "Done", 3, "OPEN"))
+
+	undone := time.Now()
+	seedHistory(t, history.Entry{Command: "issue move", Summary: "moved", UndoneAt: &undone})
+
+	rootCmd.SetOut(new(bytes.Buffer))
+	rootCmd.SetArgs([]string{"undo", "1"})
+	err := rootCmd.Execute()
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if exitcode.ExitCode(err) != exitcode.UsageError || !strings.Contains(err.Error(), "already undone") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -13,6 +14,7 @@ import (
 	"github.com/dslh/zh/internal/gh"
 	"github.com/dslh/zh/internal/output"
 	"github.com/dslh/zh/internal/resolve"
+	"github.com/dslh/zh/internal/retry"
 	"github.com/spf13/cobra"
 )
 
@@ -346,10 +348,11 @@ var (
 )
 
 var workspaceListCmd = &cobra.Command{
-	Use:   "list",
-	Short: "List available workspaces",
-	Long:  `List all ZenHub workspaces you have access to. Use --favorites or --recent to filter.`,
-	RunE:  runWorkspaceList,
+	Use:         "list",
+	Short:       "List available workspaces",
+	Long:        `List all ZenHub workspaces you have access to. Use --favorites or --recent to filter.`,
+	RunE:        runWorkspaceList,
+	Annotations: map[string]string{tabularAnnotation: "true"},
 }
 
 var workspaceShowCmd = &cobra.Command{
@@ -371,10 +374,11 @@ var workspaceSwitchCmd = &cobra.Command{
 }
 
 var workspaceReposCmd = &cobra.Command{
-	Use:   "repos",
-	Short: "List repos connected to the workspace",
-	Long:  `List all GitHub repositories connected to the current workspace. Use --github to include description, language, and stars from GitHub.`,
-	RunE:  runWorkspaceRepos,
+	Use:         "repos",
+	Short:       "List repos connected to the workspace",
+	Long:        `List all GitHub repositories connected to the current workspace. Use --github to include description, language, and stars from GitHub.`,
+	RunE:        runWorkspaceRepos,
+	Annotations: map[string]string{tabularAnnotation: "true"},
 }
 
 var workspaceStatsCmd = &cobra.Command{
@@ -423,10 +427,15 @@ var ghNewFunc = gh.New
 
 // newClient creates an API client from config, wiring up verbose logging.
 func newClient(cfg *config.Config, cmd *cobra.Command) *api.Client {
-	var opts []api.Option
+	opts := []api.Option{
+		api.WithRetryPolicy(retryPolicy(cfg)),
+	}
 	if cfg.RESTAPIKey != "" {
 		opts = append(opts, api.WithRESTAPIKey(cfg.RESTAPIKey))
 	}
+	if offline {
+		opts = append(opts, api.WithOffline())
+	}
 	if verbose {
 		opts = append(opts, api.WithVerbose(func(format string, args ...any) {
 			fmt.Fprintf(cmd.ErrOrStderr(), format, args...)
@@ -435,10 +444,25 @@ func newClient(cfg *config.Config, cmd *cobra.Command) *api.Client {
 	return apiNewFunc(cfg.APIKey, opts...)
 }
 
+// retryPolicy converts the retry section of config.yml into a retry.Policy.
+func retryPolicy(cfg *config.Config) retry.Policy {
+	return retry.Policy{
+		MaxAttempts: cfg.Retry.MaxAttempts,
+		BaseDelay:   cfg.Retry.BaseDelay,
+		MaxDelay:    cfg.Retry.MaxDelay,
+		Mutations:   cfg.Retry.Mutations,
+	}
+}
+
 // newGitHubClient creates a GitHub API client from config. Returns nil if
-// GitHub access is not configured.
+// GitHub access is not configured, or when offline.
 func newGitHubClient(cfg *config.Config, cmd *cobra.Command) *gh.Client {
-	var opts []gh.Option
+	if offline {
+		return nil
+	}
+	opts := []gh.Option{
+		gh.WithRetryPolicy(retryPolicy(cfg)),
+	}
 	if verbose {
 		opts = append(opts, gh.WithVerbose(func(format string, args ...any) {
 			fmt.Fprintf(cmd.ErrOrStderr(), format, args...)
@@ -456,6 +480,7 @@ func requireConfig() (*config.Config, error) {
 	if cfg.APIKey == "" {
 		return nil, exitcode.Auth("no API key configured — set ZH_API_KEY or run zh to configure", nil)
 	}
+	cache.SetTTLs(cfg.Cache.TTL)
 	return cfg, nil
 }
 
@@ -473,8 +498,8 @@ func requireWorkspace() (*config.Config, error) {
 
 // fetchAllWorkspaces fetches workspaces from all orgs and returns a flat list
 // with org name attached.
-func fetchAllWorkspaces(client *api.Client) ([]workspaceNode, error) {
-	data, err := client.Execute(listWorkspacesQuery, nil)
+func fetchAllWorkspaces(ctx context.Context, client *api.Client) ([]workspaceNode, error) {
+	data, err := client.Execute(ctx, listWorkspacesQuery, nil)
 	if err != nil {
 		return nil, exitcode.General("fetching workspaces", err)
 	}
@@ -559,7 +584,7 @@ func matchWorkspace(entries []cachedWorkspace, name string) (*cachedWorkspace, b
 
 // resolveWorkspaceOrAmbiguous resolves a workspace, providing a helpful error
 // for ambiguous matches.
-func resolveWorkspaceOrAmbiguous(client *api.Client, name string) (*cachedWorkspace, error) {
+func resolveWorkspaceOrAmbiguous(ctx context.Context, client *api.Client, name string) (*cachedWorkspace, error) {
 	key := cache.NewKey("workspaces")
 
 	// First try from cache
@@ -574,7 +599,7 @@ func resolveWorkspaceOrAmbiguous(client *api.Client, name string) (*cachedWorksp
 	}
 
 	// Refresh and retry
-	workspaces, err := fetchAllWorkspaces(client)
+	workspaces, err := fetchAllWorkspaces(ctx, client)
 	if err != nil {
 		return nil, err
 	}
@@ -632,6 +657,8 @@ func checkAmbiguous(entries []cachedWorkspace, name string) error {
 
 // runWorkspaceList implements `zh workspace list`.
 func runWorkspaceList(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+
 	cfg, err := requireConfig()
 	if err != nil {
 		return err
@@ -648,7 +675,7 @@ func runWorkspaceList(cmd *cobra.Command, args []string) error {
 		return runWorkspaceListFavorites(client, cfg, cmd)
 	}
 
-	workspaces, err := fetchAllWorkspaces(client)
+	workspaces, err := fetchAllWorkspaces(ctx, client)
 	if err != nil {
 		return err
 	}
@@ -697,7 +724,9 @@ func runWorkspaceList(cmd *cobra.Command, args []string) error {
 }
 
 func runWorkspaceListRecent(client *api.Client, cfg *config.Config, cmd *cobra.Command) error {
-	data, err := client.Execute(recentWorkspacesQuery, nil)
+	ctx := commandContext(cmd)
+
+	data, err := client.Execute(ctx, recentWorkspacesQuery, nil)
 	if err != nil {
 		return exitcode.General("fetching recent workspaces", err)
 	}
@@ -755,7 +784,9 @@ func runWorkspaceListRecent(client *api.Client, cfg *config.Config, cmd *cobra.C
 }
 
 func runWorkspaceListFavorites(client *api.Client, cfg *config.Config, cmd *cobra.Command) error {
-	data, err := client.Execute(favoriteWorkspacesQuery, nil)
+	ctx := commandContext(cmd)
+
+	data, err := client.Execute(ctx, favoriteWorkspacesQuery, nil)
 	if err != nil {
 		return exitcode.General("fetching favorite workspaces", err)
 	}
@@ -823,6 +854,8 @@ func runWorkspaceListFavorites(client *api.Client, cfg *config.Config, cmd *cobr
 
 // runWorkspaceShow implements `zh workspace show [name]`.
 func runWorkspaceShow(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+
 	cfg, err := requireConfig()
 	if err != nil {
 		return err
@@ -834,14 +867,14 @@ func runWorkspaceShow(cmd *cobra.Command, args []string) error {
 	workspaceID := cfg.Workspace
 	if workspaceShowInteractive {
 		identifier, err := interactiveOrArg(cmd, nil, true, func() ([]selectItem, error) {
-			return fetchWorkspaceSelectItems(client)
+			return fetchWorkspaceSelectItems(ctx, client)
 		}, "Select a workspace")
 		if err != nil {
 			return err
 		}
 		workspaceID = identifier
 	} else if len(args) > 0 {
-		ws, err := resolveWorkspaceOrAmbiguous(client, args[0])
+		ws, err := resolveWorkspaceOrAmbiguous(ctx, client, args[0])
 		if err != nil {
 			return err
 		}
@@ -852,7 +885,7 @@ func runWorkspaceShow(cmd *cobra.Command, args []string) error {
 		return exitcode.Usage("no workspace specified and no default configured — use 'zh workspace switch' to set one")
 	}
 
-	data, err := client.Execute(workspaceDetailQuery, map[string]any{
+	data, err := client.Execute(ctx, workspaceDetailQuery, map[string]any{
 		"workspaceId": workspaceID,
 	})
 	if err != nil {
@@ -969,8 +1002,8 @@ func runWorkspaceShow(cmd *cobra.Command, args []string) error {
 }
 
 // fetchWorkspaceSelectItems fetches workspaces and converts them to selectItems for interactive mode.
-func fetchWorkspaceSelectItems(client *api.Client) ([]selectItem, error) {
-	workspaces, err := fetchAllWorkspaces(client)
+func fetchWorkspaceSelectItems(ctx context.Context, client *api.Client) ([]selectItem, error) {
+	workspaces, err := fetchAllWorkspaces(ctx, client)
 	if err != nil {
 		return nil, err
 	}
@@ -1014,6 +1047,8 @@ func cacheReposFromDetail(ws *workspaceDetail) {
 
 // runWorkspaceSwitch implements `zh workspace switch <name>`.
 func runWorkspaceSwitch(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+
 	cfg, err := requireConfig()
 	if err != nil {
 		return err
@@ -1022,7 +1057,7 @@ func runWorkspaceSwitch(cmd *cobra.Command, args []string) error {
 	client := newClient(cfg, cmd)
 	name := args[0]
 
-	ws, err := resolveWorkspaceOrAmbiguous(client, name)
+	ws, err := resolveWorkspaceOrAmbiguous(ctx, client, name)
 	if err != nil {
 		return err
 	}
@@ -1058,15 +1093,20 @@ func runWorkspaceSwitch(cmd *cobra.Command, args []string) error {
 
 // runWorkspaceRepos implements `zh workspace repos`.
 func runWorkspaceRepos(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+
 	cfg, err := requireWorkspace()
 	if err != nil {
 		return err
 	}
+	if offline {
+		return runWorkspaceReposOffline(cmd, cfg.Workspace)
+	}
 
 	client := newClient(cfg, cmd)
 	w := cmd.OutOrStdout()
 
-	repos, err := fetchWorkspaceRepos(client, cfg.Workspace)
+	repos, err := fetchWorkspaceRepos(ctx, client, cfg.Workspace)
 	if err != nil {
 		return err
 	}
@@ -1090,7 +1130,7 @@ func runWorkspaceRepos(cmd *cobra.Command, args []string) error {
 		if ghClient == nil {
 			fmt.Fprintln(cmd.ErrOrStderr(), "Warning: GitHub access not configured — ignoring --github flag")
 		} else {
-			ghInfo = fetchGitHubRepoInfo(ghClient, repos)
+			ghInfo = fetchGitHubRepoInfo(ctx, ghClient, repos)
 		}
 	}
 
@@ -1161,10 +1201,10 @@ func runWorkspaceRepos(cmd *cobra.Command, args []string) error {
 }
 
 // fetchGitHubRepoInfo fetches enriched repo info from GitHub for each repo.
-func fetchGitHubRepoInfo(client *gh.Client, repos []repoNode) map[string]githubRepoInfo {
+func fetchGitHubRepoInfo(ctx context.Context, client *gh.Client, repos []repoNode) map[string]githubRepoInfo {
 	info := make(map[string]githubRepoInfo)
 	for _, r := range repos {
-		data, err := client.Execute(githubRepoQuery, map[string]any{
+		data, err := client.Execute(ctx, githubRepoQuery, map[string]any{
 			"owner": r.OwnerName,
 			"name":  r.Name,
 		})
@@ -1200,7 +1240,7 @@ func fetchGitHubRepoInfo(client *gh.Client, repos []repoNode) map[string]githubR
 }
 
 // fetchWorkspaceRepos fetches all repos for a workspace, handling pagination.
-func fetchWorkspaceRepos(client *api.Client, workspaceID string) ([]repoNode, error) {
+func fetchWorkspaceRepos(ctx context.Context, client *api.Client, workspaceID string) ([]repoNode, error) {
 	var allRepos []repoNode
 	var cursor *string
 
@@ -1213,7 +1253,7 @@ func fetchWorkspaceRepos(client *api.Client, workspaceID string) ([]repoNode, er
 			vars["after"] = *cursor
 		}
 
-		data, err := client.Execute(workspaceReposQuery, vars)
+		data, err := client.Execute(ctx, workspaceReposQuery, vars)
 		if err != nil {
 			return nil, exitcode.General("fetching workspace repos", err)
 		}
@@ -1446,6 +1486,8 @@ type statsSprint struct {
 
 // runWorkspaceStats implements `zh workspace stats`.
 func runWorkspaceStats(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+
 	cfg, err := requireWorkspace()
 	if err != nil {
 		return err
@@ -1454,7 +1496,7 @@ func runWorkspaceStats(cmd *cobra.Command, args []string) error {
 	client := newClient(cfg, cmd)
 	w := cmd.OutOrStdout()
 
-	data, err := client.Execute(workspaceStatsQuery, map[string]any{
+	data, err := client.Execute(ctx, workspaceStatsQuery, map[string]any{
 		"workspaceId": cfg.Workspace,
 		"sprintCount": workspaceStatsSprints,
 		"daysInCycle": workspaceStatsDays,
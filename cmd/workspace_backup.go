@@ -0,0 +1,1066 @@
+package cmd
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/dslh/zh/internal/api"
+	"github.com/dslh/zh/internal/backup"
+	"github.com/dslh/zh/internal/cache"
+	"github.com/dslh/zh/internal/exitcode"
+	"github.com/dslh/zh/internal/history"
+	"github.com/dslh/zh/internal/output"
+	"github.com/dslh/zh/internal/resolve"
+	"github.com/spf13/cobra"
+)
+
+// GraphQL queries for backup
+
+const backupWorkspaceQuery = `query BackupWorkspace($workspaceId: ID!) {
+  workspace(id: $workspaceId) {
+    id
+    displayName
+  }
+}`
+
+// backupIssueFields are the issue fields saved in a backup.
+const backupIssueFields = `pageInfo {
+      hasNextPage
+      endCursor
+    }
+    nodes {
+      id
+      number
+      title
+      state
+      pullRequest
+      estimate {
+        value
+      }
+      repository {
+        ghId
+        name
+        ownerName
+      }
+      assignees(first: 20) {
+        nodes {
+          login
+        }
+      }
+      labels(first: 50) {
+        nodes {
+          id
+          name
+        }
+      }
+      sprints(first: 10) {
+        nodes {
+          id
+          name
+          generatedName
+        }
+      }
+      parentZenhubEpics(first: 10) {
+        nodes {
+          id
+          title
+        }
+      }
+      blockingItems(first: 50) {
+        nodes {
+          ... on Issue {
+            __typename
+            id
+            number
+            repository {
+              name
+              ownerName
+            }
+          }
+          ... on ZenhubEpic {
+            __typename
+            id
+            title
+          }
+        }
+      }
+      connectedPrs(first: 10) {
+        nodes {
+          id
+          number
+          repository {
+            name
+            ownerName
+          }
+        }
+      }
+      pipelineIssue(workspaceId: $workspaceId) {
+        id
+        relativePosition
+        pipeline {
+          id
+          name
+        }
+        priority {
+          id
+          name
+        }
+      }
+    }`
+
+const backupPipelineIssuesQuery = `query BackupPipelineIssues($pipelineId: ID!, $workspaceId: ID!, $after: String) {
+  searchIssuesByPipeline(pipelineId: $pipelineId, filters: {}, first: 100, after: $after) {
+    ` + backupIssueFields + `
+  }
+}`
+
+const backupClosedIssuesQuery = `query BackupClosedIssues($workspaceId: ID!, $after: String) {
+  searchClosedIssues(workspaceId: $workspaceId, filters: {}, first: 100, after: $after) {
+    ` + backupIssueFields + `
+  }
+}`
+
+const backupZenhubEpicsQuery = `query BackupZenhubEpics($workspaceId: ID!, $after: String) {
+  workspace(id: $workspaceId) {
+    zenhubEpics(first: 50, after: $after) {
+      pageInfo {
+        hasNextPage
+        endCursor
+      }
+      nodes {
+        id
+        title
+        body
+        state
+        startOn
+        endOn
+        keyDates(first: 50) {
+          nodes {
+            date
+            description
+            color
+          }
+        }
+      }
+    }
+  }
+}`
+
+const backupLegacyEpicsQuery = `query BackupLegacyEpics($workspaceId: ID!, $after: String) {
+  workspace(id: $workspaceId) {
+    epics(first: 50, after: $after) {
+      pageInfo {
+        hasNextPage
+        endCursor
+      }
+      nodes {
+        id
+        startOn
+        endOn
+        issue {
+          id
+          number
+          title
+          state
+          repository {
+            name
+            ownerName
+          }
+        }
+      }
+    }
+  }
+}`
+
+const backupSprintsQuery = `query BackupSprints($workspaceId: ID!, $after: String) {
+  workspace(id: $workspaceId) {
+    sprints(first: 100, after: $after) {
+      pageInfo {
+        hasNextPage
+        endCursor
+      }
+      nodes {
+        id
+        name
+        generatedName
+        state
+        startAt
+        endAt
+      }
+    }
+  }
+}`
+
+// Commands
+
+var workspaceBackupCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "Save the workspace's issue placement to an archive",
+	Long: `Save the current workspace to a gzipped archive of versioned JSON files.
+
+Every issue, open and closed, is saved with its pipeline, position,
+estimate, priority, labels, assignees, epics, sprints, blockers and
+connected pull requests, along with the workspace's pipelines, epics (with
+their key dates) and sprints.
+
+Use zh workspace restore to re-apply a backup.
+
+Examples:
+  zh workspace backup -f ws-2026-10.tar.gz`,
+	Args: cobra.NoArgs,
+	RunE: runWorkspaceBackup,
+}
+
+var workspaceRestoreCmd = &cobra.Command{
+	Use:   "restore <archive>",
+	Short: "Re-apply issue placement from a backup",
+	Long: `Re-apply the issue placement saved by zh workspace backup to the current
+workspace.
+
+Open issues are moved back to their pipelines and positions, and estimates
+and priorities are set to their backed-up values. Issues are added back to
+the labels, epics and sprints they were in; memberships added since the
+backup are left in place. ZenHub epics that have been deleted are recreated
+with their description, dates, state and key dates.
+
+Pipelines, priorities, epics and sprints are matched by ID, then by name, so
+a backup can be restored to a different workspace. Issues that are no longer
+in the workspace or have changed state, and anything that no longer exists
+and cannot be recreated, are skipped. Assignees, blockers and pull request
+connections are saved in the backup but not restored.
+
+The restore is recorded in zh history, so it can be reverted with zh undo.
+
+Examples:
+  zh workspace restore ws-2026-10.tar.gz --dry-run
+  zh workspace restore ws-2026-10.tar.gz`,
+	Args: cobra.ExactArgs(1),
+	RunE: runWorkspaceRestore,
+}
+
+var (
+	workspaceBackupFile    string
+	workspaceRestoreDryRun bool
+)
+
+func init() {
+	workspaceBackupCmd.Flags().StringVarP(&workspaceBackupFile, "file", "f", "", "Path to write the archive to (required)")
+	_ = workspaceBackupCmd.MarkFlagRequired("file")
+
+	workspaceRestoreCmd.Flags().BoolVar(&workspaceRestoreDryRun, "dry-run", false, "Show what would be restored without executing")
+
+	workspaceCmd.AddCommand(workspaceBackupCmd)
+	workspaceCmd.AddCommand(workspaceRestoreCmd)
+}
+
+func resetWorkspaceBackupFlags() {
+	workspaceBackupFile = ""
+	workspaceRestoreDryRun = false
+}
+
+// runWorkspaceBackup implements `zh workspace backup`.
+func runWorkspaceBackup(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+
+	cfg, err := requireWorkspace()
+	if err != nil {
+		return err
+	}
+
+	client := newClient(cfg, cmd)
+	w := cmd.OutOrStdout()
+
+	archive, err := fetchWorkspaceBackup(ctx, client, cfg.Workspace)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(workspaceBackupFile)
+	if err != nil {
+		return exitcode.General("creating backup file", err)
+	}
+	if err := backup.Write(f, archive); err != nil {
+		_ = f.Close()
+		_ = os.Remove(workspaceBackupFile)
+		return exitcode.General("writing backup", err)
+	}
+	if err := f.Close(); err != nil {
+		return exitcode.General("writing backup", err)
+	}
+
+	if output.IsJSON(outputFormat) {
+		return output.JSON(w, map[string]any{
+			"path":      workspaceBackupFile,
+			"manifest":  archive.Manifest,
+			"pipelines": len(archive.Pipelines),
+			"issues":    len(archive.Issues),
+			"epics":     len(archive.Epics),
+			"sprints":   len(archive.Sprints),
+		})
+	}
+
+	output.MutationSingle(w, output.Green(fmt.Sprintf(
+		"Backed up %d issue(s), %d epic(s), %d sprint(s) and %d pipeline(s) to %s.",
+		len(archive.Issues), len(archive.Epics), len(archive.Sprints), len(archive.Pipelines), workspaceBackupFile,
+	)))
+	return nil
+}
+
+// fetchWorkspaceBackup fetches everything saved in a backup of the workspace.
+func fetchWorkspaceBackup(ctx context.Context, client *api.Client, workspaceID string) (*backup.Archive, error) {
+	a := &backup.Archive{
+		Manifest: backup.Manifest{
+			WorkspaceID: workspaceID,
+			CreatedAt:   time.Now().UTC().Truncate(time.Second),
+		},
+	}
+
+	data, err := client.Execute(ctx, backupWorkspaceQuery, map[string]any{"workspaceId": workspaceID})
+	if err != nil {
+		return nil, exitcode.General("fetching workspace", err)
+	}
+	var wsResp struct {
+		Workspace *struct {
+			DisplayName string `json:"displayName"`
+		} `json:"workspace"`
+	}
+	if err := json.Unmarshal(data, &wsResp); err != nil {
+		return nil, exitcode.General("parsing workspace response", err)
+	}
+	if wsResp.Workspace == nil {
+		return nil, exitcode.NotFoundError(fmt.Sprintf("workspace %q not found", workspaceID))
+	}
+	a.Manifest.WorkspaceName = wsResp.Workspace.DisplayName
+
+	pipelines, err := fetchWorkspacePipelines(ctx, client, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[string]bool{}
+	addIssues := func(data json.RawMessage, key string) (pageInfoNode, error) {
+		var resp map[string]struct {
+			PageInfo pageInfoNode      `json:"pageInfo"`
+			Nodes    []backupIssueNode `json:"nodes"`
+		}
+		if err := json.Unmarshal(data, &resp); err != nil {
+			return pageInfoNode{}, err
+		}
+		for _, n := range resp[key].Nodes {
+			if !seen[n.ID] {
+				seen[n.ID] = true
+				a.Issues = append(a.Issues, n.issue())
+			}
+		}
+		return resp[key].PageInfo, nil
+	}
+
+	for _, p := range pipelines {
+		a.Pipelines = append(a.Pipelines, backup.Pipeline{ID: p.ID, Name: p.Name, Description: derefString(p.Description)})
+
+		vars := map[string]any{"pipelineId": p.ID, "workspaceId": workspaceID}
+		err := forEachQueryPage(ctx, client, backupPipelineIssuesQuery, vars, "issues in "+p.Name, func(data json.RawMessage) (pageInfoNode, error) {
+			return addIssues(data, "searchIssuesByPipeline")
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	vars := map[string]any{"workspaceId": workspaceID}
+	err = forEachQueryPage(ctx, client, backupClosedIssuesQuery, vars, "closed issues", func(data json.RawMessage) (pageInfoNode, error) {
+		return addIssues(data, "searchClosedIssues")
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	vars = map[string]any{"workspaceId": workspaceID}
+	err = forEachQueryPage(ctx, client, backupZenhubEpicsQuery, vars, "epics", func(data json.RawMessage) (pageInfoNode, error) {
+		var resp struct {
+			Workspace struct {
+				ZenhubEpics struct {
+					PageInfo pageInfoNode `json:"pageInfo"`
+					Nodes    []struct {
+						ID       string  `json:"id"`
+						Title    string  `json:"title"`
+						Body     string  `json:"body"`
+						State    string  `json:"state"`
+						StartOn  *string `json:"startOn"`
+						EndOn    *string `json:"endOn"`
+						KeyDates struct {
+							Nodes []backup.KeyDate `json:"nodes"`
+						} `json:"keyDates"`
+					} `json:"nodes"`
+				} `json:"zenhubEpics"`
+			} `json:"workspace"`
+		}
+		if err := json.Unmarshal(data, &resp); err != nil {
+			return pageInfoNode{}, err
+		}
+		for _, n := range resp.Workspace.ZenhubEpics.Nodes {
+			a.Epics = append(a.Epics, backup.Epic{
+				ID: n.ID, Type: "zenhub", Title: n.Title, Body: n.Body, State: n.State,
+				StartOn: n.StartOn, EndOn: n.EndOn, KeyDates: n.KeyDates.Nodes,
+			})
+		}
+		return resp.Workspace.ZenhubEpics.PageInfo, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	vars = map[string]any{"workspaceId": workspaceID}
+	err = forEachQueryPage(ctx, client, backupLegacyEpicsQuery, vars, "legacy epics", func(data json.RawMessage) (pageInfoNode, error) {
+		var resp struct {
+			Workspace struct {
+				Epics struct {
+					PageInfo pageInfoNode `json:"pageInfo"`
+					Nodes    []struct {
+						ID      string  `json:"id"`
+						StartOn *string `json:"startOn"`
+						EndOn   *string `json:"endOn"`
+						Issue   struct {
+							ID         string `json:"id"`
+							Number     int    `json:"number"`
+							Title      string `json:"title"`
+							State      string `json:"state"`
+							Repository struct {
+								Name      string `json:"name"`
+								OwnerName string `json:"ownerName"`
+							} `json:"repository"`
+						} `json:"issue"`
+					} `json:"nodes"`
+				} `json:"epics"`
+			} `json:"workspace"`
+		}
+		if err := json.Unmarshal(data, &resp); err != nil {
+			return pageInfoNode{}, err
+		}
+		for _, n := range resp.Workspace.Epics.Nodes {
+			a.Epics = append(a.Epics, backup.Epic{
+				ID: n.ID, Type: "legacy", Title: n.Issue.Title, State: n.Issue.State,
+				StartOn: n.StartOn, EndOn: n.EndOn,
+				Issue: &backup.IssueRef{
+					ID: n.Issue.ID, Number: n.Issue.Number,
+					RepoName: n.Issue.Repository.Name, RepoOwner: n.Issue.Repository.OwnerName,
+				},
+			})
+		}
+		return resp.Workspace.Epics.PageInfo, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	vars = map[string]any{"workspaceId": workspaceID}
+	err = forEachQueryPage(ctx, client, backupSprintsQuery, vars, "sprints", func(data json.RawMessage) (pageInfoNode, error) {
+		var resp struct {
+			Workspace struct {
+				Sprints struct {
+					PageInfo pageInfoNode `json:"pageInfo"`
+					Nodes    []struct {
+						ID            string `json:"id"`
+						Name          string `json:"name"`
+						GeneratedName string `json:"generatedName"`
+						State         string `json:"state"`
+						StartAt       string `json:"startAt"`
+						EndAt         string `json:"endAt"`
+					} `json:"nodes"`
+				} `json:"sprints"`
+			} `json:"workspace"`
+		}
+		if err := json.Unmarshal(data, &resp); err != nil {
+			return pageInfoNode{}, err
+		}
+		for _, n := range resp.Workspace.Sprints.Nodes {
+			a.Sprints = append(a.Sprints, backup.Sprint{
+				ID: n.ID, Name: cmp.Or(n.Name, n.GeneratedName), State: n.State, StartAt: n.StartAt, EndAt: n.EndAt,
+			})
+		}
+		return resp.Workspace.Sprints.PageInfo, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+// forEachQueryPage runs a paginated query, passing each page's data to
+// page, which returns the page info of the connection it read.
+func forEachQueryPage(ctx context.Context, client *api.Client, query string, vars map[string]any, what string, page func(data json.RawMessage) (pageInfoNode, error)) error {
+	for {
+		data, err := client.Execute(ctx, query, vars)
+		if err != nil {
+			return exitcode.General("fetching "+what, err)
+		}
+		info, err := page(data)
+		if err != nil {
+			return exitcode.General("parsing "+what+" response", err)
+		}
+		if !info.HasNextPage {
+			return nil
+		}
+		vars["after"] = info.EndCursor
+	}
+}
+
+type backupRepoNode struct {
+	GhID      int    `json:"ghId"`
+	Name      string `json:"name"`
+	OwnerName string `json:"ownerName"`
+}
+
+type backupIssueNode struct {
+	ID          string `json:"id"`
+	Number      int    `json:"number"`
+	Title       string `json:"title"`
+	State       string `json:"state"`
+	PullRequest bool   `json:"pullRequest"`
+	Estimate    *struct {
+		Value float64 `json:"value"`
+	} `json:"estimate"`
+	Repository backupRepoNode `json:"repository"`
+	Assignees  struct {
+		Nodes []struct {
+			Login string `json:"login"`
+		} `json:"nodes"`
+	} `json:"assignees"`
+	Labels struct {
+		Nodes []backup.Entity `json:"nodes"`
+	} `json:"labels"`
+	Sprints struct {
+		Nodes []struct {
+			ID            string `json:"id"`
+			Name          string `json:"name"`
+			GeneratedName string `json:"generatedName"`
+		} `json:"nodes"`
+	} `json:"sprints"`
+	ParentZenhubEpics struct {
+		Nodes []struct {
+			ID    string `json:"id"`
+			Title string `json:"title"`
+		} `json:"nodes"`
+	} `json:"parentZenhubEpics"`
+	BlockingItems struct {
+		Nodes []struct {
+			Typename   string          `json:"__typename"`
+			ID         string          `json:"id"`
+			Number     int             `json:"number"`
+			Title      string          `json:"title"`
+			Repository *backupRepoNode `json:"repository"`
+		} `json:"nodes"`
+	} `json:"blockingItems"`
+	ConnectedPrs struct {
+		Nodes []struct {
+			ID         string         `json:"id"`
+			Number     int            `json:"number"`
+			Repository backupRepoNode `json:"repository"`
+		} `json:"nodes"`
+	} `json:"connectedPrs"`
+	PipelineIssue *struct {
+		ID               string         `json:"id"`
+		RelativePosition *int           `json:"relativePosition"`
+		Pipeline         *backup.Entity `json:"pipeline"`
+		Priority         *backup.Entity `json:"priority"`
+	} `json:"pipelineIssue"`
+}
+
+// issue converts an issue from the API to its backup form.
+func (n backupIssueNode) issue() backup.Issue {
+	issue := backup.Issue{
+		IssueRef: backup.IssueRef{
+			ID:        n.ID,
+			Number:    n.Number,
+			RepoName:  n.Repository.Name,
+			RepoOwner: n.Repository.OwnerName,
+		},
+		RepoGhID:    n.Repository.GhID,
+		Title:       n.Title,
+		State:       n.State,
+		PullRequest: n.PullRequest,
+		Labels:      n.Labels.Nodes,
+	}
+	if n.Estimate != nil {
+		issue.Estimate = &n.Estimate.Value
+	}
+	if n.PipelineIssue != nil && n.State != "CLOSED" {
+		issue.PipelineIssueID = n.PipelineIssue.ID
+		issue.Pipeline = n.PipelineIssue.Pipeline
+		issue.Position = n.PipelineIssue.RelativePosition
+		issue.Priority = n.PipelineIssue.Priority
+	}
+	for _, a := range n.Assignees.Nodes {
+		issue.Assignees = append(issue.Assignees, a.Login)
+	}
+	for _, s := range n.Sprints.Nodes {
+		issue.Sprints = append(issue.Sprints, backup.Entity{ID: s.ID, Name: cmp.Or(s.Name, s.GeneratedName)})
+	}
+	for _, e := range n.ParentZenhubEpics.Nodes {
+		issue.Epics = append(issue.Epics, backup.Entity{ID: e.ID, Name: e.Title})
+	}
+	for _, b := range n.BlockingItems.Nodes {
+		name := b.Title
+		if b.Typename == "Issue" && b.Repository != nil {
+			name = fmt.Sprintf("%s#%d", b.Repository.Name, b.Number)
+		}
+		issue.BlockedBy = append(issue.BlockedBy, backup.Entity{ID: b.ID, Name: name})
+	}
+	for _, pr := range n.ConnectedPrs.Nodes {
+		issue.ConnectedPRs = append(issue.ConnectedPRs, backup.IssueRef{
+			ID: pr.ID, Number: pr.Number, RepoName: pr.Repository.Name, RepoOwner: pr.Repository.OwnerName,
+		})
+	}
+	return issue
+}
+
+// runWorkspaceRestore implements `zh workspace restore <archive>`.
+func runWorkspaceRestore(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+
+	cfg, err := requireWorkspace()
+	if err != nil {
+		return err
+	}
+
+	client := newClient(cfg, cmd)
+	w := cmd.OutOrStdout()
+
+	f, err := os.Open(args[0])
+	if err != nil {
+		return exitcode.General("opening backup", err)
+	}
+	archive, err := backup.Read(f)
+	_ = f.Close()
+	if err != nil {
+		return exitcode.Usage(fmt.Sprintf("reading %s: %v", args[0], err))
+	}
+
+	if archive.Manifest.WorkspaceID != cfg.Workspace {
+		fmt.Fprintln(cmd.ErrOrStderr(), output.Yellow(fmt.Sprintf(
+			"Warning: %s is a backup of workspace %q; pipelines, priorities, epics and sprints will be matched by name",
+			args[0], cmp.Or(archive.Manifest.WorkspaceName, archive.Manifest.WorkspaceID),
+		)))
+	}
+
+	current, err := fetchWorkspaceBackup(ctx, client, cfg.Workspace)
+	if err != nil {
+		return err
+	}
+	priorities, err := resolve.FetchPriorities(ctx, client, cfg.Workspace)
+	if err != nil {
+		return err
+	}
+
+	plan := planRestore(archive, current, priorities)
+	header := fmt.Sprintf("backup taken %s", output.FormatTimeAgo(archive.Manifest.CreatedAt))
+
+	// Dry run
+	if workspaceRestoreDryRun {
+		if output.IsJSON(outputFormat) {
+			return output.JSON(w, map[string]any{
+				"dryRun":      true,
+				"manifest":    archive.Manifest,
+				"createEpics": plan.Epics,
+				"changes":     plan.Changes,
+				"skipped":     plan.Skipped,
+			})
+		}
+		if len(plan.Epics) == 0 && len(plan.Changes) == 0 {
+			fmt.Fprintln(w, "Workspace already matches the backup.")
+		} else {
+			output.MutationDryRun(w, fmt.Sprintf("Would restore %d change(s) from the %s:", len(plan.Epics)+len(plan.Changes), header), restoreItems(plan.Epics, plan.Changes))
+		}
+		renderRestoreSkipped(w, plan.Skipped)
+		return nil
+	}
+
+	if len(plan.Epics) == 0 && len(plan.Changes) == 0 {
+		if output.IsJSON(outputFormat) {
+			return output.JSON(w, map[string]any{
+				"manifest": archive.Manifest,
+				"skipped":  plan.Skipped,
+			})
+		}
+		fmt.Fprintln(w, "Workspace already matches the backup.")
+		renderRestoreSkipped(w, plan.Skipped)
+		return nil
+	}
+
+	// Recreate deleted epics, then point the epic changes at the new IDs
+	created, failed := executeRestoreEpics(ctx, client, cfg.Workspace, plan.Epics)
+	changes := make([]history.Change, 0, len(plan.Changes))
+	for _, c := range plan.Changes {
+		if c.Kind == history.KindEpic {
+			id, ok := created[c.After.ID]
+			if !ok && slices.ContainsFunc(plan.Epics, func(e backup.Epic) bool { return e.ID == c.After.ID }) {
+				failed = append(failed, output.FailedItem{Ref: c.Issue.Ref(), Reason: fmt.Sprintf("epic %q could not be recreated", c.After.Name)})
+				continue
+			}
+			if ok {
+				after := *c.After
+				after.ID = id
+				c.After = &after
+			}
+		}
+		changes = append(changes, c)
+	}
+
+	states := map[string]*planIssue{}
+	for _, issue := range current.Issues {
+		states[issue.ID] = &planIssue{PipelineIssueID: issue.PipelineIssueID}
+	}
+	applied, changesFailed := executeHistoryChanges(ctx, client, cfg.Workspace, changes, states)
+	failed = append(failed, changesFailed...)
+
+	recordHistory(cmd.ErrOrStderr(), cfg.Workspace, "workspace restore", fmt.Sprintf("Restored %s (%s)", filepath.Base(args[0]), header), applied)
+
+	var createdEpics []backup.Epic
+	for _, e := range plan.Epics {
+		if _, ok := created[e.ID]; ok {
+			createdEpics = append(createdEpics, e)
+		}
+	}
+
+	if output.IsJSON(outputFormat) {
+		if err := output.JSON(w, map[string]any{
+			"manifest":     archive.Manifest,
+			"createdEpics": createdEpics,
+			"restored":     applied,
+			"skipped":      plan.Skipped,
+			"failed":       failed,
+		}); err != nil {
+			return err
+		}
+	} else {
+		done := output.Green(fmt.Sprintf("Restored %d change(s) from the %s.", len(createdEpics)+len(applied), header))
+		if len(failed) > 0 {
+			output.MutationPartialFailure(w, done, restoreItems(createdEpics, applied), failed)
+		} else {
+			output.MutationBatch(w, done, restoreItems(createdEpics, applied))
+		}
+		renderRestoreSkipped(w, plan.Skipped)
+	}
+
+	if len(failed) > 0 {
+		return exitcode.Generalf("some changes failed to restore")
+	}
+	return nil
+}
+
+// restorePlan is what a restore would do: the ZenHub epics to recreate, the
+// changes to make to issues, and what cannot be restored.
+type restorePlan struct {
+	Epics   []backup.Epic
+	Changes []history.Change
+	Skipped []output.FailedItem
+}
+
+// planRestore compares a backup with the current state of the workspace and
+// works out the changes that put the backed-up issues back in place.
+// Changes that add issues to recreated epics refer to the epic by its ID in
+// the backup.
+func planRestore(a, current *backup.Archive, priorities []resolve.CachedPriority) *restorePlan {
+	plan := &restorePlan{}
+
+	var pipelines, currentEpics, sprints, priorityEntities []backup.Entity
+	for _, p := range current.Pipelines {
+		pipelines = append(pipelines, backup.Entity{ID: p.ID, Name: p.Name})
+	}
+	for _, e := range current.Epics {
+		if e.Type == "zenhub" {
+			currentEpics = append(currentEpics, backup.Entity{ID: e.ID, Name: e.Title})
+		}
+	}
+	for _, s := range current.Sprints {
+		sprints = append(sprints, backup.Entity{ID: s.ID, Name: s.Name})
+	}
+	for _, p := range priorities {
+		priorityEntities = append(priorityEntities, backup.Entity{ID: p.ID, Name: p.Name})
+	}
+
+	// Epics: match by ID or title, recreating ZenHub epics that are gone
+	epics := map[string]backup.Entity{}
+	for _, e := range a.Epics {
+		if e.Type != "zenhub" {
+			if !slices.ContainsFunc(current.Epics, func(c backup.Epic) bool { return c.ID == e.ID }) {
+				plan.Skipped = append(plan.Skipped, output.FailedItem{Ref: e.Title, Reason: "legacy epic no longer exists and cannot be recreated"})
+			}
+			continue
+		}
+		if match, ok := matchBackupEntity(backup.Entity{ID: e.ID, Name: e.Title}, currentEpics); ok {
+			epics[e.ID] = match
+			continue
+		}
+		plan.Epics = append(plan.Epics, e)
+		epics[e.ID] = backup.Entity{ID: e.ID, Name: e.Title}
+	}
+
+	currentIssues := map[string]*backup.Issue{}
+	for i := range current.Issues {
+		currentIssues[current.Issues[i].ID] = &current.Issues[i]
+	}
+
+	var moves, others []history.Change
+	type reorderCandidate struct {
+		issue           history.Issue
+		pipeline        backup.Entity
+		current, wanted int
+	}
+	reorders := map[string][]reorderCandidate{}
+
+	for _, want := range a.Issues {
+		ref := want.Ref()
+		have, ok := currentIssues[want.ID]
+		if !ok {
+			plan.Skipped = append(plan.Skipped, output.FailedItem{Ref: ref, Reason: "no longer in the workspace"})
+			continue
+		}
+		issue := history.Issue{ID: want.ID, Number: want.Number, RepoName: want.RepoName, RepoOwner: want.RepoOwner, RepoGhID: want.RepoGhID}
+
+		wantOpen, haveOpen := want.State != "CLOSED", have.State != "CLOSED"
+		switch {
+		case wantOpen && !haveOpen:
+			plan.Skipped = append(plan.Skipped, output.FailedItem{Ref: ref, Reason: "closed since the backup; reopen it to restore its pipeline"})
+		case !wantOpen && haveOpen:
+			plan.Skipped = append(plan.Skipped, output.FailedItem{Ref: ref, Reason: "reopened since the backup; left in its pipeline"})
+		case wantOpen && want.Pipeline != nil:
+			pipeline, ok := matchBackupEntity(*want.Pipeline, pipelines)
+			if !ok {
+				plan.Skipped = append(plan.Skipped, output.FailedItem{Ref: ref, Reason: fmt.Sprintf("pipeline %q no longer exists", want.Pipeline.Name)})
+				break
+			}
+			if have.Pipeline == nil || have.Pipeline.ID != pipeline.ID {
+				var before *history.Value
+				if have.Pipeline != nil {
+					before = &history.Value{ID: have.Pipeline.ID, Name: have.Pipeline.Name, Position: have.Position}
+				}
+				moves = append(moves, history.Change{
+					Kind:   history.KindMove,
+					Issue:  issue,
+					Before: before,
+					After:  &history.Value{ID: pipeline.ID, Name: pipeline.Name, Position: want.Position},
+				})
+			} else if have.Position != nil && want.Position != nil {
+				reorders[pipeline.ID] = append(reorders[pipeline.ID], reorderCandidate{issue, pipeline, *have.Position, *want.Position})
+			}
+
+			if want.Priority != nil {
+				priority, ok := matchBackupEntity(*want.Priority, priorityEntities)
+				if !ok {
+					plan.Skipped = append(plan.Skipped, output.FailedItem{Ref: ref, Reason: fmt.Sprintf("priority %q no longer exists", want.Priority.Name)})
+				} else if have.Priority == nil || have.Priority.ID != priority.ID {
+					others = append(others, history.Change{Kind: history.KindPriority, Issue: issue, Before: entityHistoryValue(have.Priority), After: entityHistoryValue(&priority)})
+				}
+			} else if have.Priority != nil {
+				others = append(others, history.Change{Kind: history.KindPriority, Issue: issue, Before: entityHistoryValue(have.Priority)})
+			}
+		}
+
+		if historyEstimate(estimateHistoryValue(want.Estimate)) != historyEstimate(estimateHistoryValue(have.Estimate)) {
+			others = append(others, history.Change{Kind: history.KindEstimate, Issue: issue, Before: estimateHistoryValue(have.Estimate), After: estimateHistoryValue(want.Estimate)})
+		}
+
+		for _, l := range want.Labels {
+			if !slices.ContainsFunc(have.Labels, func(h backup.Entity) bool { return h.ID == l.ID }) {
+				others = append(others, history.Change{Kind: history.KindLabel, Issue: issue, After: entityHistoryValue(&l)})
+			}
+		}
+
+		for _, e := range want.Epics {
+			epic, ok := epics[e.ID]
+			if !ok {
+				plan.Skipped = append(plan.Skipped, output.FailedItem{Ref: ref, Reason: fmt.Sprintf("epic %q is not in the backup", e.Name)})
+				continue
+			}
+			if !slices.ContainsFunc(have.Epics, func(h backup.Entity) bool { return h.ID == epic.ID }) {
+				others = append(others, history.Change{Kind: history.KindEpic, Issue: issue, After: entityHistoryValue(&epic)})
+			}
+		}
+
+		for _, s := range want.Sprints {
+			sprint, ok := matchBackupEntity(s, sprints)
+			if !ok {
+				plan.Skipped = append(plan.Skipped, output.FailedItem{Ref: ref, Reason: fmt.Sprintf("sprint %q no longer exists", s.Name)})
+				continue
+			}
+			if !slices.ContainsFunc(have.Sprints, func(h backup.Entity) bool { return h.ID == sprint.ID }) {
+				others = append(others, history.Change{Kind: history.KindSprint, Issue: issue, After: entityHistoryValue(&sprint)})
+			}
+		}
+	}
+
+	// Reorder issues that stayed in their pipeline but are out of order,
+	// moving as few as possible: the longest run that is already in backup
+	// order stays put.
+	for _, p := range current.Pipelines {
+		candidates := reorders[p.ID]
+		slices.SortFunc(candidates, func(a, b reorderCandidate) int { return a.current - b.current })
+		wanted := make([]int, len(candidates))
+		for i, c := range candidates {
+			wanted[i] = c.wanted
+		}
+		keep := make([]bool, len(candidates))
+		for _, i := range longestIncreasingSubsequence(wanted) {
+			keep[i] = true
+		}
+		for i, c := range candidates {
+			if keep[i] {
+				continue
+			}
+			current, wanted := c.current, c.wanted
+			moves = append(moves, history.Change{
+				Kind:   history.KindMove,
+				Issue:  c.issue,
+				Before: &history.Value{ID: c.pipeline.ID, Name: c.pipeline.Name, Position: &current},
+				After:  &history.Value{ID: c.pipeline.ID, Name: c.pipeline.Name, Position: &wanted},
+			})
+		}
+	}
+
+	plan.Changes = append(moves, others...)
+	return plan
+}
+
+// matchBackupEntity finds the current entity with the backed-up entity's ID,
+// or failing that, its name.
+func matchBackupEntity(want backup.Entity, current []backup.Entity) (backup.Entity, bool) {
+	for _, c := range current {
+		if c.ID == want.ID {
+			return c, true
+		}
+	}
+	for _, c := range current {
+		if c.Name == want.Name {
+			return c, true
+		}
+	}
+	return backup.Entity{}, false
+}
+
+func entityHistoryValue(e *backup.Entity) *history.Value {
+	if e == nil {
+		return nil
+	}
+	return &history.Value{ID: e.ID, Name: e.Name}
+}
+
+// executeRestoreEpics recreates deleted ZenHub epics with their dates, state
+// and key dates. Returns the new ID of each epic created, by its ID in the
+// backup.
+func executeRestoreEpics(ctx context.Context, client *api.Client, workspaceID string, epics []backup.Epic) (map[string]string, []output.FailedItem) {
+	created := map[string]string{}
+	if len(epics) == 0 {
+		return created, nil
+	}
+
+	var failed []output.FailedItem
+	orgID, err := fetchWorkspaceOrgID(ctx, client, workspaceID)
+	if err != nil {
+		for _, e := range epics {
+			failed = append(failed, output.FailedItem{Ref: e.Title, Reason: err.Error()})
+		}
+		return created, failed
+	}
+
+	for _, e := range epics {
+		id, err := executeRestoreEpic(ctx, client, orgID, e)
+		if id != "" {
+			created[e.ID] = id
+		}
+		if err != nil {
+			failed = append(failed, output.FailedItem{Ref: e.Title, Reason: err.Error()})
+		}
+	}
+	_ = cache.Clear(resolve.EpicCacheKey(workspaceID))
+	return created, failed
+}
+
+// executeRestoreEpic creates a single epic. The new ID is returned once the
+// epic exists, even if setting its details then fails.
+func executeRestoreEpic(ctx context.Context, client *api.Client, orgID string, e backup.Epic) (string, error) {
+	epicInput := map[string]any{"title": e.Title}
+	if e.Body != "" {
+		epicInput["body"] = e.Body
+	}
+	data, err := client.Execute(ctx, createZenhubEpicMutation, map[string]any{
+		"input": map[string]any{
+			"zenhubOrganizationId": orgID,
+			"zenhubEpic":           epicInput,
+		},
+	})
+	if err != nil {
+		return "", exitcode.General("creating epic", err)
+	}
+
+	var resp struct {
+		CreateZenhubEpic struct {
+			ZenhubEpic struct {
+				ID string `json:"id"`
+			} `json:"zenhubEpic"`
+		} `json:"createZenhubEpic"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil || resp.CreateZenhubEpic.ZenhubEpic.ID == "" {
+		return "", exitcode.General("parsing create epic response", err)
+	}
+	id := resp.CreateZenhubEpic.ZenhubEpic.ID
+
+	if e.StartOn != nil || e.EndOn != nil {
+		input := map[string]any{"zenhubEpicId": id}
+		if e.StartOn != nil {
+			input["startOn"] = *e.StartOn
+		}
+		if e.EndOn != nil {
+			input["endOn"] = *e.EndOn
+		}
+		if _, err := client.Execute(ctx, updateZenhubEpicDatesMutation, map[string]any{"input": input}); err != nil {
+			return id, exitcode.General("updating epic dates", err)
+		}
+	}
+
+	if e.State != "" && e.State != "OPEN" {
+		_, err := client.Execute(ctx, updateZenhubEpicStateMutation, map[string]any{
+			"input": map[string]any{"zenhubEpicId": id, "state": e.State},
+		})
+		if err != nil {
+			return id, exitcode.General("updating epic state", err)
+		}
+	}
+
+	for _, kd := range e.KeyDates {
+		_, err := client.Execute(ctx, createZenhubEpicKeyDateMutation, map[string]any{
+			"input": map[string]any{"zenhubEpicId": id, "date": kd.Date, "description": kd.Description},
+		})
+		if err != nil {
+			return id, exitcode.General("creating key date", err)
+		}
+	}
+	return id, nil
+}
+
+func restoreItems(epics []backup.Epic, changes []history.Change) []output.MutationItem {
+	items := make([]output.MutationItem, 0, len(epics)+len(changes))
+	for _, e := range epics {
+		items = append(items, output.MutationItem{Ref: "epic", Title: fmt.Sprintf("recreate %q", e.Title)})
+	}
+	return append(items, historyChangeItems(changes)...)
+}
+
+func renderRestoreSkipped(w writerFlusher, skipped []output.FailedItem) {
+	if len(skipped) == 0 {
+		return
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, output.Yellow("Skipped:"))
+	fmt.Fprintln(w)
+	for _, s := range skipped {
+		fmt.Fprintf(w, "  %s  %s\n", s.Ref, output.Yellow(s.Reason))
+	}
+}
@@ -0,0 +1,383 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dslh/zh/internal/backup"
+	"github.com/dslh/zh/internal/history"
+	"github.com/dslh/zh/internal/testutil"
+)
+
+func backupIssueNodeResponse(id string, number int, state string, pipeline map[string]any, position int) map[string]any {
+	node := map[string]any{
+		"id":                id,
+		"number":            number,
+		"title":             "Issue " + id,
+		"state":             state,
+		"pullRequest":       false,
+		"estimate":          nil,
+		"repository":        map[string]any{"ghId": 100, "name": "api", "ownerName": "acme"},
+		"assignees":         map[string]any{"nodes": []any{}},
+		"labels":            map[string]any{"nodes": []any{}},
+		"sprints":           map[string]any{"nodes": []any{}},
+		"parentZenhubEpics": map[string]any{"nodes": []any{}},
+		"blockingItems":     map[string]any{"nodes": []any{}},
+		"connectedPrs":      map[string]any{"nodes": []any{}},
+		"pipelineIssue":     nil,
+	}
+	if pipeline != nil {
+		node["pipelineIssue"] = map[string]any{
+			"id":               "pi-" + id,
+			"relativePosition": position,
+			"pipeline":         pipeline,
+			"priority":         nil,
+		}
+	}
+	return node
+}
+
+func backupPageResponse(key string, nodes ...any) map[string]any {
+	return map[string]any{
+		"data": map[string]any{
+			key: map[string]any{
+				"pageInfo": map[string]any{"hasNextPage": false, "endCursor": ""},
+				"nodes":    nodes,
+			},
+		},
+	}
+}
+
+func backupWorkspacePageResponse(key string, nodes ...any) map[string]any {
+	return map[string]any{
+		"data": map[string]any{
+			"workspace": backupPageResponse(key, nodes...)["data"],
+		},
+	}
+}
+
+// setupWorkspaceBackupServer serves a workspace with api#1 (estimate 3,
+// label bug, epic Auth, Sprint 1, priority Urgent) and api#2 in New Issues,
+// api#3 in In Development and the closed api#4, a ZenHub epic and a legacy
+// epic, and an unnamed sprint.
+func setupWorkspaceBackupServer(t *testing.T) (*testutil.MockServer, *[]testutil.GraphQLRequest) {
+	t.Helper()
+	resetWorkspaceBackupFlags()
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+
+	newIssues := map[string]any{"id": "p1", "name": "New Issues"}
+	inDev := map[string]any{"id": "p2", "name": "In Development"}
+
+	issue1 := backupIssueNodeResponse("i1", 1, "OPEN", newIssues, 100)
+	issue1["estimate"] = map[string]any{"value": 3}
+	issue1["labels"] = map[string]any{"nodes": []any{map[string]any{"id": "l1", "name": "bug"}}}
+	issue1["assignees"] = map[string]any{"nodes": []any{map[string]any{"login": "alice"}}}
+	issue1["parentZenhubEpics"] = map[string]any{"nodes": []any{map[string]any{"id": "e1", "title": "Auth"}}}
+	issue1["sprints"] = map[string]any{"nodes": []any{map[string]any{"id": "s1", "name": "", "generatedName": "Sprint 1"}}}
+	issue1["blockingItems"] = map[string]any{"nodes": []any{
+		map[string]any{"__typename": "Issue", "id": "i3", "number": 3, "repository": map[string]any{"name": "api", "ownerName": "acme"}},
+		map[string]any{"__typename": "ZenhubEpic", "id": "e1", "title": "Auth"},
+	}}
+	issue1["pipelineIssue"].(map[string]any)["priority"] = map[string]any{"id": "pr1", "name": "Urgent"}
+
+	ms := testutil.NewMockServer(t)
+	ms.HandleQuery("mutation CreateZenhubEpic(", map[string]any{
+		"data": map[string]any{"createZenhubEpic": map[string]any{"zenhubEpic": map[string]any{"id": "e-new"}}},
+	})
+	mutations := recordMutations(ms)
+	ms.HandleQuery("BackupWorkspace", map[string]any{
+		"data": map[string]any{"workspace": map[string]any{"id": "ws-123", "displayName": "Dev"}},
+	})
+	ms.HandleQuery("ListPipelinesFull", pipelineListResponse())
+	for pipelineID, nodes := range map[string][]any{
+		"p1": {issue1, backupIssueNodeResponse("i2", 2, "OPEN", newIssues, 200)},
+		"p2": {backupIssueNodeResponse("i3", 3, "OPEN", inDev, 100)},
+		"p3": {},
+	} {
+		resp, _ := json.Marshal(backupPageResponse("searchIssuesByPipeline", nodes...))
+		ms.Handle(
+			func(req testutil.GraphQLRequest) bool {
+				return strings.Contains(req.Query, "BackupPipelineIssues") && strings.Contains(string(req.Variables), `"pipelineId":"`+pipelineID+`"`)
+			},
+			func(w http.ResponseWriter, req testutil.GraphQLRequest) { _, _ = w.Write(resp) },
+		)
+	}
+	ms.HandleQuery("BackupClosedIssues", backupPageResponse("searchClosedIssues", backupIssueNodeResponse("i4", 4, "CLOSED", nil, 0)))
+	ms.HandleQuery("BackupZenhubEpics", backupWorkspacePageResponse("zenhubEpics", map[string]any{
+		"id": "e1", "title": "Auth", "body": "Login work", "state": "IN_PROGRESS", "startOn": "2026-10-01", "endOn": nil,
+		"keyDates": map[string]any{"nodes": []any{map[string]any{"date": "2026-10-15", "description": "Beta", "color": ""}}},
+	}))
+	ms.HandleQuery("BackupLegacyEpics", backupWorkspacePageResponse("epics", map[string]any{
+		"id": "le1", "startOn": nil, "endOn": nil,
+		"issue": map[string]any{"id": "i9", "number": 9, "title": "Old epic", "state": "OPEN", "repository": map[string]any{"name": "api", "ownerName": "acme"}},
+	}))
+	ms.HandleQuery("BackupSprints", backupWorkspacePageResponse("sprints", map[string]any{
+		"id": "s1", "name": "", "generatedName": "Sprint 1", "state": "OPEN", "startAt": "2026-10-01T00:00:00Z", "endAt": "2026-10-15T00:00:00Z",
+	}))
+	ms.HandleQuery("GetWorkspacePriorities", priorityListResponse())
+	ms.HandleQuery("GetWorkspaceOrg", workspaceOrgResponse())
+	ms.HandleQuery("GetPipelinePositions", backupPageResponse("searchIssuesByPipeline"))
+	setupIssueTestEnv(t, ms)
+	return ms, mutations
+}
+
+func runWorkspaceBackupCmd(t *testing.T) (*backup.Archive, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "ws.tar.gz")
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"workspace", "backup", "-f", path})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("workspace backup returned error: %v", err)
+	}
+	resetWorkspaceBackupFlags()
+
+	if !strings.Contains(buf.String(), "Backed up 4 issue(s), 2 epic(s), 1 sprint(s) and 3 pipeline(s) to "+path) {
+		t.Errorf("unexpected backup output: %s", buf.String())
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	a, err := backup.Read(f)
+	if err != nil {
+		t.Fatalf("reading backup: %v", err)
+	}
+	return a, path
+}
+
+func writeTestBackup(t *testing.T, a *backup.Archive) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "ws.tar.gz")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if err := backup.Write(f, a); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestWorkspaceBackup(t *testing.T) {
+	setupWorkspaceBackupServer(t)
+
+	a, _ := runWorkspaceBackupCmd(t)
+
+	if a.Manifest.WorkspaceID != "ws-123" || a.Manifest.WorkspaceName != "Dev" {
+		t.Errorf("unexpected manifest: %+v", a.Manifest)
+	}
+	if len(a.Pipelines) != 3 || a.Pipelines[1].Description != "Active work" {
+		t.Errorf("unexpected pipelines: %+v", a.Pipelines)
+	}
+
+	issue := a.Issues[0]
+	if issue.Ref() != "api#1" || issue.Pipeline.Name != "New Issues" || *issue.Position != 100 || *issue.Estimate != 3 || issue.Priority.Name != "Urgent" {
+		t.Errorf("unexpected placement for api#1: %+v", issue)
+	}
+	if issue.Labels[0].Name != "bug" || issue.Assignees[0] != "alice" || issue.Epics[0].Name != "Auth" || issue.Sprints[0].Name != "Sprint 1" {
+		t.Errorf("unexpected metadata for api#1: %+v", issue)
+	}
+	if len(issue.BlockedBy) != 2 || issue.BlockedBy[0].Name != "api#3" || issue.BlockedBy[1].Name != "Auth" {
+		t.Errorf("unexpected blockers for api#1: %+v", issue.BlockedBy)
+	}
+	if closed := a.Issues[3]; closed.State != "CLOSED" || closed.Pipeline != nil {
+		t.Errorf("closed issue should have no pipeline: %+v", closed)
+	}
+
+	if a.Epics[0].Type != "zenhub" || a.Epics[0].Body != "Login work" || a.Epics[0].KeyDates[0].Description != "Beta" {
+		t.Errorf("unexpected ZenHub epic: %+v", a.Epics[0])
+	}
+	if a.Epics[1].Type != "legacy" || a.Epics[1].Title != "Old epic" || a.Epics[1].Issue.Ref() != "api#9" {
+		t.Errorf("unexpected legacy epic: %+v", a.Epics[1])
+	}
+	if a.Sprints[0].Name != "Sprint 1" {
+		t.Errorf("sprint should fall back to its generated name: %+v", a.Sprints[0])
+	}
+}
+
+func TestWorkspaceBackupJSON(t *testing.T) {
+	setupWorkspaceBackupServer(t)
+	path := filepath.Join(t.TempDir(), "ws.tar.gz")
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"workspace", "backup", "--file", path, "--output=json"})
+	defer func() { outputFormat = "" }()
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("workspace backup returned error: %v", err)
+	}
+	resetWorkspaceBackupFlags()
+
+	var result struct {
+		Path   string `json:"path"`
+		Issues int    `json:"issues"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+	if result.Path != path || result.Issues != 4 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("archive should be written: %v", err)
+	}
+}
+
+func TestWorkspaceBackupRequiresFile(t *testing.T) {
+	setupWorkspaceBackupServer(t)
+	// Flags keep their Changed state between executions in tests
+	workspaceBackupCmd.Flags().Lookup("file").Changed = false
+
+	rootCmd.SetOut(new(bytes.Buffer))
+	rootCmd.SetArgs([]string{"workspace", "backup"})
+	err := rootCmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), `"file" not set`) {
+		t.Errorf("expected a missing --file error, got %v", err)
+	}
+}
+
+func TestWorkspaceRestoreUnchanged(t *testing.T) {
+	_, mutations := setupWorkspaceBackupServer(t)
+	_, path := runWorkspaceBackupCmd(t)
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"workspace", "restore", path})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("workspace restore returned error: %v", err)
+	}
+
+	if !strings.Contains(buf.String(), "Workspace already matches the backup.") {
+		t.Errorf("restoring a fresh backup should make no changes, got: %s", buf.String())
+	}
+	if len(*mutations) != 0 {
+		t.Errorf("expected no mutations, got %d", len(*mutations))
+	}
+}
+
+// changedBackup returns a backup of the test workspace from before api#1
+// was moved to New Issues and re-estimated, api#2 and api#3 were reordered,
+// and the Launch epic was deleted.
+func changedBackup(t *testing.T) *backup.Archive {
+	t.Helper()
+	a, _ := runWorkspaceBackupCmd(t)
+	a.Manifest.CreatedAt = time.Now().Add(-48 * time.Hour)
+
+	estimate, pos1, pos2 := 5.0, 50, 300
+	i1 := &a.Issues[0]
+	i1.Pipeline = &backup.Entity{ID: "p2", Name: "In Development"}
+	i1.Position = &pos1
+	i1.Estimate = &estimate
+	i1.Labels = append(i1.Labels, backup.Entity{ID: "l2", Name: "enhancement"})
+	i1.Epics = append(i1.Epics, backup.Entity{ID: "e2", Name: "Launch"})
+	a.Issues[1].Pipeline = &backup.Entity{ID: "p2", Name: "In Development"}
+	a.Issues[1].Position = &pos2
+	a.Issues = append(a.Issues, backup.Issue{IssueRef: backup.IssueRef{ID: "i5", Number: 5, RepoName: "api", RepoOwner: "acme"}, State: "OPEN"})
+	a.Epics = append(a.Epics, backup.Epic{ID: "e2", Type: "zenhub", Title: "Launch", State: "OPEN"})
+	return a
+}
+
+func TestWorkspaceRestoreDryRun(t *testing.T) {
+	_, mutations := setupWorkspaceBackupServer(t)
+	path := writeTestBackup(t, changedBackup(t))
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"workspace", "restore", path, "--dry-run"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("workspace restore returned error: %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{
+		"Would restore 6 change(s) from the backup taken 2d ago:",
+		`recreate "Launch"`,
+		`api#1 pipeline: "New Issues" → "In Development"`,
+		`api#2 pipeline: "New Issues" → "In Development"`,
+		"api#1 estimate: 3 → 5",
+		`api#1 label "enhancement" added`,
+		`api#1 added to epic "Launch"`,
+		"api#5  no longer in the workspace",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("dry run should contain %q, got:\n%s", want, out)
+		}
+	}
+	if len(*mutations) != 0 {
+		t.Errorf("dry run should not mutate, got %d mutation(s)", len(*mutations))
+	}
+}
+
+func TestWorkspaceRestore(t *testing.T) {
+	_, mutations := setupWorkspaceBackupServer(t)
+	path := writeTestBackup(t, changedBackup(t))
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"workspace", "restore", path})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("workspace restore returned error: %v", err)
+	}
+
+	if !strings.Contains(buf.String(), "Restored 6 change(s) from the backup taken 2d ago.") {
+		t.Errorf("unexpected restore output: %s", buf.String())
+	}
+
+	var epicInput string
+	for _, m := range *mutations {
+		if strings.Contains(m.Query, "AddIssuesToZenhubEpics") {
+			epicInput = string(m.Variables)
+		}
+	}
+	if !strings.Contains(epicInput, "e-new") {
+		t.Errorf("api#1 should be added to the recreated epic, got variables %s", epicInput)
+	}
+
+	entries, err := history.Load("ws-123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Command != "workspace restore" || len(entries[0].Changes) != 5 {
+		t.Errorf("restore should be recorded in history, got %+v", entries)
+	}
+}
+
+func TestPlanRestoreReorder(t *testing.T) {
+	pos := func(n int) *int { return &n }
+	todo := &backup.Entity{ID: "p1", Name: "Todo"}
+	issue := func(id string, number int, position int) backup.Issue {
+		return backup.Issue{
+			IssueRef: backup.IssueRef{ID: id, Number: number, RepoName: "api"},
+			State:    "OPEN",
+			Pipeline: todo,
+			Position: pos(position),
+		}
+	}
+
+	// Backed up as 1, 2, 3, 4; now 2, 3, 1, 4. Only api#1 needs to move.
+	a := &backup.Archive{Issues: []backup.Issue{issue("i1", 1, 10), issue("i2", 2, 20), issue("i3", 3, 30), issue("i4", 4, 40)}}
+	current := &backup.Archive{
+		Pipelines: []backup.Pipeline{{ID: "p1", Name: "Todo"}},
+		Issues:    []backup.Issue{issue("i1", 1, 35), issue("i2", 2, 20), issue("i3", 3, 30), issue("i4", 4, 40)},
+	}
+
+	plan := planRestore(a, current, nil)
+	if len(plan.Changes) != 1 {
+		t.Fatalf("expected 1 change, got %+v", plan.Changes)
+	}
+	c := plan.Changes[0]
+	if c.Kind != history.KindMove || c.Issue.Ref() != "api#1" || *c.Before.Position != 35 || *c.After.Position != 10 {
+		t.Errorf("unexpected reorder: %+v", c)
+	}
+}
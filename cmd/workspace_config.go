@@ -0,0 +1,699 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/dslh/zh/internal/api"
+	"github.com/dslh/zh/internal/cache"
+	"github.com/dslh/zh/internal/exitcode"
+	"github.com/dslh/zh/internal/output"
+	"github.com/dslh/zh/internal/resolve"
+	"github.com/spf13/cobra"
+	"go.yaml.in/yaml/v3"
+)
+
+// workspaceSpec is the declarative workspace configuration read and written
+// by `zh workspace export` and `zh workspace apply`.
+type workspaceSpec struct {
+	Pipelines  []workspaceSpecPipeline `json:"pipelines,omitempty" yaml:"pipelines,omitempty"`
+	Priorities []workspaceSpecPriority `json:"priorities,omitempty" yaml:"priorities,omitempty"`
+	Labels     []workspaceSpecLabel    `json:"labels,omitempty" yaml:"labels,omitempty"`
+	Repos      []workspaceSpecRepo     `json:"repos,omitempty" yaml:"repos,omitempty"`
+}
+
+type workspaceSpecPipeline struct {
+	Name        string `json:"name" yaml:"name"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+type workspaceSpecPriority struct {
+	Name        string `json:"name" yaml:"name"`
+	Color       string `json:"color,omitempty" yaml:"color,omitempty"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+type workspaceSpecLabel struct {
+	Name  string `json:"name" yaml:"name"`
+	Color string `json:"color,omitempty" yaml:"color,omitempty"`
+}
+
+// workspaceSpecRepo is a connected repository. GhID is optional in files
+// written by hand; when it is missing, apply looks it up on GitHub.
+type workspaceSpecRepo struct {
+	Repo string `json:"repo" yaml:"repo"` // owner/name
+	GhID int    `json:"ghId,omitempty" yaml:"ghId,omitempty"`
+}
+
+// workspaceChange is a single change apply makes to bring the workspace in
+// line with the file.
+type workspaceChange struct {
+	Action   string   `json:"action"`   // "create", "update", "delete" or "add"
+	Resource string   `json:"resource"` // "pipeline" or "repo"
+	Name     string   `json:"name"`
+	Details  []string `json:"details,omitempty"`
+
+	id       string         // pipeline to update or delete
+	input    map[string]any // CreatePipeline / UpdatePipeline input fields
+	into     string         // pipeline receiving a deleted pipeline's issues
+	repoGhID int
+}
+
+// label returns a short description of the change, e.g. `create pipeline "QA"`.
+func (c workspaceChange) label() string {
+	return fmt.Sprintf("%s %s %q", c.Action, c.Resource, c.Name)
+}
+
+// Commands
+
+var workspaceExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the workspace configuration as YAML",
+	Long: `Write the current workspace's pipelines (in board order, with their
+descriptions), priorities, labels and connected repositories as YAML.
+
+The output can be edited and applied to this or another workspace with
+zh workspace apply. Use --output=json for JSON instead.
+
+Examples:
+  zh workspace export > ws.yml
+  zh workspace export --output=json`,
+	Args: cobra.NoArgs,
+	RunE: runWorkspaceExport,
+}
+
+var workspaceApplyCmd = &cobra.Command{
+	Use:   "apply <file>",
+	Short: "Make the workspace match a configuration file",
+	Long: `Compare the current workspace with a file written by zh workspace export
+and make the changes needed for the workspace to match it. Applying the
+same file twice makes no further changes. Pass "-" to read from stdin.
+
+Pipelines are matched by name (case-insensitively). Pipelines in the file
+that the workspace lacks are created, and existing pipelines are updated
+to match the file's order and descriptions. Pipelines that are not in the
+file are deleted, which requires --into to name the pipeline (from the
+file) that receives their issues. Renaming a pipeline in the file deletes
+the old pipeline and creates a new one.
+
+Repositories in the file that are not connected are added. Repositories
+are never disconnected — those not in the file are reported, and can be
+removed with zh workspace repo remove. Repositories without a ghId are
+looked up on GitHub, which requires GitHub authentication.
+
+Priorities and labels cannot be changed through zh, so differences from
+the file are reported as warnings. A section that is missing or empty in
+the file is left alone.
+
+Examples:
+  zh workspace apply ws.yml --dry-run
+  zh workspace apply ws.yml
+  zh workspace apply ws.yml --into=Backlog`,
+	Args: cobra.ExactArgs(1),
+	RunE: runWorkspaceApply,
+}
+
+var (
+	workspaceApplyDryRun bool
+	workspaceApplyInto   string
+)
+
+func init() {
+	workspaceApplyCmd.Flags().BoolVar(&workspaceApplyDryRun, "dry-run", false, "Show the changes that would be made without executing them")
+	workspaceApplyCmd.Flags().StringVar(&workspaceApplyInto, "into", "", "Pipeline that receives the issues of deleted pipelines")
+
+	workspaceCmd.AddCommand(workspaceExportCmd)
+	workspaceCmd.AddCommand(workspaceApplyCmd)
+}
+
+func resetWorkspaceConfigFlags() {
+	workspaceApplyDryRun = false
+	workspaceApplyInto = ""
+}
+
+// runWorkspaceExport implements `zh workspace export`.
+func runWorkspaceExport(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+
+	cfg, err := requireWorkspace()
+	if err != nil {
+		return err
+	}
+
+	client := newClient(cfg, cmd)
+	w := cmd.OutOrStdout()
+
+	live, err := fetchWorkspaceState(ctx, client, cfg.Workspace)
+	if err != nil {
+		return err
+	}
+
+	spec := live.spec()
+	if output.IsJSON(outputFormat) {
+		return output.JSON(w, spec)
+	}
+
+	data, err := yaml.Marshal(spec)
+	if err != nil {
+		return exitcode.General("formatting workspace configuration", err)
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// runWorkspaceApply implements `zh workspace apply <file>`.
+func runWorkspaceApply(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+
+	cfg, err := requireWorkspace()
+	if err != nil {
+		return err
+	}
+
+	client := newClient(cfg, cmd)
+	w := cmd.OutOrStdout()
+	errW := cmd.ErrOrStderr()
+
+	spec, err := loadWorkspaceSpec(cmd.InOrStdin(), args[0])
+	if err != nil {
+		return err
+	}
+
+	live, err := fetchWorkspaceState(ctx, client, cfg.Workspace)
+	if err != nil {
+		return err
+	}
+
+	changes, err := diffWorkspacePipelines(live.Pipelines, spec.Pipelines, workspaceApplyInto)
+	if err != nil {
+		return err
+	}
+	repoChanges, warnings, err := diffWorkspaceRepos(live.Repos, spec.Repos, func(owner, name string) (int, string, error) {
+		ghClient := newGitHubClient(cfg, cmd)
+		if ghClient == nil {
+			return 0, "", exitcode.Auth(fmt.Sprintf("GitHub authentication required to look up %s/%s — configure ZH_GITHUB_TOKEN or run 'gh auth login', or add its ghId to the file", owner, name), nil)
+		}
+		return lookupGitHubRepo(ctx, ghClient, owner, name)
+	})
+	if err != nil {
+		return err
+	}
+	changes = append(changes, repoChanges...)
+	warnings = append(warnings, diffWorkspacePriorities(live.Priorities, spec.Priorities)...)
+	warnings = append(warnings, diffWorkspaceLabels(live.Labels, spec.Labels)...)
+
+	if !output.IsJSON(outputFormat) {
+		for _, warning := range warnings {
+			fmt.Fprintln(errW, output.Yellow("Warning: "+warning))
+		}
+	}
+
+	if len(changes) == 0 || workspaceApplyDryRun {
+		if output.IsJSON(outputFormat) {
+			return output.JSON(w, map[string]any{
+				"dryRun":   workspaceApplyDryRun,
+				"changes":  nonNilChanges(changes),
+				"warnings": warnings,
+			})
+		}
+		if len(changes) == 0 {
+			fmt.Fprintln(w, "Workspace already matches the configuration.")
+			return nil
+		}
+		output.MutationDryRun(w, fmt.Sprintf("Would make %d change(s) to the workspace:", len(changes)), workspaceChangeItems(changes))
+		return nil
+	}
+
+	applied, failure := executeWorkspaceChanges(ctx, client, cfg.Workspace, live, changes)
+
+	if changesResource(applied, "pipeline") {
+		_ = cache.Clear(resolve.PipelineCacheKey(cfg.Workspace))
+	}
+	if changesResource(applied, "repo") {
+		_ = cache.Clear(resolve.RepoCacheKey(cfg.Workspace))
+	}
+
+	if output.IsJSON(outputFormat) {
+		result := map[string]any{
+			"dryRun":   false,
+			"changes":  nonNilChanges(applied),
+			"warnings": warnings,
+		}
+		if failure != nil {
+			result["failed"] = failure
+		}
+		if err := output.JSON(w, result); err != nil {
+			return err
+		}
+	} else if failure != nil {
+		header := output.Green(fmt.Sprintf("Made %d of %d change(s).", len(applied), len(changes)))
+		output.MutationPartialFailure(w, header, workspaceChangeItems(applied), []output.FailedItem{*failure})
+	} else {
+		output.MutationBatch(w, output.Green(fmt.Sprintf("Made %d change(s) to the workspace.", len(changes))), workspaceChangeItems(applied))
+	}
+
+	if failure != nil {
+		return exitcode.Generalf("workspace only partly updated — rerun zh workspace apply to make the remaining changes")
+	}
+	return nil
+}
+
+// loadWorkspaceSpec reads and validates a workspace configuration file, or
+// stdin if path is "-". JSON files are accepted too, as JSON is valid YAML.
+func loadWorkspaceSpec(stdin io.Reader, path string) (*workspaceSpec, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, exitcode.General("reading workspace configuration", err)
+	}
+
+	var spec workspaceSpec
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&spec); err != nil && !errors.Is(err, io.EOF) {
+		return nil, exitcode.Usage(fmt.Sprintf("%s is not a valid workspace configuration: %v", path, err))
+	}
+
+	seen := make(map[string]bool)
+	for _, p := range spec.Pipelines {
+		key := strings.ToLower(p.Name)
+		if p.Name == "" {
+			return nil, exitcode.Usage(fmt.Sprintf("%s: every pipeline needs a name", path))
+		}
+		if seen[key] {
+			return nil, exitcode.Usage(fmt.Sprintf("%s: pipeline %q is listed more than once", path, p.Name))
+		}
+		seen[key] = true
+	}
+	for _, r := range spec.Repos {
+		if _, _, ok := splitOwnerRepo(r.Repo); !ok {
+			return nil, exitcode.Usage(fmt.Sprintf("%s: repository %q must be in owner/repo format", path, r.Repo))
+		}
+	}
+	return &spec, nil
+}
+
+// workspaceState is the live configuration of a workspace.
+type workspaceState struct {
+	Pipelines  []pipelineListEntry
+	Priorities []resolve.CachedPriority
+	Labels     []resolve.CachedLabel
+	Repos      []repoNode
+}
+
+// fetchWorkspaceState fetches the parts of a workspace covered by its
+// configuration file.
+func fetchWorkspaceState(ctx context.Context, client *api.Client, workspaceID string) (*workspaceState, error) {
+	pipelines, err := fetchWorkspacePipelines(ctx, client, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	state := &workspaceState{Pipelines: pipelines}
+
+	if state.Priorities, err = resolve.FetchPriorities(ctx, client, workspaceID); err != nil {
+		return nil, err
+	}
+	if state.Labels, err = resolve.FetchLabels(ctx, client, workspaceID); err != nil {
+		return nil, err
+	}
+	if state.Repos, err = fetchWorkspaceRepos(ctx, client, workspaceID); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// fetchWorkspacePipelines fetches the workspace's pipelines in board order.
+func fetchWorkspacePipelines(ctx context.Context, client *api.Client, workspaceID string) ([]pipelineListEntry, error) {
+	data, err := client.Execute(ctx, listPipelinesFullQuery, map[string]any{
+		"workspaceId": workspaceID,
+	})
+	if err != nil {
+		return nil, exitcode.General("fetching pipelines", err)
+	}
+
+	var resp struct {
+		Workspace struct {
+			PipelinesConnection struct {
+				Nodes []pipelineListEntry `json:"nodes"`
+			} `json:"pipelinesConnection"`
+		} `json:"workspace"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, exitcode.General("parsing pipelines response", err)
+	}
+	return resp.Workspace.PipelinesConnection.Nodes, nil
+}
+
+// spec converts the live state to a configuration file. Labels and repos are
+// sorted so that exports of equivalent workspaces are identical.
+func (s *workspaceState) spec() workspaceSpec {
+	var spec workspaceSpec
+	for _, p := range s.Pipelines {
+		spec.Pipelines = append(spec.Pipelines, workspaceSpecPipeline{Name: p.Name, Description: derefString(p.Description)})
+	}
+	for _, p := range s.Priorities {
+		spec.Priorities = append(spec.Priorities, workspaceSpecPriority{Name: p.Name, Color: p.Color, Description: p.Description})
+	}
+	for _, l := range s.Labels {
+		spec.Labels = append(spec.Labels, workspaceSpecLabel{Name: l.Name, Color: l.Color})
+	}
+	slices.SortFunc(spec.Labels, func(a, b workspaceSpecLabel) int {
+		return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+	})
+	for _, r := range s.Repos {
+		spec.Repos = append(spec.Repos, workspaceSpecRepo{Repo: r.OwnerName + "/" + r.Name, GhID: r.GhID})
+	}
+	slices.SortFunc(spec.Repos, func(a, b workspaceSpecRepo) int {
+		return strings.Compare(strings.ToLower(a.Repo), strings.ToLower(b.Repo))
+	})
+	return spec
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// diffWorkspacePipelines returns the pipeline changes that make live match
+// want, in the order they must be made. Creates and moves are worked out by
+// placing each wanted pipeline at its index in turn, tracking the order the
+// workspace will have after each change. Pipelines that are not wanted are
+// deleted first if their destination already exists, or last, once it has
+// been created; in the meantime they sit after the wanted pipelines.
+func diffWorkspacePipelines(live []pipelineListEntry, want []workspaceSpecPipeline, into string) ([]workspaceChange, error) {
+	if len(want) == 0 {
+		return nil, nil
+	}
+
+	wanted := func(name string) bool {
+		return slices.ContainsFunc(want, func(p workspaceSpecPipeline) bool { return strings.EqualFold(p.Name, name) })
+	}
+
+	byName := make(map[string]pipelineListEntry, len(live))
+	order := make([]string, 0, len(live)) // lowercased names, in board order
+	var removed []pipelineListEntry
+	for _, p := range live {
+		key := strings.ToLower(p.Name)
+		byName[key] = p
+		order = append(order, key)
+		if !wanted(p.Name) {
+			removed = append(removed, p)
+		}
+	}
+
+	var deletes []workspaceChange
+	deleteFirst := false
+	if len(removed) > 0 {
+		names := make([]string, len(removed))
+		for i, p := range removed {
+			names[i] = fmt.Sprintf("%q", p.Name)
+		}
+		if into == "" {
+			return nil, exitcode.Usage(fmt.Sprintf("pipeline(s) %s are not in the file — pass --into=<pipeline> to delete them and move their issues", strings.Join(names, ", ")))
+		}
+		dest := slices.IndexFunc(want, func(p workspaceSpecPipeline) bool { return strings.EqualFold(p.Name, into) })
+		if dest < 0 {
+			return nil, exitcode.Usage(fmt.Sprintf("--into pipeline %q is not in the file", into))
+		}
+
+		for _, p := range removed {
+			deletes = append(deletes, workspaceChange{
+				Action:   "delete",
+				Resource: "pipeline",
+				Name:     p.Name,
+				Details:  []string{fmt.Sprintf("move %d open issue(s) to %q", p.Issues.TotalCount, want[dest].Name)},
+				id:       p.ID,
+				into:     want[dest].Name,
+			})
+		}
+
+		_, deleteFirst = byName[strings.ToLower(want[dest].Name)]
+		if deleteFirst {
+			order = slices.DeleteFunc(order, func(key string) bool { return !wanted(key) })
+		}
+	}
+
+	var changes []workspaceChange
+	if deleteFirst {
+		changes = append(changes, deletes...)
+	}
+
+	for i, p := range want {
+		key := strings.ToLower(p.Name)
+		current := slices.Index(order, key)
+		if current < 0 {
+			input := map[string]any{"name": p.Name, "position": i}
+			details := []string{fmt.Sprintf("position %d", i)}
+			if p.Description != "" {
+				input["description"] = p.Description
+				details = append(details, fmt.Sprintf("description %q", p.Description))
+			}
+			changes = append(changes, workspaceChange{Action: "create", Resource: "pipeline", Name: p.Name, Details: details, input: input})
+			order = slices.Insert(order, i, key)
+			continue
+		}
+
+		existing := byName[key]
+		input := map[string]any{}
+		var details []string
+		if existing.Name != p.Name {
+			input["name"] = p.Name
+			details = append(details, fmt.Sprintf("name %q → %q", existing.Name, p.Name))
+		}
+		if derefString(existing.Description) != p.Description {
+			input["description"] = p.Description
+			if p.Description == "" {
+				details = append(details, "clear description")
+			} else {
+				details = append(details, fmt.Sprintf("description %q", p.Description))
+			}
+		}
+		if current != i {
+			input["position"] = i
+			details = append(details, fmt.Sprintf("position %d → %d", current, i))
+			order = slices.Delete(order, current, current+1)
+			order = slices.Insert(order, i, key)
+		}
+		if len(input) > 0 {
+			changes = append(changes, workspaceChange{Action: "update", Resource: "pipeline", Name: existing.Name, Details: details, id: existing.ID, input: input})
+		}
+	}
+
+	if !deleteFirst {
+		changes = append(changes, deletes...)
+	}
+	return changes, nil
+}
+
+// diffWorkspaceRepos returns the repos to add so that every repo in want is
+// connected, and warnings for connected repos that are not in want. lookup
+// finds the GitHub ID of repos listed without one.
+func diffWorkspaceRepos(live []repoNode, want []workspaceSpecRepo, lookup func(owner, name string) (int, string, error)) ([]workspaceChange, []string, error) {
+	if len(want) == 0 {
+		return nil, nil, nil
+	}
+
+	connected := func(r workspaceSpecRepo) bool {
+		return slices.ContainsFunc(live, func(n repoNode) bool {
+			return (r.GhID != 0 && n.GhID == r.GhID) || strings.EqualFold(n.OwnerName+"/"+n.Name, r.Repo)
+		})
+	}
+
+	var changes []workspaceChange
+	for _, r := range want {
+		if connected(r) {
+			continue
+		}
+		ghID, display := r.GhID, r.Repo
+		if ghID == 0 {
+			owner, name, _ := splitOwnerRepo(r.Repo)
+			var err error
+			if ghID, display, err = lookup(owner, name); err != nil {
+				return nil, nil, err
+			}
+		}
+		changes = append(changes, workspaceChange{
+			Action:   "add",
+			Resource: "repo",
+			Name:     display,
+			Details:  []string{fmt.Sprintf("gh-id %d", ghID)},
+			repoGhID: ghID,
+		})
+	}
+
+	var warnings []string
+	for _, n := range live {
+		inFile := slices.ContainsFunc(want, func(r workspaceSpecRepo) bool {
+			return (r.GhID != 0 && n.GhID == r.GhID) || strings.EqualFold(n.OwnerName+"/"+n.Name, r.Repo)
+		})
+		if !inFile {
+			warnings = append(warnings, fmt.Sprintf("repository %s/%s is connected but not in the file — remove it with zh workspace repo remove", n.OwnerName, n.Name))
+		}
+	}
+	return changes, warnings, nil
+}
+
+// diffWorkspacePriorities reports differences between the workspace's
+// priorities and the file. zh has no way to change priorities.
+func diffWorkspacePriorities(live []resolve.CachedPriority, want []workspaceSpecPriority) []string {
+	if len(want) == 0 {
+		return nil
+	}
+
+	var warnings []string
+	for _, p := range want {
+		i := slices.IndexFunc(live, func(l resolve.CachedPriority) bool { return strings.EqualFold(l.Name, p.Name) })
+		switch {
+		case i < 0:
+			warnings = append(warnings, fmt.Sprintf("priority %q is not in the workspace — create it in ZenHub", p.Name))
+		case p.Color != "" && !strings.EqualFold(live[i].Color, p.Color):
+			warnings = append(warnings, fmt.Sprintf("priority %q has color %s, not %s — change it in ZenHub", p.Name, live[i].Color, p.Color))
+		case p.Description != live[i].Description:
+			warnings = append(warnings, fmt.Sprintf("priority %q has a different description — change it in ZenHub", p.Name))
+		}
+	}
+	for _, l := range live {
+		if !slices.ContainsFunc(want, func(p workspaceSpecPriority) bool { return strings.EqualFold(l.Name, p.Name) }) {
+			warnings = append(warnings, fmt.Sprintf("priority %q is not in the file — remove it in ZenHub", l.Name))
+		}
+	}
+	return warnings
+}
+
+// diffWorkspaceLabels reports labels in the file that are missing from, or
+// have a different color in, the workspace's repositories. Labels belong to
+// GitHub repositories, so zh does not change them, and labels that are not
+// in the file are not reported.
+func diffWorkspaceLabels(live []resolve.CachedLabel, want []workspaceSpecLabel) []string {
+	var warnings []string
+	for _, l := range want {
+		i := slices.IndexFunc(live, func(c resolve.CachedLabel) bool { return strings.EqualFold(c.Name, l.Name) })
+		switch {
+		case i < 0:
+			warnings = append(warnings, fmt.Sprintf("label %q is not in any repository — create it in GitHub", l.Name))
+		case l.Color != "" && !strings.EqualFold(live[i].Color, l.Color):
+			warnings = append(warnings, fmt.Sprintf("label %q has color %s, not %s — change it in GitHub", l.Name, live[i].Color, l.Color))
+		}
+	}
+	return warnings
+}
+
+// executeWorkspaceChanges makes the changes in order, stopping at the first
+// failure, since later changes can depend on earlier ones. Returns the
+// changes made and the failure, if any.
+func executeWorkspaceChanges(ctx context.Context, client *api.Client, workspaceID string, live *workspaceState, changes []workspaceChange) ([]workspaceChange, *output.FailedItem) {
+	pipelineIDs := make(map[string]string, len(live.Pipelines))
+	for _, p := range live.Pipelines {
+		pipelineIDs[strings.ToLower(p.Name)] = p.ID
+	}
+
+	var applied []workspaceChange
+	for _, c := range changes {
+		if err := executeWorkspaceChange(ctx, client, workspaceID, pipelineIDs, c); err != nil {
+			return applied, &output.FailedItem{Ref: c.label(), Reason: err.Error()}
+		}
+		applied = append(applied, c)
+	}
+	return applied, nil
+}
+
+func executeWorkspaceChange(ctx context.Context, client *api.Client, workspaceID string, pipelineIDs map[string]string, c workspaceChange) error {
+	switch {
+	case c.Resource == "pipeline" && c.Action == "create":
+		input := map[string]any{"workspaceId": workspaceID}
+		for k, v := range c.input {
+			input[k] = v
+		}
+		data, err := client.Execute(ctx, createPipelineMutation, map[string]any{"input": input})
+		if err != nil {
+			return err
+		}
+		var resp struct {
+			CreatePipeline struct {
+				Pipeline struct {
+					ID string `json:"id"`
+				} `json:"pipeline"`
+			} `json:"createPipeline"`
+		}
+		if err := json.Unmarshal(data, &resp); err != nil {
+			return fmt.Errorf("parsing create pipeline response: %w", err)
+		}
+		pipelineIDs[strings.ToLower(c.Name)] = resp.CreatePipeline.Pipeline.ID
+		return nil
+
+	case c.Resource == "pipeline" && c.Action == "update":
+		input := map[string]any{"pipelineId": c.id}
+		for k, v := range c.input {
+			input[k] = v
+		}
+		_, err := client.Execute(ctx, updatePipelineMutation, map[string]any{"input": input})
+		return err
+
+	case c.Resource == "pipeline" && c.Action == "delete":
+		dest, ok := pipelineIDs[strings.ToLower(c.into)]
+		if !ok {
+			return fmt.Errorf("pipeline %q not found", c.into)
+		}
+		_, err := client.Execute(ctx, deletePipelineMutation, map[string]any{
+			"input": map[string]any{
+				"pipelineId":            c.id,
+				"destinationPipelineId": dest,
+			},
+		})
+		return err
+
+	case c.Resource == "repo" && c.Action == "add":
+		_, err := client.Execute(ctx, addRepositoryToWorkspaceMutation, map[string]any{
+			"input": map[string]any{
+				"workspaceId":    workspaceID,
+				"repositoryGhId": c.repoGhID,
+			},
+		})
+		return err
+	}
+	return fmt.Errorf("unsupported change %s", c.label())
+}
+
+func workspaceChangeItems(changes []workspaceChange) []output.MutationItem {
+	items := make([]output.MutationItem, len(changes))
+	for i, c := range changes {
+		items[i] = output.MutationItem{
+			Ref:   c.Action + " " + c.Resource,
+			Title: fmt.Sprintf("%q", c.Name),
+		}
+		if len(c.Details) > 0 {
+			items[i].Title += " (" + strings.Join(c.Details, ", ") + ")"
+		}
+	}
+	return items
+}
+
+// changesResource reports whether any of the changes were made to the given
+// kind of resource.
+func changesResource(changes []workspaceChange, resource string) bool {
+	return slices.ContainsFunc(changes, func(c workspaceChange) bool { return c.Resource == resource })
+}
+
+// nonNilChanges returns changes, or an empty list if there are none, so
+// that JSON output always has an array.
+func nonNilChanges(changes []workspaceChange) []workspaceChange {
+	if changes == nil {
+		return []workspaceChange{}
+	}
+	return changes
+}
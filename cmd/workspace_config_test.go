@@ -0,0 +1,405 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/dslh/zh/internal/exitcode"
+	"github.com/dslh/zh/internal/testutil"
+)
+
+// setupWorkspaceConfigServer serves the live state of a workspace with the
+// pipelines New Issues, In Development ("Active work") and Done, the
+// priorities and labels from the list tests, and one connected repo.
+func setupWorkspaceConfigServer(t *testing.T) (*testutil.MockServer, *[]testutil.GraphQLRequest) {
+	t.Helper()
+	resetWorkspaceConfigFlags()
+
+	ms := testutil.NewMockServer(t)
+	mutations := recordMutations(ms)
+	ms.HandleQuery("ListPipelinesFull", pipelineListResponse())
+	ms.HandleQuery("GetWorkspacePriorities", priorityListResponse())
+	ms.HandleQuery("GetWorkspaceLabels", labelListResponse())
+	ms.HandleQuery("WorkspaceRepos", map[string]any{
+		"data": map[string]any{
+			"workspace": map[string]any{
+				"repositoriesConnection": map[string]any{
+					"totalCount": 1,
+					"pageInfo":   map[string]any{"hasNextPage": false, "endCursor": ""},
+					"nodes": []any{
+						map[string]any{"id": "r1", "ghId": 12345, "name": "task-tracker", "ownerName": "dlakehammond"},
+					},
+				},
+			},
+		},
+	})
+	setupIssueTestEnv(t, ms)
+	return ms, mutations
+}
+
+func writeWorkspaceSpec(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "ws.yml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// liveWorkspaceSpec matches the workspace served by setupWorkspaceConfigServer.
+const liveWorkspaceSpec = `pipelines:
+  - name: New Issues
+  - name: In Development
+    description: Active work
+  - name: Done
+priorities:
+  - {name: Urgent, color: ff5630, description: Needs immediate attention}
+  - {name: High, color: ff7452, description: Important}
+  - {name: Medium, color: ffab00, description: Normal priority}
+  - {name: Low, color: 36b37e, description: Can wait}
+labels:
+  - {name: bug, color: d73a4a}
+repos:
+  - repo: dlakehammond/task-tracker
+`
+
+func TestWorkspaceExport(t *testing.T) {
+	setupWorkspaceConfigServer(t)
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"workspace", "export"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("workspace export returned error: %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{
+		"pipelines:\n    - name: New Issues\n    - name: In Development\n      description: Active work\n    - name: Done\n",
+		"- name: Urgent\n      color: ff5630\n      description: Needs immediate attention",
+		"- name: bug\n      color: d73a4a\n    - name: enhancement",
+		"repos:\n    - repo: dlakehammond/task-tracker\n      ghId: 12345",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("export should contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestWorkspaceExportRoundTrip(t *testing.T) {
+	_, mutations := setupWorkspaceConfigServer(t)
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"workspace", "export"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("workspace export returned error: %v", err)
+	}
+	path := writeWorkspaceSpec(t, buf.String())
+
+	buf.Reset()
+	errBuf := new(bytes.Buffer)
+	rootCmd.SetErr(errBuf)
+	rootCmd.SetArgs([]string{"workspace", "apply", path})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("workspace apply returned error: %v", err)
+	}
+	rootCmd.SetErr(os.Stderr)
+
+	if !strings.Contains(buf.String(), "Workspace already matches the configuration.") {
+		t.Errorf("applying an export should make no changes, got: %s", buf.String())
+	}
+	if errBuf.Len() != 0 {
+		t.Errorf("applying an export should not warn, got: %s", errBuf.String())
+	}
+	if len(*mutations) != 0 {
+		t.Errorf("expected no mutations, got %d", len(*mutations))
+	}
+}
+
+func TestWorkspaceApplyUnchanged(t *testing.T) {
+	_, mutations := setupWorkspaceConfigServer(t)
+	path := writeWorkspaceSpec(t, liveWorkspaceSpec)
+
+	buf := new(bytes.Buffer)
+	errBuf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(errBuf)
+	defer rootCmd.SetErr(os.Stderr)
+	rootCmd.SetArgs([]string{"workspace", "apply", path, "--output=json"})
+	defer func() { outputFormat = "" }()
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("workspace apply returned error: %v", err)
+	}
+
+	var result struct {
+		Changes  []workspaceChange `json:"changes"`
+		Warnings []string          `json:"warnings"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, buf.String())
+	}
+	if result.Changes == nil || len(result.Changes) != 0 || len(result.Warnings) != 0 {
+		t.Errorf("expected no changes or warnings, got %+v", result)
+	}
+	if errBuf.Len() != 0 || len(*mutations) != 0 {
+		t.Errorf("expected no warnings or mutations, got %q and %d mutation(s)", errBuf.String(), len(*mutations))
+	}
+}
+
+func TestWorkspaceApplyDryRun(t *testing.T) {
+	_, mutations := setupWorkspaceConfigServer(t)
+	path := writeWorkspaceSpec(t, `pipelines:
+  - name: Backlog
+    description: Not yet planned
+  - name: In Development
+  - name: New Issues
+repos:
+  - repo: dlakehammond/task-tracker
+  - repo: dlakehammond/api
+    ghId: 999
+`)
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"workspace", "apply", path, "--into=Backlog", "--dry-run"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("workspace apply --dry-run returned error: %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{
+		"Would make 4 change(s) to the workspace:",
+		`create pipeline "Backlog" (position 0, description "Not yet planned")`,
+		`update pipeline "In Development" (clear description, position 2 → 1)`,
+		`delete pipeline "Done" (move 42 open issue(s) to "Backlog")`,
+		`add repo "dlakehammond/api" (gh-id 999)`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output should contain %q, got:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, `"New Issues"`) {
+		t.Errorf("New Issues is already in place and should not change, got:\n%s", out)
+	}
+	if len(*mutations) != 0 {
+		t.Errorf("dry run should not execute mutations, got %d", len(*mutations))
+	}
+}
+
+func TestWorkspaceApply(t *testing.T) {
+	_, mutations := setupWorkspaceConfigServer(t)
+	path := writeWorkspaceSpec(t, `pipelines:
+  - name: New Issues
+    description: Triage queue
+  - name: Review
+  - name: In Development
+    description: Active work
+repos:
+  - repo: dlakehammond/api
+    ghId: 999
+`)
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"workspace", "apply", path, "--into=new issues"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("workspace apply returned error: %v", err)
+	}
+
+	if !strings.Contains(buf.String(), "Made 4 change(s) to the workspace.") {
+		t.Errorf("output should confirm changes, got: %s", buf.String())
+	}
+
+	type input struct {
+		Input map[string]any `json:"input"`
+	}
+	var got []string
+	var inputs []map[string]any
+	for _, m := range *mutations {
+		name, _, _ := strings.Cut(strings.Fields(m.Query)[1], "(")
+		got = append(got, name)
+		var v input
+		_ = json.Unmarshal(m.Variables, &v)
+		inputs = append(inputs, v.Input)
+	}
+	// Done is deleted first, as its destination already exists.
+	want := []string{"DeletePipeline", "UpdatePipeline", "CreatePipeline", "AddRepositoryToWorkspace"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("mutations = %v, want %v", got, want)
+	}
+
+	if inputs[0]["pipelineId"] != "p3" || inputs[0]["destinationPipelineId"] != "p1" {
+		t.Errorf("unexpected delete input: %v", inputs[0])
+	}
+	if inputs[1]["pipelineId"] != "p1" || inputs[1]["description"] != "Triage queue" {
+		t.Errorf("unexpected update input: %v", inputs[1])
+	}
+	if inputs[2]["workspaceId"] != "ws-123" || inputs[2]["name"] != "Review" || inputs[2]["position"] != float64(1) {
+		t.Errorf("unexpected create input: %v", inputs[2])
+	}
+	if inputs[3]["repositoryGhId"] != float64(999) {
+		t.Errorf("unexpected add repo input: %v", inputs[3])
+	}
+}
+
+func TestWorkspaceApplyRequiresInto(t *testing.T) {
+	_, mutations := setupWorkspaceConfigServer(t)
+	path := writeWorkspaceSpec(t, "pipelines:\n  - name: New Issues\n  - name: Done\n")
+
+	rootCmd.SetOut(new(bytes.Buffer))
+	rootCmd.SetArgs([]string{"workspace", "apply", path})
+
+	err := rootCmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), `"In Development"`) || !strings.Contains(err.Error(), "--into") {
+		t.Fatalf("expected an error asking for --into, got: %v", err)
+	}
+	if exitcode.ExitCode(err) != exitcode.UsageError {
+		t.Errorf("exit code = %d, want %d", exitcode.ExitCode(err), exitcode.UsageError)
+	}
+	if len(*mutations) != 0 {
+		t.Errorf("expected no mutations, got %d", len(*mutations))
+	}
+}
+
+func TestWorkspaceApplyWarnings(t *testing.T) {
+	setupWorkspaceConfigServer(t)
+	path := writeWorkspaceSpec(t, `priorities:
+  - {name: Urgent, color: 000000, description: Needs immediate attention}
+  - {name: Blocker}
+labels:
+  - {name: bug, color: d73a4a}
+  - {name: security}
+repos:
+  - repo: dlakehammond/task-tracker
+    ghId: 12345
+`)
+
+	buf := new(bytes.Buffer)
+	errBuf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(errBuf)
+	defer rootCmd.SetErr(os.Stderr)
+	rootCmd.SetArgs([]string{"workspace", "apply", path})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("workspace apply returned error: %v", err)
+	}
+
+	warnings := errBuf.String()
+	for _, want := range []string{
+		`priority "Urgent" has color ff5630, not 000000`,
+		`priority "Blocker" is not in the workspace`,
+		`priority "Low" is not in the file`,
+		`label "security" is not in any repository`,
+	} {
+		if !strings.Contains(warnings, want) {
+			t.Errorf("warnings should contain %q, got:\n%s", want, warnings)
+		}
+	}
+	if strings.Contains(warnings, "bug") || strings.Contains(warnings, "task-tracker") {
+		t.Errorf("matching labels and repos should not warn, got:\n%s", warnings)
+	}
+	if !strings.Contains(buf.String(), "Workspace already matches the configuration.") {
+		t.Errorf("expected no changes, got: %s", buf.String())
+	}
+}
+
+func TestWorkspaceApplyInvalidFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"unknown field", "pipelines:\n  - name: Todo\n    colour: red\n", "colour"},
+		{"duplicate pipeline", "pipelines:\n  - name: Todo\n  - name: todo\n", `pipeline "todo" is listed more than once`},
+		{"unnamed pipeline", "pipelines:\n  - description: x\n", "every pipeline needs a name"},
+		{"bad repo", "repos:\n  - repo: task-tracker\n", "owner/repo format"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setupWorkspaceConfigServer(t)
+			path := writeWorkspaceSpec(t, tt.content)
+
+			rootCmd.SetOut(new(bytes.Buffer))
+			rootCmd.SetArgs([]string{"workspace", "apply", path})
+
+			err := rootCmd.Execute()
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("expected error containing %q, got: %v", tt.want, err)
+			}
+			if exitcode.ExitCode(err) != exitcode.UsageError {
+				t.Errorf("exit code = %d, want %d", exitcode.ExitCode(err), exitcode.UsageError)
+			}
+		})
+	}
+}
+
+// TestDiffWorkspacePipelinesOrder replays the planned changes against the
+// live order to check that they produce the wanted order.
+func TestDiffWorkspacePipelinesOrder(t *testing.T) {
+	live := func(names ...string) []pipelineListEntry {
+		entries := make([]pipelineListEntry, len(names))
+		for i, n := range names {
+			entries[i] = pipelineListEntry{ID: "id-" + n, Name: n}
+		}
+		return entries
+	}
+
+	tests := []struct {
+		name    string
+		live    []string
+		want    []string
+		changes int
+	}{
+		{"unchanged", []string{"A", "B", "C"}, []string{"A", "B", "C"}, 0},
+		{"reversed", []string{"A", "B", "C"}, []string{"C", "B", "A"}, 2},
+		{"insert and move", []string{"A", "B", "C"}, []string{"C", "X", "A", "B"}, 2},
+		{"delete", []string{"A", "X", "B", "Y"}, []string{"A", "B"}, 2},
+		{"mixed", []string{"A", "B", "C", "D"}, []string{"D", "E", "B"}, 4},
+		{"delete into new pipeline", []string{"A", "X", "B"}, []string{"N", "A", "B"}, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var want []workspaceSpecPipeline
+			for _, n := range tt.want {
+				want = append(want, workspaceSpecPipeline{Name: n})
+			}
+
+			changes, err := diffWorkspacePipelines(live(tt.live...), want, tt.want[0])
+			if err != nil {
+				t.Fatalf("diffWorkspacePipelines() error: %v", err)
+			}
+			if len(changes) != tt.changes {
+				t.Errorf("got %d changes, want %d: %+v", len(changes), tt.changes, changes)
+			}
+
+			order := slices.Clone(tt.live)
+			for _, c := range changes {
+				switch c.Action {
+				case "create":
+					order = slices.Insert(order, c.input["position"].(int), c.Name)
+				case "update":
+					i := slices.Index(order, c.Name)
+					order = slices.Delete(order, i, i+1)
+					order = slices.Insert(order, c.input["position"].(int), c.Name)
+				case "delete":
+					i := slices.Index(order, c.Name)
+					order = slices.Delete(order, i, i+1)
+				}
+			}
+			if !slices.Equal(order, tt.want) {
+				t.Errorf("order after changes = %v, want %v", order, tt.want)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -9,6 +10,7 @@ import (
 	"github.com/dslh/zh/internal/cache"
 	"github.com/dslh/zh/internal/config"
 	"github.com/dslh/zh/internal/exitcode"
+	"github.com/dslh/zh/internal/gh"
 	"github.com/dslh/zh/internal/output"
 	"github.com/dslh/zh/internal/resolve"
 	"github.com/spf13/cobra"
@@ -79,6 +81,8 @@ repository must currently be connected to the workspace. Alternatively, pass
 }
 
 func runWorkspaceRepoAdd(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+
 	cfg, err := requireWorkspace()
 	if err != nil {
 		return err
@@ -102,7 +106,7 @@ func runWorkspaceRepoAdd(cmd *cobra.Command, args []string) error {
 		"workspaceId":    cfg.Workspace,
 		"repositoryGhId": ghID,
 	}
-	if _, err := client.Execute(addRepositoryToWorkspaceMutation, map[string]any{"input": input}); err != nil {
+	if _, err := client.Execute(ctx, addRepositoryToWorkspaceMutation, map[string]any{"input": input}); err != nil {
 		return exitcode.General("adding repository to workspace", err)
 	}
 
@@ -113,6 +117,8 @@ func runWorkspaceRepoAdd(cmd *cobra.Command, args []string) error {
 }
 
 func runWorkspaceRepoRemove(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+
 	cfg, err := requireWorkspace()
 	if err != nil {
 		return err
@@ -120,7 +126,7 @@ func runWorkspaceRepoRemove(cmd *cobra.Command, args []string) error {
 	w := cmd.OutOrStdout()
 	client := newClient(cfg, cmd)
 
-	ghID, label, err := resolveRepoForRemove(client, cfg, args)
+	ghID, label, err := resolveRepoForRemove(ctx, client, cfg, args)
 	if err != nil {
 		return err
 	}
@@ -136,7 +142,7 @@ func runWorkspaceRepoRemove(cmd *cobra.Command, args []string) error {
 		"workspaceId":    cfg.Workspace,
 		"repositoryGhId": ghID,
 	}
-	if _, err := client.Execute(disconnectWorkspaceRepositoryMutation, map[string]any{"input": input}); err != nil {
+	if _, err := client.Execute(ctx, disconnectWorkspaceRepositoryMutation, map[string]any{"input": input}); err != nil {
 		return exitcode.General("removing repository from workspace", err)
 	}
 
@@ -150,6 +156,8 @@ func runWorkspaceRepoRemove(cmd *cobra.Command, args []string) error {
 // add command. If --gh-id is set, it's used directly; otherwise the positional
 // arg is parsed as owner/repo and looked up via GitHub's GraphQL API.
 func resolveRepoForAdd(cfg *config.Config, cmd *cobra.Command, args []string) (int, string, error) {
+	ctx := commandContext(cmd)
+
 	if workspaceRepoAddGhID != 0 {
 		label := fmt.Sprintf("repository (gh-id %d)", workspaceRepoAddGhID)
 		if len(args) > 0 {
@@ -170,8 +178,14 @@ func resolveRepoForAdd(cfg *config.Config, cmd *cobra.Command, args []string) (i
 	if ghClient == nil {
 		return 0, "", exitcode.Auth("GitHub authentication required to look up repository by name — configure ZH_GITHUB_TOKEN or run 'gh auth login', or pass --gh-id <id>", nil)
 	}
+	return lookupGitHubRepo(ctx, ghClient, owner, name)
+}
 
-	data, err := ghClient.Execute(githubRepoLookupQuery, map[string]any{
+// lookupGitHubRepo looks up a repository's GitHub database ID and canonical
+// owner/name via GitHub's GraphQL API.
+func lookupGitHubRepo(ctx context.Context, ghClient *gh.Client, owner, name string) (int, string, error) {
+	identifier := owner + "/" + name
+	data, err := ghClient.Execute(ctx, githubRepoLookupQuery, map[string]any{
 		"owner": owner,
 		"name":  name,
 	})
@@ -196,7 +210,7 @@ func resolveRepoForAdd(cfg *config.Config, cmd *cobra.Command, args []string) (i
 // resolveRepoForRemove resolves a repo identifier to a GitHub database ID for
 // the remove command. The repo must already be connected to the workspace, so
 // we use the cache-backed resolver.
-func resolveRepoForRemove(client *api.Client, cfg *config.Config, args []string) (int, string, error) {
+func resolveRepoForRemove(ctx context.Context, client *api.Client, cfg *config.Config, args []string) (int, string, error) {
 	if workspaceRepoRemoveGhID != 0 {
 		label := fmt.Sprintf("repository (gh-id %d)", workspaceRepoRemoveGhID)
 		if len(args) > 0 {
@@ -207,7 +221,7 @@ func resolveRepoForRemove(client *api.Client, cfg *config.Config, args []string)
 	if len(args) == 0 {
 		return 0, "", exitcode.Usage("must provide owner/repo or --gh-id")
 	}
-	repo, err := resolve.LookupRepoWithRefresh(client, cfg.Workspace, args[0])
+	repo, err := resolve.LookupRepoWithRefresh(ctx, client, cfg.Workspace, args[0])
 	if err != nil {
 		return 0, "", err
 	}
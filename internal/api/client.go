@@ -3,7 +3,9 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -11,6 +13,7 @@ import (
 	"time"
 
 	"github.com/dslh/zh/internal/exitcode"
+	"github.com/dslh/zh/internal/retry"
 )
 
 const (
@@ -24,10 +27,15 @@ type Client struct {
 	apiKey     string
 	restAPIKey string // separate token for REST v1 API (legacy epic operations)
 	httpClient *http.Client
+	retry      retry.Policy
 	verbose    bool
 	logFunc    func(format string, args ...any) // writes to stderr when verbose
+	offline    bool
 }
 
+// ErrOffline is returned for every request made by an offline client.
+var ErrOffline = errors.New("offline — API requests are disabled")
+
 // Option configures a Client.
 type Option func(*Client)
 
@@ -54,12 +62,25 @@ func WithHTTPClient(hc *http.Client) Option {
 	return func(c *Client) { c.httpClient = hc }
 }
 
-// New creates a new ZenHub API client.
+// WithRetryPolicy sets how failed requests are retried. Without it, requests
+// are attempted once.
+func WithRetryPolicy(p retry.Policy) Option {
+	return func(c *Client) { c.retry = p }
+}
+
+// WithOffline makes every request fail with ErrOffline without touching the
+// network.
+func WithOffline() Option {
+	return func(c *Client) { c.offline = true }
+}
+
+// New creates a new ZenHub API client. Requests have no timeout of their
+// own; they are bounded by the context passed to each call.
 func New(apiKey string, opts ...Option) *Client {
 	c := &Client{
 		endpoint:   DefaultEndpoint,
 		apiKey:     apiKey,
-		httpClient: &http.Client{Timeout: 30 * time.Second},
+		httpClient: &http.Client{},
 	}
 	for _, opt := range opts {
 		opt(c)
@@ -89,7 +110,12 @@ type graphQLError struct {
 }
 
 // Execute sends a GraphQL query and returns the raw JSON data field.
-func (c *Client) Execute(query string, variables map[string]any) (json.RawMessage, error) {
+// Cancelling ctx aborts the request and any retries.
+func (c *Client) Execute(ctx context.Context, query string, variables map[string]any) (json.RawMessage, error) {
+	if c.offline {
+		return nil, ErrOffline
+	}
+
 	reqBody := graphQLRequest{
 		Query:     query,
 		Variables: variables,
@@ -109,50 +135,42 @@ func (c *Client) Execute(query string, variables map[string]any) (json.RawMessag
 		}
 	}
 
-	req, err := http.NewRequest(http.MethodPost, c.endpoint, bytes.NewReader(bodyBytes))
-	if err != nil {
-		return nil, exitcode.General("creating request", err)
-	}
-
-	req.Header.Set("Authorization", "Bearer "+c.apiKey)
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("User-Agent", userAgent)
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, exitcode.General("API request failed", err)
-	}
-	defer resp.Body.Close()
+	idempotent := !retry.IsMutation(query)
+	var respBody []byte
+	err = retry.Do(ctx, c.retry, c.retryLog(), func() error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(bodyBytes))
+		if err != nil {
+			return exitcode.General("creating request", err)
+		}
 
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, exitcode.General("reading response", err)
-	}
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("User-Agent", userAgent)
 
-	if c.verbose {
-		c.log("← %d %s\n", resp.StatusCode, http.StatusText(resp.StatusCode))
-		c.log("← Body: %s\n", truncate(string(respBody), 2000))
-	}
+		var resp *http.Response
+		resp, respBody, err = c.send(ctx, req, idempotent)
+		if err != nil {
+			return err
+		}
 
-	// Handle rate limiting
-	if resp.StatusCode == http.StatusTooManyRequests {
-		retryAfter := resp.Header.Get("Retry-After")
-		if retryAfter != "" {
-			if secs, err := strconv.Atoi(retryAfter); err == nil {
-				return nil, exitcode.Generalf("rate limited — retry after %d seconds", secs)
-			}
+		// Handle rate limiting
+		if resp.StatusCode == http.StatusTooManyRequests {
+			return c.retryable(resp, true, rateLimitError(resp))
 		}
-		return nil, exitcode.Generalf("rate limited — try again later")
-	}
 
-	// Handle auth failures
-	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
-		return nil, exitcode.Auth("authentication failed — check your API key", nil)
-	}
+		// Handle auth failures
+		if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+			return exitcode.Auth("authentication failed — check your API key", nil)
+		}
 
-	// Handle other HTTP errors
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return nil, exitcode.Generalf("API returned HTTP %d: %s", resp.StatusCode, truncate(string(respBody), 200))
+		// Handle other HTTP errors
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return c.retryable(resp, idempotent, exitcode.Generalf("API returned HTTP %d: %s", resp.StatusCode, truncate(string(respBody), 200)))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	var gqlResp graphQLResponse
@@ -220,6 +238,82 @@ func IsGraphQLNotUnique(err error) bool {
 	return false
 }
 
+// send performs a single HTTP request and reads the response body. Transport
+// errors are marked retryable when they look transient and retrying is safe.
+func (c *Client) send(ctx context.Context, req *http.Request, idempotent bool) (*http.Response, []byte, error) {
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, nil, c.retryableTransport(ctx, idempotent, requestError(ctx, "API request failed", err), err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, c.retryableTransport(ctx, idempotent, requestError(ctx, "reading response", err), err)
+	}
+
+	if c.verbose {
+		c.log("← %d %s\n", resp.StatusCode, http.StatusText(resp.StatusCode))
+		c.log("← Body: %s\n", truncate(string(body), 2000))
+	}
+	return resp, body, nil
+}
+
+// retryable wraps err as a retry.Error if the response status is transient
+// and retrying is safe. Rate limiting is always safe to retry, since the
+// server rejected the request without processing it.
+func (c *Client) retryable(resp *http.Response, idempotent bool, err error) error {
+	if !retry.RetryableStatus(resp.StatusCode) {
+		return err
+	}
+	if resp.StatusCode != http.StatusTooManyRequests && !idempotent && !c.retry.Mutations {
+		return err
+	}
+	after, _ := retry.RetryAfter(resp.Header.Get("Retry-After"), time.Now())
+	return &retry.Error{Err: err, Reason: fmt.Sprintf("HTTP %d", resp.StatusCode), After: after}
+}
+
+// retryableTransport wraps err as a retry.Error if the underlying transport
+// error is transient and retrying is safe.
+func (c *Client) retryableTransport(ctx context.Context, idempotent bool, err, cause error) error {
+	if ctx.Err() != nil || !retry.TransientError(cause) || (!idempotent && !c.retry.Mutations) {
+		return err
+	}
+	return &retry.Error{Err: err, Reason: cause.Error()}
+}
+
+// retryLog returns the retry logger, or nil when not verbose.
+func (c *Client) retryLog() func(format string, args ...any) {
+	if !c.verbose {
+		return nil
+	}
+	return c.log
+}
+
+// rateLimitError describes a 429 response, including the server's
+// Retry-After hint when present.
+func rateLimitError(resp *http.Response) error {
+	retryAfter := resp.Header.Get("Retry-After")
+	if retryAfter != "" {
+		if secs, err := strconv.Atoi(retryAfter); err == nil {
+			return exitcode.Generalf("rate limited — retry after %d seconds", secs)
+		}
+	}
+	return exitcode.Generalf("rate limited — try again later")
+}
+
+// requestError wraps a transport error, reporting cancellation and timeouts
+// in plain terms rather than as a raw net/http error.
+func requestError(ctx context.Context, msg string, err error) error {
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return exitcode.General("request timed out", ctx.Err())
+	case errors.Is(ctx.Err(), context.Canceled):
+		return exitcode.General("request canceled", ctx.Err())
+	}
+	return exitcode.General(msg, err)
+}
+
 func (c *Client) log(format string, args ...any) {
 	if c.logFunc != nil {
 		c.logFunc(format, args...)
@@ -1,14 +1,19 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/dslh/zh/internal/exitcode"
+	"github.com/dslh/zh/internal/retry"
 )
 
 func TestExecuteSendsAuthHeader(t *testing.T) {
@@ -21,7 +26,7 @@ func TestExecuteSendsAuthHeader(t *testing.T) {
 	defer srv.Close()
 
 	client := New("my-api-key", WithEndpoint(srv.URL))
-	_, err := client.Execute("{ viewer { login } }", nil)
+	_, err := client.Execute(context.Background(), "{ viewer { login } }", nil)
 	if err != nil {
 		t.Fatalf("Execute() error: %v", err)
 	}
@@ -41,7 +46,7 @@ func TestExecuteSendsUserAgent(t *testing.T) {
 	defer srv.Close()
 
 	client := New("key", WithEndpoint(srv.URL))
-	client.Execute("{ test }", nil)
+	client.Execute(context.Background(), "{ test }", nil)
 
 	if gotUA != userAgent {
 		t.Errorf("User-Agent = %q, want %q", gotUA, userAgent)
@@ -56,7 +61,7 @@ func TestExecuteReturnsData(t *testing.T) {
 	defer srv.Close()
 
 	client := New("key", WithEndpoint(srv.URL))
-	data, err := client.Execute("{ viewer { login id } }", nil)
+	data, err := client.Execute(context.Background(), "{ viewer { login id } }", nil)
 	if err != nil {
 		t.Fatalf("Execute() error: %v", err)
 	}
@@ -86,7 +91,7 @@ func TestExecuteSendsVariables(t *testing.T) {
 	defer srv.Close()
 
 	client := New("key", WithEndpoint(srv.URL))
-	client.Execute("query($id: ID!) { node(id: $id) { id } }", map[string]any{"id": "abc"})
+	client.Execute(context.Background(), "query($id: ID!) { node(id: $id) { id } }", map[string]any{"id": "abc"})
 
 	if !strings.Contains(gotBody, `"id":"abc"`) {
 		t.Errorf("request body should contain variable, got: %s", gotBody)
@@ -101,7 +106,7 @@ func TestExecuteGraphQLErrors(t *testing.T) {
 	defer srv.Close()
 
 	client := New("key", WithEndpoint(srv.URL))
-	_, err := client.Execute("{ viewer { login } }", nil)
+	_, err := client.Execute(context.Background(), "{ viewer { login } }", nil)
 
 	if err == nil {
 		t.Fatal("expected error for GraphQL errors response")
@@ -125,7 +130,7 @@ func TestExecuteHTTPAuthFailure(t *testing.T) {
 	defer srv.Close()
 
 	client := New("bad-key", WithEndpoint(srv.URL))
-	_, err := client.Execute("{ viewer { login } }", nil)
+	_, err := client.Execute(context.Background(), "{ viewer { login } }", nil)
 
 	if err == nil {
 		t.Fatal("expected error for 401 response")
@@ -144,7 +149,7 @@ func TestExecuteRateLimited(t *testing.T) {
 	defer srv.Close()
 
 	client := New("key", WithEndpoint(srv.URL))
-	_, err := client.Execute("{ viewer { login } }", nil)
+	_, err := client.Execute(context.Background(), "{ viewer { login } }", nil)
 
 	if err == nil {
 		t.Fatal("expected error for 429 response")
@@ -167,7 +172,7 @@ func TestExecuteVerboseLogging(t *testing.T) {
 	}
 
 	client := New("key", WithEndpoint(srv.URL), WithVerbose(logFunc))
-	_, err := client.Execute("{ test }", nil)
+	_, err := client.Execute(context.Background(), "{ test }", nil)
 	if err != nil {
 		t.Fatalf("Execute() error: %v", err)
 	}
@@ -201,7 +206,7 @@ func TestExecuteHTTPError(t *testing.T) {
 	defer srv.Close()
 
 	client := New("key", WithEndpoint(srv.URL))
-	_, err := client.Execute("{ test }", nil)
+	_, err := client.Execute(context.Background(), "{ test }", nil)
 
 	if err == nil {
 		t.Fatal("expected error for 500 response")
@@ -210,3 +215,148 @@ func TestExecuteHTTPError(t *testing.T) {
 		t.Errorf("exit code = %d, want %d (GeneralError)", code, exitcode.GeneralError)
 	}
 }
+
+func TestExecuteContextCanceled(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	client := New("key", WithEndpoint(srv.URL))
+	time.AfterFunc(20*time.Millisecond, cancel)
+
+	_, err := client.Execute(ctx, "{ test }", nil)
+	if err == nil {
+		t.Fatal("expected error for canceled context")
+	}
+	if !strings.Contains(err.Error(), "request canceled") {
+		t.Errorf("error = %q, want cancellation message", err.Error())
+	}
+}
+
+func TestExecuteContextDeadline(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	client := New("key", WithEndpoint(srv.URL))
+	_, err := client.Execute(ctx, "{ test }", nil)
+	if err == nil {
+		t.Fatal("expected error for expired deadline")
+	}
+	if !strings.Contains(err.Error(), "request timed out") {
+		t.Errorf("error = %q, want timeout message", err.Error())
+	}
+	if code := exitcode.ExitCode(err); code != exitcode.GeneralError {
+		t.Errorf("exit code = %d, want %d (GeneralError)", code, exitcode.GeneralError)
+	}
+}
+
+func TestExecuteRetriesServerErrors(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			http.Error(w, "bad gateway", http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte(`{"data":{"ok":true}}`))
+	}))
+	defer srv.Close()
+
+	var logged strings.Builder
+	client := New("key", WithEndpoint(srv.URL), WithRetryPolicy(testRetryPolicy()),
+		WithVerbose(func(format string, args ...any) {
+			logged.WriteString(fmt.Sprintf(format, args...))
+		}))
+	if _, err := client.Execute(context.Background(), "query Test { ok }", nil); err != nil {
+		t.Fatalf("Execute() error: %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("calls = %d, want 3", calls)
+	}
+	if !strings.Contains(logged.String(), "HTTP 502 — retrying") {
+		t.Errorf("verbose output should log retries, got: %s", logged.String())
+	}
+}
+
+func TestExecuteDoesNotRetryMutationsByDefault(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	client := New("key", WithEndpoint(srv.URL), WithRetryPolicy(testRetryPolicy()))
+	if _, err := client.Execute(context.Background(), "mutation Move { ok }", nil); err == nil {
+		t.Fatal("expected error for 500 response")
+	}
+	if calls != 1 {
+		t.Errorf("calls = %d, want 1 (mutations are not retried by default)", calls)
+	}
+
+	calls = 0
+	policy := testRetryPolicy()
+	policy.Mutations = true
+	client = New("key", WithEndpoint(srv.URL), WithRetryPolicy(policy))
+	client.Execute(context.Background(), "mutation Move { ok }", nil)
+	if calls != policy.MaxAttempts {
+		t.Errorf("calls = %d, want %d with mutation retries enabled", calls, policy.MaxAttempts)
+	}
+}
+
+func TestExecuteRetriesRateLimitedMutations(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"data":{"ok":true}}`))
+	}))
+	defer srv.Close()
+
+	client := New("key", WithEndpoint(srv.URL), WithRetryPolicy(testRetryPolicy()))
+	if _, err := client.Execute(context.Background(), "mutation Move { ok }", nil); err != nil {
+		t.Fatalf("Execute() error: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("calls = %d, want 2", calls)
+	}
+}
+
+func testRetryPolicy() retry.Policy {
+	return retry.Policy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}
+}
+
+func TestOfflineClientMakesNoRequests(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{"data":{}}`))
+	}))
+	defer srv.Close()
+
+	client := New("key", WithEndpoint(srv.URL), WithRESTAPIKey("rest-key"), WithOffline())
+	if _, err := client.Execute(context.Background(), "{ viewer { login } }", nil); !errors.Is(err, ErrOffline) {
+		t.Errorf("Execute() error = %v, want ErrOffline", err)
+	}
+	if _, err := client.REST(context.Background(), http.MethodGet, "/p1/repositories/1/epics", nil); !errors.Is(err, ErrOffline) {
+		t.Errorf("REST() error = %v, want ErrOffline", err)
+	}
+	if requests != 0 {
+		t.Errorf("offline client made %d request(s)", requests)
+	}
+}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"os"
 	"testing"
@@ -22,7 +23,7 @@ func TestIntegrationViewerQuery(t *testing.T) {
 	}
 
 	client := New(cfg.APIKey)
-	data, err := client.Execute("{ viewer { id } }", nil)
+	data, err := client.Execute(context.Background(), "{ viewer { id } }", nil)
 	if err != nil {
 		t.Fatalf("Execute() error: %v", err)
 	}
@@ -2,6 +2,7 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -9,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/dslh/zh/internal/exitcode"
+	"github.com/dslh/zh/internal/retry"
 )
 
 const DefaultRESTEndpoint = "https://api.zenhub.com"
@@ -36,13 +38,9 @@ type RESTIssueRef struct {
 // UpdateEpicIssues adds and/or removes issues from a legacy epic via the
 // ZenHub REST API v1. The epic is identified by the GitHub repository ID and
 // issue number of its backing GitHub issue.
-func (c *Client) UpdateEpicIssues(epicRepoID, epicIssueNumber int, addIssues, removeIssues []RESTIssueRef) error {
+func (c *Client) UpdateEpicIssues(ctx context.Context, epicRepoID, epicIssueNumber int, addIssues, removeIssues []RESTIssueRef) error {
 	if c.restAPIKey == "" {
-		return exitcode.Generalf("legacy epic add/remove requires a ZenHub REST API token\n\n" +
-			"The ZenHub REST v1 API uses a different token than the GraphQL API.\n" +
-			"Generate one at https://app.zenhub.com/dashboard/tokens and set it via:\n" +
-			"  config: rest_api_key in config.yml\n" +
-			"  env:    ZH_REST_API_KEY")
+		return restAPIKeyError("legacy epic add/remove")
 	}
 
 	body := map[string]any{}
@@ -53,51 +51,98 @@ func (c *Client) UpdateEpicIssues(epicRepoID, epicIssueNumber int, addIssues, re
 		body["remove_issues"] = removeIssues
 	}
 
-	url := fmt.Sprintf("%s/p1/repositories/%d/epics/%d/update_issues",
-		c.RESTEndpoint(), epicRepoID, epicIssueNumber)
-
 	bodyBytes, err := json.Marshal(body)
 	if err != nil {
 		return exitcode.General("marshaling request", err)
 	}
 
-	if c.verbose {
-		c.log("→ POST %s\n", url)
-		c.log("→ Body: %s\n", string(bodyBytes))
-	}
+	// Adding or removing the same issues twice leaves the epic unchanged, so
+	// this request is safe to retry like a query.
+	path := fmt.Sprintf("/p1/repositories/%d/epics/%d/update_issues", epicRepoID, epicIssueNumber)
+	_, err = c.rest(ctx, http.MethodPost, path, bodyBytes, true)
+	return err
+}
 
-	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(bodyBytes))
-	if err != nil {
-		return exitcode.General("creating request", err)
+// REST sends a request to the ZenHub REST v1 API and returns the raw
+// response body. The path is relative to RESTEndpoint (e.g.
+// "/p1/repositories/123/epics"). A nil body sends no request body.
+// Only idempotent methods (GET, HEAD, PUT, DELETE) are retried after server
+// or connection errors, unless the retry policy opts in to mutations.
+func (c *Client) REST(ctx context.Context, method, path string, body []byte) ([]byte, error) {
+	if c.restAPIKey == "" {
+		return nil, restAPIKeyError("the ZenHub REST API")
 	}
+	idempotent := method == http.MethodGet || method == http.MethodHead ||
+		method == http.MethodPut || method == http.MethodDelete
+	return c.rest(ctx, method, path, body, idempotent)
+}
 
-	req.Header.Set("X-Authentication-Token", c.restAPIKey)
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("User-Agent", userAgent)
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return exitcode.General("API request failed", err)
+func (c *Client) rest(ctx context.Context, method, path string, body []byte, idempotent bool) ([]byte, error) {
+	if c.offline {
+		return nil, ErrOffline
 	}
-	defer resp.Body.Close()
 
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return exitcode.General("reading response", err)
-	}
+	url := c.RESTEndpoint() + path
 
 	if c.verbose {
-		c.log("← %d %s\n", resp.StatusCode, http.StatusText(resp.StatusCode))
-		c.log("← Body: %s\n", truncate(string(respBody), 2000))
+		c.log("→ %s %s\n", method, url)
+		if body != nil {
+			c.log("→ Body: %s\n", string(body))
+		}
 	}
 
-	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
-		return exitcode.Auth("REST API authentication failed — check your rest_api_key", nil)
-	}
-
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return exitcode.Generalf("API returned HTTP %d: %s", resp.StatusCode, truncate(string(respBody), 200))
+	var respBody []byte
+	err := retry.Do(ctx, c.retry, c.retryLog(), func() error {
+		var reqBody io.Reader
+		if body != nil {
+			reqBody = bytes.NewReader(body)
+		}
+		req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
+		if err != nil {
+			return exitcode.General("creating request", err)
+		}
+
+		req.Header.Set("X-Authentication-Token", c.restAPIKey)
+		if body != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		req.Header.Set("User-Agent", userAgent)
+
+		var resp *http.Response
+		resp, respBody, err = c.send(ctx, req, idempotent)
+		if err != nil {
+			return err
+		}
+
+		if resp.StatusCode == http.StatusTooManyRequests {
+			return c.retryable(resp, true, rateLimitError(resp))
+		}
+
+		if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+			return exitcode.Auth("REST API authentication failed — check your rest_api_key", nil)
+		}
+
+		if resp.StatusCode == http.StatusNotFound {
+			return exitcode.NotFoundError(fmt.Sprintf("API returned HTTP 404 for %s %s", method, path))
+		}
+
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return c.retryable(resp, idempotent, exitcode.Generalf("API returned HTTP %d: %s", resp.StatusCode, truncate(string(respBody), 200)))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
+	return respBody, nil
+}
 
-	return nil
+// restAPIKeyError explains how to configure the separate REST v1 token.
+func restAPIKeyError(what string) error {
+	return exitcode.Generalf("%s requires a ZenHub REST API token\n\n"+
+		"The ZenHub REST v1 API uses a different token than the GraphQL API.\n"+
+		"Generate one at https://app.zenhub.com/dashboard/tokens and set it via:\n"+
+		"  config: rest_api_key in config.yml\n"+
+		"  env:    ZH_REST_API_KEY", what)
 }
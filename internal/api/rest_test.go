@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"io"
 	"net/http"
@@ -34,7 +35,7 @@ func TestUpdateEpicIssues(t *testing.T) {
 		{RepoID: 456, IssueNumber: 99},
 	}
 
-	err := client.UpdateEpicIssues(789, 10, addIssues, removeIssues)
+	err := client.UpdateEpicIssues(context.Background(), 789, 10, addIssues, removeIssues)
 	if err != nil {
 		t.Fatalf("UpdateEpicIssues returned error: %v", err)
 	}
@@ -72,6 +73,29 @@ func TestUpdateEpicIssues(t *testing.T) {
 	}
 }
 
+func TestUpdateEpicIssuesRetriesServerErrors(t *testing.T) {
+	calls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(200)
+	}))
+	defer ts.Close()
+
+	client := New("test-api-key", WithEndpoint(ts.URL), WithRESTAPIKey("test-rest-key"), WithRetryPolicy(testRetryPolicy()))
+
+	err := client.UpdateEpicIssues(context.Background(), 789, 10, []RESTIssueRef{{RepoID: 123, IssueNumber: 42}}, nil)
+	if err != nil {
+		t.Fatalf("UpdateEpicIssues returned error: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("calls = %d, want 2", calls)
+	}
+}
+
 func TestUpdateEpicIssuesAuthFailure(t *testing.T) {
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(401)
@@ -81,7 +105,7 @@ func TestUpdateEpicIssuesAuthFailure(t *testing.T) {
 
 	client := New("bad-key", WithEndpoint(ts.URL), WithRESTAPIKey("bad-rest-key"))
 
-	err := client.UpdateEpicIssues(789, 10, []RESTIssueRef{{RepoID: 123, IssueNumber: 42}}, nil)
+	err := client.UpdateEpicIssues(context.Background(), 789, 10, []RESTIssueRef{{RepoID: 123, IssueNumber: 42}}, nil)
 	if err == nil {
 		t.Fatal("expected error for 401 response")
 	}
@@ -93,7 +117,7 @@ func TestUpdateEpicIssuesAuthFailure(t *testing.T) {
 func TestUpdateEpicIssuesNoRESTKey(t *testing.T) {
 	client := New("graphql-key", WithEndpoint("http://localhost:1234"))
 
-	err := client.UpdateEpicIssues(789, 10, []RESTIssueRef{{RepoID: 123, IssueNumber: 42}}, nil)
+	err := client.UpdateEpicIssues(context.Background(), 789, 10, []RESTIssueRef{{RepoID: 123, IssueNumber: 42}}, nil)
 	if err == nil {
 		t.Fatal("expected error when no REST API key is configured")
 	}
@@ -0,0 +1,206 @@
+// Package backup reads and writes the workspace archives made by
+// `zh workspace backup` and read by `zh workspace restore`.
+//
+// An archive is a gzipped tar of JSON files: manifest.json, pipelines.json,
+// issues.json, epics.json and sprints.json. Each file is an object with a
+// "version" field alongside its data, so that the format can change without
+// older archives being misread.
+package backup
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Version is the archive format version written by this version of zh.
+const Version = 1
+
+// Archive is the full contents of a backup.
+type Archive struct {
+	Manifest  Manifest
+	Pipelines []Pipeline
+	Issues    []Issue
+	Epics     []Epic
+	Sprints   []Sprint
+}
+
+// Manifest describes when and where a backup was made.
+type Manifest struct {
+	WorkspaceID   string    `json:"workspaceId"`
+	WorkspaceName string    `json:"workspaceName,omitempty"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+// Pipeline is a pipeline, in board order.
+type Pipeline struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// Entity refers to a pipeline, priority, label, epic or sprint by ID and name.
+type Entity struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// IssueRef identifies an issue or pull request.
+type IssueRef struct {
+	ID        string `json:"id"`
+	Number    int    `json:"number"`
+	RepoName  string `json:"repoName"`
+	RepoOwner string `json:"repoOwner"`
+}
+
+// Ref returns the short issue reference, e.g. "api#12".
+func (r IssueRef) Ref() string {
+	return fmt.Sprintf("%s#%d", r.RepoName, r.Number)
+}
+
+// Issue is an issue or pull request with its workspace metadata.
+type Issue struct {
+	IssueRef
+	RepoGhID        int        `json:"repoGhId"`
+	PipelineIssueID string     `json:"pipelineIssueId,omitempty"`
+	Title           string     `json:"title"`
+	State           string     `json:"state"`
+	PullRequest     bool       `json:"pullRequest,omitempty"`
+	Pipeline        *Entity    `json:"pipeline,omitempty"`
+	Position        *int       `json:"position,omitempty"` // relativePosition within the pipeline
+	Estimate        *float64   `json:"estimate,omitempty"`
+	Priority        *Entity    `json:"priority,omitempty"`
+	Labels          []Entity   `json:"labels,omitempty"`
+	Assignees       []string   `json:"assignees,omitempty"`
+	Epics           []Entity   `json:"epics,omitempty"` // ZenHub epics
+	Sprints         []Entity   `json:"sprints,omitempty"`
+	BlockedBy       []Entity   `json:"blockedBy,omitempty"` // issues (by ref) and epics (by title)
+	ConnectedPRs    []IssueRef `json:"connectedPrs,omitempty"`
+}
+
+// Epic is a ZenHub epic or a legacy (issue-backed) epic.
+type Epic struct {
+	ID       string    `json:"id"`
+	Type     string    `json:"type"` // "zenhub" or "legacy"
+	Title    string    `json:"title"`
+	Body     string    `json:"body,omitempty"`
+	State    string    `json:"state,omitempty"`
+	StartOn  *string   `json:"startOn,omitempty"`
+	EndOn    *string   `json:"endOn,omitempty"`
+	KeyDates []KeyDate `json:"keyDates,omitempty"`
+	Issue    *IssueRef `json:"issue,omitempty"` // legacy epics only
+}
+
+// KeyDate is a milestone on a ZenHub epic.
+type KeyDate struct {
+	Date        string `json:"date"`
+	Description string `json:"description"`
+	Color       string `json:"color,omitempty"`
+}
+
+// Sprint is a sprint in the workspace.
+type Sprint struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	State   string `json:"state"`
+	StartAt string `json:"startAt"`
+	EndAt   string `json:"endAt"`
+}
+
+// The files in an archive, and the key each file's data is stored under.
+var files = []struct {
+	name, key string
+	data      func(a *Archive) any
+}{
+	{"manifest.json", "manifest", func(a *Archive) any { return &a.Manifest }},
+	{"pipelines.json", "pipelines", func(a *Archive) any { return &a.Pipelines }},
+	{"issues.json", "issues", func(a *Archive) any { return &a.Issues }},
+	{"epics.json", "epics", func(a *Archive) any { return &a.Epics }},
+	{"sprints.json", "sprints", func(a *Archive) any { return &a.Sprints }},
+}
+
+// Write writes the archive to w as a gzipped tar.
+func Write(w io.Writer, a *Archive) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	for _, f := range files {
+		data, err := json.MarshalIndent(map[string]any{
+			"version": Version,
+			f.key:     f.data(a),
+		}, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshaling %s: %w", f.name, err)
+		}
+
+		hdr := &tar.Header{
+			Name:    f.name,
+			Mode:    0o600,
+			Size:    int64(len(data)),
+			ModTime: a.Manifest.CreatedAt,
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return fmt.Errorf("writing %s: %w", f.name, err)
+		}
+		if _, err := tw.Write(data); err != nil {
+			return fmt.Errorf("writing %s: %w", f.name, err)
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("writing archive: %w", err)
+	}
+	return gz.Close()
+}
+
+// Read reads an archive written by Write. Archives from a different format
+// version are rejected.
+func Read(r io.Reader) (*Archive, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("not a zh backup archive: %w", err)
+	}
+	tr := tar.NewReader(gz)
+
+	contents := map[string][]byte{}
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading archive: %w", err)
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", hdr.Name, err)
+		}
+		contents[hdr.Name] = data
+	}
+
+	var a Archive
+	for _, f := range files {
+		data, ok := contents[f.name]
+		if !ok {
+			return nil, fmt.Errorf("archive is missing %s", f.name)
+		}
+
+		var raw map[string]json.RawMessage
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", f.name, err)
+		}
+		var version int
+		if err := json.Unmarshal(raw["version"], &version); err != nil || version != Version {
+			return nil, fmt.Errorf("%s has format version %s — this version of zh reads version %d", f.name, raw["version"], Version)
+		}
+		if body, ok := raw[f.key]; ok {
+			if err := json.Unmarshal(body, f.data(&a)); err != nil {
+				return nil, fmt.Errorf("parsing %s: %w", f.name, err)
+			}
+		}
+	}
+	return &a, nil
+}
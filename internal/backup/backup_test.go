@@ -0,0 +1,128 @@
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testArchive() *Archive {
+	position := 4096
+	estimate := 3.0
+	start := "2026-10-01"
+	return &Archive{
+		Manifest:  Manifest{WorkspaceID: "ws1", WorkspaceName: "Dev", CreatedAt: time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)},
+		Pipelines: []Pipeline{{ID: "p1", Name: "Todo", Description: "Ready to start"}, {ID: "p2", Name: "Done"}},
+		Issues: []Issue{{
+			IssueRef:  IssueRef{ID: "i1", Number: 1, RepoName: "api", RepoOwner: "acme"},
+			RepoGhID:  100,
+			Title:     "Fix login",
+			State:     "OPEN",
+			Pipeline:  &Entity{ID: "p1", Name: "Todo"},
+			Position:  &position,
+			Estimate:  &estimate,
+			Labels:    []Entity{{ID: "l1", Name: "bug"}},
+			Epics:     []Entity{{ID: "e1", Name: "Auth"}},
+			Sprints:   []Entity{{ID: "s1", Name: "Sprint 1"}},
+			BlockedBy: []Entity{{ID: "i2", Name: "api#2"}},
+		}},
+		Epics: []Epic{{
+			ID: "e1", Type: "zenhub", Title: "Auth", StartOn: &start,
+			KeyDates: []KeyDate{{Date: "2026-10-15", Description: "Beta"}},
+		}},
+		Sprints: []Sprint{{ID: "s1", Name: "Sprint 1", State: "OPEN", StartAt: "2026-10-01T00:00:00Z", EndAt: "2026-10-15T00:00:00Z"}},
+	}
+}
+
+func TestWriteRead(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, testArchive()); err != nil {
+		t.Fatalf("Write() error: %v", err)
+	}
+
+	a, err := Read(&buf)
+	if err != nil {
+		t.Fatalf("Read() error: %v", err)
+	}
+
+	if a.Manifest.WorkspaceID != "ws1" || !a.Manifest.CreatedAt.Equal(testArchive().Manifest.CreatedAt) {
+		t.Errorf("manifest did not round-trip: %+v", a.Manifest)
+	}
+	if len(a.Pipelines) != 2 || a.Pipelines[0].Description != "Ready to start" {
+		t.Errorf("pipelines did not round-trip: %+v", a.Pipelines)
+	}
+	issue := a.Issues[0]
+	if issue.Ref() != "api#1" || *issue.Position != 4096 || *issue.Estimate != 3 || issue.Epics[0].ID != "e1" || issue.BlockedBy[0].Name != "api#2" {
+		t.Errorf("issue did not round-trip: %+v", issue)
+	}
+	if *a.Epics[0].StartOn != "2026-10-01" || a.Epics[0].KeyDates[0].Description != "Beta" {
+		t.Errorf("epic did not round-trip: %+v", a.Epics[0])
+	}
+	if a.Sprints[0].Name != "Sprint 1" {
+		t.Errorf("sprint did not round-trip: %+v", a.Sprints[0])
+	}
+}
+
+func writeTar(t *testing.T, files map[string]string) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, content := range files {
+		_ = tw.WriteHeader(&tar.Header{Name: name, Mode: 0o600, Size: int64(len(content))})
+		_, _ = tw.Write([]byte(content))
+	}
+	_ = tw.Close()
+	_ = gz.Close()
+	return &buf
+}
+
+func TestReadInvalid(t *testing.T) {
+	valid := map[string]string{
+		"manifest.json":  `{"version": 1, "manifest": {"workspaceId": "ws1"}}`,
+		"pipelines.json": `{"version": 1, "pipelines": []}`,
+		"issues.json":    `{"version": 1, "issues": []}`,
+		"epics.json":     `{"version": 1, "epics": []}`,
+		"sprints.json":   `{"version": 1, "sprints": []}`,
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		if _, err := Read(writeTar(t, valid)); err != nil {
+			t.Errorf("Read() error: %v", err)
+		}
+	})
+
+	t.Run("not gzip", func(t *testing.T) {
+		_, err := Read(strings.NewReader("plain text"))
+		if err == nil || !strings.Contains(err.Error(), "not a zh backup archive") {
+			t.Errorf("expected not an archive error, got %v", err)
+		}
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		files := map[string]string{}
+		for k, v := range valid {
+			files[k] = v
+		}
+		delete(files, "epics.json")
+		_, err := Read(writeTar(t, files))
+		if err == nil || !strings.Contains(err.Error(), "missing epics.json") {
+			t.Errorf("expected missing file error, got %v", err)
+		}
+	})
+
+	t.Run("newer version", func(t *testing.T) {
+		files := map[string]string{}
+		for k, v := range valid {
+			files[k] = v
+		}
+		files["issues.json"] = `{"version": 2, "issues": []}`
+		_, err := Read(writeTar(t, files))
+		if err == nil || !strings.Contains(err.Error(), "issues.json has format version 2") {
+			t.Errorf("expected version error, got %v", err)
+		}
+	})
+}
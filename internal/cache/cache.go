@@ -4,18 +4,68 @@
 // Each resource type is stored in a separate JSON file, optionally scoped
 // by workspace ID (e.g. "pipelines-{workspace_id}.json").
 //
-// Invalidation follows the invalidate-on-miss pattern: when a lookup fails,
-// the caller refreshes the cache from the API and retries.
+// Each file holds an entry: the cached data, wrapped with the time it was
+// fetched and the schema version of the entry format. Entries older than
+// their resource's TTL, or written with a different schema version, are
+// treated as missing.
+//
+// Invalidation otherwise follows the invalidate-on-miss pattern: when a
+// lookup fails, the caller refreshes the cache from the API and retries.
 package cache
 
 import (
 	"encoding/json"
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 )
 
+// SchemaVersion is the version of the entry format written by this version
+// of zh. Entries with another version are ignored and refetched.
+const SchemaVersion = 1
+
+// entry is the on-disk form of a cached value.
+type entry struct {
+	Version     int             `json:"version"`
+	Resource    string          `json:"resource"`
+	WorkspaceID string          `json:"workspaceId,omitempty"`
+	FetchedAt   time.Time       `json:"fetchedAt"`
+	Data        json.RawMessage `json:"data"`
+}
+
+// DefaultTTLs are how long each resource is cached before it is refetched.
+// Sprints and epics change most often. Resources without a TTL, such as the
+// issue index, never expire.
+var DefaultTTLs = map[string]time.Duration{
+	"workspaces":       24 * time.Hour,
+	"pipelines":        24 * time.Hour,
+	"repos":            24 * time.Hour,
+	"labels":           24 * time.Hour,
+	"zenhub-labels":    24 * time.Hour,
+	"users":            24 * time.Hour,
+	"priorities":       24 * time.Hour,
+	"epics":            time.Hour,
+	"sprints":          time.Hour,
+	"sprint-accessors": time.Hour,
+}
+
+var ttls = maps.Clone(DefaultTTLs)
+
+// SetTTLs replaces the default TTLs of the given resources, as configured in
+// config.yml. A TTL of zero means the resource never expires.
+func SetTTLs(overrides map[string]time.Duration) {
+	ttls = maps.Clone(DefaultTTLs)
+	maps.Copy(ttls, overrides)
+}
+
+// TTL returns how long a resource is cached, or zero if it never expires.
+func TTL(resource string) time.Duration {
+	return ttls[resource]
+}
+
 // Dir returns the XDG-compliant cache directory for zh.
 func Dir() string {
 	if xdg := os.Getenv("XDG_CACHE_HOME"); xdg != "" {
@@ -57,31 +107,80 @@ func (k Key) path() string {
 }
 
 // Get reads a cached value from disk. Returns the value and true if found,
-// or the zero value and false if the cache file doesn't exist.
+// or the zero value and false if the cache file doesn't exist, was written
+// by another schema version, or is older than the resource's TTL.
 func Get[T any](key Key) (T, bool) {
 	var zero T
 
-	data, err := os.ReadFile(key.path())
-	if err != nil {
+	e, ok := readEntry(key.path())
+	if !ok {
+		return zero, false
+	}
+	if ttl := TTL(key.Resource); ttl > 0 && time.Since(e.FetchedAt) > ttl {
 		return zero, false
 	}
 
 	var value T
-	if err := json.Unmarshal(data, &value); err != nil {
+	if err := json.Unmarshal(e.Data, &value); err != nil {
 		return zero, false
 	}
 
 	return value, true
 }
 
-// Set writes a value to the cache as JSON.
+// GetStale reads a cached value regardless of its age. It is used where
+// stale data is better than none, such as shell completion.
+func GetStale[T any](key Key) (T, bool) {
+	var zero T
+
+	e, ok := readEntry(key.path())
+	if !ok {
+		return zero, false
+	}
+
+	var value T
+	if err := json.Unmarshal(e.Data, &value); err != nil {
+		return zero, false
+	}
+
+	return value, true
+}
+
+// readEntry reads the entry in a cache file. Returns false if the file is
+// missing, unreadable or from another schema version.
+func readEntry(path string) (*entry, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+
+	var e entry
+	if err := json.Unmarshal(data, &e); err != nil || e.Version != SchemaVersion {
+		return nil, false
+	}
+	return &e, true
+}
+
+// Set writes a value to the cache as JSON, recording the current time as its
+// fetch time.
 func Set[T any](key Key, value T) error {
 	dir := Dir()
 	if err := os.MkdirAll(dir, 0o700); err != nil {
 		return fmt.Errorf("creating cache directory: %w", err)
 	}
 
-	data, err := json.MarshalIndent(value, "", "  ")
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("marshaling cache data: %w", err)
+	}
+
+	data, err := json.MarshalIndent(entry{
+		Version:     SchemaVersion,
+		Resource:    key.Resource,
+		WorkspaceID: key.WorkspaceID,
+		FetchedAt:   time.Now().UTC(),
+		Data:        raw,
+	}, "", "  ")
 	if err != nil {
 		return fmt.Errorf("marshaling cache data: %w", err)
 	}
@@ -89,6 +188,77 @@ func Set[T any](key Key, value T) error {
 	return os.WriteFile(key.path(), data, 0o600)
 }
 
+// Info describes a cache file.
+type Info struct {
+	Key       Key
+	FetchedAt time.Time // zero if the file is from another schema version
+	Size      int64     // bytes on disk
+	TTL       time.Duration
+}
+
+// Stale reports whether the entry is past its TTL or unreadable.
+func (i Info) Stale() bool {
+	if i.FetchedAt.IsZero() {
+		return true
+	}
+	return i.TTL > 0 && time.Since(i.FetchedAt) > i.TTL
+}
+
+// Stat describes the cache file for a key. Returns false if it doesn't exist.
+func Stat(key Key) (Info, bool) {
+	fi, err := os.Stat(key.path())
+	if err != nil {
+		return Info{}, false
+	}
+
+	info := Info{Key: key, Size: fi.Size(), TTL: TTL(key.Resource)}
+	if e, ok := readEntry(key.path()); ok {
+		info.FetchedAt = e.FetchedAt
+	}
+	return info, true
+}
+
+// List describes every cache file that belongs to the given workspace, or
+// is not scoped to one. Files from another schema version are included with
+// a zero FetchedAt, if their key can be read from them.
+func List(workspaceID string) ([]Info, error) {
+	dir := Dir()
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading cache directory: %w", err)
+	}
+
+	var infos []Info
+	for _, de := range entries {
+		if de.IsDir() || !strings.HasSuffix(de.Name(), ".json") {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(dir, de.Name()))
+		if err != nil {
+			continue
+		}
+		var header struct {
+			Resource    string `json:"resource"`
+			WorkspaceID string `json:"workspaceId"`
+		}
+		if json.Unmarshal(data, &header) != nil || header.Resource == "" {
+			continue
+		}
+		if header.WorkspaceID != "" && header.WorkspaceID != workspaceID {
+			continue
+		}
+
+		if info, ok := Stat(Key{Resource: header.Resource, WorkspaceID: header.WorkspaceID}); ok {
+			infos = append(infos, info)
+		}
+	}
+	return infos, nil
+}
+
 // Clear removes the cache file for the given key.
 // Returns nil if the file doesn't exist.
 func Clear(key Key) error {
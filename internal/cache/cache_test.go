@@ -1,10 +1,12 @@
 package cache
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 )
 
 func TestDir(t *testing.T) {
@@ -387,3 +389,93 @@ func TestGetOrRefreshNotFoundAfterRefresh(t *testing.T) {
 		t.Errorf("expected zero value when not found after refresh, got %+v", result)
 	}
 }
+
+// writeEntry writes a cache entry fetched at the given time.
+func writeEntry(t *testing.T, key Key, version int, fetchedAt time.Time, value any) {
+	t.Helper()
+	raw, _ := json.Marshal(value)
+	data, _ := json.Marshal(entry{Version: version, Resource: key.Resource, WorkspaceID: key.WorkspaceID, FetchedAt: fetchedAt, Data: raw})
+	if err := os.MkdirAll(Dir(), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(key.path(), data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGetExpiresAfterTTL(t *testing.T) {
+	setupCacheDir(t)
+	t.Cleanup(func() { SetTTLs(nil) })
+	key := NewScopedKey("sprints", "ws123")
+	writeEntry(t, key, SchemaVersion, time.Now().Add(-2*time.Hour), []testItem{{ID: "1"}})
+
+	if _, ok := Get[[]testItem](key); ok {
+		t.Error("Get() returned true for an entry older than its TTL")
+	}
+	if items, ok := GetStale[[]testItem](key); !ok || len(items) != 1 {
+		t.Errorf("GetStale() = %v, %v; want the stale entry", items, ok)
+	}
+
+	SetTTLs(map[string]time.Duration{"sprints": 3 * time.Hour})
+	if _, ok := Get[[]testItem](key); !ok {
+		t.Error("Get() returned false within a configured TTL")
+	}
+
+	SetTTLs(map[string]time.Duration{"sprints": 0})
+	if _, ok := Get[[]testItem](key); !ok {
+		t.Error("Get() returned false for a resource that never expires")
+	}
+}
+
+func TestGetIgnoresOtherSchemaVersions(t *testing.T) {
+	setupCacheDir(t)
+	key := NewScopedKey("pipelines", "ws123")
+
+	// Entries from another schema version, and bare values written before
+	// entries were versioned, are misses.
+	writeEntry(t, key, SchemaVersion+1, time.Now(), []testItem{{ID: "1"}})
+	if _, ok := GetStale[[]testItem](key); ok {
+		t.Error("GetStale() returned true for another schema version")
+	}
+
+	if err := os.WriteFile(key.path(), []byte(`[{"id":"1","name":"legacy"}]`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := GetStale[[]testItem](key); ok {
+		t.Error("GetStale() returned true for a legacy cache file")
+	}
+}
+
+func TestList(t *testing.T) {
+	setupCacheDir(t)
+
+	if err := Set(NewKey("workspaces"), []testItem{{ID: "1"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := Set(NewScopedKey("pipelines", "ws1"), []testItem{{ID: "1"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := Set(NewScopedKey("pipelines", "ws2"), []testItem{{ID: "1"}}); err != nil {
+		t.Fatal(err)
+	}
+	writeEntry(t, NewScopedKey("epics", "ws1"), SchemaVersion, time.Now().Add(-2*time.Hour), []testItem{})
+
+	infos, err := List("ws1")
+	if err != nil {
+		t.Fatalf("List() error: %v", err)
+	}
+
+	got := map[string]Info{}
+	for _, info := range infos {
+		got[info.Key.Filename()] = info
+	}
+	if len(got) != 3 {
+		t.Fatalf("List() returned %v, want workspaces, pipelines-ws1 and epics-ws1", infos)
+	}
+	if info := got["pipelines-ws1.json"]; info.Size == 0 || info.Stale() || info.TTL != 24*time.Hour {
+		t.Errorf("pipelines info = %+v, want fresh with a 24h TTL", info)
+	}
+	if !got["epics-ws1.json"].Stale() {
+		t.Error("epics should be stale after its 1h TTL")
+	}
+}
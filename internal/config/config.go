@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -21,6 +22,33 @@ type AliasConfig struct {
 	Epics     map[string]string `mapstructure:"epics"`
 }
 
+// RetryConfig controls automatic retries of failed API requests.
+type RetryConfig struct {
+	MaxAttempts int           `mapstructure:"max_attempts"` // total attempts; 1 disables retries
+	BaseDelay   time.Duration `mapstructure:"base_delay"`   // backoff before the first retry
+	MaxDelay    time.Duration `mapstructure:"max_delay"`    // cap on backoff delays
+	Mutations   bool          `mapstructure:"mutations"`    // also retry mutations on 5xx/connection errors
+}
+
+// DefaultRetry returns the retry settings used when config.yml doesn't set them.
+func DefaultRetry() RetryConfig {
+	return RetryConfig{
+		MaxAttempts: 3,
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    30 * time.Second,
+	}
+}
+
+// CacheConfig controls how long cached resources are kept.
+type CacheConfig struct {
+	TTL map[string]time.Duration `mapstructure:"ttl"` // per-resource overrides of the default TTLs
+}
+
+// SprintConfig holds defaults for sprint commands.
+type SprintConfig struct {
+	RolloverLabel string `mapstructure:"rollover_label"` // label added to issues carried over by zh sprint rollover
+}
+
 // Config holds the complete zh configuration.
 type Config struct {
 	APIKey     string       `mapstructure:"api_key"`
@@ -28,6 +56,9 @@ type Config struct {
 	Workspace  string       `mapstructure:"workspace"`
 	GitHub     GitHubConfig `mapstructure:"github"`
 	Aliases    AliasConfig  `mapstructure:"aliases"`
+	Retry      RetryConfig  `mapstructure:"retry"`
+	Cache      CacheConfig  `mapstructure:"cache"`
+	Sprint     SprintConfig `mapstructure:"sprint"`
 }
 
 var v *viper.Viper
@@ -54,6 +85,11 @@ func Load() (*Config, error) {
 	v.SetDefault("github.method", "none")
 	v.SetDefault("aliases.pipelines", map[string]string{})
 	v.SetDefault("aliases.epics", map[string]string{})
+	defaultRetry := DefaultRetry()
+	v.SetDefault("retry.max_attempts", defaultRetry.MaxAttempts)
+	v.SetDefault("retry.base_delay", defaultRetry.BaseDelay)
+	v.SetDefault("retry.max_delay", defaultRetry.MaxDelay)
+	v.SetDefault("retry.mutations", defaultRetry.Mutations)
 
 	if err := v.ReadInConfig(); err != nil {
 		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
@@ -96,6 +132,22 @@ func Write(cfg *Config) error {
 	}
 	v.Set("aliases.pipelines", cfg.Aliases.Pipelines)
 	v.Set("aliases.epics", cfg.Aliases.Epics)
+	if cfg.Retry != (RetryConfig{}) && cfg.Retry != DefaultRetry() {
+		v.Set("retry.max_attempts", cfg.Retry.MaxAttempts)
+		v.Set("retry.base_delay", cfg.Retry.BaseDelay.String())
+		v.Set("retry.max_delay", cfg.Retry.MaxDelay.String())
+		v.Set("retry.mutations", cfg.Retry.Mutations)
+	}
+	if len(cfg.Cache.TTL) > 0 {
+		ttl := make(map[string]string, len(cfg.Cache.TTL))
+		for resource, d := range cfg.Cache.TTL {
+			ttl[resource] = d.String()
+		}
+		v.Set("cache.ttl", ttl)
+	}
+	if cfg.Sprint.RolloverLabel != "" {
+		v.Set("sprint.rollover_label", cfg.Sprint.RolloverLabel)
+	}
 
 	path := filepath.Join(dir, "config.yml")
 	return v.WriteConfigAs(path)
@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 )
 
 func TestLoadFromFile(t *testing.T) {
@@ -115,6 +116,9 @@ func TestMissingConfigReturnsZeroValues(t *testing.T) {
 	if cfg.GitHub.Method != "none" {
 		t.Errorf("GitHub.Method = %q, want %q", cfg.GitHub.Method, "none")
 	}
+	if cfg.Retry != DefaultRetry() {
+		t.Errorf("Retry = %+v, want defaults %+v", cfg.Retry, DefaultRetry())
+	}
 }
 
 func TestWriteAndReadBack(t *testing.T) {
@@ -158,3 +162,135 @@ func TestWriteAndReadBack(t *testing.T) {
 		t.Errorf("Aliases.Pipelines[dev] = %q, want %q", cfg.Aliases.Pipelines["dev"], "In Development")
 	}
 }
+
+func TestLoadRetryConfig(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "zh")
+	if err := os.MkdirAll(configPath, 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(configPath, "config.yml"), []byte(`
+api_key: test-key-123
+retry:
+  max_attempts: 6
+  base_delay: 2s
+  mutations: true
+`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("ZH_API_KEY", "")
+	t.Setenv("ZH_WORKSPACE", "")
+	t.Setenv("ZH_GITHUB_TOKEN", "")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+
+	want := RetryConfig{MaxAttempts: 6, BaseDelay: 2 * time.Second, MaxDelay: 30 * time.Second, Mutations: true}
+	if cfg.Retry != want {
+		t.Errorf("Retry = %+v, want %+v", cfg.Retry, want)
+	}
+
+	// Non-default retry settings survive a Write/Load round trip.
+	if err := Write(cfg); err != nil {
+		t.Fatalf("Write() error: %v", err)
+	}
+	cfg, err = Load()
+	if err != nil {
+		t.Fatalf("Load() after Write() error: %v", err)
+	}
+	if cfg.Retry != want {
+		t.Errorf("Retry after round trip = %+v, want %+v", cfg.Retry, want)
+	}
+}
+
+func TestLoadCacheConfig(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "zh")
+	if err := os.MkdirAll(configPath, 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(configPath, "config.yml"), []byte(`
+api_key: test-key-123
+cache:
+  ttl:
+    sprints: 10m
+    pipelines: 0s
+`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("ZH_API_KEY", "")
+	t.Setenv("ZH_WORKSPACE", "")
+	t.Setenv("ZH_GITHUB_TOKEN", "")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+
+	check := func(cfg *Config) {
+		t.Helper()
+		if len(cfg.Cache.TTL) != 2 || cfg.Cache.TTL["sprints"] != 10*time.Minute {
+			t.Errorf("Cache.TTL = %v, want sprints=10m pipelines=0s", cfg.Cache.TTL)
+		}
+		if d, ok := cfg.Cache.TTL["pipelines"]; !ok || d != 0 {
+			t.Errorf("Cache.TTL[pipelines] = %v, %v; want 0, true", d, ok)
+		}
+	}
+	check(cfg)
+
+	// TTL overrides survive a Write/Load round trip.
+	if err := Write(cfg); err != nil {
+		t.Fatalf("Write() error: %v", err)
+	}
+	cfg, err = Load()
+	if err != nil {
+		t.Fatalf("Load() after Write() error: %v", err)
+	}
+	check(cfg)
+}
+
+func TestLoadSprintConfig(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "zh")
+	if err := os.MkdirAll(configPath, 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(configPath, "config.yml"), []byte(`
+api_key: test-key-123
+sprint:
+  rollover_label: carried-over
+`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("ZH_API_KEY", "")
+	t.Setenv("ZH_WORKSPACE", "")
+	t.Setenv("ZH_GITHUB_TOKEN", "")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if cfg.Sprint.RolloverLabel != "carried-over" {
+		t.Errorf("Sprint.RolloverLabel = %q, want %q", cfg.Sprint.RolloverLabel, "carried-over")
+	}
+
+	// The rollover label survives a Write/Load round trip.
+	if err := Write(cfg); err != nil {
+		t.Fatalf("Write() error: %v", err)
+	}
+	cfg, err = Load()
+	if err != nil {
+		t.Fatalf("Load() after Write() error: %v", err)
+	}
+	if cfg.Sprint.RolloverLabel != "carried-over" {
+		t.Errorf("Sprint.RolloverLabel after round trip = %q, want %q", cfg.Sprint.RolloverLabel, "carried-over")
+	}
+}
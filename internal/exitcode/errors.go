@@ -60,3 +60,9 @@ func Auth(msg string, err error) *Error {
 func NotFoundError(msg string) *Error {
 	return &Error{Code: NotFound, Message: msg}
 }
+
+// OfflineError returns an error for work that can't be done offline (exit
+// code 5).
+func OfflineError(msg string) *Error {
+	return &Error{Code: Offline, Message: msg}
+}
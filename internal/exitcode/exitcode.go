@@ -7,4 +7,5 @@ const (
 	UsageError   = 2
 	AuthFailure  = 3
 	NotFound     = 4
+	Offline      = 5
 )
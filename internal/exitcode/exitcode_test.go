@@ -17,6 +17,7 @@ func TestExitCodeFromTypedErrors(t *testing.T) {
 		{"usage error", Usage("bad flag"), UsageError},
 		{"auth error", Auth("bad key", nil), AuthFailure},
 		{"not found error", NotFoundError("issue not found"), NotFound},
+		{"offline error", OfflineError("offline"), Offline},
 		{"plain error", errors.New("something"), GeneralError},
 		{"wrapped not found", fmt.Errorf("resolving: %w", NotFoundError("issue not found")), NotFound},
 		{"wrapped auth", fmt.Errorf("outer: %w", Auth("bad key", nil)), AuthFailure},
@@ -75,4 +76,7 @@ func TestExitCodeConstants(t *testing.T) {
 	if NotFound != 4 {
 		t.Errorf("NotFound = %d, want 4", NotFound)
 	}
+	if Offline != 5 {
+		t.Errorf("Offline = %d, want 5", Offline)
+	}
 }
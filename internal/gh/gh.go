@@ -3,13 +3,19 @@ package gh
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"os/exec"
+	"regexp"
+	"strconv"
 	"time"
 
 	"github.com/dslh/zh/internal/exitcode"
+	"github.com/dslh/zh/internal/retry"
 )
 
 // Client provides GitHub GraphQL API access.
@@ -18,6 +24,7 @@ type Client struct {
 	token      string // PAT token (only for method=pat)
 	endpoint   string // GraphQL endpoint URL
 	httpClient *http.Client
+	retry      retry.Policy
 	verbose    bool
 	logFunc    func(format string, args ...any)
 }
@@ -38,9 +45,16 @@ func WithEndpoint(endpoint string) Option {
 	return func(c *Client) { c.endpoint = endpoint }
 }
 
+// WithRetryPolicy sets how failed requests are retried. Without it, requests
+// are attempted once.
+func WithRetryPolicy(p retry.Policy) Option {
+	return func(c *Client) { c.retry = p }
+}
+
 // New creates a new GitHub API client. Method should be "gh" or "pat".
 // For "pat" method, token must be provided.
-// Returns nil if method is "none" or empty.
+// Returns nil if method is "none" or empty. Requests have no timeout of
+// their own; they are bounded by the context passed to Execute.
 func New(method, token string, opts ...Option) *Client {
 	if method == "" || method == "none" {
 		return nil
@@ -49,7 +63,7 @@ func New(method, token string, opts ...Option) *Client {
 		method:     method,
 		token:      token,
 		endpoint:   githubGraphQLEndpoint,
-		httpClient: &http.Client{Timeout: 30 * time.Second},
+		httpClient: &http.Client{},
 	}
 	for _, opt := range opts {
 		opt(c)
@@ -73,15 +87,16 @@ type graphQLResponse struct {
 	} `json:"errors"`
 }
 
-// Execute sends a GraphQL query to the GitHub API.
-func (c *Client) Execute(query string, variables map[string]any) (json.RawMessage, error) {
+// Execute sends a GraphQL query to the GitHub API. Cancelling ctx aborts an
+// in-flight request and kills a running gh subprocess.
+func (c *Client) Execute(ctx context.Context, query string, variables map[string]any) (json.RawMessage, error) {
 	if c.method == "gh" {
-		return c.executeViaGhCLI(query, variables)
+		return c.executeViaGhCLI(ctx, query, variables)
 	}
-	return c.executeViaPAT(query, variables)
+	return c.executeViaPAT(ctx, query, variables)
 }
 
-func (c *Client) executeViaPAT(query string, variables map[string]any) (json.RawMessage, error) {
+func (c *Client) executeViaPAT(ctx context.Context, query string, variables map[string]any) (json.RawMessage, error) {
 	reqBody := graphQLRequest{
 		Query:     query,
 		Variables: variables,
@@ -97,36 +112,58 @@ func (c *Client) executeViaPAT(query string, variables map[string]any) (json.Raw
 		c.log("→ Query: %s\n", query)
 	}
 
-	req, err := http.NewRequest(http.MethodPost, c.endpoint, bytes.NewReader(bodyBytes))
-	if err != nil {
-		return nil, exitcode.General("creating GitHub request", err)
-	}
+	idempotent := !retry.IsMutation(query)
+	var respBody []byte
+	err = retry.Do(ctx, c.retry, c.retryLog(), func() error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(bodyBytes))
+		if err != nil {
+			return exitcode.General("creating GitHub request", err)
+		}
 
-	req.Header.Set("Authorization", "Bearer "+c.token)
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("User-Agent", "zh-cli/dev")
+		req.Header.Set("Authorization", "Bearer "+c.token)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("User-Agent", "zh-cli/dev")
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, exitcode.General("GitHub API request failed", err)
-	}
-	defer resp.Body.Close()
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return c.retryableTransport(ctx, idempotent, requestError(ctx, "GitHub API request failed", err), err)
+		}
+		defer resp.Body.Close()
 
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, exitcode.General("reading GitHub response", err)
-	}
+		respBody, err = io.ReadAll(resp.Body)
+		if err != nil {
+			return c.retryableTransport(ctx, idempotent, requestError(ctx, "reading GitHub response", err), err)
+		}
 
-	if c.verbose {
-		c.log("← GitHub %d %s\n", resp.StatusCode, http.StatusText(resp.StatusCode))
-	}
+		if c.verbose {
+			c.log("← GitHub %d %s\n", resp.StatusCode, http.StatusText(resp.StatusCode))
+		}
 
-	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
-		return nil, exitcode.Auth("GitHub authentication failed — check your token", nil)
-	}
+		// GitHub signals secondary rate limits with a 403 plus Retry-After.
+		after, limited := retry.RetryAfter(resp.Header.Get("Retry-After"), time.Now())
+		if resp.StatusCode == http.StatusTooManyRequests || (resp.StatusCode == http.StatusForbidden && limited) {
+			return &retry.Error{
+				Err:    exitcode.Generalf("GitHub API rate limited — try again later"),
+				Reason: fmt.Sprintf("GitHub HTTP %d", resp.StatusCode),
+				After:  after,
+			}
+		}
+
+		if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+			return exitcode.Auth("GitHub authentication failed — check your token", nil)
+		}
 
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return nil, exitcode.Generalf("GitHub API returned HTTP %d", resp.StatusCode)
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			err := exitcode.Generalf("GitHub API returned HTTP %d", resp.StatusCode)
+			if retry.RetryableStatus(resp.StatusCode) && (idempotent || c.retry.Mutations) {
+				return &retry.Error{Err: err, Reason: fmt.Sprintf("GitHub HTTP %d", resp.StatusCode), After: after}
+			}
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	var gqlResp graphQLResponse
@@ -141,7 +178,7 @@ func (c *Client) executeViaPAT(query string, variables map[string]any) (json.Raw
 	return gqlResp.Data, nil
 }
 
-func (c *Client) executeViaGhCLI(query string, variables map[string]any) (json.RawMessage, error) {
+func (c *Client) executeViaGhCLI(ctx context.Context, query string, variables map[string]any) (json.RawMessage, error) {
 	// Build the JSON request body and pass via stdin to avoid shell escaping issues
 	// with $ characters in GraphQL variables.
 	reqBody := graphQLRequest{
@@ -160,18 +197,41 @@ func (c *Client) executeViaGhCLI(query string, variables map[string]any) (json.R
 		c.log("→ Body: %s\n", string(bodyBytes))
 	}
 
-	cmd := exec.Command("gh", args...)
-	cmd.Stdin = bytes.NewReader(bodyBytes)
-	var stdout, stderr bytes.Buffer
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
-
-	if err := cmd.Run(); err != nil {
-		errMsg := stderr.String()
-		if errMsg == "" {
-			errMsg = err.Error()
+	idempotent := !retry.IsMutation(query)
+	var stdout bytes.Buffer
+	err = retry.Do(ctx, c.retry, c.retryLog(), func() error {
+		// CommandContext kills the subprocess if ctx is cancelled.
+		cmd := exec.CommandContext(ctx, "gh", args...)
+		cmd.Stdin = bytes.NewReader(bodyBytes)
+		var stderr bytes.Buffer
+		stdout.Reset()
+		cmd.Stdout = &stdout
+		cmd.Stderr = &stderr
+
+		if err := cmd.Run(); err != nil {
+			if ctx.Err() != nil {
+				return requestError(ctx, "gh CLI error", err)
+			}
+			errMsg := stderr.String()
+			if errMsg == "" {
+				errMsg = err.Error()
+			}
+			cliErr := exitcode.Generalf("gh CLI error: %s", errMsg)
+
+			// gh reports the HTTP status in its error output, e.g.
+			// "HTTP 502: Bad Gateway (https://api.github.com/graphql)".
+			if m := ghHTTPStatusPattern.FindStringSubmatch(errMsg); m != nil {
+				code, _ := strconv.Atoi(m[1])
+				if code == http.StatusTooManyRequests || (retry.RetryableStatus(code) && (idempotent || c.retry.Mutations)) {
+					return &retry.Error{Err: cliErr, Reason: "gh HTTP " + m[1]}
+				}
+			}
+			return cliErr
 		}
-		return nil, exitcode.Generalf("gh CLI error: %s", errMsg)
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	if c.verbose {
@@ -190,6 +250,38 @@ func (c *Client) executeViaGhCLI(query string, variables map[string]any) (json.R
 	return gqlResp.Data, nil
 }
 
+// ghHTTPStatusPattern extracts the HTTP status from gh CLI error output.
+var ghHTTPStatusPattern = regexp.MustCompile(`HTTP (\d{3})`)
+
+// retryableTransport wraps err as a retry.Error if the underlying transport
+// error is transient and retrying is safe.
+func (c *Client) retryableTransport(ctx context.Context, idempotent bool, err, cause error) error {
+	if ctx.Err() != nil || !retry.TransientError(cause) || (!idempotent && !c.retry.Mutations) {
+		return err
+	}
+	return &retry.Error{Err: err, Reason: cause.Error()}
+}
+
+// retryLog returns the retry logger, or nil when not verbose.
+func (c *Client) retryLog() func(format string, args ...any) {
+	if !c.verbose {
+		return nil
+	}
+	return c.log
+}
+
+// requestError wraps a transport error, reporting cancellation and timeouts
+// in plain terms rather than as a raw net/http or exec error.
+func requestError(ctx context.Context, msg string, err error) error {
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return exitcode.General("GitHub request timed out", ctx.Err())
+	case errors.Is(ctx.Err(), context.Canceled):
+		return exitcode.General("GitHub request canceled", ctx.Err())
+	}
+	return exitcode.General(msg, err)
+}
+
 func (c *Client) log(format string, args ...any) {
 	if c.logFunc != nil {
 		c.logFunc(format, args...)
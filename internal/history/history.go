@@ -0,0 +1,169 @@
+// Package history keeps a local journal of the mutations zh has executed,
+// with the state each change replaced, so that they can be listed with
+// `zh history` and reverted with `zh undo`.
+//
+// The journal lives at $XDG_DATA_HOME/zh/ (default ~/.local/share/zh/), in
+// one file per workspace ("history-{workspace_id}.json"). Only the most
+// recent MaxEntries operations are kept.
+package history
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// MaxEntries is the number of operations kept per workspace.
+const MaxEntries = 100
+
+// Change kinds.
+const (
+	KindMove     = "move"
+	KindEstimate = "estimate"
+	KindPriority = "priority"
+	KindLabel    = "label"
+	KindEpic     = "epic"
+	KindSprint   = "sprint"
+)
+
+// Dir returns the XDG-compliant data directory for zh.
+func Dir() string {
+	if xdg := os.Getenv("XDG_DATA_HOME"); xdg != "" {
+		return filepath.Join(xdg, "zh")
+	}
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".local", "share", "zh")
+}
+
+// Entry is one executed command.
+type Entry struct {
+	ID       int        `json:"id"` // increases with each entry in the workspace
+	Time     time.Time  `json:"time"`
+	Command  string     `json:"command"` // e.g. "issue move"
+	Summary  string     `json:"summary"` // e.g. `Moved 3 issue(s) to "Done"`
+	Changes  []Change   `json:"changes"`
+	UndoOf   int        `json:"undoOf,omitempty"`   // the entry this one reverted
+	UndoneAt *time.Time `json:"undoneAt,omitempty"` // when this entry was reverted
+}
+
+// Change is a single field changed on a single issue. A nil Before or After
+// means the field was empty: no estimate or priority, or the label, epic or
+// sprint was absent.
+type Change struct {
+	Kind   string `json:"kind"`
+	Issue  Issue  `json:"issue"`
+	Before *Value `json:"before,omitempty"`
+	After  *Value `json:"after,omitempty"`
+}
+
+// Issue identifies the issue a change was made to.
+type Issue struct {
+	ID        string `json:"id"`
+	Number    int    `json:"number"`
+	RepoName  string `json:"repoName"`
+	RepoOwner string `json:"repoOwner"`
+	RepoGhID  int    `json:"repoGhId,omitempty"`
+}
+
+// Ref returns the short issue reference, e.g. "api#12".
+func (i Issue) Ref() string {
+	return fmt.Sprintf("%s#%d", i.RepoName, i.Number)
+}
+
+// Value is one side of a change.
+type Value struct {
+	ID       string   `json:"id,omitempty"`       // pipeline, priority, label, epic or sprint ID
+	Name     string   `json:"name,omitempty"`     // pipeline, priority, label or sprint name, or epic title
+	Estimate *float64 `json:"estimate,omitempty"` // estimate changes only
+
+	// Position is the issue's relativePosition in its pipeline. Only set on
+	// the Before side of moves, and only when the API returned it.
+	Position *int `json:"position,omitempty"`
+
+	// Epic details needed to update legacy epics. Only set on epic changes.
+	Epic *Epic `json:"epic,omitempty"`
+}
+
+// Epic holds the details of the epic in an epic change.
+type Epic struct {
+	Type        string `json:"type"` // "zenhub" or "legacy"
+	IssueNumber int    `json:"issueNumber,omitempty"`
+	RepoName    string `json:"repoName,omitempty"`
+	RepoOwner   string `json:"repoOwner,omitempty"`
+}
+
+func path(workspaceID string) string {
+	return filepath.Join(Dir(), fmt.Sprintf("history-%s.json", workspaceID))
+}
+
+// Load returns the journal for a workspace, oldest entry first. A missing
+// journal is empty.
+func Load(workspaceID string) ([]Entry, error) {
+	data, err := os.ReadFile(path(workspaceID))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading history: %w", err)
+	}
+
+	var entries []Entry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("parsing history: %w", err)
+	}
+	return entries, nil
+}
+
+// Append adds an entry to the journal, assigning its ID and time, and drops
+// the oldest entries beyond MaxEntries. Returns the stored entry.
+func Append(workspaceID string, entry Entry) (Entry, error) {
+	entries, err := Load(workspaceID)
+	if err != nil {
+		return entry, err
+	}
+
+	entry.ID = 1
+	if len(entries) > 0 {
+		entry.ID = entries[len(entries)-1].ID + 1
+	}
+	if entry.Time.IsZero() {
+		entry.Time = time.Now().UTC().Truncate(time.Second)
+	}
+
+	entries = append(entries, entry)
+	if len(entries) > MaxEntries {
+		entries = entries[len(entries)-MaxEntries:]
+	}
+	return entry, save(workspaceID, entries)
+}
+
+// MarkUndone records that the entry with the given ID has been reverted.
+func MarkUndone(workspaceID string, id int, at time.Time) error {
+	entries, err := Load(workspaceID)
+	if err != nil {
+		return err
+	}
+
+	for i := range entries {
+		if entries[i].ID == id {
+			at = at.UTC().Truncate(time.Second)
+			entries[i].UndoneAt = &at
+			return save(workspaceID, entries)
+		}
+	}
+	return fmt.Errorf("history entry %d not found", id)
+}
+
+func save(workspaceID string, entries []Entry) error {
+	if err := os.MkdirAll(Dir(), 0o700); err != nil {
+		return fmt.Errorf("creating data directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling history: %w", err)
+	}
+	return os.WriteFile(path(workspaceID), data, 0o600)
+}
@@ -0,0 +1,127 @@
+package history
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func setupDataDir(t *testing.T) {
+	t.Helper()
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+}
+
+func TestDir(t *testing.T) {
+	t.Run("uses XDG_DATA_HOME when set", func(t *testing.T) {
+		t.Setenv("XDG_DATA_HOME", "/tmp/test-data")
+		if got, want := Dir(), "/tmp/test-data/zh"; got != want {
+			t.Errorf("Dir() = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("falls back to ~/.local/share/zh", func(t *testing.T) {
+		t.Setenv("XDG_DATA_HOME", "")
+		home, _ := os.UserHomeDir()
+		if got, want := Dir(), filepath.Join(home, ".local", "share", "zh"); got != want {
+			t.Errorf("Dir() = %q, want %q", got, want)
+		}
+	})
+}
+
+func TestLoadMissing(t *testing.T) {
+	setupDataDir(t)
+
+	entries, err := Load("ws1")
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("Load() returned %d entries, want 0", len(entries))
+	}
+}
+
+func TestAppendAndLoad(t *testing.T) {
+	setupDataDir(t)
+
+	estimate := 3.0
+	first, err := Append("ws1", Entry{
+		Command: "issue estimate",
+		Summary: "Set estimate on api#1 to 5",
+		Changes: []Change{{
+			Kind:   KindEstimate,
+			Issue:  Issue{ID: "i1", Number: 1, RepoName: "api"},
+			Before: &Value{Estimate: &estimate},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("Append() error: %v", err)
+	}
+	second, err := Append("ws1", Entry{Command: "issue close"})
+	if err != nil {
+		t.Fatalf("Append() error: %v", err)
+	}
+	if _, err := Append("ws2", Entry{Command: "issue close"}); err != nil {
+		t.Fatalf("Append() error: %v", err)
+	}
+
+	if first.ID != 1 || second.ID != 2 {
+		t.Errorf("IDs = %d, %d, want 1, 2", first.ID, second.ID)
+	}
+	if first.Time.IsZero() {
+		t.Error("Append() should set the entry time")
+	}
+
+	entries, err := Load("ws1")
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("Load() returned %d entries, want 2", len(entries))
+	}
+	change := entries[0].Changes[0]
+	if change.Issue.Ref() != "api#1" || change.Before.Estimate == nil || *change.Before.Estimate != 3 || change.After != nil {
+		t.Errorf("change did not round-trip: %+v", change)
+	}
+
+	other, _ := Load("ws2")
+	if len(other) != 1 || other[0].ID != 1 {
+		t.Errorf("workspaces should have separate journals, got %+v", other)
+	}
+}
+
+func TestAppendTrims(t *testing.T) {
+	setupDataDir(t)
+
+	for i := 0; i < MaxEntries+5; i++ {
+		if _, err := Append("ws1", Entry{Command: "issue close"}); err != nil {
+			t.Fatalf("Append() error: %v", err)
+		}
+	}
+
+	entries, _ := Load("ws1")
+	if len(entries) != MaxEntries {
+		t.Fatalf("Load() returned %d entries, want %d", len(entries), MaxEntries)
+	}
+	if entries[0].ID != 6 || entries[len(entries)-1].ID != MaxEntries+5 {
+		t.Errorf("expected the oldest entries to be dropped, got IDs %d..%d", entries[0].ID, entries[len(entries)-1].ID)
+	}
+}
+
+func TestMarkUndone(t *testing.T) {
+	setupDataDir(t)
+
+	entry, _ := Append("ws1", Entry{Command: "issue move"})
+	if err := MarkUndone("ws1", entry.ID, time.Now()); err != nil {
+		t.Fatalf("MarkUndone() error: %v", err)
+	}
+
+	entries, _ := Load("ws1")
+	if entries[0].UndoneAt == nil {
+		t.Error("entry should be marked undone")
+	}
+
+	if err := MarkUndone("ws1", 99, time.Now()); err == nil {
+		t.Error("MarkUndone() should fail for an unknown entry")
+	}
+}
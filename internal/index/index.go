@@ -0,0 +1,200 @@
+// Package index keeps a local mirror of a workspace's issues, so that they
+// can be searched offline by `zh search` and offered as shell completions.
+//
+// The index is written by `zh sync` to the cache directory, in one file per
+// workspace ("index-{workspace_id}.json"). Each sync only fetches issues
+// updated since the previous one, using the latest updatedAt it has seen as
+// a watermark.
+package index
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/dslh/zh/internal/cache"
+)
+
+// Index is the local copy of a workspace's issues.
+type Index struct {
+	WorkspaceID string    `json:"workspaceId"`
+	SyncedAt    time.Time `json:"syncedAt"`
+	Watermark   time.Time `json:"watermark"` // latest updatedAt seen
+	Issues      []Issue   `json:"issues"`
+}
+
+// Issue is an indexed issue or pull request.
+type Issue struct {
+	ID          string    `json:"id"`
+	Number      int       `json:"number"`
+	RepoName    string    `json:"repoName"`
+	RepoOwner   string    `json:"repoOwner"`
+	Title       string    `json:"title"`
+	Body        string    `json:"body,omitempty"`
+	State       string    `json:"state"`
+	PullRequest bool      `json:"pullRequest,omitempty"`
+	PipelineID  string    `json:"pipelineId,omitempty"`
+	Pipeline    string    `json:"pipeline,omitempty"`
+	Estimate    *float64  `json:"estimate,omitempty"`
+	Labels      []string  `json:"labels,omitempty"`
+	Assignees   []string  `json:"assignees,omitempty"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// Ref returns the short issue reference, e.g. "api#12".
+func (i Issue) Ref() string {
+	return fmt.Sprintf("%s#%d", i.RepoName, i.Number)
+}
+
+// Key returns the cache key of a workspace's index.
+func Key(workspaceID string) cache.Key {
+	return cache.NewScopedKey("index", workspaceID)
+}
+
+// Load reads a workspace's index. Returns false if it has not been synced.
+func Load(workspaceID string) (*Index, bool) {
+	idx, ok := cache.Get[Index](Key(workspaceID))
+	if !ok {
+		return nil, false
+	}
+	return &idx, true
+}
+
+// Save writes the index.
+func Save(idx *Index) error {
+	return cache.Set(Key(idx.WorkspaceID), idx)
+}
+
+// Merge adds issues to the index, replacing older copies of the same issue,
+// and advances the watermark. Returns the number of issues added and
+// updated.
+func (idx *Index) Merge(issues []Issue) (added, updated int) {
+	byID := make(map[string]int, len(idx.Issues))
+	for i, issue := range idx.Issues {
+		byID[issue.ID] = i
+	}
+
+	for _, issue := range issues {
+		if issue.UpdatedAt.After(idx.Watermark) {
+			idx.Watermark = issue.UpdatedAt
+		}
+		i, ok := byID[issue.ID]
+		if !ok {
+			byID[issue.ID] = len(idx.Issues)
+			idx.Issues = append(idx.Issues, issue)
+			added++
+			continue
+		}
+		if !issue.UpdatedAt.Before(idx.Issues[i].UpdatedAt) {
+			idx.Issues[i] = issue
+			updated++
+		}
+	}
+	return added, updated
+}
+
+// Result is a search match.
+type Result struct {
+	Issue Issue
+	Score int
+}
+
+// Relevance weights: a term in the title counts for most, then labels and
+// assignees, then the reference, then each mention in the body.
+const (
+	scoreTitleWord   = 10
+	scoreTitlePrefix = 6
+	scoreLabel       = 6
+	scoreAssignee    = 6
+	scoreRef         = 4
+	scoreBody        = 1
+	maxBodyScore     = 5
+	scoreTitlePhrase = 15
+)
+
+// Search returns the issues matching every term in query, most relevant
+// first. Ties go to the most recently updated issue. An empty query matches
+// every issue, newest first.
+func Search(issues []Issue, query string) []Result {
+	terms := tokenize(query)
+	phrase := strings.ToLower(strings.TrimSpace(query))
+
+	var results []Result
+	for _, issue := range issues {
+		score, ok := scoreIssue(issue, terms)
+		if !ok {
+			continue
+		}
+		if len(terms) > 1 && strings.Contains(strings.ToLower(issue.Title), phrase) {
+			score += scoreTitlePhrase
+		}
+		results = append(results, Result{Issue: issue, Score: score})
+	}
+
+	slices.SortStableFunc(results, func(a, b Result) int {
+		if c := cmp.Compare(b.Score, a.Score); c != 0 {
+			return c
+		}
+		return b.Issue.UpdatedAt.Compare(a.Issue.UpdatedAt)
+	})
+	return results
+}
+
+// scoreIssue scores an issue against the search terms. Returns false if any
+// term is not found in the issue.
+func scoreIssue(issue Issue, terms []string) (int, bool) {
+	title := tokenize(issue.Title)
+	body := tokenize(issue.Body)
+	ref := strings.ToLower(issue.Ref())
+
+	total := 0
+	for _, term := range terms {
+		score := 0
+		for _, word := range title {
+			switch {
+			case word == term:
+				score += scoreTitleWord
+			case strings.HasPrefix(word, term):
+				score += scoreTitlePrefix
+			}
+		}
+		for _, label := range issue.Labels {
+			if slices.Contains(tokenize(label), term) {
+				score += scoreLabel
+			}
+		}
+		for _, login := range issue.Assignees {
+			if strings.EqualFold(login, term) {
+				score += scoreAssignee
+			}
+		}
+		if ref == term || strings.TrimPrefix(term, "#") == strconv.Itoa(issue.Number) {
+			score += scoreRef
+		}
+		mentions := 0
+		for _, word := range body {
+			if word == term || strings.HasPrefix(word, term) {
+				mentions++
+			}
+		}
+		score += min(mentions, maxBodyScore) * scoreBody
+
+		if score == 0 {
+			return 0, false
+		}
+		total += score
+	}
+	return total, true
+}
+
+// tokenize splits text into lowercase words. An issue reference such as
+// "api#12" is kept as one word.
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '#' && r != '_' && r != '-'
+	})
+}
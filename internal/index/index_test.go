@@ -0,0 +1,89 @@
+package index
+
+import (
+	"testing"
+	"time"
+)
+
+func at(day int) time.Time {
+	return time.Date(2026, 10, day, 12, 0, 0, 0, time.UTC)
+}
+
+func TestMerge(t *testing.T) {
+	idx := &Index{Issues: []Issue{
+		{ID: "i1", Number: 1, Title: "Old title", UpdatedAt: at(1)},
+		{ID: "i2", Number: 2, Title: "Unchanged", UpdatedAt: at(2)},
+	}, Watermark: at(2)}
+
+	added, updated := idx.Merge([]Issue{
+		{ID: "i1", Number: 1, Title: "New title", UpdatedAt: at(3)},
+		{ID: "i3", Number: 3, Title: "Brand new", UpdatedAt: at(4)},
+		{ID: "i2", Number: 2, Title: "Stale copy", UpdatedAt: at(1)},
+	})
+
+	if added != 1 || updated != 1 {
+		t.Errorf("Merge() = %d added, %d updated; want 1, 1", added, updated)
+	}
+	if len(idx.Issues) != 3 || idx.Issues[0].Title != "New title" || idx.Issues[1].Title != "Unchanged" {
+		t.Errorf("unexpected issues after merge: %+v", idx.Issues)
+	}
+	if !idx.Watermark.Equal(at(4)) {
+		t.Errorf("watermark = %v, want %v", idx.Watermark, at(4))
+	}
+}
+
+func TestSearch(t *testing.T) {
+	issues := []Issue{
+		{ID: "i1", Number: 1, RepoName: "api", Title: "Login timeout on slow networks", Labels: []string{"bug"}, UpdatedAt: at(1)},
+		{ID: "i2", Number: 2, RepoName: "api", Title: "Add SSO", Body: "After login, the session timeout should be configurable.", UpdatedAt: at(2)},
+		{ID: "i3", Number: 3, RepoName: "web", Title: "Timeout on the login page", Assignees: []string{"alice"}, UpdatedAt: at(3)},
+		{ID: "i4", Number: 4, RepoName: "web", Title: "Dark mode", UpdatedAt: at(4)},
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{"title phrase ranks first", "login timeout", []string{"api#1", "web#3", "api#2"}},
+		{"all terms must match", "login dark", nil},
+		{"label", "bug", []string{"api#1"}},
+		{"assignee", "alice", []string{"web#3"}},
+		{"reference", "web#4", []string{"web#4"}},
+		{"empty query lists newest first", "", []string{"web#4", "web#3", "api#2", "api#1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, r := range Search(issues, tt.query) {
+				got = append(got, r.Issue.Ref())
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Search(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("Search(%q) = %v, want %v", tt.query, got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadSave(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	if _, ok := Load("ws1"); ok {
+		t.Fatal("Load() should report a missing index")
+	}
+
+	idx := &Index{WorkspaceID: "ws1", Watermark: at(1), Issues: []Issue{{ID: "i1", Number: 1, RepoName: "api"}}}
+	if err := Save(idx); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	got, ok := Load("ws1")
+	if !ok || !got.Watermark.Equal(at(1)) || got.Issues[0].Ref() != "api#1" {
+		t.Errorf("Load() = %+v, %v", got, ok)
+	}
+}
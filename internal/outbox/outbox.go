@@ -0,0 +1,123 @@
+// Package outbox keeps the mutations queued with `zh --offline --queue`, so
+// that `zh outbox push` can run them once the API is reachable again.
+//
+// Each entry is the command line that was queued. Commands are resolved and
+// run when they are pushed, not when they are queued, since resolving names
+// needs the API.
+//
+// The outbox lives next to the history journal, in $XDG_DATA_HOME/zh/
+// (default ~/.local/share/zh/), in one file per workspace
+// ("outbox-{workspace_id}.json").
+package outbox
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dslh/zh/internal/history"
+)
+
+// Entry is one queued command.
+type Entry struct {
+	ID      int       `json:"id"` // increases with each entry in the workspace
+	Time    time.Time `json:"time"`
+	Command string    `json:"command"` // e.g. "issue move"
+	Args    []string  `json:"args"`    // the arguments after "zh", flags included
+}
+
+// CommandLine returns the queued command as it would be typed, e.g.
+// `zh issue move api#1 "In Review"`.
+func (e Entry) CommandLine() string {
+	parts := []string{"zh"}
+	for _, arg := range e.Args {
+		if arg == "" || strings.ContainsAny(arg, " \t\"'") {
+			arg = fmt.Sprintf("%q", arg)
+		}
+		parts = append(parts, arg)
+	}
+	return strings.Join(parts, " ")
+}
+
+func path(workspaceID string) string {
+	return filepath.Join(history.Dir(), fmt.Sprintf("outbox-%s.json", workspaceID))
+}
+
+// Load returns the queued commands for a workspace, oldest first. A missing
+// outbox is empty.
+func Load(workspaceID string) ([]Entry, error) {
+	data, err := os.ReadFile(path(workspaceID))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading outbox: %w", err)
+	}
+
+	var entries []Entry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("parsing outbox: %w", err)
+	}
+	return entries, nil
+}
+
+// Append queues a command, assigning its ID and time. Returns the stored
+// entry.
+func Append(workspaceID string, entry Entry) (Entry, error) {
+	entries, err := Load(workspaceID)
+	if err != nil {
+		return entry, err
+	}
+
+	entry.ID = 1
+	if len(entries) > 0 {
+		entry.ID = entries[len(entries)-1].ID + 1
+	}
+	if entry.Time.IsZero() {
+		entry.Time = time.Now().UTC().Truncate(time.Second)
+	}
+
+	return entry, save(workspaceID, append(entries, entry))
+}
+
+// Remove drops the entry with the given ID, once it has been pushed.
+func Remove(workspaceID string, id int) error {
+	entries, err := Load(workspaceID)
+	if err != nil {
+		return err
+	}
+
+	for i := range entries {
+		if entries[i].ID == id {
+			return save(workspaceID, append(entries[:i], entries[i+1:]...))
+		}
+	}
+	return fmt.Errorf("outbox entry %d not found", id)
+}
+
+// Clear drops every queued command for the workspace.
+func Clear(workspaceID string) error {
+	err := os.Remove(path(workspaceID))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+func save(workspaceID string, entries []Entry) error {
+	if len(entries) == 0 {
+		return Clear(workspaceID)
+	}
+	if err := os.MkdirAll(history.Dir(), 0o700); err != nil {
+		return fmt.Errorf("creating data directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling outbox: %w", err)
+	}
+	return os.WriteFile(path(workspaceID), data, 0o600)
+}
@@ -0,0 +1,54 @@
+package outbox
+
+import (
+	"testing"
+)
+
+func TestAppendRemoveClear(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+
+	entries, err := Load("ws1")
+	if err != nil || len(entries) != 0 {
+		t.Fatalf("Load() = %v, %v; want an empty outbox", entries, err)
+	}
+
+	first, err := Append("ws1", Entry{Command: "issue move", Args: []string{"issue", "move", "api#1", "In Review"}})
+	if err != nil {
+		t.Fatalf("Append() error: %v", err)
+	}
+	second, err := Append("ws1", Entry{Command: "issue close", Args: []string{"issue", "close", "api#2"}})
+	if err != nil {
+		t.Fatalf("Append() error: %v", err)
+	}
+	if first.ID != 1 || second.ID != 2 || first.Time.IsZero() {
+		t.Errorf("Append() assigned IDs %d, %d and time %v", first.ID, second.ID, first.Time)
+	}
+	if got, want := first.CommandLine(), `zh issue move api#1 "In Review"`; got != want {
+		t.Errorf("CommandLine() = %q, want %q", got, want)
+	}
+
+	if err := Remove("ws1", 1); err != nil {
+		t.Fatalf("Remove() error: %v", err)
+	}
+	entries, _ = Load("ws1")
+	if len(entries) != 1 || entries[0].ID != 2 {
+		t.Errorf("after Remove(), entries = %+v", entries)
+	}
+
+	// IDs keep increasing after removals.
+	third, _ := Append("ws1", Entry{Command: "issue reopen"})
+	if third.ID != 3 {
+		t.Errorf("third ID = %d, want 3", third.ID)
+	}
+
+	if other, _ := Load("ws2"); len(other) != 0 {
+		t.Errorf("outboxes should be per workspace, got %+v", other)
+	}
+
+	if err := Clear("ws1"); err != nil {
+		t.Fatalf("Clear() error: %v", err)
+	}
+	if entries, _ := Load("ws1"); len(entries) != 0 {
+		t.Errorf("after Clear(), entries = %+v", entries)
+	}
+}
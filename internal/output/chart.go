@@ -0,0 +1,123 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strings"
+)
+
+// ChartSeries is one line on a line chart. Values are plotted left to right,
+// one column per value; NaN values are left blank.
+type ChartSeries struct {
+	Name   string
+	Mark   string
+	Color  func(string) string
+	Values []float64
+}
+
+// LineChart renders series as a character chart with a labelled y-axis,
+// the first and last x labels under the axis and a legend:
+//
+//	16 ┤ ●
+//	   │   ● ● · ·
+//	 8 ┤ ·       ● ●
+//	   │             · ●
+//	 0 ┤               ● ●
+//	   └──────────────────
+//	    Jan 20     Feb 3
+//
+// Where series overlap, the first one is drawn on top.
+func LineChart(w io.Writer, height int, xStart, xEnd string, series ...ChartSeries) {
+	if height < 2 {
+		height = 2
+	}
+
+	columns := 0
+	top := 0.0
+	for _, s := range series {
+		columns = max(columns, len(s.Values))
+		for _, v := range s.Values {
+			if !math.IsNaN(v) {
+				top = max(top, v)
+			}
+		}
+	}
+	top = math.Ceil(top)
+	if top == 0 {
+		top = 1
+	}
+
+	grid := make([][]string, height)
+	for r := range grid {
+		grid[r] = make([]string, columns)
+	}
+	for i := len(series) - 1; i >= 0; i-- {
+		s := series[i]
+		mark := s.Mark
+		if s.Color != nil {
+			mark = s.Color(mark)
+		}
+		for c, v := range s.Values {
+			if math.IsNaN(v) {
+				continue
+			}
+			row := height - 1 - int(math.Round(max(v, 0)/top*float64(height-1)))
+			grid[row][c] = mark
+		}
+	}
+
+	labels := map[int]string{0: formatChartValue(top), height - 1: "0"}
+	if mid := (height - 1) / 2; mid > 0 {
+		labels[mid] = formatChartValue(top * float64(height-1-mid) / float64(height-1))
+	}
+	labelWidth := 0
+	for _, l := range labels {
+		labelWidth = max(labelWidth, len(l))
+	}
+
+	for r, row := range grid {
+		axis := "│"
+		label, ok := labels[r]
+		if ok {
+			axis = "┤"
+		}
+		var b strings.Builder
+		fmt.Fprintf(&b, "%*s %s", labelWidth, label, axis)
+		for _, cell := range row {
+			if cell == "" {
+				cell = " "
+			}
+			b.WriteString(" " + cell)
+		}
+		fmt.Fprintln(w, strings.TrimRight(b.String(), " "))
+	}
+
+	pad := strings.Repeat(" ", labelWidth+1)
+	fmt.Fprintln(w, pad+"└"+strings.Repeat("─", 2*columns+1))
+
+	gap := 2*columns - len(xStart) - len(xEnd) - 1
+	if gap < 2 {
+		gap = 2
+	}
+	fmt.Fprintln(w, pad+"  "+xStart+strings.Repeat(" ", gap)+xEnd)
+
+	legend := make([]string, len(series))
+	for i, s := range series {
+		mark := s.Mark
+		if s.Color != nil {
+			mark = s.Color(mark)
+		}
+		legend[i] = mark + " " + s.Name
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, pad+"  "+strings.Join(legend, "   "))
+}
+
+// formatChartValue formats an axis label without trailing zeros.
+func formatChartValue(v float64) string {
+	if v == math.Trunc(v) {
+		return fmt.Sprintf("%.0f", v)
+	}
+	return fmt.Sprintf("%.1f", v)
+}
@@ -0,0 +1,26 @@
+package output
+
+import (
+	"bytes"
+	"math"
+	"testing"
+)
+
+func TestLineChart(t *testing.T) {
+	var buf bytes.Buffer
+	LineChart(&buf, 3, "Mon", "Thu",
+		ChartSeries{Name: "Remaining", Mark: "●", Values: []float64{4, 4, 2, math.NaN()}},
+		ChartSeries{Name: "Ideal", Mark: "·", Values: []float64{4, 3, 1, 0}},
+	)
+
+	want := "4 ┤ ● ●\n" +
+		"2 ┤     ●\n" +
+		"0 ┤       ·\n" +
+		"  └─────────\n" +
+		"    Mon  Thu\n" +
+		"\n" +
+		"    ● Remaining   · Ideal\n"
+	if got := buf.String(); got != want {
+		t.Errorf("LineChart() =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -1,6 +1,9 @@
 package output
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 // FormatDate formats a time as a standalone date: "Jan 20, 2025".
 func FormatDate(t time.Time) string {
@@ -25,3 +28,31 @@ func FormatDateRange(start, end time.Time) string {
 func FormatDateISO(t time.Time) string {
 	return t.Format("2006-01-02")
 }
+
+// FormatTimeAgo formats a time as a human-readable relative duration:
+// "just now", "5m ago", "3h ago", "2d ago".
+func FormatTimeAgo(t time.Time) string {
+	d := time.Since(t)
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		m := int(d.Minutes())
+		if m == 1 {
+			return "1m ago"
+		}
+		return fmt.Sprintf("%dm ago", m)
+	case d < 24*time.Hour:
+		h := int(d.Hours())
+		if h == 1 {
+			return "1h ago"
+		}
+		return fmt.Sprintf("%dh ago", h)
+	default:
+		days := int(d.Hours() / 24)
+		if days == 1 {
+			return "1d ago"
+		}
+		return fmt.Sprintf("%dd ago", days)
+	}
+}
@@ -69,3 +69,27 @@ func TestFormatDateISO(t *testing.T) {
 		t.Errorf("FormatDateISO() = %q, want %q", got, want)
 	}
 }
+
+func TestFormatTimeAgo(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		t        time.Time
+		contains string
+	}{
+		{now.Add(-30 * time.Second), "just now"},
+		{now.Add(-5 * time.Minute), "5m ago"},
+		{now.Add(-1 * time.Minute), "1m ago"},
+		{now.Add(-3 * time.Hour), "3h ago"},
+		{now.Add(-1 * time.Hour), "1h ago"},
+		{now.Add(-2 * 24 * time.Hour), "2d ago"},
+		{now.Add(-1 * 24 * time.Hour), "1d ago"},
+	}
+
+	for _, tt := range tests {
+		result := FormatTimeAgo(tt.t)
+		if result != tt.contains {
+			t.Errorf("FormatTimeAgo(%v) = %q, want %q", time.Since(tt.t), result, tt.contains)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package output
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+	"go.yaml.in/yaml/v3"
+)
+
+// Output formats accepted by --output.
+const (
+	FormatText     = ""
+	FormatJSON     = "json"
+	FormatNDJSON   = "ndjson"
+	FormatYAML     = "yaml"
+	FormatCSV      = "csv"
+	FormatTSV      = "tsv"
+	FormatMarkdown = "markdown"
+)
+
+// Formats lists the values accepted by --output, for help text and errors.
+var Formats = []string{FormatJSON, FormatNDJSON, FormatYAML, FormatCSV, FormatTSV, FormatMarkdown}
+
+// activeFormat is the --output format for the current command. JSON and
+// ListWriter consult it so that commands don't need to handle each format.
+var activeFormat = FormatText
+
+// ValidateFormat checks an --output value. "text" is accepted as an alias
+// for the default human-readable output.
+func ValidateFormat(format string) error {
+	if format == FormatText || format == "text" {
+		return nil
+	}
+	for _, f := range Formats {
+		if format == f {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid output format %q — must be one of: %s", format, strings.Join(Formats, ", "))
+}
+
+// SetFormat sets the --output format for subsequent JSON and ListWriter
+// output. Structured formats (json, ndjson, yaml) change how JSON encodes values;
+// tabular formats (csv, tsv, markdown) change how ListWriter renders tables.
+func SetFormat(format string) {
+	if format == "text" {
+		format = FormatText
+	}
+	activeFormat = format
+}
+
+// IsTabular reports whether the format renders list views as a table
+// (csv, tsv or markdown) rather than the default aligned columns.
+func IsTabular(format string) bool {
+	return format == FormatCSV || format == FormatTSV || format == FormatMarkdown
+}
+
+// writeStructured writes v as a single compact JSON line if line is set
+// (for NDJSON), otherwise in the active structured format: YAML with
+// --output=yaml, or indented JSON.
+func writeStructured(w io.Writer, v any, line bool) error {
+	if line {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Errorf("formatting JSON output: %w", err)
+		}
+		_, err = fmt.Fprintln(w, string(data))
+		return err
+	}
+
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("formatting JSON output: %w", err)
+	}
+	if activeFormat == FormatYAML {
+		return writeYAML(w, data)
+	}
+	_, err = fmt.Fprintln(w, string(data))
+	return err
+}
+
+// writeYAML converts JSON to YAML. The JSON is parsed as a YAML node tree
+// (JSON is valid YAML), which keeps keys in their original order and numbers
+// in their original form, then re-emitted in block style.
+func writeYAML(w io.Writer, data []byte) error {
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return fmt.Errorf("formatting YAML output: %w", err)
+	}
+	clearYAMLStyle(&node)
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&node); err != nil {
+		return fmt.Errorf("formatting YAML output: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return fmt.Errorf("formatting YAML output: %w", err)
+	}
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// clearYAMLStyle resets the flow and quoting styles inherited from JSON so
+// that the encoder chooses plain block style, quoting only where needed.
+func clearYAMLStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		clearYAMLStyle(child)
+	}
+}
+
+// writeDelimited renders a table as CSV or TSV. Colors are stripped and
+// missing values are left empty so the output imports cleanly into
+// spreadsheets.
+func writeDelimited(w io.Writer, comma rune, headers []string, rows [][]string) {
+	cw := csv.NewWriter(w)
+	cw.Comma = comma
+	_ = cw.Write(headers)
+	for _, row := range rows {
+		cells := make([]string, len(headers))
+		for i := range cells {
+			if i < len(row) {
+				cells[i] = plainCell(row[i])
+			}
+		}
+		_ = cw.Write(cells)
+	}
+	cw.Flush()
+}
+
+// writeMarkdownTable renders a table as a GitHub-flavored markdown table.
+func writeMarkdownTable(w io.Writer, headers []string, rows [][]string) {
+	fmt.Fprintln(w, "| "+strings.Join(escapeMarkdownCells(headers), " | ")+" |")
+	seps := make([]string, len(headers))
+	for i := range seps {
+		seps[i] = "---"
+	}
+	fmt.Fprintln(w, "| "+strings.Join(seps, " | ")+" |")
+	for _, row := range rows {
+		cells := make([]string, len(headers))
+		for i := range cells {
+			if i < len(row) {
+				cells[i] = ansi.Strip(row[i])
+			}
+		}
+		fmt.Fprintln(w, "| "+strings.Join(escapeMarkdownCells(cells), " | ")+" |")
+	}
+}
+
+func plainCell(s string) string {
+	s = ansi.Strip(s)
+	if s == TableMissing {
+		return ""
+	}
+	return s
+}
+
+func escapeMarkdownCells(cells []string) []string {
+	out := make([]string, len(cells))
+	for i, c := range cells {
+		out[i] = strings.ReplaceAll(c, "|", `\|`)
+	}
+	return out
+}
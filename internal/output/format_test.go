@@ -0,0 +1,108 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func withFormat(t *testing.T, format string) {
+	t.Helper()
+	SetFormat(format)
+	t.Cleanup(func() { SetFormat(FormatText) })
+}
+
+func TestValidateFormat(t *testing.T) {
+	for _, f := range []string{"", "text", "json", "ndjson", "yaml", "csv", "tsv", "markdown"} {
+		if err := ValidateFormat(f); err != nil {
+			t.Errorf("ValidateFormat(%q) = %v, want nil", f, err)
+		}
+	}
+	err := ValidateFormat("xml")
+	if err == nil || !strings.Contains(err.Error(), "json, ndjson, yaml, csv, tsv, markdown") {
+		t.Errorf("ValidateFormat(xml) should list valid formats, got: %v", err)
+	}
+}
+
+func TestIsJSONIncludesYAML(t *testing.T) {
+	if !IsJSON("yaml") {
+		t.Error("expected IsJSON(\"yaml\") = true")
+	}
+	if IsJSON("csv") {
+		t.Error("expected IsJSON(\"csv\") = false")
+	}
+}
+
+func TestListWriterCSV(t *testing.T) {
+	withFormat(t, FormatCSV)
+
+	var buf bytes.Buffer
+	lw := NewListWriter(&buf, "ISSUE", "TITLE", "EST")
+	lw.Row(Cyan("task-tracker#1"), "Fix login, again", "3")
+	lw.Row("task-tracker#2", `Say "hello"`, TableMissing)
+	lw.FlushWithFooter("Total: 2 issue(s)")
+
+	want := "ISSUE,TITLE,EST\n" +
+		"task-tracker#1,\"Fix login, again\",3\n" +
+		"task-tracker#2,\"Say \"\"hello\"\"\",\n"
+	if got := buf.String(); got != want {
+		t.Errorf("CSV output =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestListWriterTSV(t *testing.T) {
+	withFormat(t, FormatTSV)
+
+	var buf bytes.Buffer
+	lw := NewListWriter(&buf, "LABEL", "COLOR")
+	lw.Row("bug", "#d73a4a")
+	lw.Flush()
+
+	if got, want := buf.String(), "LABEL\tCOLOR\nbug\t#d73a4a\n"; got != want {
+		t.Errorf("TSV output = %q, want %q", got, want)
+	}
+}
+
+func TestListWriterMarkdown(t *testing.T) {
+	withFormat(t, FormatMarkdown)
+
+	var buf bytes.Buffer
+	lw := NewListWriter(&buf, "NAME", "DATES")
+	lw.Row("Sprint 1", "Jan 1 | Jan 14")
+	lw.FlushWithFooter("Total: 1 sprint(s)")
+
+	want := "| NAME | DATES |\n" +
+		"| --- | --- |\n" +
+		"| Sprint 1 | Jan 1 \\| Jan 14 |\n" +
+		"\n" +
+		"Total: 1 sprint(s)\n"
+	if got := buf.String(); got != want {
+		t.Errorf("markdown output =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestJSONAsYAML(t *testing.T) {
+	withFormat(t, FormatYAML)
+
+	type issue struct {
+		Number int      `json:"number"`
+		Title  string   `json:"title"`
+		Labels []string `json:"labels"`
+		GhID   int64    `json:"ghId"`
+	}
+
+	var buf bytes.Buffer
+	err := JSON(&buf, []issue{{Number: 1, Title: "Fix: login", Labels: []string{"bug"}, GhID: 123456789012}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := "- number: 1\n" +
+		"  title: 'Fix: login'\n" +
+		"  labels:\n" +
+		"    - bug\n" +
+		"  ghId: 123456789012\n"
+	if got := buf.String(); got != want {
+		t.Errorf("YAML output =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -1,23 +1,68 @@
 package output
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
+	"reflect"
 )
 
-// JSON writes v as indented JSON to w.
-// Returns an error if marshaling fails.
+// JSON writes v as indented JSON to w, or as YAML with --output=yaml (see
+// SetFormat). It is shaped first if --json, --jq or --template is in effect
+// (see SetShaping). With --output=ndjson it is written as NDJSON.
+// Returns an error if marshaling or shaping fails.
 func JSON(w io.Writer, v any) error {
-	data, err := json.MarshalIndent(v, "", "  ")
-	if err != nil {
-		return fmt.Errorf("formatting JSON output: %w", err)
+	if activeFormat == FormatNDJSON {
+		return NDJSON(w, v)
 	}
-	_, err = fmt.Fprintln(w, string(data))
-	return err
+	return writeValue(w, v, false)
 }
 
-// IsJSON reports whether the output format flag is set to "json".
+// NDJSON writes v as newline-delimited JSON: one compact line per element if
+// v is a slice or array, otherwise a single line. Shaping is applied to each
+// line separately. Commands that stream results call this once per page.
+func NDJSON(w io.Writer, v any) error {
+	if raw, ok := v.(json.RawMessage); ok {
+		if trimmed := bytes.TrimSpace(raw); len(trimmed) > 0 && trimmed[0] == '[' {
+			var items []json.RawMessage
+			if err := json.Unmarshal(trimmed, &items); err != nil {
+				return fmt.Errorf("formatting JSON output: %w", err)
+			}
+			v = items
+		}
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array || rv.Type() == reflect.TypeFor[json.RawMessage]() {
+		return writeValue(w, v, true)
+	}
+	for i := 0; i < rv.Len(); i++ {
+		if err := writeValue(w, rv.Index(i).Interface(), true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeValue writes v, shaped if shaping is in effect. If line is set, it is
+// written as a compact NDJSON line.
+func writeValue(w io.Writer, v any, line bool) error {
+	if Shaped() {
+		return writeShaped(w, v, line)
+	}
+	return writeStructured(w, v, line)
+}
+
+// IsJSON reports whether the output format flag asks for structured output:
+// "json", or "ndjson" or "yaml", which are rendered from the same data.
 func IsJSON(format string) bool {
-	return format == "json"
+	return format == FormatJSON || format == FormatNDJSON || format == FormatYAML
+}
+
+// IsNDJSON reports whether the output format flag is set to "ndjson".
+// Commands that can stream results check this to write each page as it
+// arrives rather than buffering the whole list.
+func IsNDJSON(format string) bool {
+	return format == FormatNDJSON
 }
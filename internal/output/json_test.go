@@ -2,6 +2,7 @@ package output
 
 import (
 	"bytes"
+	"encoding/json"
 	"strings"
 	"testing"
 )
@@ -53,3 +54,50 @@ func TestIsJSON(t *testing.T) {
 		t.Error("expected IsJSON(\"text\") = false")
 	}
 }
+
+func TestNDJSON(t *testing.T) {
+	var buf bytes.Buffer
+	err := NDJSON(&buf, []map[string]any{
+		{"name": "a", "count": 1},
+		{"name": "b", "count": 2},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `{"count":1,"name":"a"}` + "\n" + `{"count":2,"name":"b"}` + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("NDJSON output = %q, want %q", got, want)
+	}
+}
+
+func TestNDJSONSingleValueAndRaw(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NDJSON(&buf, map[string]string{"name": "a"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := NDJSON(&buf, json.RawMessage(`[{"n": 1}, {"n": 2}]`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var empty []string
+	if err := NDJSON(&buf, empty); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `{"name":"a"}` + "\n" + `{"n":1}` + "\n" + `{"n":2}` + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("NDJSON output = %q, want %q", got, want)
+	}
+}
+
+func TestJSONWithNDJSONFormat(t *testing.T) {
+	SetFormat(FormatNDJSON)
+	defer SetFormat(FormatText)
+	withShaping(t, Shaping{JQ: ".name"})
+
+	var buf bytes.Buffer
+	if err := JSON(&buf, []map[string]string{{"name": "a"}, {"name": "b"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := buf.String(), "a\nb\n"; got != want {
+		t.Errorf("shaped NDJSON output = %q, want %q", got, want)
+	}
+}
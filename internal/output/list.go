@@ -41,12 +41,31 @@ func (lw *ListWriter) Flush() {
 
 // FlushWithFooter renders the table and appends a footer line (e.g. "Total: 5 items").
 // Pass an empty string to omit the footer.
+//
+// With --output=csv or tsv, the table is written as delimited rows without
+// the separator or footer; with --output=markdown, as a markdown table.
 func (lw *ListWriter) FlushWithFooter(footer string) {
 	colCount := len(lw.headers)
 	if colCount == 0 {
 		return
 	}
 
+	switch activeFormat {
+	case FormatCSV:
+		writeDelimited(lw.w, ',', lw.headers, lw.rows)
+		return
+	case FormatTSV:
+		writeDelimited(lw.w, '\t', lw.headers, lw.rows)
+		return
+	case FormatMarkdown:
+		writeMarkdownTable(lw.w, lw.headers, lw.rows)
+		if footer != "" {
+			fmt.Fprintln(lw.w)
+			fmt.Fprintln(lw.w, footer)
+		}
+		return
+	}
+
 	// Compute column widths: max of header and all row values.
 	widths := make([]int, colCount)
 	for i, h := range lw.headers {
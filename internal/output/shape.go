@@ -0,0 +1,370 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/itchyny/gojq"
+)
+
+// Shaping controls how JSON output is post-processed before it is written.
+// It is set once per command from the global --json, --jq and --template
+// flags, and applies to every value passed to JSON.
+type Shaping struct {
+	Fields   []string // restrict objects to these keys (--json)
+	JQ       string   // jq expression to filter the output through (--jq)
+	Template string   // Go template to render the output with (--template)
+}
+
+// activeShaping holds the compiled form of the current Shaping.
+var activeShaping struct {
+	fields []string
+	jq     *gojq.Code
+	tmpl   *template.Template
+}
+
+// SetShaping compiles s and applies it to subsequent JSON calls. Passing the
+// zero Shaping restores plain JSON output. Returns an error if the jq
+// expression or template is invalid, or if both are given.
+func SetShaping(s Shaping) error {
+	activeShaping.fields = nil
+	activeShaping.jq = nil
+	activeShaping.tmpl = nil
+
+	if s.JQ != "" && s.Template != "" {
+		return fmt.Errorf("--jq and --template cannot be used together")
+	}
+
+	var code *gojq.Code
+	if s.JQ != "" {
+		query, err := gojq.Parse(s.JQ)
+		if err == nil {
+			code, err = gojq.Compile(query)
+		}
+		if err != nil {
+			return fmt.Errorf("invalid --jq expression: %w", err)
+		}
+	}
+
+	var tmpl *template.Template
+	if s.Template != "" {
+		var err error
+		tmpl, err = template.New("output").Funcs(templateFuncs(nil)).Parse(s.Template)
+		if err != nil {
+			return fmt.Errorf("invalid --template: %w", err)
+		}
+	}
+
+	for _, f := range s.Fields {
+		if f = strings.TrimSpace(f); f != "" {
+			activeShaping.fields = append(activeShaping.fields, f)
+		}
+	}
+	activeShaping.jq = code
+	activeShaping.tmpl = tmpl
+	return nil
+}
+
+// Shaped reports whether JSON output is currently being shaped.
+func Shaped() bool {
+	return len(activeShaping.fields) > 0 || activeShaping.jq != nil || activeShaping.tmpl != nil
+}
+
+// writeShaped applies the active shaping to v and writes the result to w.
+func writeShaped(w io.Writer, v any, line bool) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("formatting JSON output: %w", err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var decoded any
+	if err := dec.Decode(&decoded); err != nil {
+		return fmt.Errorf("formatting JSON output: %w", err)
+	}
+
+	if len(activeShaping.fields) > 0 {
+		decoded, err = selectFields(decoded, activeShaping.fields)
+		if err != nil {
+			return err
+		}
+	}
+
+	switch {
+	case activeShaping.jq != nil:
+		return writeJQ(w, activeShaping.jq, decoded, line)
+	case activeShaping.tmpl != nil:
+		return writeTemplate(w, activeShaping.tmpl, decoded)
+	}
+	return writeStructured(w, decoded, line)
+}
+
+// selectFields restricts an object, or each object in an array, to the
+// given keys. A field that no object has is reported along with the fields
+// that are available, so typos don't silently produce empty output.
+func selectFields(v any, fields []string) (any, error) {
+	var objects []map[string]any
+	switch node := v.(type) {
+	case map[string]any:
+		objects = []map[string]any{node}
+	case []any:
+		for _, item := range node {
+			obj, ok := item.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("--json can only select fields from objects")
+			}
+			objects = append(objects, obj)
+		}
+	default:
+		return nil, fmt.Errorf("--json can only select fields from objects")
+	}
+	if len(objects) == 0 {
+		return v, nil
+	}
+
+	available := map[string]bool{}
+	for _, obj := range objects {
+		for k := range obj {
+			available[k] = true
+		}
+	}
+	for _, f := range fields {
+		if !available[f] {
+			keys := make([]string, 0, len(available))
+			for k := range available {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			return nil, fmt.Errorf("unknown JSON field %q — available fields: %s", f, strings.Join(keys, ", "))
+		}
+	}
+
+	selected := make([]any, len(objects))
+	for i, obj := range objects {
+		out := make(map[string]any, len(fields))
+		for _, f := range fields {
+			if val, ok := obj[f]; ok {
+				out[f] = val
+			}
+		}
+		selected[i] = out
+	}
+	if _, ok := v.(map[string]any); ok {
+		return selected[0], nil
+	}
+	return selected, nil
+}
+
+// writeJQ runs a compiled jq expression over v. String results are written
+// raw, as with jq -r; everything else is written as indented JSON (a
+// compact line if line is set, or YAML with --output=yaml).
+func writeJQ(w io.Writer, code *gojq.Code, v any, line bool) error {
+	iter := code.Run(v)
+	for {
+		result, ok := iter.Next()
+		if !ok {
+			return nil
+		}
+		if err, ok := result.(error); ok {
+			var haltErr *gojq.HaltError
+			if errors.As(err, &haltErr) && haltErr.Value() == nil {
+				return nil
+			}
+			return fmt.Errorf("evaluating --jq expression: %w", err)
+		}
+		if s, ok := result.(string); ok {
+			if _, err := fmt.Fprintln(w, s); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := writeStructured(w, result, line); err != nil {
+			return err
+		}
+	}
+}
+
+// writeTemplate renders v with a parsed template. Rows collected with
+// tablerow are aligned and written once the template has finished.
+func writeTemplate(w io.Writer, tmpl *template.Template, v any) error {
+	table := &templateTable{}
+	clone, err := tmpl.Clone()
+	if err != nil {
+		return fmt.Errorf("executing template: %w", err)
+	}
+	clone.Funcs(templateFuncs(table))
+
+	var buf bytes.Buffer
+	if err := clone.Execute(&buf, v); err != nil {
+		return fmt.Errorf("executing template: %w", err)
+	}
+	table.flush(&buf)
+	_, err = w.Write(buf.Bytes())
+	return err
+}
+
+// templateTable accumulates rows from tablerow for column alignment.
+type templateTable struct {
+	rows [][]string
+}
+
+func (t *templateTable) flush(w io.Writer) {
+	if len(t.rows) == 0 {
+		return
+	}
+	var widths []int
+	for _, row := range t.rows {
+		for i, cell := range row {
+			if i >= len(widths) {
+				widths = append(widths, 0)
+			}
+			widths[i] = max(widths[i], ansi.StringWidth(cell))
+		}
+	}
+	for _, row := range t.rows {
+		var b strings.Builder
+		for i, cell := range row {
+			b.WriteString(cell)
+			if i < len(row)-1 {
+				b.WriteString(strings.Repeat(" ", widths[i]-ansi.StringWidth(cell)+4))
+			}
+		}
+		fmt.Fprintln(w, b.String())
+	}
+	t.rows = nil
+}
+
+// templateFuncs returns the helper functions available to --template.
+// table may be nil when the functions are only needed for parsing.
+func templateFuncs(table *templateTable) template.FuncMap {
+	return template.FuncMap{
+		"color": templateColor,
+		"timeago": func(v any) (string, error) {
+			t, ok, err := templateTime(v)
+			if err != nil || !ok {
+				return "", err
+			}
+			return FormatTimeAgo(t), nil
+		},
+		"issueRef": templateIssueRef,
+		"tablerow": func(cells ...any) string {
+			if table != nil {
+				row := make([]string, len(cells))
+				for i, c := range cells {
+					row[i] = templateString(c)
+				}
+				table.rows = append(table.rows, row)
+			}
+			return ""
+		},
+		"tablerender": func() string {
+			if table == nil {
+				return ""
+			}
+			var buf bytes.Buffer
+			table.flush(&buf)
+			return buf.String()
+		},
+	}
+}
+
+// templateColors maps color names accepted by the color template function
+// to the formatters used elsewhere in zh's output.
+var templateColors = map[string]func(string) string{
+	"green":  Green,
+	"red":    Red,
+	"yellow": Yellow,
+	"cyan":   Cyan,
+	"dim":    Dim,
+	"bold":   Bold,
+}
+
+func templateColor(name string, v any) (string, error) {
+	fn, ok := templateColors[strings.ToLower(name)]
+	if !ok {
+		names := make([]string, 0, len(templateColors))
+		for n := range templateColors {
+			names = append(names, n)
+		}
+		slices.Sort(names)
+		return "", fmt.Errorf("unknown color %q — valid colors: %s", name, strings.Join(names, ", "))
+	}
+	return fn(templateString(v)), nil
+}
+
+// templateTime accepts an RFC 3339 string or a time.Time. An empty or nil
+// value yields ok=false so that missing timestamps render as blank.
+func templateTime(v any) (time.Time, bool, error) {
+	switch t := v.(type) {
+	case nil:
+		return time.Time{}, false, nil
+	case time.Time:
+		return t, !t.IsZero(), nil
+	case string:
+		if t == "" {
+			return time.Time{}, false, nil
+		}
+		parsed, err := time.Parse(time.RFC3339, t)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("timeago: %q is not an RFC 3339 timestamp", t)
+		}
+		return parsed, true, nil
+	}
+	return time.Time{}, false, fmt.Errorf("timeago: unsupported value %v", v)
+}
+
+// templateIssueRef formats an issue reference either from an issue object
+// (with number and repository fields) or from a repo name and number:
+//
+//	{{issueRef .}}
+//	{{issueRef "task-tracker" 12}}
+func templateIssueRef(args ...any) (string, error) {
+	refs := NewIssueRefFormatter(nil)
+	switch len(args) {
+	case 1:
+		issue, ok := args[0].(map[string]any)
+		if !ok {
+			return "", fmt.Errorf("issueRef: expected an issue object, got %v", args[0])
+		}
+		number := templateString(issue["number"])
+		repo, _ := issue["repository"].(map[string]any)
+		name, _ := repo["name"].(string)
+		owner, _ := repo["ownerName"].(string)
+		if name == "" || number == "" {
+			return "", fmt.Errorf("issueRef: object has no repository name or number")
+		}
+		return refs.FormatRef(owner, name, templateInt(number)), nil
+	case 2:
+		return refs.FormatRef("", templateString(args[0]), templateInt(templateString(args[1]))), nil
+	}
+	return "", fmt.Errorf("issueRef: expected an issue object, or a repo name and number")
+}
+
+// templateString renders a template value as text. Numbers decoded from
+// JSON keep their original form rather than being printed as floats.
+func templateString(v any) string {
+	switch s := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return s
+	case json.Number:
+		return s.String()
+	}
+	return fmt.Sprint(v)
+}
+
+func templateInt(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
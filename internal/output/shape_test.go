@@ -0,0 +1,153 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func withShaping(t *testing.T, s Shaping) {
+	t.Helper()
+	if err := SetShaping(s); err != nil {
+		t.Fatalf("SetShaping: %v", err)
+	}
+	t.Cleanup(func() { _ = SetShaping(Shaping{}) })
+}
+
+func TestJSONFields(t *testing.T) {
+	withShaping(t, Shaping{Fields: []string{"name", "count"}})
+
+	var buf bytes.Buffer
+	err := JSON(&buf, map[string]any{"name": "Sprint 42", "state": "active", "count": 12})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := buf.String()
+	if !strings.Contains(got, `"name": "Sprint 42"`) || !strings.Contains(got, `"count": 12`) {
+		t.Errorf("expected selected fields, got:\n%s", got)
+	}
+	if strings.Contains(got, "state") {
+		t.Errorf("unselected field should be omitted, got:\n%s", got)
+	}
+}
+
+func TestJSONFieldsUnknown(t *testing.T) {
+	withShaping(t, Shaping{Fields: []string{"nmae"}})
+
+	err := JSON(&bytes.Buffer{}, []map[string]string{{"name": "a", "state": "open"}})
+	if err == nil {
+		t.Fatal("expected error for unknown field")
+	}
+	if !strings.Contains(err.Error(), "available fields: name, state") {
+		t.Errorf("error should list available fields, got: %v", err)
+	}
+}
+
+func TestJSONFieldsEmptyList(t *testing.T) {
+	withShaping(t, Shaping{Fields: []string{"name"}})
+
+	var buf bytes.Buffer
+	if err := JSON(&buf, []map[string]string{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := strings.TrimSpace(buf.String()); got != "[]" {
+		t.Errorf("expected empty array, got %q", got)
+	}
+}
+
+func TestJSONJQ(t *testing.T) {
+	withShaping(t, Shaping{JQ: `.[] | {n: .number, t: .title}, .title`})
+
+	var buf bytes.Buffer
+	err := JSON(&buf, []map[string]any{{"number": 123456789, "title": "Fix login"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := buf.String()
+	if !strings.Contains(got, `"n": 123456789`) {
+		t.Errorf("large numbers should keep their form, got:\n%s", got)
+	}
+	if !strings.HasSuffix(got, "\nFix login\n") {
+		t.Errorf("string results should be written raw, got:\n%s", got)
+	}
+}
+
+func TestJSONJQRuntimeError(t *testing.T) {
+	withShaping(t, Shaping{JQ: `.foo`})
+
+	err := JSON(&bytes.Buffer{}, []string{"a"})
+	if err == nil || !strings.Contains(err.Error(), "evaluating --jq expression") {
+		t.Errorf("expected jq evaluation error, got: %v", err)
+	}
+}
+
+func TestJSONTemplate(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	withShaping(t, Shaping{Template: `{{range .}}{{tablerow (issueRef .) (color "green" .title) .estimate}}{{end}}`})
+
+	var buf bytes.Buffer
+	err := JSON(&buf, []map[string]any{
+		{"number": 1, "title": "Fix login", "estimate": 3, "repository": map[string]any{"name": "task-tracker", "ownerName": "dlakehammond"}},
+		{"number": 12, "title": "Add tests", "estimate": 5, "repository": map[string]any{"name": "api", "ownerName": "dlakehammond"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "task-tracker#1    Fix login    3\n" +
+		"api#12            Add tests    5\n"
+	if got := buf.String(); got != want {
+		t.Errorf("template output =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestTemplateTimeAgo(t *testing.T) {
+	withShaping(t, Shaping{Template: `{{timeago .updatedAt}}|{{timeago .missing}}`})
+
+	var buf bytes.Buffer
+	updated := time.Now().Add(-3 * time.Hour).UTC().Format(time.RFC3339)
+	if err := JSON(&buf, map[string]any{"updatedAt": updated}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := buf.String(); got != "3h ago|" {
+		t.Errorf("timeago output = %q, want %q", got, "3h ago|")
+	}
+}
+
+func TestTemplateIssueRefArgs(t *testing.T) {
+	withShaping(t, Shaping{Template: `{{issueRef .repo .number}}`})
+
+	var buf bytes.Buffer
+	if err := JSON(&buf, map[string]any{"repo": "task-tracker", "number": 7}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := buf.String(); got != "task-tracker#7" {
+		t.Errorf("issueRef output = %q, want %q", got, "task-tracker#7")
+	}
+}
+
+func TestTemplateUnknownColor(t *testing.T) {
+	withShaping(t, Shaping{Template: `{{color "mauve" .}}`})
+
+	err := JSON(&bytes.Buffer{}, "x")
+	if err == nil || !strings.Contains(err.Error(), `unknown color "mauve"`) {
+		t.Errorf("expected unknown color error, got: %v", err)
+	}
+}
+
+func TestSetShapingErrors(t *testing.T) {
+	defer func() { _ = SetShaping(Shaping{}) }()
+
+	if err := SetShaping(Shaping{JQ: ".", Template: "{{.}}"}); err == nil {
+		t.Error("expected error when combining --jq and --template")
+	}
+	if err := SetShaping(Shaping{Fields: []string{"name"}, JQ: ".["}); err == nil {
+		t.Error("expected error for invalid jq expression")
+	}
+	if err := SetShaping(Shaping{Template: "{{.name"}); err == nil {
+		t.Error("expected error for invalid template")
+	}
+	if Shaped() {
+		t.Error("a failed SetShaping should leave output unshaped")
+	}
+}
@@ -1,6 +1,7 @@
 package resolve
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -80,12 +81,12 @@ const listLegacyEpicsQuery = `query ListLegacyEpics($workspaceId: ID!, $first: I
 // the cache. It combines the zenhubEpics query (which returns all standalone
 // epics) with the workspace.epics query (which returns legacy issue-backed
 // epics). Results are deduplicated by ID.
-func FetchEpics(client *api.Client, workspaceID string) ([]CachedEpic, error) {
+func FetchEpics(ctx context.Context, client *api.Client, workspaceID string) ([]CachedEpic, error) {
 	seen := make(map[string]bool)
 	var allEpics []CachedEpic
 
 	// 1. Fetch all ZenHub epics via the dedicated query.
-	zenhubEpics, err := fetchZenhubEpics(client, workspaceID)
+	zenhubEpics, err := fetchZenhubEpics(ctx, client, workspaceID)
 	if err != nil {
 		return nil, err
 	}
@@ -95,7 +96,7 @@ func FetchEpics(client *api.Client, workspaceID string) ([]CachedEpic, error) {
 	}
 
 	// 2. Fetch legacy epics via the dedicated query.
-	legacyEpics, err := fetchLegacyEpics(client, workspaceID)
+	legacyEpics, err := fetchLegacyEpics(ctx, client, workspaceID)
 	if err != nil {
 		return nil, err
 	}
@@ -111,7 +112,7 @@ func FetchEpics(client *api.Client, workspaceID string) ([]CachedEpic, error) {
 }
 
 // fetchZenhubEpics fetches all standalone ZenHub epics via workspace.zenhubEpics.
-func fetchZenhubEpics(client *api.Client, workspaceID string) ([]CachedEpic, error) {
+func fetchZenhubEpics(ctx context.Context, client *api.Client, workspaceID string) ([]CachedEpic, error) {
 	var result []CachedEpic
 	var cursor *string
 
@@ -124,7 +125,7 @@ func fetchZenhubEpics(client *api.Client, workspaceID string) ([]CachedEpic, err
 			vars["after"] = *cursor
 		}
 
-		data, err := client.Execute(listZenhubEpicsQuery, vars)
+		data, err := client.Execute(ctx, listZenhubEpicsQuery, vars)
 		if err != nil {
 			return nil, exitcode.General("fetching zenhub epics", err)
 		}
@@ -165,7 +166,7 @@ func fetchZenhubEpics(client *api.Client, workspaceID string) ([]CachedEpic, err
 }
 
 // fetchLegacyEpics fetches legacy (issue-backed) epics via workspace.epics.
-func fetchLegacyEpics(client *api.Client, workspaceID string) ([]CachedEpic, error) {
+func fetchLegacyEpics(ctx context.Context, client *api.Client, workspaceID string) ([]CachedEpic, error) {
 	var result []CachedEpic
 	var cursor *string
 
@@ -178,7 +179,7 @@ func fetchLegacyEpics(client *api.Client, workspaceID string) ([]CachedEpic, err
 			vars["after"] = *cursor
 		}
 
-		data, err := client.Execute(listLegacyEpicsQuery, vars)
+		data, err := client.Execute(ctx, listLegacyEpicsQuery, vars)
 		if err != nil {
 			return nil, exitcode.General("fetching legacy epics", err)
 		}
@@ -237,7 +238,7 @@ func FetchEpicsIntoCache(entries []CachedEpic, workspaceID string) error {
 // then attempts resolution by exact ID, exact title (case-insensitive), unique
 // title substring, and owner/repo#number for legacy epics, using the cache
 // with invalidate-on-miss semantics.
-func Epic(client *api.Client, workspaceID string, identifier string, aliases map[string]string) (*EpicResult, error) {
+func Epic(ctx context.Context, client *api.Client, workspaceID string, identifier string, aliases map[string]string) (*EpicResult, error) {
 	// Check aliases first — an alias maps to an epic ID or title, which we
 	// then resolve normally.
 	if aliases != nil {
@@ -260,7 +261,7 @@ func Epic(client *api.Client, workspaceID string, identifier string, aliases map
 	}
 
 	// Cache miss — refresh from API
-	entries, err := FetchEpics(client, workspaceID)
+	entries, err := FetchEpics(ctx, client, workspaceID)
 	if err != nil {
 		return nil, err
 	}
@@ -1,6 +1,7 @@
 package resolve
 
 import (
+	"context"
 	"testing"
 
 	"github.com/dslh/zh/internal/api"
@@ -28,7 +29,7 @@ func testEpics() []CachedEpic {
 func TestEpicResolveByID(t *testing.T) {
 	setupEpicCache(t, "ws1", testEpics())
 
-	result, err := Epic(nil, "ws1", "e2", nil)
+	result, err := Epic(context.Background(), nil, "ws1", "e2", nil)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -43,7 +44,7 @@ func TestEpicResolveByID(t *testing.T) {
 func TestEpicResolveByExactTitle(t *testing.T) {
 	setupEpicCache(t, "ws1", testEpics())
 
-	result, err := Epic(nil, "ws1", "Mobile App Phase 2", nil)
+	result, err := Epic(context.Background(), nil, "ws1", "Mobile App Phase 2", nil)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -58,7 +59,7 @@ func TestEpicResolveByExactTitle(t *testing.T) {
 func TestEpicResolveByExactTitleCaseInsensitive(t *testing.T) {
 	setupEpicCache(t, "ws1", testEpics())
 
-	result, err := Epic(nil, "ws1", "mobile app phase 2", nil)
+	result, err := Epic(context.Background(), nil, "ws1", "mobile app phase 2", nil)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -70,7 +71,7 @@ func TestEpicResolveByExactTitleCaseInsensitive(t *testing.T) {
 func TestEpicResolveByUniqueSubstring(t *testing.T) {
 	setupEpicCache(t, "ws1", testEpics())
 
-	result, err := Epic(nil, "ws1", "Rate Limiting", nil)
+	result, err := Epic(context.Background(), nil, "ws1", "Rate Limiting", nil)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -88,7 +89,7 @@ func TestEpicResolveByAlias(t *testing.T) {
 	}
 
 	// Alias to title
-	result, err := Epic(nil, "ws1", "auth", aliases)
+	result, err := Epic(context.Background(), nil, "ws1", "auth", aliases)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -97,7 +98,7 @@ func TestEpicResolveByAlias(t *testing.T) {
 	}
 
 	// Alias to ID
-	result, err = Epic(nil, "ws1", "q1", aliases)
+	result, err = Epic(context.Background(), nil, "ws1", "q1", aliases)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -110,7 +111,7 @@ func TestEpicResolveByRepoNumber(t *testing.T) {
 	setupEpicCache(t, "ws1", testEpics())
 
 	// Short form: repo#number
-	result, err := Epic(nil, "ws1", "mpt#42", nil)
+	result, err := Epic(context.Background(), nil, "ws1", "mpt#42", nil)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -119,7 +120,7 @@ func TestEpicResolveByRepoNumber(t *testing.T) {
 	}
 
 	// Long form: owner/repo#number
-	result, err = Epic(nil, "ws1", "gohiring/api#15", nil)
+	result, err = Epic(context.Background(), nil, "ws1", "gohiring/api#15", nil)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -135,7 +136,7 @@ func TestEpicResolveAmbiguous(t *testing.T) {
 	}
 	setupEpicCache(t, "ws1", epics)
 
-	_, err := Epic(nil, "ws1", "Platform", nil)
+	_, err := Epic(context.Background(), nil, "ws1", "Platform", nil)
 	if err == nil {
 		t.Fatal("expected ambiguous error, got nil")
 	}
@@ -204,7 +205,7 @@ func TestEpicResolveNotFoundRefreshesCache(t *testing.T) {
 
 	client := api.New("test-key", api.WithEndpoint(ms.URL()))
 
-	result, err := Epic(client, "ws1", "New Epic", nil)
+	result, err := Epic(context.Background(), client, "ws1", "New Epic", nil)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -261,7 +262,7 @@ func TestEpicResolveNotFoundAfterRefresh(t *testing.T) {
 
 	client := api.New("test-key", api.WithEndpoint(ms.URL()))
 
-	_, err := Epic(client, "ws1", "nonexistent", nil)
+	_, err := Epic(context.Background(), client, "ws1", "nonexistent", nil)
 	if err == nil {
 		t.Fatal("expected not found error, got nil")
 	}
@@ -323,7 +324,7 @@ func TestFetchEpics(t *testing.T) {
 
 	client := api.New("test-key", api.WithEndpoint(ms.URL()))
 
-	epics, err := FetchEpics(client, "ws1")
+	epics, err := FetchEpics(context.Background(), client, "ws1")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -398,7 +399,7 @@ func TestFetchEpicsDeduplicates(t *testing.T) {
 
 	client := api.New("test-key", api.WithEndpoint(ms.URL()))
 
-	epics, err := FetchEpics(client, "ws1")
+	epics, err := FetchEpics(context.Background(), client, "ws1")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1,6 +1,7 @@
 package resolve
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"regexp"
@@ -98,7 +99,7 @@ type IssueOptions struct {
 //
 // The client parameter is for the ZenHub API. Options provide additional
 // context like --repo flag and GitHub client.
-func Issue(client *api.Client, workspaceID string, identifier string, opts *IssueOptions) (*IssueResult, error) {
+func Issue(ctx context.Context, client *api.Client, workspaceID string, identifier string, opts *IssueOptions) (*IssueResult, error) {
 	if opts == nil {
 		opts = &IssueOptions{}
 	}
@@ -107,14 +108,14 @@ func Issue(client *api.Client, workspaceID string, identifier string, opts *Issu
 	if err != nil {
 		// If parsing fails and we have a repo flag + GitHub client, try branch name
 		if opts.RepoFlag != "" && opts.GitHubClient != nil {
-			return resolveByBranch(client, workspaceID, identifier, opts)
+			return resolveByBranch(ctx, client, workspaceID, identifier, opts)
 		}
 		return nil, err
 	}
 
 	// ZenHub ID — query directly
 	if parsed.ZenHubID != "" {
-		return resolveByZenHubID(client, workspaceID, parsed.ZenHubID)
+		return resolveByZenHubID(ctx, client, workspaceID, parsed.ZenHubID)
 	}
 
 	// Bare number — requires --repo
@@ -123,11 +124,11 @@ func Issue(client *api.Client, workspaceID string, identifier string, opts *Issu
 			return nil, exitcode.Usage(fmt.Sprintf("bare issue number %d requires --repo flag", parsed.Number))
 		}
 		// Resolve the repo flag to get owner/name
-		repo, err := LookupRepoWithRefresh(client, workspaceID, opts.RepoFlag)
+		repo, err := LookupRepoWithRefresh(ctx, client, workspaceID, opts.RepoFlag)
 		if err != nil {
 			return nil, err
 		}
-		return resolveByRepoAndNumber(client, workspaceID, repo, parsed.Number)
+		return resolveByRepoAndNumber(ctx, client, workspaceID, repo, parsed.Number)
 	}
 
 	// repo#number or owner/repo#number
@@ -135,16 +136,16 @@ func Issue(client *api.Client, workspaceID string, identifier string, opts *Issu
 	if parsed.Owner != "" {
 		repoID = parsed.Owner + "/" + parsed.Repo
 	}
-	repo, err := LookupRepoWithRefresh(client, workspaceID, repoID)
+	repo, err := LookupRepoWithRefresh(ctx, client, workspaceID, repoID)
 	if err != nil {
 		return nil, err
 	}
-	return resolveByRepoAndNumber(client, workspaceID, repo, parsed.Number)
+	return resolveByRepoAndNumber(ctx, client, workspaceID, repo, parsed.Number)
 }
 
 // LookupRepoWithRefresh resolves a repo identifier using cache with
 // invalidate-on-miss. The identifier can be "repo" or "owner/repo".
-func LookupRepoWithRefresh(client *api.Client, workspaceID string, identifier string) (*CachedRepo, error) {
+func LookupRepoWithRefresh(ctx context.Context, client *api.Client, workspaceID string, identifier string) (*CachedRepo, error) {
 	key := RepoCacheKey(workspaceID)
 
 	// Try cache first
@@ -160,7 +161,7 @@ func LookupRepoWithRefresh(client *api.Client, workspaceID string, identifier st
 	}
 
 	// Cache miss — refresh from API
-	entries, err := FetchRepos(client, workspaceID)
+	entries, err := FetchRepos(ctx, client, workspaceID)
 	if err != nil {
 		return nil, err
 	}
@@ -182,8 +183,8 @@ const issueByInfoQuery = `query IssueByInfo($repositoryGhId: Int!, $issueNumber:
 
 // resolveByRepoAndNumber resolves an issue by its repo and number using
 // the issueByInfo query.
-func resolveByRepoAndNumber(client *api.Client, workspaceID string, repo *CachedRepo, number int) (*IssueResult, error) {
-	data, err := client.Execute(issueByInfoQuery, map[string]any{
+func resolveByRepoAndNumber(ctx context.Context, client *api.Client, workspaceID string, repo *CachedRepo, number int) (*IssueResult, error) {
+	data, err := client.Execute(ctx, issueByInfoQuery, map[string]any{
 		"repositoryGhId": repo.GhID,
 		"issueNumber":    number,
 	})
@@ -238,8 +239,8 @@ const issueByNodeQuery = `query IssueByNode($id: ID!) {
 }`
 
 // resolveByZenHubID resolves an issue by its ZenHub node ID.
-func resolveByZenHubID(client *api.Client, workspaceID string, zenHubID string) (*IssueResult, error) {
-	data, err := client.Execute(issueByNodeQuery, map[string]any{
+func resolveByZenHubID(ctx context.Context, client *api.Client, workspaceID string, zenHubID string) (*IssueResult, error) {
+	data, err := client.Execute(ctx, issueByNodeQuery, map[string]any{
 		"id": zenHubID,
 	})
 	if err != nil {
@@ -288,13 +289,13 @@ const githubPRByBranchQuery = `query PRByBranch($owner: String!, $repo: String!,
 
 // resolveByBranch resolves an issue by branch name using GitHub API.
 // The branch name is looked up as a PR head ref in the given repo.
-func resolveByBranch(client *api.Client, workspaceID string, branchName string, opts *IssueOptions) (*IssueResult, error) {
-	repo, err := LookupRepoWithRefresh(client, workspaceID, opts.RepoFlag)
+func resolveByBranch(ctx context.Context, client *api.Client, workspaceID string, branchName string, opts *IssueOptions) (*IssueResult, error) {
+	repo, err := LookupRepoWithRefresh(ctx, client, workspaceID, opts.RepoFlag)
 	if err != nil {
 		return nil, err
 	}
 
-	data, err := opts.GitHubClient.Execute(githubPRByBranchQuery, map[string]any{
+	data, err := opts.GitHubClient.Execute(ctx, githubPRByBranchQuery, map[string]any{
 		"owner": repo.OwnerName,
 		"repo":  repo.Name,
 		"head":  branchName,
@@ -321,7 +322,7 @@ func resolveByBranch(client *api.Client, workspaceID string, branchName string,
 		return nil, exitcode.NotFoundError(fmt.Sprintf("no PR found for branch %q in %s/%s", branchName, repo.OwnerName, repo.Name))
 	}
 
-	return resolveByRepoAndNumber(client, workspaceID, repo, prs[0].Number)
+	return resolveByRepoAndNumber(ctx, client, workspaceID, repo, prs[0].Number)
 }
 
 // Ref returns the short reference string (repo#number) for this resolved issue.
@@ -1,6 +1,7 @@
 package resolve
 
 import (
+	"context"
 	"testing"
 
 	"github.com/dslh/zh/internal/api"
@@ -227,7 +228,7 @@ func TestIssueResolveByRepoNumber(t *testing.T) {
 
 	client := api.New("test-key", api.WithEndpoint(ms.URL()))
 
-	result, err := Issue(client, "ws1", "task-tracker#3", nil)
+	result, err := Issue(context.Background(), client, "ws1", "task-tracker#3", nil)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -250,7 +251,7 @@ func TestIssueResolveByOwnerRepoNumber(t *testing.T) {
 
 	client := api.New("test-key", api.WithEndpoint(ms.URL()))
 
-	result, err := Issue(client, "ws1", "dlakehammond/task-tracker#3", nil)
+	result, err := Issue(context.Background(), client, "ws1", "dlakehammond/task-tracker#3", nil)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -268,7 +269,7 @@ func TestIssueResolveByZenHubID(t *testing.T) {
 
 	client := api.New("test-key", api.WithEndpoint(ms.URL()))
 
-	result, err := Issue(client, "ws1", "Z2lkOi8vcmFwdG9yL0lzc3VlLzEyMzQ1", nil)
+	result, err := Issue(context.Background(), client, "ws1", "Z2lkOi8vcmFwdG9yL0lzc3VlLzEyMzQ1", nil)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -288,7 +289,7 @@ func TestIssueResolveByBareNumberWithRepoFlag(t *testing.T) {
 
 	client := api.New("test-key", api.WithEndpoint(ms.URL()))
 
-	result, err := Issue(client, "ws1", "42", &IssueOptions{RepoFlag: "task-tracker"})
+	result, err := Issue(context.Background(), client, "ws1", "42", &IssueOptions{RepoFlag: "task-tracker"})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -304,7 +305,7 @@ func TestIssueResolveBareNumberWithoutRepoFlag(t *testing.T) {
 	ms := testutil.NewMockServer(t)
 	client := api.New("test-key", api.WithEndpoint(ms.URL()))
 
-	_, err := Issue(client, "ws1", "42", nil)
+	_, err := Issue(context.Background(), client, "ws1", "42", nil)
 	if err == nil {
 		t.Fatal("expected error for bare number without --repo, got nil")
 	}
@@ -337,7 +338,7 @@ func TestIssueResolveRepoNotFound(t *testing.T) {
 
 	client := api.New("test-key", api.WithEndpoint(ms.URL()))
 
-	_, err := Issue(client, "ws1", "nonexistent#1", nil)
+	_, err := Issue(context.Background(), client, "ws1", "nonexistent#1", nil)
 	if err == nil {
 		t.Fatal("expected error for repo not found, got nil")
 	}
@@ -358,7 +359,7 @@ func TestIssueResolveIssueNotFound(t *testing.T) {
 
 	client := api.New("test-key", api.WithEndpoint(ms.URL()))
 
-	_, err := Issue(client, "ws1", "task-tracker#9999", nil)
+	_, err := Issue(context.Background(), client, "ws1", "task-tracker#9999", nil)
 	if err == nil {
 		t.Fatal("expected error for issue not found, got nil")
 	}
@@ -415,7 +416,7 @@ func TestIssueResolveRepoRefreshesOnMiss(t *testing.T) {
 	client := api.New("test-key", api.WithEndpoint(ms.URL()))
 
 	// recipe-book is not in cache, should trigger refresh
-	result, err := Issue(client, "ws1", "recipe-book#1", nil)
+	result, err := Issue(context.Background(), client, "ws1", "recipe-book#1", nil)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -448,7 +449,7 @@ func TestIssueResolveByBranchName(t *testing.T) {
 	zhClient := api.New("test-key", api.WithEndpoint(zhMs.URL()))
 	ghClient := gh.New("pat", "test-token", gh.WithEndpoint(ghMs.URL()))
 
-	result, err := Issue(zhClient, "ws1", "fix-login-bug", &IssueOptions{
+	result, err := Issue(context.Background(), zhClient, "ws1", "fix-login-bug", &IssueOptions{
 		RepoFlag:     "task-tracker",
 		GitHubClient: ghClient,
 	})
@@ -478,7 +479,7 @@ func TestIssueResolveByBranchNameNoPRFound(t *testing.T) {
 	zhClient := api.New("test-key", api.WithEndpoint(zhMs.URL()))
 	ghClient := gh.New("pat", "test-token", gh.WithEndpoint(ghMs.URL()))
 
-	_, err := Issue(zhClient, "ws1", "nonexistent-branch", &IssueOptions{
+	_, err := Issue(context.Background(), zhClient, "ws1", "nonexistent-branch", &IssueOptions{
 		RepoFlag:     "task-tracker",
 		GitHubClient: ghClient,
 	})
@@ -500,7 +501,7 @@ func TestIssueResolveAmbiguousRepo(t *testing.T) {
 	ms := testutil.NewMockServer(t)
 	client := api.New("test-key", api.WithEndpoint(ms.URL()))
 
-	_, err := Issue(client, "ws1", "my-app#1", nil)
+	_, err := Issue(context.Background(), client, "ws1", "my-app#1", nil)
 	if err == nil {
 		t.Fatal("expected ambiguous error, got nil")
 	}
@@ -1,6 +1,7 @@
 package resolve
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -43,8 +44,8 @@ const listLabelsQuery = `query GetWorkspaceLabels($workspaceId: ID!) {
 // FetchLabels fetches all labels across all workspace repos from the API and
 // updates the cache. Labels are deduplicated by name (keeping the first
 // occurrence, since labels with the same name across repos are equivalent).
-func FetchLabels(client *api.Client, workspaceID string) ([]CachedLabel, error) {
-	data, err := client.Execute(listLabelsQuery, map[string]any{
+func FetchLabels(ctx context.Context, client *api.Client, workspaceID string) ([]CachedLabel, error) {
+	data, err := client.Execute(ctx, listLabelsQuery, map[string]any{
 		"workspaceId": workspaceID,
 	})
 	if err != nil {
@@ -90,7 +91,7 @@ func LabelCacheKey(workspaceID string) cache.Key {
 
 // Label resolves a label name to a LabelResult using case-insensitive exact
 // match, with invalidate-on-miss caching.
-func Label(client *api.Client, workspaceID string, name string) (*LabelResult, error) {
+func Label(ctx context.Context, client *api.Client, workspaceID string, name string) (*LabelResult, error) {
 	key := LabelCacheKey(workspaceID)
 
 	// Try cache first
@@ -101,7 +102,7 @@ func Label(client *api.Client, workspaceID string, name string) (*LabelResult, e
 	}
 
 	// Cache miss — refresh from API
-	entries, err := FetchLabels(client, workspaceID)
+	entries, err := FetchLabels(ctx, client, workspaceID)
 	if err != nil {
 		return nil, err
 	}
@@ -114,14 +115,14 @@ func Label(client *api.Client, workspaceID string, name string) (*LabelResult, e
 }
 
 // Labels resolves multiple label names, returning results and any errors.
-func Labels(client *api.Client, workspaceID string, names []string) ([]*LabelResult, error) {
+func Labels(ctx context.Context, client *api.Client, workspaceID string, names []string) ([]*LabelResult, error) {
 	key := LabelCacheKey(workspaceID)
 
 	// Ensure cache is populated
 	entries, ok := cache.Get[[]CachedLabel](key)
 	if !ok {
 		var err error
-		entries, err = FetchLabels(client, workspaceID)
+		entries, err = FetchLabels(ctx, client, workspaceID)
 		if err != nil {
 			return nil, err
 		}
@@ -140,7 +141,7 @@ func Labels(client *api.Client, workspaceID string, names []string) ([]*LabelRes
 	if len(notFound) > 0 {
 		// Try refreshing cache and retry not-found ones
 		var err error
-		entries, err = FetchLabels(client, workspaceID)
+		entries, err = FetchLabels(ctx, client, workspaceID)
 		if err != nil {
 			return nil, err
 		}
@@ -6,6 +6,7 @@
 package resolve
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -40,8 +41,8 @@ const listPipelinesQuery = `query ListPipelines($workspaceId: ID!) {
 
 // FetchPipelines fetches all pipelines for a workspace from the API and
 // updates the cache. Returns the cached pipeline entries.
-func FetchPipelines(client *api.Client, workspaceID string) ([]CachedPipeline, error) {
-	data, err := client.Execute(listPipelinesQuery, map[string]any{
+func FetchPipelines(ctx context.Context, client *api.Client, workspaceID string) ([]CachedPipeline, error) {
+	data, err := client.Execute(ctx, listPipelinesQuery, map[string]any{
 		"workspaceId": workspaceID,
 	})
 	if err != nil {
@@ -85,7 +86,7 @@ func GetCachedPipelines(key cache.Key) ([]CachedPipeline, bool) {
 // aliases first, then attempts resolution by exact ID, exact name
 // (case-insensitive), and unique substring match, using the cache with
 // invalidate-on-miss semantics.
-func Pipeline(client *api.Client, workspaceID string, identifier string, aliases map[string]string) (*PipelineResult, error) {
+func Pipeline(ctx context.Context, client *api.Client, workspaceID string, identifier string, aliases map[string]string) (*PipelineResult, error) {
 	// Check aliases first — an alias maps to a pipeline name, which we
 	// then resolve normally.
 	if aliases != nil {
@@ -109,7 +110,7 @@ func Pipeline(client *api.Client, workspaceID string, identifier string, aliases
 	}
 
 	// Cache miss — refresh from API
-	entries, err := FetchPipelines(client, workspaceID)
+	entries, err := FetchPipelines(ctx, client, workspaceID)
 	if err != nil {
 		return nil, err
 	}
@@ -1,6 +1,7 @@
 package resolve
 
 import (
+	"context"
 	"testing"
 
 	"github.com/dslh/zh/internal/api"
@@ -28,7 +29,7 @@ func testPipelines() []CachedPipeline {
 func TestPipelineResolveByID(t *testing.T) {
 	setupPipelineCache(t, "ws1", testPipelines())
 
-	result, err := Pipeline(nil, "ws1", "p2", nil)
+	result, err := Pipeline(context.Background(), nil, "ws1", "p2", nil)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -40,7 +41,7 @@ func TestPipelineResolveByID(t *testing.T) {
 func TestPipelineResolveByExactName(t *testing.T) {
 	setupPipelineCache(t, "ws1", testPipelines())
 
-	result, err := Pipeline(nil, "ws1", "Code Review", nil)
+	result, err := Pipeline(context.Background(), nil, "ws1", "Code Review", nil)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -52,7 +53,7 @@ func TestPipelineResolveByExactName(t *testing.T) {
 func TestPipelineResolveByExactNameCaseInsensitive(t *testing.T) {
 	setupPipelineCache(t, "ws1", testPipelines())
 
-	result, err := Pipeline(nil, "ws1", "code review", nil)
+	result, err := Pipeline(context.Background(), nil, "ws1", "code review", nil)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -64,7 +65,7 @@ func TestPipelineResolveByExactNameCaseInsensitive(t *testing.T) {
 func TestPipelineResolveByUniqueSubstring(t *testing.T) {
 	setupPipelineCache(t, "ws1", testPipelines())
 
-	result, err := Pipeline(nil, "ws1", "Review", nil)
+	result, err := Pipeline(context.Background(), nil, "ws1", "Review", nil)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -81,7 +82,7 @@ func TestPipelineResolveByAlias(t *testing.T) {
 		"cr": "Code Review",
 	}
 
-	result, err := Pipeline(nil, "ws1", "ip", aliases)
+	result, err := Pipeline(context.Background(), nil, "ws1", "ip", aliases)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -97,7 +98,7 @@ func TestPipelineResolveAmbiguous(t *testing.T) {
 	}
 	setupPipelineCache(t, "ws1", pipelines)
 
-	_, err := Pipeline(nil, "ws1", "In", nil)
+	_, err := Pipeline(context.Background(), nil, "ws1", "In", nil)
 	if err == nil {
 		t.Fatal("expected ambiguous error, got nil")
 	}
@@ -143,7 +144,7 @@ func TestPipelineResolveNotFoundRefreshesCache(t *testing.T) {
 
 	client := api.New("test-key", api.WithEndpoint(ms.URL()))
 
-	result, err := Pipeline(client, "ws1", "New Pipeline", nil)
+	result, err := Pipeline(context.Background(), client, "ws1", "New Pipeline", nil)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -180,7 +181,7 @@ func TestPipelineResolveNotFoundAfterRefresh(t *testing.T) {
 
 	client := api.New("test-key", api.WithEndpoint(ms.URL()))
 
-	_, err := Pipeline(client, "ws1", "nonexistent", nil)
+	_, err := Pipeline(context.Background(), client, "ws1", "nonexistent", nil)
 	if err == nil {
 		t.Fatal("expected not found error, got nil")
 	}
@@ -212,7 +213,7 @@ func TestFetchPipelines(t *testing.T) {
 
 	client := api.New("test-key", api.WithEndpoint(ms.URL()))
 
-	pipelines, err := FetchPipelines(client, "ws1")
+	pipelines, err := FetchPipelines(context.Background(), client, "ws1")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
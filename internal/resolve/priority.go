@@ -1,6 +1,7 @@
 package resolve
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -40,8 +41,8 @@ const listPrioritiesQuery = `query GetWorkspacePriorities($workspaceId: ID!) {
 
 // FetchPriorities fetches all priorities for a workspace from the API and
 // updates the cache. Returns the cached priority entries.
-func FetchPriorities(client *api.Client, workspaceID string) ([]CachedPriority, error) {
-	data, err := client.Execute(listPrioritiesQuery, map[string]any{
+func FetchPriorities(ctx context.Context, client *api.Client, workspaceID string) ([]CachedPriority, error) {
+	data, err := client.Execute(ctx, listPrioritiesQuery, map[string]any{
 		"workspaceId": workspaceID,
 	})
 	if err != nil {
@@ -72,7 +73,7 @@ func PriorityCacheKey(workspaceID string) cache.Key {
 // Priority resolves a priority identifier to a PriorityResult. It attempts
 // resolution by exact ID, exact name (case-insensitive), and unique substring
 // match, using the cache with invalidate-on-miss semantics.
-func Priority(client *api.Client, workspaceID string, identifier string) (*PriorityResult, error) {
+func Priority(ctx context.Context, client *api.Client, workspaceID string, identifier string) (*PriorityResult, error) {
 	key := PriorityCacheKey(workspaceID)
 
 	// Try cache first
@@ -87,7 +88,7 @@ func Priority(client *api.Client, workspaceID string, identifier string) (*Prior
 	}
 
 	// Cache miss — refresh from API
-	entries, err := FetchPriorities(client, workspaceID)
+	entries, err := FetchPriorities(ctx, client, workspaceID)
 	if err != nil {
 		return nil, err
 	}
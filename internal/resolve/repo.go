@@ -1,6 +1,7 @@
 package resolve
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -43,7 +44,7 @@ const listReposQuery = `query ListRepos($workspaceId: ID!, $first: Int!, $after:
 
 // FetchRepos fetches all repos for a workspace from the API and updates
 // the cache. Returns the cached repo entries.
-func FetchRepos(client *api.Client, workspaceID string) ([]CachedRepo, error) {
+func FetchRepos(ctx context.Context, client *api.Client, workspaceID string) ([]CachedRepo, error) {
 	var allRepos []CachedRepo
 	var cursor *string
 
@@ -56,7 +57,7 @@ func FetchRepos(client *api.Client, workspaceID string) ([]CachedRepo, error) {
 			vars["after"] = *cursor
 		}
 
-		data, err := client.Execute(listReposQuery, vars)
+		data, err := client.Execute(ctx, listReposQuery, vars)
 		if err != nil {
 			return nil, exitcode.General("fetching repos", err)
 		}
@@ -1,6 +1,7 @@
 package resolve
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -84,7 +85,7 @@ func SprintAccessorsCacheKey(workspaceID string) cache.Key {
 
 // FetchSprints fetches all sprints for a workspace from the API and updates
 // the cache. Returns the cached sprint entries.
-func FetchSprints(client *api.Client, workspaceID string) ([]CachedSprint, error) {
+func FetchSprints(ctx context.Context, client *api.Client, workspaceID string) ([]CachedSprint, error) {
 	var allSprints []CachedSprint
 	var cursor *string
 	var accessors sprintAccessors
@@ -98,7 +99,7 @@ func FetchSprints(client *api.Client, workspaceID string) ([]CachedSprint, error
 			vars["after"] = *cursor
 		}
 
-		data, err := client.Execute(listSprintsQuery, vars)
+		data, err := client.Execute(ctx, listSprintsQuery, vars)
 		if err != nil {
 			return nil, exitcode.General("fetching sprints", err)
 		}
@@ -159,11 +160,11 @@ func FetchSprintsIntoCache(entries []CachedSprint, workspaceID string) error {
 //   - Relative references: "current", "next", "previous"
 //
 // Uses the cache with invalidate-on-miss semantics.
-func Sprint(client *api.Client, workspaceID string, identifier string) (*SprintResult, error) {
+func Sprint(ctx context.Context, client *api.Client, workspaceID string, identifier string) (*SprintResult, error) {
 	// Handle relative references
 	idLower := strings.ToLower(identifier)
 	if idLower == "current" || idLower == "next" || idLower == "previous" {
-		return resolveRelativeSprint(client, workspaceID, idLower)
+		return resolveRelativeSprint(ctx, client, workspaceID, idLower)
 	}
 
 	key := SprintCacheKey(workspaceID)
@@ -180,7 +181,7 @@ func Sprint(client *api.Client, workspaceID string, identifier string) (*SprintR
 	}
 
 	// Cache miss — refresh from API
-	entries, err := FetchSprints(client, workspaceID)
+	entries, err := FetchSprints(ctx, client, workspaceID)
 	if err != nil {
 		return nil, err
 	}
@@ -198,7 +199,7 @@ func Sprint(client *api.Client, workspaceID string, identifier string) (*SprintR
 
 // resolveRelativeSprint resolves "current", "next", or "previous" using
 // cached sprint accessors (or fetching fresh data).
-func resolveRelativeSprint(client *api.Client, workspaceID string, relative string) (*SprintResult, error) {
+func resolveRelativeSprint(ctx context.Context, client *api.Client, workspaceID string, relative string) (*SprintResult, error) {
 	accessorsKey := SprintAccessorsCacheKey(workspaceID)
 	sprintsKey := SprintCacheKey(workspaceID)
 
@@ -209,7 +210,7 @@ func resolveRelativeSprint(client *api.Client, workspaceID string, relative stri
 	if !aOK || !sOK {
 		// Need to fetch fresh data
 		var err error
-		sprints, err = FetchSprints(client, workspaceID)
+		sprints, err = FetchSprints(ctx, client, workspaceID)
 		if err != nil {
 			return nil, err
 		}
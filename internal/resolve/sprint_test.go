@@ -1,6 +1,7 @@
 package resolve
 
 import (
+	"context"
 	"testing"
 
 	"github.com/dslh/zh/internal/api"
@@ -40,7 +41,7 @@ func TestSprintDisplayName(t *testing.T) {
 func TestSprintResolveByID(t *testing.T) {
 	setupSprintCache(t, "ws1", testSprints())
 
-	result, err := Sprint(nil, "ws1", "s3")
+	result, err := Sprint(context.Background(), nil, "ws1", "s3")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -52,7 +53,7 @@ func TestSprintResolveByID(t *testing.T) {
 func TestSprintResolveByExactName(t *testing.T) {
 	setupSprintCache(t, "ws1", testSprints())
 
-	result, err := Sprint(nil, "ws1", "Sprint 46")
+	result, err := Sprint(context.Background(), nil, "ws1", "Sprint 46")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -64,7 +65,7 @@ func TestSprintResolveByExactName(t *testing.T) {
 func TestSprintResolveByCustomName(t *testing.T) {
 	setupSprintCache(t, "ws1", testSprints())
 
-	result, err := Sprint(nil, "ws1", "Q1 Final")
+	result, err := Sprint(context.Background(), nil, "ws1", "Q1 Final")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -76,7 +77,7 @@ func TestSprintResolveByCustomName(t *testing.T) {
 func TestSprintResolveByExactNameCaseInsensitive(t *testing.T) {
 	setupSprintCache(t, "ws1", testSprints())
 
-	result, err := Sprint(nil, "ws1", "sprint 46")
+	result, err := Sprint(context.Background(), nil, "ws1", "sprint 46")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -89,7 +90,7 @@ func TestSprintResolveByGeneratedNameWhenCustomSet(t *testing.T) {
 	setupSprintCache(t, "ws1", testSprints())
 
 	// Sprint 47 has custom name "Q1 Final", but should still match by generated name
-	result, err := Sprint(nil, "ws1", "Sprint 47")
+	result, err := Sprint(context.Background(), nil, "ws1", "Sprint 47")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -101,7 +102,7 @@ func TestSprintResolveByGeneratedNameWhenCustomSet(t *testing.T) {
 func TestSprintResolveByUniqueSubstring(t *testing.T) {
 	setupSprintCache(t, "ws1", testSprints())
 
-	result, err := Sprint(nil, "ws1", "Final")
+	result, err := Sprint(context.Background(), nil, "ws1", "Final")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -117,7 +118,7 @@ func TestSprintResolveAmbiguous(t *testing.T) {
 	}
 	setupSprintCache(t, "ws1", sprints)
 
-	_, err := Sprint(nil, "ws1", "Sprint")
+	_, err := Sprint(context.Background(), nil, "ws1", "Sprint")
 	if err == nil {
 		t.Fatal("expected ambiguous error, got nil")
 	}
@@ -149,7 +150,7 @@ func TestSprintResolveRelativeCurrent(t *testing.T) {
 	_ = cache.Set(SprintCacheKey("ws1"), sprints)
 	_ = cache.Set(SprintAccessorsCacheKey("ws1"), accessors)
 
-	result, err := Sprint(nil, "ws1", "current")
+	result, err := Sprint(context.Background(), nil, "ws1", "current")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -171,7 +172,7 @@ func TestSprintResolveRelativeNext(t *testing.T) {
 	_ = cache.Set(SprintCacheKey("ws1"), sprints)
 	_ = cache.Set(SprintAccessorsCacheKey("ws1"), accessors)
 
-	result, err := Sprint(nil, "ws1", "next")
+	result, err := Sprint(context.Background(), nil, "ws1", "next")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -193,7 +194,7 @@ func TestSprintResolveRelativePrevious(t *testing.T) {
 	_ = cache.Set(SprintCacheKey("ws1"), sprints)
 	_ = cache.Set(SprintAccessorsCacheKey("ws1"), accessors)
 
-	result, err := Sprint(nil, "ws1", "previous")
+	result, err := Sprint(context.Background(), nil, "ws1", "previous")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -213,7 +214,7 @@ func TestSprintResolveNoActiveSprint(t *testing.T) {
 	_ = cache.Set(SprintCacheKey("ws1"), sprints)
 	_ = cache.Set(SprintAccessorsCacheKey("ws1"), accessors)
 
-	_, err := Sprint(nil, "ws1", "current")
+	_, err := Sprint(context.Background(), nil, "ws1", "current")
 	if err == nil {
 		t.Fatal("expected error for no active sprint, got nil")
 	}
@@ -273,7 +274,7 @@ func TestSprintResolveNotFoundRefreshesCache(t *testing.T) {
 
 	client := api.New("test-key", api.WithEndpoint(ms.URL()))
 
-	result, err := Sprint(client, "ws1", "Sprint 2")
+	result, err := Sprint(context.Background(), client, "ws1", "Sprint 2")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -325,7 +326,7 @@ func TestSprintResolveNotFoundAfterRefresh(t *testing.T) {
 
 	client := api.New("test-key", api.WithEndpoint(ms.URL()))
 
-	_, err := Sprint(client, "ws1", "nonexistent")
+	_, err := Sprint(context.Background(), client, "ws1", "nonexistent")
 	if err == nil {
 		t.Fatal("expected not found error, got nil")
 	}
@@ -386,7 +387,7 @@ func TestFetchSprints(t *testing.T) {
 
 	client := api.New("test-key", api.WithEndpoint(ms.URL()))
 
-	sprints, err := FetchSprints(client, "ws1")
+	sprints, err := FetchSprints(context.Background(), client, "ws1")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -454,7 +455,7 @@ func TestSprintRelativeRefreshesWhenNoCacheExists(t *testing.T) {
 
 	client := api.New("test-key", api.WithEndpoint(ms.URL()))
 
-	result, err := Sprint(client, "ws1", "current")
+	result, err := Sprint(context.Background(), client, "ws1", "current")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
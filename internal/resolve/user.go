@@ -1,6 +1,7 @@
 package resolve
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -16,15 +17,17 @@ type CachedUser struct {
 	Name string `json:"name"`
 	// GithubUser holds the linked GitHub account info, if any.
 	GithubUser *struct {
+		ID    string `json:"id"`
 		Login string `json:"login"`
 	} `json:"githubUser"`
 }
 
 // UserResult is the resolved user returned to callers.
 type UserResult struct {
-	ID    string
-	Name  string
-	Login string // GitHub login, if available
+	ID       string
+	Name     string
+	Login    string // GitHub login, if available
+	GithubID string // ZenHub ID of the linked GitHub user, used for issue assignment
 }
 
 // DisplayName returns the best human-readable name for the user.
@@ -49,14 +52,14 @@ const listZenhubUsersQuery = `query ListZenhubUsers($workspaceId: ID!, $first: I
       nodes {
         id
         name
-        githubUser { login }
+        githubUser { id login }
       }
     }
   }
 }`
 
 // FetchUsers fetches all ZenHub users in a workspace and updates the cache.
-func FetchUsers(client *api.Client, workspaceID string) ([]CachedUser, error) {
+func FetchUsers(ctx context.Context, client *api.Client, workspaceID string) ([]CachedUser, error) {
 	var allUsers []CachedUser
 	var cursor *string
 	pageSize := 100
@@ -70,7 +73,7 @@ func FetchUsers(client *api.Client, workspaceID string) ([]CachedUser, error) {
 			vars["after"] = *cursor
 		}
 
-		data, err := client.Execute(listZenhubUsersQuery, vars)
+		data, err := client.Execute(ctx, listZenhubUsersQuery, vars)
 		if err != nil {
 			return nil, exitcode.General("fetching workspace users", err)
 		}
@@ -112,7 +115,7 @@ func UserCacheKey(workspaceID string) cache.Key {
 // User resolves a single user identifier to a UserResult using
 // case-insensitive match on GitHub login, name, or ZenHub ID,
 // with invalidate-on-miss caching.
-func User(client *api.Client, workspaceID string, identifier string) (*UserResult, error) {
+func User(ctx context.Context, client *api.Client, workspaceID string, identifier string) (*UserResult, error) {
 	key := UserCacheKey(workspaceID)
 
 	// Try cache first
@@ -123,7 +126,7 @@ func User(client *api.Client, workspaceID string, identifier string) (*UserResul
 	}
 
 	// Cache miss — refresh from API
-	entries, err := FetchUsers(client, workspaceID)
+	entries, err := FetchUsers(ctx, client, workspaceID)
 	if err != nil {
 		return nil, err
 	}
@@ -136,14 +139,14 @@ func User(client *api.Client, workspaceID string, identifier string) (*UserResul
 }
 
 // Users resolves multiple user identifiers, returning results and any errors.
-func Users(client *api.Client, workspaceID string, identifiers []string) ([]*UserResult, error) {
+func Users(ctx context.Context, client *api.Client, workspaceID string, identifiers []string) ([]*UserResult, error) {
 	key := UserCacheKey(workspaceID)
 
 	// Ensure cache is populated
 	entries, ok := cache.Get[[]CachedUser](key)
 	if !ok {
 		var err error
-		entries, err = FetchUsers(client, workspaceID)
+		entries, err = FetchUsers(ctx, client, workspaceID)
 		if err != nil {
 			return nil, err
 		}
@@ -162,7 +165,7 @@ func Users(client *api.Client, workspaceID string, identifiers []string) ([]*Use
 	if len(notFound) > 0 {
 		// Try refreshing cache and retry
 		var err error
-		entries, err = FetchUsers(client, workspaceID)
+		entries, err = FetchUsers(ctx, client, workspaceID)
 		if err != nil {
 			return nil, err
 		}
@@ -222,14 +225,16 @@ func matchUser(entries []CachedUser, identifier string) (*UserResult, bool) {
 }
 
 func buildUserResult(u *CachedUser) *UserResult {
-	login := ""
+	login, githubID := "", ""
 	if u.GithubUser != nil {
 		login = u.GithubUser.Login
+		githubID = u.GithubUser.ID
 	}
 
 	return &UserResult{
-		ID:    u.ID,
-		Name:  u.Name,
-		Login: login,
+		ID:       u.ID,
+		Name:     u.Name,
+		Login:    login,
+		GithubID: githubID,
 	}
 }
@@ -1,6 +1,7 @@
 package resolve
 
 import (
+	"context"
 	"testing"
 
 	"github.com/dslh/zh/internal/api"
@@ -19,11 +20,13 @@ func setupUserCache(t *testing.T, workspaceID string, users []CachedUser) {
 func testUsers() []CachedUser {
 	return []CachedUser{
 		{ID: "u1", Name: "John Doe", GithubUser: &struct {
+			ID    string `json:"id"`
 			Login string `json:"login"`
-		}{Login: "johndoe"}},
+		}{ID: "gh1", Login: "johndoe"}},
 		{ID: "u2", Name: "Jane Doe", GithubUser: &struct {
+			ID    string `json:"id"`
 			Login string `json:"login"`
-		}{Login: "janedoe"}},
+		}{ID: "gh2", Login: "janedoe"}},
 		{ID: "u3", Name: "Bob Smith"},
 	}
 }
@@ -31,13 +34,16 @@ func testUsers() []CachedUser {
 func TestUserResolveByID(t *testing.T) {
 	setupUserCache(t, "ws1", testUsers())
 
-	result, err := User(nil, "ws1", "u2")
+	result, err := User(context.Background(), nil, "ws1", "u2")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 	if result.ID != "u2" || result.Name != "Jane Doe" {
 		t.Errorf("got %+v, want ID=u2 Name=Jane Doe", result)
 	}
+	if result.GithubID != "gh2" {
+		t.Errorf("GithubID = %q, want gh2", result.GithubID)
+	}
 	if result.Login != "janedoe" {
 		t.Errorf("got Login=%s, want janedoe", result.Login)
 	}
@@ -46,7 +52,7 @@ func TestUserResolveByID(t *testing.T) {
 func TestUserResolveByGitHubLogin(t *testing.T) {
 	setupUserCache(t, "ws1", testUsers())
 
-	result, err := User(nil, "ws1", "johndoe")
+	result, err := User(context.Background(), nil, "ws1", "johndoe")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -58,7 +64,7 @@ func TestUserResolveByGitHubLogin(t *testing.T) {
 func TestUserResolveByGitHubLoginCaseInsensitive(t *testing.T) {
 	setupUserCache(t, "ws1", testUsers())
 
-	result, err := User(nil, "ws1", "JohnDoe")
+	result, err := User(context.Background(), nil, "ws1", "JohnDoe")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -70,7 +76,7 @@ func TestUserResolveByGitHubLoginCaseInsensitive(t *testing.T) {
 func TestUserResolveByName(t *testing.T) {
 	setupUserCache(t, "ws1", testUsers())
 
-	result, err := User(nil, "ws1", "Bob Smith")
+	result, err := User(context.Background(), nil, "ws1", "Bob Smith")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -85,7 +91,7 @@ func TestUserResolveByName(t *testing.T) {
 func TestUserResolveWithAtPrefix(t *testing.T) {
 	setupUserCache(t, "ws1", testUsers())
 
-	result, err := User(nil, "ws1", "@janedoe")
+	result, err := User(context.Background(), nil, "ws1", "@janedoe")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -124,7 +130,7 @@ func TestUserNotFound(t *testing.T) {
 	ms.HandleQuery("ListZenhubUsers", usersAPIResponse())
 	client := api.New("test-key", api.WithEndpoint(ms.URL()))
 
-	_, err := User(client, "ws1", "nonexistent")
+	_, err := User(context.Background(), client, "ws1", "nonexistent")
 	if err == nil {
 		t.Fatal("expected error for nonexistent user")
 	}
@@ -153,7 +159,7 @@ func TestUserDisplayName(t *testing.T) {
 func TestUsersResolveMultiple(t *testing.T) {
 	setupUserCache(t, "ws1", testUsers())
 
-	results, err := Users(nil, "ws1", []string{"johndoe", "janedoe"})
+	results, err := Users(context.Background(), nil, "ws1", []string{"johndoe", "janedoe"})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -171,7 +177,7 @@ func TestUsersResolveNotFound(t *testing.T) {
 	ms.HandleQuery("ListZenhubUsers", usersAPIResponse())
 	client := api.New("test-key", api.WithEndpoint(ms.URL()))
 
-	_, err := Users(client, "ws1", []string{"johndoe", "nonexistent"})
+	_, err := Users(context.Background(), client, "ws1", []string{"johndoe", "nonexistent"})
 	if err == nil {
 		t.Fatal("expected error for nonexistent user")
 	}
@@ -213,7 +219,7 @@ func TestUserResolveWithAPIRefresh(t *testing.T) {
 
 	client := api.New("test-key", api.WithEndpoint(ms.URL()))
 
-	result, err := User(client, "ws1", "newuser")
+	result, err := User(context.Background(), client, "ws1", "newuser")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
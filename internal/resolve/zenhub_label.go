@@ -1,6 +1,7 @@
 package resolve
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -44,7 +45,7 @@ const listZenhubLabelsQuery = `query ListZenhubLabels($workspaceId: ID!, $first:
 }`
 
 // FetchZenhubLabels fetches all ZenHub labels in a workspace and updates the cache.
-func FetchZenhubLabels(client *api.Client, workspaceID string) ([]CachedZenhubLabel, error) {
+func FetchZenhubLabels(ctx context.Context, client *api.Client, workspaceID string) ([]CachedZenhubLabel, error) {
 	var allLabels []CachedZenhubLabel
 	var cursor *string
 	pageSize := 100
@@ -58,7 +59,7 @@ func FetchZenhubLabels(client *api.Client, workspaceID string) ([]CachedZenhubLa
 			vars["after"] = *cursor
 		}
 
-		data, err := client.Execute(listZenhubLabelsQuery, vars)
+		data, err := client.Execute(ctx, listZenhubLabelsQuery, vars)
 		if err != nil {
 			return nil, exitcode.General("fetching ZenHub labels", err)
 		}
@@ -99,7 +100,7 @@ func ZenhubLabelCacheKey(workspaceID string) cache.Key {
 
 // ZenhubLabel resolves a label name to a ZenhubLabelResult using case-insensitive
 // exact match, with invalidate-on-miss caching.
-func ZenhubLabel(client *api.Client, workspaceID string, name string) (*ZenhubLabelResult, error) {
+func ZenhubLabel(ctx context.Context, client *api.Client, workspaceID string, name string) (*ZenhubLabelResult, error) {
 	key := ZenhubLabelCacheKey(workspaceID)
 
 	// Try cache first
@@ -110,7 +111,7 @@ func ZenhubLabel(client *api.Client, workspaceID string, name string) (*ZenhubLa
 	}
 
 	// Cache miss — refresh from API
-	entries, err := FetchZenhubLabels(client, workspaceID)
+	entries, err := FetchZenhubLabels(ctx, client, workspaceID)
 	if err != nil {
 		return nil, err
 	}
@@ -123,14 +124,14 @@ func ZenhubLabel(client *api.Client, workspaceID string, name string) (*ZenhubLa
 }
 
 // ZenhubLabels resolves multiple label names, returning results and any errors.
-func ZenhubLabels(client *api.Client, workspaceID string, names []string) ([]*ZenhubLabelResult, error) {
+func ZenhubLabels(ctx context.Context, client *api.Client, workspaceID string, names []string) ([]*ZenhubLabelResult, error) {
 	key := ZenhubLabelCacheKey(workspaceID)
 
 	// Ensure cache is populated
 	entries, ok := cache.Get[[]CachedZenhubLabel](key)
 	if !ok {
 		var err error
-		entries, err = FetchZenhubLabels(client, workspaceID)
+		entries, err = FetchZenhubLabels(ctx, client, workspaceID)
 		if err != nil {
 			return nil, err
 		}
@@ -149,7 +150,7 @@ func ZenhubLabels(client *api.Client, workspaceID string, names []string) ([]*Ze
 	if len(notFound) > 0 {
 		// Try refreshing cache and retry
 		var err error
-		entries, err = FetchZenhubLabels(client, workspaceID)
+		entries, err = FetchZenhubLabels(ctx, client, workspaceID)
 		if err != nil {
 			return nil, err
 		}
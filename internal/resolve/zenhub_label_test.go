@@ -1,6 +1,7 @@
 package resolve
 
 import (
+	"context"
 	"testing"
 
 	"github.com/dslh/zh/internal/api"
@@ -27,7 +28,7 @@ func testZenhubLabels() []CachedZenhubLabel {
 func TestZenhubLabelResolveByID(t *testing.T) {
 	setupZenhubLabelCache(t, "ws1", testZenhubLabels())
 
-	result, err := ZenhubLabel(nil, "ws1", "zl2")
+	result, err := ZenhubLabel(context.Background(), nil, "ws1", "zl2")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -39,7 +40,7 @@ func TestZenhubLabelResolveByID(t *testing.T) {
 func TestZenhubLabelResolveByName(t *testing.T) {
 	setupZenhubLabelCache(t, "ws1", testZenhubLabels())
 
-	result, err := ZenhubLabel(nil, "ws1", "platform")
+	result, err := ZenhubLabel(context.Background(), nil, "ws1", "platform")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -51,7 +52,7 @@ func TestZenhubLabelResolveByName(t *testing.T) {
 func TestZenhubLabelResolveByNameCaseInsensitive(t *testing.T) {
 	setupZenhubLabelCache(t, "ws1", testZenhubLabels())
 
-	result, err := ZenhubLabel(nil, "ws1", "BACKEND")
+	result, err := ZenhubLabel(context.Background(), nil, "ws1", "BACKEND")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -90,7 +91,7 @@ func TestZenhubLabelNotFound(t *testing.T) {
 	ms.HandleQuery("ListZenhubLabels", zenhubLabelsAPIResponse())
 	client := api.New("test-key", api.WithEndpoint(ms.URL()))
 
-	_, err := ZenhubLabel(client, "ws1", "nonexistent")
+	_, err := ZenhubLabel(context.Background(), client, "ws1", "nonexistent")
 	if err == nil {
 		t.Fatal("expected error for nonexistent label")
 	}
@@ -102,7 +103,7 @@ func TestZenhubLabelNotFound(t *testing.T) {
 func TestZenhubLabelsResolveMultiple(t *testing.T) {
 	setupZenhubLabelCache(t, "ws1", testZenhubLabels())
 
-	results, err := ZenhubLabels(nil, "ws1", []string{"platform", "priority:high"})
+	results, err := ZenhubLabels(context.Background(), nil, "ws1", []string{"platform", "priority:high"})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -120,7 +121,7 @@ func TestZenhubLabelsResolveNotFound(t *testing.T) {
 	ms.HandleQuery("ListZenhubLabels", zenhubLabelsAPIResponse())
 	client := api.New("test-key", api.WithEndpoint(ms.URL()))
 
-	_, err := ZenhubLabels(client, "ws1", []string{"platform", "nonexistent"})
+	_, err := ZenhubLabels(context.Background(), client, "ws1", []string{"platform", "nonexistent"})
 	if err == nil {
 		t.Fatal("expected error for nonexistent label")
 	}
@@ -160,7 +161,7 @@ func TestZenhubLabelResolveWithAPIRefresh(t *testing.T) {
 
 	client := api.New("test-key", api.WithEndpoint(ms.URL()))
 
-	result, err := ZenhubLabel(client, "ws1", "new-label")
+	result, err := ZenhubLabel(context.Background(), client, "ws1", "new-label")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
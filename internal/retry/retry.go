@@ -0,0 +1,159 @@
+// Package retry implements the retry policy shared by the ZenHub GraphQL,
+// ZenHub REST and GitHub clients.
+//
+// Rate-limited requests (HTTP 429) are always retried, honouring the
+// Retry-After header, since the server rejected them without doing any work.
+// A Retry-After longer than the policy's MaxDelay is not waited out.
+// Server errors (5xx) and dropped connections are retried with exponential
+// backoff and jitter, but only for idempotent requests unless the policy
+// opts in to retrying mutations.
+package retry
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// Policy controls how failed requests are retried.
+type Policy struct {
+	MaxAttempts int           // total attempts including the first; <= 1 disables retries
+	BaseDelay   time.Duration // backoff before the first retry
+	MaxDelay    time.Duration // cap on the backoff delay and on Retry-After waits
+	Mutations   bool          // also retry mutations after server or connection errors
+}
+
+// Backoff returns the delay before the retry following the given failed
+// attempt (1-based). The delay doubles with each attempt, is capped at
+// MaxDelay, and is jittered down by up to half to spread out retries from
+// concurrent requests.
+func (p Policy) Backoff(attempt int) time.Duration {
+	d := p.BaseDelay
+	for i := 1; i < attempt && (p.MaxDelay <= 0 || d < p.MaxDelay); i++ {
+		d *= 2
+	}
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	if d <= 0 {
+		return 0
+	}
+	half := d / 2
+	return half + rand.N(half+1)
+}
+
+// Error marks an attempt's failure as retryable. Do unwraps it and returns
+// Err if no attempts remain.
+type Error struct {
+	Err    error
+	Reason string        // short description for verbose logging, e.g. "HTTP 503"
+	After  time.Duration // server-requested delay (Retry-After); 0 means use backoff
+}
+
+func (e *Error) Error() string { return e.Err.Error() }
+func (e *Error) Unwrap() error { return e.Err }
+
+// Do calls fn until it succeeds, returns an error that isn't a *Error, or the
+// policy's attempts are exhausted. Waits between attempts respect ctx. If the
+// server asks for a longer wait than MaxDelay, Do gives up rather than retry
+// early and be rejected again. If logf is non-nil, each retry is logged
+// through it.
+func Do(ctx context.Context, p Policy, logf func(format string, args ...any), fn func() error) error {
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		var rerr *Error
+		if err == nil || !errors.As(err, &rerr) {
+			return err
+		}
+		if attempt >= p.MaxAttempts || ctx.Err() != nil {
+			return rerr.Err
+		}
+
+		delay := rerr.After
+		if p.MaxDelay > 0 && delay > p.MaxDelay {
+			if logf != nil {
+				logf("↻ %s — server asked to wait %s, more than the %s limit; not retrying\n",
+					rerr.Reason, delay.Round(time.Millisecond), p.MaxDelay)
+			}
+			return rerr.Err
+		}
+		if delay <= 0 {
+			delay = p.Backoff(attempt)
+		}
+		if logf != nil {
+			logf("↻ %s — retrying in %s (attempt %d of %d)\n",
+				rerr.Reason, delay.Round(time.Millisecond), attempt+1, p.MaxAttempts)
+		}
+
+		t := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return rerr.Err
+		case <-t.C:
+		}
+	}
+}
+
+// RetryableStatus reports whether an HTTP status code indicates a transient
+// server-side failure.
+func RetryableStatus(code int) bool {
+	switch code {
+	case http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// RetryAfter parses a Retry-After header given either as a number of seconds
+// or as an HTTP date.
+func RetryAfter(header string, now time.Time) (time.Duration, bool) {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(header); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(header); err == nil {
+		if d := t.Sub(now); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// TransientError reports whether a transport error is likely to succeed on
+// retry: connection resets, refused connections, unexpected EOFs and network
+// timeouts. Context cancellation is never transient.
+func TransientError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, io.EOF) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// IsMutation reports whether a GraphQL document is a mutation.
+func IsMutation(query string) bool {
+	return strings.HasPrefix(strings.TrimSpace(query), "mutation")
+}
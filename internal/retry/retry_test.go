@@ -0,0 +1,170 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestBackoffGrowsAndIsCapped(t *testing.T) {
+	p := Policy{MaxAttempts: 10, BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+
+	for attempt, want := range map[int]time.Duration{
+		1: 100 * time.Millisecond,
+		2: 200 * time.Millisecond,
+		3: 400 * time.Millisecond,
+		5: time.Second,
+		9: time.Second,
+	} {
+		for range 20 {
+			got := p.Backoff(attempt)
+			if got < want/2 || got > want {
+				t.Fatalf("Backoff(%d) = %s, want within [%s, %s]", attempt, got, want/2, want)
+			}
+		}
+	}
+}
+
+func TestDoRetriesUntilSuccess(t *testing.T) {
+	p := Policy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}
+
+	var logged []string
+	calls := 0
+	err := Do(context.Background(), p, func(format string, args ...any) {
+		logged = append(logged, fmt.Sprintf(format, args...))
+	}, func() error {
+		calls++
+		if calls < 3 {
+			return &Error{Err: errors.New("boom"), Reason: "HTTP 503"}
+		}
+		return nil
+	})
+
+	if err != nil {
+		t.Fatalf("Do() error: %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("calls = %d, want 3", calls)
+	}
+	if len(logged) != 2 {
+		t.Errorf("logged %d retries, want 2: %v", len(logged), logged)
+	}
+}
+
+func TestDoReturnsUnwrappedErrorWhenExhausted(t *testing.T) {
+	p := Policy{MaxAttempts: 2, BaseDelay: time.Millisecond}
+	inner := errors.New("still failing")
+
+	calls := 0
+	err := Do(context.Background(), p, nil, func() error {
+		calls++
+		return &Error{Err: inner, Reason: "HTTP 502"}
+	})
+
+	if err != inner {
+		t.Errorf("err = %v, want the inner error", err)
+	}
+	if calls != 2 {
+		t.Errorf("calls = %d, want 2", calls)
+	}
+}
+
+func TestDoStopsOnNonRetryableError(t *testing.T) {
+	calls := 0
+	err := Do(context.Background(), Policy{MaxAttempts: 3}, nil, func() error {
+		calls++
+		return errors.New("not retryable")
+	})
+	if err == nil || calls != 1 {
+		t.Errorf("calls = %d, err = %v; want a single failed call", calls, err)
+	}
+}
+
+func TestDoRespectsContextDuringWait(t *testing.T) {
+	p := Policy{MaxAttempts: 3}
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+
+	start := time.Now()
+	err := Do(ctx, p, nil, func() error {
+		return &Error{Err: errors.New("rate limited"), After: time.Minute}
+	})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if time.Since(start) > 5*time.Second {
+		t.Error("Do should stop waiting when the context is cancelled")
+	}
+}
+
+func TestDoGivesUpWhenRetryAfterExceedsMaxDelay(t *testing.T) {
+	p := Policy{MaxAttempts: 3, MaxDelay: time.Second}
+	calls := 0
+	var logged string
+	logf := func(format string, args ...any) { logged += fmt.Sprintf(format, args...) }
+
+	start := time.Now()
+	err := Do(context.Background(), p, logf, func() error {
+		calls++
+		return &Error{Err: errors.New("rate limited"), Reason: "HTTP 429", After: time.Hour}
+	})
+	if err == nil || err.Error() != "rate limited" || calls != 1 {
+		t.Errorf("calls = %d, err = %v; want a single unwrapped failure", calls, err)
+	}
+	if time.Since(start) > 5*time.Second {
+		t.Error("Do should not wait out a Retry-After longer than MaxDelay")
+	}
+	if !strings.Contains(logged, "not retrying") {
+		t.Errorf("the skipped retry should be logged, got %q", logged)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2026, 1, 20, 10, 0, 0, 0, time.UTC)
+
+	if d, ok := RetryAfter("7", now); !ok || d != 7*time.Second {
+		t.Errorf("RetryAfter(7) = %s, %v", d, ok)
+	}
+	if d, ok := RetryAfter(now.Add(90*time.Second).Format(http.TimeFormat), now); !ok || d != 90*time.Second {
+		t.Errorf("RetryAfter(date) = %s, %v", d, ok)
+	}
+	if _, ok := RetryAfter("", now); ok {
+		t.Error("RetryAfter(\"\") should not be ok")
+	}
+	if _, ok := RetryAfter("soon", now); ok {
+		t.Error("RetryAfter(soon) should not be ok")
+	}
+}
+
+func TestTransientError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{fmt.Errorf("read: %w", syscall.ECONNRESET), true},
+		{io.ErrUnexpectedEOF, true},
+		{context.Canceled, false},
+		{context.DeadlineExceeded, false},
+		{errors.New("bad request"), false},
+	}
+	for _, tt := range tests {
+		if got := TransientError(tt.err); got != tt.want {
+			t.Errorf("TransientError(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestIsMutation(t *testing.T) {
+	if !IsMutation("\n  mutation MoveIssue($input: MoveIssueInput!) { x }") {
+		t.Error("expected mutation")
+	}
+	if IsMutation("query GetIssue { x }") || IsMutation("{ viewer { id } }") {
+		t.Error("expected query")
+	}
+}